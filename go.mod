@@ -8,7 +8,11 @@ require (
 	github.com/Azure/azure-storage-blob-go v0.6.0
 	github.com/Shopify/sarama v1.22.0
 	github.com/aws/aws-sdk-go v1.19.11
-	github.com/chrislusf/raft v0.0.0-20190225081310-10d6e2182d92
+	github.com/coreos/bbolt v1.3.2
+	github.com/coreos/etcd v3.3.18+incompatible
+	github.com/coreos/go-semver v0.3.0 // indirect
+	github.com/coreos/go-systemd v0.0.0-20190321100706-95778dfbb74e // indirect
+	github.com/coreos/pkg v0.0.0-20180928190104-399ea9e2e55f // indirect
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/disintegration/imaging v1.6.0
 	github.com/dustin/go-humanize v1.0.0
@@ -16,29 +20,56 @@ require (
 	github.com/go-redis/redis v6.15.2+incompatible
 	github.com/go-sql-driver/mysql v1.4.1
 	github.com/gocql/gocql v0.0.0-20190402132108-0e1d5de854df
+	github.com/gogo/protobuf v1.2.1 // indirect
 	github.com/golang/protobuf v1.3.1
 	github.com/google/btree v1.0.0
+	github.com/google/uuid v1.1.1 // indirect
 	github.com/gorilla/mux v1.7.1
+	github.com/gorilla/websocket v1.4.0 // indirect
+	github.com/grpc-ecosystem/go-grpc-middleware v1.0.0 // indirect
+	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway v1.8.5 // indirect
+	github.com/hashicorp/go-hclog v0.9.1
+	github.com/hashicorp/raft v1.3.11
+	github.com/hashicorp/raft-boltdb v0.0.0-20210422161416-485fa74b0b01
+	github.com/jonboulle/clockwork v0.1.0 // indirect
+	github.com/json-iterator/go v1.1.6 // indirect
 	github.com/karlseguin/ccache v2.0.2+incompatible
 	github.com/karlseguin/expect v1.0.1 // indirect
+	github.com/klauspost/cpuid v1.2.0 // indirect
 	github.com/klauspost/crc32 v1.2.0
+	github.com/klauspost/reedsolomon v1.9.1
 	github.com/kr/pty v1.1.3 // indirect
 	github.com/kurin/blazer v0.5.3
 	github.com/lib/pq v1.1.0
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.1 // indirect
 	github.com/peterh/liner v1.1.0
 	github.com/prometheus/client_golang v0.9.3-0.20190127221311-3c4408c8b829
 	github.com/rakyll/statik v0.1.6
 	github.com/rwcarlsen/goexif v0.0.0-20190401172101-9e8deecbddbd
 	github.com/satori/uuid v1.2.0
 	github.com/seaweedfs/fuse v0.0.0-20190222170103-1aae43e32cad
+	github.com/sirupsen/logrus v1.4.1 // indirect
+	github.com/soheilhy/cmux v0.1.4 // indirect
 	github.com/spf13/viper v1.3.2
 	github.com/syndtr/goleveldb v1.0.0
+	github.com/tmc/grpc-websocket-proxy v0.0.0-20190109142713-0ad062ec5ee5 // indirect
 	github.com/willf/bloom v2.0.3+incompatible
 	github.com/wsxiaoys/terminal v0.0.0-20160513160801-0940f3fc43a0 // indirect
+	github.com/xiang90/probing v0.0.0-20190116061207-43a291ad63a2 // indirect
+	go.uber.org/atomic v1.4.0 // indirect
+	go.uber.org/multierr v1.1.0 // indirect
+	go.uber.org/zap v1.10.0 // indirect
 	gocloud.dev v0.12.0
 	golang.org/x/net v0.0.0-20190415214537-1da14a5a36f2
+	golang.org/x/time v0.0.0-20190308202827-9d24e82272b4 // indirect
 	golang.org/x/tools v0.0.0-20190415205951-2e9de471ebd3
 	google.golang.org/api v0.3.2
 	google.golang.org/grpc v1.20.0
 	gopkg.in/karlseguin/expect.v1 v1.0.1 // indirect
+	sigs.k8s.io/yaml v1.1.0 // indirect
 )
+
+// the fork of github.com/seaweedfs/fuse with the file lock requests, used by the weed mount locks
+replace github.com/seaweedfs/fuse => ./third_party/fuse
@@ -0,0 +1,3 @@
+module github.com/seaweedfs/fuse
+
+go 1.12
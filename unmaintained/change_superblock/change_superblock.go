@@ -9,6 +9,7 @@ import (
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 )
 
 var (
@@ -48,7 +49,7 @@ func main() {
 	}
 	defer datFile.Close()
 
-	superBlock, err := storage.ReadSuperBlock(datFile)
+	superBlock, err := storage.ReadSuperBlock(backend.NewDiskFile(datFile))
 
 	if err != nil {
 		glog.Fatalf("cannot parse existing super block: %v", err)
@@ -15,6 +15,7 @@ var Commands = []*Command{
 	cmdFix,
 	cmdFilerExport,
 	cmdFilerReplicate,
+	cmdFilerSync,
 	cmdServer,
 	cmdMaster,
 	cmdFiler,
@@ -25,6 +26,7 @@ var Commands = []*Command{
 	cmdShell,
 	cmdVersion,
 	cmdVolume,
+	cmdWebDav,
 	cmdExport,
 	cmdMount,
 }
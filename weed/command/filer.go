@@ -2,6 +2,7 @@ package command
 
 import (
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -33,8 +34,11 @@ type FilerOptions struct {
 	maxMB                   *int
 	dirListingLimit         *int
 	dataCenter              *string
+	diskType                *string
 	enableNotification      *bool
 	disableHttp             *bool
+	metaLogDir              *string
+	metaLogRetentionDays    *int
 
 	// default leveldb directory, used in "weed server" mode
 	defaultLevelDbDirectory *string
@@ -54,7 +58,10 @@ func init() {
 	f.maxMB = cmdFiler.Flag.Int("maxMB", 32, "split files larger than the limit")
 	f.dirListingLimit = cmdFiler.Flag.Int("dirListLimit", 100000, "limit sub dir listing size")
 	f.dataCenter = cmdFiler.Flag.String("dataCenter", "", "prefer to write to volumes in this data center")
+	f.diskType = cmdFiler.Flag.String("disk", "", "[hdd|ssd] default disk type to write to if not specified")
 	f.disableHttp = cmdFiler.Flag.Bool("disableHttp", false, "disable http request, only gRpc operations are allowed")
+	f.metaLogDir = cmdFiler.Flag.String("metaLogDir", "", "directory to keep the metadata change log, default to ./filermeta")
+	f.metaLogRetentionDays = cmdFiler.Flag.Int("metaLogRetentionDays", 7, "days to keep the metadata change log")
 }
 
 var cmdFiler = &Command{
@@ -101,6 +108,18 @@ func (fo *FilerOptions) startFiler() {
 		defaultLevelDbDirectory = *fo.defaultLevelDbDirectory + "/filerdb"
 	}
 
+	metaLogDir := "./filermeta"
+	if fo.defaultLevelDbDirectory != nil {
+		metaLogDir = *fo.defaultLevelDbDirectory + "/filermeta"
+	}
+	if fo.metaLogDir != nil && *fo.metaLogDir != "" {
+		metaLogDir = *fo.metaLogDir
+	}
+	metaLogRetentionDays := 7
+	if fo.metaLogRetentionDays != nil {
+		metaLogRetentionDays = *fo.metaLogRetentionDays
+	}
+
 	fs, nfs_err := weed_server.NewFilerServer(defaultMux, publicVolumeMux, &weed_server.FilerOption{
 		Masters:            strings.Split(*fo.masters, ","),
 		Collection:         *fo.collection,
@@ -110,8 +129,12 @@ func (fo *FilerOptions) startFiler() {
 		MaxMB:              *fo.maxMB,
 		DirListingLimit:    *fo.dirListingLimit,
 		DataCenter:         *fo.dataCenter,
+		DiskType:           *fo.diskType,
 		DefaultLevelDbDir:  defaultLevelDbDirectory,
 		DisableHttp:        *fo.disableHttp,
+		MetaLogDir:         metaLogDir,
+		MetaLogRetention:   time.Duration(metaLogRetentionDays) * 24 * time.Hour,
+		Address:            filerAddress(*fo.ip, *fo.port),
 	})
 	if nfs_err != nil {
 		glog.Fatalf("Filer startup error: %v", nfs_err)
@@ -149,7 +172,7 @@ func (fo *FilerOptions) startFiler() {
 	if err != nil {
 		glog.Fatalf("failed to listen on grpc port %d: %v", grpcPort, err)
 	}
-	grpcS := util.NewGrpcServer(security.LoadServerTLS(viper.Sub("grpc"), "filer"))
+	grpcS := util.NewGrpcServer(append(fs.GrpcIdentityOptions(), security.LoadServerTLS(viper.Sub("grpc"), "filer"))...)
 	filer_pb.RegisterSeaweedFilerServer(grpcS, fs)
 	reflection.Register(grpcS)
 	go grpcS.Serve(grpcL)
@@ -160,3 +183,11 @@ func (fo *FilerOptions) startFiler() {
 	}
 
 }
+
+// filerAddress is the address of the filer, with the host name if the ip is not set
+func filerAddress(ip string, port int) string {
+	if ip == "" {
+		ip, _ = os.Hostname()
+	}
+	return ip + ":" + strconv.Itoa(port)
+}
@@ -0,0 +1,269 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/replication"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/replication/sink/filersink"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/replication/source"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+	"google.golang.org/grpc"
+)
+
+type SyncOptions struct {
+	filerA             *string
+	filerB             *string
+	aPath              *string
+	bPath              *string
+	aReplication       *string
+	bReplication       *string
+	aCollection        *string
+	bCollection        *string
+	checkpointDir      *string
+	checkpointInterval *time.Duration
+}
+
+var (
+	syncOptions SyncOptions
+)
+
+const (
+	syncEventRetries    = 3
+	syncEventRetryDelay = time.Second
+)
+
+func init() {
+	cmdFilerSync.Run = runFilerSync // break init cycle
+	syncOptions.filerA = cmdFilerSync.Flag.String("a", "", "filer A in one SeaweedFS cluster, e.g., localhost:8888")
+	syncOptions.filerB = cmdFilerSync.Flag.String("b", "", "filer B in the other SeaweedFS cluster, e.g., localhost:8888")
+	syncOptions.aPath = cmdFilerSync.Flag.String("a.path", "/", "directory to sync on filer A")
+	syncOptions.bPath = cmdFilerSync.Flag.String("b.path", "/", "directory to sync on filer B")
+	syncOptions.aReplication = cmdFilerSync.Flag.String("a.replication", "", "replication on filer A, default to the filer setting")
+	syncOptions.bReplication = cmdFilerSync.Flag.String("b.replication", "", "replication on filer B, default to the filer setting")
+	syncOptions.aCollection = cmdFilerSync.Flag.String("a.collection", "", "collection on filer A")
+	syncOptions.bCollection = cmdFilerSync.Flag.String("b.collection", "", "collection on filer B")
+	syncOptions.checkpointDir = cmdFilerSync.Flag.String("checkpointDir", ".", "directory to store the sync progress of each direction")
+	syncOptions.checkpointInterval = cmdFilerSync.Flag.Duration("checkpointInterval", 3*time.Second, "how often to store the sync progress")
+}
+
+var cmdFilerSync = &Command{
+	UsageLine: "filer.sync -a=<oneFilerHost>:<oneFilerPort> -b=<otherFilerHost>:<otherFilerPort>",
+	Short:     "continuously synchronize between two active-active or active-passive SeaweedFS clusters",
+	Long: `continuously synchronize file changes between two active-active or active-passive filers
+
+	filer.sync tails the metadata changes of each filer, and replays the changes to the other filer.
+	Both filers need to keep the metadata log, i.e., "weed filer -metaLogDir".
+
+	The replicated entries and deletions are tagged with the filer where the change was made,
+	so the replicated changes are not sent back to where they came from.
+
+	The sync progress of each direction is stored in the -checkpointDir directory.
+	After restarting, the sync resumes from the stored progress.
+	A change failing to replay is retried, and the sync does not move past it.
+	Without the stored progress, the sync starts from the oldest metadata log kept by the filer.
+
+  `,
+}
+
+func runFilerSync(cmd *Command, args []string) bool {
+
+	weed_server.LoadConfiguration("security", false)
+	grpcDialOption := security.LoadClientTLS(viper.Sub("grpc"), "client")
+
+	if *syncOptions.filerA == "" || *syncOptions.filerB == "" {
+		return false
+	}
+
+	// avoid recursive replication
+	if *syncOptions.filerA == *syncOptions.filerB {
+		if strings.HasPrefix(*syncOptions.aPath, *syncOptions.bPath) || strings.HasPrefix(*syncOptions.bPath, *syncOptions.aPath) {
+			glog.Fatalf("recursive sync! directory %s and %s overlap on the same filer %s", *syncOptions.aPath, *syncOptions.bPath, *syncOptions.filerA)
+		}
+	}
+
+	if err := os.MkdirAll(*syncOptions.checkpointDir, 0755); err != nil {
+		glog.Fatalf("create checkpoint dir %s: %v", *syncOptions.checkpointDir, err)
+	}
+
+	go func() {
+		for {
+			err := doSubscribeFilerMetaChanges(grpcDialOption, *syncOptions.filerA, *syncOptions.aPath,
+				*syncOptions.filerB, *syncOptions.bPath, *syncOptions.bReplication, *syncOptions.bCollection)
+			if err != nil {
+				glog.Errorf("sync from %s to %s: %v", *syncOptions.filerA, *syncOptions.filerB, err)
+				time.Sleep(1747 * time.Millisecond)
+			}
+		}
+	}()
+
+	for {
+		err := doSubscribeFilerMetaChanges(grpcDialOption, *syncOptions.filerB, *syncOptions.bPath,
+			*syncOptions.filerA, *syncOptions.aPath, *syncOptions.aReplication, *syncOptions.aCollection)
+		if err != nil {
+			glog.Errorf("sync from %s to %s: %v", *syncOptions.filerB, *syncOptions.filerA, err)
+			time.Sleep(2147 * time.Millisecond)
+		}
+	}
+
+}
+
+// doSubscribeFilerMetaChanges replays the metadata changes of the source filer to the target filer
+func doSubscribeFilerMetaChanges(grpcDialOption grpc.DialOption, sourceFiler, sourcePath, targetFiler, targetPath, replicationStr, collection string) error {
+
+	sourceGrpcAddress, err := util.ParseServerToGrpcAddress(sourceFiler)
+	if err != nil {
+		return fmt.Errorf("source filer %s: %v", sourceFiler, err)
+	}
+	targetGrpcAddress, err := util.ParseServerToGrpcAddress(targetFiler)
+	if err != nil {
+		return fmt.Errorf("target filer %s: %v", targetFiler, err)
+	}
+
+	filerSource := &source.FilerSource{}
+	filerSource.DoInitialize(sourceGrpcAddress, sourcePath, grpcDialOption)
+	filerSink := &filersink.FilerSink{}
+	filerSink.DoInitialize(targetGrpcAddress, targetPath, replicationStr, collection, 0, grpcDialOption)
+	filerSink.SetOrigin(sourceFiler)
+	replicator := replication.NewFilerSourceReplicator(filerSource, filerSink)
+
+	checkpointFile := filepath.Join(*syncOptions.checkpointDir, syncCheckpointFileName(sourceFiler, sourcePath, targetFiler, targetPath))
+	sinceNs, err := readSyncCheckpoint(checkpointFile)
+	if err != nil {
+		return err
+	}
+	glog.V(0).Infof("start sync from %s%s to %s%s since %v", sourceFiler, sourcePath, targetFiler, targetPath, time.Unix(0, sinceNs))
+
+	ctx := context.Background()
+
+	processEventFn := func(resp *filer_pb.SubscribeMetadataResponse) error {
+		message := resp.EventNotification
+
+		// skip the changes replicated from the target filer
+		if message.NewEntry != nil && replication.IsFromOrigin(message.NewEntry, targetFiler) {
+			glog.V(4).Infof("skip %s replicated from %s", message.NewEntry.Name, targetFiler)
+			return nil
+		}
+		if message.NewEntry == nil && message.Origin == targetFiler {
+			glog.V(4).Infof("skip deleting %s replicated from %s", message.OldEntry.GetName(), targetFiler)
+			return nil
+		}
+
+		var key string
+		if message.OldEntry != nil {
+			key = message.OldEntry.Name
+		}
+		if message.NewEntry != nil {
+			key = message.NewEntry.Name
+			message.NewEntry = replication.MarkOrigin(message.NewEntry, sourceFiler)
+		}
+
+		return replicator.Replicate(ctx, key, message)
+	}
+
+	lastCheckpointTime := time.Now()
+	lastTsNs := sinceNs
+
+	return util.WithCachedGrpcClient(ctx, func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+
+		stream, err := client.SubscribeMetadata(ctx, &filer_pb.SubscribeMetadataRequest{
+			ClientName: "filer.sync " + targetFiler,
+			PathPrefix: sourcePath,
+			SinceNs:    sinceNs,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe metadata: %v", err)
+		}
+
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr == io.EOF {
+				break
+			}
+			if recvErr != nil {
+				return recvErr
+			}
+
+			if err := processSyncEvent(processEventFn, resp); err != nil {
+				// stop before the failed event, to resubscribe from the last replicated event
+				if checkpointErr := writeSyncCheckpoint(checkpointFile, lastTsNs); checkpointErr != nil {
+					glog.Errorf("sync from %s to %s: %v", sourceFiler, targetFiler, checkpointErr)
+				}
+				return fmt.Errorf("sync %v: %v", resp.EventNotification, err)
+			}
+			lastTsNs = resp.TsNs
+
+			if time.Since(lastCheckpointTime) > *syncOptions.checkpointInterval {
+				if err := writeSyncCheckpoint(checkpointFile, lastTsNs); err != nil {
+					return err
+				}
+				lastCheckpointTime = time.Now()
+			}
+		}
+
+		return writeSyncCheckpoint(checkpointFile, lastTsNs)
+
+	}, sourceGrpcAddress, grpcDialOption)
+
+}
+
+// processSyncEvent retries the failed event a few times, before giving up the subscription
+func processSyncEvent(processEventFn func(resp *filer_pb.SubscribeMetadataResponse) error, resp *filer_pb.SubscribeMetadataResponse) (err error) {
+	delay := syncEventRetryDelay
+	for i := 1; ; i++ {
+		if err = processEventFn(resp); err == nil || i >= syncEventRetries {
+			return err
+		}
+		glog.V(0).Infof("sync %v: %v, retry in %v", resp.EventNotification, err, delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+var syncCheckpointNameReplacer = regexp.MustCompile(`[^a-zA-Z0-9.\-]+`)
+
+func syncCheckpointFileName(sourceFiler, sourcePath, targetFiler, targetPath string) string {
+	return fmt.Sprintf("filer.sync.%s_to_%s.offset",
+		syncCheckpointNameReplacer.ReplaceAllString(sourceFiler+sourcePath, "_"),
+		syncCheckpointNameReplacer.ReplaceAllString(targetFiler+targetPath, "_"))
+}
+
+func readSyncCheckpoint(checkpointFile string) (sinceNs int64, err error) {
+	data, err := ioutil.ReadFile(checkpointFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("read checkpoint %s: %v", checkpointFile, err)
+	}
+	sinceNs, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse checkpoint %s: %v", checkpointFile, err)
+	}
+	return sinceNs, nil
+}
+
+func writeSyncCheckpoint(checkpointFile string, tsNs int64) error {
+	tmpFile := checkpointFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, []byte(strconv.FormatInt(tsNs, 10)), 0644); err != nil {
+		return fmt.Errorf("write checkpoint %s: %v", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, checkpointFile); err != nil {
+		return fmt.Errorf("save checkpoint %s: %v", checkpointFile, err)
+	}
+	return nil
+}
@@ -1,20 +1,22 @@
 package command
 
 import (
+	"hash/fnv"
 	"net/http"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server/metrics"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 
-	"github.com/chrislusf/raft/protobuf"
 	"github.com/gorilla/mux"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc/reflection"
@@ -33,6 +35,15 @@ var cmdMaster = &Command{
 
 	The example security.toml configuration file can be generated by "weed scaffold -config=security"
 
+	The masters replicate their state with raft, listening on the http port + 20000, so the http port is at most 45535.
+	The raft connections use the TLS config of the master grpc in "security.toml", if set.
+	The -peers only bootstrap a new cluster. Once the raft state is kept in -mdir,
+	masters are added or removed with the "cluster.raft.add" and "cluster.raft.remove" shell commands.
+	A new master joining an existing cluster is started with -raftJoin, and then added by "cluster.raft.add".
+
+	The leader master copies the under-replicated volumes to more volume servers, if the lost replicas
+	are not back in -replication.repairGracePeriod. The repairs are listed at "/vol/replication/status".
+
   `,
 }
 
@@ -41,7 +52,8 @@ var (
 	masterIp                = cmdMaster.Flag.String("ip", "localhost", "master <ip>|<server> address")
 	masterBindIp            = cmdMaster.Flag.String("ip.bind", "0.0.0.0", "ip address to bind to")
 	metaFolder              = cmdMaster.Flag.String("mdir", os.TempDir(), "data directory to store meta data")
-	masterPeers             = cmdMaster.Flag.String("peers", "", "all master nodes in comma separated ip:port list, example: 127.0.0.1:9093,127.0.0.1:9094")
+	masterPeers             = cmdMaster.Flag.String("peers", "", "all master nodes in comma separated ip:port list to bootstrap a new cluster, example: 127.0.0.1:9093,127.0.0.1:9094")
+	masterRaftJoin          = cmdMaster.Flag.Bool("raftJoin", false, "start without bootstrapping a new cluster, waiting to be added to an existing cluster by cluster.raft.add")
 	volumeSizeLimitMB       = cmdMaster.Flag.Uint("volumeSizeLimitMB", 30*1000, "Master stops directing writes to oversized volumes.")
 	volumePreallocate       = cmdMaster.Flag.Bool("volumePreallocate", false, "Preallocate disk space for volumes.")
 	mpulse                  = cmdMaster.Flag.Int("pulseSeconds", 5, "number of seconds between heartbeats")
@@ -53,6 +65,11 @@ var (
 	disableHttp           = cmdMaster.Flag.Bool("disableHttp", false, "disable http requests, only gRPC operations are allowed.")
 	masterCpuProfile      = cmdMaster.Flag.String("cpuprofile", "", "cpu profile output file")
 	masterMemProfile      = cmdMaster.Flag.String("memprofile", "", "memory profile output file")
+	masterSequencer       = cmdMaster.Flag.String("sequencer", "memory", "[memory|snowflake|etcd] generator of the file ids")
+	masterSnowflakeId     = cmdMaster.Flag.Int("sequencer.snowflake.id", -1, "unique id in [0, 1023] of this master for the snowflake sequencer, required with multiple masters, default to a hash of the master address")
+	masterSequencerEtcd   = cmdMaster.Flag.String("sequencer.etcd.urls", "http://127.0.0.1:2379", "comma separated etcd urls for the etcd sequencer")
+	masterRepairGrace     = cmdMaster.Flag.Duration("replication.repairGracePeriod", 15*time.Minute, "how long to wait for the lost replicas to come back, before repairing an under-replicated volume")
+	masterRepairInterval  = cmdMaster.Flag.Duration("replication.repairInterval", time.Minute, "how often to repair one under-replicated volume, 0 to disable")
 
 	masterWhiteList []string
 )
@@ -84,6 +101,9 @@ func runMaster(cmd *Command, args []string) bool {
 		*mpulse, *defaultReplicaPlacement, *garbageThreshold,
 		masterWhiteList,
 		*disableHttp,
+		newMasterSequencer(*masterSequencer, *masterSnowflakeId, *masterSequencerEtcd, *masterIp+":"+strconv.Itoa(*mport),
+			hasOtherMasters(*masterIp+":"+strconv.Itoa(*mport), *masterPeers, *masterRaftJoin)),
+		*masterRepairGrace, *masterRepairInterval,
 	)
 
 	listeningAddress := *masterBindIp + ":" + strconv.Itoa(*mport)
@@ -97,9 +117,12 @@ func runMaster(cmd *Command, args []string) bool {
 
 	go func() {
 		// start raftServer
-		myMasterAddress, peers := checkPeers(*masterIp, *mport, *masterPeers)
-		raftServer := weed_server.NewRaftServer(security.LoadClientTLS(viper.Sub("grpc"), "master"),
-			peers, myMasterAddress, *metaFolder, ms.Topo, *mpulse)
+		myMasterAddress, peers := checkPeers(*masterIp, *mport, *masterPeers, *masterRaftJoin)
+		raftServer, err := weed_server.NewRaftServer(peers, myMasterAddress, *masterBindIp, *metaFolder, ms.Topo, *mpulse, *masterRaftJoin,
+			security.LoadServerTLSConfig(viper.Sub("grpc"), "master"), security.LoadClientTLSConfig(viper.Sub("grpc"), "master"))
+		if err != nil {
+			glog.Fatalf("master failed to start raft server: %v", err)
+		}
 		ms.SetRaftServer(raftServer)
 		r.HandleFunc("/cluster/status", raftServer.StatusHandler).Methods("GET")
 
@@ -112,7 +135,6 @@ func runMaster(cmd *Command, args []string) bool {
 		// Create your protocol servers.
 		grpcS := util.NewGrpcServer(security.LoadServerTLS(viper.Sub("grpc"), "master"))
 		master_pb.RegisterSeaweedServer(grpcS, ms)
-		protobuf.RegisterRaftServer(grpcS, raftServer)
 		reflection.Register(grpcS)
 
 		glog.V(0).Infof("Start Seaweed Master %s grpc server at %s:%d", util.VERSION, *masterBindIp, grpcPort)
@@ -128,7 +150,52 @@ func runMaster(cmd *Command, args []string) bool {
 	return true
 }
 
-func checkPeers(masterIp string, masterPort int, peers string) (masterAddress string, cleanedPeers []string) {
+// newMasterSequencer creates the file id generator. With multiple masters, the snowflake node ids are required
+// to be set to different values, since the hashes of the master addresses may collide.
+func newMasterSequencer(sequencerType string, snowflakeId int, etcdUrls string, masterAddress string, multipleMasters bool) sequence.Sequencer {
+	switch sequencerType {
+	case "memory":
+		return sequence.NewMemorySequencer()
+	case "snowflake":
+		if snowflakeId < 0 && multipleMasters {
+			glog.Fatalf("snowflake sequencer: -sequencer.snowflake.id is required to be unique with multiple masters")
+		}
+		if snowflakeId < 0 {
+			h := fnv.New32a()
+			h.Write([]byte(masterAddress))
+			snowflakeId = int(h.Sum32() % (sequence.SnowflakeMaxNodeId + 1))
+		}
+		glog.V(0).Infof("snowflake sequencer node id %d", snowflakeId)
+		seq, err := sequence.NewSnowflakeSequencer(snowflakeId)
+		if err != nil {
+			glog.Fatalf("snowflake sequencer: %v", err)
+		}
+		return seq
+	case "etcd":
+		seq, err := sequence.NewEtcdSequencer(strings.Split(etcdUrls, ","), sequence.DefaultEtcdSequenceKey)
+		if err != nil {
+			glog.Fatalf("etcd sequencer: %v", err)
+		}
+		return seq
+	}
+	glog.Fatalf("unknown sequencer %s, should be one of memory, snowflake or etcd", sequencerType)
+	return nil
+}
+
+// hasOtherMasters tells whether the master is one of multiple masters, with the peers or joining a cluster
+func hasOtherMasters(masterAddress string, peers string, join bool) bool {
+	if join {
+		return true
+	}
+	for _, peer := range strings.Split(peers, ",") {
+		if peer != "" && peer != masterAddress {
+			return true
+		}
+	}
+	return false
+}
+
+func checkPeers(masterIp string, masterPort int, peers string, join bool) (masterAddress string, cleanedPeers []string) {
 	masterAddress = masterIp + ":" + strconv.Itoa(masterPort)
 	if peers != "" {
 		cleanedPeers = strings.Split(peers, ",")
@@ -146,7 +213,7 @@ func checkPeers(masterIp string, masterPort int, peers string) (masterAddress st
 	if !hasSelf {
 		peerCount += 1
 	}
-	if peerCount%2 == 0 {
+	if peerCount%2 == 0 && !join {
 		glog.Fatalf("Only odd number of masters are supported!")
 	}
 	return
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -16,7 +17,14 @@ type MountOptions struct {
 	ttlSec             *int
 	chunkSizeLimitMB   *int
 	dataCenter         *string
+	diskType           *string
 	allowOthers        *bool
+	cacheMemoryMB      *int
+	cacheDir           *string
+	cacheCapacityMB    *int
+	readAheadChunks    *int
+	dirtyMemoryMB      *int
+	swapDir            *string
 }
 
 var (
@@ -36,7 +44,14 @@ func init() {
 	mountOptions.ttlSec = cmdMount.Flag.Int("ttl", 0, "file ttl in seconds")
 	mountOptions.chunkSizeLimitMB = cmdMount.Flag.Int("chunkSizeLimitMB", 4, "local write buffer size, also chunk large files")
 	mountOptions.dataCenter = cmdMount.Flag.String("dataCenter", "", "prefer to write to the data center")
+	mountOptions.diskType = cmdMount.Flag.String("disk", "", "[hdd|ssd] disk type to create the files. If empty, let filer decide.")
 	mountOptions.allowOthers = cmdMount.Flag.Bool("allowOthers", true, "allows other users to access the file system")
+	mountOptions.cacheMemoryMB = cmdMount.Flag.Int("cacheMemoryMB", 128, "chunk cache size in memory, 0 to disable")
+	mountOptions.cacheDir = cmdMount.Flag.String("cacheDir", "", "local directory to also cache the chunks on disk, one directory per mount")
+	mountOptions.cacheCapacityMB = cmdMount.Flag.Int("cacheCapacityMB", 1024, "chunk cache size on disk")
+	mountOptions.readAheadChunks = cmdMount.Flag.Int("readAheadChunks", 2, "number of chunks to read ahead for sequential reads, requires the chunk cache")
+	mountOptions.dirtyMemoryMB = cmdMount.Flag.Int("dirtyMemoryMB", 256, "memory for the written data not saved yet, the excess is swapped to disk")
+	mountOptions.swapDir = cmdMount.Flag.String("swapDir", os.TempDir(), "local directory to swap the written data not saved yet")
 	mountCpuProfile = cmdMount.Flag.String("cpuprofile", "", "cpu profile output file")
 	mountMemProfile = cmdMount.Flag.String("memprofile", "", "memory profile output file")
 }
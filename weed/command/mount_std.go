@@ -71,10 +71,11 @@ func runMount(cmd *Command, args []string) bool {
 		fuse.ExclCreate(),
 		fuse.DaemonTimeout("3600"),
 		fuse.AllowSUID(),
-		fuse.DefaultPermissions(),
 		fuse.MaxReadahead(1024 * 128),
 		fuse.AsyncRead(),
 		fuse.WritebackCache(),
+		fuse.LockingFlock(),
+		fuse.LockingPOSIX(),
 	}
 	if *mountOptions.allowOthers {
 		options = append(options, fuse.AllowOther())
@@ -102,7 +103,12 @@ func runMount(cmd *Command, args []string) bool {
 		mountRoot = mountRoot[0 : len(mountRoot)-1]
 	}
 
-	err = fs.Serve(c, filesys.NewSeaweedFileSystem(&filesys.Option{
+	// the permissions are checked by the mount and the filer, with the caller identity in each request
+	server := fs.New(c, &fs.Config{
+		WithContext: filesys.WithRequestIdentity,
+	})
+
+	err = server.Serve(filesys.NewSeaweedFileSystem(&filesys.Option{
 		FilerGrpcAddress:   filerGrpcAddress,
 		GrpcDialOption:     security.LoadClientTLS(viper.Sub("grpc"), "client"),
 		FilerMountRootPath: mountRoot,
@@ -111,11 +117,20 @@ func runMount(cmd *Command, args []string) bool {
 		TtlSec:             int32(*mountOptions.ttlSec),
 		ChunkSizeLimit:     int64(*mountOptions.chunkSizeLimitMB) * 1024 * 1024,
 		DataCenter:         *mountOptions.dataCenter,
+		DiskType:           *mountOptions.diskType,
 		DirListingLimit:    *mountOptions.dirListingLimit,
 		EntryCacheTtl:      3 * time.Second,
 		MountUid:           uid,
 		MountGid:           gid,
 		MountMode:          mountMode,
+
+		ChunkCacheMemoryLimit: int64(*mountOptions.cacheMemoryMB) * 1024 * 1024,
+		ChunkCacheDir:         *mountOptions.cacheDir,
+		ChunkCacheDiskLimit:   int64(*mountOptions.cacheCapacityMB) * 1024 * 1024,
+		ReadAheadChunks:       *mountOptions.readAheadChunks,
+
+		DirtyPagesMemoryLimit: int64(*mountOptions.dirtyMemoryMB) * 1024 * 1024,
+		SwapDir:               *mountOptions.swapDir,
 	}))
 	if err != nil {
 		fuse.Unmount(*mountOptions.dir)
@@ -27,6 +27,8 @@ type S3Options struct {
 	domainName       *string
 	tlsPrivateKey    *string
 	tlsCertificate   *string
+	lifecycleScan    *time.Duration
+	config           *string
 }
 
 func init() {
@@ -38,6 +40,8 @@ func init() {
 	s3options.domainName = cmdS3.Flag.String("domainName", "", "suffix of the host name, {bucket}.{domainName}")
 	s3options.tlsPrivateKey = cmdS3.Flag.String("key.file", "", "path to the TLS private key file")
 	s3options.tlsCertificate = cmdS3.Flag.String("cert.file", "", "path to the TLS certificate file")
+	s3options.config = cmdS3.Flag.String("config", "", "path to the config file of the identities and their allowed actions")
+	s3options.lifecycleScan = cmdS3.Flag.Duration("lifecycleScanInterval", time.Hour, "how often to expire the objects by the bucket lifecycle rules, 0 to disable, e.g., on all but one s3 gateway")
 }
 
 var cmdS3 = &Command{
@@ -45,6 +49,35 @@ var cmdS3 = &Command{
 	Short:     "start a s3 API compatible server that is backed by a filer",
 	Long: `start a s3 API compatible server that is backed by a filer.
 
+	By default, all requests are allowed. With the -config file, the requests are verified
+	by the AWS signature v4 or v2, and the identity needs the permission of the action.
+
+	The -config file is in json format, e.g.,
+
+	{
+	  "identities": [
+	    {
+	      "name": "anonymous",
+	      "actions": ["Read:public_bucket"]
+	    },
+	    {
+	      "name": "some_admin_user",
+	      "credentials": [{"accessKey": "some_access_key1", "secretKey": "some_secret_key1"}],
+	      "actions": ["Admin", "Read", "List", "Write"]
+	    },
+	    {
+	      "name": "some_read_only_user",
+	      "credentials": [{"accessKey": "some_access_key2", "secretKey": "some_secret_key2"}],
+	      "actions": ["Read:bucket1", "List:bucket1"],
+	      "posix": {"uid": 1000, "gids": [1000, 100]}
+	    }
+	  ]
+	}
+
+	The actions are "Read", "Write", "List" and "Admin", optionally limited to one bucket, e.g., "Write:bucket1".
+	The identity named "anonymous" is used for the requests without signatures.
+	With the optional "posix" uid and gids, the filer also checks the file permissions and ACLs of the identity.
+
 `,
 }
 
@@ -61,11 +94,13 @@ func runS3(cmd *Command, args []string) bool {
 	router := mux.NewRouter().SkipClean(true)
 
 	_, s3ApiServer_err := s3api.NewS3ApiServer(router, &s3api.S3ApiServerOption{
-		Filer:            *s3options.filer,
-		FilerGrpcAddress: filerGrpcAddress,
-		DomainName:       *s3options.domainName,
-		BucketsPath:      *s3options.filerBucketsPath,
-		GrpcDialOption:   security.LoadClientTLS(viper.Sub("grpc"), "client"),
+		Filer:                 *s3options.filer,
+		FilerGrpcAddress:      filerGrpcAddress,
+		DomainName:            *s3options.domainName,
+		BucketsPath:           *s3options.filerBucketsPath,
+		GrpcDialOption:        security.LoadClientTLS(viper.Sub("grpc"), "client"),
+		Config:                *s3options.config,
+		LifecycleScanInterval: *s3options.lifecycleScan,
 	})
 	if s3ApiServer_err != nil {
 		glog.Fatalf("S3 API Server startup error: %v", s3ApiServer_err)
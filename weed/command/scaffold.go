@@ -10,7 +10,7 @@ func init() {
 }
 
 var cmdScaffold = &Command{
-	UsageLine: "scaffold -config=[filer|notification|replication|security]",
+	UsageLine: "scaffold -config=[filer|notification|replication|security|tier]",
 	Short:     "generate basic configuration files",
 	Long: `Generate filer.toml with all possible configurations for you to customize.
 
@@ -19,7 +19,7 @@ var cmdScaffold = &Command{
 
 var (
 	outputPath = cmdScaffold.Flag.String("output", "", "if not empty, save the configuration file to this directory")
-	config     = cmdScaffold.Flag.String("config", "filer", "[filer|notification|replication|security] the configuration file to generate")
+	config     = cmdScaffold.Flag.String("config", "filer", "[filer|notification|replication|security|tier] the configuration file to generate")
 )
 
 func runScaffold(cmd *Command, args []string) bool {
@@ -34,6 +34,8 @@ func runScaffold(cmd *Command, args []string) bool {
 		content = REPLICATION_TOML_EXAMPLE
 	case "security":
 		content = SECURITY_TOML_EXAMPLE
+	case "tier":
+		content = TIER_TOML_EXAMPLE
 	}
 	if content == "" {
 		println("need a valid -config option")
@@ -66,6 +68,11 @@ enabled = false
 enabled = true
 dir = "."					# directory to store level db files
 
+[bbolt]
+# local on disk, a single file with transactions, for simple single-machine setup
+enabled = false
+dir = "."					# directory to store the bolt db file
+
 ####################################################
 # multiple filers on shared storage, fairly scalable
 ####################################################
@@ -135,6 +142,24 @@ addresses = [
     "localhost:30006",
 ]
 
+[etcd]
+enabled = false
+servers = [
+    "localhost:2379",
+]
+username = ""
+password = ""
+key_prefix = ""            # share the etcd cluster with others, e.g., "seaweedfs.filer."
+timeout = "3s"
+max_txn_ops = 128          # the --max-txn-ops of etcd, transactions with more changes fail to commit
+
+[tikv]
+# only available if built with "-tags tikv"
+enabled = false
+pd_addresses = [
+    "localhost:2379",
+]
+
 `
 
 	NOTIFICATION_TOML_EXAMPLE = `
@@ -289,6 +314,15 @@ key  = ""
 cert = ""
 key  = ""
 
+# the identity of the callers, to check the permissions of the filer entries
+# only the grpc callers authenticated by the tls above, and the callers in the white list,
+# e.g., the hosts running "weed mount" and the S3 gateway, may pass the identity of their users
+[filer.identity]
+whiteList = ""       # comma separated ip addresses or CIDR ranges, e.g., "127.0.0.1,10.0.0.0/8"
+# the "uid:gid,gid" of the requests without an identity, e.g., "weed shell", "filer.copy" and "filer.sync"
+# set "0:0" to trust them as root. if empty, these requests are denied
+default = ""
+
 
 # volume server https options
 # Note: work in progress!
@@ -300,5 +334,28 @@ cert = ""
 key  = ""
 
 
+`
+	TIER_TOML_EXAMPLE = `
+# A sample TOML config file for the volume server to move .dat files to remote storages
+# Used with "weed shell" command "volume.tier.upload -dest=s3.default" and "volume.tier.download"
+# Put this file to one of the location, with descending priority
+#    ./tier.toml
+#    $HOME/.seaweedfs/tier.toml
+#    /etc/seaweedfs/tier.toml
+# The .idx files always stay on the local disk.
+
+[storage.backend.s3.default]
+enabled = false
+aws_access_key_id     = ""     # if empty, loads from the shared credentials file (~/.aws/credentials).
+aws_secret_access_key = ""     # if empty, loads from the shared credentials file (~/.aws/credentials).
+region = "us-east-2"
+bucket = "your_bucket_name"    # an existing bucket
+endpoint = ""                  # for S3 compatible services, e.g., "http://localhost:9000" for MinIO
+
+[storage.backend.dir.default]
+# a directory on a slower disk or network file system
+enabled = false
+directory = "/mnt/cold/seaweedfs"
+
 `
 )
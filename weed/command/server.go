@@ -10,7 +10,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/chrislusf/raft/protobuf"
 	"github.com/spf13/viper"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
 
@@ -62,15 +61,22 @@ var (
 	serverRack                    = cmdServer.Flag.String("rack", "", "current volume server's rack name")
 	serverWhiteListOption         = cmdServer.Flag.String("whiteList", "", "comma separated Ip addresses having write permission. No limit if empty.")
 	serverDisableHttp             = cmdServer.Flag.Bool("disableHttp", false, "disable http requests, only gRPC operations are allowed.")
-	serverPeers                   = cmdServer.Flag.String("master.peers", "", "all master nodes in comma separated ip:masterPort list")
+	serverPeers                   = cmdServer.Flag.String("master.peers", "", "all master nodes in comma separated ip:masterPort list to bootstrap a new cluster")
+	serverRaftJoin                = cmdServer.Flag.Bool("master.raftJoin", false, "start without bootstrapping a new cluster, waiting to be added to an existing cluster by cluster.raft.add")
 	serverGarbageThreshold        = cmdServer.Flag.Float64("garbageThreshold", 0.3, "threshold to vacuum and reclaim spaces")
 	masterPort                    = cmdServer.Flag.Int("master.port", 9333, "master server http listen port")
 	masterMetaFolder              = cmdServer.Flag.String("master.dir", "", "data directory to store meta data, default to same as -dir specified")
 	masterVolumeSizeLimitMB       = cmdServer.Flag.Uint("master.volumeSizeLimitMB", 30*1000, "Master stops directing writes to oversized volumes.")
 	masterVolumePreallocate       = cmdServer.Flag.Bool("master.volumePreallocate", false, "Preallocate disk space for volumes.")
 	masterDefaultReplicaPlacement = cmdServer.Flag.String("master.defaultReplicaPlacement", "000", "Default replication type if not specified.")
+	masterSequencerType           = cmdServer.Flag.String("master.sequencer", "memory", "[memory|snowflake|etcd] generator of the file ids")
+	masterSequencerSnowflakeId    = cmdServer.Flag.Int("master.sequencer.snowflake.id", -1, "unique id in [0, 1023] of this master for the snowflake sequencer, required with multiple masters, default to a hash of the master address")
+	masterSequencerEtcdUrls       = cmdServer.Flag.String("master.sequencer.etcd.urls", "http://127.0.0.1:2379", "comma separated etcd urls for the etcd sequencer")
+	masterServerRepairGrace       = cmdServer.Flag.Duration("master.replication.repairGracePeriod", 15*time.Minute, "how long to wait for the lost replicas to come back, before repairing an under-replicated volume")
+	masterServerRepairInterval    = cmdServer.Flag.Duration("master.replication.repairInterval", time.Minute, "how often to repair one under-replicated volume, 0 to disable")
 	volumeDataFolders             = cmdServer.Flag.String("dir", os.TempDir(), "directories to store data files. dir[,dir]...")
 	volumeMaxDataVolumeCounts     = cmdServer.Flag.String("volume.max", "7", "maximum numbers of volumes, count[,count]...")
+	volumeDataDiskTypes           = cmdServer.Flag.String("volume.disk", "", "[hdd|ssd] hard drive or solid state drive, type[,type]... for each directory, default to hdd")
 	pulseSeconds                  = cmdServer.Flag.Int("pulseSeconds", 5, "number of seconds between heartbeats")
 	isStartingFiler               = cmdServer.Flag.Bool("filer", false, "whether to start filer")
 
@@ -88,6 +94,7 @@ func init() {
 	filerOptions.disableDirListing = cmdServer.Flag.Bool("filer.disableDirListing", false, "turn off directory listing")
 	filerOptions.maxMB = cmdServer.Flag.Int("filer.maxMB", 32, "split files larger than the limit")
 	filerOptions.dirListingLimit = cmdServer.Flag.Int("filer.dirListLimit", 1000, "limit sub dir listing size")
+	filerOptions.diskType = cmdServer.Flag.String("filer.disk", "", "[hdd|ssd] default disk type to write to if not specified")
 
 	serverOptions.v.port = cmdServer.Flag.Int("volume.port", 8080, "volume server http listen port")
 	serverOptions.v.publicPort = cmdServer.Flag.Int("volume.port.public", 0, "volume server public port")
@@ -176,6 +183,9 @@ func runServer(cmd *Command, args []string) bool {
 			*masterVolumeSizeLimitMB, *masterVolumePreallocate,
 			*pulseSeconds, *masterDefaultReplicaPlacement, *serverGarbageThreshold,
 			serverWhiteList, *serverDisableHttp,
+			newMasterSequencer(*masterSequencerType, *masterSequencerSnowflakeId, *masterSequencerEtcdUrls, *serverIp+":"+strconv.Itoa(*masterPort),
+				hasOtherMasters(*serverIp+":"+strconv.Itoa(*masterPort), *serverPeers, *serverRaftJoin)),
+			*masterServerRepairGrace, *masterServerRepairInterval,
 		)
 
 		glog.V(0).Infof("Start Seaweed Master %s at %s:%d", util.VERSION, *serverIp, *masterPort)
@@ -186,9 +196,12 @@ func runServer(cmd *Command, args []string) bool {
 
 		go func() {
 			// start raftServer
-			myMasterAddress, peers := checkPeers(*serverIp, *masterPort, *serverPeers)
-			raftServer := weed_server.NewRaftServer(security.LoadClientTLS(viper.Sub("grpc"), "master"),
-				peers, myMasterAddress, *masterMetaFolder, ms.Topo, *pulseSeconds)
+			myMasterAddress, peers := checkPeers(*serverIp, *masterPort, *serverPeers, *serverRaftJoin)
+			raftServer, err := weed_server.NewRaftServer(peers, myMasterAddress, *serverBindIp, *masterMetaFolder, ms.Topo, *pulseSeconds, *serverRaftJoin,
+				security.LoadServerTLSConfig(viper.Sub("grpc"), "master"), security.LoadClientTLSConfig(viper.Sub("grpc"), "master"))
+			if err != nil {
+				glog.Fatalf("master failed to start raft server: %v", err)
+			}
 			ms.SetRaftServer(raftServer)
 			r.HandleFunc("/cluster/status", raftServer.StatusHandler).Methods("GET")
 
@@ -202,7 +215,6 @@ func runServer(cmd *Command, args []string) bool {
 			glog.V(0).Infof("grpc config %+v", viper.Sub("grpc"))
 			grpcS := util.NewGrpcServer(security.LoadServerTLS(viper.Sub("grpc"), "master"))
 			master_pb.RegisterSeaweedServer(grpcS, ms)
-			protobuf.RegisterRaftServer(grpcS, raftServer)
 			reflection.Register(grpcS)
 
 			glog.V(0).Infof("Start Seaweed Master %s grpc server at %s:%d", util.VERSION, *serverIp, grpcPort)
@@ -222,7 +234,7 @@ func runServer(cmd *Command, args []string) bool {
 	volumeWait.Wait()
 	time.Sleep(100 * time.Millisecond)
 
-	serverOptions.v.startVolumeServer(*volumeDataFolders, *volumeMaxDataVolumeCounts, *serverWhiteListOption)
+	serverOptions.v.startVolumeServer(*volumeDataFolders, *volumeMaxDataVolumeCounts, *volumeDataDiskTypes, *serverWhiteListOption)
 
 	return true
 }
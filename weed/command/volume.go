@@ -29,6 +29,7 @@ type VolumeServerOptions struct {
 	publicPort            *int
 	folders               []string
 	folderMaxLimits       []int
+	folderDiskTypes       []storage.DiskType
 	ip                    *string
 	publicUrl             *string
 	bindIp                *string
@@ -77,6 +78,7 @@ var cmdVolume = &Command{
 var (
 	volumeFolders         = cmdVolume.Flag.String("dir", os.TempDir(), "directories to store data files. dir[,dir]...")
 	maxVolumeCounts       = cmdVolume.Flag.String("max", "7", "maximum numbers of volumes, count[,count]...")
+	volumeDiskTypes       = cmdVolume.Flag.String("disk", "", "[hdd|ssd] hard drive or solid state drive, type[,type]... for each directory, default to hdd")
 	volumeWhiteListOption = cmdVolume.Flag.String("whiteList", "", "comma separated Ip addresses having write permission. No limit if empty.")
 )
 
@@ -90,12 +92,12 @@ func runVolume(cmd *Command, args []string) bool {
 	runtime.GOMAXPROCS(*v.maxCpu)
 	util.SetupProfiling(*v.cpuProfile, *v.memProfile)
 
-	v.startVolumeServer(*volumeFolders, *maxVolumeCounts, *volumeWhiteListOption)
+	v.startVolumeServer(*volumeFolders, *maxVolumeCounts, *volumeDiskTypes, *volumeWhiteListOption)
 
 	return true
 }
 
-func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, volumeWhiteListOption string) {
+func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, volumeDiskTypes, volumeWhiteListOption string) {
 
 	//Set multiple folders and each folder's max volume count limit'
 	v.folders = strings.Split(volumeFolders, ",")
@@ -110,6 +112,23 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 	if len(v.folders) != len(v.folderMaxLimits) {
 		glog.Fatalf("%d directories by -dir, but only %d max is set by -max", len(v.folders), len(v.folderMaxLimits))
 	}
+	diskTypeStrings := strings.Split(volumeDiskTypes, ",")
+	for _, diskTypeString := range diskTypeStrings {
+		if diskType, e := storage.ToDiskType(diskTypeString); e == nil {
+			v.folderDiskTypes = append(v.folderDiskTypes, diskType)
+		} else {
+			glog.Fatalf("The disk type specified in -disk is not valid: %v", e)
+		}
+	}
+	if len(v.folderDiskTypes) == 1 {
+		// one disk type for all directories
+		for len(v.folderDiskTypes) < len(v.folders) {
+			v.folderDiskTypes = append(v.folderDiskTypes, v.folderDiskTypes[0])
+		}
+	}
+	if len(v.folders) != len(v.folderDiskTypes) {
+		glog.Fatalf("%d directories by -dir, but only %d disk type is set by -disk", len(v.folders), len(v.folderDiskTypes))
+	}
 	for _, folder := range v.folders {
 		if err := util.TestFolderWritable(folder); err != nil {
 			glog.Fatalf("Check Data Folder(-dir) Writable %s : %s", folder, err)
@@ -153,7 +172,7 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 
 	volumeServer := weed_server.NewVolumeServer(volumeMux, publicVolumeMux,
 		*v.ip, *v.port, *v.publicUrl,
-		v.folders, v.folderMaxLimits,
+		v.folders, v.folderMaxLimits, v.folderDiskTypes,
 		volumeNeedleMapKind,
 		strings.Split(masters, ","), *v.pulseSeconds, *v.dataCenter, *v.rack,
 		v.whiteList,
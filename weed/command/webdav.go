@@ -0,0 +1,101 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+var (
+	webDavStandaloneOptions WebDavOption
+)
+
+type WebDavOption struct {
+	filer          *string
+	port           *int
+	collection     *string
+	replication    *string
+	chunkSizeLimit *int
+	tlsPrivateKey  *string
+	tlsCertificate *string
+}
+
+func init() {
+	cmdWebDav.Run = runWebDav // break init cycle
+	webDavStandaloneOptions.filer = cmdWebDav.Flag.String("filer", "localhost:8888", "filer server address")
+	webDavStandaloneOptions.port = cmdWebDav.Flag.Int("port", 7333, "webdav server http listen port")
+	webDavStandaloneOptions.collection = cmdWebDav.Flag.String("collection", "", "collection to create the files")
+	webDavStandaloneOptions.replication = cmdWebDav.Flag.String("replication", "", "replication to create the files, default to the filer setting")
+	webDavStandaloneOptions.chunkSizeLimit = cmdWebDav.Flag.Int("chunkSizeLimitMB", 4, "local write buffer size, also chunk large files")
+	webDavStandaloneOptions.tlsPrivateKey = cmdWebDav.Flag.String("key.file", "", "path to the TLS private key file")
+	webDavStandaloneOptions.tlsCertificate = cmdWebDav.Flag.String("cert.file", "", "path to the TLS certificate file")
+}
+
+var cmdWebDav = &Command{
+	UsageLine: "webdav -port=7333 -filer=<ip:port>",
+	Short:     "start a webdav server that is backed by a filer",
+	Long: `start a webdav server that is backed by a filer.
+
+`,
+}
+
+func runWebDav(cmd *Command, args []string) bool {
+
+	weed_server.LoadConfiguration("security", false)
+
+	return webDavStandaloneOptions.startWebDav()
+
+}
+
+func (wo *WebDavOption) startWebDav() bool {
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*wo.filer)
+	if err != nil {
+		glog.Fatal(err)
+		return false
+	}
+
+	ws, webdavServer_err := weed_server.NewWebDavServer(&weed_server.WebDavOption{
+		Filer:            *wo.filer,
+		FilerGrpcAddress: filerGrpcAddress,
+		GrpcDialOption:   security.LoadClientTLS(viper.Sub("grpc"), "client"),
+		Collection:       *wo.collection,
+		Replication:      *wo.replication,
+		ChunkSizeLimit:   int64(*wo.chunkSizeLimit) * 1024 * 1024,
+		Uid:              filer2.OS_UID,
+		Gid:              filer2.OS_GID,
+	})
+	if webdavServer_err != nil {
+		glog.Fatalf("WebDav Server startup error: %v", webdavServer_err)
+	}
+
+	httpS := &http.Server{Handler: ws.Handler}
+
+	listenAddress := fmt.Sprintf(":%d", *wo.port)
+	webDavListener, err := util.NewListener(listenAddress, time.Duration(10)*time.Second)
+	if err != nil {
+		glog.Fatalf("WebDav Server listener on %s error: %v", listenAddress, err)
+	}
+
+	if *wo.tlsPrivateKey != "" {
+		glog.V(0).Infof("Start Seaweed WebDav Server %s at https port %d", util.VERSION, *wo.port)
+		if err = httpS.ServeTLS(webDavListener, *wo.tlsCertificate, *wo.tlsPrivateKey); err != nil {
+			glog.Fatalf("WebDav Server Fail to serve: %v", err)
+		}
+	} else {
+		glog.V(0).Infof("Start Seaweed WebDav Server %s at http port %d", util.VERSION, *wo.port)
+		if err = httpS.Serve(webDavListener); err != nil {
+			glog.Fatalf("WebDav Server Fail to serve: %v", err)
+		}
+	}
+
+	return true
+
+}
@@ -0,0 +1,157 @@
+package abstract_kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+)
+
+/*
+
+AbstractKvStore implements the filer store on any ordered key value store.
+
+The entry is stored with the key "<directory>\x00<name>", so the entries of one directory
+are next to each other in the order of the names, and listed by scanning the keys with the prefix "<directory>\x00".
+
+A key value store only needs to implement KvStore, and the transactions of filer2.FilerStore.
+The key value operations with the context returned by BeginTransaction should be in the transaction.
+
+*/
+
+const (
+	DIR_FILE_SEPARATOR = byte(0x00)
+)
+
+// ErrKvNotFound is returned by KvGet if the key does not exist
+var ErrKvNotFound = errors.New("kv: key not found")
+
+type KvStore interface {
+	KvGet(ctx context.Context, key []byte) (value []byte, err error)
+	KvPut(ctx context.Context, key []byte, value []byte) error
+	KvDelete(ctx context.Context, key []byte) error
+	// KvScan calls fn on the keys with the prefix in ascending order, starting from the startKey, until fn returns false
+	KvScan(ctx context.Context, prefix []byte, startKey []byte, fn func(key, value []byte) bool) error
+}
+
+type AbstractKvStore struct {
+	Kv KvStore
+}
+
+func (store *AbstractKvStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+	key := GenKey(entry.DirAndName())
+
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+
+	if err = store.Kv.KvPut(ctx, key, value); err != nil {
+		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
+	}
+
+	return nil
+}
+
+func (store *AbstractKvStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+
+	return store.InsertEntry(ctx, entry)
+}
+
+func (store *AbstractKvStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+	key := GenKey(fullpath.DirAndName())
+
+	data, err := store.Kv.KvGet(ctx, key)
+
+	if err == ErrKvNotFound {
+		return nil, filer2.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s : %v", fullpath, err)
+	}
+
+	entry = &filer2.Entry{
+		FullPath: fullpath,
+	}
+	err = entry.DecodeAttributesAndChunks(data)
+	if err != nil {
+		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+	}
+
+	return entry, nil
+}
+
+func (store *AbstractKvStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
+	key := GenKey(fullpath.DirAndName())
+
+	if err = store.Kv.KvDelete(ctx, key); err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *AbstractKvStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int) (entries []*filer2.Entry, err error) {
+
+	directoryPrefix := GenDirectoryKeyPrefix(fullpath, "")
+
+	scanErr := store.Kv.KvScan(ctx, directoryPrefix, GenDirectoryKeyPrefix(fullpath, startFileName), func(key, value []byte) bool {
+		fileName := string(key[len(directoryPrefix):])
+		if fileName == "" {
+			return true
+		}
+		if fileName == startFileName && !inclusive {
+			return true
+		}
+		limit--
+		if limit < 0 {
+			return false
+		}
+		entry := &filer2.Entry{
+			FullPath: filer2.NewFullPath(string(fullpath), fileName),
+		}
+		if decodeErr := entry.DecodeAttributesAndChunks(value); decodeErr != nil {
+			err = decodeErr
+			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
+			return false
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	if scanErr != nil {
+		return nil, fmt.Errorf("list %s : %v", fullpath, scanErr)
+	}
+
+	return entries, err
+}
+
+func GenKey(dirPath, fileName string) (key []byte) {
+	key = []byte(dirPath)
+	key = append(key, DIR_FILE_SEPARATOR)
+	key = append(key, []byte(fileName)...)
+	return key
+}
+
+func GenDirectoryKeyPrefix(fullpath filer2.FullPath, startFileName string) (keyPrefix []byte) {
+	keyPrefix = []byte(string(fullpath))
+	keyPrefix = append(keyPrefix, DIR_FILE_SEPARATOR)
+	if len(startFileName) > 0 {
+		keyPrefix = append(keyPrefix, []byte(startFileName)...)
+	}
+	return keyPrefix
+}
+
+// PrefixEnd is the smallest key larger than all the keys with the prefix, or nil if there is none
+func PrefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package abstract_kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+)
+
+// memKv is an ordered key value store in memory, to test AbstractKvStore without a real store
+type memKv struct {
+	values map[string][]byte
+}
+
+func (kv *memKv) KvGet(ctx context.Context, key []byte) ([]byte, error) {
+	value, found := kv.values[string(key)]
+	if !found {
+		return nil, ErrKvNotFound
+	}
+	return value, nil
+}
+
+func (kv *memKv) KvPut(ctx context.Context, key []byte, value []byte) error {
+	kv.values[string(key)] = value
+	return nil
+}
+
+func (kv *memKv) KvDelete(ctx context.Context, key []byte) error {
+	delete(kv.values, string(key))
+	return nil
+}
+
+func (kv *memKv) KvScan(ctx context.Context, prefix []byte, startKey []byte, fn func(key, value []byte) bool) error {
+	var keys []string
+	for key := range kv.values {
+		if strings.HasPrefix(key, string(prefix)) && key >= string(startKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !fn([]byte(key), kv.values[key]) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestKeyEncoding(t *testing.T) {
+	if key := GenKey("/home/chris", "file1.jpg"); !bytes.Equal(key, []byte("/home/chris\x00file1.jpg")) {
+		t.Errorf("key %q", key)
+	}
+	if prefix := GenDirectoryKeyPrefix("/home/chris", ""); !bytes.Equal(prefix, []byte("/home/chris\x00")) {
+		t.Errorf("directory prefix %q", prefix)
+	}
+	if prefix := GenDirectoryKeyPrefix("/home/chris", "file1"); !bytes.Equal(prefix, []byte("/home/chris\x00file1")) {
+		t.Errorf("directory prefix from file1 %q", prefix)
+	}
+
+	// the entries of a directory sort before the entries of the directories sharing the name prefix
+	if bytes.Compare(GenKey("/home/chris", "zzz"), GenKey("/home/chris1", "a")) >= 0 {
+		t.Errorf("entries of /home/chris sort after /home/chris1")
+	}
+	if bytes.HasPrefix(GenKey("/home/chris1", "a"), GenDirectoryKeyPrefix("/home/chris", "")) {
+		t.Errorf("entries of /home/chris1 have the prefix of /home/chris")
+	}
+
+	for _, c := range []struct {
+		prefix, end []byte
+	}{
+		{[]byte("/a\x00"), []byte("/a\x01")},
+		{[]byte("a\xff"), []byte("b")},
+		{[]byte("\xff\xff"), nil},
+	} {
+		if end := PrefixEnd(c.prefix); !bytes.Equal(end, c.end) {
+			t.Errorf("prefix end of %q: %q, expected %q", c.prefix, end, c.end)
+		}
+	}
+}
+
+func TestListDirectoryEntries(t *testing.T) {
+	store := &AbstractKvStore{Kv: &memKv{values: make(map[string][]byte)}}
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		if err := store.InsertEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath(fmt.Sprintf("/dir/file%02d", i)), Attr: filer2.Attr{Mode: 0644}}); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/dir1/other", Attr: filer2.Attr{Mode: 0644}})
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/dir/sub/nested", Attr: filer2.Attr{Mode: 0644}})
+
+	names := func(entries []*filer2.Entry) (names string) {
+		for _, entry := range entries {
+			names += entry.Name() + " "
+		}
+		return
+	}
+
+	for _, c := range []struct {
+		startFileName string
+		inclusive     bool
+		limit         int
+		expected      string
+	}{
+		{"", false, 3, "file00 file01 file02 "},
+		{"file05", false, 2, "file06 file07 "},
+		{"file05", true, 2, "file05 file06 "},
+		{"file18", false, 100, "file19 "},
+		{"file1", false, 2, "file10 file11 "},
+		{"file19", false, 100, ""},
+		{"", false, 0, ""},
+	} {
+		entries, err := store.ListDirectoryEntries(ctx, "/dir", c.startFileName, c.inclusive, c.limit)
+		if err != nil {
+			t.Fatalf("list from %s: %v", c.startFileName, err)
+		}
+		if names(entries) != c.expected {
+			t.Errorf("list from %q inclusive %v limit %d: %q, expected %q", c.startFileName, c.inclusive, c.limit, names(entries), c.expected)
+		}
+	}
+
+	// paging through the directory lists every entry once, without the nested or the sibling entries
+	var all []string
+	for lastFileName := ""; ; {
+		entries, err := store.ListDirectoryEntries(ctx, "/dir", lastFileName, false, 7)
+		if err != nil {
+			t.Fatalf("list after %s: %v", lastFileName, err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, entry := range entries {
+			all = append(all, entry.Name())
+		}
+		lastFileName = entries[len(entries)-1].Name()
+	}
+	if len(all) != 20 || all[0] != "file00" || all[19] != "file19" {
+		t.Errorf("paged list: %v", all)
+	}
+
+	if _, err := store.FindEntry(ctx, "/dir/missing"); err != filer2.ErrNotFound {
+		t.Errorf("find missing: %v", err)
+	}
+	store.DeleteEntry(ctx, "/dir/file00")
+	if entries, _ := store.ListDirectoryEntries(ctx, "/dir", "", false, 1); names(entries) != "file01 " {
+		t.Errorf("list after delete: %s", names(entries))
+	}
+}
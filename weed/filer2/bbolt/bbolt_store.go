@@ -0,0 +1,210 @@
+package bbolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/abstract_kv"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	weed_util "gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+/*
+
+All entries are in one bucket of the bolt db file.
+
+The changes of a transaction are buffered, and applied in one writable bolt transaction when committed.
+The writable bolt transaction is not held while the transaction is open, since it would block the other writes,
+which may hold the filer locks taken between the changes of the transaction. The reads in a transaction
+see its own changes, but are not isolated from the changes committed by others.
+
+*/
+
+var bucketName = []byte("filemeta")
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &BboltStore{})
+}
+
+type BboltStore struct {
+	abstract_kv.AbstractKvStore
+	db *bolt.DB
+}
+
+type boltTxKey struct{}
+
+func (store *BboltStore) GetName() string {
+	return "bbolt"
+}
+
+func (store *BboltStore) Initialize(configuration weed_util.Configuration) (err error) {
+	dir := configuration.GetString("dir")
+	return store.initialize(dir)
+}
+
+func (store *BboltStore) initialize(dir string) (err error) {
+	glog.Infof("filer store dir: %s", dir)
+	if err := weed_util.TestFolderWritable(dir); err != nil {
+		return fmt.Errorf("Check Bolt Folder %s Writable: %s", dir, err)
+	}
+
+	if store.db, err = bolt.Open(filepath.Join(dir, "filer.db"), 0600, &bolt.Options{Timeout: time.Second}); err != nil {
+		return fmt.Errorf("open bolt db in %s: %v", dir, err)
+	}
+	if err = store.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	}); err != nil {
+		return fmt.Errorf("create bucket %s: %v", bucketName, err)
+	}
+	store.Kv = store
+	return nil
+}
+
+// boltTxn buffers the changes of a transaction, a nil value is a deletion
+type boltTxn struct {
+	changes map[string][]byte
+}
+
+func getTxn(ctx context.Context) *boltTxn {
+	txn, _ := ctx.Value(boltTxKey{}).(*boltTxn)
+	return txn
+}
+
+func (store *BboltStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, boltTxKey{}, &boltTxn{changes: make(map[string][]byte)}), nil
+}
+func (store *BboltStore) CommitTransaction(ctx context.Context) error {
+	txn := getTxn(ctx)
+	if txn == nil || len(txn.changes) == 0 {
+		return nil
+	}
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		for key, value := range txn.changes {
+			var err error
+			if value == nil {
+				err = bucket.Delete([]byte(key))
+			} else {
+				err = bucket.Put([]byte(key), value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	txn.changes = make(map[string][]byte)
+	return err
+}
+func (store *BboltStore) RollbackTransaction(ctx context.Context) error {
+	if txn := getTxn(ctx); txn != nil {
+		txn.changes = make(map[string][]byte)
+	}
+	return nil
+}
+
+func (store *BboltStore) view(fn func(bucket *bolt.Bucket) error) error {
+	return store.db.View(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(bucketName))
+	})
+}
+
+func (store *BboltStore) KvGet(ctx context.Context, key []byte) (value []byte, err error) {
+	if txn := getTxn(ctx); txn != nil {
+		if value, found := txn.changes[string(key)]; found {
+			if value == nil {
+				return nil, abstract_kv.ErrKvNotFound
+			}
+			return append([]byte{}, value...), nil
+		}
+	}
+	err = store.view(func(bucket *bolt.Bucket) error {
+		data := bucket.Get(key)
+		if data == nil {
+			return abstract_kv.ErrKvNotFound
+		}
+		// the data is only valid in the transaction
+		value = append([]byte{}, data...)
+		return nil
+	})
+	return
+}
+
+func (store *BboltStore) KvPut(ctx context.Context, key []byte, value []byte) error {
+	if txn := getTxn(ctx); txn != nil {
+		txn.changes[string(key)] = append([]byte{}, value...)
+		return nil
+	}
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Put(key, value)
+	})
+}
+
+func (store *BboltStore) KvDelete(ctx context.Context, key []byte) error {
+	if txn := getTxn(ctx); txn != nil {
+		txn.changes[string(key)] = nil
+		return nil
+	}
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Delete(key)
+	})
+}
+
+// KvScan merges the buffered changes of the transaction, if any, into the keys read from the bolt db
+func (store *BboltStore) KvScan(ctx context.Context, prefix []byte, startKey []byte, fn func(key, value []byte) bool) error {
+
+	var pending []string
+	var changes map[string][]byte
+	if txn := getTxn(ctx); txn != nil {
+		changes = txn.changes
+		for key := range changes {
+			if strings.HasPrefix(key, string(prefix)) && key >= string(startKey) {
+				pending = append(pending, key)
+			}
+		}
+		sort.Strings(pending)
+	}
+
+	// emitPending visits the buffered changes before the key, or all if the key is nil
+	stopped := false
+	emitPending := func(key []byte) {
+		for len(pending) > 0 && !stopped && (key == nil || pending[0] < string(key)) {
+			if value := changes[pending[0]]; value != nil {
+				stopped = !fn([]byte(pending[0]), value)
+			}
+			pending = pending[1:]
+		}
+	}
+
+	err := store.view(func(bucket *bolt.Bucket) error {
+		cursor := bucket.Cursor()
+		for key, value := cursor.Seek(startKey); key != nil && bytes.HasPrefix(key, prefix); key, value = cursor.Next() {
+			if emitPending(key); stopped {
+				return nil
+			}
+			if len(pending) > 0 && pending[0] == string(key) {
+				// changed in the transaction
+				continue
+			}
+			if !fn(key, value) {
+				stopped = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	emitPending(nil)
+	return nil
+}
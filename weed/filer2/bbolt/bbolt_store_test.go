@@ -0,0 +1,166 @@
+package bbolt
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+)
+
+func TestCreateAndFind(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	fullpath := filer2.FullPath("/home/chris/this/is/one/file1.jpg")
+
+	ctx := context.Background()
+
+	entry1 := &filer2.Entry{
+		FullPath: fullpath,
+		Attr: filer2.Attr{
+			Mode: 0440,
+			Uid:  1234,
+			Gid:  5678,
+		},
+	}
+
+	if err := filer.CreateEntry(ctx, entry1); err != nil {
+		t.Fatalf("create entry %v: %v", entry1.FullPath, err)
+	}
+
+	entry, err := filer.FindEntry(ctx, fullpath)
+	if err != nil {
+		t.Fatalf("find entry: %v", err)
+	}
+	if entry.FullPath != entry1.FullPath || entry.Uid != 1234 || entry.Gid != 5678 {
+		t.Fatalf("find wrong entry: %+v", entry)
+	}
+
+	// checking one upper directory
+	entries, _ := filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is/one"), "", false, 100)
+	if len(entries) != 1 {
+		t.Fatalf("list entries count: %v", len(entries))
+	}
+
+	// checking one upper directory
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/"), "", false, 100)
+	if len(entries) != 1 {
+		t.Fatalf("list entries count: %v", len(entries))
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+
+	ctx := context.Background()
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/dir/old", Attr: filer2.Attr{Mode: 0644}})
+
+	// the changes are visible in the transaction, and discarded by the rollback
+	txCtx, err := store.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	store.DeleteEntry(txCtx, "/dir/old")
+	store.InsertEntry(txCtx, &filer2.Entry{FullPath: "/dir/new", Attr: filer2.Attr{Mode: 0644}})
+	entries, _ := store.ListDirectoryEntries(txCtx, "/dir", "", false, 100)
+	if len(entries) != 1 || entries[0].Name() != "new" {
+		t.Fatalf("list in transaction: %+v", entries)
+	}
+	store.RollbackTransaction(txCtx)
+
+	if _, err := store.FindEntry(ctx, "/dir/old"); err != nil {
+		t.Fatalf("find rolled back deletion: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/new"); err != filer2.ErrNotFound {
+		t.Fatalf("find rolled back insertion: %v", err)
+	}
+
+	txCtx, _ = store.BeginTransaction(ctx)
+	store.DeleteEntry(txCtx, "/dir/old")
+	if err := store.CommitTransaction(txCtx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/old"); err != filer2.ErrNotFound {
+		t.Fatalf("find committed deletion: %v", err)
+	}
+}
+
+func TestTransactionNotBlockingWrites(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BboltStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, name := range []string{"a", "c", "e"} {
+		store.InsertEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath("/dir/" + name), Attr: filer2.Attr{Mode: 0644}})
+	}
+
+	txCtx, _ := store.BeginTransaction(ctx)
+	store.InsertEntry(txCtx, &filer2.Entry{FullPath: "/dir/b", Attr: filer2.Attr{Mode: 0644}})
+	store.DeleteEntry(txCtx, "/dir/c")
+	store.UpdateEntry(txCtx, &filer2.Entry{FullPath: "/dir/e", Attr: filer2.Attr{Mode: 0600}})
+	store.InsertEntry(txCtx, &filer2.Entry{FullPath: "/dir/f", Attr: filer2.Attr{Mode: 0644}})
+
+	// the other writes are not blocked by the open transaction
+	done := make(chan error)
+	go func() {
+		done <- store.InsertEntry(ctx, &filer2.Entry{FullPath: "/dir/d", Attr: filer2.Attr{Mode: 0644}})
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("insert outside the transaction: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("insert outside the transaction blocked")
+	}
+
+	// the listing in the transaction merges its changes in order
+	names := func(ctx context.Context, startFileName string, limit int) (names string) {
+		entries, err := store.ListDirectoryEntries(ctx, "/dir", startFileName, false, limit)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		for _, entry := range entries {
+			names += entry.Name()
+		}
+		return
+	}
+	if listed := names(txCtx, "", 100); listed != "abdef" {
+		t.Errorf("listed in the transaction: %s", listed)
+	}
+	if listed := names(txCtx, "b", 2); listed != "de" {
+		t.Errorf("listed in the transaction after b: %s", listed)
+	}
+	if listed := names(ctx, "", 100); listed != "acde" {
+		t.Errorf("listed outside the transaction: %s", listed)
+	}
+
+	if err := store.CommitTransaction(txCtx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if listed := names(ctx, "", 100); listed != "abdef" {
+		t.Errorf("listed after commit: %s", listed)
+	}
+	if entry, err := store.FindEntry(ctx, "/dir/e"); err != nil || entry.Mode != 0600 {
+		t.Errorf("find updated entry %+v: %v", entry, err)
+	}
+}
@@ -33,6 +33,13 @@ type Entry struct {
 
 	// the following is for files
 	Chunks []*filer_pb.FileChunk `json:"chunks,omitempty"`
+
+	// extended attributes
+	Extended map[string][]byte `json:"extended,omitempty"`
+
+	// the hard links share the attributes, chunks and extended attributes
+	HardLinkId      HardLinkId `json:"hardLinkId,omitempty"`
+	HardLinkCounter int32      `json:"hardLinkCounter,omitempty"`
 }
 
 func (entry *Entry) Size() uint64 {
@@ -56,5 +63,9 @@ func (entry *Entry) ToProtoEntry() *filer_pb.Entry {
 		IsDirectory: entry.IsDirectory(),
 		Attributes:  EntryAttributeToPb(entry),
 		Chunks:      entry.Chunks,
+		Extended:    entry.Extended,
+
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 }
@@ -1,6 +1,7 @@
 package filer2
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"time"
@@ -13,6 +14,10 @@ func (entry *Entry) EncodeAttributesAndChunks() ([]byte, error) {
 	message := &filer_pb.Entry{
 		Attributes: EntryAttributeToPb(entry),
 		Chunks:     entry.Chunks,
+		Extended:   entry.Extended,
+
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 	return proto.Marshal(message)
 }
@@ -29,6 +34,11 @@ func (entry *Entry) DecodeAttributesAndChunks(blob []byte) error {
 
 	entry.Chunks = message.Chunks
 
+	entry.Extended = message.Extended
+
+	entry.HardLinkId = HardLinkId(message.HardLinkId)
+	entry.HardLinkCounter = message.HardLinkCounter
+
 	return nil
 }
 
@@ -89,5 +99,18 @@ func EqualEntry(a, b *Entry) bool {
 			return false
 		}
 	}
+
+	if !bytes.Equal(a.HardLinkId, b.HardLinkId) || a.HardLinkCounter != b.HardLinkCounter {
+		return false
+	}
+
+	if len(a.Extended) != len(b.Extended) {
+		return false
+	}
+	for k, v := range a.Extended {
+		if !bytes.Equal(v, b.Extended[k]) {
+			return false
+		}
+	}
 	return true
 }
@@ -0,0 +1,40 @@
+package filer2
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ExtendedHeader passes the extended attributes of the uploaded file to the filer,
+// so they are saved in the same write as the file, one "key=value" per header value
+const ExtendedHeader = "Seaweed-Extended"
+
+// SetExtendedHeader passes the extended attributes to the filer in the http requests, removing any set by others
+func SetExtendedHeader(header http.Header, extended map[string][]byte) {
+	header.Del(ExtendedHeader)
+	for key, value := range extended {
+		header.Add(ExtendedHeader, url.QueryEscape(key)+"="+url.QueryEscape(string(value)))
+	}
+}
+
+func ExtendedFromHeader(header http.Header) map[string][]byte {
+	values := header[ExtendedHeader]
+	if len(values) == 0 {
+		return nil
+	}
+	extended := make(map[string][]byte)
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, keyErr := url.QueryUnescape(parts[0])
+		v, valueErr := url.QueryUnescape(parts[1])
+		if keyErr != nil || valueErr != nil {
+			continue
+		}
+		extended[key] = []byte(v)
+	}
+	return extended
+}
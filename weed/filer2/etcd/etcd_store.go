@@ -0,0 +1,378 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	weed_util "gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+/*
+
+The entries are stored as "<key_prefix><directory>\x00<name>", so the entries of a directory
+are listed by a range scan in the order of the names.
+
+In a transaction, the changes are buffered and visible to the reads in the same transaction,
+and written in one etcd txn when committed. The txn compares the mod revisions of the keys read
+in the transaction, and checks no key is added to the listed ranges, so the commit fails if
+the entries read are changed meanwhile.
+A transaction with more changes or reads than the etcd --max-txn-ops, configured as max_txn_ops,
+fails to commit instead of being split into several txns.
+
+*/
+
+const (
+	DIR_FILE_SEPARATOR = byte(0x00)
+
+	// the default --max-txn-ops of etcd
+	defaultMaxTxnOps = 128
+)
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &EtcdStore{})
+}
+
+type EtcdStore struct {
+	client    *clientv3.Client
+	keyPrefix string
+	timeout   time.Duration
+	maxTxnOps int
+}
+
+func (store *EtcdStore) GetName() string {
+	return "etcd"
+}
+
+func (store *EtcdStore) Initialize(configuration weed_util.Configuration) (err error) {
+	timeout, err := time.ParseDuration(configuration.GetString("timeout"))
+	if err != nil {
+		return fmt.Errorf("parse etcd timeout %s: %v", configuration.GetString("timeout"), err)
+	}
+	return store.initialize(
+		configuration.GetStringSlice("servers"),
+		configuration.GetString("username"),
+		configuration.GetString("password"),
+		configuration.GetString("key_prefix"),
+		timeout,
+		configuration.GetInt("max_txn_ops"),
+	)
+}
+
+func (store *EtcdStore) initialize(servers []string, username, password, keyPrefix string, timeout time.Duration, maxTxnOps int) (err error) {
+	glog.Infof("filer store etcd: %v", servers)
+
+	store.keyPrefix = keyPrefix
+	store.timeout = timeout
+	store.maxTxnOps = maxTxnOps
+	if store.maxTxnOps <= 0 {
+		store.maxTxnOps = defaultMaxTxnOps
+	}
+
+	store.client, err = clientv3.New(clientv3.Config{
+		Endpoints:   servers,
+		Username:    username,
+		Password:    password,
+		DialTimeout: timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("connect to etcd %v: %v", servers, err)
+	}
+
+	return nil
+}
+
+type etcdTxnKey struct{}
+
+// etcdTxn buffers the changes of a transaction, a nil value is a deletion,
+// and the comparisons of the reads checked when committed
+type etcdTxn struct {
+	changes  map[string][]byte
+	keys     []string
+	compares []clientv3.Cmp
+	read     map[string]bool
+}
+
+func newEtcdTxn() *etcdTxn {
+	return &etcdTxn{changes: make(map[string][]byte), read: make(map[string]bool)}
+}
+
+// readKey checks the key is not changed since read at the mod revision, 0 if not found
+func (txn *etcdTxn) readKey(key string, modRevision int64) {
+	if txn.read[key] {
+		return
+	}
+	txn.read[key] = true
+	txn.compares = append(txn.compares, clientv3.Compare(clientv3.ModRevision(key), "=", modRevision))
+}
+
+// readRange checks no key in [start, end) is created or updated after the revision
+func (txn *etcdTxn) readRange(start, end string, revision int64) {
+	txn.compares = append(txn.compares, clientv3.Compare(clientv3.ModRevision(start), "<", revision+1).WithRange(end))
+}
+
+func (txn *etcdTxn) put(key string, value []byte) {
+	if _, found := txn.changes[key]; !found {
+		txn.keys = append(txn.keys, key)
+	}
+	txn.changes[key] = value
+}
+
+func getTxn(ctx context.Context) *etcdTxn {
+	txn, _ := ctx.Value(etcdTxnKey{}).(*etcdTxn)
+	return txn
+}
+
+func (store *EtcdStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, etcdTxnKey{}, newEtcdTxn()), nil
+}
+
+func (store *EtcdStore) CommitTransaction(ctx context.Context) error {
+	txn := getTxn(ctx)
+	if txn == nil {
+		return nil
+	}
+
+	defer txn.reset()
+
+	if len(txn.keys) > store.maxTxnOps || len(txn.compares) > store.maxTxnOps {
+		return fmt.Errorf("commit %d changes and %d reads: more than max_txn_ops %d in one etcd txn",
+			len(txn.keys), len(txn.compares), store.maxTxnOps)
+	}
+	if len(txn.keys) == 0 {
+		return nil
+	}
+
+	var ops []clientv3.Op
+	for _, key := range txn.keys {
+		if value := txn.changes[key]; value != nil {
+			ops = append(ops, clientv3.OpPut(key, string(value)))
+		} else {
+			ops = append(ops, clientv3.OpDelete(key))
+		}
+	}
+	return store.withTimeout(ctx, func(ctx context.Context) error {
+		resp, err := store.client.Txn(ctx).If(txn.compares...).Then(ops...).Commit()
+		if err != nil {
+			return fmt.Errorf("commit %d changes: %v", len(ops), err)
+		}
+		if !resp.Succeeded {
+			return fmt.Errorf("commit %d changes: the entries read are changed by others", len(ops))
+		}
+		return nil
+	})
+}
+
+func (store *EtcdStore) RollbackTransaction(ctx context.Context) error {
+	if txn := getTxn(ctx); txn != nil {
+		txn.reset()
+	}
+	return nil
+}
+
+func (txn *etcdTxn) reset() {
+	txn.changes, txn.keys = make(map[string][]byte), nil
+	txn.compares, txn.read = nil, make(map[string]bool)
+}
+
+func (store *EtcdStore) withTimeout(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, store.timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+func (store *EtcdStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+	key := store.genKey(entry.DirAndName())
+
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+
+	if txn := getTxn(ctx); txn != nil {
+		txn.put(key, value)
+		return nil
+	}
+
+	if err = store.withTimeout(ctx, func(ctx context.Context) error {
+		_, err := store.client.Put(ctx, key, string(value))
+		return err
+	}); err != nil {
+		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
+	}
+
+	return nil
+}
+
+func (store *EtcdStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+
+	return store.InsertEntry(ctx, entry)
+}
+
+func (store *EtcdStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+	key := store.genKey(fullpath.DirAndName())
+
+	var data []byte
+	var found bool
+	txn := getTxn(ctx)
+	if txn != nil {
+		data, found = txn.changes[key]
+	}
+
+	if !found {
+		if err = store.withTimeout(ctx, func(ctx context.Context) error {
+			resp, err := store.client.Get(ctx, key)
+			if err != nil {
+				return err
+			}
+			var modRevision int64
+			if len(resp.Kvs) > 0 {
+				data, modRevision = resp.Kvs[0].Value, resp.Kvs[0].ModRevision
+			}
+			if txn != nil {
+				txn.readKey(key, modRevision)
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("get %s : %v", fullpath, err)
+		}
+	}
+
+	if data == nil {
+		return nil, filer2.ErrNotFound
+	}
+
+	entry = &filer2.Entry{
+		FullPath: fullpath,
+	}
+	err = entry.DecodeAttributesAndChunks(data)
+	if err != nil {
+		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+	}
+
+	return entry, nil
+}
+
+func (store *EtcdStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
+	key := store.genKey(fullpath.DirAndName())
+
+	if txn := getTxn(ctx); txn != nil {
+		txn.put(key, nil)
+		return nil
+	}
+
+	if err = store.withTimeout(ctx, func(ctx context.Context) error {
+		_, err := store.client.Delete(ctx, key)
+		return err
+	}); err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *EtcdStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int) (entries []*filer2.Entry, err error) {
+
+	directoryPrefix := store.genDirectoryKeyPrefix(fullpath, "")
+	startKey := store.genDirectoryKeyPrefix(fullpath, startFileName)
+
+	// the buffered changes of the transaction in the range
+	changes := make(map[string][]byte)
+	txn := getTxn(ctx)
+	if txn != nil {
+		for key, value := range txn.changes {
+			if strings.HasPrefix(key, directoryPrefix) && key >= startKey {
+				changes[key] = value
+			}
+		}
+	}
+
+	// fetch enough entries to cover the skipped start file and the buffered deletions
+	fetchLimit := int64(limit + 1 + len(changes))
+
+	values := make(map[string][]byte)
+	if err = store.withTimeout(ctx, func(ctx context.Context) error {
+		resp, err := store.client.Get(ctx, startKey,
+			clientv3.WithRange(clientv3.GetPrefixRangeEnd(directoryPrefix)),
+			clientv3.WithLimit(fetchLimit),
+		)
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			values[string(kv.Key)] = kv.Value
+			if txn != nil {
+				txn.readKey(string(kv.Key), kv.ModRevision)
+			}
+		}
+		rangeEnd := clientv3.GetPrefixRangeEnd(directoryPrefix)
+		// the fetched range ends at the last key, the buffered changes after it are listed in the next pages
+		if resp.More && len(resp.Kvs) > 0 {
+			lastKey := string(resp.Kvs[len(resp.Kvs)-1].Key)
+			for key := range changes {
+				if key > lastKey {
+					delete(changes, key)
+				}
+			}
+			rangeEnd = lastKey + "\x00"
+		}
+		if txn != nil {
+			txn.readRange(startKey, rangeEnd, resp.Header.Revision)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list %s : %v", fullpath, err)
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(values, key)
+		} else {
+			values[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fileName := key[len(directoryPrefix):]
+		if fileName == "" {
+			continue
+		}
+		if fileName == startFileName && !inclusive {
+			continue
+		}
+		limit--
+		if limit < 0 {
+			break
+		}
+		entry := &filer2.Entry{
+			FullPath: filer2.NewFullPath(string(fullpath), fileName),
+		}
+		if decodeErr := entry.DecodeAttributesAndChunks(values[key]); decodeErr != nil {
+			err = decodeErr
+			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
+			break
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, err
+}
+
+func (store *EtcdStore) genKey(dirPath, fileName string) string {
+	return store.keyPrefix + dirPath + string(DIR_FILE_SEPARATOR) + fileName
+}
+
+func (store *EtcdStore) genDirectoryKeyPrefix(fullpath filer2.FullPath, startFileName string) string {
+	return store.keyPrefix + string(fullpath) + string(DIR_FILE_SEPARATOR) + startFileName
+}
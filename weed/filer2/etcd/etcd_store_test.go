@@ -0,0 +1,255 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/embed"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+)
+
+func startEmbeddedEtcd(t *testing.T, dir string) (*embed.Etcd, string) {
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	clientUrl, peerUrl := freeUrl(t), freeUrl(t)
+	cfg.LCUrls, cfg.ACUrls = []url.URL{clientUrl}, []url.URL{clientUrl}
+	cfg.LPUrls, cfg.APUrls = []url.URL{peerUrl}, []url.URL{peerUrl}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+	cfg.MaxTxnOps = 512
+
+	server, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatalf("start etcd: %v", err)
+	}
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(time.Minute):
+		server.Close()
+		t.Fatalf("etcd is not ready")
+	}
+	return server, clientUrl.Host
+}
+
+func freeUrl(t *testing.T) url.URL {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	return url.URL{Scheme: "http", Host: listener.Addr().String()}
+}
+
+func TestCreateAndFind(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	server, address := startEmbeddedEtcd(t, dir)
+	defer server.Close()
+
+	filer := filer2.NewFiler(nil, nil)
+	store := &EtcdStore{}
+	if err := store.initialize([]string{address}, "", "", "seaweedfs.", 10*time.Second, 0); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	ctx := context.Background()
+
+	fullpath := filer2.FullPath("/home/chris/this/is/one/file1.jpg")
+	entry1 := &filer2.Entry{
+		FullPath: fullpath,
+		Attr: filer2.Attr{
+			Mode: 0440,
+			Uid:  1234,
+			Gid:  5678,
+		},
+	}
+	if err := filer.CreateEntry(ctx, entry1); err != nil {
+		t.Fatalf("create entry %v: %v", entry1.FullPath, err)
+	}
+
+	entry, err := filer.FindEntry(ctx, fullpath)
+	if err != nil {
+		t.Fatalf("find entry: %v", err)
+	}
+	if entry.FullPath != entry1.FullPath || entry.Uid != 1234 || entry.Gid != 5678 {
+		t.Fatalf("find wrong entry: %+v", entry)
+	}
+
+	// the entries of other directories with the same prefix are not listed
+	filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/home/chris/this/is/one1/file2.jpg", Attr: filer2.Attr{Mode: 0440}})
+	entries, _ := filer.ListDirectoryEntries(ctx, "/home/chris/this/is/one", "", false, 100)
+	if len(entries) != 1 {
+		t.Fatalf("list entries count: %v", len(entries))
+	}
+	entries, _ = filer.ListDirectoryEntries(ctx, "/home/chris/this/is", "", false, 100)
+	if len(entries) != 2 {
+		t.Fatalf("list entries count: %v", len(entries))
+	}
+	entries, _ = filer.ListDirectoryEntries(ctx, "/", "", false, 100)
+	if len(entries) != 1 {
+		t.Fatalf("list entries count: %v", len(entries))
+	}
+}
+
+func TestListAndTransaction(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	server, address := startEmbeddedEtcd(t, dir)
+	defer server.Close()
+
+	store := &EtcdStore{}
+	if err := store.initialize([]string{address}, "", "", "", 10*time.Second, 0); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+
+	ctx := context.Background()
+	insert := func(ctx context.Context, p string) {
+		if err := store.InsertEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath(p), Attr: filer2.Attr{Mode: 0644}}); err != nil {
+			t.Fatalf("insert %s: %v", p, err)
+		}
+	}
+	names := func(entries []*filer2.Entry) (names string) {
+		for _, entry := range entries {
+			names += entry.Name() + " "
+		}
+		return
+	}
+
+	for i := 0; i < 200; i++ {
+		insert(ctx, fmt.Sprintf("/dir/file%03d", i))
+	}
+
+	entries, err := store.ListDirectoryEntries(ctx, "/dir", "file010", false, 3)
+	if err != nil || names(entries) != "file011 file012 file013 " {
+		t.Fatalf("list after file010: %s %v", names(entries), err)
+	}
+	entries, _ = store.ListDirectoryEntries(ctx, "/dir", "file010", true, 2)
+	if names(entries) != "file010 file011 " {
+		t.Fatalf("list from file010: %s", names(entries))
+	}
+
+	// the changes in the transaction are visible in the transaction only
+	txCtx, _ := store.BeginTransaction(ctx)
+	store.DeleteEntry(txCtx, "/dir/file001")
+	store.DeleteEntry(txCtx, "/dir/file002")
+	insert(txCtx, "/dir/file001a")
+	entries, _ = store.ListDirectoryEntries(txCtx, "/dir", "", false, 3)
+	if names(entries) != "file000 file001a file003 " {
+		t.Fatalf("list in transaction: %s", names(entries))
+	}
+	if _, err := store.FindEntry(txCtx, "/dir/file001"); err != filer2.ErrNotFound {
+		t.Fatalf("find deleted in transaction: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/file001"); err != nil {
+		t.Fatalf("find outside transaction: %v", err)
+	}
+	store.RollbackTransaction(txCtx)
+	if _, err := store.FindEntry(ctx, "/dir/file001a"); err != filer2.ErrNotFound {
+		t.Fatalf("find rolled back: %v", err)
+	}
+
+	// more changes than max_txn_ops are not committed partially
+	deleteAll := func(ctx context.Context) {
+		for i := 0; i < 200; i++ {
+			store.DeleteEntry(ctx, filer2.FullPath(fmt.Sprintf("/dir/file%03d", i)))
+		}
+		insert(ctx, "/dir/last")
+	}
+	txCtx, _ = store.BeginTransaction(ctx)
+	deleteAll(txCtx)
+	if err := store.CommitTransaction(txCtx); err == nil {
+		t.Fatalf("commit more changes than max_txn_ops")
+	}
+	entries, _ = store.ListDirectoryEntries(ctx, "/dir", "", false, 1000)
+	if len(entries) != 200 {
+		t.Fatalf("list after failed commit: %d entries", len(entries))
+	}
+
+	// all the changes are committed in one txn, with a larger max_txn_ops
+	store.maxTxnOps = 512
+	txCtx, _ = store.BeginTransaction(ctx)
+	deleteAll(txCtx)
+	if err := store.CommitTransaction(txCtx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	entries, _ = store.ListDirectoryEntries(ctx, "/dir", "", false, 100)
+	if names(entries) != "last " {
+		t.Fatalf("list after commit: %s", names(entries))
+	}
+}
+
+func TestTransactionConflict(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	server, address := startEmbeddedEtcd(t, dir)
+	defer server.Close()
+
+	store := &EtcdStore{}
+	if err := store.initialize([]string{address}, "", "", "", 10*time.Second, 0); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+
+	ctx := context.Background()
+	insert := func(ctx context.Context, p string, uid uint32) {
+		if err := store.InsertEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath(p), Attr: filer2.Attr{Mode: 0644, Uid: uid}}); err != nil {
+			t.Fatalf("insert %s: %v", p, err)
+		}
+	}
+	insert(ctx, "/dir/a", 1)
+	insert(ctx, "/dir/b", 1)
+
+	// the entry read is updated by others
+	txCtx, _ := store.BeginTransaction(ctx)
+	store.FindEntry(txCtx, "/dir/a")
+	insert(txCtx, "/dir/c", 2)
+	insert(ctx, "/dir/a", 3)
+	if err := store.CommitTransaction(txCtx); err == nil {
+		t.Errorf("commit after the entry read is updated")
+	}
+
+	// the entry not found is created by others
+	txCtx, _ = store.BeginTransaction(ctx)
+	store.FindEntry(txCtx, "/dir/d")
+	insert(txCtx, "/dir/c", 2)
+	insert(ctx, "/dir/d", 3)
+	if err := store.CommitTransaction(txCtx); err == nil {
+		t.Errorf("commit after the entry not found is created")
+	}
+
+	// the listed directory gets a new entry, or loses one
+	for _, change := range []func(){
+		func() { insert(ctx, "/dir/e", 3) },
+		func() { store.DeleteEntry(ctx, "/dir/b") },
+	} {
+		txCtx, _ = store.BeginTransaction(ctx)
+		store.ListDirectoryEntries(txCtx, "/dir", "", false, 100)
+		insert(txCtx, "/dir/c", 2)
+		change()
+		if err := store.CommitTransaction(txCtx); err == nil {
+			t.Errorf("commit after the listed directory is changed")
+		}
+	}
+	if _, err := store.FindEntry(ctx, "/dir/c"); err != filer2.ErrNotFound {
+		t.Fatalf("find the entry of the conflicted transactions: %v", err)
+	}
+
+	// the reads not changed by others
+	txCtx, _ = store.BeginTransaction(ctx)
+	store.FindEntry(txCtx, "/dir/a")
+	store.ListDirectoryEntries(txCtx, "/dir", "", false, 100)
+	insert(txCtx, "/dir/c", 2)
+	insert(ctx, "/other/f", 3)
+	if err := store.CommitTransaction(txCtx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if entry, err := store.FindEntry(ctx, "/dir/c"); err != nil || entry.Uid != 2 {
+		t.Fatalf("find the committed entry: %v", err)
+	}
+}
@@ -71,6 +71,7 @@ type ChunkView struct {
 	Size        uint64
 	LogicOffset int64
 	IsFullChunk bool
+	ChunkSize   uint64
 }
 
 func ViewFromChunks(chunks []*filer_pb.FileChunk, offset int64, size int) (views []*ChunkView) {
@@ -86,16 +87,18 @@ func ViewFromVisibleIntervals(visibles []VisibleInterval, offset int64, size int
 	stop := offset + int64(size)
 
 	for _, chunk := range visibles {
-		if chunk.start <= offset && offset < chunk.stop && offset < stop {
-			isFullChunk := chunk.isFullChunk && chunk.start == offset && chunk.stop <= stop
+		// the holes between the visible intervals are skipped
+		viewStart, viewStop := max(chunk.start, offset), min(chunk.stop, stop)
+		if viewStart < viewStop {
+			isFullChunk := chunk.isFullChunk && chunk.start == viewStart && chunk.stop <= stop
 			views = append(views, &ChunkView{
 				FileId:      chunk.fileId,
-				Offset:      offset - chunk.start, // offset is the data starting location in this file id
-				Size:        uint64(min(chunk.stop, stop) - offset),
-				LogicOffset: offset,
+				Offset:      viewStart - chunk.start, // offset is the data starting location in this file id
+				Size:        uint64(viewStop - viewStart),
+				LogicOffset: viewStart,
 				IsFullChunk: isFullChunk,
+				ChunkSize:   chunk.chunkSize,
 			})
-			offset = min(chunk.stop, stop)
 		}
 	}
 
@@ -126,6 +129,7 @@ func MergeIntoVisibles(visibles, newVisibles []VisibleInterval, chunk *filer_pb.
 		chunk.FileId,
 		chunk.Mtime,
 		true,
+		chunk.Size,
 	)
 
 	length := len(visibles)
@@ -146,6 +150,7 @@ func MergeIntoVisibles(visibles, newVisibles []VisibleInterval, chunk *filer_pb.
 				v.fileId,
 				v.modifiedTime,
 				false,
+				v.chunkSize,
 			))
 		}
 		chunkStop := chunk.Offset + int64(chunk.Size)
@@ -156,6 +161,7 @@ func MergeIntoVisibles(visibles, newVisibles []VisibleInterval, chunk *filer_pb.
 				v.fileId,
 				v.modifiedTime,
 				false,
+				v.chunkSize,
 			))
 		}
 		if chunkStop <= v.start || v.stop <= chunk.Offset {
@@ -208,18 +214,27 @@ type VisibleInterval struct {
 	modifiedTime int64
 	fileId       string
 	isFullChunk  bool
+	chunkSize    uint64
 }
 
-func newVisibleInterval(start, stop int64, fileId string, modifiedTime int64, isFullChunk bool) VisibleInterval {
+func newVisibleInterval(start, stop int64, fileId string, modifiedTime int64, isFullChunk bool, chunkSize uint64) VisibleInterval {
 	return VisibleInterval{
 		start:        start,
 		stop:         stop,
 		fileId:       fileId,
 		modifiedTime: modifiedTime,
 		isFullChunk:  isFullChunk,
+		chunkSize:    chunkSize,
 	}
 }
 
+func max(x, y int64) int64 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
 func min(x, y int64) int64 {
 	if x <= y {
 		return x
@@ -1,6 +1,7 @@
 package filer2
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -23,10 +24,13 @@ var (
 
 type Filer struct {
 	store              FilerStore
+	hardLinks          *hardLinkStore
 	directoryCache     *ccache.Cache
 	MasterClient       *wdclient.MasterClient
 	fileIdDeletionChan chan string
 	GrpcDialOption     grpc.DialOption
+	MetaLog            *MetaLog
+	quotas             quotas
 }
 
 func NewFiler(masters []string, grpcDialOption grpc.DialOption) *Filer {
@@ -35,6 +39,7 @@ func NewFiler(masters []string, grpcDialOption grpc.DialOption) *Filer {
 		MasterClient:       wdclient.NewMasterClient(context.Background(), grpcDialOption, "filer", masters),
 		fileIdDeletionChan: make(chan string, 4096),
 		GrpcDialOption:     grpcDialOption,
+		quotas:             quotas{refreshInterval: DefaultQuotaRefreshInterval},
 	}
 
 	go f.loopProcessingDeletion()
@@ -43,7 +48,16 @@ func NewFiler(masters []string, grpcDialOption grpc.DialOption) *Filer {
 }
 
 func (f *Filer) SetStore(store FilerStore) {
-	f.store = store
+	f.hardLinks = &hardLinkStore{FilerStore: store}
+	f.store = f.hardLinks
+	if err := f.loadQuotas(context.Background()); err != nil {
+		glog.Errorf("load quotas: %v", err)
+	}
+}
+
+// Store returns the store of the filer, to save the records not visible as entries
+func (f *Filer) Store() FilerStore {
+	return f.store
 }
 
 func (f *Filer) DisableDirectoryCache() {
@@ -76,6 +90,8 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
 		return nil
 	}
 
+	id := IdentityFromContext(ctx)
+
 	dirParts := strings.Split(string(entry.FullPath), "/")
 
 	// fmt.Printf("directory parts: %+v\n", dirParts)
@@ -92,7 +108,7 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
 		// not found, check the store directly
 		if dirEntry == nil {
 			glog.V(4).Infof("find uncached directory: %s", dirPath)
-			dirEntry, _ = f.FindEntry(ctx, FullPath(dirPath))
+			dirEntry, _ = f.findEntry(ctx, FullPath(dirPath))
 		} else {
 			glog.V(4).Infof("found cached directory: %s", dirPath)
 		}
@@ -100,6 +116,10 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
 		// no such existing directory
 		if dirEntry == nil {
 
+			if err := checkParentWritable(id, lastDirectoryEntry, FullPath(dirPath), "mkdir"); err != nil {
+				return err
+			}
+
 			// create the directory
 			now := time.Now()
 
@@ -113,11 +133,13 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
 					Gid:    entry.Gid,
 				},
 			}
+			setOwner(id, lastDirectoryEntry, dirEntry)
+			inheritAcl(lastDirectoryEntry, dirEntry)
 
 			glog.V(2).Infof("create directory: %s %v", dirPath, dirEntry.Mode)
 			mkdirErr := f.store.InsertEntry(ctx, dirEntry)
 			if mkdirErr != nil {
-				if _, err := f.FindEntry(ctx, FullPath(dirPath)); err == ErrNotFound {
+				if _, err := f.findEntry(ctx, FullPath(dirPath)); err == ErrNotFound {
 					return fmt.Errorf("mkdir %s: %v", dirPath, mkdirErr)
 				}
 			} else {
@@ -126,15 +148,15 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
 
 		} else if !dirEntry.IsDirectory() {
 			return fmt.Errorf("%s is a file", dirPath)
+		} else if !dirEntry.hasPermission(id, PermExec) {
+			return permissionDenied(FullPath(dirPath), id, "search")
 		}
 
 		// cache the directory entry
 		f.cacheSetDirectory(dirPath, dirEntry, i)
 
-		// remember the direct parent directory entry
-		if i == len(dirParts)-1 {
-			lastDirectoryEntry = dirEntry
-		}
+		// remember the last directory entry, which is the direct parent directory entry after the loop
+		lastDirectoryEntry = dirEntry
 
 	}
 
@@ -142,34 +164,72 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
 		return fmt.Errorf("parent folder not found: %v", entry.FullPath)
 	}
 
-	/*
-		if !hasWritePermission(lastDirectoryEntry, entry) {
-			glog.V(0).Infof("directory %s: %v, entry: uid=%d gid=%d",
-				lastDirectoryEntry.FullPath, lastDirectoryEntry.Attr, entry.Uid, entry.Gid)
-			return fmt.Errorf("no write permission in folder %v", lastDirectoryEntry.FullPath)
-		}
-	*/
-
-	oldEntry, _ := f.FindEntry(ctx, entry.FullPath)
+	// the parent directories are already checked to be searchable
+	oldEntry, _ := f.findEntry(ctx, entry.FullPath)
 
 	if oldEntry == nil {
-		if err := f.store.InsertEntry(ctx, entry); err != nil {
-			return fmt.Errorf("insert entry %s: %v", entry.FullPath, err)
+		if err := checkParentWritable(id, lastDirectoryEntry, entry.FullPath, "create"); err != nil {
+			return err
+		}
+		setOwner(id, lastDirectoryEntry, entry)
+		inheritAcl(lastDirectoryEntry, entry)
+		deltaBytes, deltaFiles := usageDelta(nil, entry)
+		if err := f.changeWithQuota(ctx, entry.FullPath, deltaBytes, deltaFiles, func() error {
+			if err := f.store.InsertEntry(ctx, entry); err != nil {
+				return fmt.Errorf("insert entry %s: %v", entry.FullPath, err)
+			}
+			return nil
+		}); err != nil {
+			return err
 		}
 	} else {
-		if err := f.UpdateEntry(ctx, oldEntry, entry); err != nil {
+		// overwriting needs the write permission, and keeps the owner
+		if id != nil && !id.IsRoot() {
+			if !oldEntry.hasPermission(id, PermWrite) {
+				return permissionDenied(entry.FullPath, id, "write")
+			}
+			keepPermissions(id, oldEntry, entry)
+		}
+		if err := f.updateEntry(ctx, oldEntry, entry); err != nil {
 			return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
 		}
 	}
 
 	f.NotifyUpdateEvent(oldEntry, entry, true)
 
+	// the entry replaces one of the hard links
+	if oldEntry != nil && len(oldEntry.HardLinkId) > 0 && !bytes.Equal(oldEntry.HardLinkId, entry.HardLinkId) {
+		isLastLink, err := f.unlinkHardLink(ctx, oldEntry.HardLinkId)
+		if err != nil {
+			glog.Errorf("unlink %s: %v", entry.FullPath, err)
+		}
+		if !isLastLink {
+			return nil
+		}
+	}
+
 	f.deleteChunksIfNotNew(oldEntry, entry)
 
 	return nil
 }
 
 func (f *Filer) UpdateEntry(ctx context.Context, oldEntry, entry *Entry) (err error) {
+
+	if id := IdentityFromContext(ctx); id != nil && !id.IsRoot() {
+		if oldEntry == nil {
+			if oldEntry, err = f.FindEntry(ctx, entry.FullPath); err != nil {
+				return err
+			}
+		}
+		if err = checkUpdate(id, oldEntry, entry); err != nil {
+			return err
+		}
+	}
+
+	return f.updateEntry(ctx, oldEntry, entry)
+}
+
+func (f *Filer) updateEntry(ctx context.Context, oldEntry, entry *Entry) (err error) {
 	if oldEntry != nil {
 		if oldEntry.IsDirectory() && !entry.IsDirectory() {
 			return fmt.Errorf("existing %s is a directory", entry.FullPath)
@@ -178,20 +238,64 @@ func (f *Filer) UpdateEntry(ctx context.Context, oldEntry, entry *Entry) (err er
 			return fmt.Errorf("existing %s is a file", entry.FullPath)
 		}
 	}
-	return f.store.UpdateEntry(ctx, entry)
+
+	if entry.IsDirectory() {
+		f.cacheDelDirectory(string(entry.FullPath))
+	}
+
+	if !f.hasQuota(entry.FullPath) {
+		return f.store.UpdateEntry(ctx, entry)
+	}
+
+	if oldEntry == nil {
+		oldEntry, _ = f.FindEntry(ctx, entry.FullPath)
+	}
+	deltaBytes, deltaFiles := usageDelta(oldEntry, entry)
+	return f.changeWithQuota(ctx, entry.FullPath, deltaBytes, deltaFiles, func() error {
+		return f.store.UpdateEntry(ctx, entry)
+	})
 }
 
+// FindEntry finds the entry, if the identity of the context can search all its parent directories
 func (f *Filer) FindEntry(ctx context.Context, p FullPath) (entry *Entry, err error) {
+	if id := IdentityFromContext(ctx); id != nil && !id.IsRoot() {
+		if err = f.checkSearchable(ctx, id, p); err != nil {
+			return nil, err
+		}
+	}
+	return f.findEntry(ctx, p)
+}
+
+// checkSearchable checks the identity has the exec permission on all the parent directories of the path, from the root
+func (f *Filer) checkSearchable(ctx context.Context, id *Identity, p FullPath) error {
+	dirs := ancestorsOf(p, false)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		dirEntry := f.cacheGetDirectory(string(dirs[i]))
+		if dirEntry == nil {
+			var err error
+			if dirEntry, err = f.findEntry(ctx, dirs[i]); err != nil {
+				return err
+			}
+		}
+		if !dirEntry.hasPermission(id, PermExec) {
+			return permissionDenied(dirs[i], id, "search")
+		}
+	}
+	return nil
+}
+
+func (f *Filer) findEntry(ctx context.Context, p FullPath) (entry *Entry, err error) {
 
 	now := time.Now()
 
 	if string(p) == "/" {
+		// everyone can create entries in the root directory, and only remove the owned ones
 		return &Entry{
 			FullPath: p,
 			Attr: Attr{
 				Mtime:  now,
 				Crtime: now,
-				Mode:   os.ModeDir | 0755,
+				Mode:   os.ModeDir | os.ModeSticky | 0777,
 				Uid:    OS_UID,
 				Gid:    OS_GID,
 			},
@@ -206,7 +310,25 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p FullPath, isRecurs
 		return err
 	}
 
+	if id := IdentityFromContext(ctx); id != nil && p != "/" {
+		dir, _ := p.DirAndName()
+		dirEntry, err := f.FindEntry(ctx, FullPath(dir))
+		if err != nil {
+			return fmt.Errorf("find %s: %v", dir, err)
+		}
+		if err = checkParentWritable(id, dirEntry, p, "delete"); err != nil {
+			return err
+		}
+		if err = checkSticky(id, dirEntry, entry, p, "delete"); err != nil {
+			return err
+		}
+	}
+
 	if entry.IsDirectory() {
+		// removing the entries in the directory needs to list and search it, and write to it
+		if id := IdentityFromContext(ctx); isRecursive && !entry.hasPermission(id, PermRead|PermWrite|PermExec) {
+			return permissionDenied(p, id, "delete entries in")
+		}
 		limit := int(1)
 		if isRecursive {
 			limit = math.MaxInt32
@@ -214,7 +336,7 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p FullPath, isRecurs
 		lastFileName := ""
 		includeLastFile := false
 		for limit > 0 {
-			entries, err := f.ListDirectoryEntries(ctx, p, lastFileName, includeLastFile, 1024)
+			entries, err := f.listDirectoryEntries(ctx, p, lastFileName, includeLastFile, 1024)
 			if err != nil {
 				return fmt.Errorf("list folder %s: %v", p, err)
 			}
@@ -244,10 +366,22 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p FullPath, isRecurs
 
 		f.cacheDelDirectory(string(p))
 
+		if err := f.RemoveQuota(ctx, p); err != nil {
+			glog.Errorf("remove quota of %s: %v", p, err)
+		}
+
 	}
 
 	if shouldDeleteChunks {
-		f.DeleteChunks(entry.Chunks)
+		isLastLink := true
+		if len(entry.HardLinkId) > 0 {
+			if isLastLink, err = f.unlinkHardLink(ctx, entry.HardLinkId); err != nil {
+				return fmt.Errorf("unlink %s: %v", p, err)
+			}
+		}
+		if isLastLink {
+			f.DeleteChunks(entry.Chunks)
+		}
 	}
 
 	if p == "/" {
@@ -255,15 +389,31 @@ func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p FullPath, isRecurs
 	}
 	glog.V(3).Infof("deleting entry %v", p)
 
-	f.NotifyUpdateEvent(entry, nil, shouldDeleteChunks)
+	f.notifyUpdateEvent(entry, nil, shouldDeleteChunks, OriginFromContext(ctx))
 
-	return f.store.DeleteEntry(ctx, p)
+	deltaBytes, deltaFiles := usageDelta(entry, nil)
+	return f.changeWithQuota(ctx, p, deltaBytes, deltaFiles, func() error {
+		return f.store.DeleteEntry(ctx, p)
+	})
 }
 
 func (f *Filer) ListDirectoryEntries(ctx context.Context, p FullPath, startFileName string, inclusive bool, limit int) ([]*Entry, error) {
 	if strings.HasSuffix(string(p), "/") && len(p) > 1 {
 		p = p[0 : len(p)-1]
 	}
+	if id := IdentityFromContext(ctx); id != nil {
+		dirEntry, err := f.FindEntry(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if !dirEntry.hasPermission(id, PermRead) {
+			return nil, permissionDenied(p, id, "list")
+		}
+	}
+	return f.listDirectoryEntries(ctx, p, startFileName, inclusive, limit)
+}
+
+func (f *Filer) listDirectoryEntries(ctx context.Context, p FullPath, startFileName string, inclusive bool, limit int) ([]*Entry, error) {
 	return f.store.ListDirectoryEntries(ctx, p, startFileName, inclusive, limit)
 }
 
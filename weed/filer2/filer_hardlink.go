@@ -0,0 +1,229 @@
+package filer2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+)
+
+/*
+
+A hard link is stored as an inode-like record keyed by the hard link id,
+which keeps the attributes, chunks, extended attributes and the link counter once.
+Each linked path only keeps a pointer entry with the hard link id.
+
+The link counter is owned by the filer. It is only changed by LinkEntry and by removing a link,
+and the counter of the entries from the clients is ignored.
+
+The records are kept in the store under HardLinkDir, which has no directory entry
+and is not visible when listing the root directory.
+
+*/
+
+const HardLinkDir = "/.hardlinks"
+
+type HardLinkId []byte
+
+func NewHardLinkId() HardLinkId {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return id
+}
+
+func (id HardLinkId) String() string {
+	return hex.EncodeToString(id)
+}
+
+func (id HardLinkId) recordPath() FullPath {
+	return NewFullPath(HardLinkDir, id.String())
+}
+
+// hardLinkStore resolves the pointer entries of the hard links to the shared records
+type hardLinkStore struct {
+	FilerStore
+	recordLock sync.Mutex // serializes the updates of the records in this filer
+}
+
+func (store *hardLinkStore) InsertEntry(ctx context.Context, entry *Entry) error {
+	if len(entry.HardLinkId) == 0 {
+		return store.FilerStore.InsertEntry(ctx, entry)
+	}
+	if err := store.saveHardLinkRecord(ctx, entry); err != nil {
+		return err
+	}
+	return store.FilerStore.InsertEntry(ctx, toHardLinkPointer(entry))
+}
+
+func (store *hardLinkStore) UpdateEntry(ctx context.Context, entry *Entry) error {
+	if len(entry.HardLinkId) == 0 {
+		return store.FilerStore.UpdateEntry(ctx, entry)
+	}
+	if err := store.saveHardLinkRecord(ctx, entry); err != nil {
+		return err
+	}
+	return store.FilerStore.UpdateEntry(ctx, toHardLinkPointer(entry))
+}
+
+func (store *hardLinkStore) FindEntry(ctx context.Context, fullpath FullPath) (*Entry, error) {
+	entry, err := store.FilerStore.FindEntry(ctx, fullpath)
+	if err != nil || len(entry.HardLinkId) == 0 {
+		return entry, err
+	}
+	return store.resolveHardLink(ctx, entry)
+}
+
+func (store *hardLinkStore) ListDirectoryEntries(ctx context.Context, dirPath FullPath, startFileName string, includeStartFile bool, limit int) ([]*Entry, error) {
+	entries, err := store.FilerStore.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit)
+	if err != nil {
+		return entries, err
+	}
+	for i, entry := range entries {
+		if len(entry.HardLinkId) == 0 {
+			continue
+		}
+		resolved, resolveErr := store.resolveHardLink(ctx, entry)
+		if resolveErr != nil {
+			glog.V(0).Infof("list %s: %v", entry.FullPath, resolveErr)
+			continue
+		}
+		entries[i] = resolved
+	}
+	return entries, nil
+}
+
+func (store *hardLinkStore) saveHardLinkRecord(ctx context.Context, entry *Entry) error {
+
+	store.recordLock.Lock()
+	defer store.recordLock.Unlock()
+
+	record := &Entry{
+		FullPath:        entry.HardLinkId.recordPath(),
+		Attr:            entry.Attr,
+		Chunks:          entry.Chunks,
+		Extended:        entry.Extended,
+		HardLinkCounter: 1,
+	}
+
+	// keep the counter of the existing record
+	existing, err := store.FilerStore.FindEntry(ctx, record.FullPath)
+	if err == ErrNotFound {
+		err = store.FilerStore.InsertEntry(ctx, record)
+	} else if err == nil {
+		record.HardLinkCounter = existing.HardLinkCounter
+		err = store.FilerStore.UpdateEntry(ctx, record)
+	}
+	if err != nil {
+		return fmt.Errorf("save hard link %s of %s: %v", entry.HardLinkId, entry.FullPath, err)
+	}
+	return nil
+}
+
+// adjustHardLinkCounter changes the link counter by delta, and removes the record when no link is left
+func (store *hardLinkStore) adjustHardLinkCounter(ctx context.Context, id HardLinkId, delta int32) (counter int32, err error) {
+
+	store.recordLock.Lock()
+	defer store.recordLock.Unlock()
+
+	record, err := store.FilerStore.FindEntry(ctx, id.recordPath())
+	if err == ErrNotFound && delta < 0 {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("find hard link %s: %v", id, err)
+	}
+
+	record.HardLinkCounter += delta
+	if record.HardLinkCounter > 0 {
+		glog.V(3).Infof("hard link %s has %d links", id, record.HardLinkCounter)
+		return record.HardLinkCounter, store.FilerStore.UpdateEntry(ctx, record)
+	}
+
+	glog.V(3).Infof("deleting hard link %s", id)
+	return 0, store.FilerStore.DeleteEntry(ctx, id.recordPath())
+}
+
+func (store *hardLinkStore) resolveHardLink(ctx context.Context, pointer *Entry) (*Entry, error) {
+
+	record, err := store.FilerStore.FindEntry(ctx, pointer.HardLinkId.recordPath())
+	if err != nil {
+		return nil, fmt.Errorf("find hard link %s of %s: %v", pointer.HardLinkId, pointer.FullPath, err)
+	}
+
+	return &Entry{
+		FullPath:        pointer.FullPath,
+		Attr:            record.Attr,
+		Chunks:          record.Chunks,
+		Extended:        record.Extended,
+		HardLinkId:      pointer.HardLinkId,
+		HardLinkCounter: record.HardLinkCounter,
+	}, nil
+}
+
+func toHardLinkPointer(entry *Entry) *Entry {
+	return &Entry{
+		FullPath:   entry.FullPath,
+		Attr:       entry.Attr,
+		HardLinkId: entry.HardLinkId,
+	}
+}
+
+// LinkEntry creates newPath as a hard link to the file of oldPath
+func (f *Filer) LinkEntry(ctx context.Context, oldPath, newPath FullPath) (*Entry, error) {
+
+	oldEntry, err := f.FindEntry(ctx, oldPath)
+	if err != nil {
+		return nil, err
+	}
+	if oldEntry.IsDirectory() {
+		return nil, fmt.Errorf("%s is a directory", oldPath)
+	}
+	if _, err = f.FindEntry(ctx, newPath); err == nil {
+		return nil, fmt.Errorf("%s already exists", newPath)
+	}
+
+	// the old entry becomes the first link
+	if len(oldEntry.HardLinkId) == 0 {
+		oldEntry.HardLinkId = NewHardLinkId()
+		if err = f.store.UpdateEntry(ctx, oldEntry); err != nil {
+			return nil, fmt.Errorf("link %s: %v", oldPath, err)
+		}
+	}
+
+	// count the new link before creating it, so the chunks are never freed with a link left
+	counter, err := f.hardLinks.adjustHardLinkCounter(ctx, oldEntry.HardLinkId, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	newEntry := &Entry{
+		FullPath:        newPath,
+		Attr:            oldEntry.Attr,
+		Chunks:          oldEntry.Chunks,
+		Extended:        oldEntry.Extended,
+		HardLinkId:      oldEntry.HardLinkId,
+		HardLinkCounter: counter,
+	}
+	if err = f.CreateEntry(ctx, newEntry); err != nil {
+		if isLastLink, unlinkErr := f.unlinkHardLink(ctx, oldEntry.HardLinkId); unlinkErr != nil {
+			glog.Errorf("unlink %s: %v", newPath, unlinkErr)
+		} else if isLastLink {
+			f.DeleteChunks(oldEntry.Chunks)
+		}
+		return nil, err
+	}
+
+	return newEntry, nil
+}
+
+// unlinkHardLink decrements the link counter, and removes the shared record when the last link goes away
+func (f *Filer) unlinkHardLink(ctx context.Context, id HardLinkId) (isLastLink bool, err error) {
+	counter, err := f.hardLinks.adjustHardLinkCounter(ctx, id, -1)
+	if err != nil {
+		return false, err
+	}
+	return counter == 0, nil
+}
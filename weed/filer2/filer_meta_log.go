@@ -0,0 +1,346 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+/*
+
+The meta log persists every filer metadata change, so the changes can be replayed from a point in time.
+
+Each log file has the records of 4 bytes length + marshalled SubscribeMetadataResponse.
+The log file is named by the time of its first record, and rotated by size or by age.
+Log files older than the retention period are removed.
+
+The recent records are also kept in memory, so the subscribers tailing the log do not read the disk.
+
+*/
+
+const (
+	metaLogFileSizeLimit  = 64 * 1024 * 1024
+	metaLogFileTimeLimit  = time.Hour
+	metaLogBufferSize     = 4096
+	metaLogFileNameLayout = "2006-01-02T15-04-05.000000000"
+	metaLogFileExt        = ".log"
+)
+
+type MetaLog struct {
+	dir       string
+	retention time.Duration
+
+	sync.Mutex
+	file          *os.File
+	fileStartTime time.Time
+	fileSize      int64
+	lastTsNs      int64
+	buffer        []*filer_pb.SubscribeMetadataResponse
+	// all records up to evictedTsNs are only on the disk
+	evictedTsNs int64
+	notifyChan  chan struct{}
+}
+
+func NewMetaLog(dir string, retention time.Duration) (*MetaLog, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create meta log dir %s: %v", dir, err)
+	}
+	now := time.Now().UnixNano()
+	return &MetaLog{
+		dir:         dir,
+		retention:   retention,
+		lastTsNs:    now,
+		evictedTsNs: now,
+		notifyChan:  make(chan struct{}),
+	}, nil
+}
+
+// AppendEvent persists the event, and wakes up the subscribers
+func (m *MetaLog) AppendEvent(directory string, eventNotification *filer_pb.EventNotification) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	tsNs := time.Now().UnixNano()
+	if tsNs <= m.lastTsNs {
+		tsNs = m.lastTsNs + 1
+	}
+
+	event := &filer_pb.SubscribeMetadataResponse{
+		Directory:         directory,
+		EventNotification: eventNotification,
+		TsNs:              tsNs,
+	}
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %v", err)
+	}
+
+	if err = m.maybeRotate(tsNs); err != nil {
+		return err
+	}
+
+	record := make([]byte, 4+len(data))
+	util.Uint32toBytes(record[0:4], uint32(len(data)))
+	copy(record[4:], data)
+	if _, err = m.file.Write(record); err != nil {
+		return fmt.Errorf("write meta log %s: %v", m.file.Name(), err)
+	}
+	m.fileSize += int64(len(record))
+	m.lastTsNs = tsNs
+
+	if len(m.buffer) >= metaLogBufferSize {
+		m.evictedTsNs = m.buffer[0].TsNs
+		m.buffer = m.buffer[1:]
+	}
+	m.buffer = append(m.buffer, event)
+
+	close(m.notifyChan)
+	m.notifyChan = make(chan struct{})
+
+	return nil
+}
+
+func (m *MetaLog) maybeRotate(tsNs int64) (err error) {
+
+	now := time.Unix(0, tsNs)
+
+	if m.file != nil && m.fileSize < metaLogFileSizeLimit && now.Sub(m.fileStartTime) < metaLogFileTimeLimit {
+		return nil
+	}
+
+	if m.file != nil {
+		m.file.Close()
+		m.file = nil
+	}
+
+	fileName := filepath.Join(m.dir, now.UTC().Format(metaLogFileNameLayout)+metaLogFileExt)
+	if m.file, err = os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644); err != nil {
+		return fmt.Errorf("create meta log %s: %v", fileName, err)
+	}
+	m.fileStartTime = now
+	m.fileSize = 0
+
+	m.removeExpiredFiles(now)
+
+	return nil
+}
+
+// removeExpiredFiles removes a log file if the next log file starts before the retention period
+func (m *MetaLog) removeExpiredFiles(now time.Time) {
+	if m.retention <= 0 {
+		return
+	}
+	fileNames, startTimes, err := m.listFiles()
+	if err != nil {
+		glog.V(0).Infof("list meta log files: %v", err)
+		return
+	}
+	for i := 0; i+1 < len(fileNames); i++ {
+		if now.Sub(startTimes[i+1]) <= m.retention {
+			break
+		}
+		glog.V(1).Infof("remove expired meta log %s", fileNames[i])
+		os.Remove(fileNames[i])
+	}
+}
+
+// listFiles returns the log files sorted by their start time
+func (m *MetaLog) listFiles() (fileNames []string, startTimes []time.Time, err error) {
+	fileInfos, err := ioutil.ReadDir(m.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !strings.HasSuffix(name, metaLogFileExt) {
+			continue
+		}
+		startTime, parseErr := time.Parse(metaLogFileNameLayout, name[:len(name)-len(metaLogFileExt)])
+		if parseErr != nil {
+			continue
+		}
+		fileNames = append(fileNames, filepath.Join(m.dir, name))
+		startTimes = append(startTimes, startTime)
+	}
+	sort.Sort(&metaLogFiles{fileNames, startTimes})
+	return
+}
+
+type metaLogFiles struct {
+	fileNames  []string
+	startTimes []time.Time
+}
+
+func (f *metaLogFiles) Len() int { return len(f.fileNames) }
+func (f *metaLogFiles) Less(i, j int) bool {
+	return f.startTimes[i].Before(f.startTimes[j])
+}
+func (f *metaLogFiles) Swap(i, j int) {
+	f.fileNames[i], f.fileNames[j] = f.fileNames[j], f.fileNames[i]
+	f.startTimes[i], f.startTimes[j] = f.startTimes[j], f.startTimes[i]
+}
+
+// Subscribe sends the events after sinceNs under the pathPrefix, and then waits for new events until the context is done
+func (m *MetaLog) Subscribe(ctx context.Context, pathPrefix string, sinceNs int64, fn func(event *filer_pb.SubscribeMetadataResponse) error) error {
+
+	lastTsNs := sinceNs
+
+	eachEventFn := func(event *filer_pb.SubscribeMetadataResponse) error {
+		lastTsNs = event.TsNs
+		if !eventHasPathPrefix(event, pathPrefix) {
+			return nil
+		}
+		return fn(event)
+	}
+
+	for {
+		events, evictedTsNs, notifyChan := m.readFromBuffer(lastTsNs)
+
+		if lastTsNs < evictedTsNs {
+			// the records up to evictedTsNs were fully written before reading the disk
+			if err := m.readFromDisk(lastTsNs, eachEventFn); err != nil {
+				return err
+			}
+			if lastTsNs < evictedTsNs {
+				lastTsNs = evictedTsNs
+			}
+			continue
+		}
+
+		for _, event := range events {
+			if err := eachEventFn(event); err != nil {
+				return err
+			}
+		}
+		if len(events) > 0 {
+			continue
+		}
+
+		select {
+		case <-notifyChan:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+}
+
+func (m *MetaLog) readFromBuffer(lastTsNs int64) (events []*filer_pb.SubscribeMetadataResponse, evictedTsNs int64, notifyChan chan struct{}) {
+	m.Lock()
+	defer m.Unlock()
+
+	if lastTsNs < m.evictedTsNs {
+		return nil, m.evictedTsNs, m.notifyChan
+	}
+
+	i := sort.Search(len(m.buffer), func(i int) bool {
+		return m.buffer[i].TsNs > lastTsNs
+	})
+	events = make([]*filer_pb.SubscribeMetadataResponse, len(m.buffer)-i)
+	copy(events, m.buffer[i:])
+
+	return events, m.evictedTsNs, m.notifyChan
+}
+
+func (m *MetaLog) readFromDisk(lastTsNs int64, eachEventFn func(event *filer_pb.SubscribeMetadataResponse) error) error {
+
+	fileNames, startTimes, err := m.listFiles()
+	if err != nil {
+		return fmt.Errorf("list meta log files: %v", err)
+	}
+
+	// skip the files that end before lastTsNs
+	start := 0
+	for i := 1; i < len(fileNames); i++ {
+		if startTimes[i].UnixNano() <= lastTsNs {
+			start = i
+		}
+	}
+
+	for i := start; i < len(fileNames); i++ {
+		if err = readMetaLogFile(fileNames[i], func(event *filer_pb.SubscribeMetadataResponse) error {
+			if event.TsNs <= lastTsNs {
+				return nil
+			}
+			lastTsNs = event.TsNs
+			return eachEventFn(event)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readMetaLogFile visits each complete record in the log file
+func readMetaLogFile(fileName string, fn func(event *filer_pb.SubscribeMetadataResponse) error) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// removed after expiration
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	sizeBuf := make([]byte, 4)
+	for {
+		if _, err = io.ReadFull(f, sizeBuf); err != nil {
+			// the last record may be still being written
+			return nil
+		}
+		data := make([]byte, util.BytesToUint32(sizeBuf))
+		if _, err = io.ReadFull(f, data); err != nil {
+			return nil
+		}
+		event := &filer_pb.SubscribeMetadataResponse{}
+		if err = proto.Unmarshal(data, event); err != nil {
+			return fmt.Errorf("unmarshal meta log %s: %v", fileName, err)
+		}
+		if err = fn(event); err != nil {
+			return err
+		}
+	}
+}
+
+func eventHasPathPrefix(event *filer_pb.SubscribeMetadataResponse, pathPrefix string) bool {
+	if pathPrefix == "" || pathPrefix == "/" {
+		return true
+	}
+	if oldEntry := event.EventNotification.OldEntry; oldEntry != nil && hasPathPrefix(oldEntry.Name, pathPrefix) {
+		return true
+	}
+	if newEntry := event.EventNotification.NewEntry; newEntry != nil && hasPathPrefix(newEntry.Name, pathPrefix) {
+		return true
+	}
+	return false
+}
+
+// hasPathPrefix tells whether the path is the directory of the prefix or under it, so "/a" does not match "/ab"
+func hasPathPrefix(fullpath, pathPrefix string) bool {
+	dir := strings.TrimSuffix(pathPrefix, "/")
+	return fullpath == dir || strings.HasPrefix(fullpath, dir+"/")
+}
+
+func (m *MetaLog) Close() {
+	m.Lock()
+	defer m.Unlock()
+	if m.file != nil {
+		m.file.Close()
+		m.file = nil
+	}
+}
@@ -0,0 +1,155 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func appendTestEvents(t *testing.T, metaLog *MetaLog, dir string, start, count int) {
+	for i := start; i < start+count; i++ {
+		err := metaLog.AppendEvent(dir, &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{
+				Name: fmt.Sprintf("%s/file%d", dir, i),
+			},
+		})
+		if err != nil {
+			t.Fatalf("append event %d: %v", i, err)
+		}
+	}
+}
+
+// collectEvents subscribes until the expected number of events are received
+func collectEvents(t *testing.T, metaLog *MetaLog, pathPrefix string, sinceNs int64, expected int) (names []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var lastTsNs int64
+	err := metaLog.Subscribe(ctx, pathPrefix, sinceNs, func(event *filer_pb.SubscribeMetadataResponse) error {
+		if event.TsNs <= lastTsNs {
+			t.Errorf("event ts %d is not after %d", event.TsNs, lastTsNs)
+		}
+		lastTsNs = event.TsNs
+		names = append(names, event.EventNotification.NewEntry.Name)
+		if len(names) == expected {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if len(names) != expected {
+		t.Fatalf("received %d events, expected %d", len(names), expected)
+	}
+	return
+}
+
+func TestMetaLogReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filermeta")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("create meta log: %v", err)
+	}
+	defer metaLog.Close()
+
+	// more events than the memory buffer, so the old ones are read from the disk
+	appendTestEvents(t, metaLog, "/a", 0, metaLogBufferSize)
+	middleTsNs := metaLog.lastTsNs
+	appendTestEvents(t, metaLog, "/b", 0, 100)
+	appendTestEvents(t, metaLog, "/a", metaLogBufferSize, 100)
+
+	names := collectEvents(t, metaLog, "/a/", 0, metaLogBufferSize+100)
+	for i, name := range names {
+		if expected := fmt.Sprintf("/a/file%d", i); name != expected {
+			t.Fatalf("event %d: %s, expected %s", i, name, expected)
+		}
+	}
+
+	names = collectEvents(t, metaLog, "/", middleTsNs, 200)
+	if names[0] != "/b/file0" || names[199] != fmt.Sprintf("/a/file%d", metaLogBufferSize+99) {
+		t.Errorf("unexpected events since %d: %s ... %s", middleTsNs, names[0], names[199])
+	}
+
+	// a new meta log reads the events of the previous one from the disk
+	metaLog.Close()
+	reopened, err := NewMetaLog(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("reopen meta log: %v", err)
+	}
+	defer reopened.Close()
+	names = collectEvents(t, reopened, "/b", 0, 100)
+	if names[99] != "/b/file99" {
+		t.Errorf("unexpected last event %s", names[99])
+	}
+}
+
+func TestMetaLogTail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filermeta")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("create meta log: %v", err)
+	}
+	defer metaLog.Close()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		appendTestEvents(t, metaLog, "/c", 0, 10)
+	}()
+
+	names := collectEvents(t, metaLog, "/c", time.Now().UnixNano(), 10)
+	if names[9] != "/c/file9" {
+		t.Errorf("unexpected last event %s", names[9])
+	}
+}
+
+func TestEventHasPathPrefix(t *testing.T) {
+	event := func(oldName, newName string) *filer_pb.SubscribeMetadataResponse {
+		notification := &filer_pb.EventNotification{}
+		if oldName != "" {
+			notification.OldEntry = &filer_pb.Entry{Name: oldName}
+		}
+		if newName != "" {
+			notification.NewEntry = &filer_pb.Entry{Name: newName}
+		}
+		return &filer_pb.SubscribeMetadataResponse{EventNotification: notification}
+	}
+
+	for _, c := range []struct {
+		event      *filer_pb.SubscribeMetadataResponse
+		pathPrefix string
+		expected   bool
+	}{
+		{event("", "/a/b"), "", true},
+		{event("", "/a/b"), "/", true},
+		{event("", "/a"), "/a", true},
+		{event("", "/a"), "/a/", true},
+		{event("", "/a/b"), "/a", true},
+		{event("", "/a/b/c"), "/a/", true},
+		{event("", "/ab"), "/a", false},
+		{event("", "/ab/c"), "/a/", false},
+		{event("", "/b"), "/a", false},
+		{event("/a/b", ""), "/a", true},
+		{event("/ab", "/a/b"), "/a", true},
+		{event("/a/b", "/ab"), "/ab", true},
+		{event("/a/b", "/ab"), "/abc", false},
+	} {
+		if got := eventHasPathPrefix(c.event, c.pathPrefix); got != c.expected {
+			t.Errorf("event %v with path prefix %q: %v, expected %v", c.event.EventNotification, c.pathPrefix, got, c.expected)
+		}
+	}
+}
@@ -1,12 +1,35 @@
 package filer2
 
 import (
+	"context"
+
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/notification"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 )
 
+type originKey struct{}
+
+// WithOrigin marks the changes made with the context as replicated from the origin filer,
+// so the replication does not send them back.
+func WithOrigin(ctx context.Context, origin string) context.Context {
+	if origin == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFromContext returns the origin filer set by WithOrigin
+func OriginFromContext(ctx context.Context) string {
+	origin, _ := ctx.Value(originKey{}).(string)
+	return origin
+}
+
 func (f *Filer) NotifyUpdateEvent(oldEntry, newEntry *Entry, deleteChunks bool) {
+	f.notifyUpdateEvent(oldEntry, newEntry, deleteChunks, "")
+}
+
+func (f *Filer) notifyUpdateEvent(oldEntry, newEntry *Entry, deleteChunks bool, origin string) {
 	var key string
 	if oldEntry != nil {
 		key = string(oldEntry.FullPath)
@@ -16,17 +39,27 @@ func (f *Filer) NotifyUpdateEvent(oldEntry, newEntry *Entry, deleteChunks bool)
 		return
 	}
 
+	eventNotification := &filer_pb.EventNotification{
+		OldEntry:     oldEntry.ToProtoEntry(),
+		NewEntry:     newEntry.ToProtoEntry(),
+		DeleteChunks: deleteChunks,
+		Origin:       origin,
+	}
+
+	if f.MetaLog != nil {
+		dir, _ := FullPath(key).DirAndName()
+		if err := f.MetaLog.AppendEvent(dir, eventNotification); err != nil {
+			glog.Errorf("log entry update %v: %v", key, err)
+		}
+	}
+
 	if notification.Queue != nil {
 
 		glog.V(3).Infof("notifying entry update %v", key)
 
 		notification.Queue.SendMessage(
 			key,
-			&filer_pb.EventNotification{
-				OldEntry:     oldEntry.ToProtoEntry(),
-				NewEntry:     newEntry.ToProtoEntry(),
-				DeleteChunks: deleteChunks,
-			},
+			eventNotification,
 		)
 
 	}
@@ -0,0 +1,574 @@
+package filer2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+/*
+
+A directory quota limits the total file size and the number of files under the directory.
+The usage is counted when the quota is set, and then tracked incrementally
+when the entries under the directory are created, updated and deleted.
+
+The quotas, with the counted usage, are stored in the store under QuotaDir,
+which has no directory entry and is not visible when listing the root directory.
+Each filer keeps its own usage changes of a quota in a separate record under QuotaUsageDir,
+so the filers sharing the store do not overwrite the usage changes of each other.
+The usage is the counted usage plus the changes of all filers.
+
+A change under a quota is checked and recorded under the lock of the quota, against the cached usage.
+The cached usage is refreshed from the store every quota refresh interval. The changes by other filers
+since the last refresh are not seen, which may exceed the quota by their sizes.
+
+*/
+
+const (
+	QuotaDir         = "/.quotas"
+	QuotaUsageDir    = "/.quotas.usage"
+	quotaExtendedKey = "quota"
+
+	DefaultQuotaRefreshInterval = 10 * time.Second
+)
+
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// IsQuotaExceeded also recognizes the error passed through grpc
+func IsQuotaExceeded(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ErrQuotaExceeded.Error())
+}
+
+type quotas struct {
+	sync.Mutex
+	directories map[FullPath]*quotaState
+	// the name of the usage change records of this filer
+	filerId string
+	// how long the usage loaded from the store is used, 0 to load it on every change
+	refreshInterval time.Duration
+}
+
+// quotaState caches the quota of a directory, the fields are guarded by the quotas lock
+type quotaState struct {
+	// serializes the changes under the directory, locked before the quotas lock
+	changeLock sync.Mutex
+	// the limits and the usage, with the usage changes of all filers
+	quota *filer_pb.Quota
+	// the usage changes recorded by this filer, or nil if none is recorded yet
+	own      *filer_pb.Quota
+	loadedAt time.Time
+}
+
+// SetQuotaRefreshInterval sets how long the quota usage is cached before loaded again from the store,
+// which has the usage changes by the other filers sharing the store
+func (f *Filer) SetQuotaRefreshInterval(interval time.Duration) {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	f.quotas.refreshInterval = interval
+}
+
+func quotaRecordPath(dir FullPath) FullPath {
+	return NewFullPath(QuotaDir, url.QueryEscape(string(dir)))
+}
+
+func quotaUsageDir(dir FullPath) FullPath {
+	return NewFullPath(QuotaUsageDir, url.QueryEscape(string(dir)))
+}
+
+func (f *Filer) loadQuotas(ctx context.Context) error {
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	if f.quotas.filerId == "" {
+		id := make([]byte, 8)
+		rand.Read(id)
+		f.quotas.filerId = hex.EncodeToString(id)
+	}
+	f.quotas.directories = make(map[FullPath]*quotaState)
+
+	lastFileName := ""
+	for {
+		entries, err := f.store.ListDirectoryEntries(ctx, QuotaDir, lastFileName, false, 1024)
+		if err != nil {
+			return fmt.Errorf("list quotas: %v", err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			record, err := decodeQuota(entry)
+			if err != nil {
+				glog.Errorf("load quota %s: %v", entry.FullPath, err)
+				continue
+			}
+			quota, own, err := f.loadQuota(ctx, FullPath(record.Directory))
+			if err != nil {
+				glog.Errorf("load quota %s: %v", entry.FullPath, err)
+				continue
+			}
+			f.quotas.directories[FullPath(quota.Directory)] = &quotaState{quota: quota, own: own, loadedAt: time.Now()}
+		}
+		if len(entries) < 1024 {
+			break
+		}
+	}
+
+	if len(f.quotas.directories) > 0 {
+		glog.V(0).Infof("loaded %d directory quotas", len(f.quotas.directories))
+	}
+
+	return nil
+}
+
+func decodeQuota(entry *Entry) (*filer_pb.Quota, error) {
+	quota := &filer_pb.Quota{}
+	if err := proto.Unmarshal(entry.Extended[quotaExtendedKey], quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// findQuotaRecord reads the limits and the counted usage of the directory, or ErrNotFound
+func (f *Filer) findQuotaRecord(ctx context.Context, dir FullPath) (*filer_pb.Quota, error) {
+	entry, err := f.store.FindEntry(ctx, quotaRecordPath(dir))
+	if err != nil {
+		return nil, err
+	}
+	quota, err := decodeQuota(entry)
+	if err != nil {
+		return nil, fmt.Errorf("decode quota of %s: %v", dir, err)
+	}
+	return quota, nil
+}
+
+// loadQuota reads the quota of the directory from the store, adding the usage changes of all filers to the counted usage.
+// The usage changes of this filer are also returned, or nil if none is recorded yet.
+func (f *Filer) loadQuota(ctx context.Context, dir FullPath) (quota, own *filer_pb.Quota, err error) {
+
+	if quota, err = f.findQuotaRecord(ctx, dir); err != nil {
+		return nil, nil, err
+	}
+
+	err = f.listQuotaUsages(ctx, dir, func(entry *Entry) {
+		usage, decodeErr := decodeQuota(entry)
+		if decodeErr != nil {
+			glog.Errorf("load quota usage %s: %v", entry.FullPath, decodeErr)
+			return
+		}
+		quota.UsedBytes += usage.UsedBytes
+		quota.UsedFiles += usage.UsedFiles
+		if entry.Name() == f.quotas.filerId {
+			own = usage
+		}
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list quota usages of %s: %v", dir, err)
+	}
+
+	if quota.UsedBytes < 0 {
+		quota.UsedBytes = 0
+	}
+	if quota.UsedFiles < 0 {
+		quota.UsedFiles = 0
+	}
+	return quota, own, nil
+}
+
+func (f *Filer) listQuotaUsages(ctx context.Context, dir FullPath, fn func(entry *Entry)) error {
+	lastFileName := ""
+	for {
+		entries, err := f.store.ListDirectoryEntries(ctx, quotaUsageDir(dir), lastFileName, false, 1024)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			fn(entry)
+		}
+		if len(entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+func (f *Filer) deleteQuotaUsages(ctx context.Context, dir FullPath) error {
+	var usages []FullPath
+	if err := f.listQuotaUsages(ctx, dir, func(entry *Entry) {
+		usages = append(usages, entry.FullPath)
+	}); err != nil {
+		return err
+	}
+	for _, p := range usages {
+		if err := f.store.DeleteEntry(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetQuota sets the limits of the directory, counting the current usage if the directory has no quota yet
+func (f *Filer) SetQuota(ctx context.Context, dir FullPath, maxBytes, maxFiles int64) (*filer_pb.Quota, error) {
+
+	entry, err := f.FindEntry(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("find %s: %v", dir, err)
+	}
+	if !entry.IsDirectory() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	record, err := f.findQuotaRecord(ctx, dir)
+	found := err == nil
+	if err != nil && err != ErrNotFound {
+		return nil, fmt.Errorf("find quota of %s: %v", dir, err)
+	}
+
+	if !found {
+		record = &filer_pb.Quota{Directory: string(dir)}
+		if record.UsedBytes, record.UsedFiles, err = f.countUsage(ctx, dir); err != nil {
+			return nil, fmt.Errorf("count usage of %s: %v", dir, err)
+		}
+		// the usage changes of a removed quota are included in the counted usage
+		if err = f.deleteQuotaUsages(ctx, dir); err != nil {
+			return nil, fmt.Errorf("delete quota usages of %s: %v", dir, err)
+		}
+	}
+
+	state := f.quotaState(dir, true)
+	state.changeLock.Lock()
+	defer state.changeLock.Unlock()
+
+	record.MaxBytes, record.MaxFiles = maxBytes, maxFiles
+	if err := f.saveQuota(ctx, quotaRecordPath(dir), record, !found); err != nil {
+		return nil, err
+	}
+	quota, own, err := f.loadQuota(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("load quota of %s: %v", dir, err)
+	}
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	state.quota, state.own, state.loadedAt = quota, own, time.Now()
+
+	return proto.Clone(quota).(*filer_pb.Quota), nil
+}
+
+// quotaState returns the cached quota of the directory, optionally creating an empty one if not found
+func (f *Filer) quotaState(dir FullPath, create bool) *quotaState {
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	state, found := f.quotas.directories[dir]
+	if !found && create {
+		state = &quotaState{}
+		f.quotas.directories[dir] = state
+	}
+	return state
+}
+
+func (f *Filer) RemoveQuota(ctx context.Context, dir FullPath) error {
+
+	state := f.quotaState(dir, false)
+	if state == nil {
+		return nil
+	}
+	state.changeLock.Lock()
+	defer state.changeLock.Unlock()
+
+	f.quotas.Lock()
+	delete(f.quotas.directories, dir)
+	f.quotas.Unlock()
+
+	if err := f.store.DeleteEntry(ctx, quotaRecordPath(dir)); err != nil {
+		return fmt.Errorf("delete quota of %s: %v", dir, err)
+	}
+	if err := f.deleteQuotaUsages(ctx, dir); err != nil {
+		return fmt.Errorf("delete quota usages of %s: %v", dir, err)
+	}
+	return nil
+}
+
+// GetQuota returns the closest quota of the directory or its ancestors, or nil if there is none
+func (f *Filer) GetQuota(dir FullPath) *filer_pb.Quota {
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	for _, p := range ancestorsOf(dir, true) {
+		if state, found := f.quotas.directories[p]; found && state.quota != nil {
+			return proto.Clone(state.quota).(*filer_pb.Quota)
+		}
+	}
+	return nil
+}
+
+func (f *Filer) ListQuotas() (quotas []*filer_pb.Quota) {
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	for _, state := range f.quotas.directories {
+		if state.quota != nil {
+			quotas = append(quotas, proto.Clone(state.quota).(*filer_pb.Quota))
+		}
+	}
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].Directory < quotas[j].Directory
+	})
+	return
+}
+
+// CheckQuota returns ErrQuotaExceeded if adding the bytes and files to the path exceeds any quota of its ancestors,
+// with the usage last loaded. It only rejects the changes early, which are checked again when saved.
+func (f *Filer) CheckQuota(p FullPath, deltaBytes, deltaFiles int64) error {
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	for _, dir := range ancestorsOf(p, false) {
+		if state, found := f.quotas.directories[dir]; found && state.quota != nil {
+			if err := checkQuota(state.quota, deltaBytes, deltaFiles); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkQuota(quota *filer_pb.Quota, deltaBytes, deltaFiles int64) error {
+	if deltaBytes > 0 && quota.MaxBytes > 0 && quota.UsedBytes+deltaBytes > quota.MaxBytes {
+		return fmt.Errorf("%s: %v, %d of %d bytes used", quota.Directory, ErrQuotaExceeded, quota.UsedBytes, quota.MaxBytes)
+	}
+	if deltaFiles > 0 && quota.MaxFiles > 0 && quota.UsedFiles+deltaFiles > quota.MaxFiles {
+		return fmt.Errorf("%s: %v, %d of %d files used", quota.Directory, ErrQuotaExceeded, quota.UsedFiles, quota.MaxFiles)
+	}
+	return nil
+}
+
+// ancestorQuotas lists the cached quotas of the parent directories of the path, from the closest one
+func (f *Filer) ancestorQuotas(p FullPath) (dirs []FullPath, states []*quotaState) {
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	if len(f.quotas.directories) == 0 {
+		return nil, nil
+	}
+	for _, dir := range ancestorsOf(p, false) {
+		if state, found := f.quotas.directories[dir]; found {
+			dirs = append(dirs, dir)
+			states = append(states, state)
+		}
+	}
+	return
+}
+
+func (f *Filer) hasQuota(p FullPath) bool {
+	dirs, _ := f.ancestorQuotas(p)
+	return len(dirs) > 0
+}
+
+// changeWithQuota saves the change of the path by fn, if the usage change does not exceed any quota of its ancestors.
+// The quotas are checked with the cached usage, and the usage change is recorded, under the locks of the quotas.
+// The locks are taken from the closest quota, so the changes of any paths lock the shared quotas in the same order.
+func (f *Filer) changeWithQuota(ctx context.Context, p FullPath, deltaBytes, deltaFiles int64, fn func() error) error {
+
+	if deltaBytes == 0 && deltaFiles == 0 {
+		return fn()
+	}
+	dirs, states := f.ancestorQuotas(p)
+	if len(dirs) == 0 {
+		return fn()
+	}
+
+	for _, state := range states {
+		state.changeLock.Lock()
+		defer state.changeLock.Unlock()
+	}
+
+	var checked []int
+	for i, dir := range dirs {
+		quota, err := f.refreshQuota(ctx, dir, states[i])
+		if err != nil {
+			return err
+		}
+		if quota == nil {
+			continue
+		}
+		if err = checkQuota(quota, deltaBytes, deltaFiles); err != nil {
+			return err
+		}
+		checked = append(checked, i)
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	for _, i := range checked {
+		dir, state := dirs[i], states[i]
+		f.quotas.Lock()
+		own, isNew := state.own, state.own == nil
+		if isNew {
+			own = &filer_pb.Quota{Directory: string(dir)}
+		} else {
+			own = proto.Clone(own).(*filer_pb.Quota)
+		}
+		f.quotas.Unlock()
+
+		own.UsedBytes += deltaBytes
+		own.UsedFiles += deltaFiles
+		if err := f.saveQuota(ctx, NewFullPath(string(quotaUsageDir(dir)), f.quotas.filerId), own, isNew); err != nil {
+			glog.Errorf("update quota usage of %s: %v", dir, err)
+			// load the usage again on the next change
+			f.quotas.Lock()
+			state.loadedAt = time.Time{}
+			f.quotas.Unlock()
+			continue
+		}
+
+		f.quotas.Lock()
+		state.own = own
+		state.quota.UsedBytes += deltaBytes
+		state.quota.UsedFiles += deltaFiles
+		if state.quota.UsedBytes < 0 {
+			state.quota.UsedBytes = 0
+		}
+		if state.quota.UsedFiles < 0 {
+			state.quota.UsedFiles = 0
+		}
+		f.quotas.Unlock()
+	}
+
+	return nil
+}
+
+// refreshQuota returns a copy of the cached quota, loading it again from the store if it is older than the refresh interval.
+// It returns nil if the quota is removed. The change lock of the quota is held by the caller.
+func (f *Filer) refreshQuota(ctx context.Context, dir FullPath, state *quotaState) (*filer_pb.Quota, error) {
+
+	f.quotas.Lock()
+	if state.quota != nil && time.Since(state.loadedAt) < f.quotas.refreshInterval {
+		quota := proto.Clone(state.quota).(*filer_pb.Quota)
+		f.quotas.Unlock()
+		return quota, nil
+	}
+	f.quotas.Unlock()
+
+	quota, own, err := f.loadQuota(ctx, dir)
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	if err == ErrNotFound {
+		// removed by another filer
+		if f.quotas.directories[dir] == state {
+			delete(f.quotas.directories, dir)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load quota of %s: %v", dir, err)
+	}
+	state.quota, state.own, state.loadedAt = quota, own, time.Now()
+	return proto.Clone(quota).(*filer_pb.Quota), nil
+}
+
+func (f *Filer) saveQuota(ctx context.Context, p FullPath, quota *filer_pb.Quota, isNew bool) error {
+
+	data, err := proto.Marshal(quota)
+	if err != nil {
+		return fmt.Errorf("encode quota of %s: %v", quota.Directory, err)
+	}
+
+	now := time.Now()
+	record := &Entry{
+		FullPath: p,
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   0600,
+		},
+		Extended: map[string][]byte{quotaExtendedKey: data},
+	}
+
+	if isNew {
+		err = f.store.InsertEntry(ctx, record)
+	} else {
+		err = f.store.UpdateEntry(ctx, record)
+	}
+	if err != nil {
+		return fmt.Errorf("save quota of %s: %v", quota.Directory, err)
+	}
+	return nil
+}
+
+// countUsage walks the directory tree to count the total file size and the number of files
+func (f *Filer) countUsage(ctx context.Context, dir FullPath) (bytes, files int64, err error) {
+
+	lastFileName := ""
+	for {
+		entries, err := f.store.ListDirectoryEntries(ctx, dir, lastFileName, false, 1024)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if entry.IsDirectory() {
+				subBytes, subFiles, err := f.countUsage(ctx, entry.FullPath)
+				if err != nil {
+					return 0, 0, err
+				}
+				bytes += subBytes
+				files += subFiles
+				continue
+			}
+			bytes += int64(entry.Size())
+			files++
+		}
+		if len(entries) < 1024 {
+			break
+		}
+	}
+
+	return bytes, files, nil
+}
+
+// usageDelta is the change of the counted usage when the old entry is replaced by the new one, either can be nil
+func usageDelta(oldEntry, newEntry *Entry) (deltaBytes, deltaFiles int64) {
+	if oldEntry != nil && !oldEntry.IsDirectory() {
+		deltaBytes -= int64(oldEntry.Size())
+		deltaFiles--
+	}
+	if newEntry != nil && !newEntry.IsDirectory() {
+		deltaBytes += int64(newEntry.Size())
+		deltaFiles++
+	}
+	return
+}
+
+// ancestorsOf lists the parent directories of the path, from the closest one, optionally including the path itself
+func ancestorsOf(p FullPath, includeSelf bool) (dirs []FullPath) {
+	if includeSelf {
+		dirs = append(dirs, p)
+	}
+	for p != "/" && p != "" {
+		dir, _ := p.DirAndName()
+		p = FullPath(dir)
+		dirs = append(dirs, p)
+	}
+	return
+}
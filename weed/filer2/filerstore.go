@@ -3,6 +3,7 @@ package filer2
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
@@ -25,3 +26,8 @@ type FilerStore interface {
 }
 
 var ErrNotFound = errors.New("filer: no entry is found in filer store")
+
+// IsNotFound also recognizes the error passed through grpc
+func IsNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ErrNotFound.Error())
+}
@@ -0,0 +1,166 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+/*
+
+The identity of the caller is passed to the filer by the trusted clients, e.g., "weed mount" and the S3 gateway,
+in the grpc metadata or the http headers. The filer server only accepts the identity from the trusted callers,
+and the requests without an identity take the configured default identity, or the Anonymous identity.
+
+The filer operations with a context without any identity are not checked, e.g., the internal operations of the filer.
+
+*/
+
+const (
+	IdentityUidHeader  = "Seaweed-Uid"
+	IdentityGidsHeader = "Seaweed-Gids"
+)
+
+var (
+	identityUidMetadataKey  = strings.ToLower(IdentityUidHeader)
+	identityGidsMetadataKey = strings.ToLower(IdentityGidsHeader)
+)
+
+// Identity is the caller of the filer requests, the first gid is the primary group
+type Identity struct {
+	Uid  uint32
+	Gids []uint32
+}
+
+type identityContextKey struct{}
+
+// Anonymous is the identity of the requests without an identity when there is no default identity,
+// it is denied by all the permission checks
+var Anonymous = &Identity{Uid: math.MaxUint32}
+
+func (id *Identity) IsRoot() bool {
+	return id.Uid == 0
+}
+
+func (id *Identity) InGroup(gid uint32) bool {
+	for _, g := range id.Gids {
+		if g == gid {
+			return true
+		}
+	}
+	return false
+}
+
+func (id *Identity) PrimaryGid() uint32 {
+	if len(id.Gids) == 0 {
+		return 0
+	}
+	return id.Gids[0]
+}
+
+func (id *Identity) String() string {
+	return fmt.Sprintf("uid=%d gids=%s", id.Uid, id.encodeGids())
+}
+
+func (id *Identity) encodeGids() string {
+	gids := make([]string, len(id.Gids))
+	for i, gid := range id.Gids {
+		gids[i] = strconv.FormatUint(uint64(gid), 10)
+	}
+	return strings.Join(gids, ",")
+}
+
+// ParseIdentity parses the identity in the format of "uid:gid,gid", the first gid is the primary group
+func ParseIdentity(s string) (*Identity, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) == 1 {
+		return parseIdentity(parts[0], "")
+	}
+	return parseIdentity(parts[0], parts[1])
+}
+
+func parseIdentity(uid, gids string) (*Identity, error) {
+	parsedUid, err := strconv.ParseUint(uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse uid %s: %v", uid, err)
+	}
+	id := &Identity{Uid: uint32(parsedUid)}
+	for _, gid := range strings.Split(gids, ",") {
+		if gid == "" {
+			continue
+		}
+		parsedGid, err := strconv.ParseUint(gid, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse gid %s: %v", gid, err)
+		}
+		id.Gids = append(id.Gids, uint32(parsedGid))
+	}
+	return id, nil
+}
+
+// WithIdentity sets the identity of the filer operations, nil to skip the permission checks
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, id)
+}
+
+// IdentityFromContext returns the identity set by WithIdentity
+func IdentityFromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return id
+}
+
+// IdentityFromIncomingContext returns the identity passed in the incoming grpc metadata, only to be trusted from the trusted callers
+func IdentityFromIncomingContext(ctx context.Context) *Identity {
+	md, found := metadata.FromIncomingContext(ctx)
+	if !found {
+		return nil
+	}
+	uids := md.Get(identityUidMetadataKey)
+	if len(uids) == 0 {
+		return nil
+	}
+	id, err := parseIdentity(uids[0], strings.Join(md.Get(identityGidsMetadataKey), ","))
+	if err != nil {
+		return nil
+	}
+	return id
+}
+
+// AppendIdentityToOutgoingContext passes the identity to the filer in the grpc requests
+func AppendIdentityToOutgoingContext(ctx context.Context, id *Identity) context.Context {
+	if id == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		identityUidMetadataKey, strconv.FormatUint(uint64(id.Uid), 10),
+		identityGidsMetadataKey, id.encodeGids())
+}
+
+// SetIdentityHeader passes the identity to the filer in the http requests, removing any identity set by others
+func SetIdentityHeader(header http.Header, id *Identity) {
+	header.Del(IdentityUidHeader)
+	header.Del(IdentityGidsHeader)
+	if id == nil {
+		return
+	}
+	header.Set(IdentityUidHeader, strconv.FormatUint(uint64(id.Uid), 10))
+	header.Set(IdentityGidsHeader, id.encodeGids())
+}
+
+// IdentityFromHeader returns the identity passed in the http headers, only to be trusted from the trusted callers
+func IdentityFromHeader(header http.Header) *Identity {
+	uid := header.Get(IdentityUidHeader)
+	if uid == "" {
+		return nil
+	}
+	id, err := parseIdentity(uid, header.Get(IdentityGidsHeader))
+	if err != nil {
+		return nil
+	}
+	return id
+}
@@ -1,26 +1,23 @@
 package leveldb
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	leveldb_util "github.com/syndtr/goleveldb/leveldb/util"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/abstract_kv"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	weed_util "gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
-const (
-	DIR_FILE_SEPARATOR = byte(0x00)
-)
-
 func init() {
 	filer2.Stores = append(filer2.Stores, &LevelDBStore{})
 }
 
 type LevelDBStore struct {
+	abstract_kv.AbstractKvStore
 	db *leveldb.DB
 }
 
@@ -43,6 +40,7 @@ func (store *LevelDBStore) initialize(dir string) (err error) {
 		glog.Infof("filer store open dir %s: %v", dir, err)
 		return
 	}
+	store.Kv = store
 	return
 }
 
@@ -56,126 +54,29 @@ func (store *LevelDBStore) RollbackTransaction(ctx context.Context) error {
 	return nil
 }
 
-func (store *LevelDBStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
-	key := genKey(entry.DirAndName())
-
-	value, err := entry.EncodeAttributesAndChunks()
-	if err != nil {
-		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
-	}
-
-	err = store.db.Put(key, value, nil)
-
-	if err != nil {
-		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
-	}
-
-	// println("saved", entry.FullPath, "chunks", len(entry.Chunks))
-
-	return nil
-}
-
-func (store *LevelDBStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
-
-	return store.InsertEntry(ctx, entry)
-}
-
-func (store *LevelDBStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
-	key := genKey(fullpath.DirAndName())
-
-	data, err := store.db.Get(key, nil)
-
+func (store *LevelDBStore) KvGet(ctx context.Context, key []byte) (value []byte, err error) {
+	value, err = store.db.Get(key, nil)
 	if err == leveldb.ErrNotFound {
-		return nil, filer2.ErrNotFound
-	}
-	if err != nil {
-		return nil, fmt.Errorf("get %s : %v", entry.FullPath, err)
-	}
-
-	entry = &filer2.Entry{
-		FullPath: fullpath,
-	}
-	err = entry.DecodeAttributesAndChunks(data)
-	if err != nil {
-		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+		return nil, abstract_kv.ErrKvNotFound
 	}
-
-	// println("read", entry.FullPath, "chunks", len(entry.Chunks), "data", len(data), string(data))
-
-	return entry, nil
+	return
 }
 
-func (store *LevelDBStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
-	key := genKey(fullpath.DirAndName())
-
-	err = store.db.Delete(key, nil)
-	if err != nil {
-		return fmt.Errorf("delete %s : %v", fullpath, err)
-	}
-
-	return nil
+func (store *LevelDBStore) KvPut(ctx context.Context, key []byte, value []byte) error {
+	return store.db.Put(key, value, nil)
 }
 
-func (store *LevelDBStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
-	limit int) (entries []*filer2.Entry, err error) {
-
-	directoryPrefix := genDirectoryKeyPrefix(fullpath, "")
+func (store *LevelDBStore) KvDelete(ctx context.Context, key []byte) error {
+	return store.db.Delete(key, nil)
+}
 
-	iter := store.db.NewIterator(&leveldb_util.Range{Start: genDirectoryKeyPrefix(fullpath, startFileName)}, nil)
+func (store *LevelDBStore) KvScan(ctx context.Context, prefix []byte, startKey []byte, fn func(key, value []byte) bool) error {
+	iter := store.db.NewIterator(&leveldb_util.Range{Start: startKey, Limit: abstract_kv.PrefixEnd(prefix)}, nil)
+	defer iter.Release()
 	for iter.Next() {
-		key := iter.Key()
-		if !bytes.HasPrefix(key, directoryPrefix) {
-			break
-		}
-		fileName := getNameFromKey(key)
-		if fileName == "" {
-			continue
-		}
-		if fileName == startFileName && !inclusive {
-			continue
-		}
-		limit--
-		if limit < 0 {
-			break
-		}
-		entry := &filer2.Entry{
-			FullPath: filer2.NewFullPath(string(fullpath), fileName),
-		}
-		if decodeErr := entry.DecodeAttributesAndChunks(iter.Value()); decodeErr != nil {
-			err = decodeErr
-			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
+		if !fn(iter.Key(), iter.Value()) {
 			break
 		}
-		entries = append(entries, entry)
 	}
-	iter.Release()
-
-	return entries, err
-}
-
-func genKey(dirPath, fileName string) (key []byte) {
-	key = []byte(dirPath)
-	key = append(key, DIR_FILE_SEPARATOR)
-	key = append(key, []byte(fileName)...)
-	return key
-}
-
-func genDirectoryKeyPrefix(fullpath filer2.FullPath, startFileName string) (keyPrefix []byte) {
-	keyPrefix = []byte(string(fullpath))
-	keyPrefix = append(keyPrefix, DIR_FILE_SEPARATOR)
-	if len(startFileName) > 0 {
-		keyPrefix = append(keyPrefix, []byte(startFileName)...)
-	}
-	return keyPrefix
-}
-
-func getNameFromKey(key []byte) string {
-
-	sepIndex := len(key) - 1
-	for sepIndex >= 0 && key[sepIndex] != DIR_FILE_SEPARATOR {
-		sepIndex--
-	}
-
-	return string(key[sepIndex+1:])
-
+	return iter.Error()
 }
@@ -2,11 +2,18 @@ package leveldb
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 )
 
 func TestCreateAndFind(t *testing.T) {
@@ -87,3 +94,503 @@ func TestEmptyRoot(t *testing.T) {
 	}
 
 }
+
+func TestHardLink(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	ctx := context.Background()
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{
+		FullPath: "/a/file1",
+		Attr:     filer2.Attr{Mode: 0644},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: 5}},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	link, err := filer.LinkEntry(ctx, "/a/file1", "/b/file2")
+	if err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if link.HardLinkCounter != 2 {
+		t.Fatalf("link counter: %d", link.HardLinkCounter)
+	}
+	hardLinkId := link.HardLinkId
+
+	if _, err := filer.LinkEntry(ctx, "/a/file1", "/b/file2"); err == nil {
+		t.Fatalf("link to an existing entry")
+	}
+
+	// the update through one link is visible through the other link
+	entry, _ := filer.FindEntry(ctx, "/a/file1")
+	entry.Mode = 0600
+	if err := filer.UpdateEntry(ctx, nil, entry); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	entries, _ := filer.ListDirectoryEntries(ctx, "/b", "", false, 100)
+	if len(entries) != 1 || entries[0].Mode != 0600 || entries[0].HardLinkCounter != 2 || len(entries[0].Chunks) != 1 {
+		t.Fatalf("list hard link: %+v", entries)
+	}
+
+	// the hard link records are not listed
+	entries, _ = filer.ListDirectoryEntries(ctx, "/", "", false, 100)
+	if len(entries) != 2 {
+		t.Errorf("list root: %+v", entries)
+	}
+
+	// a client with the stale counter can not reset the counter
+	stale := &filer2.Entry{
+		FullPath:        "/a/file1",
+		Attr:            filer2.Attr{Mode: 0600},
+		Chunks:          []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: 5}},
+		HardLinkId:      hardLinkId,
+		HardLinkCounter: 1,
+	}
+	if err := filer.UpdateEntry(ctx, nil, stale); err != nil {
+		t.Fatalf("stale update: %v", err)
+	}
+	if entry, err := filer.FindEntry(ctx, "/b/file2"); err != nil || entry.HardLinkCounter != 2 {
+		t.Fatalf("find after stale update: %+v %v", entry, err)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/a/file1", false, true); err != nil {
+		t.Fatalf("delete the first link: %v", err)
+	}
+	if entry, err := filer.FindEntry(ctx, "/b/file2"); err != nil || entry.HardLinkCounter != 1 || len(entry.Chunks) != 1 {
+		t.Fatalf("find the remaining link: %+v %v", entry, err)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/b/file2", false, true); err != nil {
+		t.Fatalf("delete the last link: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, filer2.NewFullPath(filer2.HardLinkDir, hardLinkId.String())); err != filer2.ErrNotFound {
+		t.Errorf("the hard link record should be deleted: %v", err)
+	}
+}
+
+func TestQuota(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	ctx := context.Background()
+
+	createFile := func(p string, size uint64) error {
+		return filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: size}},
+		})
+	}
+
+	// the existing files are counted when the quota is set
+	createFile("/team/a/file1", 100)
+	createFile("/other/file", 100)
+	if _, err := filer.SetQuota(ctx, "/team", 300, 3); err != nil {
+		t.Fatalf("set quota: %v", err)
+	}
+
+	if err := createFile("/team/b/file2", 150); err != nil {
+		t.Fatalf("create within quota: %v", err)
+	}
+	if err := createFile("/team/b/file3", 100); !filer2.IsQuotaExceeded(err) {
+		t.Fatalf("create over the byte quota: %v", err)
+	}
+	if err := createFile("/team/b/file2", 200); err != nil {
+		t.Fatalf("overwrite within quota: %v", err)
+	}
+	if err := createFile("/other/big", 1000); err != nil {
+		t.Fatalf("create outside quota: %v", err)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/team/b", true, false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	createFile("/team/file4", 1)
+	createFile("/team/file5", 1)
+	if err := createFile("/team/file6", 1); !filer2.IsQuotaExceeded(err) {
+		t.Fatalf("create over the file quota: %v", err)
+	}
+
+	// the usage is persisted and loaded with the store
+	filer.SetStore(store)
+	quota := filer.GetQuota("/team/a")
+	if quota == nil || quota.Directory != "/team" || quota.UsedBytes != 102 || quota.UsedFiles != 3 {
+		t.Fatalf("quota: %+v", quota)
+	}
+
+	// the quota is removed with the directory
+	if err := filer.DeleteEntryMetaAndData(ctx, "/team", true, false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if quotas := filer.ListQuotas(); len(quotas) != 0 {
+		t.Errorf("quotas: %+v", quotas)
+	}
+}
+
+func TestQuotaWithMultipleFilers(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+
+	// the filers share the store
+	filerA, filerB := filer2.NewFiler(nil, nil), filer2.NewFiler(nil, nil)
+	for _, filer := range []*filer2.Filer{filerA, filerB} {
+		filer.SetStore(store)
+		filer.DisableDirectoryCache()
+	}
+
+	ctx := context.Background()
+	createFile := func(filer *filer2.Filer, p string, size uint64) error {
+		return filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: size}},
+		})
+	}
+
+	createFile(filerA, "/team/first", 100)
+	if _, err := filerA.SetQuota(ctx, "/team", 1000, 10); err != nil {
+		t.Fatalf("set quota: %v", err)
+	}
+	filerB.SetStore(store)
+
+	// the concurrent changes on one filer are checked one by one
+	var wg sync.WaitGroup
+	var exceeded int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := createFile(filerA, fmt.Sprintf("/team/file%d", i), 100); filer2.IsQuotaExceeded(err) {
+				atomic.AddInt32(&exceeded, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if exceeded != 11 {
+		t.Fatalf("%d of 20 files exceeded the quota, expected 11", exceeded)
+	}
+
+	// the changes by the other filer are counted after the cached usage is refreshed, and not overwritten
+	if err := createFile(filerB, "/team/other", 50); err != nil {
+		t.Fatalf("create on the other filer with the cached usage: %v", err)
+	}
+	filerB.DeleteEntryMetaAndData(ctx, "/team/other", false, false)
+	filerA.SetQuotaRefreshInterval(0)
+	filerB.SetQuotaRefreshInterval(0)
+	if err := createFile(filerB, "/team/other", 50); !filer2.IsQuotaExceeded(err) {
+		t.Fatalf("create on the other filer over the quota: %v", err)
+	}
+	if err := filerB.DeleteEntryMetaAndData(ctx, "/team/first", false, false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := createFile(filerA, "/team/last", 50); err != nil {
+		t.Fatalf("create after deleted by the other filer: %v", err)
+	}
+
+	filerC := filer2.NewFiler(nil, nil)
+	filerC.SetStore(store)
+	quota := filerC.GetQuota("/team")
+	if quota == nil || quota.UsedBytes != 950 || quota.UsedFiles != 10 {
+		t.Fatalf("quota: %+v", quota)
+	}
+}
+
+// quotaCountingStore counts the listings of the quota usage records
+type quotaCountingStore struct {
+	*LevelDBStore
+	usageListings int32
+}
+
+func (store *quotaCountingStore) ListDirectoryEntries(ctx context.Context, dirPath filer2.FullPath, startFileName string, includeStartFile bool, limit int) ([]*filer2.Entry, error) {
+	if strings.HasPrefix(string(dirPath), filer2.QuotaUsageDir) {
+		atomic.AddInt32(&store.usageListings, 1)
+	}
+	return store.LevelDBStore.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit)
+}
+
+func TestQuotaCachedUsage(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &quotaCountingStore{LevelDBStore: &LevelDBStore{}}
+	store.initialize(dir)
+
+	filer := filer2.NewFiler(nil, nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	ctx := context.Background()
+	createFile := func(p string, size uint64) error {
+		return filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: size}},
+		})
+	}
+
+	filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/team/a", Attr: filer2.Attr{Mode: os.ModeDir | 0755}})
+	if _, err := filer.SetQuota(ctx, "/team", 1000, 10); err != nil {
+		t.Fatalf("set quota: %v", err)
+	}
+	if _, err := filer.SetQuota(ctx, "/team/a", 500, 10); err != nil {
+		t.Fatalf("set quota: %v", err)
+	}
+
+	// the changes are checked against the cached usage, without loading the usage records
+	listings := atomic.LoadInt32(&store.usageListings)
+	for i := 0; i < 5; i++ {
+		if err := createFile(fmt.Sprintf("/team/a/file%d", i), 100); err != nil {
+			t.Fatalf("create within quota: %v", err)
+		}
+	}
+	if err := createFile("/team/a/file5", 100); !filer2.IsQuotaExceeded(err) {
+		t.Fatalf("create over the nested quota: %v", err)
+	}
+	if n := atomic.LoadInt32(&store.usageListings); n != listings {
+		t.Errorf("listed the quota usages %d times", n-listings)
+	}
+
+	// the usage is loaded again after the refresh interval
+	filer.SetQuotaRefreshInterval(0)
+	if err := createFile("/team/b", 100); err != nil {
+		t.Fatalf("create within quota: %v", err)
+	}
+	if n := atomic.LoadInt32(&store.usageListings); n != listings+1 {
+		t.Errorf("listed the quota usages %d times after the refresh interval", n-listings)
+	}
+
+	quota := filer.GetQuota("/team")
+	if quota == nil || quota.UsedBytes != 600 || quota.UsedFiles != 6 {
+		t.Fatalf("quota: %+v", quota)
+	}
+}
+
+func TestPermission(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	ctx := context.Background()
+	alice := filer2.WithIdentity(ctx, &filer2.Identity{Uid: 1000, Gids: []uint32{1000}})
+	bob := filer2.WithIdentity(ctx, &filer2.Identity{Uid: 1001, Gids: []uint32{1001}})
+	carol := filer2.WithIdentity(ctx, &filer2.Identity{Uid: 1002, Gids: []uint32{1002, 1000}})
+
+	create := func(ctx context.Context, p string, mode os.FileMode, uid, gid uint32) error {
+		return filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: mode, Uid: uid, Gid: gid},
+		})
+	}
+
+	create(ctx, "/home", os.ModeDir|0755, 0, 0)
+	create(ctx, "/home/alice", os.ModeDir|0750, 1000, 1000)
+	create(ctx, "/tmp", os.ModeDir|os.ModeSticky|0777, 0, 0)
+
+	// the new entry is owned by the caller
+	if err := create(alice, "/home/alice/file", 0640, 0, 0); err != nil {
+		t.Fatalf("create own file: %v", err)
+	}
+	entry, _ := filer.FindEntry(ctx, "/home/alice/file")
+	if entry.Uid != 1000 || entry.Gid != 1000 {
+		t.Fatalf("owner %d:%d", entry.Uid, entry.Gid)
+	}
+
+	if err := create(bob, "/home/alice/bob", 0644, 1001, 1001); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("create by others: %v", err)
+	}
+	if _, err := filer.ListDirectoryEntries(bob, "/home/alice", "", false, 100); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("list by others: %v", err)
+	}
+	if _, err := filer.ListDirectoryEntries(carol, "/home/alice", "", false, 100); err != nil {
+		t.Fatalf("list by group: %v", err)
+	}
+	if err := create(carol, "/home/alice/carol", 0644, 1002, 1002); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("create by group: %v", err)
+	}
+
+	// only the owner changes the mode
+	chmod := *entry
+	chmod.Mode = 0600
+	if err := filer.UpdateEntry(carol, entry, &chmod); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("chmod by group: %v", err)
+	}
+	if err := filer.UpdateEntry(alice, entry, &chmod); err != nil {
+		t.Fatalf("chmod by owner: %v", err)
+	}
+
+	// the named user in the ACL
+	dirEntry, _ := filer.FindEntry(ctx, "/home/alice")
+	withAcl := *dirEntry
+	withAcl.Extended = map[string][]byte{
+		filer2.AclAccessXattr: encodeAcl(
+			[3]uint32{0x01, 7, 0}, [3]uint32{0x02, 7, 1001}, [3]uint32{0x04, 5, 0}, [3]uint32{0x10, 7, 0}, [3]uint32{0x20, 0, 0}),
+	}
+	withAcl.Mode = os.ModeDir | 0770
+	if err := filer.UpdateEntry(alice, dirEntry, &withAcl); err != nil {
+		t.Fatalf("setfacl by owner: %v", err)
+	}
+	if err := create(bob, "/home/alice/bob", 0644, 1001, 1001); err != nil {
+		t.Fatalf("create by the named user: %v", err)
+	}
+	if err := create(carol, "/home/alice/carol", 0644, 1002, 1002); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("create by the owning group: %v", err)
+	}
+
+	// only the owner removes the entry in the sticky directory
+	create(alice, "/tmp/alice", 0666, 0, 0)
+	if err := filer.DeleteEntryMetaAndData(bob, "/tmp/alice", false, false); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("delete by others: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(alice, "/tmp/alice", false, false); err != nil {
+		t.Fatalf("delete by owner: %v", err)
+	}
+
+	// finding an entry needs to search all the parent directories
+	create(alice, "/home/alice/private", os.ModeDir|0700, 0, 0)
+	create(alice, "/home/alice/private/file", 0644, 0, 0)
+	if _, err := filer.FindEntry(bob, "/home/alice/private/file"); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("find in a directory not searchable: %v", err)
+	}
+	if _, err := filer.FindEntry(alice, "/home/alice/private/file"); err != nil {
+		t.Fatalf("find by owner: %v", err)
+	}
+
+	// removing a directory recursively checks every directory visited
+	create(bob, "/tmp/bob", os.ModeDir|0777, 0, 0)
+	create(alice, "/tmp/bob/alice", os.ModeDir|0700, 0, 0)
+	create(alice, "/tmp/bob/alice/file", 0644, 0, 0)
+	if err := filer.DeleteEntryMetaAndData(bob, "/tmp/bob", true, false); !filer2.IsPermissionDenied(err) {
+		t.Fatalf("delete the directory of others recursively: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, "/tmp/bob/alice/file"); err != nil {
+		t.Fatalf("find the file after the denied deletion: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(alice, "/tmp/bob/alice", true, false); err != nil {
+		t.Fatalf("delete own directory recursively: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(bob, "/tmp/bob", true, false); err != nil {
+		t.Fatalf("delete recursively: %v", err)
+	}
+}
+
+func encodeAcl(entries ...[3]uint32) []byte {
+	data := make([]byte, 4+8*len(entries))
+	binary.LittleEndian.PutUint32(data, 2)
+	for i, e := range entries {
+		binary.LittleEndian.PutUint16(data[4+8*i:], uint16(e[0]))
+		binary.LittleEndian.PutUint16(data[6+8*i:], uint16(e[1]))
+		binary.LittleEndian.PutUint32(data[8+8*i:], e[2])
+	}
+	return data
+}
+
+func TestLocksSavedInStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+
+	first := filer2.NewLockManager(time.Minute)
+	first.Serve(store, "filer1:8888")
+	if err := first.CheckServing(); err != nil {
+		t.Fatalf("serve locks: %v", err)
+	}
+	writeLock := &filer_pb.FileLock{Client: "c1", Owner: 1, Start: 0, End: 99, IsWrite: true}
+	if conflict := first.Lock("/a/b.db", writeLock); conflict != nil {
+		t.Fatalf("lock: %v", conflict)
+	}
+	flock := &filer_pb.FileLock{Client: "c1", Owner: 2, IsWrite: true, IsFlock: true}
+	first.Lock("/a/c", flock)
+	first.Unlock("/a/c", flock)
+
+	// the other filers sharing the store do not serve the locks
+	second := filer2.NewLockManager(time.Minute)
+	second.Serve(store, "filer2:8888")
+	if err := second.CheckServing(); !filer2.IsNotLockServer(err) || !strings.Contains(err.Error(), "filer1:8888") {
+		t.Fatalf("serve locks on the other filer: %v", err)
+	}
+
+	// the locks are kept when the filer restarts
+	restarted := filer2.NewLockManager(time.Minute)
+	restarted.Serve(store, "filer1:8888")
+	if err := restarted.CheckServing(); err != nil {
+		t.Fatalf("serve locks after restart: %v", err)
+	}
+	if conflict := restarted.QueryLock("/a/b.db", &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 50, End: 50}); conflict == nil || conflict.Client != "c1" {
+		t.Errorf("lock after restart: %v", conflict)
+	}
+	if conflict := restarted.QueryLock("/a/c", &filer_pb.FileLock{Client: "c2", Owner: 1, IsWrite: true, IsFlock: true}); conflict != nil {
+		t.Errorf("unlocked flock after restart: %v", conflict)
+	}
+
+	// the locks of the released client are removed from the store
+	restarted.ReleaseClient("c1")
+	again := filer2.NewLockManager(time.Minute)
+	again.Serve(store, "filer1:8888")
+	if conflict := again.QueryLock("/a/b.db", &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 50, End: 50, IsWrite: true}); conflict != nil {
+		t.Errorf("released lock: %v", conflict)
+	}
+}
+
+func TestDeleteEventOrigin(t *testing.T) {
+	filer := filer2.NewFiler(nil, nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+	metaLog, err := filer2.NewMetaLog(dir+"/meta", time.Hour)
+	if err != nil {
+		t.Fatalf("meta log: %v", err)
+	}
+	filer.MetaLog = metaLog
+
+	ctx := context.Background()
+	for _, name := range []string{"/a/replicated", "/a/local"} {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath(name), Attr: filer2.Attr{Mode: 0644}}); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+	if err := filer.DeleteEntryMetaAndData(filer2.WithOrigin(ctx, "filerB:8888"), "/a/replicated", false, false); err != nil {
+		t.Fatalf("delete replicated: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/a/local", false, false); err != nil {
+		t.Fatalf("delete local: %v", err)
+	}
+
+	// the deletions are logged with the filer where they were made
+	origins := make(map[string]string)
+	subscribeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	metaLog.Subscribe(subscribeCtx, "/a", 0, func(event *filer_pb.SubscribeMetadataResponse) error {
+		if message := event.EventNotification; message.NewEntry == nil {
+			origins[message.OldEntry.Name] = message.Origin
+		}
+		if len(origins) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if origin, found := origins["/a/replicated"]; !found || origin != "filerB:8888" {
+		t.Errorf("replicated deletion origin %q, found %v", origin, found)
+	}
+	if origin, found := origins["/a/local"]; !found || origin != "" {
+		t.Errorf("local deletion origin %q, found %v", origin, found)
+	}
+}
@@ -0,0 +1,435 @@
+package filer2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+/*
+
+The lock manager keeps the advisory file locks of the mounts in memory, and saves them in the filer store
+under LocksDir, so the locks are kept when the filer restarts.
+
+The locks are served by only one of the filers sharing the store. The serving filer claims the lock server record
+in the store, and renews the claim with the same lease as the clients. The other filers reject the lock requests,
+so the mounts using the file locks should connect to the serving filer. If the serving filer is gone for longer
+than the lease, another filer takes over the claim and the saved locks. The claim is not atomic across the filers,
+so two filers starting at the same moment may both claim it until the next renewal.
+
+The POSIX (fcntl) locks are byte range locks, and the flock locks are whole file locks.
+The two kinds of locks do not conflict with each other, the same as on Linux.
+The locks are held by the lock owner in the client, i.e., the mount.
+
+Each client keeps a lease by KeepLocks. If the lease is not renewed in time,
+e.g., the mount is killed or the host is down, all the locks of the client are released.
+
+*/
+
+const (
+	LocksDir          = "/.locks"
+	LockServerDir     = "/.locks.server"
+	locksExtendedKey  = "locks"
+	lockServerRecName = "server"
+)
+
+var ErrNotLockServer = errors.New("not the lock server")
+
+// IsNotLockServer also recognizes the error passed through grpc
+func IsNotLockServer(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ErrNotLockServer.Error())
+}
+
+type LockManager struct {
+	leaseDuration time.Duration
+
+	locksLock sync.Mutex
+	files     map[FullPath]*fileLocks
+	// the lease expiration time of the clients
+	clients map[string]time.Time
+
+	// the store to save the locks, or nil to keep them only in memory
+	store  FilerStore
+	server string
+	// whether this filer holds the lock server claim, or the filer holding it
+	serving  bool
+	servedBy string
+}
+
+type fileLocks struct {
+	posixLocks []*filer_pb.FileLock
+	flockLocks []*filer_pb.FileLock
+	// whether the locks are saved in the store
+	saved bool
+}
+
+func NewLockManager(leaseDuration time.Duration) *LockManager {
+	lm := &LockManager{
+		leaseDuration: leaseDuration,
+		files:         make(map[FullPath]*fileLocks),
+		clients:       make(map[string]time.Time),
+	}
+	go lm.loopExpireClients()
+	return lm
+}
+
+func (lm *LockManager) LeaseDuration() time.Duration {
+	return lm.leaseDuration
+}
+
+// Serve saves the locks in the store, and serves them if this filer, at the server address, claims the lock server
+func (lm *LockManager) Serve(store FilerStore, server string) {
+	lm.locksLock.Lock()
+	lm.store, lm.server = store, server
+	lm.locksLock.Unlock()
+
+	lm.claimServer(time.Now())
+}
+
+// CheckServing returns ErrNotLockServer if the locks are served by another filer
+func (lm *LockManager) CheckServing() error {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	if lm.store != nil && !lm.serving {
+		return fmt.Errorf("%v, the file locks are served by filer %s", ErrNotLockServer, lm.servedBy)
+	}
+	return nil
+}
+
+// Lock acquires the lock, or returns the conflicting lock
+func (lm *LockManager) Lock(path FullPath, lock *filer_pb.FileLock) (conflict *filer_pb.FileLock) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	lm.renewLease(lock.Client, time.Now())
+
+	locks := lm.files[path]
+	if locks == nil {
+		locks = &fileLocks{}
+	}
+
+	lock = proto.Clone(lock).(*filer_pb.FileLock)
+	if lock.IsFlock {
+		if conflict = findConflict(locks.flockLocks, lock); conflict != nil {
+			return conflict
+		}
+		// upgrade or downgrade the existing flock
+		locks.flockLocks = append(removeOwnerLocks(locks.flockLocks, lock), lock)
+	} else {
+		if conflict = findConflict(locks.posixLocks, lock); conflict != nil {
+			return conflict
+		}
+		locks.posixLocks = append(removeOwnerRange(locks.posixLocks, lock), lock)
+	}
+	lm.files[path] = locks
+	lm.saveLocks(path, locks)
+
+	return nil
+}
+
+// Unlock releases the byte range of the POSIX locks, or the flock of the lock owner
+func (lm *LockManager) Unlock(path FullPath, lock *filer_pb.FileLock) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	lm.renewLease(lock.Client, time.Now())
+
+	locks := lm.files[path]
+	if locks == nil {
+		return
+	}
+
+	if lock.IsFlock {
+		locks.flockLocks = removeOwnerLocks(locks.flockLocks, lock)
+	} else {
+		locks.posixLocks = removeOwnerRange(locks.posixLocks, lock)
+	}
+
+	if len(locks.posixLocks) == 0 && len(locks.flockLocks) == 0 {
+		delete(lm.files, path)
+	}
+	lm.saveLocks(path, locks)
+}
+
+// QueryLock returns the lock conflicting with the lock, or nil if the lock can be acquired
+func (lm *LockManager) QueryLock(path FullPath, lock *filer_pb.FileLock) (conflict *filer_pb.FileLock) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	locks := lm.files[path]
+	if locks == nil {
+		return nil
+	}
+	if lock.IsFlock {
+		return findConflict(locks.flockLocks, lock)
+	}
+	return findConflict(locks.posixLocks, lock)
+}
+
+// KeepLocks renews the lease of the client
+func (lm *LockManager) KeepLocks(client string) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	lm.renewLease(client, time.Now())
+}
+
+// ReleaseClient releases all the locks of the client
+func (lm *LockManager) ReleaseClient(client string) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	lm.releaseClients(map[string]bool{client: true})
+}
+
+func (lm *LockManager) renewLease(client string, now time.Time) {
+	lm.clients[client] = now.Add(lm.leaseDuration)
+}
+
+func (lm *LockManager) loopExpireClients() {
+	for now := range time.Tick(lm.leaseDuration / 3) {
+		lm.claimServer(now)
+		lm.expireClients(now)
+	}
+}
+
+func (lm *LockManager) expireClients(now time.Time) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	expiredClients := make(map[string]bool)
+	for client, expiration := range lm.clients {
+		if expiration.Before(now) {
+			glog.V(0).Infof("release the locks of expired client %s", client)
+			expiredClients[client] = true
+		}
+	}
+	if len(expiredClients) > 0 {
+		lm.releaseClients(expiredClients)
+	}
+}
+
+func (lm *LockManager) releaseClients(clients map[string]bool) {
+	for client := range clients {
+		delete(lm.clients, client)
+	}
+	for path, locks := range lm.files {
+		posixCount, flockCount := len(locks.posixLocks), len(locks.flockLocks)
+		locks.posixLocks = removeClientLocks(locks.posixLocks, clients)
+		locks.flockLocks = removeClientLocks(locks.flockLocks, clients)
+		if len(locks.posixLocks) == posixCount && len(locks.flockLocks) == flockCount {
+			continue
+		}
+		if len(locks.posixLocks) == 0 && len(locks.flockLocks) == 0 {
+			delete(lm.files, path)
+		}
+		lm.saveLocks(path, locks)
+	}
+}
+
+func lockRecordPath(path FullPath) FullPath {
+	return NewFullPath(LocksDir, url.QueryEscape(string(path)))
+}
+
+// saveLocks saves the locks of the file in the store, or deletes them if there is none left.
+// The locks in memory are used even if failed to save.
+func (lm *LockManager) saveLocks(path FullPath, locks *fileLocks) {
+	if lm.store == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if len(locks.posixLocks) == 0 && len(locks.flockLocks) == 0 {
+		if locks.saved {
+			if err := lm.store.DeleteEntry(ctx, lockRecordPath(path)); err != nil {
+				glog.Errorf("delete the locks of %s: %v", path, err)
+				return
+			}
+			locks.saved = false
+		}
+		return
+	}
+
+	data, err := proto.Marshal(&filer_pb.FileLocks{
+		Path:       string(path),
+		PosixLocks: locks.posixLocks,
+		FlockLocks: locks.flockLocks,
+	})
+	if err != nil {
+		glog.Errorf("encode the locks of %s: %v", path, err)
+		return
+	}
+	if err = lm.saveRecord(ctx, lockRecordPath(path), locksExtendedKey, data, !locks.saved); err != nil {
+		glog.Errorf("save the locks of %s: %v", path, err)
+		return
+	}
+	locks.saved = true
+}
+
+func (lm *LockManager) saveRecord(ctx context.Context, p FullPath, key string, data []byte, isNew bool) error {
+	now := time.Now()
+	record := &Entry{
+		FullPath: p,
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   0600,
+		},
+		Extended: map[string][]byte{key: data},
+	}
+	if isNew {
+		return lm.store.InsertEntry(ctx, record)
+	}
+	return lm.store.UpdateEntry(ctx, record)
+}
+
+// loadLocks loads the saved locks, and starts the leases of their clients
+func (lm *LockManager) loadLocks(ctx context.Context, now time.Time) error {
+	files := make(map[FullPath]*fileLocks)
+	clients := make(map[string]time.Time)
+
+	lastFileName := ""
+	for {
+		entries, err := lm.store.ListDirectoryEntries(ctx, LocksDir, lastFileName, false, 1024)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			record := &filer_pb.FileLocks{}
+			if err := proto.Unmarshal(entry.Extended[locksExtendedKey], record); err != nil {
+				glog.Errorf("decode locks %s: %v", entry.FullPath, err)
+				continue
+			}
+			files[FullPath(record.Path)] = &fileLocks{posixLocks: record.PosixLocks, flockLocks: record.FlockLocks, saved: true}
+			for _, l := range append(record.PosixLocks, record.FlockLocks...) {
+				clients[l.Client] = now.Add(lm.leaseDuration)
+			}
+		}
+		if len(entries) < 1024 {
+			break
+		}
+	}
+
+	lm.files, lm.clients = files, clients
+	return nil
+}
+
+// claimServer claims or renews the lock server record, unless claimed by another filer before it expires.
+// The saved locks are loaded when the claim is taken.
+func (lm *LockManager) claimServer(now time.Time) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+
+	if lm.store == nil {
+		return
+	}
+	ctx := context.Background()
+	p := NewFullPath(LockServerDir, lockServerRecName)
+
+	claim := &filer_pb.LockServer{}
+	entry, err := lm.store.FindEntry(ctx, p)
+	if err != nil && err != ErrNotFound {
+		glog.Errorf("find the lock server: %v", err)
+		return
+	}
+	found := err == nil
+	if found {
+		if err = proto.Unmarshal(entry.Extended[locksExtendedKey], claim); err != nil {
+			glog.Errorf("decode the lock server: %v", err)
+		}
+	}
+
+	if found && claim.Server != lm.server && claim.ExpireAtNs > now.UnixNano() {
+		if lm.serving || lm.servedBy != claim.Server {
+			glog.V(0).Infof("the file locks are served by filer %s", claim.Server)
+		}
+		lm.serving, lm.servedBy = false, claim.Server
+		lm.files, lm.clients = make(map[FullPath]*fileLocks), make(map[string]time.Time)
+		return
+	}
+
+	claim.Server, claim.ExpireAtNs = lm.server, now.Add(lm.leaseDuration).UnixNano()
+	data, err := proto.Marshal(claim)
+	if err == nil {
+		err = lm.saveRecord(ctx, p, locksExtendedKey, data, !found)
+	}
+	if err != nil {
+		glog.Errorf("claim the lock server: %v", err)
+		return
+	}
+
+	if !lm.serving {
+		if err = lm.loadLocks(ctx, now); err != nil {
+			glog.Errorf("load the file locks: %v", err)
+			return
+		}
+		glog.V(0).Infof("serving the file locks of %d files", len(lm.files))
+		lm.serving, lm.servedBy = true, lm.server
+	}
+}
+
+func isSameOwner(a, b *filer_pb.FileLock) bool {
+	return a.Client == b.Client && a.Owner == b.Owner
+}
+
+func findConflict(locks []*filer_pb.FileLock, lock *filer_pb.FileLock) *filer_pb.FileLock {
+	for _, l := range locks {
+		if isSameOwner(l, lock) || !l.IsWrite && !lock.IsWrite {
+			continue
+		}
+		// the flock is on the whole file
+		if lock.IsFlock || l.Start <= lock.End && lock.Start <= l.End {
+			return l
+		}
+	}
+	return nil
+}
+
+func removeOwnerLocks(locks []*filer_pb.FileLock, lock *filer_pb.FileLock) (remaining []*filer_pb.FileLock) {
+	for _, l := range locks {
+		if !isSameOwner(l, lock) {
+			remaining = append(remaining, l)
+		}
+	}
+	return
+}
+
+// removeOwnerRange removes the byte range from the locks of the lock owner, splitting the locks if needed
+func removeOwnerRange(locks []*filer_pb.FileLock, lock *filer_pb.FileLock) (remaining []*filer_pb.FileLock) {
+	for _, l := range locks {
+		if !isSameOwner(l, lock) || l.End < lock.Start || lock.End < l.Start {
+			remaining = append(remaining, l)
+			continue
+		}
+		if l.Start < lock.Start {
+			left := proto.Clone(l).(*filer_pb.FileLock)
+			left.End = lock.Start - 1
+			remaining = append(remaining, left)
+		}
+		if lock.End < l.End {
+			right := proto.Clone(l).(*filer_pb.FileLock)
+			right.Start = lock.End + 1
+			remaining = append(remaining, right)
+		}
+	}
+	return
+}
+
+func removeClientLocks(locks []*filer_pb.FileLock, clients map[string]bool) (remaining []*filer_pb.FileLock) {
+	for _, l := range locks {
+		if !clients[l.Client] {
+			remaining = append(remaining, l)
+		}
+	}
+	return
+}
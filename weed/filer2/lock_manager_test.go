@@ -0,0 +1,107 @@
+package filer2
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestPosixLocks(t *testing.T) {
+
+	lm := &LockManager{
+		leaseDuration: time.Minute,
+		files:         make(map[FullPath]*fileLocks),
+		clients:       make(map[string]time.Time),
+	}
+	path := FullPath("/a/b.db")
+
+	// read locks are shared
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, Start: 0, End: 99}); conflict != nil {
+		t.Fatalf("read lock: %v", conflict)
+	}
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 50, End: 149}); conflict != nil {
+		t.Fatalf("shared read lock: %v", conflict)
+	}
+
+	// the write lock conflicts with the read locks of the other owners
+	writeLock := &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 90, End: 199, IsWrite: true}
+	if conflict := lm.Lock(path, writeLock); conflict == nil || conflict.Client != "c1" {
+		t.Fatalf("conflicting write lock: %v", conflict)
+	}
+
+	// unlock the middle of the read lock, and split it into [0,9] and [91,99]
+	lm.Unlock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, Start: 10, End: 90})
+	if len(lm.files[path].posixLocks) != 3 {
+		t.Fatalf("unexpected locks: %v", lm.files[path].posixLocks)
+	}
+	if conflict := lm.QueryLock(path, &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 10, End: 90, IsWrite: true}); conflict != nil {
+		t.Errorf("unlocked range: %v", conflict)
+	}
+	if conflict := lm.QueryLock(path, &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 95, End: 95, IsWrite: true}); conflict == nil || conflict.Start != 91 || conflict.End != 99 {
+		t.Errorf("split lock: %v", conflict)
+	}
+
+	// upgrade the own read lock to the write lock, keeping the read lock on [50,89]
+	lm.Unlock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, Start: 0, End: math.MaxUint64})
+	if conflict := lm.Lock(path, writeLock); conflict != nil {
+		t.Fatalf("upgrade to write lock: %v", conflict)
+	}
+	if conflict := lm.QueryLock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, Start: 150, End: 150}); conflict == nil || !conflict.IsWrite {
+		t.Errorf("read lock on the write locked range: %v", conflict)
+	}
+	if conflict := lm.QueryLock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, Start: 0, End: 49, IsWrite: true}); conflict != nil {
+		t.Errorf("unlocked range: %v", conflict)
+	}
+
+	lm.Unlock(path, &filer_pb.FileLock{Client: "c2", Owner: 1, Start: 0, End: math.MaxUint64})
+	if _, found := lm.files[path]; found {
+		t.Errorf("all locks should be released: %v", lm.files[path])
+	}
+
+}
+
+func TestFlockLocks(t *testing.T) {
+
+	lm := &LockManager{
+		leaseDuration: time.Minute,
+		files:         make(map[FullPath]*fileLocks),
+		clients:       make(map[string]time.Time),
+	}
+	path := FullPath("/a/build.lock")
+
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, IsFlock: true, IsWrite: true}); conflict != nil {
+		t.Fatalf("flock: %v", conflict)
+	}
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c2", Owner: 2, IsFlock: true}); conflict == nil {
+		t.Fatalf("shared flock should conflict with the exclusive flock")
+	}
+	// the flock does not conflict with the POSIX locks
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c2", Owner: 2, End: math.MaxUint64, IsWrite: true}); conflict != nil {
+		t.Fatalf("posix lock: %v", conflict)
+	}
+
+	// downgrade to the shared flock
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c1", Owner: 1, IsFlock: true}); conflict != nil || len(lm.files[path].flockLocks) != 1 {
+		t.Fatalf("downgrade flock: %v", conflict)
+	}
+	if conflict := lm.Lock(path, &filer_pb.FileLock{Client: "c2", Owner: 2, IsFlock: true}); conflict != nil {
+		t.Fatalf("shared flock: %v", conflict)
+	}
+
+	// the client c1 disappears
+	lm.KeepLocks("c2")
+	lm.expireClients(time.Now())
+	lm.clients["c1"] = time.Now().Add(-time.Second)
+	lm.expireClients(time.Now())
+	if len(lm.files[path].flockLocks) != 1 || lm.files[path].flockLocks[0].Client != "c2" {
+		t.Errorf("the locks of the expired client should be released: %v", lm.files[path].flockLocks)
+	}
+
+	lm.ReleaseClient("c2")
+	if _, found := lm.files[path]; found {
+		t.Errorf("all locks should be released: %v", lm.files[path])
+	}
+
+}
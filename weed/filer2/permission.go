@@ -1,22 +1,316 @@
 package filer2
 
-func hasWritePermission(dir *Entry, entry *Entry) bool {
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 
-	if dir == nil {
-		return false
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+/*
+
+The permissions are the POSIX mode bits of the owner, the group and the others,
+plus the optional named users and groups in the POSIX access ACL,
+which is kept in the extended attributes in the same format as the Linux xattr,
+so "setfacl" and "getfacl" work on "weed mount".
+
+As on Linux, if the ACL has the mask entry, the group bits of the mode are the mask.
+
+*/
+
+const (
+	PermRead  = 04
+	PermWrite = 02
+	PermExec  = 01
+
+	AclAccessXattr  = "system.posix_acl_access"
+	AclDefaultXattr = "system.posix_acl_default"
+
+	aclVersion  = 2
+	aclUserObj  = 0x01
+	aclUser     = 0x02
+	aclGroupObj = 0x04
+	aclGroup    = 0x08
+	aclMask     = 0x10
+	aclOther    = 0x20
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// IsPermissionDenied also recognizes the error passed through grpc
+func IsPermissionDenied(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ErrPermissionDenied.Error())
+}
+
+type aclEntry struct {
+	tag  uint16
+	perm uint16
+	id   uint32
+}
+
+func parseAcl(data []byte) ([]aclEntry, error) {
+	if len(data) < 4 || (len(data)-4)%8 != 0 {
+		return nil, fmt.Errorf("invalid acl size %d", len(data))
+	}
+	if version := binary.LittleEndian.Uint32(data); version != aclVersion {
+		return nil, fmt.Errorf("unsupported acl version %d", version)
 	}
+	var entries []aclEntry
+	for i := 4; i < len(data); i += 8 {
+		entries = append(entries, aclEntry{
+			tag:  binary.LittleEndian.Uint16(data[i:]),
+			perm: binary.LittleEndian.Uint16(data[i+2:]),
+			id:   binary.LittleEndian.Uint32(data[i+4:]),
+		})
+	}
+	return entries, nil
+}
+
+// HasPermission checks whether the identity has all the permission bits on the entry with the owner, mode and extended attributes
+func HasPermission(id *Identity, uid, gid uint32, mode os.FileMode, extended map[string][]byte, perm uint32) bool {
 
-	if dir.Uid == entry.Uid && dir.Mode&0200 > 0 {
+	if id == nil || id.IsRoot() {
 		return true
 	}
+	if id == Anonymous {
+		return false
+	}
 
-	if dir.Gid == entry.Gid && dir.Mode&0020 > 0 {
-		return true
+	if id.Uid == uid {
+		return uint32(mode>>6)&perm == perm
 	}
 
-	if dir.Mode&0002 > 0 {
-		return true
+	var acl []aclEntry
+	if data, found := extended[AclAccessXattr]; found {
+		var err error
+		if acl, err = parseAcl(data); err != nil {
+			acl = nil
+		}
 	}
 
+	groupPerm, mask := uint32(mode>>3)&07, uint32(07)
+	if hasAclEntry(acl, aclMask) {
+		// the group bits of the mode are the mask, and the owning group has its own entry
+		mask = groupPerm
+		for _, e := range acl {
+			if e.tag == aclGroupObj {
+				groupPerm = uint32(e.perm)
+			}
+		}
+	}
+
+	for _, e := range acl {
+		if e.tag == aclUser && e.id == id.Uid {
+			return uint32(e.perm)&mask&perm == perm
+		}
+	}
+
+	inAnyGroup := false
+	if id.InGroup(gid) {
+		inAnyGroup = true
+		if groupPerm&mask&perm == perm {
+			return true
+		}
+	}
+	for _, e := range acl {
+		if e.tag == aclGroup && id.InGroup(e.id) {
+			inAnyGroup = true
+			if uint32(e.perm)&mask&perm == perm {
+				return true
+			}
+		}
+	}
+	if inAnyGroup {
+		return false
+	}
+
+	return uint32(mode)&perm == perm
+}
+
+func hasAclEntry(acl []aclEntry, tag uint16) bool {
+	for _, e := range acl {
+		if e.tag == tag {
+			return true
+		}
+	}
 	return false
 }
+
+func (entry *Entry) hasPermission(id *Identity, perm uint32) bool {
+	return HasPermission(id, entry.Uid, entry.Gid, entry.Mode, entry.Extended, perm)
+}
+
+func (entry *Entry) isOwnedBy(id *Identity) bool {
+	return id == nil || id.IsRoot() || id != Anonymous && id.Uid == entry.Uid
+}
+
+func permissionDenied(p FullPath, id *Identity, op string) error {
+	return fmt.Errorf("%s %s as %v: %v", op, p, id, ErrPermissionDenied)
+}
+
+// checkParentWritable checks the write and search permission on the parent directory to create or remove an entry
+func checkParentWritable(id *Identity, dir *Entry, p FullPath, op string) error {
+	if !dir.hasPermission(id, PermWrite|PermExec) {
+		return permissionDenied(p, id, op)
+	}
+	return nil
+}
+
+// checkSticky only allows the owner of the entry or the directory to remove or rename the entry in a sticky directory
+func checkSticky(id *Identity, dir, entry *Entry, p FullPath, op string) error {
+	if dir.Mode&os.ModeSticky == 0 || entry.isOwnedBy(id) || dir.isOwnedBy(id) {
+		return nil
+	}
+	return permissionDenied(p, id, op)
+}
+
+// checkUpdate checks the permission to change the old entry into the new entry
+func checkUpdate(id *Identity, oldEntry, entry *Entry) error {
+
+	if id == nil || id.IsRoot() {
+		return nil
+	}
+
+	p := entry.FullPath
+
+	if entry.Uid != oldEntry.Uid {
+		return permissionDenied(p, id, "chown")
+	}
+	if entry.Gid != oldEntry.Gid && !(oldEntry.isOwnedBy(id) && id.InGroup(entry.Gid)) {
+		return permissionDenied(p, id, "chgrp")
+	}
+	if entry.Mode != oldEntry.Mode && !oldEntry.isOwnedBy(id) {
+		return permissionDenied(p, id, "chmod")
+	}
+	if !equalExtendedKey(oldEntry.Extended, entry.Extended, AclAccessXattr) ||
+		!equalExtendedKey(oldEntry.Extended, entry.Extended, AclDefaultXattr) {
+		if !oldEntry.isOwnedBy(id) {
+			return permissionDenied(p, id, "setfacl")
+		}
+	}
+	if !equalChunks(oldEntry.Chunks, entry.Chunks) && !oldEntry.hasPermission(id, PermWrite) {
+		return permissionDenied(p, id, "write")
+	}
+	if !oldEntry.isOwnedBy(id) && !oldEntry.hasPermission(id, PermWrite) {
+		return permissionDenied(p, id, "update")
+	}
+
+	return nil
+}
+
+func equalChunks(a, b []*filer_pb.FileChunk) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].FileId != b[i].FileId || a[i].Offset != b[i].Offset || a[i].Size != b[i].Size {
+			return false
+		}
+	}
+	return true
+}
+
+func equalExtendedKey(a, b map[string][]byte, key string) bool {
+	x, foundX := a[key]
+	y, foundY := b[key]
+	return foundX == foundY && string(x) == string(y)
+}
+
+// setOwner makes the caller own the new entry, keeping the group if the caller is in the group
+func setOwner(id *Identity, dir, entry *Entry) {
+	if id == nil || id.IsRoot() {
+		return
+	}
+	entry.Uid = id.Uid
+	if dir.Mode&os.ModeSetgid != 0 {
+		entry.Gid = dir.Gid
+	} else if !id.InGroup(entry.Gid) {
+		entry.Gid = id.PrimaryGid()
+	}
+}
+
+// keepPermissions keeps the owner of the old entry when it is overwritten,
+// and also the group, the mode and the ACL unless the caller is the owner
+func keepPermissions(id *Identity, oldEntry, entry *Entry) {
+	if oldEntry.isOwnedBy(id) {
+		entry.Uid = oldEntry.Uid
+		if !id.InGroup(entry.Gid) {
+			entry.Gid = oldEntry.Gid
+		}
+		return
+	}
+	entry.Uid, entry.Gid, entry.Mode = oldEntry.Uid, oldEntry.Gid, oldEntry.Mode
+	for _, key := range []string{AclAccessXattr, AclDefaultXattr} {
+		data, found := oldEntry.Extended[key]
+		if !found {
+			delete(entry.Extended, key)
+			continue
+		}
+		if entry.Extended == nil {
+			entry.Extended = make(map[string][]byte)
+		}
+		entry.Extended[key] = data
+	}
+}
+
+// inheritAcl copies the default ACL of the directory to the new entry
+func inheritAcl(dir, entry *Entry) {
+	data, found := dir.Extended[AclDefaultXattr]
+	if !found {
+		return
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	if _, found := entry.Extended[AclAccessXattr]; !found {
+		entry.Extended[AclAccessXattr] = data
+	}
+	if entry.IsDirectory() {
+		if _, found := entry.Extended[AclDefaultXattr]; !found {
+			entry.Extended[AclDefaultXattr] = data
+		}
+	}
+}
+
+// CheckRename checks the permission to move the entry to the new path
+func (f *Filer) CheckRename(ctx context.Context, entry *Entry, newPath FullPath) error {
+
+	id := IdentityFromContext(ctx)
+	if id == nil || id.IsRoot() {
+		return nil
+	}
+
+	oldDir, _ := entry.FullPath.DirAndName()
+	newDir, _ := newPath.DirAndName()
+
+	for _, p := range []FullPath{entry.FullPath, newPath} {
+		dir, _ := p.DirAndName()
+		dirEntry, err := f.FindEntry(ctx, FullPath(dir))
+		if err != nil {
+			return fmt.Errorf("find %s: %v", dir, err)
+		}
+		if err = checkParentWritable(id, dirEntry, p, "rename"); err != nil {
+			return err
+		}
+		target := entry
+		if p == newPath {
+			if target, err = f.FindEntry(ctx, newPath); err != nil {
+				continue
+			}
+		}
+		if err = checkSticky(id, dirEntry, target, p, "rename"); err != nil {
+			return err
+		}
+	}
+
+	// the moved directory is changed to the new parent
+	if entry.IsDirectory() && oldDir != newDir && !entry.hasPermission(id, PermWrite) {
+		return permissionDenied(entry.FullPath, id, "rename")
+	}
+
+	return nil
+}
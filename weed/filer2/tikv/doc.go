@@ -0,0 +1,7 @@
+/*
+Package tikv is the filer store on TiKV, a distributed transactional key value store.
+
+It is only built with "-tags tikv", since the TiKV client is not vendored by default.
+Add the client with "go get github.com/tikv/client-go" and build in the module mode, without "-mod=vendor".
+*/
+package tikv
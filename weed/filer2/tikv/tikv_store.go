@@ -0,0 +1,123 @@
+// +build tikv
+
+package tikv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tikv/client-go/config"
+	"github.com/tikv/client-go/key"
+	"github.com/tikv/client-go/txnkv"
+	"github.com/tikv/client-go/txnkv/kv"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/abstract_kv"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	weed_util "gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &TikvStore{})
+}
+
+type TikvStore struct {
+	abstract_kv.AbstractKvStore
+	client *txnkv.Client
+}
+
+type tikvTxKey struct{}
+
+func (store *TikvStore) GetName() string {
+	return "tikv"
+}
+
+func (store *TikvStore) Initialize(configuration weed_util.Configuration) (err error) {
+	return store.initialize(configuration.GetStringSlice("pd_addresses"))
+}
+
+func (store *TikvStore) initialize(pdAddresses []string) (err error) {
+	glog.Infof("filer store tikv pd: %v", pdAddresses)
+	if store.client, err = txnkv.NewClient(context.Background(), pdAddresses, config.Default()); err != nil {
+		return fmt.Errorf("connect to tikv %v: %v", pdAddresses, err)
+	}
+	store.Kv = store
+	return nil
+}
+
+func (store *TikvStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	tx, err := store.client.Begin(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, tikvTxKey{}, tx), nil
+}
+func (store *TikvStore) CommitTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(tikvTxKey{}).(*txnkv.Transaction); ok {
+		return tx.Commit(ctx)
+	}
+	return nil
+}
+func (store *TikvStore) RollbackTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(tikvTxKey{}).(*txnkv.Transaction); ok {
+		return tx.Rollback()
+	}
+	return nil
+}
+
+// withTx runs fn in the transaction of the context, or in a new transaction committed after fn
+func (store *TikvStore) withTx(ctx context.Context, fn func(tx *txnkv.Transaction) error) error {
+	if tx, ok := ctx.Value(tikvTxKey{}).(*txnkv.Transaction); ok {
+		return fn(tx)
+	}
+	tx, err := store.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (store *TikvStore) KvGet(ctx context.Context, k []byte) (value []byte, err error) {
+	err = store.withTx(ctx, func(tx *txnkv.Transaction) error {
+		value, err = tx.Get(ctx, key.Key(k))
+		if kv.IsErrNotFound(err) {
+			return abstract_kv.ErrKvNotFound
+		}
+		return err
+	})
+	return
+}
+
+func (store *TikvStore) KvPut(ctx context.Context, k []byte, value []byte) error {
+	return store.withTx(ctx, func(tx *txnkv.Transaction) error {
+		return tx.Set(key.Key(k), value)
+	})
+}
+
+func (store *TikvStore) KvDelete(ctx context.Context, k []byte) error {
+	return store.withTx(ctx, func(tx *txnkv.Transaction) error {
+		return tx.Delete(key.Key(k))
+	})
+}
+
+func (store *TikvStore) KvScan(ctx context.Context, prefix []byte, startKey []byte, fn func(key, value []byte) bool) error {
+	return store.withTx(ctx, func(tx *txnkv.Transaction) error {
+		iter, err := tx.Iter(ctx, key.Key(startKey), key.Key(abstract_kv.PrefixEnd(prefix)))
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+		for iter.Valid() {
+			if !fn(iter.Key(), iter.Value()) {
+				break
+			}
+			if err = iter.Next(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -15,9 +15,9 @@ import (
 )
 
 type Dir struct {
-	Path       string
-	wfs        *WFS
-	attributes *filer_pb.FuseAttributes
+	Path  string
+	wfs   *WFS
+	entry *filer_pb.Entry
 }
 
 var _ = fs.Node(&Dir{})
@@ -74,7 +74,7 @@ func (dir *Dir) Attr(ctx context.Context, attr *fuse.Attr) error {
 		}
 
 		if resp.Entry != nil {
-			dir.attributes = resp.Entry.Attributes
+			dir.entry = resp.Entry
 		}
 
 		// dir.wfs.listDirectoryEntriesCache.Set(dir.Path, resp.Entry, dir.wfs.option.EntryCacheTtl)
@@ -89,12 +89,12 @@ func (dir *Dir) Attr(ctx context.Context, attr *fuse.Attr) error {
 	// glog.V(1).Infof("dir %s: %v", dir.Path, attributes)
 	// glog.V(1).Infof("dir %s permission: %v", dir.Path, os.FileMode(attributes.FileMode))
 
-	attr.Mode = os.FileMode(dir.attributes.FileMode) | os.ModeDir
+	attr.Mode = os.FileMode(dir.entry.Attributes.FileMode) | os.ModeDir
 
-	attr.Mtime = time.Unix(dir.attributes.Mtime, 0)
-	attr.Ctime = time.Unix(dir.attributes.Crtime, 0)
-	attr.Gid = dir.attributes.Gid
-	attr.Uid = dir.attributes.Uid
+	attr.Mtime = time.Unix(dir.entry.Attributes.Mtime, 0)
+	attr.Ctime = time.Unix(dir.entry.Attributes.Crtime, 0)
+	attr.Gid = dir.entry.Attributes.Gid
+	attr.Uid = dir.entry.Attributes.Uid
 
 	return nil
 }
@@ -112,6 +112,11 @@ func (dir *Dir) newFile(name string, entry *filer_pb.Entry) *File {
 func (dir *Dir) Create(ctx context.Context, req *fuse.CreateRequest,
 	resp *fuse.CreateResponse) (fs.Node, fs.Handle, error) {
 
+	// the file is created in the filer when it is flushed
+	if err := dir.checkPermission(ctx, filer2.PermWrite|filer2.PermExec); err != nil {
+		return nil, nil, err
+	}
+
 	request := &filer_pb.CreateEntryRequest{
 		Directory: dir.Path,
 		Entry: &filer_pb.Entry{
@@ -135,7 +140,7 @@ func (dir *Dir) Create(ctx context.Context, req *fuse.CreateRequest,
 		if err := dir.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 			if _, err := client.CreateEntry(ctx, request); err != nil {
 				glog.V(0).Infof("create %s/%s: %v", dir.Path, req.Name, err)
-				return fuse.EIO
+				return filerErrno(err, fuse.EIO)
 			}
 			return nil
 		}); err != nil {
@@ -175,7 +180,7 @@ func (dir *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, err
 		glog.V(1).Infof("mkdir: %v", request)
 		if _, err := client.CreateEntry(ctx, request); err != nil {
 			glog.V(0).Infof("mkdir %s/%s: %v", dir.Path, req.Name, err)
-			return fuse.EIO
+			return filerErrno(err, fuse.EIO)
 		}
 
 		return nil
@@ -191,6 +196,10 @@ func (dir *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, err
 
 func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.LookupResponse) (node fs.Node, err error) {
 
+	if err = dir.checkPermission(ctx, filer2.PermExec); err != nil {
+		return nil, err
+	}
+
 	var entry *filer_pb.Entry
 
 	item := dir.wfs.listDirectoryEntriesCache.Get(path.Join(dir.Path, req.Name))
@@ -223,7 +232,7 @@ func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.
 
 	if entry != nil {
 		if entry.IsDirectory {
-			node = &Dir{Path: path.Join(dir.Path, req.Name), wfs: dir.wfs, attributes: entry.Attributes}
+			node = &Dir{Path: path.Join(dir.Path, req.Name), wfs: dir.wfs, entry: entry}
 		} else {
 			node = dir.newFile(req.Name, entry)
 		}
@@ -262,7 +271,7 @@ func (dir *Dir) ReadDirAll(ctx context.Context) (ret []fuse.Dirent, err error) {
 			resp, err := client.ListEntries(ctx, request)
 			if err != nil {
 				glog.V(0).Infof("list %s: %v", dir.Path, err)
-				return fuse.EIO
+				return filerErrno(err, fuse.EIO)
 			}
 
 			cacheTtl := estimatedCacheTtl(len(resp.Entries))
@@ -329,21 +338,25 @@ func (dir *Dir) removeOneFile(ctx context.Context, req *fuse.RemoveRequest) erro
 		return err
 	}
 
-	dir.wfs.deleteFileChunks(ctx, entry.Chunks)
+	// the filer deletes the chunks of the hard link with the last link
+	isHardLink := len(entry.HardLinkId) > 0
+	if !isHardLink {
+		dir.wfs.deleteFileChunks(ctx, entry.Chunks)
+	}
 
 	return dir.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 
 		request := &filer_pb.DeleteEntryRequest{
 			Directory:    dir.Path,
 			Name:         req.Name,
-			IsDeleteData: false,
+			IsDeleteData: isHardLink,
 		}
 
 		glog.V(3).Infof("remove file: %v", request)
 		_, err := client.DeleteEntry(ctx, request)
 		if err != nil {
 			glog.V(3).Infof("remove file %s/%s: %v", dir.Path, req.Name, err)
-			return fuse.ENOENT
+			return filerErrno(err, fuse.ENOENT)
 		}
 
 		dir.wfs.listDirectoryEntriesCache.Delete(path.Join(dir.Path, req.Name))
@@ -367,7 +380,7 @@ func (dir *Dir) removeFolder(ctx context.Context, req *fuse.RemoveRequest) error
 		_, err := client.DeleteEntry(ctx, request)
 		if err != nil {
 			glog.V(3).Infof("remove %s/%s: %v", dir.Path, req.Name, err)
-			return fuse.ENOENT
+			return filerErrno(err, fuse.ENOENT)
 		}
 
 		dir.wfs.listDirectoryEntriesCache.Delete(path.Join(dir.Path, req.Name))
@@ -379,25 +392,28 @@ func (dir *Dir) removeFolder(ctx context.Context, req *fuse.RemoveRequest) error
 
 func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse.SetattrResponse) error {
 
-	if dir.attributes == nil {
+	if dir.entry == nil || dir.entry.Attributes == nil {
 		return nil
 	}
 
 	glog.V(3).Infof("%v dir setattr %+v, fh=%d", dir.Path, req, req.Handle)
+	if err := checkSetattr(ctx, dir.entry.Attributes, req); err != nil {
+		return err
+	}
 	if req.Valid.Mode() {
-		dir.attributes.FileMode = uint32(req.Mode)
+		dir.entry.Attributes.FileMode = uint32(req.Mode)
 	}
 
 	if req.Valid.Uid() {
-		dir.attributes.Uid = req.Uid
+		dir.entry.Attributes.Uid = req.Uid
 	}
 
 	if req.Valid.Gid() {
-		dir.attributes.Gid = req.Gid
+		dir.entry.Attributes.Gid = req.Gid
 	}
 
 	if req.Valid.Mtime() {
-		dir.attributes.Mtime = req.Mtime.Unix()
+		dir.entry.Attributes.Mtime = req.Mtime.Unix()
 	}
 
 	parentDir, name := filer2.FullPath(dir.Path).DirAndName()
@@ -407,7 +423,8 @@ func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fus
 			Directory: parentDir,
 			Entry: &filer_pb.Entry{
 				Name:       name,
-				Attributes: dir.attributes,
+				Attributes: dir.entry.Attributes,
+				Extended:   dir.entry.Extended,
 			},
 		}
 
@@ -415,7 +432,8 @@ func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fus
 		_, err := client.UpdateEntry(ctx, request)
 		if err != nil {
 			glog.V(0).Infof("UpdateEntry %s: %v", dir.Path, err)
-			return fuse.EIO
+			dir.entry = nil
+			return filerErrno(err, fuse.EIO)
 		}
 
 		dir.wfs.listDirectoryEntriesCache.Delete(dir.Path)
@@ -12,9 +12,53 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 )
 
+var _ = fs.NodeLinker(&Dir{})
 var _ = fs.NodeSymlinker(&Dir{})
 var _ = fs.NodeReadlinker(&File{})
 
+// Link creates a hard link to the file, sharing the chunks and attributes kept by the filer
+func (dir *Dir) Link(ctx context.Context, req *fuse.LinkRequest, old fs.Node) (fs.Node, error) {
+
+	oldFile, ok := old.(*File)
+	if !ok {
+		return nil, fuse.EPERM
+	}
+
+	glog.V(3).Infof("Link: %v/%v to %v", dir.Path, req.NewName, oldFile.fullpath())
+
+	// the filer counts the links
+	request := &filer_pb.LinkEntryRequest{
+		OldDirectory: oldFile.dir.Path,
+		OldName:      oldFile.Name,
+		NewDirectory: dir.Path,
+		NewName:      req.NewName,
+	}
+
+	var entry *filer_pb.Entry
+	err := dir.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.LinkEntry(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("link %s/%s: %v", dir.Path, req.NewName, err)
+			return filerErrno(err, fuse.EIO)
+		}
+		entry = resp.Entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the old file is a hard link now, with the new link counter
+	dir.wfs.listDirectoryEntriesCache.Delete(oldFile.fullpath())
+	if oldFile.entry != nil {
+		oldFile.entry.HardLinkId = entry.HardLinkId
+		oldFile.entry.HardLinkCounter = entry.HardLinkCounter
+	}
+
+	return dir.newFile(req.NewName, entry), nil
+
+}
+
 func (dir *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node, error) {
 
 	glog.V(3).Infof("Symlink: %v/%v to %v", dir.Path, req.NewName, req.Target)
@@ -38,7 +82,7 @@ func (dir *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node,
 	err := dir.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 		if _, err := client.CreateEntry(ctx, request); err != nil {
 			glog.V(0).Infof("symlink %s/%s: %v", dir.Path, req.NewName, err)
-			return fuse.EIO
+			return filerErrno(err, fuse.EIO)
 		}
 		return nil
 	})
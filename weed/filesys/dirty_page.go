@@ -14,155 +14,130 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
 )
 
-type ContinuousDirtyPages struct {
-	hasData bool
-	Offset  int64
-	Size    int64
-	Data    []byte
-	f       *File
-	lock    sync.Mutex
+type DirtyPages struct {
+	intervals dirtyIntervals
+	f         *File
+	lock      sync.Mutex
 }
 
-func newDirtyPages(file *File) *ContinuousDirtyPages {
-	return &ContinuousDirtyPages{
-		Data: nil,
-		f:    file,
+func newDirtyPages(file *File) *DirtyPages {
+	return &DirtyPages{
+		f: file,
 	}
 }
 
-func (pages *ContinuousDirtyPages) releaseResource() {
-	if pages.Data != nil {
-		pages.f.wfs.bufPool.Put(pages.Data)
-		pages.Data = nil
-		atomic.AddInt32(&counter, -1)
-		glog.V(3).Infof("%s/%s releasing resource %d", pages.f.dir.Path, pages.f.Name, counter)
-	}
-}
+func (pages *DirtyPages) releaseResource() {
+	pages.lock.Lock()
+	defer pages.lock.Unlock()
 
-var counter = int32(0)
+	memorySize := pages.intervals.memorySize
+	pages.intervals.destroy()
+	atomic.AddInt64(&pages.f.wfs.dirtyPagesMemory, -memorySize)
+	glog.V(3).Infof("%s/%s releasing resource %d", pages.f.dir.Path, pages.f.Name, memorySize)
+}
 
-func (pages *ContinuousDirtyPages) AddPage(ctx context.Context, offset int64, data []byte) (chunks []*filer_pb.FileChunk, err error) {
+// AddPage buffers the written data, and saves the intervals reaching the chunk size to the volume servers
+func (pages *DirtyPages) AddPage(ctx context.Context, offset int64, data []byte) (chunks []*filer_pb.FileChunk, err error) {
 
 	pages.lock.Lock()
 	defer pages.lock.Unlock()
 
-	var chunk *filer_pb.FileChunk
+	memorySize := pages.intervals.memorySize
+	defer func() {
+		atomic.AddInt64(&pages.f.wfs.dirtyPagesMemory, pages.intervals.memorySize-memorySize)
+	}()
 
-	if len(data) > int(pages.f.wfs.option.ChunkSizeLimit) {
-		// this is more than what buffer can hold.
-		return pages.flushAndSave(ctx, offset, data)
+	interval, err := pages.intervals.add(offset, data)
+	if err != nil {
+		return nil, err
 	}
 
-	if pages.Data == nil {
-		pages.Data = pages.f.wfs.bufPool.Get().([]byte)
-		atomic.AddInt32(&counter, 1)
-		glog.V(3).Infof("%s/%s acquire resource %d", pages.f.dir.Path, pages.f.Name, counter)
+	if interval.size() >= pages.f.wfs.option.ChunkSizeLimit {
+		glog.V(4).Infof("%s/%s add save [%d,%d)", pages.f.dir.Path, pages.f.Name, interval.start, interval.stop)
+		return pages.saveIntervalToStorage(ctx, interval)
 	}
 
-	if offset < pages.Offset || offset >= pages.Offset+int64(len(pages.Data)) ||
-		pages.Offset+int64(len(pages.Data)) < offset+int64(len(data)) {
-		// if the data is out of range,
-		// or buffer is full if adding new data,
-		// flush current buffer and add new data
-
-		// println("offset", offset, "size", len(data), "existing offset", pages.Offset, "size", pages.Size)
-
-		if chunk, err = pages.saveExistingPagesToStorage(ctx); err == nil {
-			if chunk != nil {
-				glog.V(4).Infof("%s/%s add save [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-				chunks = append(chunks, chunk)
+	// spill the other intervals to the swap file
+	if memoryLimit := pages.f.wfs.option.DirtyPagesMemoryLimit; memoryLimit > 0 {
+		if exceeded := atomic.LoadInt64(&pages.f.wfs.dirtyPagesMemory) + pages.intervals.memorySize - memorySize - memoryLimit; exceeded > 0 {
+			glog.V(3).Infof("%s/%s swap out %d bytes", pages.f.dir.Path, pages.f.Name, exceeded)
+			if err = pages.intervals.swapOut(pages.f.wfs.option.SwapDir, pages.intervals.memorySize-exceeded, interval); err != nil {
+				glog.Errorf("%s/%s swap out: %v", pages.f.dir.Path, pages.f.Name, err)
+				return nil, err
 			}
-		} else {
-			glog.V(0).Infof("%s/%s add save [%d,%d): %v", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size), err)
-			return
 		}
-		pages.Offset = offset
-		copy(pages.Data, data)
-		pages.Size = int64(len(data))
-		return
 	}
 
-	if offset != pages.Offset+pages.Size {
-		// when this happens, debug shows the data overlapping with existing data is empty
-		// the data is not just append
-		if offset == pages.Offset && int(pages.Size) < len(data) {
-			// glog.V(2).Infof("pages[%d,%d) pages.Data len=%v, data len=%d, pages.Size=%d", pages.Offset, pages.Offset+pages.Size, len(pages.Data), len(data), pages.Size)
-			copy(pages.Data[pages.Size:], data[pages.Size:])
-		} else {
-			if pages.Size != 0 {
-				glog.V(1).Infof("%s/%s add page: pages [%d, %d) write [%d, %d)", pages.f.dir.Path, pages.f.Name, pages.Offset, pages.Offset+pages.Size, offset, offset+int64(len(data)))
-			}
-			return pages.flushAndSave(ctx, offset, data)
-		}
-	} else {
-		copy(pages.Data[offset-pages.Offset:], data)
-	}
-
-	pages.Size = max(pages.Size, offset+int64(len(data))-pages.Offset)
-
 	return
 }
 
-func (pages *ContinuousDirtyPages) flushAndSave(ctx context.Context, offset int64, data []byte) (chunks []*filer_pb.FileChunk, err error) {
+// ReadDirtyData copies the buffered data over the buffer, and returns the end of the dirty data in the buffer
+func (pages *DirtyPages) ReadDirtyData(buf []byte, offset int64) (maxStop int64, err error) {
 
-	var chunk *filer_pb.FileChunk
+	pages.lock.Lock()
+	defer pages.lock.Unlock()
 
-	// flush existing
-	if chunk, err = pages.saveExistingPagesToStorage(ctx); err == nil {
-		if chunk != nil {
-			glog.V(4).Infof("%s/%s flush existing [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-			chunks = append(chunks, chunk)
-		}
-	} else {
-		glog.V(0).Infof("%s/%s failed to flush1 [%d,%d): %v", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size), err)
-		return
-	}
-	pages.Size = 0
-	pages.Offset = 0
-
-	// flush the new page
-	if chunk, err = pages.saveToStorage(ctx, data, offset); err == nil {
-		if chunk != nil {
-			glog.V(4).Infof("%s/%s flush big request [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
-			chunks = append(chunks, chunk)
-		}
-	} else {
-		glog.V(0).Infof("%s/%s failed to flush2 [%d,%d): %v", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size), err)
-		return
-	}
+	return pages.intervals.read(buf, offset)
+}
 
-	return
+// MaxStop returns the end of the buffered data
+func (pages *DirtyPages) MaxStop() int64 {
+
+	pages.lock.Lock()
+	defer pages.lock.Unlock()
+
+	return pages.intervals.maxStop()
 }
 
-func (pages *ContinuousDirtyPages) FlushToStorage(ctx context.Context) (chunk *filer_pb.FileChunk, err error) {
+// FlushToStorage saves all the buffered intervals to the volume servers
+func (pages *DirtyPages) FlushToStorage(ctx context.Context) (chunks []*filer_pb.FileChunk, err error) {
 
 	pages.lock.Lock()
 	defer pages.lock.Unlock()
 
-	if pages.Size == 0 {
-		return nil, nil
-	}
+	memorySize := pages.intervals.memorySize
+	defer func() {
+		atomic.AddInt64(&pages.f.wfs.dirtyPagesMemory, pages.intervals.memorySize-memorySize)
+	}()
 
-	if chunk, err = pages.saveExistingPagesToStorage(ctx); err == nil {
-		pages.Size = 0
-		pages.Offset = 0
-		if chunk != nil {
-			glog.V(4).Infof("%s/%s flush [%d,%d)", pages.f.dir.Path, pages.f.Name, chunk.Offset, chunk.Offset+int64(chunk.Size))
+	for len(pages.intervals.intervals) > 0 {
+		interval := pages.intervals.intervals[0]
+		intervalChunks, err := pages.saveIntervalToStorage(ctx, interval)
+		chunks = append(chunks, intervalChunks...)
+		if err != nil {
+			return chunks, err
 		}
+		glog.V(4).Infof("%s/%s flush [%d,%d)", pages.f.dir.Path, pages.f.Name, interval.start, interval.stop)
 	}
+
 	return
 }
 
-func (pages *ContinuousDirtyPages) saveExistingPagesToStorage(ctx context.Context) (*filer_pb.FileChunk, error) {
+// saveIntervalToStorage saves the interval in chunks, and removes it once all of them are saved
+func (pages *DirtyPages) saveIntervalToStorage(ctx context.Context, interval *dirtyInterval) (chunks []*filer_pb.FileChunk, err error) {
+
+	data, err := pages.intervals.load(interval)
+	if err != nil {
+		return nil, err
+	}
 
-	if pages.Size == 0 {
-		return nil, nil
+	chunkSize := pages.f.wfs.option.ChunkSizeLimit
+	for start := int64(0); start < int64(len(data)); start += chunkSize {
+		stop := min(start+chunkSize, int64(len(data)))
+		chunk, err := pages.saveToStorage(ctx, data[start:stop], interval.start+start)
+		if err != nil {
+			glog.V(0).Infof("%s/%s failed to save [%d,%d): %v", pages.f.dir.Path, pages.f.Name, interval.start+start, interval.start+stop, err)
+			return chunks, err
+		}
+		chunks = append(chunks, chunk)
 	}
 
-	return pages.saveToStorage(ctx, pages.Data[:pages.Size], pages.Offset)
+	pages.intervals.remove(interval)
+
+	return chunks, nil
 }
 
-func (pages *ContinuousDirtyPages) saveToStorage(ctx context.Context, buf []byte, offset int64) (*filer_pb.FileChunk, error) {
+func (pages *DirtyPages) saveToStorage(ctx context.Context, buf []byte, offset int64) (*filer_pb.FileChunk, error) {
 
 	var fileId, host string
 	var auth security.EncodedJwt
@@ -175,6 +150,7 @@ func (pages *ContinuousDirtyPages) saveToStorage(ctx context.Context, buf []byte
 			Collection:  pages.f.wfs.option.Collection,
 			TtlSec:      pages.f.wfs.option.TtlSec,
 			DataCenter:  pages.f.wfs.option.DataCenter,
+			DiskType:    pages.f.wfs.option.DiskType,
 		}
 
 		resp, err := client.AssignVolume(ctx, request)
@@ -0,0 +1,221 @@
+package filesys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// dirtyInterval is a range of the written data, kept in memory or in the swap file
+type dirtyInterval struct {
+	start, stop int64
+	data        []byte // nil if swapped out
+	swapOffset  int64
+}
+
+func (interval *dirtyInterval) size() int64 {
+	return interval.stop - interval.start
+}
+
+// dirtyIntervals keeps the disjoint and non-adjacent intervals sorted by the offset
+type dirtyIntervals struct {
+	intervals  []*dirtyInterval
+	memorySize int64
+	swap       *swapFile
+}
+
+// add writes the data, merging the overlapping and adjacent intervals, and returns the merged interval
+func (list *dirtyIntervals) add(offset int64, data []byte) (*dirtyInterval, error) {
+
+	start, stop := offset, offset+int64(len(data))
+
+	// the intervals in [i, j) overlap with or are adjacent to the data
+	i := sort.Search(len(list.intervals), func(k int) bool {
+		return list.intervals[k].stop >= start
+	})
+	j := i
+	for j < len(list.intervals) && list.intervals[j].start <= stop {
+		j++
+	}
+
+	if j == i+1 && list.intervals[i].data != nil {
+		interval := list.intervals[i]
+		// overwrite within the interval
+		if interval.start <= start && stop <= interval.stop {
+			copy(interval.data[start-interval.start:], data)
+			return interval, nil
+		}
+		// append to the interval
+		if interval.start <= start && start <= interval.stop {
+			interval.data = append(interval.data[:start-interval.start], data...)
+			list.memorySize += stop - interval.stop
+			interval.stop = stop
+			return interval, nil
+		}
+	}
+
+	merged := &dirtyInterval{start: start, stop: stop}
+	if i < j {
+		merged.start = min(start, list.intervals[i].start)
+		merged.stop = max(stop, list.intervals[j-1].stop)
+	}
+	merged.data = make([]byte, merged.size())
+	for _, interval := range list.intervals[i:j] {
+		intervalData, err := list.load(interval)
+		if err != nil {
+			return nil, err
+		}
+		copy(merged.data[interval.start-merged.start:], intervalData)
+		if interval.data != nil {
+			list.memorySize -= interval.size()
+		}
+	}
+	copy(merged.data[start-merged.start:], data)
+	list.memorySize += merged.size()
+
+	list.intervals = append(list.intervals[:i], append([]*dirtyInterval{merged}, list.intervals[j:]...)...)
+
+	return merged, nil
+}
+
+// read copies the dirty data over the buffer, and returns the end of the dirty data in the buffer
+func (list *dirtyIntervals) read(buf []byte, offset int64) (maxStop int64, err error) {
+
+	stop := offset + int64(len(buf))
+	for _, interval := range list.intervals {
+		if interval.stop <= offset || stop <= interval.start {
+			continue
+		}
+		data, err := list.load(interval)
+		if err != nil {
+			return 0, err
+		}
+		readStart, readStop := max(offset, interval.start), min(stop, interval.stop)
+		copy(buf[readStart-offset:readStop-offset], data[readStart-interval.start:])
+		maxStop = max(maxStop, readStop)
+	}
+	return maxStop, nil
+}
+
+func (list *dirtyIntervals) remove(interval *dirtyInterval) {
+	for i, t := range list.intervals {
+		if t == interval {
+			list.intervals = append(list.intervals[:i], list.intervals[i+1:]...)
+			break
+		}
+	}
+	if interval.data != nil {
+		list.memorySize -= interval.size()
+	}
+	if len(list.intervals) == 0 && list.swap != nil {
+		list.swap.reset()
+	}
+}
+
+// swapOut moves the in memory intervals to the swap file, the largest first, until the memory size is within the limit
+func (list *dirtyIntervals) swapOut(swapDir string, memoryLimit int64, except *dirtyInterval) error {
+
+	var candidates []*dirtyInterval
+	for _, interval := range list.intervals {
+		if interval.data != nil && interval != except {
+			candidates = append(candidates, interval)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].size() > candidates[j].size()
+	})
+
+	for _, interval := range candidates {
+		if list.memorySize <= memoryLimit {
+			break
+		}
+		if list.swap == nil {
+			list.swap = &swapFile{dir: swapDir}
+		}
+		swapOffset, err := list.swap.write(interval.data)
+		if err != nil {
+			return err
+		}
+		interval.swapOffset = swapOffset
+		interval.data = nil
+		list.memorySize -= interval.size()
+	}
+
+	return nil
+}
+
+func (list *dirtyIntervals) load(interval *dirtyInterval) ([]byte, error) {
+	if interval.data != nil {
+		return interval.data, nil
+	}
+	return list.swap.read(interval.swapOffset, interval.size())
+}
+
+func (list *dirtyIntervals) maxStop() int64 {
+	if len(list.intervals) == 0 {
+		return 0
+	}
+	return list.intervals[len(list.intervals)-1].stop
+}
+
+func (list *dirtyIntervals) destroy() {
+	list.intervals = nil
+	list.memorySize = 0
+	if list.swap != nil {
+		list.swap.destroy()
+		list.swap = nil
+	}
+}
+
+// swapFile appends the swapped out data, and is truncated when all the intervals are gone
+type swapFile struct {
+	dir  string
+	file *os.File
+	size int64
+}
+
+func (sf *swapFile) write(data []byte) (offset int64, err error) {
+	if sf.file == nil {
+		if sf.file, err = ioutil.TempFile(sf.dir, "weedmount_swap_"); err != nil {
+			return 0, fmt.Errorf("create swap file in %s: %v", sf.dir, err)
+		}
+	}
+	if _, err = sf.file.WriteAt(data, sf.size); err != nil {
+		return 0, fmt.Errorf("write swap file %s: %v", sf.file.Name(), err)
+	}
+	offset = sf.size
+	sf.size += int64(len(data))
+	return offset, nil
+}
+
+func (sf *swapFile) read(offset, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := sf.file.ReadAt(data, offset); err != nil {
+		return nil, fmt.Errorf("read swap file %s: %v", sf.file.Name(), err)
+	}
+	return data, nil
+}
+
+func (sf *swapFile) reset() {
+	if sf.file != nil && sf.size > 0 {
+		sf.file.Truncate(0)
+	}
+	sf.size = 0
+}
+
+func (sf *swapFile) destroy() {
+	if sf.file != nil {
+		sf.file.Close()
+		os.Remove(sf.file.Name())
+		sf.file = nil
+	}
+	sf.size = 0
+}
+
+func min(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,84 @@
+package filesys
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestDirtyIntervalsMerge(t *testing.T) {
+
+	list := &dirtyIntervals{}
+	file := make([]byte, 100)
+	write := func(offset int64, data string) {
+		if _, err := list.add(offset, []byte(data)); err != nil {
+			t.Fatalf("add %d: %v", offset, err)
+		}
+		copy(file[offset:], data)
+	}
+
+	write(10, "aaaa")
+	write(30, "bbbb")
+	write(14, "cc") // appended to [10,14)
+	write(50, "dddd")
+	if len(list.intervals) != 3 || list.intervals[0].stop != 16 {
+		t.Fatalf("disjoint intervals: %+v", list.intervals)
+	}
+
+	write(12, "eeeeeeeeeeeeeeeeeeeeeeeeeeee") // [12,40) merges [10,16) and [30,34)
+	write(60, "ff")
+	write(54, "gggggg") // adjacent to both [50,54) and [60,62)
+	if len(list.intervals) != 2 || list.intervals[0].start != 10 || list.intervals[0].stop != 40 ||
+		list.intervals[1].start != 50 || list.intervals[1].stop != 62 {
+		t.Fatalf("merged intervals: %+v", list.intervals)
+	}
+	if list.memorySize != 30+12 {
+		t.Errorf("memory size %d", list.memorySize)
+	}
+
+	buf := make([]byte, 100)
+	maxStop, err := list.read(buf[5:], 5)
+	if err != nil || maxStop != 62 || !bytes.Equal(buf, file) {
+		t.Errorf("read %d %v: %q", maxStop, err, buf)
+	}
+
+}
+
+func TestDirtyIntervalsSwap(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "seaweedfs_swap_test")
+	defer os.RemoveAll(dir)
+
+	list := &dirtyIntervals{}
+	list.add(0, []byte("0123456789"))
+	list.add(20, []byte("abc"))
+	current, _ := list.add(40, []byte("xyz"))
+
+	if err := list.swapOut(dir, 6, current); err != nil {
+		t.Fatalf("swap out: %v", err)
+	}
+	if list.intervals[0].data != nil || list.intervals[1].data == nil || list.memorySize != 6 {
+		t.Fatalf("the largest interval should be swapped out: %d", list.memorySize)
+	}
+
+	// merge with the swapped out interval
+	list.add(8, []byte("ABCD"))
+	if len(list.intervals) != 3 || list.intervals[0].data == nil {
+		t.Fatalf("merge with the swapped interval: %+v", list.intervals)
+	}
+
+	buf := make([]byte, 23)
+	if maxStop, err := list.read(buf, 0); err != nil || maxStop != 23 || string(buf[:12]) != "01234567ABCD" || string(buf[20:]) != "abc" {
+		t.Errorf("read %d %v: %q", maxStop, err, buf)
+	}
+
+	for len(list.intervals) > 0 {
+		list.remove(list.intervals[0])
+	}
+	if list.memorySize != 0 || list.swap.size != 0 {
+		t.Errorf("memory %d swap %d", list.memorySize, list.swap.size)
+	}
+	list.destroy()
+
+}
@@ -28,6 +28,8 @@ type File struct {
 	entry          *filer_pb.Entry
 	entryViewCache []filer2.VisibleInterval
 	isOpen         bool
+	// the chunks uploaded but not saved to the filer yet
+	unsavedChunks []*filer_pb.FileChunk
 }
 
 func (file *File) fullpath() string {
@@ -42,11 +44,23 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) error {
 
 	attr.Mode = os.FileMode(file.entry.Attributes.FileMode)
 	attr.Size = filer2.TotalSize(file.entry.Chunks)
+	if file.isOpen {
+		// include the buffered writes not saved yet
+		if fh := file.wfs.findHandle(file.fullpath()); fh != nil {
+			if dirtyStop := uint64(fh.dirtyPages.MaxStop()); dirtyStop > attr.Size {
+				attr.Size = dirtyStop
+			}
+		}
+	}
 	attr.Mtime = time.Unix(file.entry.Attributes.Mtime, 0)
 	attr.Gid = file.entry.Attributes.Gid
 	attr.Uid = file.entry.Attributes.Uid
 	attr.Blocks = attr.Size/blockSize + 1
 	attr.BlockSize = uint32(file.wfs.option.ChunkSizeLimit)
+	attr.Nlink = 1
+	if file.entry.HardLinkCounter > 0 {
+		attr.Nlink = uint32(file.entry.HardLinkCounter)
+	}
 
 	return nil
 
@@ -56,6 +70,15 @@ func (file *File) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.Op
 
 	glog.V(3).Infof("%v file open %+v", file.fullpath(), req)
 
+	if !file.isOpen {
+		if err := file.maybeLoadEntry(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkPermission(ctx, file.entry.Attributes, file.entry.Extended, openPermission(req.Flags)); err != nil {
+		return nil, err
+	}
+
 	file.isOpen = true
 
 	handle := file.wfs.AcquireHandle(file, req.Uid, req.Gid)
@@ -75,6 +98,9 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 	}
 
 	glog.V(3).Infof("%v file setattr %+v, old:%+v", file.fullpath(), req, file.entry.Attributes)
+	if err := checkSetattr(ctx, file.entry.Attributes, req); err != nil {
+		return err
+	}
 	if req.Valid.Size() {
 
 		glog.V(3).Infof("%v file setattr set size=%v", file.fullpath(), req.Size)
@@ -120,7 +146,8 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 		_, err := client.UpdateEntry(ctx, request)
 		if err != nil {
 			glog.V(0).Infof("UpdateEntry file %s/%s: %v", file.dir.Path, file.Name, err)
-			return fuse.EIO
+			file.entry = nil
+			return filerErrno(err, fuse.EIO)
 		}
 
 		return nil
@@ -174,12 +201,6 @@ func (file *File) maybeLoadAttributes(ctx context.Context) error {
 	return nil
 }
 
-func (file *File) addChunk(chunk *filer_pb.FileChunk) {
-	if chunk != nil {
-		file.addChunks([]*filer_pb.FileChunk{chunk})
-	}
-}
-
 func (file *File) addChunks(chunks []*filer_pb.FileChunk) {
 
 	sort.Slice(chunks, func(i, j int) bool {
@@ -195,6 +216,28 @@ func (file *File) addChunks(chunks []*filer_pb.FileChunk) {
 	}
 
 	file.entry.Chunks = append(file.entry.Chunks, chunks...)
+	file.unsavedChunks = append(file.unsavedChunks, chunks...)
+}
+
+// discardUnsavedChunks restores the chunks to the ones saved to the filer, and deletes the chunks failed to save
+func (file *File) discardUnsavedChunks(ctx context.Context, chunks []*filer_pb.FileChunk) {
+
+	unsaved := make(map[string]bool)
+	for _, chunk := range file.unsavedChunks {
+		unsaved[chunk.FileId] = true
+	}
+
+	var savedChunks []*filer_pb.FileChunk
+	for _, chunk := range chunks {
+		if !unsaved[chunk.FileId] {
+			savedChunks = append(savedChunks, chunk)
+		}
+	}
+
+	file.wfs.deleteFileChunks(ctx, file.unsavedChunks)
+	file.unsavedChunks = nil
+	file.entry.Chunks = savedChunks
+	file.entryViewCache = filer2.NonOverlappingVisibleIntervals(file.entry.Chunks)
 }
 
 func (file *File) setEntry(entry *filer_pb.Entry) {
@@ -7,6 +7,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gabriel-vasile/mimetype"
@@ -16,17 +17,24 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
-	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 	"google.golang.org/grpc"
 )
 
 type FileHandle struct {
 	// cache file has been written to
-	dirtyPages    *ContinuousDirtyPages
+	dirtyPages    *DirtyPages
 	contentType   string
 	dirtyMetadata bool
 	handle        uint64
 
+	// to detect the sequential reads
+	readLock      sync.Mutex
+	lastReadStop  int64
+	readAheadStop int64
+
+	// whether any POSIX lock has been acquired through the handle
+	hasPosixLocks int32
+
 	f         *File
 	RequestId fuse.RequestID // unique ID for request
 	NodeId    fuse.NodeID    // file or directory the request is about
@@ -56,7 +64,7 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 	glog.V(4).Infof("%s read fh %d: [%d,%d)", fh.f.fullpath(), fh.handle, req.Offset, req.Offset+int64(req.Size))
 
 	// this value should come from the filer instead of the old f
-	if len(fh.f.entry.Chunks) == 0 {
+	if len(fh.f.entry.Chunks) == 0 && fh.dirtyPages.MaxStop() == 0 {
 		glog.V(1).Infof("empty fh %v/%v", fh.f.dir.Path, fh.f.Name)
 		return nil
 	}
@@ -74,30 +82,28 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 		vids = append(vids, volumeId(chunkView.FileId))
 	}
 
-	vid2Locations := make(map[string]*filer_pb.Locations)
-
-	err := fh.f.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
-
-		glog.V(4).Infof("read fh lookup volume id locations: %v", vids)
-		resp, err := client.LookupVolume(ctx, &filer_pb.LookupVolumeRequest{
-			VolumeIds: vids,
-		})
-		if err != nil {
-			return err
-		}
-
-		vid2Locations = resp.LocationsMap
-
-		return nil
-	})
-
+	vid2Locations, err := fh.f.wfs.lookupVolumeLocations(ctx, vids)
 	if err != nil {
 		glog.V(4).Infof("%v/%v read fh lookup volume ids: %v", fh.f.dir.Path, fh.f.Name, err)
-		return fmt.Errorf("failed to lookup volume ids %v: %v", vids, err)
+		return err
 	}
 
+	// the holes between the chunks are read as zeros
 	var totalRead int64
 	var wg sync.WaitGroup
+	var resultLock sync.Mutex
+	setErr := func(e error) {
+		resultLock.Lock()
+		err = e
+		resultLock.Unlock()
+	}
+	setReadStop := func(stop int64) {
+		resultLock.Lock()
+		if stop-req.Offset > totalRead {
+			totalRead = stop - req.Offset
+		}
+		resultLock.Unlock()
+	}
 	for _, chunkView := range chunkViews {
 		wg.Add(1)
 		go func(chunkView *filer2.ChunkView) {
@@ -108,39 +114,89 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 			locations := vid2Locations[volumeId(chunkView.FileId)]
 			if locations == nil || len(locations.Locations) == 0 {
 				glog.V(0).Infof("failed to locate %s", chunkView.FileId)
-				err = fmt.Errorf("failed to locate %s", chunkView.FileId)
+				setErr(fmt.Errorf("failed to locate %s", chunkView.FileId))
 				return
 			}
 
-			var n int64
-			n, err = util.ReadUrl(
-				fmt.Sprintf("http://%s/%s", locations.Locations[0].Url, chunkView.FileId),
-				chunkView.Offset,
-				int(chunkView.Size),
-				buff[chunkView.LogicOffset-req.Offset:chunkView.LogicOffset-req.Offset+int64(chunkView.Size)],
-				!chunkView.IsFullChunk)
+			n, readErr := fh.f.wfs.readChunkView(
+				chunkView,
+				locations,
+				buff[chunkView.LogicOffset-req.Offset:chunkView.LogicOffset-req.Offset+int64(chunkView.Size)])
+
+			if readErr != nil {
 
-			if err != nil {
+				glog.V(0).Infof("%v/%v read %v %v bytes: %v", fh.f.dir.Path, fh.f.Name, chunkView.FileId, n, readErr)
 
-				glog.V(0).Infof("%v/%v read http://%s/%v %v bytes: %v", fh.f.dir.Path, fh.f.Name, locations.Locations[0].Url, chunkView.FileId, n, err)
+				// the volume may have been moved
+				fh.f.wfs.volumeLocationCache.Delete(volumeId(chunkView.FileId))
 
-				err = fmt.Errorf("failed to read http://%s/%s: %v",
-					locations.Locations[0].Url, chunkView.FileId, err)
+				setErr(fmt.Errorf("failed to read %s: %v", chunkView.FileId, readErr))
 				return
 			}
 
 			glog.V(4).Infof("read fh read %d bytes: %+v", n, chunkView)
-			totalRead += n
+			setReadStop(chunkView.LogicOffset + n)
 
 		}(chunkView)
 	}
 	wg.Wait()
 
+	// the buffered writes are newer than the saved chunks
+	dirtyStop, dirtyErr := fh.dirtyPages.ReadDirtyData(buff, req.Offset)
+	if dirtyErr != nil {
+		glog.Errorf("%v/%v read dirty pages: %v", fh.f.dir.Path, fh.f.Name, dirtyErr)
+		setErr(dirtyErr)
+	}
+	setReadStop(dirtyStop)
+
 	resp.Data = buff[:totalRead]
 
+	if err == nil && fh.isSequentialRead(req.Offset, req.Offset+totalRead) {
+		fh.f.wfs.readAhead(fh.readAheadChunkViews(req.Offset + totalRead))
+	}
+
 	return err
 }
 
+// isSequentialRead tracks the read positions, and detects the read continuing from the last one
+func (fh *FileHandle) isSequentialRead(start, stop int64) bool {
+	fh.readLock.Lock()
+	defer fh.readLock.Unlock()
+
+	isSequential := start == fh.lastReadStop && start > 0
+	fh.lastReadStop = stop
+	if !isSequential {
+		fh.readAheadStop = 0
+	}
+	return isSequential
+}
+
+// readAheadChunkViews returns the chunk views after the offset, skipping the ones already read ahead
+func (fh *FileHandle) readAheadChunkViews(offset int64) (chunkViews []*filer2.ChunkView) {
+
+	readAheadChunks := fh.f.wfs.option.ReadAheadChunks
+	if readAheadChunks <= 0 || fh.f.wfs.chunkCache == nil {
+		return nil
+	}
+
+	fh.readLock.Lock()
+	defer fh.readLock.Unlock()
+
+	if offset < fh.readAheadStop {
+		offset = fh.readAheadStop
+	}
+	size := int64(readAheadChunks) * fh.f.wfs.option.ChunkSizeLimit
+	for _, chunkView := range filer2.ViewFromVisibleIntervals(fh.f.entryViewCache, offset, int(size)) {
+		if len(chunkViews) >= readAheadChunks {
+			break
+		}
+		chunkViews = append(chunkViews, chunkView)
+		fh.readAheadStop = chunkView.LogicOffset + int64(chunkView.Size)
+	}
+
+	return
+}
+
 // Write to the file handle
 func (fh *FileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
 
@@ -149,6 +205,11 @@ func (fh *FileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *f
 	glog.V(4).Infof("%+v/%v write fh %d: [%d,%d)", fh.f.dir.Path, fh.f.Name, fh.handle, req.Offset, req.Offset+int64(len(req.Data)))
 
 	chunks, err := fh.dirtyPages.AddPage(ctx, req.Offset, req.Data)
+	// the chunks saved before a failure are kept, the same as flushing
+	fh.f.addChunks(chunks)
+	if len(chunks) > 0 {
+		fh.dirtyMetadata = true
+	}
 	if err != nil {
 		glog.Errorf("%+v/%v write fh %d: [%d,%d): %v", fh.f.dir.Path, fh.f.Name, fh.handle, req.Offset, req.Offset+int64(len(req.Data)), err)
 		return fmt.Errorf("write %s/%s at [%d,%d): %v", fh.f.dir.Path, fh.f.Name, req.Offset, req.Offset+int64(len(req.Data)), err)
@@ -167,12 +228,6 @@ func (fh *FileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *f
 		fh.dirtyMetadata = true
 	}
 
-	fh.f.addChunks(chunks)
-
-	if len(chunks) > 0 {
-		fh.dirtyMetadata = true
-	}
-
 	return nil
 }
 
@@ -180,6 +235,12 @@ func (fh *FileHandle) Release(ctx context.Context, req *fuse.ReleaseRequest) err
 
 	glog.V(4).Infof("%v release fh %d", fh.f.fullpath(), fh.handle)
 
+	if req.ReleaseFlags&fuse.ReleaseFlockUnlock != 0 {
+		if err := fh.unlockFlock(ctx, req.LockOwner); err != nil {
+			glog.Errorf("release the flock of %s: %v", fh.f.fullpath(), err)
+		}
+	}
+
 	fh.dirtyPages.releaseResource()
 
 	fh.f.wfs.ReleaseHandle(fh.f.fullpath(), fuse.HandleID(fh.handle))
@@ -194,14 +255,23 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 	// send the data to the OS
 	glog.V(4).Infof("%s fh %d flush %v", fh.f.fullpath(), fh.handle, req)
 
-	chunk, err := fh.dirtyPages.FlushToStorage(ctx)
+	// the file is closed by the lock owner
+	if atomic.LoadInt32(&fh.hasPosixLocks) != 0 {
+		if err := fh.unlockPosixLocks(ctx, fuse.LockOwner(req.LockOwner)); err != nil {
+			glog.Errorf("release the locks of %s: %v", fh.f.fullpath(), err)
+		}
+	}
+
+	chunks, err := fh.dirtyPages.FlushToStorage(ctx)
+	fh.f.addChunks(chunks)
+	if len(chunks) > 0 {
+		fh.dirtyMetadata = true
+	}
 	if err != nil {
 		glog.Errorf("flush %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
 		return fmt.Errorf("flush %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
 	}
 
-	fh.f.addChunk(chunk)
-
 	if !fh.dirtyMetadata {
 		return nil
 	}
@@ -209,12 +279,9 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 	return fh.f.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 
 		if fh.f.entry.Attributes != nil {
+			// the owner and the mode are set when the file is created, and changed by setattr
 			fh.f.entry.Attributes.Mime = fh.contentType
-			fh.f.entry.Attributes.Uid = req.Uid
-			fh.f.entry.Attributes.Gid = req.Gid
 			fh.f.entry.Attributes.Mtime = time.Now().Unix()
-			fh.f.entry.Attributes.Crtime = time.Now().Unix()
-			fh.f.entry.Attributes.FileMode = uint32(0770)
 		}
 
 		request := &filer_pb.CreateEntryRequest{
@@ -227,15 +294,24 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 		//	glog.V(4).Infof("%s/%s chunks %d: %v [%d,%d)", fh.f.dir.Path, fh.f.Name, i, chunk.FileId, chunk.Offset, chunk.Offset+int64(chunk.Size))
 		//}
 
-		chunks, garbages := filer2.CompactFileChunks(fh.f.entry.Chunks)
+		allChunks := fh.f.entry.Chunks
+		chunks, garbages := filer2.CompactFileChunks(allChunks)
 		fh.f.entry.Chunks = chunks
 		// fh.f.entryViewCache = nil
-		fh.f.wfs.deleteFileChunks(ctx, garbages)
 
 		if _, err := client.CreateEntry(ctx, request); err != nil {
+			// the saved entry still points to the garbage chunks, and not to the new chunks
+			fh.f.discardUnsavedChunks(ctx, allChunks)
+			if filer2.IsQuotaExceeded(err) || filer2.IsPermissionDenied(err) {
+				glog.V(0).Infof("update fh %s: %v", fh.f.fullpath(), err)
+				return filerErrno(err, fuse.EIO)
+			}
 			return fmt.Errorf("update fh: %v", err)
 		}
 
+		fh.f.unsavedChunks = nil
+		fh.f.wfs.deleteFileChunks(ctx, garbages)
+
 		return nil
 	})
 }
@@ -0,0 +1,204 @@
+package filesys
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/seaweedfs/fuse"
+	"github.com/seaweedfs/fuse/fs"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+const maxLockWaitInterval = time.Second
+
+var _ = fs.HandleLocker(&FileHandle{})
+
+// Lock tries to acquire the lock, and returns EAGAIN if the lock is held by others
+func (fh *FileHandle) Lock(ctx context.Context, req *fuse.LockRequest) error {
+
+	glog.V(4).Infof("%s fh %d lock %v", fh.f.fullpath(), fh.handle, req)
+
+	granted, err := fh.tryLock(ctx, fh.toFileLock(req.LockOwner, req.Lock, req.LockFlags))
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return fuse.Errno(syscall.EAGAIN)
+	}
+	return nil
+}
+
+// LockWait waits until the lock is acquired, by polling the filer
+func (fh *FileHandle) LockWait(ctx context.Context, req *fuse.LockWaitRequest) error {
+
+	glog.V(4).Infof("%s fh %d lock wait %v", fh.f.fullpath(), fh.handle, req)
+
+	lock := fh.toFileLock(req.LockOwner, req.Lock, req.LockFlags)
+
+	interval := 10 * time.Millisecond
+	for {
+		granted, err := fh.tryLock(ctx, lock)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fuse.EINTR
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxLockWaitInterval {
+			interval = maxLockWaitInterval
+		}
+	}
+}
+
+func (fh *FileHandle) Unlock(ctx context.Context, req *fuse.UnlockRequest) error {
+
+	glog.V(4).Infof("%s fh %d unlock %v", fh.f.fullpath(), fh.handle, req)
+
+	return fh.unlock(ctx, fh.toFileLock(req.LockOwner, req.Lock, req.LockFlags))
+}
+
+func (fh *FileHandle) QueryLock(ctx context.Context, req *fuse.QueryLockRequest, resp *fuse.QueryLockResponse) error {
+
+	return fh.f.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		queryResp, err := client.QueryLock(ctx, &filer_pb.QueryLockRequest{
+			Directory: fh.f.dir.Path,
+			Name:      fh.f.Name,
+			Lock:      fh.toFileLock(req.LockOwner, req.Lock, req.LockFlags),
+		})
+		if err != nil {
+			glog.V(0).Infof("query lock %s: %v", fh.f.fullpath(), err)
+			return filerErrno(err, fuse.EIO)
+		}
+
+		if conflict := queryResp.Conflict; conflict != nil {
+			resp.Lock = fuse.FileLock{
+				Start: conflict.Start,
+				End:   conflict.End,
+				Type:  fuse.LockRead,
+				PID:   conflict.Pid,
+			}
+			if conflict.IsWrite {
+				resp.Lock.Type = fuse.LockWrite
+			}
+		}
+
+		return nil
+	})
+}
+
+// unlockPosixLocks releases all POSIX locks of the lock owner, the kernel does not do it for us
+func (fh *FileHandle) unlockPosixLocks(ctx context.Context, owner fuse.LockOwner) error {
+	return fh.unlock(ctx, &filer_pb.FileLock{
+		Client: fh.f.wfs.lockClient,
+		Owner:  uint64(owner),
+		Start:  0,
+		End:    math.MaxUint64,
+	})
+}
+
+// unlockFlock releases the flock of the lock owner
+func (fh *FileHandle) unlockFlock(ctx context.Context, owner fuse.LockOwner) error {
+	return fh.unlock(ctx, &filer_pb.FileLock{
+		Client:  fh.f.wfs.lockClient,
+		Owner:   uint64(owner),
+		IsFlock: true,
+	})
+}
+
+func (fh *FileHandle) tryLock(ctx context.Context, lock *filer_pb.FileLock) (granted bool, err error) {
+
+	err = fh.f.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		resp, err := client.Lock(ctx, &filer_pb.LockRequest{
+			Directory: fh.f.dir.Path,
+			Name:      fh.f.Name,
+			Lock:      lock,
+		})
+		if err != nil {
+			glog.V(0).Infof("lock %s: %v", fh.f.fullpath(), err)
+			return filerErrno(err, fuse.EIO)
+		}
+
+		granted = resp.Granted
+		if granted && !lock.IsFlock {
+			atomic.StoreInt32(&fh.hasPosixLocks, 1)
+		}
+		return nil
+	})
+
+	return
+}
+
+func (fh *FileHandle) unlock(ctx context.Context, lock *filer_pb.FileLock) error {
+
+	return fh.f.wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		_, err := client.Unlock(ctx, &filer_pb.UnlockRequest{
+			Directory: fh.f.dir.Path,
+			Name:      fh.f.Name,
+			Lock:      lock,
+		})
+		if err != nil {
+			glog.V(0).Infof("unlock %s: %v", fh.f.fullpath(), err)
+			return filerErrno(err, fuse.EIO)
+		}
+
+		return nil
+	})
+}
+
+func (fh *FileHandle) toFileLock(owner fuse.LockOwner, lock fuse.FileLock, flags fuse.LockFlags) *filer_pb.FileLock {
+	return &filer_pb.FileLock{
+		Client:  fh.f.wfs.lockClient,
+		Owner:   uint64(owner),
+		Start:   lock.Start,
+		End:     lock.End,
+		IsWrite: lock.Type == fuse.LockWrite,
+		Pid:     lock.PID,
+		IsFlock: flags&fuse.LockFlock != 0,
+	}
+}
+
+func newLockClientId() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%x", hostname, os.Getpid(), rand.Uint32())
+}
+
+// keepLocks renews the lease of the locks held by the mount
+func (wfs *WFS) keepLocks() {
+
+	interval := 10 * time.Second
+
+	for {
+		err := wfs.withFilerClient(context.Background(), func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.KeepLocks(context.Background(), &filer_pb.KeepLocksRequest{
+				Client: wfs.lockClient,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.LeaseSeconds > 0 {
+				interval = time.Duration(resp.LeaseSeconds) * time.Second / 3
+			}
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("keep locks of %s: %v", wfs.lockClient, err)
+		}
+		time.Sleep(interval)
+	}
+
+}
@@ -0,0 +1,81 @@
+package filesys
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/karlseguin/ccache"
+	"github.com/seaweedfs/fuse"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"google.golang.org/grpc"
+)
+
+// testAssignFiler assigns the file ids on its volume server, which fails the uploads after the first ones
+type testAssignFiler struct {
+	filer_pb.SeaweedFilerServer // the calls not used by the tests are not implemented
+
+	volumeServer *httptest.Server
+	assigned     int32
+}
+
+func (f *testAssignFiler) AssignVolume(ctx context.Context, req *filer_pb.AssignVolumeRequest) (*filer_pb.AssignVolumeResponse, error) {
+	return &filer_pb.AssignVolumeResponse{
+		FileId: fmt.Sprintf("1,%x", atomic.AddInt32(&f.assigned, 1)),
+		Url:    strings.TrimPrefix(f.volumeServer.URL, "http://"),
+	}, nil
+}
+
+func TestWriteKeepsSavedChunks(t *testing.T) {
+
+	var uploads, uploadLimit int32 = 0, 1
+	volumeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&uploads, 1) > uploadLimit {
+			fmt.Fprint(w, `{"error":"volume is full"}`)
+			return
+		}
+		fmt.Fprint(w, `{"size":4}`)
+	}))
+	defer volumeServer.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	filer_pb.RegisterSeaweedFilerServer(grpcServer, &testAssignFiler{volumeServer: volumeServer})
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	wfs := &WFS{
+		option: &Option{
+			FilerGrpcAddress: listener.Addr().String(),
+			GrpcDialOption:   grpc.WithInsecure(),
+			ChunkSizeLimit:   4,
+		},
+		listDirectoryEntriesCache: ccache.New(ccache.Configure().MaxSize(1024).ItemsToPrune(100)),
+	}
+	file := &File{Name: "file", dir: &Dir{Path: "/dir", wfs: wfs}, wfs: wfs, entry: &filer_pb.Entry{Name: "file"}, isOpen: true}
+	fh := newFileHandle(file, 0, 0)
+	defer fh.dirtyPages.releaseResource()
+
+	// the first chunk is saved, the second one fails
+	err = fh.Write(context.Background(), &fuse.WriteRequest{Offset: 4, Data: []byte("01234567")}, &fuse.WriteResponse{})
+	if err == nil {
+		t.Fatalf("write with the upload failure")
+	}
+	if len(file.entry.Chunks) != 1 || file.entry.Chunks[0].Offset != 4 || file.entry.Chunks[0].Size != 4 {
+		t.Errorf("chunks %v", file.entry.Chunks)
+	}
+	if len(file.unsavedChunks) != 1 || file.unsavedChunks[0] != file.entry.Chunks[0] {
+		t.Errorf("unsaved chunks %v", file.unsavedChunks)
+	}
+	if !fh.dirtyMetadata {
+		t.Errorf("the saved chunk is not marked to be saved to the filer")
+	}
+}
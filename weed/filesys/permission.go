@@ -0,0 +1,164 @@
+package filesys
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/seaweedfs/fuse"
+	"github.com/seaweedfs/fuse/fs"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+/*
+
+The filer checks the permissions of the caller on the metadata changes and the directory listing.
+Since the kernel does not know the ACLs, the mount checks the permissions to open, create and look up the files
+with the same rules, instead of the kernel "default_permissions".
+
+*/
+
+var _ = fs.NodeAccesser(&File{})
+var _ = fs.NodeAccesser(&Dir{})
+
+// WithRequestIdentity passes the caller of the fuse request to the filer
+func WithRequestIdentity(ctx context.Context, req fuse.Request) context.Context {
+	header := req.Hdr()
+	id := &filer2.Identity{
+		Uid:  header.Uid,
+		Gids: processGroups(header.Pid, header.Gid),
+	}
+	ctx = filer2.WithIdentity(ctx, id)
+	return filer2.AppendIdentityToOutgoingContext(ctx, id)
+}
+
+// processGroups returns the primary group and the supplementary groups of the process, if available in /proc
+func processGroups(pid uint32, gid uint32) []uint32 {
+
+	gids := []uint32{gid}
+	if pid == 0 {
+		return gids
+	}
+
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return gids
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "Groups:") {
+			continue
+		}
+		for _, field := range strings.Fields(strings.TrimPrefix(line, "Groups:")) {
+			if g, err := strconv.ParseUint(field, 10, 32); err == nil && uint32(g) != gid {
+				gids = append(gids, uint32(g))
+			}
+		}
+		break
+	}
+
+	return gids
+}
+
+func checkPermission(ctx context.Context, attributes *filer_pb.FuseAttributes, extended map[string][]byte, perm uint32) error {
+	if attributes == nil {
+		return nil
+	}
+	id := filer2.IdentityFromContext(ctx)
+	if !filer2.HasPermission(id, attributes.Uid, attributes.Gid, os.FileMode(attributes.FileMode), extended, perm) {
+		return fuse.Errno(syscall.EACCES)
+	}
+	return nil
+}
+
+// checkSetattr only allows root to change the owner, and the owner to change the mode and the group
+func checkSetattr(ctx context.Context, attributes *filer_pb.FuseAttributes, req *fuse.SetattrRequest) error {
+
+	id := filer2.IdentityFromContext(ctx)
+	if id == nil || id.IsRoot() || attributes == nil {
+		return nil
+	}
+
+	if req.Valid.Uid() && req.Uid != attributes.Uid {
+		return fuse.EPERM
+	}
+	isOwner := id.Uid == attributes.Uid
+	if req.Valid.Gid() && req.Gid != attributes.Gid && !(isOwner && id.InGroup(req.Gid)) {
+		return fuse.EPERM
+	}
+	if req.Valid.Mode() && uint32(req.Mode) != attributes.FileMode && !isOwner {
+		return fuse.EPERM
+	}
+
+	return nil
+}
+
+func (file *File) Access(ctx context.Context, req *fuse.AccessRequest) error {
+
+	if err := file.maybeLoadEntry(ctx); err != nil {
+		return err
+	}
+
+	return checkPermission(ctx, file.entry.Attributes, file.entry.Extended, req.Mask&07)
+}
+
+func (dir *Dir) Access(ctx context.Context, req *fuse.AccessRequest) error {
+	return dir.checkPermission(ctx, req.Mask&07)
+}
+
+func (dir *Dir) checkPermission(ctx context.Context, perm uint32) error {
+
+	if dir.Path == dir.wfs.option.FilerMountRootPath {
+		return checkPermission(ctx, &filer_pb.FuseAttributes{
+			Uid:      dir.wfs.option.MountUid,
+			Gid:      dir.wfs.option.MountGid,
+			FileMode: uint32(dir.wfs.option.MountMode),
+		}, nil, perm)
+	}
+
+	if err := dir.maybeLoadEntry(ctx); err != nil {
+		return err
+	}
+	if dir.entry == nil {
+		return nil
+	}
+
+	return checkPermission(ctx, dir.entry.Attributes, dir.entry.Extended, perm)
+}
+
+func openPermission(flags fuse.OpenFlags) (perm uint32) {
+	switch {
+	case flags.IsReadOnly():
+		perm = filer2.PermRead
+	case flags.IsWriteOnly():
+		perm = filer2.PermWrite
+	case flags.IsReadWrite():
+		perm = filer2.PermRead | filer2.PermWrite
+	}
+	if flags&fuse.OpenTruncate != 0 {
+		perm |= filer2.PermWrite
+	}
+	return
+}
+
+// filerErrno maps the errors of the filer requests to the errno
+func filerErrno(err error, defaultErrno fuse.Errno) error {
+	if filer2.IsQuotaExceeded(err) {
+		return fuse.Errno(syscall.EDQUOT)
+	}
+	if filer2.IsPermissionDenied(err) {
+		return fuse.Errno(syscall.EACCES)
+	}
+	if filer2.IsNotLockServer(err) {
+		return fuse.Errno(syscall.ENOLCK)
+	}
+	return defaultErrno
+}
@@ -14,6 +14,7 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util/chunk_cache"
 	"google.golang.org/grpc"
 )
 
@@ -26,9 +27,21 @@ type Option struct {
 	TtlSec             int32
 	ChunkSizeLimit     int64
 	DataCenter         string
+	DiskType           string
 	DirListingLimit    int
 	EntryCacheTtl      time.Duration
 
+	// chunk cache, disabled if both capacities are 0
+	ChunkCacheMemoryLimit int64
+	ChunkCacheDir         string
+	ChunkCacheDiskLimit   int64
+	// the number of chunks to read ahead for sequential reads
+	ReadAheadChunks int
+
+	// the dirty pages beyond the memory limit are swapped to the swap directory
+	DirtyPagesMemoryLimit int64
+	SwapDir               string
+
 	MountUid  uint32
 	MountGid  uint32
 	MountMode os.FileMode
@@ -45,12 +58,23 @@ type WFS struct {
 	handles           []*FileHandle
 	pathToHandleIndex map[string]int
 	pathToHandleLock  sync.Mutex
-	bufPool           sync.Pool
 
 	stats statsCache
+
+	chunkCache          *chunk_cache.ChunkCache
+	volumeLocationCache *ccache.Cache
+	chunkFetches        map[string]*chunkFetch
+	chunkFetchesLock    sync.Mutex
+
+	// the memory size of the dirty pages of all handles
+	dirtyPagesMemory int64
+
+	// identifies the mount to the filer lock manager
+	lockClient string
 }
 type statsCache struct {
 	filer_pb.StatisticsResponse
+	quota       *filer_pb.Quota
 	lastChecked int64 // unix time in seconds
 }
 
@@ -59,13 +83,22 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 		option:                    option,
 		listDirectoryEntriesCache: ccache.New(ccache.Configure().MaxSize(1024 * 8).ItemsToPrune(100)),
 		pathToHandleIndex:         make(map[string]int),
-		bufPool: sync.Pool{
-			New: func() interface{} {
-				return make([]byte, option.ChunkSizeLimit)
-			},
-		},
+		volumeLocationCache:       ccache.New(ccache.Configure().MaxSize(1024 * 8).ItemsToPrune(100)),
+		chunkFetches:              make(map[string]*chunkFetch),
+		lockClient:                newLockClientId(),
+	}
+
+	if option.ChunkCacheMemoryLimit > 0 || option.ChunkCacheDiskLimit > 0 && option.ChunkCacheDir != "" {
+		chunkCache, err := chunk_cache.NewChunkCache(option.ChunkCacheMemoryLimit, option.ChunkCacheDir, option.ChunkCacheDiskLimit)
+		if err != nil {
+			glog.Errorf("chunk cache is disabled: %v", err)
+		} else {
+			wfs.chunkCache = chunkCache
+		}
 	}
 
+	go wfs.keepLocks()
+
 	return wfs
 }
 
@@ -113,6 +146,16 @@ func (wfs *WFS) AcquireHandle(file *File, uid, gid uint32) (fileHandle *FileHand
 	return
 }
 
+func (wfs *WFS) findHandle(fullpath string) *FileHandle {
+	wfs.pathToHandleLock.Lock()
+	defer wfs.pathToHandleLock.Unlock()
+
+	if index, found := wfs.pathToHandleIndex[fullpath]; found && index < len(wfs.handles) {
+		return wfs.handles[index]
+	}
+	return nil
+}
+
 func (wfs *WFS) ReleaseHandle(fullpath string, handleId fuse.HandleID) {
 	wfs.pathToHandleLock.Lock()
 	defer wfs.pathToHandleLock.Unlock()
@@ -152,6 +195,17 @@ func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.
 			wfs.stats.TotalSize = resp.TotalSize
 			wfs.stats.UsedSize = resp.UsedSize
 			wfs.stats.FileCount = resp.FileCount
+
+			// the mount is limited by the quota of the mount root or its ancestors
+			quotaResp, err := client.GetQuota(ctx, &filer_pb.GetQuotaRequest{
+				Directory: wfs.option.FilerMountRootPath,
+			})
+			if err != nil {
+				glog.V(0).Infof("reading quota of %s: %v", wfs.option.FilerMountRootPath, err)
+				return err
+			}
+			wfs.stats.quota = quotaResp.Quota
+
 			wfs.stats.lastChecked = time.Now().Unix()
 
 			return nil
@@ -165,6 +219,24 @@ func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.
 	totalDiskSize := wfs.stats.TotalSize
 	usedDiskSize := wfs.stats.UsedSize
 	actualFileCount := wfs.stats.FileCount
+	totalFileCount := uint64(math.MaxInt64)
+
+	if quota := wfs.stats.quota; quota != nil {
+		if quota.MaxBytes > 0 {
+			totalDiskSize = uint64(quota.MaxBytes)
+			usedDiskSize = uint64(quota.UsedBytes)
+			if usedDiskSize > totalDiskSize {
+				usedDiskSize = totalDiskSize
+			}
+		}
+		if quota.MaxFiles > 0 {
+			totalFileCount = uint64(quota.MaxFiles)
+			actualFileCount = uint64(quota.UsedFiles)
+			if actualFileCount > totalFileCount {
+				actualFileCount = totalFileCount
+			}
+		}
+	}
 
 	// Compute the total number of available blocks
 	resp.Blocks = totalDiskSize / blockSize
@@ -178,8 +250,8 @@ func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.
 	resp.Bsize = uint32(blockSize)
 
 	// Report the total number of possible files in the file system (and those free)
-	resp.Files = math.MaxInt64
-	resp.Ffree = math.MaxInt64 - actualFileCount
+	resp.Files = totalFileCount
+	resp.Ffree = totalFileCount - actualFileCount
 
 	// Report the maximum length of a name and the minimum fragment size
 	resp.Namelen = 1024
@@ -0,0 +1,162 @@
+package filesys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/wdclient"
+)
+
+const volumeLocationCacheTtl = 10 * time.Minute
+
+// chunkFetch is the in flight fetching of one chunk, shared by the readers and the read ahead
+type chunkFetch struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// lookupVolumeLocations returns the locations of the volume ids, looking up the filer only for the uncached ones
+func (wfs *WFS) lookupVolumeLocations(ctx context.Context, vids []string) (map[string]*filer_pb.Locations, error) {
+
+	vid2Locations := make(map[string]*filer_pb.Locations)
+
+	var missingVids []string
+	for _, vid := range vids {
+		if _, found := vid2Locations[vid]; found {
+			continue
+		}
+		if item := wfs.volumeLocationCache.Get(vid); item != nil && !item.Expired() {
+			vid2Locations[vid] = item.Value().(*filer_pb.Locations)
+			continue
+		}
+		missingVids = append(missingVids, vid)
+	}
+
+	if len(missingVids) == 0 {
+		return vid2Locations, nil
+	}
+
+	err := wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		glog.V(4).Infof("read fh lookup volume id locations: %v", missingVids)
+		resp, err := client.LookupVolume(ctx, &filer_pb.LookupVolumeRequest{
+			VolumeIds: missingVids,
+		})
+		if err != nil {
+			return err
+		}
+
+		for vid, locations := range resp.LocationsMap {
+			if len(locations.Locations) == 0 {
+				continue
+			}
+			vid2Locations[vid] = locations
+			wfs.volumeLocationCache.Set(vid, locations, volumeLocationCacheTtl)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup volume ids %v: %v", missingVids, err)
+	}
+
+	return vid2Locations, nil
+}
+
+// readChunkView reads the chunk view into the buffer, through the chunk cache if enabled
+func (wfs *WFS) readChunkView(chunkView *filer2.ChunkView, locations *filer_pb.Locations, buff []byte) (int64, error) {
+
+	if wfs.chunkCache == nil {
+		return wdclient.ReadFromLocations(wdclient.FromFilerLocations(locations), wfs.option.DataCenter,
+			chunkView.FileId, chunkView.Offset, int(chunkView.Size), buff, !chunkView.IsFullChunk)
+	}
+
+	data, err := wfs.fetchChunk(chunkView.FileId, chunkView.ChunkSize, locations)
+	if err != nil {
+		return 0, err
+	}
+	if chunkView.Offset >= int64(len(data)) {
+		return 0, nil
+	}
+	return int64(copy(buff, data[chunkView.Offset:])), nil
+}
+
+// fetchChunk returns the whole chunk content from the chunk cache, or reads it from the volume servers
+func (wfs *WFS) fetchChunk(fileId string, chunkSize uint64, locations *filer_pb.Locations) ([]byte, error) {
+
+	if data := wfs.chunkCache.GetChunk(fileId); data != nil {
+		return data, nil
+	}
+
+	wfs.chunkFetchesLock.Lock()
+	fetch, found := wfs.chunkFetches[fileId]
+	if !found {
+		fetch = &chunkFetch{done: make(chan struct{})}
+		wfs.chunkFetches[fileId] = fetch
+	}
+	wfs.chunkFetchesLock.Unlock()
+
+	if found {
+		<-fetch.done
+		return fetch.data, fetch.err
+	}
+
+	data := make([]byte, chunkSize)
+	n, err := wdclient.ReadFromLocations(wdclient.FromFilerLocations(locations), wfs.option.DataCenter, fileId, 0, int(chunkSize), data, false)
+	if err != nil {
+		fetch.err = err
+	} else {
+		fetch.data = data[:n]
+		wfs.chunkCache.SetChunk(fileId, fetch.data)
+	}
+
+	wfs.chunkFetchesLock.Lock()
+	delete(wfs.chunkFetches, fileId)
+	wfs.chunkFetchesLock.Unlock()
+	close(fetch.done)
+
+	return fetch.data, fetch.err
+}
+
+// readAhead fetches the chunks of the chunk views into the chunk cache in the background
+func (wfs *WFS) readAhead(chunkViews []*filer2.ChunkView) {
+
+	if wfs.chunkCache == nil || len(chunkViews) == 0 {
+		return
+	}
+
+	go func() {
+
+		ctx := context.Background()
+
+		var vids []string
+		for _, chunkView := range chunkViews {
+			vids = append(vids, volumeId(chunkView.FileId))
+		}
+		vid2Locations, err := wfs.lookupVolumeLocations(ctx, vids)
+		if err != nil {
+			glog.V(1).Infof("read ahead: %v", err)
+			return
+		}
+
+		for _, chunkView := range chunkViews {
+			locations := vid2Locations[volumeId(chunkView.FileId)]
+			if locations == nil {
+				continue
+			}
+			glog.V(4).Infof("read ahead %s", chunkView.FileId)
+			if _, err := wfs.fetchChunk(chunkView.FileId, chunkView.ChunkSize, locations); err != nil {
+				glog.V(1).Infof("read ahead %s: %v", chunkView.FileId, err)
+				wfs.volumeLocationCache.Delete(volumeId(chunkView.FileId))
+			}
+		}
+
+	}()
+
+}
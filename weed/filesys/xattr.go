@@ -0,0 +1,356 @@
+package filesys
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/seaweedfs/fuse"
+	"github.com/seaweedfs/fuse/fs"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	// the same limits as Linux
+	MaxXattrNameSize  = 255
+	MaxXattrValueSize = 65536
+
+	xattrCreate  = 0x1 // XATTR_CREATE
+	xattrReplace = 0x2 // XATTR_REPLACE
+)
+
+// only the names in the xattr namespaces are exposed,
+// the other keys of the extended attributes are internal, e.g., the S3 object metadata
+var xattrNamespaces = []string{"user.", "trusted.", "security.", "system."}
+
+var _ = fs.NodeGetxattrer(&File{})
+var _ = fs.NodeSetxattrer(&File{})
+var _ = fs.NodeRemovexattrer(&File{})
+var _ = fs.NodeListxattrer(&File{})
+
+var _ = fs.NodeGetxattrer(&Dir{})
+var _ = fs.NodeSetxattrer(&Dir{})
+var _ = fs.NodeRemovexattrer(&Dir{})
+var _ = fs.NodeListxattrer(&Dir{})
+
+func (file *File) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if err := file.maybeLoadEntry(ctx); err != nil {
+		return err
+	}
+
+	return getxattr(file.entry, req, resp)
+}
+
+func (file *File) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+
+	glog.V(3).Infof("%v file setxattr %s", file.fullpath(), req.Name)
+
+	return file.updateXattr(ctx, func(entry *filer_pb.Entry) error {
+		return setxattr(entry, req)
+	})
+}
+
+func (file *File) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+
+	glog.V(3).Infof("%v file removexattr %s", file.fullpath(), req.Name)
+
+	return file.updateXattr(ctx, func(entry *filer_pb.Entry) error {
+		return removexattr(entry, req)
+	})
+}
+
+func (file *File) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if err := file.maybeLoadEntry(ctx); err != nil {
+		return err
+	}
+
+	return listxattr(file.entry, req, resp)
+}
+
+// updateXattr saves the extended attributes, the open file keeps its unsaved chunks and attributes
+func (file *File) updateXattr(ctx context.Context, fn func(entry *filer_pb.Entry) error) error {
+
+	entry, err := file.wfs.updateXattr(ctx, file.dir.Path, file.Name, fn)
+	if err == fuse.ENOENT && file.entry != nil && file.isOpen {
+		// the created file is saved with its extended attributes when it is flushed
+		return fn(file.entry)
+	}
+	if err != nil {
+		return err
+	}
+
+	if file.entry != nil && file.isOpen {
+		file.entry.Extended = entry.Extended
+	} else {
+		file.setEntry(entry)
+	}
+
+	return nil
+}
+
+// maybeLoadEntry refreshes the entry unless the file is open, in which case the local entry is the latest
+func (file *File) maybeLoadEntry(ctx context.Context) error {
+
+	if file.entry != nil && file.isOpen {
+		return nil
+	}
+
+	entry, err := file.wfs.lookupEntry(ctx, file.dir.Path, file.Name)
+	if err != nil {
+		return err
+	}
+	if entry != file.entry {
+		file.setEntry(entry)
+	}
+
+	return nil
+}
+
+func (dir *Dir) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if err := dir.maybeLoadEntry(ctx); err != nil {
+		return err
+	}
+
+	return getxattr(dir.entry, req, resp)
+}
+
+func (dir *Dir) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+
+	glog.V(3).Infof("%v dir setxattr %s", dir.Path, req.Name)
+
+	parentDir, name := filer2.FullPath(dir.Path).DirAndName()
+	if name == "" {
+		return fuse.ENOTSUP
+	}
+
+	entry, err := dir.wfs.updateXattr(ctx, parentDir, name, func(entry *filer_pb.Entry) error {
+		return setxattr(entry, req)
+	})
+	if err != nil {
+		return err
+	}
+	dir.entry = entry
+
+	return nil
+}
+
+func (dir *Dir) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+
+	glog.V(3).Infof("%v dir removexattr %s", dir.Path, req.Name)
+
+	parentDir, name := filer2.FullPath(dir.Path).DirAndName()
+	if name == "" {
+		return fuse.ENOTSUP
+	}
+
+	entry, err := dir.wfs.updateXattr(ctx, parentDir, name, func(entry *filer_pb.Entry) error {
+		return removexattr(entry, req)
+	})
+	if err != nil {
+		return err
+	}
+	dir.entry = entry
+
+	return nil
+}
+
+func (dir *Dir) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if err := dir.maybeLoadEntry(ctx); err != nil {
+		return err
+	}
+
+	return listxattr(dir.entry, req, resp)
+}
+
+// maybeLoadEntry refreshes the directory entry, the filer root has no entry
+func (dir *Dir) maybeLoadEntry(ctx context.Context) error {
+
+	parentDir, name := filer2.FullPath(dir.Path).DirAndName()
+	if name == "" {
+		return nil
+	}
+
+	entry, err := dir.wfs.lookupEntry(ctx, parentDir, name)
+	if err != nil {
+		return err
+	}
+	dir.entry = entry
+
+	return nil
+}
+
+func isXattrName(name string) bool {
+	for _, namespace := range xattrNamespaces {
+		if strings.HasPrefix(name, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+func getxattr(entry *filer_pb.Entry, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if entry == nil || !isXattrName(req.Name) {
+		return fuse.ErrNoXattr
+	}
+
+	data, found := entry.Extended[req.Name]
+	if !found {
+		return fuse.ErrNoXattr
+	}
+
+	resp.Xattr = data
+
+	return nil
+}
+
+func setxattr(entry *filer_pb.Entry, req *fuse.SetxattrRequest) error {
+
+	if entry == nil {
+		return fuse.ENOTSUP
+	}
+	if !isXattrName(req.Name) {
+		return fuse.ENOTSUP
+	}
+	if len(req.Name) > MaxXattrNameSize {
+		return fuse.ERANGE
+	}
+	if len(req.Xattr) > MaxXattrValueSize {
+		return fuse.Errno(syscall.E2BIG)
+	}
+
+	_, found := entry.Extended[req.Name]
+	if found && req.Flags&xattrCreate != 0 {
+		return fuse.EEXIST
+	}
+	if !found && req.Flags&xattrReplace != 0 {
+		return fuse.ErrNoXattr
+	}
+
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[req.Name] = append([]byte(nil), req.Xattr...)
+
+	return nil
+}
+
+func removexattr(entry *filer_pb.Entry, req *fuse.RemovexattrRequest) error {
+
+	if entry == nil || !isXattrName(req.Name) {
+		return fuse.ErrNoXattr
+	}
+
+	if _, found := entry.Extended[req.Name]; !found {
+		return fuse.ErrNoXattr
+	}
+
+	delete(entry.Extended, req.Name)
+
+	return nil
+}
+
+func listxattr(entry *filer_pb.Entry, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if entry == nil {
+		return nil
+	}
+
+	for name := range entry.Extended {
+		if isXattrName(name) {
+			resp.Append(name)
+		}
+	}
+
+	return nil
+}
+
+// lookupEntry looks up the entry, and caches it for EntryCacheTtl
+func (wfs *WFS) lookupEntry(ctx context.Context, dir, name string) (entry *filer_pb.Entry, err error) {
+
+	fullpath := filepath.Join(dir, name)
+
+	item := wfs.listDirectoryEntriesCache.Get(fullpath)
+	if item != nil && !item.Expired() {
+		return item.Value().(*filer_pb.Entry), nil
+	}
+
+	return wfs.loadEntry(ctx, dir, name)
+}
+
+// loadEntry reads the entry from the filer, and caches it for EntryCacheTtl
+func (wfs *WFS) loadEntry(ctx context.Context, dir, name string) (entry *filer_pb.Entry, err error) {
+
+	fullpath := filepath.Join(dir, name)
+
+	err = wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		resp, err := client.LookupDirectoryEntry(ctx, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if err != nil {
+			glog.V(3).Infof("lookup %s: %v", fullpath, err)
+			return fuse.ENOENT
+		}
+
+		entry = resp.Entry
+		wfs.listDirectoryEntriesCache.Set(fullpath, entry, wfs.option.EntryCacheTtl)
+
+		return nil
+	})
+
+	return
+}
+
+// updateEntry saves the entry to the filer, and refreshes the cached entry
+func (wfs *WFS) updateEntry(ctx context.Context, dir string, entry *filer_pb.Entry) error {
+
+	fullpath := filepath.Join(dir, entry.Name)
+
+	return wfs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		if _, err := client.UpdateEntry(ctx, &filer_pb.UpdateEntryRequest{
+			Directory: dir,
+			Entry:     entry,
+		}); err != nil {
+			glog.V(0).Infof("UpdateEntry %s: %v", fullpath, err)
+			wfs.listDirectoryEntriesCache.Delete(fullpath)
+			return filerErrno(err, fuse.EIO)
+		}
+
+		wfs.listDirectoryEntriesCache.Set(fullpath, entry, wfs.option.EntryCacheTtl)
+
+		return nil
+	})
+}
+
+// updateXattr changes the extended attributes of the entry read from the filer, and saves it.
+// The change is made on a copy, leaving the cached entry as it is if the update fails,
+// and the chunks not saved by the open files are not sent to the filer.
+func (wfs *WFS) updateXattr(ctx context.Context, dir, name string, fn func(entry *filer_pb.Entry) error) (*filer_pb.Entry, error) {
+
+	entry, err := wfs.loadEntry(ctx, dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = proto.Clone(entry).(*filer_pb.Entry)
+	if err = fn(entry); err != nil {
+		return nil, err
+	}
+
+	if err = wfs.updateEntry(ctx, dir, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,273 @@
+package filesys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/karlseguin/ccache"
+	"github.com/seaweedfs/fuse"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"google.golang.org/grpc"
+)
+
+func TestXattrEncoding(t *testing.T) {
+
+	entry := &filer_pb.Entry{Name: "file"}
+
+	// the values are binary, kept as they are, and copied from the request buffer
+	value := []byte{0, 1, 0xff, '\n', 0}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.binary", Xattr: value}); err != nil {
+		t.Fatalf("set user.binary: %v", err)
+	}
+	value[0] = 'x'
+	resp := &fuse.GetxattrResponse{}
+	if err := getxattr(entry, &fuse.GetxattrRequest{Name: "user.binary"}, resp); err != nil {
+		t.Fatalf("get user.binary: %v", err)
+	}
+	if !bytes.Equal(resp.Xattr, []byte{0, 1, 0xff, '\n', 0}) {
+		t.Errorf("user.binary %q", resp.Xattr)
+	}
+
+	// an empty value is an attribute
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "security.empty"}); err != nil {
+		t.Fatalf("set security.empty: %v", err)
+	}
+	resp = &fuse.GetxattrResponse{}
+	if err := getxattr(entry, &fuse.GetxattrRequest{Name: "security.empty"}, resp); err != nil || len(resp.Xattr) != 0 {
+		t.Errorf("get security.empty %q: %v", resp.Xattr, err)
+	}
+
+	// the names are listed NUL terminated, without the internal keys
+	entry.Extended["X-Amz-Meta-Color"] = []byte("red")
+	listResp := &fuse.ListxattrResponse{}
+	if err := listxattr(entry, &fuse.ListxattrRequest{}, listResp); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(listResp.Xattr) == 0 || listResp.Xattr[len(listResp.Xattr)-1] != 0 {
+		t.Fatalf("list %q not NUL terminated", listResp.Xattr)
+	}
+	names := strings.Split(string(listResp.Xattr[:len(listResp.Xattr)-1]), "\x00")
+	sort.Strings(names)
+	if strings.Join(names, ",") != "security.empty,user.binary" {
+		t.Errorf("listed %q", names)
+	}
+
+	// the internal keys are not exposed or changed
+	if err := getxattr(entry, &fuse.GetxattrRequest{Name: "X-Amz-Meta-Color"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("get internal key: %v", err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "X-Amz-Meta-Color", Xattr: []byte("blue")}); err != fuse.ENOTSUP {
+		t.Errorf("set internal key: %v", err)
+	}
+	if err := removexattr(entry, &fuse.RemovexattrRequest{Name: "X-Amz-Meta-Color"}); err != fuse.ErrNoXattr {
+		t.Errorf("remove internal key: %v", err)
+	}
+	if string(entry.Extended["X-Amz-Meta-Color"]) != "red" {
+		t.Errorf("internal key changed to %q", entry.Extended["X-Amz-Meta-Color"])
+	}
+
+	if err := listxattr(nil, &fuse.ListxattrRequest{}, &fuse.ListxattrResponse{}); err != nil {
+		t.Errorf("list without entry: %v", err)
+	}
+}
+
+func TestXattrSizeLimits(t *testing.T) {
+
+	entry := &filer_pb.Entry{Name: "file"}
+
+	name := "user." + strings.Repeat("n", MaxXattrNameSize-len("user."))
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: name, Xattr: []byte("v")}); err != nil {
+		t.Errorf("set name of %d bytes: %v", len(name), err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: name + "n", Xattr: []byte("v")}); err != fuse.ERANGE {
+		t.Errorf("set name of %d bytes: %v", len(name)+1, err)
+	}
+
+	value := make([]byte, MaxXattrValueSize)
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.large", Xattr: value}); err != nil {
+		t.Errorf("set value of %d bytes: %v", len(value), err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.large", Xattr: append(value, 0)}); err != fuse.Errno(syscall.E2BIG) {
+		t.Errorf("set value of %d bytes: %v", len(value)+1, err)
+	}
+	if len(entry.Extended["user.large"]) != MaxXattrValueSize {
+		t.Errorf("value of %d bytes after the oversized set", len(entry.Extended["user.large"]))
+	}
+}
+
+func TestXattrMissing(t *testing.T) {
+
+	entry := &filer_pb.Entry{Name: "file"}
+
+	// ENODATA on Linux, ENOATTR on OS X
+	if err := getxattr(entry, &fuse.GetxattrRequest{Name: "user.missing"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("get missing: %v", err)
+	}
+	if err := getxattr(nil, &fuse.GetxattrRequest{Name: "user.missing"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("get without entry: %v", err)
+	}
+	if err := getxattr(entry, &fuse.GetxattrRequest{Name: "missing"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("get outside the namespaces: %v", err)
+	}
+	if err := removexattr(entry, &fuse.RemovexattrRequest{Name: "user.missing"}); err != fuse.ErrNoXattr {
+		t.Errorf("remove missing: %v", err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.missing", Flags: xattrReplace}); err != fuse.ErrNoXattr {
+		t.Errorf("replace missing: %v", err)
+	}
+	if err := setxattr(nil, &fuse.SetxattrRequest{Name: "user.a"}); err != fuse.ENOTSUP {
+		t.Errorf("set without entry: %v", err)
+	}
+
+	// create fails on an existing attribute, replace and remove succeed
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("1"), Flags: xattrCreate}); err != nil {
+		t.Fatalf("create user.a: %v", err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("2"), Flags: xattrCreate}); err != fuse.EEXIST {
+		t.Errorf("create existing: %v", err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("3"), Flags: xattrReplace}); err != nil {
+		t.Errorf("replace existing: %v", err)
+	}
+	if string(entry.Extended["user.a"]) != "3" {
+		t.Errorf("user.a %q", entry.Extended["user.a"])
+	}
+	if err := removexattr(entry, &fuse.RemovexattrRequest{Name: "user.a"}); err != nil {
+		t.Errorf("remove user.a: %v", err)
+	}
+	if err := getxattr(entry, &fuse.GetxattrRequest{Name: "user.a"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Errorf("get removed: %v", err)
+	}
+}
+
+// testXattrFiler keeps one entry, and fails the updates if failUpdate is set
+type testXattrFiler struct {
+	filer_pb.SeaweedFilerServer // the calls not used by the tests are not implemented
+
+	lock       sync.Mutex
+	entry      *filer_pb.Entry
+	failUpdate bool
+}
+
+func (f *testXattrFiler) LookupDirectoryEntry(ctx context.Context, req *filer_pb.LookupDirectoryEntryRequest) (*filer_pb.LookupDirectoryEntryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.entry == nil || req.Name != f.entry.Name {
+		return nil, fmt.Errorf("%s not found", req.Name)
+	}
+	return &filer_pb.LookupDirectoryEntryResponse{Entry: proto.Clone(f.entry).(*filer_pb.Entry)}, nil
+}
+
+func (f *testXattrFiler) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntryRequest) (*filer_pb.UpdateEntryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failUpdate {
+		return nil, fmt.Errorf("update %s failed", req.Entry.Name)
+	}
+	f.entry = proto.Clone(req.Entry).(*filer_pb.Entry)
+	return &filer_pb.UpdateEntryResponse{}, nil
+}
+
+func (f *testXattrFiler) stored() *filer_pb.Entry {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return proto.Clone(f.entry).(*filer_pb.Entry)
+}
+
+func TestXattrUpdate(t *testing.T) {
+
+	filer := &testXattrFiler{entry: &filer_pb.Entry{
+		Name:       "file",
+		Attributes: &filer_pb.FuseAttributes{FileMode: 0644},
+		Chunks:     []*filer_pb.FileChunk{{FileId: "1,saved", Size: 10}},
+		Extended:   map[string][]byte{"user.a": []byte("1")},
+	}}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	filer_pb.RegisterSeaweedFilerServer(grpcServer, filer)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	wfs := &WFS{
+		option: &Option{
+			FilerGrpcAddress: listener.Addr().String(),
+			GrpcDialOption:   grpc.WithInsecure(),
+			EntryCacheTtl:    time.Hour,
+		},
+		listDirectoryEntriesCache: ccache.New(ccache.Configure().MaxSize(1024).ItemsToPrune(100)),
+	}
+	file := &File{Name: "file", dir: &Dir{Path: "/dir", wfs: wfs}, wfs: wfs}
+	ctx := context.Background()
+
+	// the cached entry is not changed by a failed update
+	if err := file.Getxattr(ctx, &fuse.GetxattrRequest{Name: "user.a"}, &fuse.GetxattrResponse{}); err != nil {
+		t.Fatalf("get user.a: %v", err)
+	}
+	cached := wfs.listDirectoryEntriesCache.Get("/dir/file").Value().(*filer_pb.Entry)
+	filer.failUpdate = true
+	if err := file.Setxattr(ctx, &fuse.SetxattrRequest{Name: "user.b", Xattr: []byte("2")}); err == nil {
+		t.Errorf("set user.b with the update failure")
+	}
+	if err := file.Removexattr(ctx, &fuse.RemovexattrRequest{Name: "user.a"}); err == nil {
+		t.Errorf("remove user.a with the update failure")
+	}
+	if _, found := cached.Extended["user.b"]; found || string(cached.Extended["user.a"]) != "1" {
+		t.Errorf("cached entry changed to %v", cached.Extended)
+	}
+	if _, found := file.entry.Extended["user.b"]; found || string(file.entry.Extended["user.a"]) != "1" {
+		t.Errorf("file entry changed to %v", file.entry.Extended)
+	}
+	filer.failUpdate = false
+
+	// the update is made on the entry in the filer, not the cached one
+	filer.lock.Lock()
+	filer.entry.Extended["user.c"] = []byte("3")
+	filer.lock.Unlock()
+	if err := file.Setxattr(ctx, &fuse.SetxattrRequest{Name: "user.b", Xattr: []byte("2")}); err != nil {
+		t.Fatalf("set user.b: %v", err)
+	}
+	if stored := filer.stored(); len(stored.Extended) != 3 {
+		t.Errorf("stored %v", stored.Extended)
+	}
+
+	// the open file does not save its unsaved chunks, and keeps them
+	file.isOpen = true
+	file.addChunks([]*filer_pb.FileChunk{{FileId: "2,unsaved", Offset: 10, Size: 10, Mtime: 1}})
+	if err := file.Removexattr(ctx, &fuse.RemovexattrRequest{Name: "user.a"}); err != nil {
+		t.Fatalf("remove user.a: %v", err)
+	}
+	stored := filer.stored()
+	if len(stored.Chunks) != 1 || stored.Chunks[0].FileId != "1,saved" {
+		t.Errorf("stored chunks %v", stored.Chunks)
+	}
+	if _, found := stored.Extended["user.a"]; found {
+		t.Errorf("stored %v", stored.Extended)
+	}
+	if len(file.entry.Chunks) != 2 || len(file.unsavedChunks) != 1 {
+		t.Errorf("open file chunks %v, unsaved %v", file.entry.Chunks, file.unsavedChunks)
+	}
+	if _, found := file.entry.Extended["user.a"]; found || len(file.entry.Extended) != 2 {
+		t.Errorf("open file %v", file.entry.Extended)
+	}
+
+	// the created file is not in the filer until it is flushed
+	created := &File{Name: "created", dir: file.dir, wfs: wfs, entry: &filer_pb.Entry{Name: "created"}, isOpen: true}
+	if err := created.Setxattr(ctx, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("1")}); err != nil {
+		t.Fatalf("set user.a of the created file: %v", err)
+	}
+	if string(created.entry.Extended["user.a"]) != "1" {
+		t.Errorf("created file %v", created.entry.Extended)
+	}
+}
@@ -19,6 +19,7 @@ type VolumeAssignRequest struct {
 	DataCenter  string
 	Rack        string
 	DataNode    string
+	DiskType    string
 }
 
 type AssignResult struct {
@@ -44,7 +45,7 @@ func Assign(server string, grpcDialOption grpc.DialOption, primaryRequest *Volum
 			continue
 		}
 
-		lastError = withMasterServerClient(server, grpcDialOption, func(masterClient master_pb.SeaweedClient) error {
+		lastError = WithMasterServerClient(server, grpcDialOption, func(masterClient master_pb.SeaweedClient) error {
 
 			req := &master_pb.AssignRequest{
 				Count:       primaryRequest.Count,
@@ -54,6 +55,7 @@ func Assign(server string, grpcDialOption grpc.DialOption, primaryRequest *Volum
 				DataCenter:  primaryRequest.DataCenter,
 				Rack:        primaryRequest.Rack,
 				DataNode:    primaryRequest.DataNode,
+				DiskType:    primaryRequest.DiskType,
 			}
 			resp, grpcErr := masterClient.Assign(context.Background(), req)
 			if grpcErr != nil {
@@ -25,6 +25,8 @@ It has these top-level messages:
 	DeleteEntryResponse
 	AtomicRenameEntryRequest
 	AtomicRenameEntryResponse
+	LinkEntryRequest
+	LinkEntryResponse
 	AssignVolumeRequest
 	AssignVolumeResponse
 	LookupVolumeRequest
@@ -35,6 +37,26 @@ It has these top-level messages:
 	DeleteCollectionResponse
 	StatisticsRequest
 	StatisticsResponse
+	SubscribeMetadataRequest
+	SubscribeMetadataResponse
+	FileLock
+	LockRequest
+	LockResponse
+	UnlockRequest
+	UnlockResponse
+	QueryLockRequest
+	QueryLockResponse
+	KeepLocksRequest
+	KeepLocksResponse
+	FileLocks
+	LockServer
+	Quota
+	SetQuotaRequest
+	SetQuotaResponse
+	GetQuotaRequest
+	GetQuotaResponse
+	ListQuotasRequest
+	ListQuotasResponse
 */
 package filer_pb
 
@@ -163,11 +185,13 @@ func (m *ListEntriesResponse) GetEntries() []*Entry {
 }
 
 type Entry struct {
-	Name        string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	IsDirectory bool              `protobuf:"varint,2,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
-	Chunks      []*FileChunk      `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
-	Attributes  *FuseAttributes   `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
-	Extended    map[string][]byte `protobuf:"bytes,5,rep,name=extended" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Name            string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	IsDirectory     bool              `protobuf:"varint,2,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
+	Chunks          []*FileChunk      `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
+	Attributes      *FuseAttributes   `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
+	Extended        map[string][]byte `protobuf:"bytes,5,rep,name=extended" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	HardLinkId      []byte            `protobuf:"bytes,6,opt,name=hard_link_id,json=hardLinkId,proto3" json:"hard_link_id,omitempty"`
+	HardLinkCounter int32             `protobuf:"varint,7,opt,name=hard_link_counter,json=hardLinkCounter" json:"hard_link_counter,omitempty"`
 }
 
 func (m *Entry) Reset()                    { *m = Entry{} }
@@ -210,10 +234,25 @@ func (m *Entry) GetExtended() map[string][]byte {
 	return nil
 }
 
+func (m *Entry) GetHardLinkId() []byte {
+	if m != nil {
+		return m.HardLinkId
+	}
+	return nil
+}
+
+func (m *Entry) GetHardLinkCounter() int32 {
+	if m != nil {
+		return m.HardLinkCounter
+	}
+	return 0
+}
+
 type EventNotification struct {
 	OldEntry     *Entry `protobuf:"bytes,1,opt,name=old_entry,json=oldEntry" json:"old_entry,omitempty"`
 	NewEntry     *Entry `protobuf:"bytes,2,opt,name=new_entry,json=newEntry" json:"new_entry,omitempty"`
 	DeleteChunks bool   `protobuf:"varint,3,opt,name=delete_chunks,json=deleteChunks" json:"delete_chunks,omitempty"`
+	Origin       string `protobuf:"bytes,4,opt,name=origin" json:"origin,omitempty"`
 }
 
 func (m *EventNotification) Reset()                    { *m = EventNotification{} }
@@ -242,6 +281,13 @@ func (m *EventNotification) GetDeleteChunks() bool {
 	return false
 }
 
+func (m *EventNotification) GetOrigin() string {
+	if m != nil {
+		return m.Origin
+	}
+	return ""
+}
+
 type FileChunk struct {
 	FileId       string `protobuf:"bytes,1,opt,name=file_id,json=fileId" json:"file_id,omitempty"`
 	Offset       int64  `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
@@ -478,8 +524,9 @@ type DeleteEntryRequest struct {
 	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
 	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
 	// bool is_directory = 3;
-	IsDeleteData bool `protobuf:"varint,4,opt,name=is_delete_data,json=isDeleteData" json:"is_delete_data,omitempty"`
-	IsRecursive  bool `protobuf:"varint,5,opt,name=is_recursive,json=isRecursive" json:"is_recursive,omitempty"`
+	IsDeleteData bool   `protobuf:"varint,4,opt,name=is_delete_data,json=isDeleteData" json:"is_delete_data,omitempty"`
+	IsRecursive  bool   `protobuf:"varint,5,opt,name=is_recursive,json=isRecursive" json:"is_recursive,omitempty"`
+	Origin       string `protobuf:"bytes,6,opt,name=origin" json:"origin,omitempty"`
 }
 
 func (m *DeleteEntryRequest) Reset()                    { *m = DeleteEntryRequest{} }
@@ -515,6 +562,13 @@ func (m *DeleteEntryRequest) GetIsRecursive() bool {
 	return false
 }
 
+func (m *DeleteEntryRequest) GetOrigin() string {
+	if m != nil {
+		return m.Origin
+	}
+	return ""
+}
+
 type DeleteEntryResponse struct {
 }
 
@@ -571,18 +625,75 @@ func (m *AtomicRenameEntryResponse) String() string            { return proto.Co
 func (*AtomicRenameEntryResponse) ProtoMessage()               {}
 func (*AtomicRenameEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
+type LinkEntryRequest struct {
+	OldDirectory string `protobuf:"bytes,1,opt,name=old_directory,json=oldDirectory" json:"old_directory,omitempty"`
+	OldName      string `protobuf:"bytes,2,opt,name=old_name,json=oldName" json:"old_name,omitempty"`
+	NewDirectory string `protobuf:"bytes,3,opt,name=new_directory,json=newDirectory" json:"new_directory,omitempty"`
+	NewName      string `protobuf:"bytes,4,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+}
+
+func (m *LinkEntryRequest) Reset()                    { *m = LinkEntryRequest{} }
+func (m *LinkEntryRequest) String() string            { return proto.CompactTextString(m) }
+func (*LinkEntryRequest) ProtoMessage()               {}
+func (*LinkEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *LinkEntryRequest) GetOldDirectory() string {
+	if m != nil {
+		return m.OldDirectory
+	}
+	return ""
+}
+
+func (m *LinkEntryRequest) GetOldName() string {
+	if m != nil {
+		return m.OldName
+	}
+	return ""
+}
+
+func (m *LinkEntryRequest) GetNewDirectory() string {
+	if m != nil {
+		return m.NewDirectory
+	}
+	return ""
+}
+
+func (m *LinkEntryRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type LinkEntryResponse struct {
+	Entry *Entry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+}
+
+func (m *LinkEntryResponse) Reset()                    { *m = LinkEntryResponse{} }
+func (m *LinkEntryResponse) String() string            { return proto.CompactTextString(m) }
+func (*LinkEntryResponse) ProtoMessage()               {}
+func (*LinkEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *LinkEntryResponse) GetEntry() *Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
 type AssignVolumeRequest struct {
 	Count       int32  `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
 	Collection  string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
 	Replication string `protobuf:"bytes,3,opt,name=replication" json:"replication,omitempty"`
 	TtlSec      int32  `protobuf:"varint,4,opt,name=ttl_sec,json=ttlSec" json:"ttl_sec,omitempty"`
 	DataCenter  string `protobuf:"bytes,5,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
+	DiskType    string `protobuf:"bytes,6,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
 }
 
 func (m *AssignVolumeRequest) Reset()                    { *m = AssignVolumeRequest{} }
 func (m *AssignVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*AssignVolumeRequest) ProtoMessage()               {}
-func (*AssignVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*AssignVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *AssignVolumeRequest) GetCount() int32 {
 	if m != nil {
@@ -619,6 +730,13 @@ func (m *AssignVolumeRequest) GetDataCenter() string {
 	return ""
 }
 
+func (m *AssignVolumeRequest) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
 type AssignVolumeResponse struct {
 	FileId    string `protobuf:"bytes,1,opt,name=file_id,json=fileId" json:"file_id,omitempty"`
 	Url       string `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
@@ -630,7 +748,7 @@ type AssignVolumeResponse struct {
 func (m *AssignVolumeResponse) Reset()                    { *m = AssignVolumeResponse{} }
 func (m *AssignVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*AssignVolumeResponse) ProtoMessage()               {}
-func (*AssignVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*AssignVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *AssignVolumeResponse) GetFileId() string {
 	if m != nil {
@@ -674,7 +792,7 @@ type LookupVolumeRequest struct {
 func (m *LookupVolumeRequest) Reset()                    { *m = LookupVolumeRequest{} }
 func (m *LookupVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeRequest) ProtoMessage()               {}
-func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *LookupVolumeRequest) GetVolumeIds() []string {
 	if m != nil {
@@ -690,7 +808,7 @@ type Locations struct {
 func (m *Locations) Reset()                    { *m = Locations{} }
 func (m *Locations) String() string            { return proto.CompactTextString(m) }
 func (*Locations) ProtoMessage()               {}
-func (*Locations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*Locations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *Locations) GetLocations() []*Location {
 	if m != nil {
@@ -700,14 +818,15 @@ func (m *Locations) GetLocations() []*Location {
 }
 
 type Location struct {
-	Url       string `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
-	PublicUrl string `protobuf:"bytes,2,opt,name=public_url,json=publicUrl" json:"public_url,omitempty"`
+	Url        string `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	PublicUrl  string `protobuf:"bytes,2,opt,name=public_url,json=publicUrl" json:"public_url,omitempty"`
+	DataCenter string `protobuf:"bytes,3,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
 }
 
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Location) GetUrl() string {
 	if m != nil {
@@ -723,6 +842,13 @@ func (m *Location) GetPublicUrl() string {
 	return ""
 }
 
+func (m *Location) GetDataCenter() string {
+	if m != nil {
+		return m.DataCenter
+	}
+	return ""
+}
+
 type LookupVolumeResponse struct {
 	LocationsMap map[string]*Locations `protobuf:"bytes,1,rep,name=locations_map,json=locationsMap" json:"locations_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -730,7 +856,7 @@ type LookupVolumeResponse struct {
 func (m *LookupVolumeResponse) Reset()                    { *m = LookupVolumeResponse{} }
 func (m *LookupVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse) ProtoMessage()               {}
-func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *LookupVolumeResponse) GetLocationsMap() map[string]*Locations {
 	if m != nil {
@@ -746,7 +872,7 @@ type DeleteCollectionRequest struct {
 func (m *DeleteCollectionRequest) Reset()                    { *m = DeleteCollectionRequest{} }
 func (m *DeleteCollectionRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCollectionRequest) ProtoMessage()               {}
-func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *DeleteCollectionRequest) GetCollection() string {
 	if m != nil {
@@ -761,7 +887,7 @@ type DeleteCollectionResponse struct {
 func (m *DeleteCollectionResponse) Reset()                    { *m = DeleteCollectionResponse{} }
 func (m *DeleteCollectionResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCollectionResponse) ProtoMessage()               {}
-func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 type StatisticsRequest struct {
 	Replication string `protobuf:"bytes,1,opt,name=replication" json:"replication,omitempty"`
@@ -772,7 +898,7 @@ type StatisticsRequest struct {
 func (m *StatisticsRequest) Reset()                    { *m = StatisticsRequest{} }
 func (m *StatisticsRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsRequest) ProtoMessage()               {}
-func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *StatisticsRequest) GetReplication() string {
 	if m != nil {
@@ -807,7 +933,7 @@ type StatisticsResponse struct {
 func (m *StatisticsResponse) Reset()                    { *m = StatisticsResponse{} }
 func (m *StatisticsResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsResponse) ProtoMessage()               {}
-func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *StatisticsResponse) GetReplication() string {
 	if m != nil {
@@ -851,194 +977,871 @@ func (m *StatisticsResponse) GetFileCount() uint64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*LookupDirectoryEntryRequest)(nil), "filer_pb.LookupDirectoryEntryRequest")
-	proto.RegisterType((*LookupDirectoryEntryResponse)(nil), "filer_pb.LookupDirectoryEntryResponse")
-	proto.RegisterType((*ListEntriesRequest)(nil), "filer_pb.ListEntriesRequest")
-	proto.RegisterType((*ListEntriesResponse)(nil), "filer_pb.ListEntriesResponse")
-	proto.RegisterType((*Entry)(nil), "filer_pb.Entry")
-	proto.RegisterType((*EventNotification)(nil), "filer_pb.EventNotification")
-	proto.RegisterType((*FileChunk)(nil), "filer_pb.FileChunk")
-	proto.RegisterType((*FuseAttributes)(nil), "filer_pb.FuseAttributes")
-	proto.RegisterType((*CreateEntryRequest)(nil), "filer_pb.CreateEntryRequest")
-	proto.RegisterType((*CreateEntryResponse)(nil), "filer_pb.CreateEntryResponse")
-	proto.RegisterType((*UpdateEntryRequest)(nil), "filer_pb.UpdateEntryRequest")
-	proto.RegisterType((*UpdateEntryResponse)(nil), "filer_pb.UpdateEntryResponse")
-	proto.RegisterType((*DeleteEntryRequest)(nil), "filer_pb.DeleteEntryRequest")
-	proto.RegisterType((*DeleteEntryResponse)(nil), "filer_pb.DeleteEntryResponse")
-	proto.RegisterType((*AtomicRenameEntryRequest)(nil), "filer_pb.AtomicRenameEntryRequest")
-	proto.RegisterType((*AtomicRenameEntryResponse)(nil), "filer_pb.AtomicRenameEntryResponse")
-	proto.RegisterType((*AssignVolumeRequest)(nil), "filer_pb.AssignVolumeRequest")
-	proto.RegisterType((*AssignVolumeResponse)(nil), "filer_pb.AssignVolumeResponse")
-	proto.RegisterType((*LookupVolumeRequest)(nil), "filer_pb.LookupVolumeRequest")
-	proto.RegisterType((*Locations)(nil), "filer_pb.Locations")
-	proto.RegisterType((*Location)(nil), "filer_pb.Location")
-	proto.RegisterType((*LookupVolumeResponse)(nil), "filer_pb.LookupVolumeResponse")
-	proto.RegisterType((*DeleteCollectionRequest)(nil), "filer_pb.DeleteCollectionRequest")
-	proto.RegisterType((*DeleteCollectionResponse)(nil), "filer_pb.DeleteCollectionResponse")
-	proto.RegisterType((*StatisticsRequest)(nil), "filer_pb.StatisticsRequest")
-	proto.RegisterType((*StatisticsResponse)(nil), "filer_pb.StatisticsResponse")
+type SubscribeMetadataRequest struct {
+	ClientName string `protobuf:"bytes,1,opt,name=client_name,json=clientName" json:"client_name,omitempty"`
+	PathPrefix string `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix" json:"path_prefix,omitempty"`
+	SinceNs    int64  `protobuf:"varint,3,opt,name=since_ns,json=sinceNs" json:"since_ns,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SubscribeMetadataRequest) Reset()                    { *m = SubscribeMetadataRequest{} }
+func (m *SubscribeMetadataRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeMetadataRequest) ProtoMessage()               {}
+func (*SubscribeMetadataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
-// Client API for SeaweedFiler service
+func (m *SubscribeMetadataRequest) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
 
-type SeaweedFilerClient interface {
-	LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error)
-	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
-	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error)
-	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error)
-	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error)
-	AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error)
-	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
-	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
-	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
-	Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error)
+func (m *SubscribeMetadataRequest) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
 }
 
-type seaweedFilerClient struct {
-	cc *grpc.ClientConn
+func (m *SubscribeMetadataRequest) GetSinceNs() int64 {
+	if m != nil {
+		return m.SinceNs
+	}
+	return 0
 }
 
-func NewSeaweedFilerClient(cc *grpc.ClientConn) SeaweedFilerClient {
-	return &seaweedFilerClient{cc}
+type SubscribeMetadataResponse struct {
+	Directory         string             `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	EventNotification *EventNotification `protobuf:"bytes,2,opt,name=event_notification,json=eventNotification" json:"event_notification,omitempty"`
+	TsNs              int64              `protobuf:"varint,3,opt,name=ts_ns,json=tsNs" json:"ts_ns,omitempty"`
 }
 
-func (c *seaweedFilerClient) LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error) {
-	out := new(LookupDirectoryEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LookupDirectoryEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SubscribeMetadataResponse) Reset()                    { *m = SubscribeMetadataResponse{} }
+func (m *SubscribeMetadataResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeMetadataResponse) ProtoMessage()               {}
+func (*SubscribeMetadataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *SubscribeMetadataResponse) GetDirectory() string {
+	if m != nil {
+		return m.Directory
 	}
-	return out, nil
+	return ""
 }
 
-func (c *seaweedFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
-	out := new(ListEntriesResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/ListEntries", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SubscribeMetadataResponse) GetEventNotification() *EventNotification {
+	if m != nil {
+		return m.EventNotification
 	}
-	return out, nil
+	return nil
 }
 
-func (c *seaweedFilerClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
-	out := new(CreateEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/CreateEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SubscribeMetadataResponse) GetTsNs() int64 {
+	if m != nil {
+		return m.TsNs
 	}
-	return out, nil
+	return 0
 }
 
-func (c *seaweedFilerClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error) {
-	out := new(UpdateEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/UpdateEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// the advisory file locks, held by the lock owners in the clients
+type FileLock struct {
+	Client  string `protobuf:"bytes,1,opt,name=client" json:"client,omitempty"`
+	Owner   uint64 `protobuf:"varint,2,opt,name=owner" json:"owner,omitempty"`
+	Start   uint64 `protobuf:"varint,3,opt,name=start" json:"start,omitempty"`
+	End     uint64 `protobuf:"varint,4,opt,name=end" json:"end,omitempty"`
+	IsWrite bool   `protobuf:"varint,5,opt,name=is_write,json=isWrite" json:"is_write,omitempty"`
+	Pid     int32  `protobuf:"varint,6,opt,name=pid" json:"pid,omitempty"`
+	IsFlock bool   `protobuf:"varint,7,opt,name=is_flock,json=isFlock" json:"is_flock,omitempty"`
 }
 
-func (c *seaweedFilerClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error) {
-	out := new(DeleteEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DeleteEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileLock) Reset()                    { *m = FileLock{} }
+func (m *FileLock) String() string            { return proto.CompactTextString(m) }
+func (*FileLock) ProtoMessage()               {}
+func (*FileLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *FileLock) GetClient() string {
+	if m != nil {
+		return m.Client
 	}
-	return out, nil
+	return ""
 }
 
-func (c *seaweedFilerClient) AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error) {
-	out := new(AtomicRenameEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AtomicRenameEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileLock) GetOwner() uint64 {
+	if m != nil {
+		return m.Owner
 	}
-	return out, nil
+	return 0
 }
 
-func (c *seaweedFilerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
-	out := new(AssignVolumeResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AssignVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileLock) GetStart() uint64 {
+	if m != nil {
+		return m.Start
 	}
-	return out, nil
+	return 0
 }
 
-func (c *seaweedFilerClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
-	out := new(LookupVolumeResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LookupVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileLock) GetEnd() uint64 {
+	if m != nil {
+		return m.End
 	}
-	return out, nil
+	return 0
 }
 
-func (c *seaweedFilerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
-	out := new(DeleteCollectionResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DeleteCollection", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileLock) GetIsWrite() bool {
+	if m != nil {
+		return m.IsWrite
 	}
-	return out, nil
+	return false
 }
 
-func (c *seaweedFilerClient) Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error) {
-	out := new(StatisticsResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/Statistics", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileLock) GetPid() int32 {
+	if m != nil {
+		return m.Pid
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for SeaweedFiler service
-
-type SeaweedFilerServer interface {
-	LookupDirectoryEntry(context.Context, *LookupDirectoryEntryRequest) (*LookupDirectoryEntryResponse, error)
-	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
-	CreateEntry(context.Context, *CreateEntryRequest) (*CreateEntryResponse, error)
-	UpdateEntry(context.Context, *UpdateEntryRequest) (*UpdateEntryResponse, error)
-	DeleteEntry(context.Context, *DeleteEntryRequest) (*DeleteEntryResponse, error)
-	AtomicRenameEntry(context.Context, *AtomicRenameEntryRequest) (*AtomicRenameEntryResponse, error)
-	AssignVolume(context.Context, *AssignVolumeRequest) (*AssignVolumeResponse, error)
-	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
-	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
-	Statistics(context.Context, *StatisticsRequest) (*StatisticsResponse, error)
+func (m *FileLock) GetIsFlock() bool {
+	if m != nil {
+		return m.IsFlock
+	}
+	return false
 }
 
-func RegisterSeaweedFilerServer(s *grpc.Server, srv SeaweedFilerServer) {
-	s.RegisterService(&_SeaweedFiler_serviceDesc, srv)
+type LockRequest struct {
+	Directory string    `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string    `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Lock      *FileLock `protobuf:"bytes,3,opt,name=lock" json:"lock,omitempty"`
 }
 
-func _SeaweedFiler_LookupDirectoryEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LookupDirectoryEntryRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SeaweedFilerServer).LookupDirectoryEntry(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/filer_pb.SeaweedFiler/LookupDirectoryEntry",
+func (m *LockRequest) Reset()                    { *m = LockRequest{} }
+func (m *LockRequest) String() string            { return proto.CompactTextString(m) }
+func (*LockRequest) ProtoMessage()               {}
+func (*LockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *LockRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SeaweedFilerServer).LookupDirectoryEntry(ctx, req.(*LookupDirectoryEntryRequest))
+	return ""
+}
+
+func (m *LockRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _SeaweedFiler_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func (m *LockRequest) GetLock() *FileLock {
+	if m != nil {
+		return m.Lock
+	}
+	return nil
+}
+
+type LockResponse struct {
+	Granted  bool      `protobuf:"varint,1,opt,name=granted" json:"granted,omitempty"`
+	Conflict *FileLock `protobuf:"bytes,2,opt,name=conflict" json:"conflict,omitempty"`
+}
+
+func (m *LockResponse) Reset()                    { *m = LockResponse{} }
+func (m *LockResponse) String() string            { return proto.CompactTextString(m) }
+func (*LockResponse) ProtoMessage()               {}
+func (*LockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *LockResponse) GetGranted() bool {
+	if m != nil {
+		return m.Granted
+	}
+	return false
+}
+
+func (m *LockResponse) GetConflict() *FileLock {
+	if m != nil {
+		return m.Conflict
+	}
+	return nil
+}
+
+type UnlockRequest struct {
+	Directory string    `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string    `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Lock      *FileLock `protobuf:"bytes,3,opt,name=lock" json:"lock,omitempty"`
+}
+
+func (m *UnlockRequest) Reset()                    { *m = UnlockRequest{} }
+func (m *UnlockRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnlockRequest) ProtoMessage()               {}
+func (*UnlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *UnlockRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *UnlockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UnlockRequest) GetLock() *FileLock {
+	if m != nil {
+		return m.Lock
+	}
+	return nil
+}
+
+type UnlockResponse struct {
+}
+
+func (m *UnlockResponse) Reset()                    { *m = UnlockResponse{} }
+func (m *UnlockResponse) String() string            { return proto.CompactTextString(m) }
+func (*UnlockResponse) ProtoMessage()               {}
+func (*UnlockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+type QueryLockRequest struct {
+	Directory string    `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string    `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Lock      *FileLock `protobuf:"bytes,3,opt,name=lock" json:"lock,omitempty"`
+}
+
+func (m *QueryLockRequest) Reset()                    { *m = QueryLockRequest{} }
+func (m *QueryLockRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryLockRequest) ProtoMessage()               {}
+func (*QueryLockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *QueryLockRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *QueryLockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryLockRequest) GetLock() *FileLock {
+	if m != nil {
+		return m.Lock
+	}
+	return nil
+}
+
+type QueryLockResponse struct {
+	Conflict *FileLock `protobuf:"bytes,1,opt,name=conflict" json:"conflict,omitempty"`
+}
+
+func (m *QueryLockResponse) Reset()                    { *m = QueryLockResponse{} }
+func (m *QueryLockResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryLockResponse) ProtoMessage()               {}
+func (*QueryLockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *QueryLockResponse) GetConflict() *FileLock {
+	if m != nil {
+		return m.Conflict
+	}
+	return nil
+}
+
+type KeepLocksRequest struct {
+	Client     string `protobuf:"bytes,1,opt,name=client" json:"client,omitempty"`
+	ReleaseAll bool   `protobuf:"varint,2,opt,name=release_all,json=releaseAll" json:"release_all,omitempty"`
+}
+
+func (m *KeepLocksRequest) Reset()                    { *m = KeepLocksRequest{} }
+func (m *KeepLocksRequest) String() string            { return proto.CompactTextString(m) }
+func (*KeepLocksRequest) ProtoMessage()               {}
+func (*KeepLocksRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *KeepLocksRequest) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+func (m *KeepLocksRequest) GetReleaseAll() bool {
+	if m != nil {
+		return m.ReleaseAll
+	}
+	return false
+}
+
+type KeepLocksResponse struct {
+	LeaseSeconds int64 `protobuf:"varint,1,opt,name=lease_seconds,json=leaseSeconds" json:"lease_seconds,omitempty"`
+}
+
+func (m *KeepLocksResponse) Reset()                    { *m = KeepLocksResponse{} }
+func (m *KeepLocksResponse) String() string            { return proto.CompactTextString(m) }
+func (*KeepLocksResponse) ProtoMessage()               {}
+func (*KeepLocksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *KeepLocksResponse) GetLeaseSeconds() int64 {
+	if m != nil {
+		return m.LeaseSeconds
+	}
+	return 0
+}
+
+// the locks of a file, saved in the filer store
+type FileLocks struct {
+	Path       string      `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	PosixLocks []*FileLock `protobuf:"bytes,2,rep,name=posix_locks,json=posixLocks" json:"posix_locks,omitempty"`
+	FlockLocks []*FileLock `protobuf:"bytes,3,rep,name=flock_locks,json=flockLocks" json:"flock_locks,omitempty"`
+}
+
+func (m *FileLocks) Reset()                    { *m = FileLocks{} }
+func (m *FileLocks) String() string            { return proto.CompactTextString(m) }
+func (*FileLocks) ProtoMessage()               {}
+func (*FileLocks) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *FileLocks) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileLocks) GetPosixLocks() []*FileLock {
+	if m != nil {
+		return m.PosixLocks
+	}
+	return nil
+}
+
+func (m *FileLocks) GetFlockLocks() []*FileLock {
+	if m != nil {
+		return m.FlockLocks
+	}
+	return nil
+}
+
+// the filer serving the file locks, saved in the filer store
+type LockServer struct {
+	Server     string `protobuf:"bytes,1,opt,name=server" json:"server,omitempty"`
+	ExpireAtNs int64  `protobuf:"varint,2,opt,name=expire_at_ns,json=expireAtNs" json:"expire_at_ns,omitempty"`
+}
+
+func (m *LockServer) Reset()                    { *m = LockServer{} }
+func (m *LockServer) String() string            { return proto.CompactTextString(m) }
+func (*LockServer) ProtoMessage()               {}
+func (*LockServer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *LockServer) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
+func (m *LockServer) GetExpireAtNs() int64 {
+	if m != nil {
+		return m.ExpireAtNs
+	}
+	return 0
+}
+
+// the directory quota, 0 means unlimited
+type Quota struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	MaxBytes  int64  `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes" json:"max_bytes,omitempty"`
+	MaxFiles  int64  `protobuf:"varint,3,opt,name=max_files,json=maxFiles" json:"max_files,omitempty"`
+	UsedBytes int64  `protobuf:"varint,4,opt,name=used_bytes,json=usedBytes" json:"used_bytes,omitempty"`
+	UsedFiles int64  `protobuf:"varint,5,opt,name=used_files,json=usedFiles" json:"used_files,omitempty"`
+}
+
+func (m *Quota) Reset()                    { *m = Quota{} }
+func (m *Quota) String() string            { return proto.CompactTextString(m) }
+func (*Quota) ProtoMessage()               {}
+func (*Quota) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *Quota) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *Quota) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *Quota) GetMaxFiles() int64 {
+	if m != nil {
+		return m.MaxFiles
+	}
+	return 0
+}
+
+func (m *Quota) GetUsedBytes() int64 {
+	if m != nil {
+		return m.UsedBytes
+	}
+	return 0
+}
+
+func (m *Quota) GetUsedFiles() int64 {
+	if m != nil {
+		return m.UsedFiles
+	}
+	return 0
+}
+
+type SetQuotaRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	MaxBytes  int64  `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes" json:"max_bytes,omitempty"`
+	MaxFiles  int64  `protobuf:"varint,3,opt,name=max_files,json=maxFiles" json:"max_files,omitempty"`
+	Remove    bool   `protobuf:"varint,4,opt,name=remove" json:"remove,omitempty"`
+}
+
+func (m *SetQuotaRequest) Reset()                    { *m = SetQuotaRequest{} }
+func (m *SetQuotaRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetQuotaRequest) ProtoMessage()               {}
+func (*SetQuotaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *SetQuotaRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *SetQuotaRequest) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *SetQuotaRequest) GetMaxFiles() int64 {
+	if m != nil {
+		return m.MaxFiles
+	}
+	return 0
+}
+
+func (m *SetQuotaRequest) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
+type SetQuotaResponse struct {
+	Quota *Quota `protobuf:"bytes,1,opt,name=quota" json:"quota,omitempty"`
+}
+
+func (m *SetQuotaResponse) Reset()                    { *m = SetQuotaResponse{} }
+func (m *SetQuotaResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetQuotaResponse) ProtoMessage()               {}
+func (*SetQuotaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *SetQuotaResponse) GetQuota() *Quota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+// find the closest quota of the directory or its ancestors
+type GetQuotaRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+}
+
+func (m *GetQuotaRequest) Reset()                    { *m = GetQuotaRequest{} }
+func (m *GetQuotaRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetQuotaRequest) ProtoMessage()               {}
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *GetQuotaRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+type GetQuotaResponse struct {
+	Quota *Quota `protobuf:"bytes,1,opt,name=quota" json:"quota,omitempty"`
+}
+
+func (m *GetQuotaResponse) Reset()                    { *m = GetQuotaResponse{} }
+func (m *GetQuotaResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetQuotaResponse) ProtoMessage()               {}
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *GetQuotaResponse) GetQuota() *Quota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+type ListQuotasRequest struct {
+}
+
+func (m *ListQuotasRequest) Reset()                    { *m = ListQuotasRequest{} }
+func (m *ListQuotasRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListQuotasRequest) ProtoMessage()               {}
+func (*ListQuotasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+type ListQuotasResponse struct {
+	Quotas []*Quota `protobuf:"bytes,1,rep,name=quotas" json:"quotas,omitempty"`
+}
+
+func (m *ListQuotasResponse) Reset()                    { *m = ListQuotasResponse{} }
+func (m *ListQuotasResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListQuotasResponse) ProtoMessage()               {}
+func (*ListQuotasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *ListQuotasResponse) GetQuotas() []*Quota {
+	if m != nil {
+		return m.Quotas
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*LookupDirectoryEntryRequest)(nil), "filer_pb.LookupDirectoryEntryRequest")
+	proto.RegisterType((*LookupDirectoryEntryResponse)(nil), "filer_pb.LookupDirectoryEntryResponse")
+	proto.RegisterType((*ListEntriesRequest)(nil), "filer_pb.ListEntriesRequest")
+	proto.RegisterType((*ListEntriesResponse)(nil), "filer_pb.ListEntriesResponse")
+	proto.RegisterType((*Entry)(nil), "filer_pb.Entry")
+	proto.RegisterType((*EventNotification)(nil), "filer_pb.EventNotification")
+	proto.RegisterType((*FileChunk)(nil), "filer_pb.FileChunk")
+	proto.RegisterType((*FuseAttributes)(nil), "filer_pb.FuseAttributes")
+	proto.RegisterType((*CreateEntryRequest)(nil), "filer_pb.CreateEntryRequest")
+	proto.RegisterType((*CreateEntryResponse)(nil), "filer_pb.CreateEntryResponse")
+	proto.RegisterType((*UpdateEntryRequest)(nil), "filer_pb.UpdateEntryRequest")
+	proto.RegisterType((*UpdateEntryResponse)(nil), "filer_pb.UpdateEntryResponse")
+	proto.RegisterType((*DeleteEntryRequest)(nil), "filer_pb.DeleteEntryRequest")
+	proto.RegisterType((*DeleteEntryResponse)(nil), "filer_pb.DeleteEntryResponse")
+	proto.RegisterType((*AtomicRenameEntryRequest)(nil), "filer_pb.AtomicRenameEntryRequest")
+	proto.RegisterType((*AtomicRenameEntryResponse)(nil), "filer_pb.AtomicRenameEntryResponse")
+	proto.RegisterType((*LinkEntryRequest)(nil), "filer_pb.LinkEntryRequest")
+	proto.RegisterType((*LinkEntryResponse)(nil), "filer_pb.LinkEntryResponse")
+	proto.RegisterType((*AssignVolumeRequest)(nil), "filer_pb.AssignVolumeRequest")
+	proto.RegisterType((*AssignVolumeResponse)(nil), "filer_pb.AssignVolumeResponse")
+	proto.RegisterType((*LookupVolumeRequest)(nil), "filer_pb.LookupVolumeRequest")
+	proto.RegisterType((*Locations)(nil), "filer_pb.Locations")
+	proto.RegisterType((*Location)(nil), "filer_pb.Location")
+	proto.RegisterType((*LookupVolumeResponse)(nil), "filer_pb.LookupVolumeResponse")
+	proto.RegisterType((*DeleteCollectionRequest)(nil), "filer_pb.DeleteCollectionRequest")
+	proto.RegisterType((*DeleteCollectionResponse)(nil), "filer_pb.DeleteCollectionResponse")
+	proto.RegisterType((*StatisticsRequest)(nil), "filer_pb.StatisticsRequest")
+	proto.RegisterType((*StatisticsResponse)(nil), "filer_pb.StatisticsResponse")
+	proto.RegisterType((*SubscribeMetadataRequest)(nil), "filer_pb.SubscribeMetadataRequest")
+	proto.RegisterType((*SubscribeMetadataResponse)(nil), "filer_pb.SubscribeMetadataResponse")
+	proto.RegisterType((*FileLock)(nil), "filer_pb.FileLock")
+	proto.RegisterType((*LockRequest)(nil), "filer_pb.LockRequest")
+	proto.RegisterType((*LockResponse)(nil), "filer_pb.LockResponse")
+	proto.RegisterType((*UnlockRequest)(nil), "filer_pb.UnlockRequest")
+	proto.RegisterType((*UnlockResponse)(nil), "filer_pb.UnlockResponse")
+	proto.RegisterType((*QueryLockRequest)(nil), "filer_pb.QueryLockRequest")
+	proto.RegisterType((*QueryLockResponse)(nil), "filer_pb.QueryLockResponse")
+	proto.RegisterType((*KeepLocksRequest)(nil), "filer_pb.KeepLocksRequest")
+	proto.RegisterType((*KeepLocksResponse)(nil), "filer_pb.KeepLocksResponse")
+	proto.RegisterType((*FileLocks)(nil), "filer_pb.FileLocks")
+	proto.RegisterType((*LockServer)(nil), "filer_pb.LockServer")
+	proto.RegisterType((*Quota)(nil), "filer_pb.Quota")
+	proto.RegisterType((*SetQuotaRequest)(nil), "filer_pb.SetQuotaRequest")
+	proto.RegisterType((*SetQuotaResponse)(nil), "filer_pb.SetQuotaResponse")
+	proto.RegisterType((*GetQuotaRequest)(nil), "filer_pb.GetQuotaRequest")
+	proto.RegisterType((*GetQuotaResponse)(nil), "filer_pb.GetQuotaResponse")
+	proto.RegisterType((*ListQuotasRequest)(nil), "filer_pb.ListQuotasRequest")
+	proto.RegisterType((*ListQuotasResponse)(nil), "filer_pb.ListQuotasResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for SeaweedFiler service
+
+type SeaweedFilerClient interface {
+	LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error)
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error)
+	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error)
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error)
+	AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error)
+	LinkEntry(ctx context.Context, in *LinkEntryRequest, opts ...grpc.CallOption) (*LinkEntryResponse, error)
+	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
+	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error)
+	SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error)
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error)
+	QueryLock(ctx context.Context, in *QueryLockRequest, opts ...grpc.CallOption) (*QueryLockResponse, error)
+	KeepLocks(ctx context.Context, in *KeepLocksRequest, opts ...grpc.CallOption) (*KeepLocksResponse, error)
+	SetQuota(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*SetQuotaResponse, error)
+	GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error)
+	ListQuotas(ctx context.Context, in *ListQuotasRequest, opts ...grpc.CallOption) (*ListQuotasResponse, error)
+}
+
+type seaweedFilerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSeaweedFilerClient(cc *grpc.ClientConn) SeaweedFilerClient {
+	return &seaweedFilerClient{cc}
+}
+
+func (c *seaweedFilerClient) LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error) {
+	out := new(LookupDirectoryEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LookupDirectoryEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
+	out := new(ListEntriesResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/ListEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
+	out := new(CreateEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/CreateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error) {
+	out := new(UpdateEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/UpdateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error) {
+	out := new(DeleteEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DeleteEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error) {
+	out := new(AtomicRenameEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AtomicRenameEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) LinkEntry(ctx context.Context, in *LinkEntryRequest, opts ...grpc.CallOption) (*LinkEntryResponse, error) {
+	out := new(LinkEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LinkEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
+	out := new(AssignVolumeResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AssignVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
+	out := new(LookupVolumeResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LookupVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	out := new(DeleteCollectionResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DeleteCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error) {
+	out := new(StatisticsResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/Statistics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SeaweedFiler_serviceDesc.Streams[0], c.cc, "/filer_pb.SeaweedFiler/SubscribeMetadata", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedFilerSubscribeMetadataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SeaweedFiler_SubscribeMetadataClient interface {
+	Recv() (*SubscribeMetadataResponse, error)
+	grpc.ClientStream
+}
+
+type seaweedFilerSubscribeMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedFilerSubscribeMetadataClient) Recv() (*SubscribeMetadataResponse, error) {
+	m := new(SubscribeMetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedFilerClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/Lock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error) {
+	out := new(UnlockResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/Unlock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) QueryLock(ctx context.Context, in *QueryLockRequest, opts ...grpc.CallOption) (*QueryLockResponse, error) {
+	out := new(QueryLockResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/QueryLock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) KeepLocks(ctx context.Context, in *KeepLocksRequest, opts ...grpc.CallOption) (*KeepLocksResponse, error) {
+	out := new(KeepLocksResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/KeepLocks", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) SetQuota(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*SetQuotaResponse, error) {
+	out := new(SetQuotaResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/SetQuota", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	out := new(GetQuotaResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/GetQuota", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) ListQuotas(ctx context.Context, in *ListQuotasRequest, opts ...grpc.CallOption) (*ListQuotasResponse, error) {
+	out := new(ListQuotasResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/ListQuotas", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SeaweedFiler service
+
+type SeaweedFilerServer interface {
+	LookupDirectoryEntry(context.Context, *LookupDirectoryEntryRequest) (*LookupDirectoryEntryResponse, error)
+	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
+	CreateEntry(context.Context, *CreateEntryRequest) (*CreateEntryResponse, error)
+	UpdateEntry(context.Context, *UpdateEntryRequest) (*UpdateEntryResponse, error)
+	DeleteEntry(context.Context, *DeleteEntryRequest) (*DeleteEntryResponse, error)
+	AtomicRenameEntry(context.Context, *AtomicRenameEntryRequest) (*AtomicRenameEntryResponse, error)
+	LinkEntry(context.Context, *LinkEntryRequest) (*LinkEntryResponse, error)
+	AssignVolume(context.Context, *AssignVolumeRequest) (*AssignVolumeResponse, error)
+	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	Statistics(context.Context, *StatisticsRequest) (*StatisticsResponse, error)
+	SubscribeMetadata(*SubscribeMetadataRequest, SeaweedFiler_SubscribeMetadataServer) error
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
+	QueryLock(context.Context, *QueryLockRequest) (*QueryLockResponse, error)
+	KeepLocks(context.Context, *KeepLocksRequest) (*KeepLocksResponse, error)
+	SetQuota(context.Context, *SetQuotaRequest) (*SetQuotaResponse, error)
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
+	ListQuotas(context.Context, *ListQuotasRequest) (*ListQuotasResponse, error)
+}
+
+func RegisterSeaweedFilerServer(s *grpc.Server, srv SeaweedFilerServer) {
+	s.RegisterService(&_SeaweedFiler_serviceDesc, srv)
+}
+
+func _SeaweedFiler_LookupDirectoryEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupDirectoryEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).LookupDirectoryEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/LookupDirectoryEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).LookupDirectoryEntry(ctx, req.(*LookupDirectoryEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
@@ -1128,6 +1931,24 @@ func _SeaweedFiler_AtomicRenameEntry_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_LinkEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).LinkEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/LinkEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).LinkEntry(ctx, req.(*LinkEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SeaweedFiler_AssignVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignVolumeRequest)
 	if err := dec(in); err != nil {
@@ -1200,6 +2021,153 @@ func _SeaweedFiler_Statistics_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_SubscribeMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMetadataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeaweedFilerServer).SubscribeMetadata(m, &seaweedFilerSubscribeMetadataServer{stream})
+}
+
+type SeaweedFiler_SubscribeMetadataServer interface {
+	Send(*SubscribeMetadataResponse) error
+	grpc.ServerStream
+}
+
+type seaweedFilerSubscribeMetadataServer struct {
+	grpc.ServerStream
+}
+
+func (x *seaweedFilerSubscribeMetadataServer) Send(m *SubscribeMetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SeaweedFiler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_QueryLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).QueryLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/QueryLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).QueryLock(ctx, req.(*QueryLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_KeepLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeepLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).KeepLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/KeepLocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).KeepLocks(ctx, req.(*KeepLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_SetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).SetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/SetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).SetQuota(ctx, req.(*SetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/GetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).GetQuota(ctx, req.(*GetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_ListQuotas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuotasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).ListQuotas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/ListQuotas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).ListQuotas(ctx, req.(*ListQuotasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "filer_pb.SeaweedFiler",
 	HandlerType: (*SeaweedFilerServer)(nil),
@@ -1228,6 +2196,10 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AtomicRenameEntry",
 			Handler:    _SeaweedFiler_AtomicRenameEntry_Handler,
 		},
+		{
+			MethodName: "LinkEntry",
+			Handler:    _SeaweedFiler_LinkEntry_Handler,
+		},
 		{
 			MethodName: "AssignVolume",
 			Handler:    _SeaweedFiler_AssignVolume_Handler,
@@ -1244,100 +2216,185 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Statistics",
 			Handler:    _SeaweedFiler_Statistics_Handler,
 		},
+		{
+			MethodName: "Lock",
+			Handler:    _SeaweedFiler_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _SeaweedFiler_Unlock_Handler,
+		},
+		{
+			MethodName: "QueryLock",
+			Handler:    _SeaweedFiler_QueryLock_Handler,
+		},
+		{
+			MethodName: "KeepLocks",
+			Handler:    _SeaweedFiler_KeepLocks_Handler,
+		},
+		{
+			MethodName: "SetQuota",
+			Handler:    _SeaweedFiler_SetQuota_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _SeaweedFiler_GetQuota_Handler,
+		},
+		{
+			MethodName: "ListQuotas",
+			Handler:    _SeaweedFiler_ListQuotas_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeMetadata",
+			Handler:       _SeaweedFiler_SubscribeMetadata_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "filer.proto",
 }
 
 func init() { proto.RegisterFile("filer.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0xdc, 0x44,
-	0x14, 0xae, 0xf7, 0x2f, 0xf1, 0xd9, 0xdd, 0x92, 0x4c, 0x02, 0x75, 0x37, 0x49, 0xd9, 0x3a, 0x14,
-	0xa5, 0xa2, 0x8a, 0xaa, 0xc2, 0x45, 0x4b, 0x85, 0x44, 0x9b, 0xa6, 0x52, 0xa5, 0xb4, 0x45, 0x4e,
-	0x8b, 0x84, 0x90, 0xb0, 0x1c, 0x7b, 0xb2, 0x1d, 0xc5, 0x6b, 0x2f, 0x9e, 0x71, 0xd2, 0xf2, 0x08,
-	0xdc, 0x70, 0xc3, 0x15, 0x12, 0x17, 0x5c, 0xf1, 0x16, 0xdc, 0xf0, 0x14, 0xbc, 0x04, 0xcf, 0x80,
-	0xce, 0xcc, 0xd8, 0x3b, 0x5e, 0x3b, 0x29, 0x08, 0xf5, 0x6e, 0xe6, 0xfc, 0x7e, 0xe7, 0xcc, 0xf9,
-	0xb1, 0xa1, 0x7f, 0xcc, 0x62, 0x9a, 0xed, 0xce, 0xb2, 0x54, 0xa4, 0x64, 0x59, 0x5e, 0xfc, 0xd9,
-	0x91, 0xfb, 0x1c, 0x36, 0x0e, 0xd2, 0xf4, 0x24, 0x9f, 0x3d, 0x62, 0x19, 0x0d, 0x45, 0x9a, 0xbd,
-	0xd9, 0x4f, 0x44, 0xf6, 0xc6, 0xa3, 0xdf, 0xe7, 0x94, 0x0b, 0xb2, 0x09, 0x76, 0x54, 0x30, 0x1c,
-	0x6b, 0x6c, 0xed, 0xd8, 0xde, 0x9c, 0x40, 0x08, 0x74, 0x92, 0x60, 0x4a, 0x9d, 0x96, 0x64, 0xc8,
-	0xb3, 0xbb, 0x0f, 0x9b, 0xcd, 0x06, 0xf9, 0x2c, 0x4d, 0x38, 0x25, 0x37, 0xa0, 0x4b, 0x91, 0x20,
-	0xad, 0xf5, 0xef, 0xbc, 0xb7, 0x5b, 0x40, 0xd9, 0x55, 0x72, 0x8a, 0xeb, 0xfe, 0x61, 0x01, 0x39,
-	0x60, 0x5c, 0x20, 0x91, 0x51, 0xfe, 0xef, 0xf0, 0x7c, 0x00, 0xbd, 0x59, 0x46, 0x8f, 0xd9, 0x6b,
-	0x8d, 0x48, 0xdf, 0xc8, 0x2d, 0x58, 0xe5, 0x22, 0xc8, 0xc4, 0xe3, 0x2c, 0x9d, 0x3e, 0x66, 0x31,
-	0x7d, 0x86, 0xa0, 0xdb, 0x52, 0xa4, 0xce, 0x20, 0xbb, 0x40, 0x58, 0x12, 0xc6, 0x39, 0x67, 0xa7,
-	0xf4, 0xb0, 0xe0, 0x3a, 0x9d, 0xb1, 0xb5, 0xb3, 0xec, 0x35, 0x70, 0xc8, 0x3a, 0x74, 0x63, 0x36,
-	0x65, 0xc2, 0xe9, 0x8e, 0xad, 0x9d, 0xa1, 0xa7, 0x2e, 0xee, 0x97, 0xb0, 0x56, 0xc1, 0xaf, 0xc3,
-	0xbf, 0x09, 0x4b, 0x54, 0x91, 0x1c, 0x6b, 0xdc, 0x6e, 0x4a, 0x40, 0xc1, 0x77, 0x7f, 0x6d, 0x41,
-	0x57, 0x92, 0xca, 0x3c, 0x5b, 0xf3, 0x3c, 0x93, 0xeb, 0x30, 0x60, 0xdc, 0x9f, 0x27, 0xa3, 0x25,
-	0xf1, 0xf5, 0x19, 0x2f, 0xf3, 0x4e, 0x3e, 0x81, 0x5e, 0xf8, 0x2a, 0x4f, 0x4e, 0xb8, 0xd3, 0x96,
-	0xae, 0xd6, 0xe6, 0xae, 0x30, 0xd8, 0x3d, 0xe4, 0x79, 0x5a, 0x84, 0xdc, 0x05, 0x08, 0x84, 0xc8,
-	0xd8, 0x51, 0x2e, 0x28, 0x97, 0xd1, 0xf6, 0xef, 0x38, 0x86, 0x42, 0xce, 0xe9, 0x83, 0x92, 0xef,
-	0x19, 0xb2, 0xe4, 0x1e, 0x2c, 0xd3, 0xd7, 0x82, 0x26, 0x11, 0x8d, 0x9c, 0xae, 0x74, 0xb4, 0xb5,
-	0x10, 0xd3, 0xee, 0xbe, 0xe6, 0xab, 0x08, 0x4b, 0xf1, 0xd1, 0x7d, 0x18, 0x56, 0x58, 0x64, 0x05,
-	0xda, 0x27, 0xb4, 0x78, 0x59, 0x3c, 0x62, 0x76, 0x4f, 0x83, 0x38, 0x57, 0x45, 0x36, 0xf0, 0xd4,
-	0xe5, 0xf3, 0xd6, 0x5d, 0xcb, 0xfd, 0xd9, 0x82, 0xd5, 0xfd, 0x53, 0x9a, 0x88, 0x67, 0xa9, 0x60,
-	0xc7, 0x2c, 0x0c, 0x04, 0x4b, 0x13, 0x72, 0x0b, 0xec, 0x34, 0x8e, 0xfc, 0x0b, 0x6b, 0x6c, 0x39,
-	0x8d, 0xb5, 0xbf, 0x5b, 0x60, 0x27, 0xf4, 0x4c, 0x4b, 0xb7, 0xce, 0x91, 0x4e, 0xe8, 0x99, 0x92,
-	0xde, 0x86, 0x61, 0x44, 0x63, 0x2a, 0xa8, 0x5f, 0xe6, 0x15, 0x93, 0x3e, 0x50, 0x44, 0x99, 0x4f,
-	0xee, 0xfe, 0x66, 0x81, 0x5d, 0xa6, 0x97, 0x5c, 0x81, 0x25, 0x34, 0xe7, 0xb3, 0x48, 0x07, 0xd5,
-	0xc3, 0xeb, 0x93, 0x08, 0x6b, 0x35, 0x3d, 0x3e, 0xe6, 0x54, 0x48, 0xb7, 0x6d, 0x4f, 0xdf, 0xf0,
-	0xad, 0x39, 0xfb, 0x41, 0x95, 0x67, 0xc7, 0x93, 0x67, 0xcc, 0xc1, 0x54, 0xb0, 0x29, 0x95, 0xcf,
-	0xd2, 0xf6, 0xd4, 0x85, 0xac, 0x41, 0x97, 0xfa, 0x22, 0x98, 0xc8, 0xba, 0xb3, 0xbd, 0x0e, 0x7d,
-	0x11, 0x4c, 0xc8, 0x47, 0x70, 0x99, 0xa7, 0x79, 0x16, 0x52, 0xbf, 0x70, 0xdb, 0x93, 0xdc, 0x81,
-	0xa2, 0x3e, 0x96, 0xce, 0xdd, 0xbf, 0x5b, 0x70, 0xb9, 0xfa, 0xa2, 0x64, 0x03, 0x6c, 0xa9, 0x21,
-	0x9d, 0x5b, 0xd2, 0xb9, 0x9c, 0x12, 0x87, 0x15, 0x00, 0x2d, 0x13, 0x40, 0xa1, 0x32, 0x4d, 0x23,
-	0x85, 0x77, 0xa8, 0x54, 0x9e, 0xa6, 0x11, 0xc5, 0x97, 0xcc, 0x59, 0x24, 0x11, 0x0f, 0x3d, 0x3c,
-	0x22, 0x65, 0xc2, 0x22, 0xdd, 0x25, 0x78, 0xc4, 0x1c, 0x84, 0x99, 0xb4, 0xdb, 0x53, 0x39, 0x50,
-	0x37, 0xcc, 0xc1, 0x14, 0xa9, 0x4b, 0x2a, 0x30, 0x3c, 0x93, 0x31, 0xf4, 0x33, 0x3a, 0x8b, 0xf5,
-	0x33, 0x3b, 0xcb, 0x92, 0x65, 0x92, 0xc8, 0x35, 0x80, 0x30, 0x8d, 0x63, 0x1a, 0x4a, 0x01, 0x5b,
-	0x0a, 0x18, 0x14, 0x7c, 0x0a, 0x21, 0x62, 0x9f, 0xd3, 0xd0, 0x81, 0xb1, 0xb5, 0xd3, 0xf5, 0x7a,
-	0x42, 0xc4, 0x87, 0x34, 0xc4, 0x38, 0x72, 0x4e, 0x33, 0x5f, 0xf6, 0x58, 0x5f, 0xea, 0x2d, 0x23,
-	0x41, 0x4e, 0x83, 0x2d, 0x80, 0x49, 0x96, 0xe6, 0x33, 0xc5, 0x1d, 0x8c, 0xdb, 0x38, 0x72, 0x24,
-	0x45, 0xb2, 0x6f, 0xc0, 0x65, 0xfe, 0x66, 0x1a, 0xb3, 0xe4, 0xc4, 0x17, 0x41, 0x36, 0xa1, 0xc2,
-	0x19, 0x4a, 0x03, 0x43, 0x4d, 0x7d, 0x21, 0x89, 0xee, 0x37, 0x40, 0xf6, 0x32, 0x1a, 0x08, 0xfa,
-	0x1f, 0xa6, 0x6b, 0x39, 0x29, 0x5b, 0x17, 0x4e, 0xca, 0xf7, 0x61, 0xad, 0x62, 0x5a, 0x0d, 0x1a,
-	0xf4, 0xf8, 0x72, 0x16, 0xbd, 0x2b, 0x8f, 0x15, 0xd3, 0xda, 0xe3, 0x4f, 0x16, 0x90, 0x47, 0xb2,
-	0x13, 0xfe, 0xdf, 0x0a, 0xc1, 0x1a, 0xc6, 0xd1, 0xa6, 0x3a, 0x2d, 0x0a, 0x44, 0xa0, 0x87, 0xef,
-	0x80, 0x71, 0x65, 0xff, 0x51, 0x20, 0x02, 0x3d, 0x00, 0x33, 0x1a, 0xe6, 0x19, 0xce, 0x63, 0x59,
-	0x57, 0x72, 0x00, 0x7a, 0x05, 0x09, 0x81, 0x56, 0x00, 0x69, 0xa0, 0xbf, 0x58, 0xe0, 0x3c, 0x10,
-	0xe9, 0x94, 0x85, 0x1e, 0x45, 0x87, 0x15, 0xb8, 0xdb, 0x30, 0xc4, 0xf9, 0xb1, 0x08, 0x79, 0x90,
-	0xc6, 0xd1, 0x7c, 0xb2, 0x5e, 0x05, 0x1c, 0x21, 0xbe, 0x81, 0x7c, 0x29, 0x8d, 0x23, 0x59, 0x10,
-	0xdb, 0x30, 0xc4, 0x89, 0x32, 0xd7, 0x57, 0x7b, 0x66, 0x90, 0xd0, 0xb3, 0x8a, 0x3e, 0x0a, 0x49,
-	0xfd, 0x8e, 0xd2, 0x4f, 0xe8, 0x19, 0xea, 0xbb, 0x1b, 0x70, 0xb5, 0x01, 0x9b, 0x46, 0xfe, 0xbb,
-	0x05, 0x6b, 0x0f, 0x38, 0x67, 0x93, 0xe4, 0xeb, 0x34, 0xce, 0xa7, 0xb4, 0x00, 0xbd, 0x0e, 0xdd,
-	0x30, 0xcd, 0x13, 0x21, 0xc1, 0x76, 0x3d, 0x75, 0x59, 0x68, 0x88, 0x56, 0xad, 0x21, 0x16, 0x5a,
-	0xaa, 0x5d, 0x6f, 0x29, 0xa3, 0x65, 0x3a, 0x95, 0x96, 0xf9, 0x10, 0xfa, 0xf8, 0x30, 0x7e, 0x48,
-	0x13, 0x41, 0x33, 0x3d, 0x81, 0x00, 0x49, 0x7b, 0x92, 0xe2, 0xfe, 0x68, 0xc1, 0x7a, 0x15, 0xa9,
-	0x5e, 0x80, 0xe7, 0x0e, 0x44, 0x1c, 0x18, 0x59, 0xac, 0x61, 0xe2, 0x11, 0x5b, 0x6f, 0x96, 0x1f,
-	0xc5, 0x2c, 0xf4, 0x91, 0xa1, 0xe0, 0xd9, 0x8a, 0xf2, 0x32, 0x8b, 0xe7, 0x41, 0x77, 0xcc, 0xa0,
-	0x09, 0x74, 0x82, 0x5c, 0xbc, 0x2a, 0x86, 0x22, 0x9e, 0xdd, 0xcf, 0x60, 0x4d, 0x7d, 0x93, 0x54,
-	0xb3, 0xb6, 0x05, 0x70, 0x2a, 0x09, 0x3e, 0x8b, 0xd4, 0x3a, 0xb6, 0x3d, 0x5b, 0x51, 0x9e, 0x44,
-	0xdc, 0xfd, 0x02, 0xec, 0x83, 0x54, 0x25, 0x82, 0x93, 0xdb, 0x60, 0xc7, 0xc5, 0x45, 0x6f, 0x6e,
-	0x32, 0x6f, 0x8f, 0x42, 0xce, 0x9b, 0x0b, 0xb9, 0xf7, 0x61, 0xb9, 0x20, 0x17, 0xb1, 0x59, 0xe7,
-	0xc5, 0xd6, 0x5a, 0x88, 0xcd, 0xfd, 0xd3, 0x82, 0xf5, 0x2a, 0x64, 0x9d, 0xbe, 0x97, 0x30, 0x2c,
-	0x5d, 0xf8, 0xd3, 0x60, 0xa6, 0xb1, 0xdc, 0x36, 0xb1, 0xd4, 0xd5, 0x4a, 0x80, 0xfc, 0x69, 0x30,
-	0x53, 0x25, 0x35, 0x88, 0x0d, 0xd2, 0xe8, 0x05, 0xac, 0xd6, 0x44, 0x1a, 0x96, 0xf1, 0x4d, 0x73,
-	0x19, 0x57, 0x3e, 0x28, 0x4a, 0x6d, 0x73, 0x43, 0xdf, 0x83, 0x2b, 0xaa, 0xff, 0xf6, 0xca, 0xa2,
-	0x2b, 0x72, 0x5f, 0xad, 0x4d, 0x6b, 0xb1, 0x36, 0xdd, 0x11, 0x38, 0x75, 0x55, 0xdd, 0x05, 0x13,
-	0x58, 0x3d, 0x14, 0x81, 0x60, 0x5c, 0xb0, 0xb0, 0xfc, 0x32, 0x5c, 0x28, 0x66, 0xeb, 0x6d, 0xfb,
-	0xa1, 0xde, 0x0e, 0x2b, 0xd0, 0x16, 0xa2, 0xa8, 0x33, 0x3c, 0xe2, 0x2b, 0x10, 0xd3, 0x93, 0x7e,
-	0x83, 0x77, 0xe0, 0x0a, 0xeb, 0x41, 0xa4, 0x22, 0x88, 0xd5, 0xfe, 0xed, 0xc8, 0xfd, 0x6b, 0x4b,
-	0x8a, 0x5c, 0xc0, 0x6a, 0x45, 0x45, 0x8a, 0xdb, 0x55, 0xdb, 0x19, 0x09, 0x92, 0xb9, 0x05, 0x20,
-	0x5b, 0x4a, 0x75, 0x43, 0x4f, 0xe9, 0x22, 0x65, 0x0f, 0x09, 0x77, 0xfe, 0xea, 0xc1, 0xe0, 0x90,
-	0x06, 0x67, 0x94, 0x46, 0xb8, 0xfe, 0x33, 0x32, 0x29, 0x6a, 0xab, 0xfa, 0x89, 0x4e, 0x6e, 0x2c,
-	0x16, 0x51, 0xe3, 0x3f, 0xc1, 0xe8, 0xe3, 0xb7, 0x89, 0xe9, 0x67, 0xba, 0x44, 0x0e, 0xa0, 0x6f,
-	0x7c, 0x03, 0x93, 0x4d, 0x43, 0xb1, 0xf6, 0x69, 0x3f, 0xda, 0x3a, 0x87, 0x6b, 0x5a, 0x33, 0x16,
-	0x9d, 0x69, 0xad, 0xbe, 0x5a, 0x4d, 0x6b, 0x4d, 0xdb, 0x51, 0x5a, 0x33, 0x96, 0x98, 0x69, 0xad,
-	0xbe, 0x36, 0x4d, 0x6b, 0x4d, 0x9b, 0x4f, 0x5a, 0x33, 0x36, 0x8d, 0x69, 0xad, 0xbe, 0x11, 0x4d,
-	0x6b, 0x4d, 0xeb, 0xe9, 0x12, 0xf9, 0x0e, 0x56, 0x6b, 0x3b, 0x80, 0xb8, 0x73, 0xad, 0xf3, 0x96,
-	0xd7, 0x68, 0xfb, 0x42, 0x99, 0xd2, 0xfe, 0x73, 0x18, 0x98, 0xb3, 0x99, 0x18, 0x80, 0x1a, 0xb6,
-	0xcb, 0xe8, 0xda, 0x79, 0x6c, 0xd3, 0xa0, 0x39, 0x76, 0x4c, 0x83, 0x0d, 0x83, 0xd7, 0x34, 0xd8,
-	0x34, 0xad, 0xdc, 0x4b, 0xe4, 0x5b, 0x58, 0x59, 0x6c, 0x7f, 0x72, 0x7d, 0x31, 0x6d, 0xb5, 0xa9,
-	0x32, 0x72, 0x2f, 0x12, 0x29, 0x8d, 0x3f, 0x01, 0x98, 0x77, 0x35, 0xd9, 0x98, 0xeb, 0xd4, 0xa6,
-	0xca, 0x68, 0xb3, 0x99, 0x59, 0x98, 0x7a, 0x78, 0x0d, 0x56, 0xb8, 0x6a, 0xad, 0x63, 0xbe, 0x1b,
-	0xc6, 0x8c, 0x26, 0xe2, 0x21, 0xc8, 0x2e, 0xfb, 0x0a, 0x7f, 0xb4, 0x8f, 0x7a, 0xf2, 0x7f, 0xfb,
-	0xd3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf3, 0x11, 0xc9, 0xda, 0x7e, 0x0f, 0x00, 0x00,
+	// 2193 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x59, 0x4b, 0x6f, 0xdc, 0xc8,
+	0xf1, 0xff, 0x73, 0xde, 0x53, 0x33, 0x63, 0x6b, 0x5a, 0x7e, 0xd0, 0x94, 0x65, 0xcf, 0x52, 0x7f,
+	0x6f, 0xbc, 0x89, 0xa1, 0x18, 0x76, 0x80, 0xd8, 0x1b, 0x18, 0x88, 0x2d, 0x5b, 0x82, 0xb2, 0xb2,
+	0x76, 0x97, 0xb2, 0xf3, 0x40, 0x82, 0x30, 0x14, 0xd9, 0x1a, 0x37, 0xc4, 0x21, 0x67, 0xd9, 0x3d,
+	0x7a, 0xe4, 0x96, 0x63, 0x72, 0xcc, 0x31, 0x40, 0x0e, 0x39, 0x25, 0x87, 0x7c, 0x86, 0x5c, 0x92,
+	0x0f, 0x92, 0x7b, 0x80, 0x7c, 0x86, 0xa0, 0xba, 0x9b, 0x9c, 0xe6, 0x3c, 0xe4, 0x47, 0xb0, 0x8b,
+	0xdc, 0xd8, 0xf5, 0xea, 0xaa, 0xea, 0xea, 0x5f, 0x57, 0xcd, 0x40, 0xe7, 0x88, 0xc5, 0x34, 0xdb,
+	0x1c, 0x67, 0xa9, 0x48, 0x49, 0x4b, 0x2e, 0xfc, 0xf1, 0xa1, 0xfb, 0x39, 0xac, 0xed, 0xa5, 0xe9,
+	0xf1, 0x64, 0xfc, 0x9c, 0x65, 0x34, 0x14, 0x69, 0x76, 0xfe, 0x22, 0x11, 0xd9, 0xb9, 0x47, 0xbf,
+	0x9a, 0x50, 0x2e, 0xc8, 0x4d, 0x68, 0x47, 0x39, 0xc3, 0xb6, 0x06, 0xd6, 0xdd, 0xb6, 0x37, 0x25,
+	0x10, 0x02, 0xb5, 0x24, 0x18, 0x51, 0xbb, 0x22, 0x19, 0xf2, 0xdb, 0x7d, 0x01, 0x37, 0x17, 0x1b,
+	0xe4, 0xe3, 0x34, 0xe1, 0x94, 0xdc, 0x81, 0x3a, 0x4d, 0x84, 0xb6, 0xd6, 0x79, 0x70, 0x79, 0x33,
+	0x77, 0x65, 0x53, 0xc9, 0x29, 0xae, 0xfb, 0x37, 0x0b, 0xc8, 0x1e, 0xe3, 0x02, 0x89, 0x8c, 0xf2,
+	0x77, 0xf3, 0xe7, 0x1a, 0x34, 0xc6, 0x19, 0x3d, 0x62, 0x67, 0xda, 0x23, 0xbd, 0x22, 0xf7, 0xa0,
+	0xcf, 0x45, 0x90, 0x89, 0xed, 0x2c, 0x1d, 0x6d, 0xb3, 0x98, 0xee, 0xa3, 0xd3, 0x55, 0x29, 0x32,
+	0xcf, 0x20, 0x9b, 0x40, 0x58, 0x12, 0xc6, 0x13, 0xce, 0x4e, 0xe8, 0x41, 0xce, 0xb5, 0x6b, 0x03,
+	0xeb, 0x6e, 0xcb, 0x5b, 0xc0, 0x21, 0x57, 0xa0, 0x1e, 0xb3, 0x11, 0x13, 0x76, 0x7d, 0x60, 0xdd,
+	0xed, 0x79, 0x6a, 0xe1, 0xfe, 0x10, 0x56, 0x4b, 0xfe, 0xeb, 0xf0, 0x3f, 0x81, 0x26, 0x55, 0x24,
+	0xdb, 0x1a, 0x54, 0x17, 0x25, 0x20, 0xe7, 0xbb, 0xff, 0xaa, 0x40, 0x5d, 0x92, 0x8a, 0x3c, 0x5b,
+	0xd3, 0x3c, 0x93, 0x8f, 0xa0, 0xcb, 0xb8, 0x3f, 0x4d, 0x46, 0x45, 0xfa, 0xd7, 0x61, 0xbc, 0xc8,
+	0x3b, 0xf9, 0x0e, 0x34, 0xc2, 0x37, 0x93, 0xe4, 0x98, 0xdb, 0x55, 0xb9, 0xd5, 0xea, 0x74, 0x2b,
+	0x0c, 0x76, 0x0b, 0x79, 0x9e, 0x16, 0x21, 0x8f, 0x00, 0x02, 0x21, 0x32, 0x76, 0x38, 0x11, 0x94,
+	0xcb, 0x68, 0x3b, 0x0f, 0x6c, 0x43, 0x61, 0xc2, 0xe9, 0xd3, 0x82, 0xef, 0x19, 0xb2, 0xe4, 0x31,
+	0xb4, 0xe8, 0x99, 0xa0, 0x49, 0x44, 0x23, 0xbb, 0x2e, 0x37, 0x5a, 0x9f, 0x89, 0x69, 0xf3, 0x85,
+	0xe6, 0xab, 0x08, 0x0b, 0x71, 0x32, 0x80, 0xee, 0x9b, 0x20, 0x8b, 0xfc, 0x98, 0x25, 0xc7, 0x3e,
+	0x8b, 0xec, 0xc6, 0xc0, 0xba, 0xdb, 0xf5, 0x00, 0x69, 0x7b, 0x2c, 0x39, 0xde, 0x8d, 0xc8, 0xb7,
+	0xa1, 0x3f, 0x95, 0x08, 0xd3, 0x49, 0x22, 0x68, 0x66, 0x37, 0x07, 0xd6, 0xdd, 0xba, 0x77, 0x39,
+	0x17, 0xdb, 0x52, 0x64, 0xe7, 0x07, 0xd0, 0x2b, 0x6d, 0x44, 0x56, 0xa0, 0x7a, 0x4c, 0xf3, 0x3a,
+	0xc1, 0x4f, 0x3c, 0xab, 0x93, 0x20, 0x9e, 0xa8, 0x92, 0xed, 0x7a, 0x6a, 0xf1, 0x69, 0xe5, 0x91,
+	0xe5, 0xfe, 0xd5, 0x82, 0xfe, 0x8b, 0x13, 0x9a, 0x88, 0xfd, 0x54, 0xb0, 0x23, 0x16, 0x06, 0x82,
+	0xa5, 0x09, 0xb9, 0x07, 0xed, 0x34, 0x8e, 0xfc, 0x0b, 0x2b, 0xb6, 0x95, 0xc6, 0x7a, 0xbf, 0x7b,
+	0xd0, 0x4e, 0xe8, 0xa9, 0x96, 0xae, 0x2c, 0x91, 0x4e, 0xe8, 0xa9, 0x92, 0xde, 0x80, 0x5e, 0x44,
+	0x63, 0x2a, 0xa8, 0x5f, 0x9c, 0x12, 0x1e, 0x61, 0x57, 0x11, 0xb7, 0xd4, 0xb1, 0x5c, 0x83, 0x46,
+	0x9a, 0xb1, 0x21, 0x4b, 0xe4, 0x91, 0xb4, 0x3d, 0xbd, 0x72, 0xff, 0x64, 0x41, 0xbb, 0x38, 0x44,
+	0x72, 0x1d, 0x9a, 0xb8, 0x0d, 0xa6, 0x50, 0x05, 0xdb, 0xc0, 0xe5, 0x6e, 0x24, 0xd5, 0x8f, 0x8e,
+	0x38, 0x15, 0xd2, 0x9d, 0xaa, 0xa7, 0x57, 0x58, 0x51, 0x9c, 0xfd, 0x5a, 0x5d, 0x82, 0x9a, 0x27,
+	0xbf, 0x31, 0x37, 0x23, 0xc1, 0x46, 0x54, 0xee, 0x54, 0xf5, 0xd4, 0x82, 0xac, 0x42, 0x9d, 0xfa,
+	0x22, 0x18, 0xca, 0xea, 0x6e, 0x7b, 0x35, 0xfa, 0x2a, 0x18, 0x92, 0xff, 0x87, 0x4b, 0x3c, 0x9d,
+	0x64, 0x21, 0xf5, 0xf3, 0x6d, 0x1b, 0x92, 0xdb, 0x55, 0xd4, 0x6d, 0xb9, 0xb9, 0xfb, 0xef, 0x0a,
+	0x5c, 0x2a, 0xd7, 0x0d, 0x59, 0x83, 0xb6, 0xd4, 0x90, 0x9b, 0x5b, 0x72, 0x73, 0x89, 0x45, 0x07,
+	0x25, 0x07, 0x2a, 0xa6, 0x03, 0xb9, 0xca, 0x28, 0x8d, 0x94, 0xbf, 0x3d, 0xa5, 0xf2, 0x32, 0x8d,
+	0x28, 0x9e, 0xf0, 0x84, 0x45, 0xd2, 0xe3, 0x9e, 0x87, 0x9f, 0x48, 0x19, 0xb2, 0x48, 0xdf, 0x45,
+	0xfc, 0xc4, 0x1c, 0x84, 0x99, 0xb4, 0xdb, 0x50, 0x39, 0x50, 0x2b, 0xcc, 0xc1, 0x08, 0xa9, 0x4d,
+	0x15, 0x18, 0x7e, 0x93, 0x01, 0x74, 0x32, 0x3a, 0x8e, 0xf5, 0xf1, 0xdb, 0x2d, 0xc9, 0x32, 0x49,
+	0xe4, 0x16, 0x40, 0x98, 0xc6, 0x31, 0x0d, 0xa5, 0x40, 0x5b, 0x0a, 0x18, 0x14, 0x3c, 0x0a, 0x21,
+	0x62, 0x9f, 0xd3, 0xd0, 0x06, 0x59, 0xa6, 0x0d, 0x21, 0xe2, 0x03, 0x1a, 0x62, 0x1c, 0x13, 0x4e,
+	0x33, 0x5f, 0xde, 0xe4, 0x8e, 0xd4, 0x6b, 0x21, 0x41, 0x62, 0xce, 0x3a, 0xc0, 0x30, 0x4b, 0x27,
+	0x63, 0xc5, 0xed, 0x0e, 0xaa, 0x08, 0x6c, 0x92, 0x22, 0xd9, 0x77, 0xe0, 0x12, 0x3f, 0x1f, 0xc9,
+	0x3b, 0x20, 0x82, 0x6c, 0x48, 0x85, 0xdd, 0x93, 0x06, 0x7a, 0x9a, 0xfa, 0x4a, 0x12, 0xdd, 0x9f,
+	0x01, 0xd9, 0xca, 0x68, 0x20, 0xe8, 0x7b, 0x60, 0x78, 0x81, 0xc7, 0x95, 0x0b, 0xf1, 0xf8, 0x2a,
+	0xac, 0x96, 0x4c, 0x2b, 0x38, 0xc3, 0x1d, 0x5f, 0x8f, 0xa3, 0xaf, 0x6b, 0xc7, 0x92, 0x69, 0xbd,
+	0xe3, 0x9f, 0x2d, 0x20, 0xcf, 0xe5, 0x0d, 0xf9, 0xef, 0x1e, 0x2a, 0xac, 0x61, 0x04, 0x50, 0x75,
+	0x03, 0xa3, 0x40, 0x04, 0x1a, 0xe2, 0xbb, 0x8c, 0x2b, 0xfb, 0xcf, 0x03, 0x11, 0x68, 0x98, 0xcd,
+	0x68, 0x38, 0xc9, 0x10, 0xf5, 0xed, 0x7a, 0x0e, 0xb3, 0x5e, 0x4e, 0x32, 0xae, 0x68, 0xa3, 0x74,
+	0x45, 0xaf, 0xc2, 0x6a, 0xc9, 0x51, 0x1d, 0xc0, 0x1f, 0x2c, 0xb0, 0x9f, 0x8a, 0x74, 0xc4, 0x42,
+	0x8f, 0xa2, 0x23, 0xa5, 0x30, 0x36, 0xa0, 0x87, 0x78, 0x33, 0x1b, 0x4a, 0x37, 0x8d, 0xa3, 0x29,
+	0xae, 0xdf, 0x00, 0x84, 0x1c, 0xdf, 0x88, 0xa8, 0x99, 0xc6, 0x91, 0x2c, 0x94, 0x0d, 0xe8, 0x21,
+	0x02, 0x4d, 0xf5, 0xd5, 0x2b, 0xd7, 0x4d, 0xe8, 0x69, 0x49, 0x1f, 0x85, 0xa4, 0xbe, 0x42, 0x95,
+	0x66, 0x42, 0x4f, 0x51, 0xdf, 0x5d, 0x83, 0x1b, 0x0b, 0x7c, 0xd3, 0x9e, 0xff, 0xde, 0x82, 0x15,
+	0xc4, 0xdb, 0xff, 0x29, 0x8f, 0x3f, 0x85, 0xbe, 0xe1, 0xd3, 0xfb, 0x35, 0x19, 0xff, 0xb0, 0x60,
+	0xf5, 0x29, 0xe7, 0x6c, 0x98, 0xfc, 0x38, 0x8d, 0x27, 0x23, 0x9a, 0xc7, 0x74, 0x05, 0xea, 0xf2,
+	0xa9, 0x91, 0xea, 0x75, 0x4f, 0x2d, 0x66, 0x6e, 0x7e, 0x65, 0xee, 0xe6, 0xcf, 0x60, 0x47, 0x75,
+	0x1e, 0x3b, 0x0c, 0x6c, 0xa8, 0x95, 0xb0, 0xe1, 0x36, 0x74, 0xb0, 0x02, 0xfd, 0x90, 0xca, 0xf7,
+	0x4d, 0x41, 0x2d, 0x20, 0x69, 0x4b, 0x52, 0x10, 0x3c, 0x22, 0xc6, 0x8f, 0x7d, 0x71, 0x3e, 0xa6,
+	0xba, 0xcc, 0x5a, 0x48, 0x78, 0x75, 0x3e, 0xa6, 0xee, 0xef, 0x2c, 0xb8, 0x52, 0x0e, 0x43, 0xa7,
+	0x61, 0xe9, 0xb3, 0x80, 0xb0, 0x99, 0xc5, 0x3a, 0x06, 0xfc, 0x44, 0x00, 0x1a, 0x4f, 0x0e, 0x63,
+	0x16, 0xfa, 0xc8, 0x50, 0xbe, 0xb7, 0x15, 0xe5, 0x75, 0x16, 0x4f, 0x33, 0x52, 0x33, 0x33, 0x42,
+	0xa0, 0x16, 0x4c, 0xc4, 0x9b, 0xfc, 0x69, 0xc0, 0x6f, 0xf7, 0x7b, 0xb0, 0xaa, 0xfa, 0xbf, 0x72,
+	0x4a, 0xd7, 0x01, 0x4e, 0x24, 0xc1, 0x67, 0x91, 0x6a, 0x7d, 0xda, 0x5e, 0x5b, 0x51, 0x76, 0x23,
+	0xee, 0x3e, 0x81, 0xf6, 0x5e, 0xaa, 0xb2, 0xc4, 0xc9, 0x7d, 0x68, 0xc7, 0xf9, 0x42, 0x77, 0x49,
+	0x64, 0x7a, 0x82, 0xb9, 0x9c, 0x37, 0x15, 0x72, 0x7f, 0x01, 0xad, 0x9c, 0x9c, 0xc7, 0x66, 0x2d,
+	0x8b, 0xad, 0x32, 0x1b, 0xdb, 0x4c, 0xf2, 0xab, 0xb3, 0xc9, 0x77, 0xff, 0x6e, 0xc1, 0x95, 0x72,
+	0x4c, 0x3a, 0xbf, 0xaf, 0xa1, 0x57, 0xf8, 0xe0, 0x8f, 0x82, 0xb1, 0x76, 0xf6, 0xbe, 0xe9, 0xec,
+	0xbc, 0x5a, 0x11, 0x01, 0x7f, 0x19, 0x8c, 0x55, 0x3d, 0x76, 0x63, 0x83, 0xe4, 0xbc, 0x82, 0xfe,
+	0x9c, 0xc8, 0x82, 0x5e, 0xe6, 0x13, 0xb3, 0x97, 0x29, 0x75, 0x77, 0x85, 0xb6, 0xd9, 0xe0, 0x3c,
+	0x86, 0xeb, 0x0a, 0x8e, 0xb6, 0x8a, 0x92, 0xcd, 0x0f, 0xa7, 0x5c, 0xd9, 0xd6, 0x6c, 0x65, 0xbb,
+	0x0e, 0xd8, 0xf3, 0xaa, 0x1a, 0x14, 0x86, 0xd0, 0x3f, 0x10, 0x81, 0x60, 0x5c, 0xb0, 0xb0, 0x68,
+	0xd3, 0x67, 0xae, 0x82, 0xf5, 0xb6, 0x67, 0x74, 0xfe, 0x32, 0xad, 0x40, 0x55, 0x88, 0xbc, 0x10,
+	0xf1, 0x13, 0x4f, 0x81, 0x98, 0x3b, 0xe9, 0x33, 0xf8, 0x1a, 0xb6, 0xc2, 0x82, 0x11, 0xa9, 0x08,
+	0x62, 0xd5, 0xa6, 0xd4, 0x64, 0x9b, 0xd2, 0x96, 0x14, 0xd9, 0xa7, 0xa8, 0x97, 0x3c, 0x52, 0xdc,
+	0xba, 0xe4, 0xe2, 0x4b, 0x1e, 0x49, 0xe6, 0x3a, 0x80, 0xbc, 0x73, 0xea, 0xba, 0x34, 0x94, 0x2e,
+	0x52, 0x64, 0x97, 0xea, 0x9e, 0x82, 0x7d, 0x30, 0x39, 0xe4, 0x61, 0xc6, 0x0e, 0xe9, 0x4b, 0x2a,
+	0x02, 0x2c, 0xb3, 0x3c, 0x6b, 0xb7, 0xa1, 0x13, 0xc6, 0x8c, 0x26, 0xc2, 0x37, 0xba, 0x7d, 0x50,
+	0x24, 0x89, 0x95, 0xb7, 0xa1, 0x33, 0x0e, 0xc4, 0x1b, 0xbf, 0x34, 0xe4, 0x00, 0x92, 0xbe, 0x90,
+	0x14, 0xc4, 0x49, 0xce, 0x92, 0x90, 0xfa, 0x89, 0xea, 0x26, 0xab, 0x5e, 0x53, 0xae, 0xf7, 0x39,
+	0x3e, 0x3b, 0x37, 0x16, 0xec, 0xac, 0xb3, 0x78, 0xf1, 0xf3, 0xf9, 0x23, 0x20, 0xf4, 0x44, 0xfa,
+	0x65, 0xf4, 0xc6, 0xba, 0xec, 0xd6, 0x0c, 0x6c, 0x9d, 0x6d, 0x9f, 0xbd, 0x3e, 0x9d, 0x25, 0x61,
+	0x3f, 0x29, 0xf8, 0xd4, 0xbf, 0x9a, 0xe0, 0xfb, 0xdc, 0xfd, 0x8b, 0x05, 0x2d, 0x6c, 0x1a, 0xf7,
+	0xd2, 0xf0, 0x58, 0xf6, 0x6b, 0x32, 0xe6, 0x1c, 0xb4, 0xd4, 0x0a, 0x31, 0x28, 0x3d, 0x4d, 0x68,
+	0x26, 0x37, 0xae, 0x79, 0x6a, 0x81, 0x54, 0x39, 0xc2, 0xe9, 0x56, 0x56, 0x2d, 0xf0, 0x4c, 0x69,
+	0x12, 0xe9, 0xa3, 0xc3, 0x4f, 0x4c, 0x0d, 0xe3, 0xfe, 0x69, 0xc6, 0x44, 0xfe, 0x88, 0x37, 0x19,
+	0xff, 0x09, 0x2e, 0x51, 0x78, 0xac, 0x5b, 0xd8, 0xba, 0x87, 0x9f, 0x5a, 0xf8, 0x28, 0x4e, 0xc3,
+	0x63, 0xbb, 0x99, 0x0b, 0x6f, 0xe3, 0xd2, 0x1d, 0x42, 0x07, 0xbd, 0xfc, 0xf0, 0xbe, 0xe3, 0x63,
+	0xa8, 0x49, 0xbb, 0xd5, 0x81, 0x55, 0x06, 0xb6, 0x3c, 0x01, 0x9e, 0xe4, 0xbb, 0x3f, 0x85, 0xae,
+	0x5c, 0xe5, 0x47, 0x64, 0x43, 0x73, 0x98, 0x05, 0x89, 0xa0, 0x0a, 0xcc, 0x5b, 0x5e, 0xbe, 0x24,
+	0x9b, 0xd0, 0x0a, 0xd3, 0xe4, 0x28, 0x66, 0xa1, 0xb0, 0x2b, 0x4b, 0xad, 0x16, 0x32, 0x2e, 0x83,
+	0xde, 0xeb, 0x24, 0xfe, 0x46, 0x82, 0x58, 0x81, 0x4b, 0xf9, 0x56, 0x1a, 0x2f, 0x62, 0x58, 0xf9,
+	0x72, 0x42, 0xb3, 0xf3, 0x6f, 0x26, 0x89, 0x5b, 0xd0, 0x37, 0x76, 0xd3, 0x99, 0x34, 0xf3, 0x65,
+	0xbd, 0x43, 0xbe, 0x3e, 0x83, 0x95, 0xcf, 0x28, 0x1d, 0x23, 0xb5, 0x40, 0xb8, 0x65, 0x45, 0x7a,
+	0x1b, 0xe1, 0x28, 0xa6, 0x01, 0xa7, 0x7e, 0x10, 0xc7, 0x7a, 0x2a, 0x07, 0x4d, 0x7a, 0x1a, 0xc7,
+	0xee, 0x23, 0xe8, 0x1b, 0xc6, 0xb4, 0x47, 0x1b, 0xd0, 0x53, 0x3a, 0x9c, 0x86, 0x69, 0x22, 0x1f,
+	0x48, 0xbc, 0x1c, 0x5d, 0x49, 0x3c, 0x50, 0x34, 0xf7, 0xb7, 0x7a, 0xe4, 0x93, 0xaa, 0x98, 0x15,
+	0xbc, 0xf8, 0xf9, 0x6f, 0x02, 0xf8, 0x4d, 0x1e, 0x42, 0x67, 0x9c, 0x72, 0x76, 0xe6, 0x63, 0xec,
+	0xdc, 0xae, 0xcc, 0x3e, 0x9d, 0x45, 0x6c, 0x20, 0xc5, 0x94, 0xa1, 0x87, 0xd0, 0x91, 0x85, 0xae,
+	0x95, 0xaa, 0xcb, 0x95, 0xa4, 0x98, 0x54, 0x72, 0xb7, 0x01, 0xf0, 0xe3, 0x80, 0x66, 0x27, 0x34,
+	0xc3, 0x64, 0x70, 0xf9, 0x95, 0x27, 0x43, 0xad, 0x70, 0xbc, 0xa7, 0x67, 0x63, 0x96, 0x51, 0x3f,
+	0x10, 0x78, 0xe5, 0xd5, 0x5c, 0x07, 0x8a, 0xf6, 0x54, 0xec, 0x73, 0xf7, 0x8f, 0x16, 0xd4, 0xbf,
+	0x9c, 0xa4, 0x22, 0x78, 0x4b, 0x0d, 0xac, 0x41, 0x7b, 0x14, 0x9c, 0xf9, 0x87, 0xe7, 0x82, 0xe6,
+	0x66, 0x5a, 0xa3, 0xe0, 0xec, 0xd9, 0xb9, 0x1e, 0x2a, 0x91, 0x89, 0x1e, 0xe7, 0xb0, 0x82, 0x4c,
+	0x74, 0x9c, 0x23, 0x1e, 0x4b, 0xb0, 0x56, 0xaa, 0x6a, 0xb4, 0x95, 0xf0, 0xad, 0x74, 0x73, 0xb6,
+	0x52, 0xae, 0x4f, 0xd9, 0x52, 0xdb, 0xfd, 0x8d, 0x05, 0x97, 0x0f, 0xa8, 0x90, 0x2e, 0xbe, 0x5b,
+	0xb5, 0x7e, 0xb8, 0xa7, 0xd7, 0xa0, 0x91, 0xd1, 0x51, 0x7a, 0x42, 0xf5, 0x20, 0xa2, 0x57, 0xee,
+	0x63, 0x58, 0x99, 0xba, 0x30, 0x6d, 0x70, 0xbf, 0x42, 0xc2, 0x7c, 0x83, 0xab, 0xe4, 0x14, 0xd7,
+	0xfd, 0x2e, 0x5c, 0xde, 0x79, 0x1f, 0xef, 0x71, 0xaf, 0x9d, 0x0f, 0xdc, 0x6b, 0x15, 0x1b, 0x71,
+	0xae, 0x74, 0xf3, 0x6b, 0xe2, 0x3e, 0x01, 0x62, 0x12, 0xb5, 0xc5, 0x6f, 0x41, 0x43, 0xea, 0x2c,
+	0xf8, 0x0d, 0x4c, 0x99, 0xd4, 0xec, 0x07, 0xff, 0xec, 0x40, 0xf7, 0x80, 0x06, 0xa7, 0x54, 0x9d,
+	0x47, 0x46, 0x86, 0x79, 0x27, 0x56, 0xfe, 0x75, 0x91, 0xdc, 0x99, 0x6d, 0xb9, 0x16, 0xfe, 0x9c,
+	0xe9, 0x7c, 0xfc, 0x36, 0x31, 0x0d, 0x52, 0xff, 0x47, 0xf6, 0xa0, 0x63, 0xfc, 0x7c, 0x47, 0x6e,
+	0x1a, 0x8a, 0x73, 0xbf, 0x4a, 0x3a, 0xeb, 0x4b, 0xb8, 0xa6, 0x35, 0x63, 0x7a, 0x36, 0xad, 0xcd,
+	0xcf, 0xeb, 0xce, 0xfa, 0x12, 0xae, 0x69, 0xcd, 0x98, 0x8c, 0x4d, 0x6b, 0xf3, 0xb3, 0xb8, 0xb3,
+	0xbe, 0x84, 0x6b, 0x5a, 0x33, 0xc6, 0x54, 0xd3, 0xda, 0xfc, 0x98, 0xed, 0xac, 0x2f, 0xe1, 0x16,
+	0xd6, 0x7e, 0x09, 0xfd, 0xb9, 0x01, 0x92, 0xb8, 0x53, 0xad, 0x65, 0x93, 0xaf, 0xb3, 0x71, 0xa1,
+	0x4c, 0x61, 0x7f, 0x1b, 0xda, 0xc5, 0xb8, 0x47, 0x1c, 0x33, 0xef, 0xe5, 0xb9, 0xd4, 0x59, 0x5b,
+	0xc8, 0x2b, 0xec, 0x7c, 0x0e, 0x5d, 0x73, 0x64, 0x22, 0x46, 0x60, 0x0b, 0x26, 0x42, 0xe7, 0xd6,
+	0x32, 0xb6, 0x69, 0xd0, 0x6c, 0xf6, 0x4d, 0x83, 0x0b, 0xe6, 0x21, 0xe7, 0xd6, 0x32, 0x76, 0x61,
+	0xf0, 0xe7, 0xb0, 0x32, 0xdb, 0x74, 0x93, 0x8f, 0x66, 0xd3, 0x3f, 0xd7, 0xcb, 0x3b, 0xee, 0x45,
+	0x22, 0x85, 0xf1, 0x5d, 0x80, 0x69, 0x2f, 0x4d, 0x8c, 0x5c, 0xcd, 0xf5, 0xf2, 0xce, 0xcd, 0xc5,
+	0xcc, 0xc2, 0xd4, 0xaf, 0xa0, 0x3f, 0xd7, 0x57, 0x9a, 0x27, 0xbe, 0xac, 0xdd, 0x75, 0x36, 0x2e,
+	0x94, 0xc9, 0xed, 0xdf, 0xb7, 0xc8, 0xf7, 0xa1, 0x26, 0x1b, 0xc3, 0xab, 0xa5, 0x09, 0x27, 0xef,
+	0x1e, 0x9c, 0x6b, 0xb3, 0xe4, 0xc2, 0xb5, 0x27, 0xd0, 0x50, 0xdd, 0x07, 0xb9, 0x6e, 0xdc, 0x02,
+	0xb3, 0xf5, 0x71, 0xec, 0x79, 0x86, 0x59, 0x6b, 0x45, 0xf3, 0x60, 0xd6, 0xda, 0x6c, 0xff, 0xe2,
+	0xac, 0x2d, 0xe4, 0x99, 0x76, 0x8a, 0x27, 0xdf, 0xb4, 0x33, 0xdb, 0x54, 0x38, 0x6b, 0x0b, 0x79,
+	0x85, 0x9d, 0x2d, 0x68, 0xe5, 0x0f, 0x01, 0xb9, 0x61, 0x24, 0xaf, 0x8c, 0xf0, 0x8e, 0xb3, 0x88,
+	0x65, 0x1a, 0xd9, 0x59, 0x60, 0x64, 0x67, 0xb9, 0x91, 0x9d, 0x79, 0x23, 0xbb, 0x00, 0x53, 0x58,
+	0x27, 0x6b, 0x65, 0xf8, 0x2b, 0xbd, 0x00, 0xce, 0xcd, 0xc5, 0xcc, 0xdc, 0xd4, 0xb3, 0x5b, 0xb0,
+	0xc2, 0x15, 0xc2, 0x1f, 0xf1, 0x4d, 0xd5, 0x44, 0x3d, 0x03, 0x09, 0xf6, 0x5f, 0xe0, 0x5f, 0x55,
+	0x87, 0x0d, 0xf9, 0x8f, 0xd5, 0xc3, 0xff, 0x0c, 0x00, 0xef, 0xd4, 0xe8, 0x31, 0xc0, 0x1a, 0x00,
+	0x00,
 }
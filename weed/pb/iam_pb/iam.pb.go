@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go.
+// source: iam.proto
+// DO NOT EDIT!
+
+/*
+Package iam_pb is a generated protocol buffer package.
+
+It is generated from these files:
+	iam.proto
+
+It has these top-level messages:
+	Identities
+	Identity
+	PosixIdentity
+	Credential
+*/
+package iam_pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Identities struct {
+	Identities []*Identity `protobuf:"bytes,1,rep,name=identities" json:"identities,omitempty"`
+}
+
+func (m *Identities) Reset()                    { *m = Identities{} }
+func (m *Identities) String() string            { return proto.CompactTextString(m) }
+func (*Identities) ProtoMessage()               {}
+func (*Identities) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Identities) GetIdentities() []*Identity {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
+type Identity struct {
+	Name        string         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Credentials []*Credential  `protobuf:"bytes,2,rep,name=credentials" json:"credentials,omitempty"`
+	Actions     []string       `protobuf:"bytes,3,rep,name=actions" json:"actions,omitempty"`
+	Posix       *PosixIdentity `protobuf:"bytes,4,opt,name=posix" json:"posix,omitempty"`
+}
+
+func (m *Identity) Reset()                    { *m = Identity{} }
+func (m *Identity) String() string            { return proto.CompactTextString(m) }
+func (*Identity) ProtoMessage()               {}
+func (*Identity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Identity) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Identity) GetCredentials() []*Credential {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+func (m *Identity) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *Identity) GetPosix() *PosixIdentity {
+	if m != nil {
+		return m.Posix
+	}
+	return nil
+}
+
+// the uid and gids to access the filer as, the first gid is the primary group
+type PosixIdentity struct {
+	Uid  uint32   `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Gids []uint32 `protobuf:"varint,2,rep,packed,name=gids" json:"gids,omitempty"`
+}
+
+func (m *PosixIdentity) Reset()                    { *m = PosixIdentity{} }
+func (m *PosixIdentity) String() string            { return proto.CompactTextString(m) }
+func (*PosixIdentity) ProtoMessage()               {}
+func (*PosixIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PosixIdentity) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *PosixIdentity) GetGids() []uint32 {
+	if m != nil {
+		return m.Gids
+	}
+	return nil
+}
+
+type Credential struct {
+	AccessKey string `protobuf:"bytes,1,opt,name=access_key,json=accessKey" json:"access_key,omitempty"`
+	SecretKey string `protobuf:"bytes,2,opt,name=secret_key,json=secretKey" json:"secret_key,omitempty"`
+}
+
+func (m *Credential) Reset()                    { *m = Credential{} }
+func (m *Credential) String() string            { return proto.CompactTextString(m) }
+func (*Credential) ProtoMessage()               {}
+func (*Credential) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Credential) GetAccessKey() string {
+	if m != nil {
+		return m.AccessKey
+	}
+	return ""
+}
+
+func (m *Credential) GetSecretKey() string {
+	if m != nil {
+		return m.SecretKey
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Identities)(nil), "iam_pb.Identities")
+	proto.RegisterType((*Identity)(nil), "iam_pb.Identity")
+	proto.RegisterType((*PosixIdentity)(nil), "iam_pb.PosixIdentity")
+	proto.RegisterType((*Credential)(nil), "iam_pb.Credential")
+}
+
+func init() { proto.RegisterFile("iam.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 245 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x54, 0x90, 0x4f, 0x4b, 0xc4, 0x30,
+	0x10, 0xc5, 0xc9, 0x76, 0x5d, 0xed, 0x94, 0xc2, 0x32, 0x20, 0xe4, 0x22, 0x94, 0x9e, 0x0a, 0x42,
+	0x91, 0x55, 0xaf, 0x5e, 0x3c, 0xe9, 0x5e, 0x24, 0x5f, 0x60, 0xc9, 0xa6, 0x83, 0x0c, 0xda, 0x3f,
+	0x34, 0x11, 0xec, 0x67, 0xf1, 0xcb, 0x4a, 0x93, 0x4d, 0x5d, 0x6f, 0x6f, 0xde, 0xfb, 0xf1, 0x78,
+	0x09, 0xa4, 0xac, 0xdb, 0x7a, 0x18, 0x7b, 0xd7, 0xe3, 0x86, 0x75, 0x7b, 0x18, 0x8e, 0xe5, 0x13,
+	0xc0, 0x4b, 0x43, 0x9d, 0x63, 0xc7, 0x64, 0xf1, 0x0e, 0x80, 0x97, 0x4b, 0x8a, 0x22, 0xa9, 0xb2,
+	0xdd, 0xb6, 0x0e, 0x68, 0x7d, 0xe2, 0x26, 0x75, 0xc6, 0x94, 0x3f, 0x02, 0xae, 0x62, 0x80, 0x08,
+	0xeb, 0x4e, 0xb7, 0x24, 0x45, 0x21, 0xaa, 0x54, 0x79, 0x8d, 0x0f, 0x90, 0x99, 0x91, 0x3c, 0xa1,
+	0x3f, 0xad, 0x5c, 0xf9, 0x4e, 0x8c, 0x9d, 0xcf, 0x4b, 0xa4, 0xce, 0x31, 0x94, 0x70, 0xa9, 0x8d,
+	0xe3, 0xbe, 0xb3, 0x32, 0x29, 0x92, 0x2a, 0x55, 0xf1, 0xc4, 0x5b, 0xb8, 0x18, 0x7a, 0xcb, 0xdf,
+	0x72, 0x5d, 0x88, 0x2a, 0xdb, 0x5d, 0xc7, 0xa6, 0xb7, 0xd9, 0x5c, 0x26, 0x06, 0xa6, 0x7c, 0x84,
+	0xfc, 0x9f, 0x8f, 0x5b, 0x48, 0xbe, 0xb8, 0xf1, 0x03, 0x73, 0x35, 0xcb, 0x79, 0xf3, 0x3b, 0x37,
+	0x61, 0x58, 0xae, 0xbc, 0x2e, 0x5f, 0x01, 0xfe, 0x86, 0xe1, 0x0d, 0x80, 0x36, 0x86, 0xac, 0x3d,
+	0x7c, 0xd0, 0x74, 0x7a, 0x5b, 0x1a, 0x9c, 0x3d, 0x4d, 0x73, 0x6c, 0xc9, 0x8c, 0xe4, 0x7c, 0xbc,
+	0x0a, 0x71, 0x70, 0xf6, 0x34, 0x1d, 0x37, 0xfe, 0xbf, 0xef, 0x7f, 0x07, 0x00, 0xee, 0x48, 0x9e,
+	0xda, 0x7c, 0x01, 0x00, 0x00,
+}
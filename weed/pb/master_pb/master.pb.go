@@ -12,6 +12,7 @@ It has these top-level messages:
 	Heartbeat
 	HeartbeatResponse
 	VolumeInformationMessage
+	VolumeEcShardInformationMessage
 	Empty
 	SuperBlockExtra
 	ClientListenRequest
@@ -33,8 +34,23 @@ It has these top-level messages:
 	RackInfo
 	DataCenterInfo
 	TopologyInfo
+	DiskInfo
 	VolumeListRequest
 	VolumeListResponse
+	LookupEcVolumeRequest
+	LookupEcVolumeResponse
+	VolumeServerDrainRequest
+	VolumeServerDrainResponse
+	VolumeMoveLocationRequest
+	VolumeMoveLocationResponse
+	ReplicationRepairStatusRequest
+	ReplicationRepairStatusResponse
+	RaftListClusterServersRequest
+	RaftListClusterServersResponse
+	RaftAddServerRequest
+	RaftAddServerResponse
+	RaftRemoveServerRequest
+	RaftRemoveServerResponse
 */
 package master_pb
 
@@ -71,6 +87,13 @@ type Heartbeat struct {
 	// delta volume ids
 	NewVids     []uint32 `protobuf:"varint,10,rep,packed,name=new_vids,json=newVids" json:"new_vids,omitempty"`
 	DeletedVids []uint32 `protobuf:"varint,11,rep,packed,name=deleted_vids,json=deletedVids" json:"deleted_vids,omitempty"`
+	// erasure coding shards
+	EcShards []*VolumeEcShardInformationMessage `protobuf:"bytes,12,rep,name=ec_shards,json=ecShards" json:"ec_shards,omitempty"`
+	// delta erasure coding shards
+	NewEcShards     []*VolumeEcShardInformationMessage `protobuf:"bytes,13,rep,name=new_ec_shards,json=newEcShards" json:"new_ec_shards,omitempty"`
+	DeletedEcShards []*VolumeEcShardInformationMessage `protobuf:"bytes,14,rep,name=deleted_ec_shards,json=deletedEcShards" json:"deleted_ec_shards,omitempty"`
+	// max volume count of each disk type, the empty type is the hard drive
+	MaxVolumeCounts map[string]uint32 `protobuf:"bytes,15,rep,name=max_volume_counts,json=maxVolumeCounts" json:"max_volume_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 }
 
 func (m *Heartbeat) Reset()                    { *m = Heartbeat{} }
@@ -155,6 +178,34 @@ func (m *Heartbeat) GetDeletedVids() []uint32 {
 	return nil
 }
 
+func (m *Heartbeat) GetEcShards() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.EcShards
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetNewEcShards() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.NewEcShards
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetDeletedEcShards() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.DeletedEcShards
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetMaxVolumeCounts() map[string]uint32 {
+	if m != nil {
+		return m.MaxVolumeCounts
+	}
+	return nil
+}
+
 type HeartbeatResponse struct {
 	VolumeSizeLimit uint64 `protobuf:"varint,1,opt,name=volumeSizeLimit" json:"volumeSizeLimit,omitempty"`
 	Leader          string `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
@@ -180,17 +231,20 @@ func (m *HeartbeatResponse) GetLeader() string {
 }
 
 type VolumeInformationMessage struct {
-	Id               uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Size             uint64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
-	Collection       string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
-	FileCount        uint64 `protobuf:"varint,4,opt,name=file_count,json=fileCount" json:"file_count,omitempty"`
-	DeleteCount      uint64 `protobuf:"varint,5,opt,name=delete_count,json=deleteCount" json:"delete_count,omitempty"`
-	DeletedByteCount uint64 `protobuf:"varint,6,opt,name=deleted_byte_count,json=deletedByteCount" json:"deleted_byte_count,omitempty"`
-	ReadOnly         bool   `protobuf:"varint,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
-	ReplicaPlacement uint32 `protobuf:"varint,8,opt,name=replica_placement,json=replicaPlacement" json:"replica_placement,omitempty"`
-	Version          uint32 `protobuf:"varint,9,opt,name=version" json:"version,omitempty"`
-	Ttl              uint32 `protobuf:"varint,10,opt,name=ttl" json:"ttl,omitempty"`
-	CompactRevision  uint32 `protobuf:"varint,11,opt,name=compact_revision,json=compactRevision" json:"compact_revision,omitempty"`
+	Id                uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Size              uint64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	Collection        string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
+	FileCount         uint64 `protobuf:"varint,4,opt,name=file_count,json=fileCount" json:"file_count,omitempty"`
+	DeleteCount       uint64 `protobuf:"varint,5,opt,name=delete_count,json=deleteCount" json:"delete_count,omitempty"`
+	DeletedByteCount  uint64 `protobuf:"varint,6,opt,name=deleted_byte_count,json=deletedByteCount" json:"deleted_byte_count,omitempty"`
+	ReadOnly          bool   `protobuf:"varint,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+	ReplicaPlacement  uint32 `protobuf:"varint,8,opt,name=replica_placement,json=replicaPlacement" json:"replica_placement,omitempty"`
+	Version           uint32 `protobuf:"varint,9,opt,name=version" json:"version,omitempty"`
+	Ttl               uint32 `protobuf:"varint,10,opt,name=ttl" json:"ttl,omitempty"`
+	CompactRevision   uint32 `protobuf:"varint,11,opt,name=compact_revision,json=compactRevision" json:"compact_revision,omitempty"`
+	RemoteStorageName string `protobuf:"bytes,12,opt,name=remote_storage_name,json=remoteStorageName" json:"remote_storage_name,omitempty"`
+	RemoteStorageKey  string `protobuf:"bytes,13,opt,name=remote_storage_key,json=remoteStorageKey" json:"remote_storage_key,omitempty"`
+	DiskType          string `protobuf:"bytes,14,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
 }
 
 func (m *VolumeInformationMessage) Reset()                    { *m = VolumeInformationMessage{} }
@@ -275,13 +329,68 @@ func (m *VolumeInformationMessage) GetCompactRevision() uint32 {
 	return 0
 }
 
+func (m *VolumeInformationMessage) GetRemoteStorageName() string {
+	if m != nil {
+		return m.RemoteStorageName
+	}
+	return ""
+}
+
+func (m *VolumeInformationMessage) GetRemoteStorageKey() string {
+	if m != nil {
+		return m.RemoteStorageKey
+	}
+	return ""
+}
+
+func (m *VolumeInformationMessage) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
+type VolumeEcShardInformationMessage struct {
+	Id          uint32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Collection  string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	EcIndexBits uint32 `protobuf:"varint,3,opt,name=ec_index_bits,json=ecIndexBits" json:"ec_index_bits,omitempty"`
+}
+
+func (m *VolumeEcShardInformationMessage) Reset()         { *m = VolumeEcShardInformationMessage{} }
+func (m *VolumeEcShardInformationMessage) String() string { return proto.CompactTextString(m) }
+func (*VolumeEcShardInformationMessage) ProtoMessage()    {}
+func (*VolumeEcShardInformationMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3}
+}
+
+func (m *VolumeEcShardInformationMessage) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *VolumeEcShardInformationMessage) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeEcShardInformationMessage) GetEcIndexBits() uint32 {
+	if m != nil {
+		return m.EcIndexBits
+	}
+	return 0
+}
+
 type Empty struct {
 }
 
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 type SuperBlockExtra struct {
 	ErasureCoding *SuperBlockExtra_ErasureCoding `protobuf:"bytes,1,opt,name=erasure_coding,json=erasureCoding" json:"erasure_coding,omitempty"`
@@ -290,7 +399,7 @@ type SuperBlockExtra struct {
 func (m *SuperBlockExtra) Reset()                    { *m = SuperBlockExtra{} }
 func (m *SuperBlockExtra) String() string            { return proto.CompactTextString(m) }
 func (*SuperBlockExtra) ProtoMessage()               {}
-func (*SuperBlockExtra) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*SuperBlockExtra) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *SuperBlockExtra) GetErasureCoding() *SuperBlockExtra_ErasureCoding {
 	if m != nil {
@@ -309,7 +418,7 @@ func (m *SuperBlockExtra_ErasureCoding) Reset()         { *m = SuperBlockExtra_E
 func (m *SuperBlockExtra_ErasureCoding) String() string { return proto.CompactTextString(m) }
 func (*SuperBlockExtra_ErasureCoding) ProtoMessage()    {}
 func (*SuperBlockExtra_ErasureCoding) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{4, 0}
+	return fileDescriptor0, []int{5, 0}
 }
 
 func (m *SuperBlockExtra_ErasureCoding) GetData() uint32 {
@@ -340,7 +449,7 @@ type ClientListenRequest struct {
 func (m *ClientListenRequest) Reset()                    { *m = ClientListenRequest{} }
 func (m *ClientListenRequest) String() string            { return proto.CompactTextString(m) }
 func (*ClientListenRequest) ProtoMessage()               {}
-func (*ClientListenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*ClientListenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *ClientListenRequest) GetName() string {
 	if m != nil {
@@ -350,16 +459,19 @@ func (m *ClientListenRequest) GetName() string {
 }
 
 type VolumeLocation struct {
-	Url         string   `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
-	PublicUrl   string   `protobuf:"bytes,2,opt,name=public_url,json=publicUrl" json:"public_url,omitempty"`
-	NewVids     []uint32 `protobuf:"varint,3,rep,packed,name=new_vids,json=newVids" json:"new_vids,omitempty"`
-	DeletedVids []uint32 `protobuf:"varint,4,rep,packed,name=deleted_vids,json=deletedVids" json:"deleted_vids,omitempty"`
+	Url           string   `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	PublicUrl     string   `protobuf:"bytes,2,opt,name=public_url,json=publicUrl" json:"public_url,omitempty"`
+	NewVids       []uint32 `protobuf:"varint,3,rep,packed,name=new_vids,json=newVids" json:"new_vids,omitempty"`
+	DeletedVids   []uint32 `protobuf:"varint,4,rep,packed,name=deleted_vids,json=deletedVids" json:"deleted_vids,omitempty"`
+	NewEcVids     []uint32 `protobuf:"varint,5,rep,packed,name=new_ec_vids,json=newEcVids" json:"new_ec_vids,omitempty"`
+	DeletedEcVids []uint32 `protobuf:"varint,6,rep,packed,name=deleted_ec_vids,json=deletedEcVids" json:"deleted_ec_vids,omitempty"`
+	DataCenter    string   `protobuf:"bytes,7,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
 }
 
 func (m *VolumeLocation) Reset()                    { *m = VolumeLocation{} }
 func (m *VolumeLocation) String() string            { return proto.CompactTextString(m) }
 func (*VolumeLocation) ProtoMessage()               {}
-func (*VolumeLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*VolumeLocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *VolumeLocation) GetUrl() string {
 	if m != nil {
@@ -389,6 +501,27 @@ func (m *VolumeLocation) GetDeletedVids() []uint32 {
 	return nil
 }
 
+func (m *VolumeLocation) GetNewEcVids() []uint32 {
+	if m != nil {
+		return m.NewEcVids
+	}
+	return nil
+}
+
+func (m *VolumeLocation) GetDeletedEcVids() []uint32 {
+	if m != nil {
+		return m.DeletedEcVids
+	}
+	return nil
+}
+
+func (m *VolumeLocation) GetDataCenter() string {
+	if m != nil {
+		return m.DataCenter
+	}
+	return ""
+}
+
 type LookupVolumeRequest struct {
 	VolumeIds  []string `protobuf:"bytes,1,rep,name=volume_ids,json=volumeIds" json:"volume_ids,omitempty"`
 	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
@@ -397,7 +530,7 @@ type LookupVolumeRequest struct {
 func (m *LookupVolumeRequest) Reset()                    { *m = LookupVolumeRequest{} }
 func (m *LookupVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeRequest) ProtoMessage()               {}
-func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *LookupVolumeRequest) GetVolumeIds() []string {
 	if m != nil {
@@ -420,7 +553,7 @@ type LookupVolumeResponse struct {
 func (m *LookupVolumeResponse) Reset()                    { *m = LookupVolumeResponse{} }
 func (m *LookupVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse) ProtoMessage()               {}
-func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *LookupVolumeResponse) GetVolumeIdLocations() []*LookupVolumeResponse_VolumeIdLocation {
 	if m != nil {
@@ -439,7 +572,7 @@ func (m *LookupVolumeResponse_VolumeIdLocation) Reset()         { *m = LookupVol
 func (m *LookupVolumeResponse_VolumeIdLocation) String() string { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse_VolumeIdLocation) ProtoMessage()    {}
 func (*LookupVolumeResponse_VolumeIdLocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{8, 0}
+	return fileDescriptor0, []int{9, 0}
 }
 
 func (m *LookupVolumeResponse_VolumeIdLocation) GetVolumeId() string {
@@ -471,7 +604,7 @@ type Location struct {
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *Location) GetUrl() string {
 	if m != nil {
@@ -495,12 +628,13 @@ type AssignRequest struct {
 	DataCenter  string `protobuf:"bytes,5,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
 	Rack        string `protobuf:"bytes,6,opt,name=rack" json:"rack,omitempty"`
 	DataNode    string `protobuf:"bytes,7,opt,name=data_node,json=dataNode" json:"data_node,omitempty"`
+	DiskType    string `protobuf:"bytes,8,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
 }
 
 func (m *AssignRequest) Reset()                    { *m = AssignRequest{} }
 func (m *AssignRequest) String() string            { return proto.CompactTextString(m) }
 func (*AssignRequest) ProtoMessage()               {}
-func (*AssignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*AssignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *AssignRequest) GetCount() uint64 {
 	if m != nil {
@@ -551,6 +685,13 @@ func (m *AssignRequest) GetDataNode() string {
 	return ""
 }
 
+func (m *AssignRequest) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
 type AssignResponse struct {
 	Fid       string `protobuf:"bytes,1,opt,name=fid" json:"fid,omitempty"`
 	Url       string `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
@@ -563,7 +704,7 @@ type AssignResponse struct {
 func (m *AssignResponse) Reset()                    { *m = AssignResponse{} }
 func (m *AssignResponse) String() string            { return proto.CompactTextString(m) }
 func (*AssignResponse) ProtoMessage()               {}
-func (*AssignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*AssignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *AssignResponse) GetFid() string {
 	if m != nil {
@@ -611,12 +752,13 @@ type StatisticsRequest struct {
 	Replication string `protobuf:"bytes,1,opt,name=replication" json:"replication,omitempty"`
 	Collection  string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
 	Ttl         string `protobuf:"bytes,3,opt,name=ttl" json:"ttl,omitempty"`
+	DiskType    string `protobuf:"bytes,4,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
 }
 
 func (m *StatisticsRequest) Reset()                    { *m = StatisticsRequest{} }
 func (m *StatisticsRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsRequest) ProtoMessage()               {}
-func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*StatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *StatisticsRequest) GetReplication() string {
 	if m != nil {
@@ -639,6 +781,13 @@ func (m *StatisticsRequest) GetTtl() string {
 	return ""
 }
 
+func (m *StatisticsRequest) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
 type StatisticsResponse struct {
 	Replication string `protobuf:"bytes,1,opt,name=replication" json:"replication,omitempty"`
 	Collection  string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
@@ -651,7 +800,7 @@ type StatisticsResponse struct {
 func (m *StatisticsResponse) Reset()                    { *m = StatisticsResponse{} }
 func (m *StatisticsResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatisticsResponse) ProtoMessage()               {}
-func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*StatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *StatisticsResponse) GetReplication() string {
 	if m != nil {
@@ -703,7 +852,7 @@ type StorageType struct {
 func (m *StorageType) Reset()                    { *m = StorageType{} }
 func (m *StorageType) String() string            { return proto.CompactTextString(m) }
 func (*StorageType) ProtoMessage()               {}
-func (*StorageType) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*StorageType) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *StorageType) GetReplication() string {
 	if m != nil {
@@ -726,7 +875,7 @@ type Collection struct {
 func (m *Collection) Reset()                    { *m = Collection{} }
 func (m *Collection) String() string            { return proto.CompactTextString(m) }
 func (*Collection) ProtoMessage()               {}
-func (*Collection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*Collection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *Collection) GetName() string {
 	if m != nil {
@@ -741,7 +890,7 @@ type CollectionListRequest struct {
 func (m *CollectionListRequest) Reset()                    { *m = CollectionListRequest{} }
 func (m *CollectionListRequest) String() string            { return proto.CompactTextString(m) }
 func (*CollectionListRequest) ProtoMessage()               {}
-func (*CollectionListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*CollectionListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 type CollectionListResponse struct {
 	Collections []*Collection `protobuf:"bytes,1,rep,name=collections" json:"collections,omitempty"`
@@ -750,7 +899,7 @@ type CollectionListResponse struct {
 func (m *CollectionListResponse) Reset()                    { *m = CollectionListResponse{} }
 func (m *CollectionListResponse) String() string            { return proto.CompactTextString(m) }
 func (*CollectionListResponse) ProtoMessage()               {}
-func (*CollectionListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*CollectionListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *CollectionListResponse) GetCollections() []*Collection {
 	if m != nil {
@@ -766,7 +915,7 @@ type CollectionDeleteRequest struct {
 func (m *CollectionDeleteRequest) Reset()                    { *m = CollectionDeleteRequest{} }
 func (m *CollectionDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*CollectionDeleteRequest) ProtoMessage()               {}
-func (*CollectionDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*CollectionDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *CollectionDeleteRequest) GetName() string {
 	if m != nil {
@@ -781,24 +930,27 @@ type CollectionDeleteResponse struct {
 func (m *CollectionDeleteResponse) Reset()                    { *m = CollectionDeleteResponse{} }
 func (m *CollectionDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*CollectionDeleteResponse) ProtoMessage()               {}
-func (*CollectionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*CollectionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 //
 // volume related
 //
 type DataNodeInfo struct {
-	Id                string                      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	VolumeCount       uint64                      `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
-	MaxVolumeCount    uint64                      `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
-	FreeVolumeCount   uint64                      `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
-	ActiveVolumeCount uint64                      `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
-	VolumeInfos       []*VolumeInformationMessage `protobuf:"bytes,6,rep,name=volume_infos,json=volumeInfos" json:"volume_infos,omitempty"`
+	Id                string                             `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64                             `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64                             `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64                             `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64                             `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	VolumeInfos       []*VolumeInformationMessage        `protobuf:"bytes,6,rep,name=volume_infos,json=volumeInfos" json:"volume_infos,omitempty"`
+	EcShardInfos      []*VolumeEcShardInformationMessage `protobuf:"bytes,7,rep,name=ec_shard_infos,json=ecShardInfos" json:"ec_shard_infos,omitempty"`
+	DiskInfos         []*DiskInfo                        `protobuf:"bytes,8,rep,name=disk_infos,json=diskInfos" json:"disk_infos,omitempty"`
+	IsDraining        bool                               `protobuf:"varint,9,opt,name=is_draining,json=isDraining" json:"is_draining,omitempty"`
 }
 
 func (m *DataNodeInfo) Reset()                    { *m = DataNodeInfo{} }
 func (m *DataNodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*DataNodeInfo) ProtoMessage()               {}
-func (*DataNodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*DataNodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *DataNodeInfo) GetId() string {
 	if m != nil {
@@ -842,6 +994,27 @@ func (m *DataNodeInfo) GetVolumeInfos() []*VolumeInformationMessage {
 	return nil
 }
 
+func (m *DataNodeInfo) GetEcShardInfos() []*VolumeEcShardInformationMessage {
+	if m != nil {
+		return m.EcShardInfos
+	}
+	return nil
+}
+
+func (m *DataNodeInfo) GetDiskInfos() []*DiskInfo {
+	if m != nil {
+		return m.DiskInfos
+	}
+	return nil
+}
+
+func (m *DataNodeInfo) GetIsDraining() bool {
+	if m != nil {
+		return m.IsDraining
+	}
+	return false
+}
+
 type RackInfo struct {
 	Id                string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	VolumeCount       uint64          `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
@@ -849,12 +1022,13 @@ type RackInfo struct {
 	FreeVolumeCount   uint64          `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
 	ActiveVolumeCount uint64          `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
 	DataNodeInfos     []*DataNodeInfo `protobuf:"bytes,6,rep,name=data_node_infos,json=dataNodeInfos" json:"data_node_infos,omitempty"`
+	DiskInfos         []*DiskInfo     `protobuf:"bytes,7,rep,name=disk_infos,json=diskInfos" json:"disk_infos,omitempty"`
 }
 
 func (m *RackInfo) Reset()                    { *m = RackInfo{} }
 func (m *RackInfo) String() string            { return proto.CompactTextString(m) }
 func (*RackInfo) ProtoMessage()               {}
-func (*RackInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*RackInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *RackInfo) GetId() string {
 	if m != nil {
@@ -898,6 +1072,13 @@ func (m *RackInfo) GetDataNodeInfos() []*DataNodeInfo {
 	return nil
 }
 
+func (m *RackInfo) GetDiskInfos() []*DiskInfo {
+	if m != nil {
+		return m.DiskInfos
+	}
+	return nil
+}
+
 type DataCenterInfo struct {
 	Id                string      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	VolumeCount       uint64      `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
@@ -905,12 +1086,13 @@ type DataCenterInfo struct {
 	FreeVolumeCount   uint64      `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
 	ActiveVolumeCount uint64      `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
 	RackInfos         []*RackInfo `protobuf:"bytes,6,rep,name=rack_infos,json=rackInfos" json:"rack_infos,omitempty"`
+	DiskInfos         []*DiskInfo `protobuf:"bytes,7,rep,name=disk_infos,json=diskInfos" json:"disk_infos,omitempty"`
 }
 
 func (m *DataCenterInfo) Reset()                    { *m = DataCenterInfo{} }
 func (m *DataCenterInfo) String() string            { return proto.CompactTextString(m) }
 func (*DataCenterInfo) ProtoMessage()               {}
-func (*DataCenterInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*DataCenterInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *DataCenterInfo) GetId() string {
 	if m != nil {
@@ -954,6 +1136,13 @@ func (m *DataCenterInfo) GetRackInfos() []*RackInfo {
 	return nil
 }
 
+func (m *DataCenterInfo) GetDiskInfos() []*DiskInfo {
+	if m != nil {
+		return m.DiskInfos
+	}
+	return nil
+}
+
 type TopologyInfo struct {
 	Id                string            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	VolumeCount       uint64            `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
@@ -961,12 +1150,13 @@ type TopologyInfo struct {
 	FreeVolumeCount   uint64            `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
 	ActiveVolumeCount uint64            `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
 	DataCenterInfos   []*DataCenterInfo `protobuf:"bytes,6,rep,name=data_center_infos,json=dataCenterInfos" json:"data_center_infos,omitempty"`
+	DiskInfos         []*DiskInfo       `protobuf:"bytes,7,rep,name=disk_infos,json=diskInfos" json:"disk_infos,omitempty"`
 }
 
 func (m *TopologyInfo) Reset()                    { *m = TopologyInfo{} }
 func (m *TopologyInfo) String() string            { return proto.CompactTextString(m) }
 func (*TopologyInfo) ProtoMessage()               {}
-func (*TopologyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*TopologyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *TopologyInfo) GetId() string {
 	if m != nil {
@@ -1010,13 +1200,60 @@ func (m *TopologyInfo) GetDataCenterInfos() []*DataCenterInfo {
 	return nil
 }
 
+func (m *TopologyInfo) GetDiskInfos() []*DiskInfo {
+	if m != nil {
+		return m.DiskInfos
+	}
+	return nil
+}
+
+type DiskInfo struct {
+	Type            string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	VolumeCount     uint64 `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount  uint64 `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount uint64 `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+}
+
+func (m *DiskInfo) Reset()                    { *m = DiskInfo{} }
+func (m *DiskInfo) String() string            { return proto.CompactTextString(m) }
+func (*DiskInfo) ProtoMessage()               {}
+func (*DiskInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *DiskInfo) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DiskInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *DiskInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *DiskInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
+}
+
 type VolumeListRequest struct {
 }
 
 func (m *VolumeListRequest) Reset()                    { *m = VolumeListRequest{} }
 func (m *VolumeListRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeListRequest) ProtoMessage()               {}
-func (*VolumeListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*VolumeListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 type VolumeListResponse struct {
 	TopologyInfo *TopologyInfo `protobuf:"bytes,1,opt,name=topology_info,json=topologyInfo" json:"topology_info,omitempty"`
@@ -1025,7 +1262,7 @@ type VolumeListResponse struct {
 func (m *VolumeListResponse) Reset()                    { *m = VolumeListResponse{} }
 func (m *VolumeListResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeListResponse) ProtoMessage()               {}
-func (*VolumeListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*VolumeListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *VolumeListResponse) GetTopologyInfo() *TopologyInfo {
 	if m != nil {
@@ -1034,132 +1271,581 @@ func (m *VolumeListResponse) GetTopologyInfo() *TopologyInfo {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Heartbeat)(nil), "master_pb.Heartbeat")
-	proto.RegisterType((*HeartbeatResponse)(nil), "master_pb.HeartbeatResponse")
-	proto.RegisterType((*VolumeInformationMessage)(nil), "master_pb.VolumeInformationMessage")
-	proto.RegisterType((*Empty)(nil), "master_pb.Empty")
-	proto.RegisterType((*SuperBlockExtra)(nil), "master_pb.SuperBlockExtra")
-	proto.RegisterType((*SuperBlockExtra_ErasureCoding)(nil), "master_pb.SuperBlockExtra.ErasureCoding")
-	proto.RegisterType((*ClientListenRequest)(nil), "master_pb.ClientListenRequest")
-	proto.RegisterType((*VolumeLocation)(nil), "master_pb.VolumeLocation")
-	proto.RegisterType((*LookupVolumeRequest)(nil), "master_pb.LookupVolumeRequest")
-	proto.RegisterType((*LookupVolumeResponse)(nil), "master_pb.LookupVolumeResponse")
-	proto.RegisterType((*LookupVolumeResponse_VolumeIdLocation)(nil), "master_pb.LookupVolumeResponse.VolumeIdLocation")
-	proto.RegisterType((*Location)(nil), "master_pb.Location")
-	proto.RegisterType((*AssignRequest)(nil), "master_pb.AssignRequest")
-	proto.RegisterType((*AssignResponse)(nil), "master_pb.AssignResponse")
-	proto.RegisterType((*StatisticsRequest)(nil), "master_pb.StatisticsRequest")
-	proto.RegisterType((*StatisticsResponse)(nil), "master_pb.StatisticsResponse")
-	proto.RegisterType((*StorageType)(nil), "master_pb.StorageType")
-	proto.RegisterType((*Collection)(nil), "master_pb.Collection")
-	proto.RegisterType((*CollectionListRequest)(nil), "master_pb.CollectionListRequest")
-	proto.RegisterType((*CollectionListResponse)(nil), "master_pb.CollectionListResponse")
-	proto.RegisterType((*CollectionDeleteRequest)(nil), "master_pb.CollectionDeleteRequest")
-	proto.RegisterType((*CollectionDeleteResponse)(nil), "master_pb.CollectionDeleteResponse")
-	proto.RegisterType((*DataNodeInfo)(nil), "master_pb.DataNodeInfo")
-	proto.RegisterType((*RackInfo)(nil), "master_pb.RackInfo")
-	proto.RegisterType((*DataCenterInfo)(nil), "master_pb.DataCenterInfo")
-	proto.RegisterType((*TopologyInfo)(nil), "master_pb.TopologyInfo")
-	proto.RegisterType((*VolumeListRequest)(nil), "master_pb.VolumeListRequest")
-	proto.RegisterType((*VolumeListResponse)(nil), "master_pb.VolumeListResponse")
+type LookupEcVolumeRequest struct {
+	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Seaweed service
+func (m *LookupEcVolumeRequest) Reset()                    { *m = LookupEcVolumeRequest{} }
+func (m *LookupEcVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*LookupEcVolumeRequest) ProtoMessage()               {}
+func (*LookupEcVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
-type SeaweedClient interface {
-	SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error)
-	KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error)
-	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
-	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
-	Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error)
-	CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error)
-	CollectionDelete(ctx context.Context, in *CollectionDeleteRequest, opts ...grpc.CallOption) (*CollectionDeleteResponse, error)
-	VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error)
+func (m *LookupEcVolumeRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
 }
 
-type seaweedClient struct {
-	cc *grpc.ClientConn
+type LookupEcVolumeResponse struct {
+	VolumeId         uint32                                      `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	ShardIdLocations []*LookupEcVolumeResponse_EcShardIdLocation `protobuf:"bytes,2,rep,name=shard_id_locations,json=shardIdLocations" json:"shard_id_locations,omitempty"`
 }
 
-func NewSeaweedClient(cc *grpc.ClientConn) SeaweedClient {
-	return &seaweedClient{cc}
-}
+func (m *LookupEcVolumeResponse) Reset()                    { *m = LookupEcVolumeResponse{} }
+func (m *LookupEcVolumeResponse) String() string            { return proto.CompactTextString(m) }
+func (*LookupEcVolumeResponse) ProtoMessage()               {}
+func (*LookupEcVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
-func (c *seaweedClient) SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[0], c.cc, "/master_pb.Seaweed/SendHeartbeat", opts...)
-	if err != nil {
-		return nil, err
+func (m *LookupEcVolumeResponse) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
 	}
-	x := &seaweedSendHeartbeatClient{stream}
-	return x, nil
+	return 0
 }
 
-type Seaweed_SendHeartbeatClient interface {
-	Send(*Heartbeat) error
-	Recv() (*HeartbeatResponse, error)
-	grpc.ClientStream
+func (m *LookupEcVolumeResponse) GetShardIdLocations() []*LookupEcVolumeResponse_EcShardIdLocation {
+	if m != nil {
+		return m.ShardIdLocations
+	}
+	return nil
 }
 
-type seaweedSendHeartbeatClient struct {
-	grpc.ClientStream
+type LookupEcVolumeResponse_EcShardIdLocation struct {
+	ShardId   uint32      `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Locations []*Location `protobuf:"bytes,2,rep,name=locations" json:"locations,omitempty"`
 }
 
-func (x *seaweedSendHeartbeatClient) Send(m *Heartbeat) error {
-	return x.ClientStream.SendMsg(m)
+func (m *LookupEcVolumeResponse_EcShardIdLocation) Reset() {
+	*m = LookupEcVolumeResponse_EcShardIdLocation{}
+}
+func (m *LookupEcVolumeResponse_EcShardIdLocation) String() string { return proto.CompactTextString(m) }
+func (*LookupEcVolumeResponse_EcShardIdLocation) ProtoMessage()    {}
+func (*LookupEcVolumeResponse_EcShardIdLocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{29, 0}
 }
 
-func (x *seaweedSendHeartbeatClient) Recv() (*HeartbeatResponse, error) {
-	m := new(HeartbeatResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *LookupEcVolumeResponse_EcShardIdLocation) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
 	}
-	return m, nil
+	return 0
 }
 
-func (c *seaweedClient) KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[1], c.cc, "/master_pb.Seaweed/KeepConnected", opts...)
-	if err != nil {
-		return nil, err
+func (m *LookupEcVolumeResponse_EcShardIdLocation) GetLocations() []*Location {
+	if m != nil {
+		return m.Locations
 	}
-	x := &seaweedKeepConnectedClient{stream}
-	return x, nil
+	return nil
 }
 
-type Seaweed_KeepConnectedClient interface {
-	Send(*ClientListenRequest) error
-	Recv() (*VolumeLocation, error)
-	grpc.ClientStream
+type VolumeServerDrainRequest struct {
+	Node     string `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	Draining bool   `protobuf:"varint,2,opt,name=draining" json:"draining,omitempty"`
 }
 
-type seaweedKeepConnectedClient struct {
-	grpc.ClientStream
-}
+func (m *VolumeServerDrainRequest) Reset()                    { *m = VolumeServerDrainRequest{} }
+func (m *VolumeServerDrainRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeServerDrainRequest) ProtoMessage()               {}
+func (*VolumeServerDrainRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
-func (x *seaweedKeepConnectedClient) Send(m *ClientListenRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *VolumeServerDrainRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
 }
 
-func (x *seaweedKeepConnectedClient) Recv() (*VolumeLocation, error) {
-	m := new(VolumeLocation)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *VolumeServerDrainRequest) GetDraining() bool {
+	if m != nil {
+		return m.Draining
 	}
-	return m, nil
+	return false
 }
 
-func (c *seaweedClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
-	out := new(LookupVolumeResponse)
-	err := grpc.Invoke(ctx, "/master_pb.Seaweed/LookupVolume", in, out, c.cc, opts...)
-	if err != nil {
+type VolumeServerDrainResponse struct {
+}
+
+func (m *VolumeServerDrainResponse) Reset()                    { *m = VolumeServerDrainResponse{} }
+func (m *VolumeServerDrainResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeServerDrainResponse) ProtoMessage()               {}
+func (*VolumeServerDrainResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+type VolumeMoveLocationRequest struct {
+	VolumeId   uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	SourceNode string `protobuf:"bytes,2,opt,name=source_node,json=sourceNode" json:"source_node,omitempty"`
+	TargetNode string `protobuf:"bytes,3,opt,name=target_node,json=targetNode" json:"target_node,omitempty"`
+	// whether the moved volume is read-only, as the source was before the move
+	ReadOnly bool `protobuf:"varint,4,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+}
+
+func (m *VolumeMoveLocationRequest) Reset()                    { *m = VolumeMoveLocationRequest{} }
+func (m *VolumeMoveLocationRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMoveLocationRequest) ProtoMessage()               {}
+func (*VolumeMoveLocationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *VolumeMoveLocationRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeMoveLocationRequest) GetSourceNode() string {
+	if m != nil {
+		return m.SourceNode
+	}
+	return ""
+}
+
+func (m *VolumeMoveLocationRequest) GetTargetNode() string {
+	if m != nil {
+		return m.TargetNode
+	}
+	return ""
+}
+
+func (m *VolumeMoveLocationRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type VolumeMoveLocationResponse struct {
+}
+
+func (m *VolumeMoveLocationResponse) Reset()                    { *m = VolumeMoveLocationResponse{} }
+func (m *VolumeMoveLocationResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMoveLocationResponse) ProtoMessage()               {}
+func (*VolumeMoveLocationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type ReplicationRepairStatusRequest struct {
+}
+
+func (m *ReplicationRepairStatusRequest) Reset()         { *m = ReplicationRepairStatusRequest{} }
+func (m *ReplicationRepairStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicationRepairStatusRequest) ProtoMessage()    {}
+func (*ReplicationRepairStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{34}
+}
+
+type ReplicationRepairStatusResponse struct {
+	Repairs []*ReplicationRepairStatusResponse_ReplicationRepair `protobuf:"bytes,1,rep,name=repairs" json:"repairs,omitempty"`
+}
+
+func (m *ReplicationRepairStatusResponse) Reset()         { *m = ReplicationRepairStatusResponse{} }
+func (m *ReplicationRepairStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplicationRepairStatusResponse) ProtoMessage()    {}
+func (*ReplicationRepairStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{35}
+}
+
+func (m *ReplicationRepairStatusResponse) GetRepairs() []*ReplicationRepairStatusResponse_ReplicationRepair {
+	if m != nil {
+		return m.Repairs
+	}
+	return nil
+}
+
+type ReplicationRepairStatusResponse_ReplicationRepair struct {
+	VolumeId         uint32   `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection       string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ReplicaPlacement string   `protobuf:"bytes,3,opt,name=replica_placement,json=replicaPlacement" json:"replica_placement,omitempty"`
+	Ttl              string   `protobuf:"bytes,4,opt,name=ttl" json:"ttl,omitempty"`
+	DiskType         string   `protobuf:"bytes,5,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
+	State            string   `protobuf:"bytes,6,opt,name=state" json:"state,omitempty"`
+	Locations        []string `protobuf:"bytes,7,rep,name=locations" json:"locations,omitempty"`
+	Targets          []string `protobuf:"bytes,8,rep,name=targets" json:"targets,omitempty"`
+	DetectedAt       int64    `protobuf:"varint,9,opt,name=detected_at,json=detectedAt" json:"detected_at,omitempty"`
+	StartedAt        int64    `protobuf:"varint,10,opt,name=started_at,json=startedAt" json:"started_at,omitempty"`
+	FinishedAt       int64    `protobuf:"varint,11,opt,name=finished_at,json=finishedAt" json:"finished_at,omitempty"`
+	Error            string   `protobuf:"bytes,12,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) Reset() {
+	*m = ReplicationRepairStatusResponse_ReplicationRepair{}
+}
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) String() string {
+	return proto.CompactTextString(m)
+}
+func (*ReplicationRepairStatusResponse_ReplicationRepair) ProtoMessage() {}
+func (*ReplicationRepairStatusResponse_ReplicationRepair) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{35, 0}
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetReplicaPlacement() string {
+	if m != nil {
+		return m.ReplicaPlacement
+	}
+	return ""
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetTtl() string {
+	if m != nil {
+		return m.Ttl
+	}
+	return ""
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetLocations() []string {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetTargets() []string {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetDetectedAt() int64 {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return 0
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetFinishedAt() int64 {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return 0
+}
+
+func (m *ReplicationRepairStatusResponse_ReplicationRepair) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type RaftListClusterServersRequest struct {
+}
+
+func (m *RaftListClusterServersRequest) Reset()                    { *m = RaftListClusterServersRequest{} }
+func (m *RaftListClusterServersRequest) String() string            { return proto.CompactTextString(m) }
+func (*RaftListClusterServersRequest) ProtoMessage()               {}
+func (*RaftListClusterServersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+type RaftListClusterServersResponse struct {
+	ClusterServers []*RaftListClusterServersResponse_ClusterServer `protobuf:"bytes,1,rep,name=cluster_servers,json=clusterServers" json:"cluster_servers,omitempty"`
+}
+
+func (m *RaftListClusterServersResponse) Reset()         { *m = RaftListClusterServersResponse{} }
+func (m *RaftListClusterServersResponse) String() string { return proto.CompactTextString(m) }
+func (*RaftListClusterServersResponse) ProtoMessage()    {}
+func (*RaftListClusterServersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37}
+}
+
+func (m *RaftListClusterServersResponse) GetClusterServers() []*RaftListClusterServersResponse_ClusterServer {
+	if m != nil {
+		return m.ClusterServers
+	}
+	return nil
+}
+
+type RaftListClusterServersResponse_ClusterServer struct {
+	Id       string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Address  string `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Suffrage string `protobuf:"bytes,3,opt,name=suffrage" json:"suffrage,omitempty"`
+	IsLeader bool   `protobuf:"varint,4,opt,name=is_leader,json=isLeader" json:"is_leader,omitempty"`
+}
+
+func (m *RaftListClusterServersResponse_ClusterServer) Reset() {
+	*m = RaftListClusterServersResponse_ClusterServer{}
+}
+func (m *RaftListClusterServersResponse_ClusterServer) String() string {
+	return proto.CompactTextString(m)
+}
+func (*RaftListClusterServersResponse_ClusterServer) ProtoMessage() {}
+func (*RaftListClusterServersResponse_ClusterServer) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37, 0}
+}
+
+func (m *RaftListClusterServersResponse_ClusterServer) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RaftListClusterServersResponse_ClusterServer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RaftListClusterServersResponse_ClusterServer) GetSuffrage() string {
+	if m != nil {
+		return m.Suffrage
+	}
+	return ""
+}
+
+func (m *RaftListClusterServersResponse_ClusterServer) GetIsLeader() bool {
+	if m != nil {
+		return m.IsLeader
+	}
+	return false
+}
+
+type RaftAddServerRequest struct {
+	Id      string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Voter   bool   `protobuf:"varint,3,opt,name=voter" json:"voter,omitempty"`
+}
+
+func (m *RaftAddServerRequest) Reset()                    { *m = RaftAddServerRequest{} }
+func (m *RaftAddServerRequest) String() string            { return proto.CompactTextString(m) }
+func (*RaftAddServerRequest) ProtoMessage()               {}
+func (*RaftAddServerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *RaftAddServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RaftAddServerRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RaftAddServerRequest) GetVoter() bool {
+	if m != nil {
+		return m.Voter
+	}
+	return false
+}
+
+type RaftAddServerResponse struct {
+}
+
+func (m *RaftAddServerResponse) Reset()                    { *m = RaftAddServerResponse{} }
+func (m *RaftAddServerResponse) String() string            { return proto.CompactTextString(m) }
+func (*RaftAddServerResponse) ProtoMessage()               {}
+func (*RaftAddServerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+type RaftRemoveServerRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *RaftRemoveServerRequest) Reset()                    { *m = RaftRemoveServerRequest{} }
+func (m *RaftRemoveServerRequest) String() string            { return proto.CompactTextString(m) }
+func (*RaftRemoveServerRequest) ProtoMessage()               {}
+func (*RaftRemoveServerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *RaftRemoveServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RaftRemoveServerResponse struct {
+}
+
+func (m *RaftRemoveServerResponse) Reset()                    { *m = RaftRemoveServerResponse{} }
+func (m *RaftRemoveServerResponse) String() string            { return proto.CompactTextString(m) }
+func (*RaftRemoveServerResponse) ProtoMessage()               {}
+func (*RaftRemoveServerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func init() {
+	proto.RegisterType((*Heartbeat)(nil), "master_pb.Heartbeat")
+	proto.RegisterType((*HeartbeatResponse)(nil), "master_pb.HeartbeatResponse")
+	proto.RegisterType((*VolumeInformationMessage)(nil), "master_pb.VolumeInformationMessage")
+	proto.RegisterType((*VolumeEcShardInformationMessage)(nil), "master_pb.VolumeEcShardInformationMessage")
+	proto.RegisterType((*Empty)(nil), "master_pb.Empty")
+	proto.RegisterType((*SuperBlockExtra)(nil), "master_pb.SuperBlockExtra")
+	proto.RegisterType((*SuperBlockExtra_ErasureCoding)(nil), "master_pb.SuperBlockExtra.ErasureCoding")
+	proto.RegisterType((*ClientListenRequest)(nil), "master_pb.ClientListenRequest")
+	proto.RegisterType((*VolumeLocation)(nil), "master_pb.VolumeLocation")
+	proto.RegisterType((*LookupVolumeRequest)(nil), "master_pb.LookupVolumeRequest")
+	proto.RegisterType((*LookupVolumeResponse)(nil), "master_pb.LookupVolumeResponse")
+	proto.RegisterType((*LookupVolumeResponse_VolumeIdLocation)(nil), "master_pb.LookupVolumeResponse.VolumeIdLocation")
+	proto.RegisterType((*Location)(nil), "master_pb.Location")
+	proto.RegisterType((*AssignRequest)(nil), "master_pb.AssignRequest")
+	proto.RegisterType((*AssignResponse)(nil), "master_pb.AssignResponse")
+	proto.RegisterType((*StatisticsRequest)(nil), "master_pb.StatisticsRequest")
+	proto.RegisterType((*StatisticsResponse)(nil), "master_pb.StatisticsResponse")
+	proto.RegisterType((*StorageType)(nil), "master_pb.StorageType")
+	proto.RegisterType((*Collection)(nil), "master_pb.Collection")
+	proto.RegisterType((*CollectionListRequest)(nil), "master_pb.CollectionListRequest")
+	proto.RegisterType((*CollectionListResponse)(nil), "master_pb.CollectionListResponse")
+	proto.RegisterType((*CollectionDeleteRequest)(nil), "master_pb.CollectionDeleteRequest")
+	proto.RegisterType((*CollectionDeleteResponse)(nil), "master_pb.CollectionDeleteResponse")
+	proto.RegisterType((*DataNodeInfo)(nil), "master_pb.DataNodeInfo")
+	proto.RegisterType((*RackInfo)(nil), "master_pb.RackInfo")
+	proto.RegisterType((*DataCenterInfo)(nil), "master_pb.DataCenterInfo")
+	proto.RegisterType((*TopologyInfo)(nil), "master_pb.TopologyInfo")
+	proto.RegisterType((*DiskInfo)(nil), "master_pb.DiskInfo")
+	proto.RegisterType((*VolumeListRequest)(nil), "master_pb.VolumeListRequest")
+	proto.RegisterType((*VolumeListResponse)(nil), "master_pb.VolumeListResponse")
+	proto.RegisterType((*LookupEcVolumeRequest)(nil), "master_pb.LookupEcVolumeRequest")
+	proto.RegisterType((*LookupEcVolumeResponse)(nil), "master_pb.LookupEcVolumeResponse")
+	proto.RegisterType((*LookupEcVolumeResponse_EcShardIdLocation)(nil), "master_pb.LookupEcVolumeResponse.EcShardIdLocation")
+	proto.RegisterType((*VolumeServerDrainRequest)(nil), "master_pb.VolumeServerDrainRequest")
+	proto.RegisterType((*VolumeServerDrainResponse)(nil), "master_pb.VolumeServerDrainResponse")
+	proto.RegisterType((*VolumeMoveLocationRequest)(nil), "master_pb.VolumeMoveLocationRequest")
+	proto.RegisterType((*VolumeMoveLocationResponse)(nil), "master_pb.VolumeMoveLocationResponse")
+	proto.RegisterType((*ReplicationRepairStatusRequest)(nil), "master_pb.ReplicationRepairStatusRequest")
+	proto.RegisterType((*ReplicationRepairStatusResponse)(nil), "master_pb.ReplicationRepairStatusResponse")
+	proto.RegisterType((*ReplicationRepairStatusResponse_ReplicationRepair)(nil), "master_pb.ReplicationRepairStatusResponse.ReplicationRepair")
+	proto.RegisterType((*RaftListClusterServersRequest)(nil), "master_pb.RaftListClusterServersRequest")
+	proto.RegisterType((*RaftListClusterServersResponse)(nil), "master_pb.RaftListClusterServersResponse")
+	proto.RegisterType((*RaftListClusterServersResponse_ClusterServer)(nil), "master_pb.RaftListClusterServersResponse.ClusterServer")
+	proto.RegisterType((*RaftAddServerRequest)(nil), "master_pb.RaftAddServerRequest")
+	proto.RegisterType((*RaftAddServerResponse)(nil), "master_pb.RaftAddServerResponse")
+	proto.RegisterType((*RaftRemoveServerRequest)(nil), "master_pb.RaftRemoveServerRequest")
+	proto.RegisterType((*RaftRemoveServerResponse)(nil), "master_pb.RaftRemoveServerResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Seaweed service
+
+type SeaweedClient interface {
+	SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error)
+	KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error)
+	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
+	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
+	Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error)
+	CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error)
+	CollectionDelete(ctx context.Context, in *CollectionDeleteRequest, opts ...grpc.CallOption) (*CollectionDeleteResponse, error)
+	VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error)
+	LookupEcVolume(ctx context.Context, in *LookupEcVolumeRequest, opts ...grpc.CallOption) (*LookupEcVolumeResponse, error)
+	VolumeServerDrain(ctx context.Context, in *VolumeServerDrainRequest, opts ...grpc.CallOption) (*VolumeServerDrainResponse, error)
+	VolumeMoveLocation(ctx context.Context, in *VolumeMoveLocationRequest, opts ...grpc.CallOption) (*VolumeMoveLocationResponse, error)
+	ReplicationRepairStatus(ctx context.Context, in *ReplicationRepairStatusRequest, opts ...grpc.CallOption) (*ReplicationRepairStatusResponse, error)
+	RaftListClusterServers(ctx context.Context, in *RaftListClusterServersRequest, opts ...grpc.CallOption) (*RaftListClusterServersResponse, error)
+	RaftAddServer(ctx context.Context, in *RaftAddServerRequest, opts ...grpc.CallOption) (*RaftAddServerResponse, error)
+	RaftRemoveServer(ctx context.Context, in *RaftRemoveServerRequest, opts ...grpc.CallOption) (*RaftRemoveServerResponse, error)
+}
+
+type seaweedClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSeaweedClient(cc *grpc.ClientConn) SeaweedClient {
+	return &seaweedClient{cc}
+}
+
+func (c *seaweedClient) SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[0], c.cc, "/master_pb.Seaweed/SendHeartbeat", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedSendHeartbeatClient{stream}
+	return x, nil
+}
+
+type Seaweed_SendHeartbeatClient interface {
+	Send(*Heartbeat) error
+	Recv() (*HeartbeatResponse, error)
+	grpc.ClientStream
+}
+
+type seaweedSendHeartbeatClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedSendHeartbeatClient) Send(m *Heartbeat) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *seaweedSendHeartbeatClient) Recv() (*HeartbeatResponse, error) {
+	m := new(HeartbeatResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedClient) KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[1], c.cc, "/master_pb.Seaweed/KeepConnected", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedKeepConnectedClient{stream}
+	return x, nil
+}
+
+type Seaweed_KeepConnectedClient interface {
+	Send(*ClientListenRequest) error
+	Recv() (*VolumeLocation, error)
+	grpc.ClientStream
+}
+
+type seaweedKeepConnectedClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedKeepConnectedClient) Send(m *ClientListenRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *seaweedKeepConnectedClient) Recv() (*VolumeLocation, error) {
+	m := new(VolumeLocation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
+	out := new(LookupVolumeResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/LookupVolume", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
 	return out, nil
@@ -1210,6 +1896,69 @@ func (c *seaweedClient) VolumeList(ctx context.Context, in *VolumeListRequest, o
 	return out, nil
 }
 
+func (c *seaweedClient) LookupEcVolume(ctx context.Context, in *LookupEcVolumeRequest, opts ...grpc.CallOption) (*LookupEcVolumeResponse, error) {
+	out := new(LookupEcVolumeResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/LookupEcVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VolumeServerDrain(ctx context.Context, in *VolumeServerDrainRequest, opts ...grpc.CallOption) (*VolumeServerDrainResponse, error) {
+	out := new(VolumeServerDrainResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VolumeServerDrain", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VolumeMoveLocation(ctx context.Context, in *VolumeMoveLocationRequest, opts ...grpc.CallOption) (*VolumeMoveLocationResponse, error) {
+	out := new(VolumeMoveLocationResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VolumeMoveLocation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) ReplicationRepairStatus(ctx context.Context, in *ReplicationRepairStatusRequest, opts ...grpc.CallOption) (*ReplicationRepairStatusResponse, error) {
+	out := new(ReplicationRepairStatusResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/ReplicationRepairStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) RaftListClusterServers(ctx context.Context, in *RaftListClusterServersRequest, opts ...grpc.CallOption) (*RaftListClusterServersResponse, error) {
+	out := new(RaftListClusterServersResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/RaftListClusterServers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) RaftAddServer(ctx context.Context, in *RaftAddServerRequest, opts ...grpc.CallOption) (*RaftAddServerResponse, error) {
+	out := new(RaftAddServerResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/RaftAddServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) RaftRemoveServer(ctx context.Context, in *RaftRemoveServerRequest, opts ...grpc.CallOption) (*RaftRemoveServerResponse, error) {
+	out := new(RaftRemoveServerResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/RaftRemoveServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Seaweed service
 
 type SeaweedServer interface {
@@ -1221,6 +1970,13 @@ type SeaweedServer interface {
 	CollectionList(context.Context, *CollectionListRequest) (*CollectionListResponse, error)
 	CollectionDelete(context.Context, *CollectionDeleteRequest) (*CollectionDeleteResponse, error)
 	VolumeList(context.Context, *VolumeListRequest) (*VolumeListResponse, error)
+	LookupEcVolume(context.Context, *LookupEcVolumeRequest) (*LookupEcVolumeResponse, error)
+	VolumeServerDrain(context.Context, *VolumeServerDrainRequest) (*VolumeServerDrainResponse, error)
+	VolumeMoveLocation(context.Context, *VolumeMoveLocationRequest) (*VolumeMoveLocationResponse, error)
+	ReplicationRepairStatus(context.Context, *ReplicationRepairStatusRequest) (*ReplicationRepairStatusResponse, error)
+	RaftListClusterServers(context.Context, *RaftListClusterServersRequest) (*RaftListClusterServersResponse, error)
+	RaftAddServer(context.Context, *RaftAddServerRequest) (*RaftAddServerResponse, error)
+	RaftRemoveServer(context.Context, *RaftRemoveServerRequest) (*RaftRemoveServerResponse, error)
 }
 
 func RegisterSeaweedServer(s *grpc.Server, srv SeaweedServer) {
@@ -1387,6 +2143,132 @@ func _Seaweed_VolumeList_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Seaweed_LookupEcVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupEcVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).LookupEcVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/LookupEcVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).LookupEcVolume(ctx, req.(*LookupEcVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VolumeServerDrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeServerDrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VolumeServerDrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VolumeServerDrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VolumeServerDrain(ctx, req.(*VolumeServerDrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VolumeMoveLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMoveLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VolumeMoveLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VolumeMoveLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VolumeMoveLocation(ctx, req.(*VolumeMoveLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_ReplicationRepairStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationRepairStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).ReplicationRepairStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/ReplicationRepairStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).ReplicationRepairStatus(ctx, req.(*ReplicationRepairStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_RaftListClusterServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftListClusterServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).RaftListClusterServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/RaftListClusterServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).RaftListClusterServers(ctx, req.(*RaftListClusterServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_RaftAddServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftAddServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).RaftAddServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/RaftAddServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).RaftAddServer(ctx, req.(*RaftAddServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_RaftRemoveServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftRemoveServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).RaftRemoveServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/RaftRemoveServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).RaftRemoveServer(ctx, req.(*RaftRemoveServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Seaweed_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "master_pb.Seaweed",
 	HandlerType: (*SeaweedServer)(nil),
@@ -1415,6 +2297,34 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeList",
 			Handler:    _Seaweed_VolumeList_Handler,
 		},
+		{
+			MethodName: "LookupEcVolume",
+			Handler:    _Seaweed_LookupEcVolume_Handler,
+		},
+		{
+			MethodName: "VolumeServerDrain",
+			Handler:    _Seaweed_VolumeServerDrain_Handler,
+		},
+		{
+			MethodName: "VolumeMoveLocation",
+			Handler:    _Seaweed_VolumeMoveLocation_Handler,
+		},
+		{
+			MethodName: "ReplicationRepairStatus",
+			Handler:    _Seaweed_ReplicationRepairStatus_Handler,
+		},
+		{
+			MethodName: "RaftListClusterServers",
+			Handler:    _Seaweed_RaftListClusterServers_Handler,
+		},
+		{
+			MethodName: "RaftAddServer",
+			Handler:    _Seaweed_RaftAddServer_Handler,
+		},
+		{
+			MethodName: "RaftRemoveServer",
+			Handler:    _Seaweed_RaftRemoveServer_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1436,94 +2346,151 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("master.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1416 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x6f, 0xdc, 0x44,
-	0x14, 0x8f, 0xbd, 0x1f, 0x59, 0xbf, 0xfd, 0xc8, 0xee, 0x24, 0x6d, 0xdd, 0x2d, 0x6d, 0xb7, 0xee,
-	0x65, 0xcb, 0x47, 0x54, 0xc2, 0x81, 0x03, 0xa0, 0xaa, 0x4d, 0x53, 0x51, 0x35, 0xd0, 0xd6, 0x69,
-	0x8b, 0x84, 0x84, 0xcc, 0xc4, 0x7e, 0x09, 0x56, 0xbc, 0xb6, 0xb1, 0x67, 0xb7, 0xd9, 0x5e, 0xb8,
-	0x70, 0x44, 0x70, 0xe0, 0xff, 0xe1, 0xc2, 0x91, 0x3f, 0x85, 0x03, 0x12, 0x77, 0x24, 0x34, 0xe3,
-	0xb1, 0x77, 0xec, 0xdd, 0xa4, 0x15, 0x12, 0x87, 0xde, 0x3c, 0xbf, 0xf7, 0x66, 0xe6, 0xcd, 0xef,
-	0x7d, 0xee, 0x42, 0x67, 0x42, 0x53, 0x86, 0xc9, 0x76, 0x9c, 0x44, 0x2c, 0x22, 0x46, 0xb6, 0x72,
-	0xe2, 0x43, 0xeb, 0x4f, 0x1d, 0x8c, 0xcf, 0x91, 0x26, 0xec, 0x10, 0x29, 0x23, 0x3d, 0xd0, 0xfd,
-	0xd8, 0xd4, 0x46, 0xda, 0xd8, 0xb0, 0x75, 0x3f, 0x26, 0x04, 0xea, 0x71, 0x94, 0x30, 0x53, 0x1f,
-	0x69, 0xe3, 0xae, 0x2d, 0xbe, 0xc9, 0x55, 0x80, 0x78, 0x7a, 0x18, 0xf8, 0xae, 0x33, 0x4d, 0x02,
-	0xb3, 0x26, 0x74, 0x8d, 0x0c, 0x79, 0x9e, 0x04, 0x64, 0x0c, 0xfd, 0x09, 0x3d, 0x75, 0x66, 0x51,
-	0x30, 0x9d, 0xa0, 0xe3, 0x46, 0xd3, 0x90, 0x99, 0x75, 0xb1, 0xbd, 0x37, 0xa1, 0xa7, 0x2f, 0x04,
-	0xbc, 0xcb, 0x51, 0x32, 0xe2, 0x56, 0x9d, 0x3a, 0x47, 0x7e, 0x80, 0xce, 0x09, 0xce, 0xcd, 0xc6,
-	0x48, 0x1b, 0xd7, 0x6d, 0x98, 0xd0, 0xd3, 0x07, 0x7e, 0x80, 0x8f, 0x70, 0x4e, 0xae, 0x43, 0xdb,
-	0xa3, 0x8c, 0x3a, 0x2e, 0x86, 0x0c, 0x13, 0xb3, 0x29, 0xee, 0x02, 0x0e, 0xed, 0x0a, 0x84, 0xdb,
-	0x97, 0x50, 0xf7, 0xc4, 0x5c, 0x17, 0x12, 0xf1, 0xcd, 0xed, 0xa3, 0xde, 0xc4, 0x0f, 0x1d, 0x61,
-	0x79, 0x4b, 0x5c, 0x6d, 0x08, 0xe4, 0x09, 0x37, 0xff, 0x33, 0x58, 0xcf, 0x6c, 0x4b, 0x4d, 0x63,
-	0x54, 0x1b, 0xb7, 0x77, 0x6e, 0x6e, 0x17, 0x6c, 0x6c, 0x67, 0xe6, 0x3d, 0x0c, 0x8f, 0xa2, 0x64,
-	0x42, 0x99, 0x1f, 0x85, 0x5f, 0x60, 0x9a, 0xd2, 0x63, 0xb4, 0xf3, 0x3d, 0xe4, 0x32, 0xb4, 0x42,
-	0x7c, 0xe9, 0xcc, 0x7c, 0x2f, 0x35, 0x61, 0x54, 0x1b, 0x77, 0xed, 0xf5, 0x10, 0x5f, 0xbe, 0xf0,
-	0xbd, 0x94, 0xdc, 0x80, 0x8e, 0x87, 0x01, 0x32, 0xf4, 0x32, 0x71, 0x5b, 0x88, 0xdb, 0x12, 0xe3,
-	0x2a, 0xd6, 0x73, 0x18, 0x14, 0x64, 0xdb, 0x98, 0xc6, 0x51, 0x98, 0x22, 0x19, 0xc3, 0x46, 0x76,
-	0xfa, 0x81, 0xff, 0x0a, 0xf7, 0xfd, 0x89, 0xcf, 0x84, 0x07, 0xea, 0x76, 0x15, 0x26, 0x17, 0xa1,
-	0x19, 0x20, 0xf5, 0x30, 0x91, 0xb4, 0xcb, 0x95, 0xf5, 0x97, 0x0e, 0xe6, 0x59, 0xa6, 0x0b, 0x9f,
-	0x7a, 0xe2, 0xc4, 0xae, 0xad, 0xfb, 0x1e, 0xe7, 0x2c, 0xf5, 0x5f, 0xa1, 0xf0, 0x69, 0xdd, 0x16,
-	0xdf, 0xe4, 0x1a, 0x80, 0x1b, 0x05, 0x01, 0xba, 0x7c, 0xa3, 0x3c, 0x5c, 0x41, 0x38, 0xa7, 0xc2,
-	0x4d, 0x0b, 0x77, 0xd6, 0x6d, 0x83, 0x23, 0x99, 0x27, 0x8b, 0x97, 0x4b, 0x85, 0xcc, 0x93, 0xf2,
-	0xe5, 0x99, 0xca, 0xfb, 0x40, 0x72, 0x72, 0x0e, 0xe7, 0x85, 0x62, 0x53, 0x28, 0xf6, 0xa5, 0xe4,
-	0xde, 0x3c, 0xd7, 0xbe, 0x02, 0x46, 0x82, 0xd4, 0x73, 0xa2, 0x30, 0x98, 0x0b, 0xe7, 0xb6, 0xec,
-	0x16, 0x07, 0x1e, 0x87, 0xc1, 0x9c, 0xbc, 0x07, 0x83, 0x04, 0xe3, 0xc0, 0x77, 0xa9, 0x13, 0x07,
-	0xd4, 0xc5, 0x09, 0x86, 0xb9, 0x9f, 0xfb, 0x52, 0xf0, 0x24, 0xc7, 0x89, 0x09, 0xeb, 0x33, 0x4c,
-	0x52, 0xfe, 0x2c, 0x43, 0xa8, 0xe4, 0x4b, 0xd2, 0x87, 0x1a, 0x63, 0x81, 0x09, 0x02, 0xe5, 0x9f,
-	0xe4, 0x16, 0xf4, 0xdd, 0x68, 0x12, 0x53, 0x97, 0x39, 0x09, 0xce, 0x7c, 0xb1, 0xa9, 0x2d, 0xc4,
-	0x1b, 0x12, 0xb7, 0x25, 0x6c, 0xad, 0x43, 0x63, 0x6f, 0x12, 0xb3, 0xb9, 0xf5, 0x9b, 0x06, 0x1b,
-	0x07, 0xd3, 0x18, 0x93, 0x7b, 0x41, 0xe4, 0x9e, 0xec, 0x9d, 0xb2, 0x84, 0x92, 0xc7, 0xd0, 0xc3,
-	0x84, 0xa6, 0xd3, 0x84, 0x3f, 0xd3, 0xf3, 0xc3, 0x63, 0xc1, 0x7e, 0x7b, 0x67, 0xac, 0x44, 0x5a,
-	0x65, 0xcf, 0xf6, 0x5e, 0xb6, 0x61, 0x57, 0xe8, 0xdb, 0x5d, 0x54, 0x97, 0xc3, 0xaf, 0xa1, 0x5b,
-	0x92, 0x73, 0x1f, 0xf2, 0x2c, 0x90, 0x5e, 0x15, 0xdf, 0x3c, 0x38, 0x62, 0x9a, 0xf8, 0x6c, 0x2e,
-	0xb3, 0x55, 0xae, 0xb8, 0xef, 0x64, 0x32, 0xf2, 0xa0, 0xac, 0x89, 0xa0, 0x34, 0x32, 0xe4, 0xa1,
-	0x97, 0x5a, 0xb7, 0x60, 0x73, 0x37, 0xf0, 0x31, 0x64, 0xfb, 0x7e, 0xca, 0x30, 0xb4, 0xf1, 0xfb,
-	0x29, 0xa6, 0x8c, 0xdf, 0x10, 0xd2, 0x09, 0xca, 0x5a, 0x20, 0xbe, 0xad, 0x1f, 0xa0, 0x97, 0x45,
-	0xd9, 0x7e, 0xe4, 0x8a, 0x10, 0xe3, 0x1c, 0xf2, 0x22, 0x90, 0x29, 0xf1, 0xcf, 0x4a, 0x75, 0xd0,
-	0xab, 0xd5, 0x41, 0x4d, 0x9f, 0xda, 0xf9, 0xe9, 0x53, 0x5f, 0x4e, 0x9f, 0x67, 0xb0, 0xb9, 0x1f,
-	0x45, 0x27, 0xd3, 0x38, 0x33, 0x23, 0xb7, 0xb5, 0xfc, 0x42, 0x6d, 0x54, 0xe3, 0x77, 0x16, 0x2f,
-	0xac, 0x04, 0xb7, 0x5e, 0x0d, 0x6e, 0xeb, 0x6f, 0x0d, 0xb6, 0xca, 0xc7, 0xca, 0xc4, 0xfc, 0x16,
-	0x36, 0x8b, 0x73, 0x9d, 0x40, 0xbe, 0x39, 0xbb, 0xa0, 0xbd, 0x73, 0x5b, 0x71, 0xe6, 0xaa, 0xdd,
-	0x79, 0x2d, 0xf1, 0x72, 0xb2, 0xec, 0xc1, 0xac, 0x82, 0xa4, 0xc3, 0x53, 0xe8, 0x57, 0xd5, 0x78,
-	0xec, 0x17, 0xb7, 0x4a, 0x66, 0x5b, 0xf9, 0x4e, 0xf2, 0x21, 0x18, 0x0b, 0x43, 0x74, 0x61, 0xc8,
-	0x66, 0xc9, 0x10, 0x79, 0xd7, 0x42, 0x8b, 0x6c, 0x41, 0x03, 0x93, 0x24, 0xca, 0x6b, 0x46, 0xb6,
-	0xb0, 0x3e, 0x81, 0xd6, 0x7f, 0xf6, 0xa2, 0xf5, 0x87, 0x06, 0xdd, 0xbb, 0x69, 0xea, 0x1f, 0x17,
-	0xe1, 0xb2, 0x05, 0x8d, 0x2c, 0xa3, 0xb3, 0xca, 0x95, 0x2d, 0xc8, 0x08, 0xda, 0x32, 0x21, 0x15,
-	0xea, 0x55, 0xe8, 0xb5, 0x85, 0x47, 0x26, 0x69, 0x3d, 0x33, 0x8d, 0x27, 0x69, 0xa5, 0x27, 0x34,
-	0xce, 0xec, 0x09, 0x4d, 0xa5, 0x27, 0x5c, 0x01, 0x43, 0x6c, 0x0a, 0x23, 0x0f, 0x65, 0xb3, 0x68,
-	0x71, 0xe0, 0xcb, 0xc8, 0x43, 0xeb, 0x57, 0x0d, 0x7a, 0xf9, 0x6b, 0xa4, 0xe7, 0xfb, 0x50, 0x3b,
-	0x2a, 0xd8, 0xe7, 0x9f, 0x39, 0x47, 0xfa, 0x59, 0x1c, 0x2d, 0xf5, 0xc1, 0x82, 0x91, 0xba, 0xca,
-	0x48, 0xe1, 0x8c, 0x86, 0xe2, 0x0c, 0x6e, 0x32, 0x9d, 0xb2, 0xef, 0x72, 0x93, 0xf9, 0xb7, 0x75,
-	0x0c, 0x83, 0x03, 0x46, 0x99, 0x9f, 0x32, 0xdf, 0x4d, 0x73, 0x9a, 0x2b, 0x84, 0x6a, 0xaf, 0x23,
-	0x54, 0x3f, 0x8b, 0xd0, 0x5a, 0x41, 0xa8, 0xf5, 0xbb, 0x06, 0x44, 0xbd, 0x49, 0x52, 0xf0, 0x3f,
-	0x5c, 0xc5, 0x29, 0x63, 0x11, 0xa3, 0x81, 0x23, 0x1a, 0x90, 0x6c, 0x23, 0x02, 0xe1, 0x3d, 0x8e,
-	0x7b, 0x69, 0x9a, 0xa2, 0x97, 0x49, 0xb3, 0x1e, 0xd2, 0xe2, 0x80, 0x10, 0x96, 0x5b, 0x50, 0xb3,
-	0xd2, 0x82, 0xac, 0xbb, 0xd0, 0x3e, 0x60, 0x51, 0x42, 0x8f, 0xf1, 0xd9, 0x3c, 0x7e, 0x13, 0xeb,
-	0xa5, 0x75, 0xfa, 0x82, 0x88, 0x11, 0xc0, 0xee, 0xc2, 0xfa, 0x55, 0x05, 0xf0, 0x12, 0x5c, 0x58,
-	0x68, 0xf0, 0x7a, 0x29, 0xfd, 0x62, 0x3d, 0x85, 0x8b, 0x55, 0x81, 0xa4, 0xf1, 0x63, 0x68, 0x2f,
-	0x28, 0xc9, 0x6b, 0xc7, 0x05, 0x25, 0x65, 0x17, 0xfb, 0x6c, 0x55, 0xd3, 0xfa, 0x00, 0x2e, 0x2d,
-	0x44, 0xf7, 0x45, 0x11, 0x3c, 0xaf, 0x36, 0x0f, 0xc1, 0x5c, 0x56, 0xcf, 0x6c, 0xb0, 0x7e, 0xd1,
-	0xa1, 0x73, 0x5f, 0x46, 0x3b, 0x1f, 0x10, 0x94, 0x91, 0xc0, 0x10, 0x23, 0xc1, 0x0d, 0xe8, 0x94,
-	0xe6, 0xb5, 0x6c, 0x34, 0x68, 0xcf, 0x94, 0x61, 0x6d, 0xd5, 0x58, 0x57, 0x13, 0x6a, 0xd5, 0xb1,
-	0xee, 0x5d, 0x18, 0x1c, 0x25, 0x88, 0xcb, 0x13, 0x60, 0xdd, 0xde, 0xe0, 0x02, 0x55, 0x77, 0x1b,
-	0x36, 0xa9, 0xcb, 0xfc, 0x59, 0x45, 0x3b, 0xf3, 0xfd, 0x20, 0x13, 0xa9, 0xfa, 0x0f, 0x0a, 0x43,
-	0xfd, 0xf0, 0x28, 0x4a, 0xcd, 0xe6, 0x9b, 0x4f, 0x70, 0xf2, 0x35, 0x5c, 0x92, 0x5a, 0x3f, 0xea,
-	0xd0, 0xb2, 0xa9, 0x7b, 0xf2, 0x76, 0xb3, 0x71, 0x07, 0x36, 0x8a, 0xaa, 0x56, 0x22, 0xe4, 0x92,
-	0x42, 0x88, 0xea, 0x78, 0xbb, 0xeb, 0x29, 0xab, 0xd4, 0xfa, 0x47, 0x83, 0xde, 0xfd, 0xa2, 0x72,
-	0xbe, 0xdd, 0x64, 0xec, 0x00, 0xf0, 0x52, 0x5f, 0xe2, 0x41, 0x6d, 0x8d, 0xb9, 0xbb, 0x6d, 0x23,
-	0x91, 0x5f, 0xa9, 0xf5, 0xb3, 0x0e, 0x9d, 0x67, 0x51, 0x1c, 0x05, 0xd1, 0xf1, 0xfc, 0xed, 0x7e,
-	0xfd, 0x1e, 0x0c, 0x94, 0xae, 0x58, 0x22, 0xe1, 0x72, 0x25, 0x18, 0x16, 0xce, 0xb6, 0x37, 0xbc,
-	0xd2, 0x3a, 0xb5, 0x36, 0x61, 0x20, 0x27, 0x3c, 0xa5, 0xb8, 0xd9, 0x40, 0x54, 0x50, 0x16, 0xb6,
-	0x4f, 0xa1, 0xcb, 0x24, 0x75, 0xe2, 0x3a, 0x39, 0xe3, 0xaa, 0xa1, 0xa7, 0x52, 0x6b, 0x77, 0x98,
-	0xb2, 0xda, 0xf9, 0xa9, 0x01, 0xeb, 0x07, 0x48, 0x5f, 0x22, 0x7a, 0xe4, 0x21, 0x74, 0x0f, 0x30,
-	0xf4, 0x16, 0xbf, 0x42, 0xb7, 0x94, 0x33, 0x0a, 0x74, 0xf8, 0xce, 0x2a, 0xb4, 0xa8, 0x71, 0x6b,
-	0x63, 0xed, 0xb6, 0x46, 0x9e, 0x40, 0xf7, 0x11, 0x62, 0xbc, 0x1b, 0x85, 0x21, 0xba, 0x0c, 0x3d,
-	0x72, 0x4d, 0xad, 0xb4, 0xcb, 0x63, 0xee, 0xf0, 0xf2, 0x52, 0xe9, 0xc8, 0xa7, 0x22, 0x79, 0xe2,
-	0x53, 0xe8, 0xa8, 0xd3, 0x5d, 0xe9, 0xc0, 0x15, 0xb3, 0xe8, 0xf0, 0xfa, 0x6b, 0xc6, 0x42, 0x6b,
-	0x8d, 0xdc, 0x81, 0x66, 0x36, 0x6e, 0x10, 0x53, 0x51, 0x2e, 0xcd, 0x53, 0x25, 0xbb, 0xca, 0xb3,
-	0x89, 0xb5, 0x46, 0x1e, 0x01, 0x2c, 0x1a, 0x36, 0x51, 0x79, 0x59, 0x9a, 0x18, 0x86, 0x57, 0xcf,
-	0x90, 0x16, 0x87, 0x7d, 0x05, 0xbd, 0x72, 0xeb, 0x22, 0xa3, 0x95, 0xdd, 0x49, 0x89, 0x88, 0xe1,
-	0x8d, 0x73, 0x34, 0x8a, 0x83, 0xbf, 0x81, 0x7e, 0xb5, 0x23, 0x11, 0x6b, 0xe5, 0xc6, 0x52, 0x77,
-	0x1b, 0xde, 0x3c, 0x57, 0x47, 0x25, 0x61, 0x11, 0x95, 0x25, 0x12, 0x96, 0x22, 0xb8, 0x44, 0xc2,
-	0x72, 0x28, 0x5b, 0x6b, 0x87, 0x4d, 0xf1, 0xbf, 0xc8, 0x47, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff,
-	0x88, 0xac, 0x76, 0x9c, 0x27, 0x11, 0x00, 0x00,
+	// 2326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x39, 0xcd, 0x6f, 0x1c, 0x49,
+	0xf5, 0x99, 0x0f, 0x7b, 0x66, 0xde, 0x7c, 0x97, 0x9d, 0x64, 0xd2, 0xf9, 0xf0, 0xa4, 0xf3, 0xfb,
+	0xa1, 0x71, 0x00, 0x6b, 0xf1, 0x22, 0x2d, 0x82, 0x45, 0x2b, 0xc7, 0xf6, 0x42, 0x88, 0x93, 0xcd,
+	0xb6, 0x13, 0x23, 0x21, 0xa1, 0xde, 0x72, 0x77, 0xd9, 0x69, 0xb9, 0xa7, 0x7b, 0xe8, 0xaa, 0x99,
+	0x78, 0xf6, 0xcc, 0x7f, 0xc0, 0x61, 0xff, 0x0e, 0x2e, 0x88, 0x13, 0x07, 0x90, 0xf8, 0x5b, 0x10,
+	0x12, 0x07, 0x38, 0xc0, 0x81, 0x0b, 0xaa, 0x8f, 0xee, 0xae, 0xee, 0x9e, 0x19, 0x3b, 0x91, 0x10,
+	0xca, 0xad, 0xeb, 0xbd, 0x57, 0xaf, 0x5e, 0xbd, 0xef, 0x57, 0x0d, 0xad, 0x31, 0xa6, 0x8c, 0x44,
+	0x3b, 0x93, 0x28, 0x64, 0x21, 0x6a, 0xc8, 0x95, 0x3d, 0x39, 0x35, 0xff, 0xb5, 0x06, 0x8d, 0x9f,
+	0x12, 0x1c, 0xb1, 0x53, 0x82, 0x19, 0xea, 0x40, 0xd9, 0x9b, 0x0c, 0x4a, 0xc3, 0xd2, 0xa8, 0x61,
+	0x95, 0xbd, 0x09, 0x42, 0x50, 0x9d, 0x84, 0x11, 0x1b, 0x94, 0x87, 0xa5, 0x51, 0xdb, 0x12, 0xdf,
+	0xe8, 0x3e, 0xc0, 0x64, 0x7a, 0xea, 0x7b, 0x8e, 0x3d, 0x8d, 0xfc, 0x41, 0x45, 0xd0, 0x36, 0x24,
+	0xe4, 0x75, 0xe4, 0xa3, 0x11, 0xf4, 0xc6, 0xf8, 0xd2, 0x9e, 0x85, 0xfe, 0x74, 0x4c, 0x6c, 0x27,
+	0x9c, 0x06, 0x6c, 0x50, 0x15, 0xdb, 0x3b, 0x63, 0x7c, 0x79, 0x22, 0xc0, 0xfb, 0x1c, 0x8a, 0x86,
+	0x5c, 0xaa, 0x4b, 0xfb, 0xcc, 0xf3, 0x89, 0x7d, 0x41, 0xe6, 0x83, 0xb5, 0x61, 0x69, 0x54, 0xb5,
+	0x60, 0x8c, 0x2f, 0x3f, 0xf7, 0x7c, 0xf2, 0x8c, 0xcc, 0xd1, 0x16, 0x34, 0x5d, 0xcc, 0xb0, 0xed,
+	0x90, 0x80, 0x91, 0x68, 0xb0, 0x2e, 0xce, 0x02, 0x0e, 0xda, 0x17, 0x10, 0x2e, 0x5f, 0x84, 0x9d,
+	0x8b, 0x41, 0x4d, 0x60, 0xc4, 0x37, 0x97, 0x0f, 0xbb, 0x63, 0x2f, 0xb0, 0x85, 0xe4, 0x75, 0x71,
+	0x74, 0x43, 0x40, 0x5e, 0x72, 0xf1, 0x7f, 0x0c, 0x35, 0x29, 0x1b, 0x1d, 0x34, 0x86, 0x95, 0x51,
+	0x73, 0xf7, 0xd1, 0x4e, 0xa2, 0x8d, 0x1d, 0x29, 0xde, 0xd3, 0xe0, 0x2c, 0x8c, 0xc6, 0x98, 0x79,
+	0x61, 0xf0, 0x9c, 0x50, 0x8a, 0xcf, 0x89, 0x15, 0xef, 0x41, 0x77, 0xa0, 0x1e, 0x90, 0xb7, 0xf6,
+	0xcc, 0x73, 0xe9, 0x00, 0x86, 0x95, 0x51, 0xdb, 0xaa, 0x05, 0xe4, 0xed, 0x89, 0xe7, 0x52, 0xf4,
+	0x10, 0x5a, 0x2e, 0xf1, 0x09, 0x23, 0xae, 0x44, 0x37, 0x05, 0xba, 0xa9, 0x60, 0x82, 0xe4, 0x27,
+	0xd0, 0x20, 0x8e, 0x4d, 0xdf, 0xe0, 0xc8, 0xa5, 0x83, 0x96, 0x38, 0xfe, 0x71, 0xe1, 0xf8, 0x43,
+	0xe7, 0x98, 0x13, 0x2c, 0x90, 0xa2, 0x4e, 0x24, 0x8a, 0xa2, 0x17, 0xd0, 0xe6, 0x62, 0xa4, 0xcc,
+	0xda, 0xef, 0xcc, 0xac, 0x19, 0x90, 0xb7, 0x87, 0x31, 0xbf, 0x13, 0xe8, 0xc7, 0xb2, 0xa7, 0x3c,
+	0x3b, 0xef, 0xcc, 0xb3, 0xab, 0x98, 0x24, 0x7c, 0x5f, 0x43, 0x3f, 0xef, 0x0d, 0x74, 0xd0, 0x15,
+	0x7c, 0xb7, 0x35, 0xbe, 0x89, 0x07, 0xee, 0x3c, 0xcf, 0xf8, 0x08, 0x3d, 0x0c, 0x58, 0x34, 0xb7,
+	0xba, 0x59, 0xcf, 0xa1, 0xc6, 0x13, 0xd8, 0x5c, 0x44, 0x88, 0x7a, 0x50, 0xe1, 0x9e, 0x24, 0x1d,
+	0x98, 0x7f, 0xa2, 0x4d, 0x58, 0x9b, 0x61, 0x7f, 0x4a, 0x94, 0x0b, 0xcb, 0xc5, 0x0f, 0xcb, 0x3f,
+	0x28, 0x99, 0xaf, 0xa1, 0x9f, 0x1c, 0x6b, 0x11, 0x3a, 0x09, 0x03, 0x4a, 0xd0, 0x08, 0xba, 0x52,
+	0xd6, 0x63, 0xef, 0x6b, 0x72, 0xe4, 0x8d, 0x3d, 0x26, 0x98, 0x55, 0xad, 0x3c, 0x18, 0xdd, 0x82,
+	0x75, 0x9f, 0x60, 0x97, 0x44, 0x2a, 0x04, 0xd4, 0xca, 0xfc, 0x6b, 0x05, 0x06, 0xcb, 0xdc, 0x48,
+	0xc4, 0x97, 0x2b, 0x38, 0xb6, 0xad, 0xb2, 0xe7, 0x72, 0xff, 0xa5, 0xde, 0xd7, 0x52, 0xb8, 0xaa,
+	0x25, 0xbe, 0xd1, 0x03, 0x00, 0x27, 0xf4, 0x7d, 0xe2, 0xf0, 0x8d, 0x8a, 0xb9, 0x06, 0xe1, 0xfe,
+	0x2d, 0x42, 0x26, 0x0d, 0xad, 0xaa, 0xd5, 0xe0, 0x10, 0x19, 0x55, 0x89, 0x17, 0x2a, 0x02, 0x19,
+	0x55, 0xca, 0x0b, 0x25, 0xc9, 0x77, 0x00, 0xc5, 0xc6, 0x3e, 0x9d, 0x27, 0x84, 0xeb, 0x82, 0xb0,
+	0xa7, 0x30, 0x4f, 0xe6, 0x31, 0xf5, 0x5d, 0x68, 0x44, 0x04, 0xbb, 0x76, 0x18, 0xf8, 0x73, 0x11,
+	0x68, 0x75, 0xab, 0xce, 0x01, 0x5f, 0x04, 0xfe, 0x1c, 0x7d, 0x1b, 0xfa, 0x11, 0x99, 0xf8, 0x9e,
+	0x83, 0xed, 0x89, 0x8f, 0x1d, 0x32, 0x26, 0x41, 0x1c, 0x73, 0x3d, 0x85, 0x78, 0x19, 0xc3, 0xd1,
+	0x00, 0x6a, 0x33, 0x12, 0x51, 0x7e, 0xad, 0x86, 0x20, 0x89, 0x97, 0xdc, 0x6e, 0x8c, 0xf9, 0x03,
+	0x10, 0x50, 0xfe, 0x89, 0xb6, 0xa1, 0xe7, 0x84, 0xe3, 0x09, 0x76, 0x98, 0x1d, 0x91, 0x99, 0x27,
+	0x36, 0x35, 0x05, 0xba, 0xab, 0xe0, 0x96, 0x02, 0xa3, 0x1d, 0xd8, 0x88, 0xc8, 0x38, 0x64, 0xc4,
+	0xa6, 0x2c, 0x8c, 0xf0, 0x39, 0xb1, 0x03, 0x3c, 0x26, 0x83, 0x96, 0xd0, 0x5c, 0x5f, 0xa2, 0x8e,
+	0x25, 0xe6, 0x05, 0x1e, 0x13, 0x7e, 0xfd, 0x1c, 0x3d, 0xf7, 0x99, 0xb6, 0x20, 0xef, 0x65, 0xc8,
+	0x79, 0x0e, 0xba, 0x0b, 0x0d, 0xd7, 0xa3, 0x17, 0x36, 0x9b, 0x4f, 0xc8, 0xa0, 0x23, 0x88, 0xea,
+	0x1c, 0xf0, 0x6a, 0x3e, 0x21, 0xe6, 0x14, 0xb6, 0xae, 0x08, 0x89, 0x82, 0xc9, 0xb3, 0xe6, 0x2d,
+	0x17, 0xcc, 0x6b, 0x42, 0x9b, 0x38, 0xb6, 0x17, 0xb8, 0xe4, 0xd2, 0x3e, 0xf5, 0x18, 0x15, 0x1e,
+	0xd0, 0xb6, 0x9a, 0xc4, 0x79, 0xca, 0x61, 0x4f, 0x3c, 0x46, 0xcd, 0x1a, 0xac, 0x1d, 0x8e, 0x27,
+	0x6c, 0x6e, 0xfe, 0xa1, 0x04, 0xdd, 0xe3, 0xe9, 0x84, 0x44, 0x4f, 0xfc, 0xd0, 0xb9, 0x38, 0xbc,
+	0x64, 0x11, 0x46, 0x5f, 0x40, 0x87, 0x44, 0x98, 0x4e, 0x23, 0x6e, 0x58, 0xd7, 0x0b, 0xce, 0xc5,
+	0xe1, 0xcd, 0xdd, 0x91, 0x16, 0x6f, 0xb9, 0x3d, 0x3b, 0x87, 0x72, 0xc3, 0xbe, 0xa0, 0xb7, 0xda,
+	0x44, 0x5f, 0x1a, 0xbf, 0x80, 0x76, 0x06, 0xcf, 0xbd, 0x96, 0xe7, 0x60, 0x75, 0x29, 0xf1, 0xcd,
+	0xc3, 0x61, 0x82, 0x23, 0x8f, 0xcd, 0x55, 0xa0, 0xa9, 0x15, 0xf7, 0x56, 0x15, 0xfc, 0x3c, 0x25,
+	0x56, 0x44, 0x4a, 0x6c, 0x48, 0xc8, 0x53, 0x97, 0x9a, 0xdb, 0xb0, 0xb1, 0xef, 0x7b, 0x24, 0x60,
+	0x47, 0x1e, 0x65, 0x24, 0xb0, 0xc8, 0xaf, 0xa6, 0x84, 0x32, 0x7e, 0x82, 0xb0, 0xa1, 0x0c, 0x64,
+	0xf1, 0x6d, 0xfe, 0xa5, 0x04, 0x1d, 0xa9, 0xec, 0xa3, 0xd0, 0xc1, 0x4c, 0xb9, 0x0d, 0xaf, 0x41,
+	0x2a, 0xdc, 0xa7, 0x91, 0x9f, 0x2b, 0x4e, 0xe5, 0x7c, 0x71, 0xd2, 0xb3, 0x77, 0x65, 0x75, 0xf6,
+	0xae, 0x16, 0xb3, 0xf7, 0x03, 0x68, 0xaa, 0xa4, 0x2b, 0x28, 0xd6, 0xe4, 0x65, 0x44, 0x1a, 0x15,
+	0xf8, 0x6f, 0x41, 0x57, 0x4b, 0xa2, 0x82, 0x66, 0x5d, 0xd0, 0xb4, 0x93, 0xb4, 0x28, 0xe8, 0x72,
+	0x65, 0xad, 0x96, 0x2f, 0x6b, 0xe6, 0x2b, 0xd8, 0x38, 0x0a, 0xc3, 0x8b, 0xe9, 0x44, 0xde, 0x37,
+	0xd6, 0x4a, 0x56, 0x97, 0xa5, 0x61, 0x85, 0x5f, 0x2e, 0xd1, 0xe5, 0x55, 0x9e, 0x65, 0xfe, 0xa3,
+	0x04, 0x9b, 0x59, 0xb6, 0x2a, 0xe9, 0x7d, 0x05, 0x1b, 0x09, 0x5f, 0xdb, 0x57, 0xca, 0x95, 0x07,
+	0x34, 0x77, 0x3f, 0xd2, 0xdc, 0x66, 0xd1, 0xee, 0xb8, 0x66, 0xba, 0xb1, 0x55, 0xac, 0xfe, 0x2c,
+	0x07, 0xa1, 0xc6, 0x25, 0xf4, 0xf2, 0x64, 0x3c, 0xb0, 0x92, 0x53, 0x95, 0x09, 0xeb, 0xf1, 0x4e,
+	0xf4, 0x3d, 0x68, 0xa4, 0x82, 0x94, 0x85, 0x20, 0x1b, 0x19, 0x41, 0xd4, 0x59, 0x29, 0x15, 0xcf,
+	0xf4, 0x24, 0x8a, 0xc2, 0x38, 0x1f, 0xcb, 0x85, 0xf9, 0x23, 0xa8, 0xbf, 0xb7, 0xbb, 0x70, 0x97,
+	0x6b, 0xef, 0x51, 0xea, 0x9d, 0x27, 0x8e, 0xb9, 0x09, 0x6b, 0x32, 0x5b, 0xca, 0xaa, 0x20, 0x17,
+	0x68, 0x08, 0x4d, 0x95, 0xec, 0x34, 0xd5, 0xeb, 0xa0, 0x2b, 0x93, 0xba, 0x4a, 0x80, 0x55, 0x29,
+	0x1a, 0x4f, 0x80, 0x39, 0x27, 0x59, 0x5b, 0xda, 0xfb, 0xac, 0x6b, 0xbd, 0x0f, 0x4f, 0x56, 0x7c,
+	0x53, 0x10, 0xba, 0x44, 0xf9, 0x55, 0x9d, 0x03, 0x5e, 0x84, 0x2e, 0xc9, 0x66, 0xb2, 0x7a, 0x2e,
+	0x93, 0xfd, 0xa6, 0x04, 0x9d, 0xf8, 0xaa, 0xca, 0x2d, 0x7a, 0x50, 0x39, 0x4b, 0x4c, 0xc3, 0x3f,
+	0x63, 0x05, 0x96, 0x97, 0x29, 0xb0, 0xd0, 0x0c, 0x26, 0xea, 0xaa, 0xea, 0xea, 0x4a, 0x2c, 0xb5,
+	0xa6, 0x59, 0x8a, 0xdf, 0x07, 0x4f, 0xd9, 0x9b, 0xf8, 0x3e, 0xfc, 0xdb, 0xfc, 0x75, 0x09, 0xfa,
+	0xc7, 0x0c, 0x33, 0x8f, 0x32, 0xcf, 0xa1, 0xb1, 0x11, 0x72, 0xea, 0x2e, 0x5d, 0xa5, 0xee, 0xf2,
+	0x32, 0x75, 0x57, 0x52, 0x75, 0x67, 0x94, 0x53, 0xcd, 0x29, 0xe7, 0x4f, 0x25, 0x40, 0xba, 0x18,
+	0x4a, 0x41, 0xff, 0x0d, 0x39, 0xee, 0x03, 0xb0, 0x90, 0x61, 0xdf, 0x16, 0x7d, 0x81, 0xaa, 0xee,
+	0x02, 0xc2, 0x5b, 0x0f, 0x2e, 0xe6, 0x94, 0x12, 0x57, 0x62, 0x65, 0x69, 0xaf, 0x73, 0x80, 0x40,
+	0x66, 0x3b, 0x83, 0xf5, 0x5c, 0x67, 0x60, 0xee, 0x41, 0x53, 0xd5, 0x35, 0x7e, 0xa9, 0x6b, 0x48,
+	0xaf, 0xa4, 0x2b, 0x27, 0xd2, 0x99, 0x43, 0x80, 0xfd, 0x54, 0xfa, 0x45, 0x59, 0xfa, 0x36, 0xdc,
+	0x4c, 0x29, 0x78, 0x52, 0x57, 0x46, 0x33, 0xbf, 0x84, 0x5b, 0x79, 0x84, 0x52, 0xe3, 0x27, 0xd0,
+	0x4c, 0x55, 0x12, 0xa7, 0x9d, 0x9b, 0x5a, 0xb4, 0xa7, 0xfb, 0x2c, 0x9d, 0xd2, 0xfc, 0x2e, 0xdc,
+	0x4e, 0x51, 0x07, 0x22, 0xc5, 0xae, 0x2a, 0x20, 0x06, 0x0c, 0x8a, 0xe4, 0x52, 0x06, 0xf3, 0x77,
+	0x15, 0x68, 0x1d, 0xa8, 0x40, 0xe1, 0x45, 0x5c, 0x2b, 0xdb, 0x0d, 0x51, 0xb6, 0x1f, 0x42, 0x2b,
+	0x33, 0xd2, 0xc8, 0x8e, 0xad, 0x39, 0xd3, 0xe6, 0x99, 0x45, 0x93, 0x4f, 0x45, 0x90, 0xe5, 0x27,
+	0x9f, 0xc7, 0xd0, 0x3f, 0x8b, 0x08, 0x29, 0x0e, 0x49, 0x55, 0xab, 0xcb, 0x11, 0x3a, 0xed, 0x0e,
+	0x6c, 0x60, 0x87, 0x79, 0xb3, 0x1c, 0xb5, 0xb4, 0x7d, 0x5f, 0xa2, 0x74, 0xfa, 0xcf, 0x13, 0x41,
+	0xbd, 0xe0, 0x2c, 0x94, 0x15, 0xe8, 0x9a, 0x43, 0x4e, 0x73, 0x96, 0x60, 0x28, 0x7a, 0x09, 0x9d,
+	0x78, 0x12, 0x50, 0x9c, 0x6a, 0xef, 0x3c, 0x0e, 0xb4, 0x48, 0x8a, 0xa2, 0x68, 0x17, 0x40, 0x84,
+	0x98, 0xe4, 0x56, 0x2f, 0x24, 0xf5, 0x03, 0x8f, 0x5e, 0x70, 0x4a, 0xab, 0xe1, 0xaa, 0x2f, 0x51,
+	0x2a, 0x3d, 0x6a, 0xbb, 0x11, 0xf6, 0x02, 0xde, 0xc9, 0x34, 0x44, 0xfb, 0x09, 0x1e, 0x3d, 0x50,
+	0x10, 0xf3, 0xf7, 0x65, 0xa8, 0x5b, 0xd8, 0xb9, 0xf8, 0xb0, 0x8d, 0xf6, 0x19, 0x74, 0x93, 0xbc,
+	0x9d, 0xb1, 0xdb, 0x6d, 0x5d, 0x3f, 0x9a, 0x7f, 0x5a, 0x6d, 0x57, 0x5b, 0xe5, 0x75, 0x5b, 0xbb,
+	0x8e, 0x6e, 0xcd, 0xdf, 0x96, 0xa1, 0x73, 0x90, 0xd4, 0x93, 0x0f, 0x5b, 0x81, 0xbb, 0x00, 0xbc,
+	0x00, 0x66, 0x74, 0xa7, 0xdf, 0x3f, 0x76, 0x11, 0xab, 0x11, 0xa9, 0xaf, 0xf7, 0xd3, 0xd9, 0x1f,
+	0xcb, 0xd0, 0x7a, 0x15, 0x4e, 0x42, 0x3f, 0x3c, 0x9f, 0x7f, 0xd8, 0x1a, 0x3b, 0x84, 0xbe, 0xd6,
+	0x5f, 0x64, 0x14, 0x77, 0x27, 0xe7, 0x74, 0xa9, 0x83, 0x58, 0x5d, 0x37, 0xb3, 0x7e, 0x3f, 0x25,
+	0x7e, 0x53, 0x82, 0x7a, 0x0c, 0xe7, 0x99, 0x5a, 0xd4, 0x5c, 0x95, 0xa9, 0xf9, 0xf7, 0xff, 0x4c,
+	0x89, 0xe6, 0x06, 0xf4, 0xe5, 0x52, 0xaf, 0x5c, 0x16, 0x20, 0x1d, 0xa8, 0xaa, 0xd6, 0xa7, 0xd0,
+	0x66, 0xca, 0x11, 0xc4, 0xe5, 0xd5, 0x94, 0xa5, 0x07, 0xac, 0xee, 0x28, 0x56, 0x8b, 0x69, 0x2b,
+	0xf3, 0xfb, 0x70, 0x53, 0x36, 0xd3, 0x87, 0x4e, 0xb6, 0xc7, 0x2f, 0x74, 0xc5, 0xed, 0xb4, 0x2b,
+	0x36, 0xff, 0x5d, 0x82, 0x5b, 0xf9, 0x6d, 0x4a, 0x9c, 0x55, 0xfb, 0x10, 0x06, 0xa4, 0x12, 0xb9,
+	0x6b, 0xe7, 0xdb, 0xea, 0x8f, 0x0b, 0xfd, 0x7d, 0x9e, 0xf7, 0x4e, 0x9c, 0xe0, 0xd3, 0x16, 0xbf,
+	0x47, 0xb3, 0x00, 0x6a, 0x60, 0xe8, 0x17, 0xc8, 0xf8, 0xb8, 0x15, 0x9f, 0xab, 0x64, 0xaa, 0xa9,
+	0x8d, 0xef, 0xd1, 0xe0, 0x9b, 0x3f, 0x8b, 0x1f, 0x56, 0x8e, 0x49, 0x34, 0x23, 0x91, 0x28, 0x01,
+	0x7a, 0xbd, 0xe7, 0x3d, 0x6f, 0x5c, 0xef, 0x43, 0x97, 0x20, 0x03, 0xea, 0x49, 0xe1, 0x28, 0xcb,
+	0x77, 0x8b, 0x78, 0x6d, 0xde, 0x85, 0x3b, 0x0b, 0x78, 0xa9, 0x66, 0xe0, 0x9b, 0x52, 0x8c, 0x7d,
+	0x1e, 0xce, 0x92, 0x69, 0xf3, 0x3a, 0x16, 0xe2, 0xf5, 0x8a, 0x86, 0xd3, 0xc8, 0x21, 0xb2, 0x05,
+	0x57, 0x1d, 0x9f, 0x04, 0x89, 0x26, 0x7c, 0x0b, 0x9a, 0x0c, 0x47, 0xe7, 0x84, 0x49, 0x02, 0x35,
+	0x09, 0x48, 0x50, 0xdc, 0xa5, 0xa7, 0xcf, 0x2d, 0xd5, 0xec, 0x73, 0x8b, 0x79, 0x0f, 0x8c, 0x45,
+	0x82, 0x29, 0xb9, 0x87, 0xf0, 0xc0, 0x4a, 0xdb, 0x37, 0x8b, 0x4c, 0xb0, 0x17, 0xf1, 0xb6, 0x75,
+	0x1a, 0x77, 0xce, 0xe6, 0xdf, 0x2a, 0xb0, 0xb5, 0x94, 0x44, 0x79, 0xd2, 0x09, 0xd4, 0x22, 0x01,
+	0x8f, 0x5b, 0xb1, 0x4f, 0xf5, 0x3c, 0xba, 0x7a, 0x73, 0x11, 0x6f, 0xc5, 0xcc, 0x8c, 0xbf, 0x97,
+	0xa1, 0x5f, 0x40, 0xaf, 0xd6, 0xe6, 0x55, 0xed, 0xf3, 0xc2, 0xd7, 0xa7, 0x4a, 0xfc, 0x90, 0x93,
+	0x7b, 0x7d, 0x2a, 0x8e, 0x58, 0x99, 0x9e, 0x7f, 0x2d, 0xdb, 0xf3, 0xf3, 0x21, 0x85, 0x32, 0xcc,
+	0x88, 0x9a, 0x47, 0xe4, 0x02, 0xdd, 0xd3, 0xdd, 0xb6, 0x26, 0x27, 0xf0, 0x04, 0xc0, 0x1f, 0xb8,
+	0xa4, 0x25, 0x65, 0x7b, 0xd3, 0xb0, 0xe2, 0xa5, 0x98, 0xe6, 0x08, 0x23, 0x0e, 0x23, 0xae, 0x8d,
+	0x99, 0xe8, 0x63, 0x2a, 0x16, 0xc4, 0xa0, 0x3d, 0x31, 0xdb, 0x53, 0x86, 0x23, 0x85, 0x07, 0x81,
+	0x6f, 0x28, 0xc8, 0x1e, 0xe3, 0xfb, 0xcf, 0xbc, 0xc0, 0xa3, 0x6f, 0x24, 0xbe, 0x29, 0xf7, 0xc7,
+	0xa0, 0x3d, 0x6d, 0xa6, 0x6a, 0xe9, 0xd3, 0xef, 0x16, 0xdc, 0xb7, 0xf0, 0x99, 0x78, 0x5c, 0xd9,
+	0xf7, 0xa7, 0xdc, 0x88, 0xd2, 0xdf, 0x13, 0x87, 0xf8, 0x67, 0x09, 0x1e, 0x2c, 0xa3, 0x48, 0x5e,
+	0x07, 0xba, 0x8e, 0xc4, 0xd8, 0x54, 0xa2, 0x94, 0x5f, 0x7c, 0x92, 0xa9, 0xaf, 0xab, 0x78, 0xec,
+	0x64, 0xc0, 0x56, 0xc7, 0xc9, 0x50, 0x19, 0x11, 0xb4, 0x33, 0x04, 0x85, 0xa2, 0x3a, 0x80, 0x1a,
+	0x76, 0xdd, 0x88, 0x50, 0xaa, 0x9c, 0x20, 0x5e, 0xf2, 0x18, 0xa7, 0xd3, 0xb3, 0x33, 0x3e, 0xd4,
+	0x28, 0xc3, 0x27, 0x6b, 0x6e, 0x5e, 0x8f, 0xda, 0xea, 0x91, 0x56, 0x45, 0x92, 0x47, 0x8f, 0xc4,
+	0xda, 0x3c, 0x81, 0x4d, 0x2e, 0xf3, 0x9e, 0xeb, 0x2a, 0xa1, 0x54, 0x74, 0x5f, 0xff, 0x68, 0xfe,
+	0xb2, 0x1c, 0x32, 0xf5, 0xfe, 0x5b, 0xb7, 0xe4, 0x82, 0xcf, 0x3f, 0x39, 0xbe, 0x2a, 0x38, 0xb7,
+	0xe1, 0x36, 0x47, 0x58, 0x64, 0x1c, 0xce, 0xc8, 0xca, 0x33, 0xf9, 0xa0, 0x52, 0x24, 0x95, 0x6c,
+	0x76, 0xff, 0x0c, 0x50, 0x3b, 0x26, 0xf8, 0x2d, 0x21, 0x2e, 0x7a, 0x0a, 0xed, 0x63, 0x12, 0xb8,
+	0xe9, 0xef, 0x9b, 0xcd, 0x45, 0x4f, 0xea, 0xc6, 0xbd, 0x45, 0xd0, 0x44, 0xae, 0x1b, 0xa3, 0xd2,
+	0x47, 0x25, 0xf4, 0x12, 0xda, 0xcf, 0x08, 0x99, 0xec, 0x87, 0x41, 0x20, 0x3c, 0x12, 0x3d, 0xd0,
+	0xe7, 0xaf, 0xe2, 0x0b, 0x9d, 0x71, 0xa7, 0x30, 0x06, 0xc4, 0xe9, 0x48, 0x71, 0xfc, 0x12, 0x5a,
+	0xfa, 0x73, 0x51, 0x86, 0xe1, 0x82, 0xc7, 0x2d, 0x63, 0xeb, 0x8a, 0x77, 0x26, 0xf3, 0x06, 0xfa,
+	0x0c, 0xd6, 0xe5, 0x13, 0x05, 0x1a, 0x68, 0xc4, 0x99, 0x07, 0x1a, 0xe3, 0xce, 0x02, 0x4c, 0xc2,
+	0xe0, 0x19, 0x40, 0x3a, 0xc6, 0x23, 0x5d, 0x2f, 0x85, 0x47, 0x06, 0xe3, 0xfe, 0x12, 0x6c, 0xc2,
+	0xec, 0xe7, 0xd0, 0xc9, 0x0e, 0xb4, 0x68, 0xb8, 0x70, 0x66, 0xd5, 0x5a, 0x09, 0xe3, 0xe1, 0x0a,
+	0x8a, 0x84, 0xf1, 0x2f, 0xa1, 0x97, 0x9f, 0x53, 0x91, 0xb9, 0x70, 0x63, 0x66, 0xe6, 0x35, 0x1e,
+	0xad, 0xa4, 0xd1, 0x95, 0x90, 0xb6, 0x33, 0x19, 0x25, 0x14, 0x5a, 0x1f, 0xe3, 0xfe, 0x12, 0xac,
+	0xae, 0x84, 0x6c, 0xd3, 0x90, 0x51, 0xc2, 0xc2, 0x16, 0xc7, 0x78, 0xb8, 0x82, 0x22, 0x61, 0xfc,
+	0x55, 0xdc, 0x89, 0x69, 0x05, 0x1a, 0x15, 0xa7, 0xd8, 0x62, 0x2b, 0x60, 0xfc, 0xdf, 0x6a, 0xa2,
+	0xe4, 0x04, 0x07, 0x50, 0xb1, 0x96, 0xa2, 0xe2, 0xee, 0x05, 0x3d, 0x80, 0xf1, 0xff, 0x57, 0x50,
+	0x25, 0x87, 0x44, 0x70, 0x7b, 0x49, 0xc9, 0x44, 0xdb, 0xd7, 0x29, 0xab, 0xf2, 0xb8, 0xc7, 0xd7,
+	0xaf, 0xc0, 0xe6, 0x0d, 0x14, 0xc2, 0xad, 0xc5, 0xe9, 0x18, 0x8d, 0xae, 0x91, 0xb1, 0xe5, 0x89,
+	0xdb, 0xd7, 0xce, 0xed, 0xe6, 0x0d, 0xf4, 0x0a, 0xda, 0x99, 0x9c, 0x87, 0xb6, 0x72, 0xbb, 0xf3,
+	0x59, 0xd6, 0x18, 0x2e, 0x27, 0xd0, 0xc3, 0x20, 0x9f, 0x05, 0x33, 0x61, 0xb0, 0x24, 0x9b, 0x1a,
+	0x8f, 0x56, 0xd2, 0xc4, 0xec, 0x4f, 0xd7, 0xc5, 0xaf, 0xf0, 0x8f, 0xff, 0x33, 0x00, 0x0f, 0xd7,
+	0x83, 0x74, 0x1a, 0x1f, 0x00, 0x00,
 }
@@ -29,6 +29,8 @@ It has these top-level messages:
 	VolumeSyncStatusResponse
 	VolumeFollowRequest
 	VolumeFollowResponse
+	VolumeTailReceiverRequest
+	VolumeTailReceiverResponse
 	VolumeMountRequest
 	VolumeMountResponse
 	VolumeUnmountRequest
@@ -41,6 +43,28 @@ It has these top-level messages:
 	CopyFileResponse
 	ReadVolumeFileStatusRequest
 	ReadVolumeFileStatusResponse
+	VolumeMarkReadonlyRequest
+	VolumeMarkReadonlyResponse
+	VolumeEcShardsGenerateRequest
+	VolumeEcShardsGenerateResponse
+	VolumeEcShardsRebuildRequest
+	VolumeEcShardsRebuildResponse
+	VolumeEcShardsCopyRequest
+	VolumeEcShardsCopyResponse
+	VolumeEcShardsDeleteRequest
+	VolumeEcShardsDeleteResponse
+	VolumeEcShardsMountRequest
+	VolumeEcShardsMountResponse
+	VolumeEcShardsUnmountRequest
+	VolumeEcShardsUnmountResponse
+	VolumeEcShardReadRequest
+	VolumeEcShardReadResponse
+	RemoteFile
+	VolumeInfo
+	VolumeTierMoveDatToRemoteRequest
+	VolumeTierMoveDatToRemoteResponse
+	VolumeTierMoveDatFromRemoteRequest
+	VolumeTierMoveDatFromRemoteResponse
 	DiskStatus
 	MemStatus
 */
@@ -288,6 +312,7 @@ type AllocateVolumeRequest struct {
 	Preallocate int64  `protobuf:"varint,3,opt,name=preallocate" json:"preallocate,omitempty"`
 	Replication string `protobuf:"bytes,4,opt,name=replication" json:"replication,omitempty"`
 	Ttl         string `protobuf:"bytes,5,opt,name=ttl" json:"ttl,omitempty"`
+	DiskType    string `protobuf:"bytes,6,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
 }
 
 func (m *AllocateVolumeRequest) Reset()                    { *m = AllocateVolumeRequest{} }
@@ -330,6 +355,13 @@ func (m *AllocateVolumeRequest) GetTtl() string {
 	return ""
 }
 
+func (m *AllocateVolumeRequest) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
 type AllocateVolumeResponse struct {
 }
 
@@ -362,6 +394,8 @@ type VolumeSyncStatusResponse struct {
 	TailOffset      uint64 `protobuf:"varint,6,opt,name=tail_offset,json=tailOffset" json:"tail_offset,omitempty"`
 	CompactRevision uint32 `protobuf:"varint,7,opt,name=compact_revision,json=compactRevision" json:"compact_revision,omitempty"`
 	IdxFileSize     uint64 `protobuf:"varint,8,opt,name=idx_file_size,json=idxFileSize" json:"idx_file_size,omitempty"`
+	DiskType        string `protobuf:"bytes,9,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
+	ReadOnly        bool   `protobuf:"varint,10,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
 }
 
 func (m *VolumeSyncStatusResponse) Reset()                    { *m = VolumeSyncStatusResponse{} }
@@ -418,9 +452,26 @@ func (m *VolumeSyncStatusResponse) GetIdxFileSize() uint64 {
 	return 0
 }
 
+func (m *VolumeSyncStatusResponse) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
+func (m *VolumeSyncStatusResponse) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 type VolumeFollowRequest struct {
 	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
 	Since    uint64 `protobuf:"varint,2,opt,name=since" json:"since,omitempty"`
+	// if set, follows from the offset in the .dat file instead, for a byte copy of the same compact revision
+	SinceOffset     uint64 `protobuf:"varint,3,opt,name=since_offset,json=sinceOffset" json:"since_offset,omitempty"`
+	CompactRevision uint32 `protobuf:"varint,4,opt,name=compact_revision,json=compactRevision" json:"compact_revision,omitempty"`
 }
 
 func (m *VolumeFollowRequest) Reset()                    { *m = VolumeFollowRequest{} }
@@ -442,6 +493,20 @@ func (m *VolumeFollowRequest) GetSince() uint64 {
 	return 0
 }
 
+func (m *VolumeFollowRequest) GetSinceOffset() uint64 {
+	if m != nil {
+		return m.SinceOffset
+	}
+	return 0
+}
+
+func (m *VolumeFollowRequest) GetCompactRevision() uint32 {
+	if m != nil {
+		return m.CompactRevision
+	}
+	return 0
+}
+
 type VolumeFollowResponse struct {
 	FileContent []byte `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
 }
@@ -458,6 +523,46 @@ func (m *VolumeFollowResponse) GetFileContent() []byte {
 	return nil
 }
 
+type VolumeTailReceiverRequest struct {
+	VolumeId           uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	SourceVolumeServer string `protobuf:"bytes,2,opt,name=source_volume_server,json=sourceVolumeServer" json:"source_volume_server,omitempty"`
+	IdleTimeoutSeconds uint32 `protobuf:"varint,3,opt,name=idle_timeout_seconds,json=idleTimeoutSeconds" json:"idle_timeout_seconds,omitempty"`
+}
+
+func (m *VolumeTailReceiverRequest) Reset()                    { *m = VolumeTailReceiverRequest{} }
+func (m *VolumeTailReceiverRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeTailReceiverRequest) ProtoMessage()               {}
+func (*VolumeTailReceiverRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *VolumeTailReceiverRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeTailReceiverRequest) GetSourceVolumeServer() string {
+	if m != nil {
+		return m.SourceVolumeServer
+	}
+	return ""
+}
+
+func (m *VolumeTailReceiverRequest) GetIdleTimeoutSeconds() uint32 {
+	if m != nil {
+		return m.IdleTimeoutSeconds
+	}
+	return 0
+}
+
+type VolumeTailReceiverResponse struct {
+}
+
+func (m *VolumeTailReceiverResponse) Reset()                    { *m = VolumeTailReceiverResponse{} }
+func (m *VolumeTailReceiverResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeTailReceiverResponse) ProtoMessage()               {}
+func (*VolumeTailReceiverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
 type VolumeMountRequest struct {
 	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
 }
@@ -465,7 +570,7 @@ type VolumeMountRequest struct {
 func (m *VolumeMountRequest) Reset()                    { *m = VolumeMountRequest{} }
 func (m *VolumeMountRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeMountRequest) ProtoMessage()               {}
-func (*VolumeMountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*VolumeMountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *VolumeMountRequest) GetVolumeId() uint32 {
 	if m != nil {
@@ -480,7 +585,7 @@ type VolumeMountResponse struct {
 func (m *VolumeMountResponse) Reset()                    { *m = VolumeMountResponse{} }
 func (m *VolumeMountResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeMountResponse) ProtoMessage()               {}
-func (*VolumeMountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*VolumeMountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 type VolumeUnmountRequest struct {
 	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
@@ -489,7 +594,7 @@ type VolumeUnmountRequest struct {
 func (m *VolumeUnmountRequest) Reset()                    { *m = VolumeUnmountRequest{} }
 func (m *VolumeUnmountRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeUnmountRequest) ProtoMessage()               {}
-func (*VolumeUnmountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*VolumeUnmountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *VolumeUnmountRequest) GetVolumeId() uint32 {
 	if m != nil {
@@ -504,16 +609,17 @@ type VolumeUnmountResponse struct {
 func (m *VolumeUnmountResponse) Reset()                    { *m = VolumeUnmountResponse{} }
 func (m *VolumeUnmountResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeUnmountResponse) ProtoMessage()               {}
-func (*VolumeUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*VolumeUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 type VolumeDeleteRequest struct {
-	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	VolumeId      uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	AllowReadOnly bool   `protobuf:"varint,2,opt,name=allow_read_only,json=allowReadOnly" json:"allow_read_only,omitempty"`
 }
 
 func (m *VolumeDeleteRequest) Reset()                    { *m = VolumeDeleteRequest{} }
 func (m *VolumeDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeDeleteRequest) ProtoMessage()               {}
-func (*VolumeDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*VolumeDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *VolumeDeleteRequest) GetVolumeId() uint32 {
 	if m != nil {
@@ -522,13 +628,20 @@ func (m *VolumeDeleteRequest) GetVolumeId() uint32 {
 	return 0
 }
 
+func (m *VolumeDeleteRequest) GetAllowReadOnly() bool {
+	if m != nil {
+		return m.AllowReadOnly
+	}
+	return false
+}
+
 type VolumeDeleteResponse struct {
 }
 
 func (m *VolumeDeleteResponse) Reset()                    { *m = VolumeDeleteResponse{} }
 func (m *VolumeDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeDeleteResponse) ProtoMessage()               {}
-func (*VolumeDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*VolumeDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 type ReplicateVolumeRequest struct {
 	VolumeId       uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
@@ -536,12 +649,14 @@ type ReplicateVolumeRequest struct {
 	Replication    string `protobuf:"bytes,3,opt,name=replication" json:"replication,omitempty"`
 	Ttl            string `protobuf:"bytes,4,opt,name=ttl" json:"ttl,omitempty"`
 	SourceDataNode string `protobuf:"bytes,5,opt,name=source_data_node,json=sourceDataNode" json:"source_data_node,omitempty"`
+	DiskType       string `protobuf:"bytes,6,opt,name=disk_type,json=diskType" json:"disk_type,omitempty"`
+	ReadOnly       bool   `protobuf:"varint,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
 }
 
 func (m *ReplicateVolumeRequest) Reset()                    { *m = ReplicateVolumeRequest{} }
 func (m *ReplicateVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateVolumeRequest) ProtoMessage()               {}
-func (*ReplicateVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*ReplicateVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *ReplicateVolumeRequest) GetVolumeId() uint32 {
 	if m != nil {
@@ -578,24 +693,42 @@ func (m *ReplicateVolumeRequest) GetSourceDataNode() string {
 	return ""
 }
 
+func (m *ReplicateVolumeRequest) GetDiskType() string {
+	if m != nil {
+		return m.DiskType
+	}
+	return ""
+}
+
+func (m *ReplicateVolumeRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 type ReplicateVolumeResponse struct {
 }
 
 func (m *ReplicateVolumeResponse) Reset()                    { *m = ReplicateVolumeResponse{} }
 func (m *ReplicateVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateVolumeResponse) ProtoMessage()               {}
-func (*ReplicateVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*ReplicateVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 type CopyFileRequest struct {
-	VolumeId  uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
-	IsIdxFile bool   `protobuf:"varint,2,opt,name=is_idx_file,json=isIdxFile" json:"is_idx_file,omitempty"`
-	IsDatFile bool   `protobuf:"varint,3,opt,name=is_dat_file,json=isDatFile" json:"is_dat_file,omitempty"`
+	VolumeId   uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	IsIdxFile  bool   `protobuf:"varint,2,opt,name=is_idx_file,json=isIdxFile" json:"is_idx_file,omitempty"`
+	IsDatFile  bool   `protobuf:"varint,3,opt,name=is_dat_file,json=isDatFile" json:"is_dat_file,omitempty"`
+	Ext        string `protobuf:"bytes,4,opt,name=ext" json:"ext,omitempty"`
+	Collection string `protobuf:"bytes,5,opt,name=collection" json:"collection,omitempty"`
+	IsEcVolume bool   `protobuf:"varint,6,opt,name=is_ec_volume,json=isEcVolume" json:"is_ec_volume,omitempty"`
+	StopOffset uint64 `protobuf:"varint,7,opt,name=stop_offset,json=stopOffset" json:"stop_offset,omitempty"`
 }
 
 func (m *CopyFileRequest) Reset()                    { *m = CopyFileRequest{} }
 func (m *CopyFileRequest) String() string            { return proto.CompactTextString(m) }
 func (*CopyFileRequest) ProtoMessage()               {}
-func (*CopyFileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*CopyFileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *CopyFileRequest) GetVolumeId() uint32 {
 	if m != nil {
@@ -618,14 +751,43 @@ func (m *CopyFileRequest) GetIsDatFile() bool {
 	return false
 }
 
+func (m *CopyFileRequest) GetExt() string {
+	if m != nil {
+		return m.Ext
+	}
+	return ""
+}
+
+func (m *CopyFileRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *CopyFileRequest) GetIsEcVolume() bool {
+	if m != nil {
+		return m.IsEcVolume
+	}
+	return false
+}
+
+func (m *CopyFileRequest) GetStopOffset() uint64 {
+	if m != nil {
+		return m.StopOffset
+	}
+	return 0
+}
+
 type CopyFileResponse struct {
 	FileContent []byte `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	FileSize    uint64 `protobuf:"varint,2,opt,name=file_size,json=fileSize" json:"file_size,omitempty"`
 }
 
 func (m *CopyFileResponse) Reset()                    { *m = CopyFileResponse{} }
 func (m *CopyFileResponse) String() string            { return proto.CompactTextString(m) }
 func (*CopyFileResponse) ProtoMessage()               {}
-func (*CopyFileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*CopyFileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *CopyFileResponse) GetFileContent() []byte {
 	if m != nil {
@@ -634,6 +796,13 @@ func (m *CopyFileResponse) GetFileContent() []byte {
 	return nil
 }
 
+func (m *CopyFileResponse) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
 type ReadVolumeFileStatusRequest struct {
 	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
 }
@@ -641,7 +810,7 @@ type ReadVolumeFileStatusRequest struct {
 func (m *ReadVolumeFileStatusRequest) Reset()                    { *m = ReadVolumeFileStatusRequest{} }
 func (m *ReadVolumeFileStatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReadVolumeFileStatusRequest) ProtoMessage()               {}
-func (*ReadVolumeFileStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*ReadVolumeFileStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *ReadVolumeFileStatusRequest) GetVolumeId() uint32 {
 	if m != nil {
@@ -662,7 +831,7 @@ type ReadVolumeFileStatusResponse struct {
 func (m *ReadVolumeFileStatusResponse) Reset()                    { *m = ReadVolumeFileStatusResponse{} }
 func (m *ReadVolumeFileStatusResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReadVolumeFileStatusResponse) ProtoMessage()               {}
-func (*ReadVolumeFileStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*ReadVolumeFileStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *ReadVolumeFileStatusResponse) GetVolumeId() uint32 {
 	if m != nil {
@@ -706,262 +875,1018 @@ func (m *ReadVolumeFileStatusResponse) GetFileCount() uint64 {
 	return 0
 }
 
-type DiskStatus struct {
-	Dir  string `protobuf:"bytes,1,opt,name=dir" json:"dir,omitempty"`
-	All  uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
-	Used uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
-	Free uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
+type VolumeMarkReadonlyRequest struct {
+	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
 }
 
-func (m *DiskStatus) Reset()                    { *m = DiskStatus{} }
-func (m *DiskStatus) String() string            { return proto.CompactTextString(m) }
-func (*DiskStatus) ProtoMessage()               {}
-func (*DiskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (m *VolumeMarkReadonlyRequest) Reset()                    { *m = VolumeMarkReadonlyRequest{} }
+func (m *VolumeMarkReadonlyRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMarkReadonlyRequest) ProtoMessage()               {}
+func (*VolumeMarkReadonlyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
-func (m *DiskStatus) GetDir() string {
+func (m *VolumeMarkReadonlyRequest) GetVolumeId() uint32 {
 	if m != nil {
-		return m.Dir
+		return m.VolumeId
 	}
-	return ""
+	return 0
 }
 
-func (m *DiskStatus) GetAll() uint64 {
+type VolumeMarkReadonlyResponse struct {
+}
+
+func (m *VolumeMarkReadonlyResponse) Reset()                    { *m = VolumeMarkReadonlyResponse{} }
+func (m *VolumeMarkReadonlyResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeMarkReadonlyResponse) ProtoMessage()               {}
+func (*VolumeMarkReadonlyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+type VolumeEcShardsGenerateRequest struct {
+	VolumeId   uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
+
+func (m *VolumeEcShardsGenerateRequest) Reset()                    { *m = VolumeEcShardsGenerateRequest{} }
+func (m *VolumeEcShardsGenerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsGenerateRequest) ProtoMessage()               {}
+func (*VolumeEcShardsGenerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *VolumeEcShardsGenerateRequest) GetVolumeId() uint32 {
 	if m != nil {
-		return m.All
+		return m.VolumeId
 	}
 	return 0
 }
 
-func (m *DiskStatus) GetUsed() uint64 {
+func (m *VolumeEcShardsGenerateRequest) GetCollection() string {
 	if m != nil {
-		return m.Used
+		return m.Collection
 	}
-	return 0
+	return ""
 }
 
-func (m *DiskStatus) GetFree() uint64 {
+type VolumeEcShardsGenerateResponse struct {
+}
+
+func (m *VolumeEcShardsGenerateResponse) Reset()         { *m = VolumeEcShardsGenerateResponse{} }
+func (m *VolumeEcShardsGenerateResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeEcShardsGenerateResponse) ProtoMessage()    {}
+func (*VolumeEcShardsGenerateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37}
+}
+
+type VolumeEcShardsRebuildRequest struct {
+	VolumeId   uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
+
+func (m *VolumeEcShardsRebuildRequest) Reset()                    { *m = VolumeEcShardsRebuildRequest{} }
+func (m *VolumeEcShardsRebuildRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsRebuildRequest) ProtoMessage()               {}
+func (*VolumeEcShardsRebuildRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *VolumeEcShardsRebuildRequest) GetVolumeId() uint32 {
 	if m != nil {
-		return m.Free
+		return m.VolumeId
 	}
 	return 0
 }
 
-type MemStatus struct {
-	Goroutines int32  `protobuf:"varint,1,opt,name=goroutines" json:"goroutines,omitempty"`
-	All        uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
-	Used       uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
-	Free       uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
-	Self       uint64 `protobuf:"varint,5,opt,name=self" json:"self,omitempty"`
-	Heap       uint64 `protobuf:"varint,6,opt,name=heap" json:"heap,omitempty"`
-	Stack      uint64 `protobuf:"varint,7,opt,name=stack" json:"stack,omitempty"`
+func (m *VolumeEcShardsRebuildRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
 }
 
-func (m *MemStatus) Reset()                    { *m = MemStatus{} }
-func (m *MemStatus) String() string            { return proto.CompactTextString(m) }
-func (*MemStatus) ProtoMessage()               {}
-func (*MemStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+type VolumeEcShardsRebuildResponse struct {
+	RebuiltShardIds []uint32 `protobuf:"varint,1,rep,packed,name=rebuilt_shard_ids,json=rebuiltShardIds" json:"rebuilt_shard_ids,omitempty"`
+}
 
-func (m *MemStatus) GetGoroutines() int32 {
+func (m *VolumeEcShardsRebuildResponse) Reset()                    { *m = VolumeEcShardsRebuildResponse{} }
+func (m *VolumeEcShardsRebuildResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsRebuildResponse) ProtoMessage()               {}
+func (*VolumeEcShardsRebuildResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *VolumeEcShardsRebuildResponse) GetRebuiltShardIds() []uint32 {
 	if m != nil {
-		return m.Goroutines
+		return m.RebuiltShardIds
 	}
-	return 0
+	return nil
 }
 
-func (m *MemStatus) GetAll() uint64 {
+type VolumeEcShardsCopyRequest struct {
+	VolumeId       uint32   `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection     string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ShardIds       []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+	CopyEcxFile    bool     `protobuf:"varint,4,opt,name=copy_ecx_file,json=copyEcxFile" json:"copy_ecx_file,omitempty"`
+	SourceDataNode string   `protobuf:"bytes,5,opt,name=source_data_node,json=sourceDataNode" json:"source_data_node,omitempty"`
+}
+
+func (m *VolumeEcShardsCopyRequest) Reset()                    { *m = VolumeEcShardsCopyRequest{} }
+func (m *VolumeEcShardsCopyRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsCopyRequest) ProtoMessage()               {}
+func (*VolumeEcShardsCopyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *VolumeEcShardsCopyRequest) GetVolumeId() uint32 {
 	if m != nil {
-		return m.All
+		return m.VolumeId
 	}
 	return 0
 }
 
-func (m *MemStatus) GetUsed() uint64 {
+func (m *VolumeEcShardsCopyRequest) GetCollection() string {
 	if m != nil {
-		return m.Used
+		return m.Collection
 	}
-	return 0
+	return ""
 }
 
-func (m *MemStatus) GetFree() uint64 {
+func (m *VolumeEcShardsCopyRequest) GetShardIds() []uint32 {
 	if m != nil {
-		return m.Free
+		return m.ShardIds
 	}
-	return 0
+	return nil
 }
 
-func (m *MemStatus) GetSelf() uint64 {
+func (m *VolumeEcShardsCopyRequest) GetCopyEcxFile() bool {
 	if m != nil {
-		return m.Self
+		return m.CopyEcxFile
 	}
-	return 0
+	return false
 }
 
-func (m *MemStatus) GetHeap() uint64 {
+func (m *VolumeEcShardsCopyRequest) GetSourceDataNode() string {
 	if m != nil {
-		return m.Heap
+		return m.SourceDataNode
 	}
-	return 0
+	return ""
 }
 
-func (m *MemStatus) GetStack() uint64 {
+type VolumeEcShardsCopyResponse struct {
+}
+
+func (m *VolumeEcShardsCopyResponse) Reset()                    { *m = VolumeEcShardsCopyResponse{} }
+func (m *VolumeEcShardsCopyResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsCopyResponse) ProtoMessage()               {}
+func (*VolumeEcShardsCopyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+type VolumeEcShardsDeleteRequest struct {
+	VolumeId   uint32   `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ShardIds   []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+}
+
+func (m *VolumeEcShardsDeleteRequest) Reset()                    { *m = VolumeEcShardsDeleteRequest{} }
+func (m *VolumeEcShardsDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsDeleteRequest) ProtoMessage()               {}
+func (*VolumeEcShardsDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *VolumeEcShardsDeleteRequest) GetVolumeId() uint32 {
 	if m != nil {
-		return m.Stack
+		return m.VolumeId
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*BatchDeleteRequest)(nil), "volume_server_pb.BatchDeleteRequest")
-	proto.RegisterType((*BatchDeleteResponse)(nil), "volume_server_pb.BatchDeleteResponse")
-	proto.RegisterType((*DeleteResult)(nil), "volume_server_pb.DeleteResult")
-	proto.RegisterType((*Empty)(nil), "volume_server_pb.Empty")
-	proto.RegisterType((*VacuumVolumeCheckRequest)(nil), "volume_server_pb.VacuumVolumeCheckRequest")
-	proto.RegisterType((*VacuumVolumeCheckResponse)(nil), "volume_server_pb.VacuumVolumeCheckResponse")
-	proto.RegisterType((*VacuumVolumeCompactRequest)(nil), "volume_server_pb.VacuumVolumeCompactRequest")
-	proto.RegisterType((*VacuumVolumeCompactResponse)(nil), "volume_server_pb.VacuumVolumeCompactResponse")
-	proto.RegisterType((*VacuumVolumeCommitRequest)(nil), "volume_server_pb.VacuumVolumeCommitRequest")
-	proto.RegisterType((*VacuumVolumeCommitResponse)(nil), "volume_server_pb.VacuumVolumeCommitResponse")
-	proto.RegisterType((*VacuumVolumeCleanupRequest)(nil), "volume_server_pb.VacuumVolumeCleanupRequest")
-	proto.RegisterType((*VacuumVolumeCleanupResponse)(nil), "volume_server_pb.VacuumVolumeCleanupResponse")
-	proto.RegisterType((*DeleteCollectionRequest)(nil), "volume_server_pb.DeleteCollectionRequest")
-	proto.RegisterType((*DeleteCollectionResponse)(nil), "volume_server_pb.DeleteCollectionResponse")
-	proto.RegisterType((*AllocateVolumeRequest)(nil), "volume_server_pb.AllocateVolumeRequest")
-	proto.RegisterType((*AllocateVolumeResponse)(nil), "volume_server_pb.AllocateVolumeResponse")
-	proto.RegisterType((*VolumeSyncStatusRequest)(nil), "volume_server_pb.VolumeSyncStatusRequest")
-	proto.RegisterType((*VolumeSyncStatusResponse)(nil), "volume_server_pb.VolumeSyncStatusResponse")
-	proto.RegisterType((*VolumeFollowRequest)(nil), "volume_server_pb.VolumeFollowRequest")
-	proto.RegisterType((*VolumeFollowResponse)(nil), "volume_server_pb.VolumeFollowResponse")
-	proto.RegisterType((*VolumeMountRequest)(nil), "volume_server_pb.VolumeMountRequest")
-	proto.RegisterType((*VolumeMountResponse)(nil), "volume_server_pb.VolumeMountResponse")
-	proto.RegisterType((*VolumeUnmountRequest)(nil), "volume_server_pb.VolumeUnmountRequest")
-	proto.RegisterType((*VolumeUnmountResponse)(nil), "volume_server_pb.VolumeUnmountResponse")
-	proto.RegisterType((*VolumeDeleteRequest)(nil), "volume_server_pb.VolumeDeleteRequest")
-	proto.RegisterType((*VolumeDeleteResponse)(nil), "volume_server_pb.VolumeDeleteResponse")
-	proto.RegisterType((*ReplicateVolumeRequest)(nil), "volume_server_pb.ReplicateVolumeRequest")
-	proto.RegisterType((*ReplicateVolumeResponse)(nil), "volume_server_pb.ReplicateVolumeResponse")
-	proto.RegisterType((*CopyFileRequest)(nil), "volume_server_pb.CopyFileRequest")
-	proto.RegisterType((*CopyFileResponse)(nil), "volume_server_pb.CopyFileResponse")
-	proto.RegisterType((*ReadVolumeFileStatusRequest)(nil), "volume_server_pb.ReadVolumeFileStatusRequest")
-	proto.RegisterType((*ReadVolumeFileStatusResponse)(nil), "volume_server_pb.ReadVolumeFileStatusResponse")
-	proto.RegisterType((*DiskStatus)(nil), "volume_server_pb.DiskStatus")
-	proto.RegisterType((*MemStatus)(nil), "volume_server_pb.MemStatus")
+func (m *VolumeEcShardsDeleteRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *VolumeEcShardsDeleteRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type VolumeEcShardsDeleteResponse struct {
+}
 
-// Client API for VolumeServer service
+func (m *VolumeEcShardsDeleteResponse) Reset()                    { *m = VolumeEcShardsDeleteResponse{} }
+func (m *VolumeEcShardsDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsDeleteResponse) ProtoMessage()               {}
+func (*VolumeEcShardsDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
-type VolumeServerClient interface {
-	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
-	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
-	VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error)
-	VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error)
-	VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error)
-	VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error)
-	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
-	AllocateVolume(ctx context.Context, in *AllocateVolumeRequest, opts ...grpc.CallOption) (*AllocateVolumeResponse, error)
-	VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error)
-	VolumeFollow(ctx context.Context, in *VolumeFollowRequest, opts ...grpc.CallOption) (VolumeServer_VolumeFollowClient, error)
-	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
-	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
-	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error)
-	ReplicateVolume(ctx context.Context, in *ReplicateVolumeRequest, opts ...grpc.CallOption) (*ReplicateVolumeResponse, error)
-	ReadVolumeFileStatus(ctx context.Context, in *ReadVolumeFileStatusRequest, opts ...grpc.CallOption) (*ReadVolumeFileStatusResponse, error)
-	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (VolumeServer_CopyFileClient, error)
+type VolumeEcShardsMountRequest struct {
+	VolumeId   uint32   `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	ShardIds   []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
 }
 
-type volumeServerClient struct {
-	cc *grpc.ClientConn
-}
+func (m *VolumeEcShardsMountRequest) Reset()                    { *m = VolumeEcShardsMountRequest{} }
+func (m *VolumeEcShardsMountRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsMountRequest) ProtoMessage()               {}
+func (*VolumeEcShardsMountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
-func NewVolumeServerClient(cc *grpc.ClientConn) VolumeServerClient {
-	return &volumeServerClient{cc}
+func (m *VolumeEcShardsMountRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardsMountRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeEcShardsMountRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
+
+type VolumeEcShardsMountResponse struct {
+}
+
+func (m *VolumeEcShardsMountResponse) Reset()                    { *m = VolumeEcShardsMountResponse{} }
+func (m *VolumeEcShardsMountResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsMountResponse) ProtoMessage()               {}
+func (*VolumeEcShardsMountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+type VolumeEcShardsUnmountRequest struct {
+	VolumeId uint32   `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	ShardIds []uint32 `protobuf:"varint,3,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+}
+
+func (m *VolumeEcShardsUnmountRequest) Reset()                    { *m = VolumeEcShardsUnmountRequest{} }
+func (m *VolumeEcShardsUnmountRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsUnmountRequest) ProtoMessage()               {}
+func (*VolumeEcShardsUnmountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *VolumeEcShardsUnmountRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardsUnmountRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
+
+type VolumeEcShardsUnmountResponse struct {
+}
+
+func (m *VolumeEcShardsUnmountResponse) Reset()                    { *m = VolumeEcShardsUnmountResponse{} }
+func (m *VolumeEcShardsUnmountResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardsUnmountResponse) ProtoMessage()               {}
+func (*VolumeEcShardsUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+type VolumeEcShardReadRequest struct {
+	VolumeId uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	ShardId  uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Offset   int64  `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	Size     int64  `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *VolumeEcShardReadRequest) Reset()                    { *m = VolumeEcShardReadRequest{} }
+func (m *VolumeEcShardReadRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardReadRequest) ProtoMessage()               {}
+func (*VolumeEcShardReadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *VolumeEcShardReadRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardReadRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *VolumeEcShardReadRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *VolumeEcShardReadRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type VolumeEcShardReadResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *VolumeEcShardReadResponse) Reset()                    { *m = VolumeEcShardReadResponse{} }
+func (m *VolumeEcShardReadResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeEcShardReadResponse) ProtoMessage()               {}
+func (*VolumeEcShardReadResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *VolumeEcShardReadResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type RemoteFile struct {
+	BackendType  string `protobuf:"bytes,1,opt,name=backend_type,json=backendType" json:"backend_type,omitempty"`
+	BackendId    string `protobuf:"bytes,2,opt,name=backend_id,json=backendId" json:"backend_id,omitempty"`
+	Key          string `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	Offset       uint64 `protobuf:"varint,4,opt,name=offset" json:"offset,omitempty"`
+	FileSize     uint64 `protobuf:"varint,5,opt,name=file_size,json=fileSize" json:"file_size,omitempty"`
+	ModifiedTime uint64 `protobuf:"varint,6,opt,name=modified_time,json=modifiedTime" json:"modified_time,omitempty"`
+	Extension    string `protobuf:"bytes,7,opt,name=extension" json:"extension,omitempty"`
+}
+
+func (m *RemoteFile) Reset()                    { *m = RemoteFile{} }
+func (m *RemoteFile) String() string            { return proto.CompactTextString(m) }
+func (*RemoteFile) ProtoMessage()               {}
+func (*RemoteFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *RemoteFile) GetBackendType() string {
+	if m != nil {
+		return m.BackendType
+	}
+	return ""
+}
+
+func (m *RemoteFile) GetBackendId() string {
+	if m != nil {
+		return m.BackendId
+	}
+	return ""
+}
+
+func (m *RemoteFile) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RemoteFile) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *RemoteFile) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *RemoteFile) GetModifiedTime() uint64 {
+	if m != nil {
+		return m.ModifiedTime
+	}
+	return 0
+}
+
+func (m *RemoteFile) GetExtension() string {
+	if m != nil {
+		return m.Extension
+	}
+	return ""
+}
+
+type VolumeInfo struct {
+	Files   []*RemoteFile `protobuf:"bytes,1,rep,name=files" json:"files,omitempty"`
+	Version uint32        `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *VolumeInfo) Reset()                    { *m = VolumeInfo{} }
+func (m *VolumeInfo) String() string            { return proto.CompactTextString(m) }
+func (*VolumeInfo) ProtoMessage()               {}
+func (*VolumeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *VolumeInfo) GetFiles() []*RemoteFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type VolumeTierMoveDatToRemoteRequest struct {
+	VolumeId               uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection             string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	DestinationBackendName string `protobuf:"bytes,3,opt,name=destination_backend_name,json=destinationBackendName" json:"destination_backend_name,omitempty"`
+	KeepLocalDatFile       bool   `protobuf:"varint,4,opt,name=keep_local_dat_file,json=keepLocalDatFile" json:"keep_local_dat_file,omitempty"`
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) Reset()         { *m = VolumeTierMoveDatToRemoteRequest{} }
+func (m *VolumeTierMoveDatToRemoteRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatToRemoteRequest) ProtoMessage()    {}
+func (*VolumeTierMoveDatToRemoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{52}
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetDestinationBackendName() string {
+	if m != nil {
+		return m.DestinationBackendName
+	}
+	return ""
+}
+
+func (m *VolumeTierMoveDatToRemoteRequest) GetKeepLocalDatFile() bool {
+	if m != nil {
+		return m.KeepLocalDatFile
+	}
+	return false
+}
+
+type VolumeTierMoveDatToRemoteResponse struct {
+	Processed           int64   `protobuf:"varint,1,opt,name=processed" json:"processed,omitempty"`
+	ProcessedPercentage float32 `protobuf:"fixed32,2,opt,name=processedPercentage" json:"processedPercentage,omitempty"`
+}
+
+func (m *VolumeTierMoveDatToRemoteResponse) Reset()         { *m = VolumeTierMoveDatToRemoteResponse{} }
+func (m *VolumeTierMoveDatToRemoteResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatToRemoteResponse) ProtoMessage()    {}
+func (*VolumeTierMoveDatToRemoteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{53}
+}
+
+func (m *VolumeTierMoveDatToRemoteResponse) GetProcessed() int64 {
+	if m != nil {
+		return m.Processed
+	}
+	return 0
+}
+
+func (m *VolumeTierMoveDatToRemoteResponse) GetProcessedPercentage() float32 {
+	if m != nil {
+		return m.ProcessedPercentage
+	}
+	return 0
+}
+
+type VolumeTierMoveDatFromRemoteRequest struct {
+	VolumeId          uint32 `protobuf:"varint,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection        string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	KeepRemoteDatFile bool   `protobuf:"varint,3,opt,name=keep_remote_dat_file,json=keepRemoteDatFile" json:"keep_remote_dat_file,omitempty"`
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) Reset()         { *m = VolumeTierMoveDatFromRemoteRequest{} }
+func (m *VolumeTierMoveDatFromRemoteRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatFromRemoteRequest) ProtoMessage()    {}
+func (*VolumeTierMoveDatFromRemoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{54}
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeTierMoveDatFromRemoteRequest) GetKeepRemoteDatFile() bool {
+	if m != nil {
+		return m.KeepRemoteDatFile
+	}
+	return false
+}
+
+type VolumeTierMoveDatFromRemoteResponse struct {
+	Processed           int64   `protobuf:"varint,1,opt,name=processed" json:"processed,omitempty"`
+	ProcessedPercentage float32 `protobuf:"fixed32,2,opt,name=processedPercentage" json:"processedPercentage,omitempty"`
+}
+
+func (m *VolumeTierMoveDatFromRemoteResponse) Reset()         { *m = VolumeTierMoveDatFromRemoteResponse{} }
+func (m *VolumeTierMoveDatFromRemoteResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeTierMoveDatFromRemoteResponse) ProtoMessage()    {}
+func (*VolumeTierMoveDatFromRemoteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{55}
+}
+
+func (m *VolumeTierMoveDatFromRemoteResponse) GetProcessed() int64 {
+	if m != nil {
+		return m.Processed
+	}
+	return 0
+}
+
+func (m *VolumeTierMoveDatFromRemoteResponse) GetProcessedPercentage() float32 {
+	if m != nil {
+		return m.ProcessedPercentage
+	}
+	return 0
+}
+
+type DiskStatus struct {
+	Dir  string `protobuf:"bytes,1,opt,name=dir" json:"dir,omitempty"`
+	All  uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
+	Used uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
+	Free uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
+}
+
+func (m *DiskStatus) Reset()                    { *m = DiskStatus{} }
+func (m *DiskStatus) String() string            { return proto.CompactTextString(m) }
+func (*DiskStatus) ProtoMessage()               {}
+func (*DiskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *DiskStatus) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+func (m *DiskStatus) GetAll() uint64 {
+	if m != nil {
+		return m.All
+	}
+	return 0
+}
+
+func (m *DiskStatus) GetUsed() uint64 {
+	if m != nil {
+		return m.Used
+	}
+	return 0
+}
+
+func (m *DiskStatus) GetFree() uint64 {
+	if m != nil {
+		return m.Free
+	}
+	return 0
+}
+
+type MemStatus struct {
+	Goroutines int32  `protobuf:"varint,1,opt,name=goroutines" json:"goroutines,omitempty"`
+	All        uint64 `protobuf:"varint,2,opt,name=all" json:"all,omitempty"`
+	Used       uint64 `protobuf:"varint,3,opt,name=used" json:"used,omitempty"`
+	Free       uint64 `protobuf:"varint,4,opt,name=free" json:"free,omitempty"`
+	Self       uint64 `protobuf:"varint,5,opt,name=self" json:"self,omitempty"`
+	Heap       uint64 `protobuf:"varint,6,opt,name=heap" json:"heap,omitempty"`
+	Stack      uint64 `protobuf:"varint,7,opt,name=stack" json:"stack,omitempty"`
+}
+
+func (m *MemStatus) Reset()                    { *m = MemStatus{} }
+func (m *MemStatus) String() string            { return proto.CompactTextString(m) }
+func (*MemStatus) ProtoMessage()               {}
+func (*MemStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *MemStatus) GetGoroutines() int32 {
+	if m != nil {
+		return m.Goroutines
+	}
+	return 0
+}
+
+func (m *MemStatus) GetAll() uint64 {
+	if m != nil {
+		return m.All
+	}
+	return 0
+}
+
+func (m *MemStatus) GetUsed() uint64 {
+	if m != nil {
+		return m.Used
+	}
+	return 0
+}
+
+func (m *MemStatus) GetFree() uint64 {
+	if m != nil {
+		return m.Free
+	}
+	return 0
+}
+
+func (m *MemStatus) GetSelf() uint64 {
+	if m != nil {
+		return m.Self
+	}
+	return 0
+}
+
+func (m *MemStatus) GetHeap() uint64 {
+	if m != nil {
+		return m.Heap
+	}
+	return 0
+}
+
+func (m *MemStatus) GetStack() uint64 {
+	if m != nil {
+		return m.Stack
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*BatchDeleteRequest)(nil), "volume_server_pb.BatchDeleteRequest")
+	proto.RegisterType((*BatchDeleteResponse)(nil), "volume_server_pb.BatchDeleteResponse")
+	proto.RegisterType((*DeleteResult)(nil), "volume_server_pb.DeleteResult")
+	proto.RegisterType((*Empty)(nil), "volume_server_pb.Empty")
+	proto.RegisterType((*VacuumVolumeCheckRequest)(nil), "volume_server_pb.VacuumVolumeCheckRequest")
+	proto.RegisterType((*VacuumVolumeCheckResponse)(nil), "volume_server_pb.VacuumVolumeCheckResponse")
+	proto.RegisterType((*VacuumVolumeCompactRequest)(nil), "volume_server_pb.VacuumVolumeCompactRequest")
+	proto.RegisterType((*VacuumVolumeCompactResponse)(nil), "volume_server_pb.VacuumVolumeCompactResponse")
+	proto.RegisterType((*VacuumVolumeCommitRequest)(nil), "volume_server_pb.VacuumVolumeCommitRequest")
+	proto.RegisterType((*VacuumVolumeCommitResponse)(nil), "volume_server_pb.VacuumVolumeCommitResponse")
+	proto.RegisterType((*VacuumVolumeCleanupRequest)(nil), "volume_server_pb.VacuumVolumeCleanupRequest")
+	proto.RegisterType((*VacuumVolumeCleanupResponse)(nil), "volume_server_pb.VacuumVolumeCleanupResponse")
+	proto.RegisterType((*DeleteCollectionRequest)(nil), "volume_server_pb.DeleteCollectionRequest")
+	proto.RegisterType((*DeleteCollectionResponse)(nil), "volume_server_pb.DeleteCollectionResponse")
+	proto.RegisterType((*AllocateVolumeRequest)(nil), "volume_server_pb.AllocateVolumeRequest")
+	proto.RegisterType((*AllocateVolumeResponse)(nil), "volume_server_pb.AllocateVolumeResponse")
+	proto.RegisterType((*VolumeSyncStatusRequest)(nil), "volume_server_pb.VolumeSyncStatusRequest")
+	proto.RegisterType((*VolumeSyncStatusResponse)(nil), "volume_server_pb.VolumeSyncStatusResponse")
+	proto.RegisterType((*VolumeFollowRequest)(nil), "volume_server_pb.VolumeFollowRequest")
+	proto.RegisterType((*VolumeFollowResponse)(nil), "volume_server_pb.VolumeFollowResponse")
+	proto.RegisterType((*VolumeTailReceiverRequest)(nil), "volume_server_pb.VolumeTailReceiverRequest")
+	proto.RegisterType((*VolumeTailReceiverResponse)(nil), "volume_server_pb.VolumeTailReceiverResponse")
+	proto.RegisterType((*VolumeMountRequest)(nil), "volume_server_pb.VolumeMountRequest")
+	proto.RegisterType((*VolumeMountResponse)(nil), "volume_server_pb.VolumeMountResponse")
+	proto.RegisterType((*VolumeUnmountRequest)(nil), "volume_server_pb.VolumeUnmountRequest")
+	proto.RegisterType((*VolumeUnmountResponse)(nil), "volume_server_pb.VolumeUnmountResponse")
+	proto.RegisterType((*VolumeDeleteRequest)(nil), "volume_server_pb.VolumeDeleteRequest")
+	proto.RegisterType((*VolumeDeleteResponse)(nil), "volume_server_pb.VolumeDeleteResponse")
+	proto.RegisterType((*ReplicateVolumeRequest)(nil), "volume_server_pb.ReplicateVolumeRequest")
+	proto.RegisterType((*ReplicateVolumeResponse)(nil), "volume_server_pb.ReplicateVolumeResponse")
+	proto.RegisterType((*CopyFileRequest)(nil), "volume_server_pb.CopyFileRequest")
+	proto.RegisterType((*CopyFileResponse)(nil), "volume_server_pb.CopyFileResponse")
+	proto.RegisterType((*ReadVolumeFileStatusRequest)(nil), "volume_server_pb.ReadVolumeFileStatusRequest")
+	proto.RegisterType((*ReadVolumeFileStatusResponse)(nil), "volume_server_pb.ReadVolumeFileStatusResponse")
+	proto.RegisterType((*VolumeMarkReadonlyRequest)(nil), "volume_server_pb.VolumeMarkReadonlyRequest")
+	proto.RegisterType((*VolumeMarkReadonlyResponse)(nil), "volume_server_pb.VolumeMarkReadonlyResponse")
+	proto.RegisterType((*VolumeEcShardsGenerateRequest)(nil), "volume_server_pb.VolumeEcShardsGenerateRequest")
+	proto.RegisterType((*VolumeEcShardsGenerateResponse)(nil), "volume_server_pb.VolumeEcShardsGenerateResponse")
+	proto.RegisterType((*VolumeEcShardsRebuildRequest)(nil), "volume_server_pb.VolumeEcShardsRebuildRequest")
+	proto.RegisterType((*VolumeEcShardsRebuildResponse)(nil), "volume_server_pb.VolumeEcShardsRebuildResponse")
+	proto.RegisterType((*VolumeEcShardsCopyRequest)(nil), "volume_server_pb.VolumeEcShardsCopyRequest")
+	proto.RegisterType((*VolumeEcShardsCopyResponse)(nil), "volume_server_pb.VolumeEcShardsCopyResponse")
+	proto.RegisterType((*VolumeEcShardsDeleteRequest)(nil), "volume_server_pb.VolumeEcShardsDeleteRequest")
+	proto.RegisterType((*VolumeEcShardsDeleteResponse)(nil), "volume_server_pb.VolumeEcShardsDeleteResponse")
+	proto.RegisterType((*VolumeEcShardsMountRequest)(nil), "volume_server_pb.VolumeEcShardsMountRequest")
+	proto.RegisterType((*VolumeEcShardsMountResponse)(nil), "volume_server_pb.VolumeEcShardsMountResponse")
+	proto.RegisterType((*VolumeEcShardsUnmountRequest)(nil), "volume_server_pb.VolumeEcShardsUnmountRequest")
+	proto.RegisterType((*VolumeEcShardsUnmountResponse)(nil), "volume_server_pb.VolumeEcShardsUnmountResponse")
+	proto.RegisterType((*VolumeEcShardReadRequest)(nil), "volume_server_pb.VolumeEcShardReadRequest")
+	proto.RegisterType((*VolumeEcShardReadResponse)(nil), "volume_server_pb.VolumeEcShardReadResponse")
+	proto.RegisterType((*RemoteFile)(nil), "volume_server_pb.RemoteFile")
+	proto.RegisterType((*VolumeInfo)(nil), "volume_server_pb.VolumeInfo")
+	proto.RegisterType((*VolumeTierMoveDatToRemoteRequest)(nil), "volume_server_pb.VolumeTierMoveDatToRemoteRequest")
+	proto.RegisterType((*VolumeTierMoveDatToRemoteResponse)(nil), "volume_server_pb.VolumeTierMoveDatToRemoteResponse")
+	proto.RegisterType((*VolumeTierMoveDatFromRemoteRequest)(nil), "volume_server_pb.VolumeTierMoveDatFromRemoteRequest")
+	proto.RegisterType((*VolumeTierMoveDatFromRemoteResponse)(nil), "volume_server_pb.VolumeTierMoveDatFromRemoteResponse")
+	proto.RegisterType((*DiskStatus)(nil), "volume_server_pb.DiskStatus")
+	proto.RegisterType((*MemStatus)(nil), "volume_server_pb.MemStatus")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for VolumeServer service
+
+type VolumeServerClient interface {
+	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
+	VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error)
+	VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error)
+	VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error)
+	VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	AllocateVolume(ctx context.Context, in *AllocateVolumeRequest, opts ...grpc.CallOption) (*AllocateVolumeResponse, error)
+	VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error)
+	VolumeFollow(ctx context.Context, in *VolumeFollowRequest, opts ...grpc.CallOption) (VolumeServer_VolumeFollowClient, error)
+	VolumeTailReceiver(ctx context.Context, in *VolumeTailReceiverRequest, opts ...grpc.CallOption) (*VolumeTailReceiverResponse, error)
+	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
+	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
+	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error)
+	ReplicateVolume(ctx context.Context, in *ReplicateVolumeRequest, opts ...grpc.CallOption) (*ReplicateVolumeResponse, error)
+	ReadVolumeFileStatus(ctx context.Context, in *ReadVolumeFileStatusRequest, opts ...grpc.CallOption) (*ReadVolumeFileStatusResponse, error)
+	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (VolumeServer_CopyFileClient, error)
+	VolumeMarkReadonly(ctx context.Context, in *VolumeMarkReadonlyRequest, opts ...grpc.CallOption) (*VolumeMarkReadonlyResponse, error)
+	// erasure coding
+	VolumeEcShardsGenerate(ctx context.Context, in *VolumeEcShardsGenerateRequest, opts ...grpc.CallOption) (*VolumeEcShardsGenerateResponse, error)
+	VolumeEcShardsRebuild(ctx context.Context, in *VolumeEcShardsRebuildRequest, opts ...grpc.CallOption) (*VolumeEcShardsRebuildResponse, error)
+	VolumeEcShardsCopy(ctx context.Context, in *VolumeEcShardsCopyRequest, opts ...grpc.CallOption) (*VolumeEcShardsCopyResponse, error)
+	VolumeEcShardsDelete(ctx context.Context, in *VolumeEcShardsDeleteRequest, opts ...grpc.CallOption) (*VolumeEcShardsDeleteResponse, error)
+	VolumeEcShardsMount(ctx context.Context, in *VolumeEcShardsMountRequest, opts ...grpc.CallOption) (*VolumeEcShardsMountResponse, error)
+	VolumeEcShardsUnmount(ctx context.Context, in *VolumeEcShardsUnmountRequest, opts ...grpc.CallOption) (*VolumeEcShardsUnmountResponse, error)
+	VolumeEcShardRead(ctx context.Context, in *VolumeEcShardReadRequest, opts ...grpc.CallOption) (VolumeServer_VolumeEcShardReadClient, error)
+	// tiered storage
+	VolumeTierMoveDatToRemote(ctx context.Context, in *VolumeTierMoveDatToRemoteRequest, opts ...grpc.CallOption) (VolumeServer_VolumeTierMoveDatToRemoteClient, error)
+	VolumeTierMoveDatFromRemote(ctx context.Context, in *VolumeTierMoveDatFromRemoteRequest, opts ...grpc.CallOption) (VolumeServer_VolumeTierMoveDatFromRemoteClient, error)
+}
+
+type volumeServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewVolumeServerClient(cc *grpc.ClientConn) VolumeServerClient {
+	return &volumeServerClient{cc}
+}
+
+func (c *volumeServerClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
+	out := new(BatchDeleteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/BatchDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error) {
+	out := new(VacuumVolumeCheckResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCheck", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error) {
+	out := new(VacuumVolumeCompactResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCompact", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error) {
+	out := new(VacuumVolumeCommitResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCommit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error) {
+	out := new(VacuumVolumeCleanupResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCleanup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	out := new(DeleteCollectionResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/DeleteCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) AllocateVolume(ctx context.Context, in *AllocateVolumeRequest, opts ...grpc.CallOption) (*AllocateVolumeResponse, error) {
+	out := new(AllocateVolumeResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/AllocateVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error) {
+	out := new(VolumeSyncStatusResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeSyncStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeFollow(ctx context.Context, in *VolumeFollowRequest, opts ...grpc.CallOption) (VolumeServer_VolumeFollowClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[0], c.cc, "/volume_server_pb.VolumeServer/VolumeFollow", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerVolumeFollowClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeServer_VolumeFollowClient interface {
+	Recv() (*VolumeFollowResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServerVolumeFollowClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServerVolumeFollowClient) Recv() (*VolumeFollowResponse, error) {
+	m := new(VolumeFollowResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *volumeServerClient) VolumeTailReceiver(ctx context.Context, in *VolumeTailReceiverRequest, opts ...grpc.CallOption) (*VolumeTailReceiverResponse, error) {
+	out := new(VolumeTailReceiverResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeTailReceiver", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error) {
+	out := new(VolumeMountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error) {
+	out := new(VolumeUnmountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeUnmount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error) {
+	out := new(VolumeDeleteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) ReplicateVolume(ctx context.Context, in *ReplicateVolumeRequest, opts ...grpc.CallOption) (*ReplicateVolumeResponse, error) {
+	out := new(ReplicateVolumeResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/ReplicateVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) ReadVolumeFileStatus(ctx context.Context, in *ReadVolumeFileStatusRequest, opts ...grpc.CallOption) (*ReadVolumeFileStatusResponse, error) {
+	out := new(ReadVolumeFileStatusResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/ReadVolumeFileStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (VolumeServer_CopyFileClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[1], c.cc, "/volume_server_pb.VolumeServer/CopyFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerCopyFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeServer_CopyFileClient interface {
+	Recv() (*CopyFileResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServerCopyFileClient struct {
+	grpc.ClientStream
 }
 
-func (c *volumeServerClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
-	out := new(BatchDeleteResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/BatchDelete", in, out, c.cc, opts...)
-	if err != nil {
+func (x *volumeServerCopyFileClient) Recv() (*CopyFileResponse, error) {
+	m := new(CopyFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *volumeServerClient) VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error) {
-	out := new(VacuumVolumeCheckResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCheck", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeMarkReadonly(ctx context.Context, in *VolumeMarkReadonlyRequest, opts ...grpc.CallOption) (*VolumeMarkReadonlyResponse, error) {
+	out := new(VolumeMarkReadonlyResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMarkReadonly", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (*VacuumVolumeCompactResponse, error) {
-	out := new(VacuumVolumeCompactResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCompact", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeEcShardsGenerate(ctx context.Context, in *VolumeEcShardsGenerateRequest, opts ...grpc.CallOption) (*VolumeEcShardsGenerateResponse, error) {
+	out := new(VolumeEcShardsGenerateResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsGenerate", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) VacuumVolumeCommit(ctx context.Context, in *VacuumVolumeCommitRequest, opts ...grpc.CallOption) (*VacuumVolumeCommitResponse, error) {
-	out := new(VacuumVolumeCommitResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCommit", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeEcShardsRebuild(ctx context.Context, in *VolumeEcShardsRebuildRequest, opts ...grpc.CallOption) (*VolumeEcShardsRebuildResponse, error) {
+	out := new(VolumeEcShardsRebuildResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsRebuild", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) VacuumVolumeCleanup(ctx context.Context, in *VacuumVolumeCleanupRequest, opts ...grpc.CallOption) (*VacuumVolumeCleanupResponse, error) {
-	out := new(VacuumVolumeCleanupResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VacuumVolumeCleanup", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeEcShardsCopy(ctx context.Context, in *VolumeEcShardsCopyRequest, opts ...grpc.CallOption) (*VolumeEcShardsCopyResponse, error) {
+	out := new(VolumeEcShardsCopyResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsCopy", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
-	out := new(DeleteCollectionResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/DeleteCollection", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeEcShardsDelete(ctx context.Context, in *VolumeEcShardsDeleteRequest, opts ...grpc.CallOption) (*VolumeEcShardsDeleteResponse, error) {
+	out := new(VolumeEcShardsDeleteResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsDelete", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) AllocateVolume(ctx context.Context, in *AllocateVolumeRequest, opts ...grpc.CallOption) (*AllocateVolumeResponse, error) {
-	out := new(AllocateVolumeResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/AllocateVolume", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeEcShardsMount(ctx context.Context, in *VolumeEcShardsMountRequest, opts ...grpc.CallOption) (*VolumeEcShardsMountResponse, error) {
+	out := new(VolumeEcShardsMountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsMount", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error) {
-	out := new(VolumeSyncStatusResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeSyncStatus", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeEcShardsUnmount(ctx context.Context, in *VolumeEcShardsUnmountRequest, opts ...grpc.CallOption) (*VolumeEcShardsUnmountResponse, error) {
+	out := new(VolumeEcShardsUnmountResponse)
+	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeEcShardsUnmount", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *volumeServerClient) VolumeFollow(ctx context.Context, in *VolumeFollowRequest, opts ...grpc.CallOption) (VolumeServer_VolumeFollowClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[0], c.cc, "/volume_server_pb.VolumeServer/VolumeFollow", opts...)
+func (c *volumeServerClient) VolumeEcShardRead(ctx context.Context, in *VolumeEcShardReadRequest, opts ...grpc.CallOption) (VolumeServer_VolumeEcShardReadClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[2], c.cc, "/volume_server_pb.VolumeServer/VolumeEcShardRead", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &volumeServerVolumeFollowClient{stream}
+	x := &volumeServerVolumeEcShardReadClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -971,74 +1896,61 @@ func (c *volumeServerClient) VolumeFollow(ctx context.Context, in *VolumeFollowR
 	return x, nil
 }
 
-type VolumeServer_VolumeFollowClient interface {
-	Recv() (*VolumeFollowResponse, error)
+type VolumeServer_VolumeEcShardReadClient interface {
+	Recv() (*VolumeEcShardReadResponse, error)
 	grpc.ClientStream
 }
 
-type volumeServerVolumeFollowClient struct {
+type volumeServerVolumeEcShardReadClient struct {
 	grpc.ClientStream
 }
 
-func (x *volumeServerVolumeFollowClient) Recv() (*VolumeFollowResponse, error) {
-	m := new(VolumeFollowResponse)
+func (x *volumeServerVolumeEcShardReadClient) Recv() (*VolumeEcShardReadResponse, error) {
+	m := new(VolumeEcShardReadResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *volumeServerClient) VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error) {
-	out := new(VolumeMountResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeMount", in, out, c.cc, opts...)
+func (c *volumeServerClient) VolumeTierMoveDatToRemote(ctx context.Context, in *VolumeTierMoveDatToRemoteRequest, opts ...grpc.CallOption) (VolumeServer_VolumeTierMoveDatToRemoteClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[3], c.cc, "/volume_server_pb.VolumeServer/VolumeTierMoveDatToRemote", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *volumeServerClient) VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error) {
-	out := new(VolumeUnmountResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeUnmount", in, out, c.cc, opts...)
-	if err != nil {
+	x := &volumeServerVolumeTierMoveDatToRemoteClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *volumeServerClient) VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error) {
-	out := new(VolumeDeleteResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/VolumeDelete", in, out, c.cc, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return x, nil
 }
 
-func (c *volumeServerClient) ReplicateVolume(ctx context.Context, in *ReplicateVolumeRequest, opts ...grpc.CallOption) (*ReplicateVolumeResponse, error) {
-	out := new(ReplicateVolumeResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/ReplicateVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type VolumeServer_VolumeTierMoveDatToRemoteClient interface {
+	Recv() (*VolumeTierMoveDatToRemoteResponse, error)
+	grpc.ClientStream
 }
 
-func (c *volumeServerClient) ReadVolumeFileStatus(ctx context.Context, in *ReadVolumeFileStatusRequest, opts ...grpc.CallOption) (*ReadVolumeFileStatusResponse, error) {
-	out := new(ReadVolumeFileStatusResponse)
-	err := grpc.Invoke(ctx, "/volume_server_pb.VolumeServer/ReadVolumeFileStatus", in, out, c.cc, opts...)
-	if err != nil {
+type volumeServerVolumeTierMoveDatToRemoteClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServerVolumeTierMoveDatToRemoteClient) Recv() (*VolumeTierMoveDatToRemoteResponse, error) {
+	m := new(VolumeTierMoveDatToRemoteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *volumeServerClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (VolumeServer_CopyFileClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[1], c.cc, "/volume_server_pb.VolumeServer/CopyFile", opts...)
+func (c *volumeServerClient) VolumeTierMoveDatFromRemote(ctx context.Context, in *VolumeTierMoveDatFromRemoteRequest, opts ...grpc.CallOption) (VolumeServer_VolumeTierMoveDatFromRemoteClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[4], c.cc, "/volume_server_pb.VolumeServer/VolumeTierMoveDatFromRemote", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &volumeServerCopyFileClient{stream}
+	x := &volumeServerVolumeTierMoveDatFromRemoteClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1048,17 +1960,17 @@ func (c *volumeServerClient) CopyFile(ctx context.Context, in *CopyFileRequest,
 	return x, nil
 }
 
-type VolumeServer_CopyFileClient interface {
-	Recv() (*CopyFileResponse, error)
+type VolumeServer_VolumeTierMoveDatFromRemoteClient interface {
+	Recv() (*VolumeTierMoveDatFromRemoteResponse, error)
 	grpc.ClientStream
 }
 
-type volumeServerCopyFileClient struct {
+type volumeServerVolumeTierMoveDatFromRemoteClient struct {
 	grpc.ClientStream
 }
 
-func (x *volumeServerCopyFileClient) Recv() (*CopyFileResponse, error) {
-	m := new(CopyFileResponse)
+func (x *volumeServerVolumeTierMoveDatFromRemoteClient) Recv() (*VolumeTierMoveDatFromRemoteResponse, error) {
+	m := new(VolumeTierMoveDatFromRemoteResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -1078,12 +1990,25 @@ type VolumeServerServer interface {
 	AllocateVolume(context.Context, *AllocateVolumeRequest) (*AllocateVolumeResponse, error)
 	VolumeSyncStatus(context.Context, *VolumeSyncStatusRequest) (*VolumeSyncStatusResponse, error)
 	VolumeFollow(*VolumeFollowRequest, VolumeServer_VolumeFollowServer) error
+	VolumeTailReceiver(context.Context, *VolumeTailReceiverRequest) (*VolumeTailReceiverResponse, error)
 	VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error)
 	VolumeUnmount(context.Context, *VolumeUnmountRequest) (*VolumeUnmountResponse, error)
 	VolumeDelete(context.Context, *VolumeDeleteRequest) (*VolumeDeleteResponse, error)
 	ReplicateVolume(context.Context, *ReplicateVolumeRequest) (*ReplicateVolumeResponse, error)
 	ReadVolumeFileStatus(context.Context, *ReadVolumeFileStatusRequest) (*ReadVolumeFileStatusResponse, error)
 	CopyFile(*CopyFileRequest, VolumeServer_CopyFileServer) error
+	VolumeMarkReadonly(context.Context, *VolumeMarkReadonlyRequest) (*VolumeMarkReadonlyResponse, error)
+	// erasure coding
+	VolumeEcShardsGenerate(context.Context, *VolumeEcShardsGenerateRequest) (*VolumeEcShardsGenerateResponse, error)
+	VolumeEcShardsRebuild(context.Context, *VolumeEcShardsRebuildRequest) (*VolumeEcShardsRebuildResponse, error)
+	VolumeEcShardsCopy(context.Context, *VolumeEcShardsCopyRequest) (*VolumeEcShardsCopyResponse, error)
+	VolumeEcShardsDelete(context.Context, *VolumeEcShardsDeleteRequest) (*VolumeEcShardsDeleteResponse, error)
+	VolumeEcShardsMount(context.Context, *VolumeEcShardsMountRequest) (*VolumeEcShardsMountResponse, error)
+	VolumeEcShardsUnmount(context.Context, *VolumeEcShardsUnmountRequest) (*VolumeEcShardsUnmountResponse, error)
+	VolumeEcShardRead(*VolumeEcShardReadRequest, VolumeServer_VolumeEcShardReadServer) error
+	// tiered storage
+	VolumeTierMoveDatToRemote(*VolumeTierMoveDatToRemoteRequest, VolumeServer_VolumeTierMoveDatToRemoteServer) error
+	VolumeTierMoveDatFromRemote(*VolumeTierMoveDatFromRemoteRequest, VolumeServer_VolumeTierMoveDatFromRemoteServer) error
 }
 
 func RegisterVolumeServerServer(s *grpc.Server, srv VolumeServerServer) {
@@ -1255,6 +2180,24 @@ func (x *volumeServerVolumeFollowServer) Send(m *VolumeFollowResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _VolumeServer_VolumeTailReceiver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeTailReceiverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeTailReceiver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeTailReceiver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeTailReceiver(ctx, req.(*VolumeTailReceiverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VolumeServer_VolumeMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VolumeMountRequest)
 	if err := dec(in); err != nil {
@@ -1366,6 +2309,195 @@ func (x *volumeServerCopyFileServer) Send(m *CopyFileResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _VolumeServer_VolumeMarkReadonly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMarkReadonlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeMarkReadonly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeMarkReadonly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeMarkReadonly(ctx, req.(*VolumeMarkReadonlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsGenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsGenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsGenerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsGenerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsGenerate(ctx, req.(*VolumeEcShardsGenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsRebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsRebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsRebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsRebuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsRebuild(ctx, req.(*VolumeEcShardsRebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsCopy(ctx, req.(*VolumeEcShardsCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsDelete(ctx, req.(*VolumeEcShardsDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsMountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsMount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsMount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsMount(ctx, req.(*VolumeEcShardsMountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsUnmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsUnmountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsUnmount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/volume_server_pb.VolumeServer/VolumeEcShardsUnmount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsUnmount(ctx, req.(*VolumeEcShardsUnmountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardRead_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeEcShardReadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).VolumeEcShardRead(m, &volumeServerVolumeEcShardReadServer{stream})
+}
+
+type VolumeServer_VolumeEcShardReadServer interface {
+	Send(*VolumeEcShardReadResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerVolumeEcShardReadServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServerVolumeEcShardReadServer) Send(m *VolumeEcShardReadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeServer_VolumeTierMoveDatToRemote_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeTierMoveDatToRemoteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).VolumeTierMoveDatToRemote(m, &volumeServerVolumeTierMoveDatToRemoteServer{stream})
+}
+
+type VolumeServer_VolumeTierMoveDatToRemoteServer interface {
+	Send(*VolumeTierMoveDatToRemoteResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerVolumeTierMoveDatToRemoteServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServerVolumeTierMoveDatToRemoteServer) Send(m *VolumeTierMoveDatToRemoteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeServer_VolumeTierMoveDatFromRemote_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeTierMoveDatFromRemoteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).VolumeTierMoveDatFromRemote(m, &volumeServerVolumeTierMoveDatFromRemoteServer{stream})
+}
+
+type VolumeServer_VolumeTierMoveDatFromRemoteServer interface {
+	Send(*VolumeTierMoveDatFromRemoteResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerVolumeTierMoveDatFromRemoteServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServerVolumeTierMoveDatFromRemoteServer) Send(m *VolumeTierMoveDatFromRemoteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "volume_server_pb.VolumeServer",
 	HandlerType: (*VolumeServerServer)(nil),
@@ -1402,6 +2534,10 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeSyncStatus",
 			Handler:    _VolumeServer_VolumeSyncStatus_Handler,
 		},
+		{
+			MethodName: "VolumeTailReceiver",
+			Handler:    _VolumeServer_VolumeTailReceiver_Handler,
+		},
 		{
 			MethodName: "VolumeMount",
 			Handler:    _VolumeServer_VolumeMount_Handler,
@@ -1422,6 +2558,34 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReadVolumeFileStatus",
 			Handler:    _VolumeServer_ReadVolumeFileStatus_Handler,
 		},
+		{
+			MethodName: "VolumeMarkReadonly",
+			Handler:    _VolumeServer_VolumeMarkReadonly_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsGenerate",
+			Handler:    _VolumeServer_VolumeEcShardsGenerate_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsRebuild",
+			Handler:    _VolumeServer_VolumeEcShardsRebuild_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsCopy",
+			Handler:    _VolumeServer_VolumeEcShardsCopy_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsDelete",
+			Handler:    _VolumeServer_VolumeEcShardsDelete_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsMount",
+			Handler:    _VolumeServer_VolumeEcShardsMount_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsUnmount",
+			Handler:    _VolumeServer_VolumeEcShardsUnmount_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1434,6 +2598,21 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _VolumeServer_CopyFile_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "VolumeEcShardRead",
+			Handler:       _VolumeServer_VolumeEcShardRead_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "VolumeTierMoveDatToRemote",
+			Handler:       _VolumeServer_VolumeTierMoveDatToRemote_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "VolumeTierMoveDatFromRemote",
+			Handler:       _VolumeServer_VolumeTierMoveDatFromRemote_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "volume_server.proto",
 }
@@ -1441,79 +2620,136 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("volume_server.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1169 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x58, 0x4b, 0x73, 0xdc, 0x44,
-	0x10, 0xb6, 0xb2, 0xbb, 0x7e, 0xf4, 0xae, 0xe3, 0x65, 0xfc, 0x92, 0xe5, 0x60, 0x36, 0x03, 0x4e,
-	0xd6, 0x89, 0x63, 0xc0, 0x29, 0x20, 0xe1, 0x04, 0xd8, 0xa4, 0xf0, 0x21, 0xa4, 0x4a, 0x26, 0x29,
-	0xaa, 0xa0, 0x4a, 0x35, 0x96, 0x66, 0x6d, 0x95, 0xb5, 0x1a, 0x45, 0x1a, 0x39, 0x31, 0x7f, 0x87,
-	0x1b, 0x57, 0xae, 0xfc, 0x17, 0x7e, 0x08, 0x17, 0x6a, 0x1e, 0x92, 0x57, 0x8f, 0xcd, 0x8a, 0xc7,
-	0x6d, 0xd4, 0xd3, 0xfd, 0x75, 0xf7, 0x4c, 0x77, 0xcf, 0x57, 0x82, 0xd5, 0x2b, 0x16, 0xa4, 0x63,
-	0xea, 0x24, 0x34, 0xbe, 0xa2, 0xf1, 0x41, 0x14, 0x33, 0xce, 0x50, 0xbf, 0x20, 0x74, 0xa2, 0x33,
-	0xfc, 0x31, 0xa0, 0x6f, 0x08, 0x77, 0x2f, 0x8e, 0x69, 0x40, 0x39, 0xb5, 0xe9, 0xeb, 0x94, 0x26,
-	0x1c, 0x6d, 0xc1, 0xe2, 0xc8, 0x0f, 0xa8, 0xe3, 0x7b, 0x89, 0x69, 0x0c, 0x5a, 0xc3, 0x25, 0x7b,
-	0x41, 0x7c, 0x9f, 0x78, 0x09, 0x7e, 0x01, 0xab, 0x05, 0x83, 0x24, 0x62, 0x61, 0x42, 0xd1, 0x13,
-	0x58, 0x88, 0x69, 0x92, 0x06, 0x5c, 0x19, 0x74, 0x0f, 0x77, 0x0e, 0xca, 0xbe, 0x0e, 0x72, 0x93,
-	0x34, 0xe0, 0x76, 0xa6, 0x8e, 0x7d, 0xe8, 0x4d, 0x6e, 0xa0, 0x4d, 0x58, 0xd0, 0xbe, 0x4d, 0x63,
-	0x60, 0x0c, 0x97, 0xec, 0x79, 0xe5, 0x1a, 0x6d, 0xc0, 0x7c, 0xc2, 0x09, 0x4f, 0x13, 0xf3, 0xd6,
-	0xc0, 0x18, 0x76, 0x6c, 0xfd, 0x85, 0xd6, 0xa0, 0x43, 0xe3, 0x98, 0xc5, 0x66, 0x4b, 0xaa, 0xab,
-	0x0f, 0x84, 0xa0, 0x9d, 0xf8, 0xbf, 0x50, 0xb3, 0x3d, 0x30, 0x86, 0xcb, 0xb6, 0x5c, 0xe3, 0x05,
-	0xe8, 0x7c, 0x3b, 0x8e, 0xf8, 0x35, 0xfe, 0x02, 0xcc, 0x57, 0xc4, 0x4d, 0xd3, 0xf1, 0x2b, 0x19,
-	0xe3, 0xd1, 0x05, 0x75, 0x2f, 0xb3, 0xdc, 0xb7, 0x61, 0x49, 0x47, 0xae, 0x23, 0x58, 0xb6, 0x17,
-	0x95, 0xe0, 0xc4, 0xc3, 0x5f, 0xc1, 0x56, 0x8d, 0xa1, 0x3e, 0x83, 0x0f, 0x61, 0xf9, 0x9c, 0xc4,
-	0x67, 0xe4, 0x9c, 0x3a, 0x31, 0xe1, 0x3e, 0x93, 0xd6, 0x86, 0xdd, 0xd3, 0x42, 0x5b, 0xc8, 0xf0,
-	0x4f, 0x60, 0x15, 0x10, 0xd8, 0x38, 0x22, 0x2e, 0x6f, 0xe2, 0x1c, 0x0d, 0xa0, 0x1b, 0xc5, 0x94,
-	0x04, 0x01, 0x73, 0x09, 0xa7, 0xf2, 0x14, 0x5a, 0xf6, 0xa4, 0x08, 0xbf, 0x0f, 0xdb, 0xb5, 0xe0,
-	0x2a, 0x40, 0xfc, 0xa4, 0x14, 0x3d, 0x1b, 0x8f, 0xfd, 0x46, 0xae, 0xf1, 0x9d, 0x4a, 0xd4, 0xd2,
-	0x52, 0xe3, 0x3e, 0x2d, 0xed, 0x06, 0x94, 0x84, 0x69, 0xd4, 0x08, 0xb8, 0x1c, 0x71, 0x66, 0x9a,
-	0x23, 0x6f, 0xaa, 0xe2, 0x38, 0x62, 0x41, 0x40, 0x5d, 0xee, 0xb3, 0x30, 0x83, 0xdd, 0x01, 0x70,
-	0x73, 0xa1, 0x2e, 0x95, 0x09, 0x09, 0xb6, 0xc0, 0xac, 0x9a, 0x6a, 0xd8, 0xdf, 0x0c, 0x58, 0xff,
-	0x5a, 0x1f, 0x9a, 0x72, 0xdc, 0xe8, 0x02, 0x8a, 0x2e, 0x6f, 0x95, 0x5d, 0x96, 0x2f, 0xa8, 0x55,
-	0xb9, 0x20, 0xa1, 0x11, 0xd3, 0x28, 0xf0, 0x5d, 0x22, 0x21, 0xda, 0x12, 0x62, 0x52, 0x84, 0xfa,
-	0xd0, 0xe2, 0x3c, 0x30, 0x3b, 0x72, 0x47, 0x2c, 0xb1, 0x09, 0x1b, 0xe5, 0x58, 0x75, 0x1a, 0x9f,
-	0xc3, 0xa6, 0x92, 0x9c, 0x5e, 0x87, 0xee, 0xa9, 0xec, 0x86, 0x46, 0x87, 0xfe, 0x97, 0x01, 0x66,
-	0xd5, 0x50, 0x57, 0xf1, 0x7f, 0x3d, 0x81, 0x7f, 0x9a, 0x1f, 0xfa, 0x00, 0xba, 0x9c, 0xf8, 0x81,
-	0xc3, 0x46, 0xa3, 0x84, 0x72, 0x73, 0x7e, 0x60, 0x0c, 0xdb, 0x36, 0x08, 0xd1, 0x0b, 0x29, 0x41,
-	0x7b, 0xd0, 0x77, 0x55, 0x25, 0x3b, 0x31, 0xbd, 0xf2, 0x13, 0x81, 0xbc, 0x20, 0x03, 0x5b, 0x71,
-	0xb3, 0x0a, 0x57, 0x62, 0x84, 0x61, 0xd9, 0xf7, 0xde, 0x3a, 0x72, 0x80, 0xc8, 0xf6, 0x5f, 0x94,
-	0x68, 0x5d, 0xdf, 0x7b, 0xfb, 0xcc, 0x0f, 0xe8, 0xa9, 0x98, 0x02, 0xdf, 0xc1, 0xaa, 0x4a, 0xfe,
-	0x19, 0x0b, 0x02, 0xf6, 0xa6, 0xd1, 0xcd, 0xaf, 0x41, 0x27, 0xf1, 0x43, 0x57, 0x35, 0x5d, 0xdb,
-	0x56, 0x1f, 0xf8, 0x29, 0xac, 0x15, 0x91, 0xf4, 0x11, 0xde, 0x85, 0x9e, 0x8c, 0xc0, 0x65, 0x21,
-	0xa7, 0x21, 0x97, 0x68, 0x3d, 0xbb, 0x2b, 0x64, 0x47, 0x4a, 0x84, 0x3f, 0x05, 0xa4, 0x4c, 0x9f,
-	0xb3, 0x34, 0x6c, 0xd6, 0x83, 0xeb, 0x59, 0xdc, 0xda, 0x44, 0x17, 0xc1, 0xe3, 0x2c, 0x88, 0x97,
-	0xe1, 0xb8, 0x31, 0xd6, 0x26, 0xac, 0x97, 0x8c, 0x34, 0xda, 0x61, 0xe6, 0xa4, 0xf8, 0x20, 0xbc,
-	0x13, 0x6c, 0x23, 0x8b, 0xa0, 0xf8, 0x26, 0xe0, 0xdf, 0x0d, 0xd8, 0xb0, 0xf5, 0xd5, 0xff, 0xcf,
-	0x6d, 0x36, 0x59, 0x64, 0xad, 0xa9, 0x45, 0xd6, 0xbe, 0x29, 0xb2, 0x21, 0xf4, 0x13, 0x96, 0xc6,
-	0x2e, 0x75, 0x3c, 0xc2, 0x89, 0x13, 0x32, 0x8f, 0xea, 0x1a, 0xbc, 0xad, 0xe4, 0xc7, 0x84, 0x93,
-	0xef, 0x99, 0x47, 0xf1, 0x16, 0x6c, 0x56, 0x82, 0xd6, 0x09, 0x85, 0xb0, 0x72, 0xc4, 0xa2, 0x6b,
-	0x51, 0x49, 0x0d, 0x13, 0xe9, 0xfa, 0x89, 0x93, 0x15, 0xa4, 0xcc, 0x64, 0xd1, 0x5e, 0xf2, 0x93,
-	0x13, 0x55, 0x8d, 0x7a, 0xdf, 0x23, 0x5c, 0xed, 0xb7, 0xb2, 0xfd, 0x63, 0xc2, 0xc5, 0x3e, 0xfe,
-	0x0c, 0xfa, 0x37, 0xfe, 0x9a, 0xd7, 0xd6, 0x97, 0xb0, 0x6d, 0x53, 0xe2, 0xe9, 0xd2, 0x14, 0x65,
-	0xdf, 0x7c, 0x34, 0xfc, 0x69, 0xc0, 0x9d, 0x7a, 0xe3, 0x26, 0xe3, 0x61, 0x1f, 0x50, 0xde, 0x7e,
-	0xdc, 0x1f, 0xd3, 0x84, 0x93, 0x71, 0xa4, 0x7b, 0xa6, 0xaf, 0x7b, 0xf0, 0x87, 0x4c, 0x5e, 0x6d,
-	0xd6, 0x56, 0xa5, 0x59, 0x05, 0x62, 0x76, 0x3e, 0x13, 0x88, 0x6d, 0x85, 0xe8, 0xa9, 0x73, 0x2a,
-	0x20, 0xe6, 0xda, 0x12, 0xb1, 0xa3, 0x10, 0xb5, 0xa2, 0x6c, 0xff, 0x1f, 0x01, 0x8e, 0xfd, 0xe4,
-	0x52, 0xa5, 0x25, 0x2a, 0xc5, 0xf3, 0x63, 0xfd, 0x7c, 0x88, 0xa5, 0x90, 0x90, 0x20, 0xd0, 0x41,
-	0x8b, 0xa5, 0xa0, 0x12, 0x69, 0x42, 0x3d, 0x1d, 0x9e, 0x5c, 0x0b, 0xd9, 0x28, 0xa6, 0x54, 0x47,
-	0x22, 0xd7, 0xf8, 0x57, 0x03, 0x96, 0x9e, 0xd3, 0xb1, 0x46, 0xde, 0x01, 0x38, 0x67, 0x31, 0x4b,
-	0xb9, 0x1f, 0xd2, 0x44, 0x3a, 0xe8, 0xd8, 0x13, 0x92, 0x7f, 0xef, 0x47, 0x52, 0x1b, 0x1a, 0x8c,
-	0x74, 0x72, 0x72, 0x2d, 0x64, 0x17, 0x94, 0x44, 0x7a, 0x7a, 0xca, 0xb5, 0x1c, 0x5a, 0x9c, 0xb8,
-	0x97, 0x72, 0x58, 0x8a, 0xa1, 0x25, 0x3e, 0x0e, 0xff, 0xe8, 0x41, 0x4f, 0x0f, 0x7f, 0xc9, 0xcc,
-	0xd0, 0xcf, 0xd0, 0x9d, 0x60, 0x74, 0xe8, 0xa3, 0x2a, 0x71, 0xab, 0x32, 0x44, 0x6b, 0x77, 0x86,
-	0x96, 0xee, 0x98, 0x39, 0x14, 0xc2, 0x7b, 0x15, 0xc6, 0x84, 0x1e, 0x54, 0xad, 0xa7, 0xf1, 0x31,
-	0xeb, 0x61, 0x23, 0xdd, 0xdc, 0x1f, 0x87, 0xd5, 0x1a, 0x0a, 0x84, 0xf6, 0x67, 0xa0, 0x14, 0x68,
-	0x98, 0xf5, 0xa8, 0xa1, 0x76, 0xee, 0xf5, 0x35, 0xa0, 0x2a, 0x3f, 0x42, 0x0f, 0x67, 0xc2, 0xdc,
-	0xf0, 0x2f, 0x6b, 0xbf, 0x99, 0xf2, 0xd4, 0x44, 0x15, 0x73, 0x9a, 0x99, 0x68, 0x81, 0x9b, 0xcd,
-	0x4c, 0xb4, 0x44, 0xc7, 0xe6, 0xd0, 0x25, 0xf4, 0xcb, 0xac, 0x0a, 0xed, 0x4d, 0xa3, 0xfa, 0x15,
-	0xd2, 0x66, 0x3d, 0x68, 0xa2, 0x9a, 0x3b, 0xa3, 0x70, 0xbb, 0xc8, 0x7c, 0xd0, 0xfd, 0xaa, 0x7d,
-	0x2d, 0x8f, 0xb3, 0x86, 0xb3, 0x15, 0x27, 0x73, 0x2a, 0xb3, 0xa1, 0xba, 0x9c, 0xa6, 0x50, 0xad,
-	0xba, 0x9c, 0xa6, 0x91, 0x2b, 0x3c, 0x87, 0x48, 0xd6, 0x7d, 0x8a, 0x33, 0xa0, 0xdd, 0x69, 0xd6,
-	0x05, 0x76, 0x62, 0xdd, 0x9b, 0xa5, 0x96, 0x39, 0xf8, 0xc4, 0x10, 0x0d, 0x3d, 0x41, 0x14, 0xea,
-	0x1a, 0xba, 0x4a, 0x3d, 0xac, 0xdd, 0x19, 0x5a, 0x79, 0x02, 0x67, 0xb0, 0x5c, 0xa0, 0x0e, 0x68,
-	0x6a, 0x68, 0x45, 0x42, 0x62, 0xdd, 0x9f, 0xa9, 0x97, 0xfb, 0x70, 0xb2, 0x43, 0xd2, 0x33, 0x69,
-	0x6a, 0x70, 0xc5, 0xa1, 0x74, 0x6f, 0x96, 0x5a, 0xee, 0xe0, 0x02, 0x56, 0x4a, 0x8f, 0x3c, 0xaa,
-	0xa9, 0x98, 0x7a, 0xf2, 0x62, 0xed, 0x35, 0xd0, 0xcc, 0x3d, 0xbd, 0x81, 0xb5, 0xba, 0xf7, 0x14,
-	0x3d, 0xaa, 0x03, 0x99, 0xfa, 0x68, 0x5b, 0x07, 0x4d, 0xd5, 0x73, 0xc7, 0x2f, 0x61, 0x31, 0x23,
-	0x0f, 0xe8, 0x6e, 0xd5, 0xba, 0x44, 0x64, 0x2c, 0xfc, 0x2e, 0x95, 0x9b, 0xe2, 0x3a, 0x9b, 0x97,
-	0x7f, 0x12, 0x1e, 0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x6a, 0xdf, 0xd7, 0x82, 0x60, 0x10, 0x00,
-	0x00,
+	// 2096 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x3a, 0x4b, 0x73, 0xdb, 0xc8,
+	0xd1, 0x0b, 0x91, 0x34, 0xc9, 0x26, 0xb9, 0xa2, 0x47, 0xb2, 0x44, 0x41, 0x8f, 0x95, 0xb1, 0x9f,
+	0xbd, 0xb2, 0xac, 0x87, 0x3f, 0x3b, 0x0f, 0x6f, 0x4e, 0xc9, 0xfa, 0x91, 0x52, 0x25, 0x5e, 0xa7,
+	0x20, 0xed, 0xd6, 0xd6, 0xae, 0xab, 0x50, 0x10, 0x30, 0xb2, 0x50, 0x04, 0x01, 0x2c, 0x30, 0x90,
+	0x45, 0x57, 0x25, 0xe7, 0xe4, 0x92, 0x43, 0x0e, 0x39, 0xe5, 0x96, 0xdf, 0x90, 0x5f, 0x90, 0x43,
+	0xf2, 0x13, 0x72, 0xcf, 0x39, 0x7f, 0x21, 0x95, 0x9a, 0x07, 0x40, 0x3c, 0xc5, 0x51, 0xac, 0xdc,
+	0x86, 0xfd, 0xee, 0x46, 0x77, 0xcf, 0x74, 0x4b, 0xb0, 0x74, 0xe1, 0xbb, 0xf1, 0x04, 0x1b, 0x11,
+	0x0e, 0x2f, 0x70, 0x78, 0x10, 0x84, 0x3e, 0xf1, 0xd1, 0x30, 0x07, 0x34, 0x82, 0x53, 0xed, 0x10,
+	0xd0, 0x17, 0x26, 0xb1, 0xce, 0x9f, 0x63, 0x17, 0x13, 0xac, 0xe3, 0xef, 0x63, 0x1c, 0x11, 0xb4,
+	0x06, 0x9d, 0x33, 0xc7, 0xc5, 0x86, 0x63, 0x47, 0x23, 0x65, 0xbb, 0xb1, 0xd3, 0xd5, 0xdb, 0xf4,
+	0xf7, 0x91, 0x1d, 0x69, 0xaf, 0x61, 0x29, 0xc7, 0x10, 0x05, 0xbe, 0x17, 0x61, 0xf4, 0x14, 0xda,
+	0x21, 0x8e, 0x62, 0x97, 0x70, 0x86, 0xde, 0xe3, 0xad, 0x83, 0xa2, 0xae, 0x83, 0x94, 0x25, 0x76,
+	0x89, 0x9e, 0x90, 0x6b, 0x0e, 0xf4, 0xb3, 0x08, 0xb4, 0x0a, 0x6d, 0xa1, 0x7b, 0xa4, 0x6c, 0x2b,
+	0x3b, 0x5d, 0xfd, 0x16, 0x57, 0x8d, 0x56, 0xe0, 0x56, 0x44, 0x4c, 0x12, 0x47, 0xa3, 0x85, 0x6d,
+	0x65, 0xa7, 0xa5, 0x8b, 0x5f, 0x68, 0x19, 0x5a, 0x38, 0x0c, 0xfd, 0x70, 0xd4, 0x60, 0xe4, 0xfc,
+	0x07, 0x42, 0xd0, 0x8c, 0x9c, 0xf7, 0x78, 0xd4, 0xdc, 0x56, 0x76, 0x06, 0x3a, 0x3b, 0x6b, 0x6d,
+	0x68, 0xbd, 0x98, 0x04, 0x64, 0xaa, 0xfd, 0x18, 0x46, 0x5f, 0x9b, 0x56, 0x1c, 0x4f, 0xbe, 0x66,
+	0x36, 0x3e, 0x3b, 0xc7, 0xd6, 0x38, 0xf1, 0x7d, 0x1d, 0xba, 0xc2, 0x72, 0x61, 0xc1, 0x40, 0xef,
+	0x70, 0xc0, 0x91, 0xad, 0xfd, 0x14, 0xd6, 0x2a, 0x18, 0x45, 0x0c, 0x3e, 0x85, 0xc1, 0x5b, 0x33,
+	0x3c, 0x35, 0xdf, 0x62, 0x23, 0x34, 0x89, 0xe3, 0x33, 0x6e, 0x45, 0xef, 0x0b, 0xa0, 0x4e, 0x61,
+	0xda, 0x77, 0xa0, 0xe6, 0x24, 0xf8, 0x93, 0xc0, 0xb4, 0x88, 0x8c, 0x72, 0xb4, 0x0d, 0xbd, 0x20,
+	0xc4, 0xa6, 0xeb, 0xfa, 0x96, 0x49, 0x30, 0x8b, 0x42, 0x43, 0xcf, 0x82, 0xb4, 0x4d, 0x58, 0xaf,
+	0x14, 0xce, 0x0d, 0xd4, 0x9e, 0x16, 0xac, 0xf7, 0x27, 0x13, 0x47, 0x4a, 0xb5, 0xb6, 0x01, 0x6a,
+	0x15, 0xa7, 0x90, 0xfb, 0x79, 0x01, 0xeb, 0x62, 0xd3, 0x8b, 0x03, 0x29, 0xc1, 0x45, 0x8b, 0x13,
+	0xd6, 0x54, 0xf2, 0x2a, 0x4f, 0x8e, 0x67, 0xbe, 0xeb, 0x62, 0x8b, 0x38, 0xbe, 0x97, 0x88, 0xdd,
+	0x02, 0xb0, 0x52, 0xa0, 0x48, 0x95, 0x0c, 0x44, 0x53, 0x61, 0x54, 0x66, 0x15, 0x62, 0xff, 0xa6,
+	0xc0, 0x9d, 0x9f, 0x89, 0xa0, 0x71, 0xc5, 0x52, 0x1f, 0x20, 0xaf, 0x72, 0xa1, 0xa8, 0xb2, 0xf8,
+	0x81, 0x1a, 0xa5, 0x0f, 0x44, 0x29, 0x42, 0x1c, 0xb8, 0x8e, 0x65, 0x32, 0x11, 0x4d, 0x26, 0x22,
+	0x0b, 0x42, 0x43, 0x68, 0x10, 0xe2, 0x8e, 0x5a, 0x0c, 0x43, 0x8f, 0xd4, 0x24, 0xdb, 0x89, 0xc6,
+	0x06, 0x99, 0x06, 0x78, 0x74, 0x8b, 0xc1, 0x3b, 0x14, 0x70, 0x32, 0x0d, 0xb0, 0x36, 0x82, 0x95,
+	0xa2, 0x23, 0xc2, 0xc7, 0x1f, 0xc1, 0x2a, 0x87, 0x1c, 0x4f, 0x3d, 0xeb, 0x98, 0x95, 0x8a, 0xd4,
+	0x17, 0xf9, 0xcb, 0x02, 0x8c, 0xca, 0x8c, 0x22, 0xc5, 0x3f, 0x34, 0x3c, 0xd7, 0x76, 0xfe, 0x13,
+	0xe8, 0x11, 0xd3, 0x71, 0x0d, 0xff, 0xec, 0x2c, 0xc2, 0x84, 0xb9, 0xdf, 0xd4, 0x81, 0x82, 0x5e,
+	0x33, 0x08, 0x7a, 0x00, 0x43, 0x8b, 0xa7, 0xb9, 0x11, 0xe2, 0x0b, 0x27, 0xa2, 0x92, 0xdb, 0xcc,
+	0xb0, 0x45, 0x2b, 0x49, 0x7f, 0x0e, 0x46, 0x1a, 0x0c, 0x1c, 0xfb, 0xd2, 0x60, 0xdd, 0x85, 0xf5,
+	0x86, 0x0e, 0x93, 0xd6, 0x73, 0xec, 0xcb, 0x97, 0x8e, 0x8b, 0x8f, 0x9d, 0xf7, 0x38, 0x1f, 0xec,
+	0x6e, 0x3e, 0xd8, 0x14, 0x19, 0x62, 0xd3, 0x36, 0x7c, 0xcf, 0x9d, 0x8e, 0x60, 0x5b, 0xd9, 0xe9,
+	0xe8, 0x1d, 0x0a, 0x78, 0xed, 0xb9, 0x53, 0xed, 0x8f, 0x0a, 0x2c, 0xf1, 0xb8, 0xbd, 0xf4, 0x5d,
+	0xd7, 0x7f, 0x27, 0x95, 0x51, 0xcb, 0xd0, 0x8a, 0x1c, 0xcf, 0xe2, 0xc5, 0xdc, 0xd4, 0xf9, 0x0f,
+	0x74, 0x17, 0xfa, 0xec, 0x90, 0x78, 0xdd, 0xe0, 0x76, 0x32, 0xd8, 0x15, 0x6e, 0x37, 0x2b, 0xdd,
+	0xd6, 0x3e, 0x87, 0xe5, 0xbc, 0x5d, 0xe2, 0x5b, 0xde, 0x85, 0x3e, 0x0b, 0x85, 0xe5, 0x7b, 0x04,
+	0x7b, 0x84, 0xd9, 0xd6, 0xd7, 0x7b, 0x14, 0xf6, 0x8c, 0x83, 0xb4, 0x3f, 0x29, 0xb0, 0xc6, 0x79,
+	0x4f, 0x4c, 0xc7, 0xd5, 0xb1, 0x85, 0x9d, 0x0b, 0x1c, 0x4a, 0x79, 0xf6, 0x08, 0x96, 0x23, 0x3f,
+	0x0e, 0x2d, 0x6c, 0xe4, 0xee, 0x01, 0x91, 0x16, 0x88, 0xe3, 0x44, 0x9e, 0x31, 0x0c, 0xe5, 0x70,
+	0x6c, 0x17, 0x1b, 0xc4, 0x99, 0x60, 0x3f, 0x26, 0x46, 0x84, 0x2d, 0xdf, 0xb3, 0x23, 0xe6, 0xfd,
+	0x40, 0x47, 0x14, 0x77, 0xc2, 0x51, 0xc7, 0x1c, 0xc3, 0xba, 0x52, 0x85, 0x75, 0xa2, 0x00, 0xfe,
+	0x1f, 0x10, 0xc7, 0xbe, 0xf2, 0x63, 0x4f, 0xae, 0xcd, 0xdd, 0x81, 0xa5, 0x1c, 0x8b, 0x90, 0xf4,
+	0x24, 0x89, 0xe0, 0x57, 0xde, 0x44, 0x5a, 0xd6, 0x2a, 0xdc, 0x29, 0x30, 0x09, 0x69, 0xdf, 0x26,
+	0x4a, 0xf2, 0x77, 0xee, 0x95, 0xd1, 0xbc, 0x0f, 0x8b, 0xb4, 0x87, 0xbc, 0x33, 0x66, 0xf9, 0xb7,
+	0xc0, 0xf2, 0x6f, 0x60, 0xf2, 0x6f, 0x2a, 0x92, 0x70, 0x05, 0x96, 0xf3, 0xb2, 0x85, 0xce, 0x7f,
+	0x29, 0xb0, 0xa2, 0x8b, 0x42, 0xbb, 0xe1, 0x8e, 0x97, 0x2d, 0xe9, 0x46, 0x6d, 0x49, 0x37, 0x67,
+	0x25, 0xbd, 0x03, 0x43, 0x91, 0x19, 0xb6, 0x49, 0x4c, 0xc3, 0xf3, 0x6d, 0x2c, 0x2a, 0xfe, 0x63,
+	0x0e, 0x7f, 0x6e, 0x12, 0xf3, 0x4b, 0xdf, 0xc6, 0x57, 0x76, 0xbe, 0x7c, 0x31, 0xb6, 0x0b, 0xc5,
+	0xb8, 0x06, 0xab, 0x25, 0x77, 0x45, 0x28, 0xfe, 0xa9, 0xc0, 0xe2, 0x33, 0x3f, 0x98, 0xd2, 0x92,
+	0x97, 0x8c, 0x41, 0xcf, 0x89, 0x8c, 0xa4, 0x73, 0x88, 0xb8, 0x77, 0x9d, 0xe8, 0x88, 0xb7, 0x0d,
+	0x81, 0xb7, 0x4d, 0xc2, 0xf1, 0x8d, 0x04, 0xff, 0xdc, 0x24, 0x0c, 0x3f, 0x84, 0x06, 0xbe, 0x24,
+	0x49, 0x04, 0xf0, 0x65, 0xf1, 0xea, 0x6a, 0x55, 0x44, 0xb5, 0xef, 0x44, 0x06, 0xb6, 0x44, 0xe9,
+	0x30, 0xd7, 0x3b, 0x3a, 0x38, 0xd1, 0x0b, 0x8b, 0x3b, 0x43, 0xdb, 0x62, 0x44, 0xfc, 0x20, 0x69,
+	0x10, 0x6d, 0xde, 0x16, 0x29, 0x88, 0xf7, 0x07, 0x4d, 0x87, 0xe1, 0xcc, 0x49, 0xe9, 0x82, 0xa7,
+	0x81, 0x98, 0xb5, 0x47, 0xde, 0x93, 0x3a, 0x67, 0xa2, 0x37, 0x6a, 0x3f, 0x81, 0x75, 0x9a, 0x68,
+	0xa2, 0x99, 0x50, 0xa8, 0xfc, 0xad, 0xf2, 0x6f, 0x05, 0x36, 0xaa, 0x99, 0x65, 0x6e, 0x96, 0x3d,
+	0x40, 0x69, 0xe7, 0xa6, 0xed, 0x21, 0x22, 0xe6, 0x24, 0x10, 0xf6, 0x0d, 0x45, 0xfb, 0x3e, 0x49,
+	0xe0, 0xe5, 0x3e, 0xdf, 0x28, 0xf7, 0xf9, 0x3d, 0x40, 0xc9, 0x17, 0xcb, 0x48, 0x6c, 0x72, 0x89,
+	0xb6, 0x49, 0x4a, 0x12, 0x53, 0x6a, 0x26, 0xb1, 0xc5, 0x25, 0x0a, 0x42, 0x26, 0x71, 0x13, 0x40,
+	0x44, 0x37, 0xf6, 0x92, 0x8b, 0xaa, 0xcb, 0x63, 0x1b, 0x7b, 0x84, 0xbd, 0xbd, 0x78, 0x6b, 0x31,
+	0xc3, 0x31, 0x8d, 0x04, 0xcd, 0x5b, 0xe9, 0xb7, 0x57, 0x05, 0xa7, 0x48, 0xe7, 0x37, 0xb0, 0xc9,
+	0xb1, 0x2f, 0xac, 0xe3, 0x73, 0x33, 0xb4, 0xa3, 0x9f, 0x63, 0x0f, 0x87, 0x26, 0xb9, 0x91, 0xfa,
+	0xd6, 0xb6, 0x61, 0xab, 0x4e, 0xba, 0xd0, 0xff, 0x1d, 0x6c, 0xe4, 0x29, 0x74, 0x7c, 0x1a, 0x3b,
+	0xae, 0x7d, 0x23, 0xea, 0x7f, 0x01, 0x9b, 0x35, 0xc2, 0x45, 0xd6, 0xec, 0xc2, 0xed, 0x90, 0x81,
+	0x88, 0x11, 0x51, 0x82, 0x74, 0x62, 0x19, 0xe8, 0x8b, 0x02, 0xc1, 0x18, 0xe9, 0xe4, 0xf2, 0xd7,
+	0xf4, 0x32, 0x4b, 0xa4, 0xd1, 0x0a, 0xb9, 0x91, 0x36, 0xb8, 0x0e, 0xdd, 0x99, 0xfa, 0x06, 0x53,
+	0xdf, 0x89, 0x84, 0x5e, 0x9a, 0x3c, 0x96, 0x1f, 0x4c, 0x0d, 0x6c, 0x89, 0x0e, 0xd2, 0x64, 0xe5,
+	0xdc, 0xa3, 0xc0, 0x17, 0x16, 0xef, 0x21, 0xd2, 0x3d, 0x71, 0x96, 0x0d, 0x79, 0x27, 0xc4, 0xd7,
+	0x78, 0x07, 0xeb, 0x79, 0xec, 0x35, 0xee, 0x98, 0x0f, 0x71, 0x52, 0xdb, 0x82, 0x8d, 0x6a, 0xc5,
+	0xc2, 0xb0, 0x8b, 0xa2, 0xd9, 0xd2, 0x97, 0xf2, 0x87, 0xd9, 0xb5, 0x09, 0xeb, 0x95, 0x7a, 0x85,
+	0x59, 0xdf, 0x14, 0xcd, 0xbe, 0xc6, 0x0d, 0x7f, 0xb5, 0xe2, 0x4f, 0x60, 0xb3, 0x46, 0xb2, 0x50,
+	0xfd, 0x1b, 0x18, 0xe5, 0x08, 0x68, 0x65, 0x4b, 0xa9, 0x5d, 0x83, 0x4e, 0xa2, 0x96, 0x45, 0x63,
+	0xa0, 0xb7, 0x85, 0x56, 0x3a, 0x22, 0x67, 0x9e, 0x8c, 0x0d, 0x5d, 0xfc, 0xca, 0x0d, 0xc3, 0x0d,
+	0x31, 0x0c, 0x1f, 0xc2, 0x5a, 0x85, 0x7e, 0x51, 0x57, 0x08, 0x9a, 0x34, 0x11, 0xc5, 0x15, 0xc1,
+	0xce, 0xda, 0x3f, 0x14, 0x00, 0x1d, 0x4f, 0x7c, 0xc2, 0xda, 0x37, 0xbd, 0x4d, 0x4e, 0x4d, 0x6b,
+	0x8c, 0x3d, 0x9b, 0xdf, 0xcf, 0x7c, 0x02, 0xeb, 0x09, 0x18, 0xbb, 0xa2, 0x37, 0x01, 0x12, 0x12,
+	0x61, 0x6b, 0x57, 0xef, 0x0a, 0xc8, 0x91, 0x4d, 0x2f, 0xc6, 0x31, 0x9e, 0x8a, 0x47, 0x03, 0x3d,
+	0x66, 0xec, 0xe7, 0x9d, 0x38, 0xb1, 0x3f, 0x77, 0x2d, 0xb5, 0xf2, 0xd7, 0x12, 0x1d, 0xbb, 0x27,
+	0xbe, 0xed, 0x9c, 0x39, 0xd8, 0x66, 0xad, 0x5c, 0xf4, 0xde, 0x7e, 0x02, 0xa4, 0x6d, 0x1c, 0x6d,
+	0x40, 0x17, 0x5f, 0x12, 0xec, 0xa5, 0xf3, 0x41, 0x57, 0x9f, 0x01, 0xb4, 0x6f, 0x01, 0x78, 0x2c,
+	0x8e, 0xbc, 0x33, 0x1f, 0x3d, 0x86, 0x16, 0x15, 0x9e, 0x6c, 0x32, 0x36, 0xca, 0x9b, 0x8c, 0x59,
+	0x18, 0x74, 0x4e, 0x8a, 0x46, 0xd0, 0xbe, 0xc0, 0x61, 0x94, 0x64, 0xe8, 0x40, 0x4f, 0x7e, 0x6a,
+	0x7f, 0x57, 0x60, 0x5b, 0xbc, 0x52, 0x1d, 0x1c, 0xbe, 0xf2, 0x2f, 0x68, 0x2d, 0x9f, 0xf8, 0x5c,
+	0xc4, 0x8d, 0x14, 0xc0, 0x53, 0x18, 0xd9, 0x38, 0x22, 0x8e, 0xc7, 0x5e, 0x5c, 0x46, 0x12, 0x72,
+	0xcf, 0x9c, 0x60, 0x11, 0xdc, 0x95, 0x0c, 0xfe, 0x0b, 0x8e, 0xfe, 0xd2, 0x9c, 0x60, 0xb4, 0x0f,
+	0x4b, 0x63, 0x8c, 0x03, 0x83, 0x0e, 0x90, 0xee, 0xec, 0x09, 0xc3, 0x1b, 0xd4, 0x90, 0xa2, 0x7e,
+	0x49, 0x31, 0xe2, 0x25, 0xa3, 0x45, 0x70, 0xf7, 0x0a, 0x4f, 0x44, 0xea, 0x6c, 0x40, 0x37, 0x08,
+	0x7d, 0x0b, 0x47, 0x11, 0xe6, 0xae, 0x34, 0xf4, 0x19, 0x00, 0x3d, 0x82, 0xa5, 0xf4, 0xc7, 0xaf,
+	0x70, 0x68, 0x61, 0x8f, 0x98, 0x6f, 0xf9, 0x53, 0x63, 0x41, 0xaf, 0x42, 0x69, 0x7f, 0x50, 0x40,
+	0x2b, 0x69, 0x7d, 0x19, 0xfa, 0x93, 0x1b, 0x8c, 0xe0, 0x21, 0x2c, 0xb3, 0x38, 0x84, 0x4c, 0x64,
+	0xf1, 0x2d, 0x77, 0x9b, 0xe2, 0xb8, 0xb6, 0x24, 0x12, 0x31, 0x7c, 0x7a, 0xa5, 0x4d, 0xff, 0xa3,
+	0x58, 0x7c, 0x03, 0xf0, 0xdc, 0x89, 0xc6, 0xfc, 0xe9, 0x44, 0xeb, 0xc7, 0x76, 0x42, 0x51, 0x78,
+	0xf4, 0x48, 0x21, 0xa6, 0xeb, 0x8a, 0x87, 0x11, 0x3d, 0xd2, 0x42, 0x8e, 0xa9, 0x72, 0xfe, 0x04,
+	0x62, 0x67, 0x0a, 0x3b, 0x0b, 0x31, 0x16, 0x35, 0xc6, 0xce, 0xda, 0x9f, 0x15, 0xe8, 0xbe, 0xc2,
+	0x13, 0x21, 0x79, 0x0b, 0xe0, 0xad, 0x1f, 0xfa, 0x31, 0x71, 0x3c, 0x56, 0x06, 0x74, 0xdd, 0x96,
+	0x81, 0xfc, 0xf7, 0x7a, 0x28, 0x2c, 0xc2, 0xee, 0x99, 0x28, 0x62, 0x76, 0xa6, 0xb0, 0x73, 0x6c,
+	0x06, 0xa2, 0x6e, 0xd9, 0x99, 0x0d, 0xc6, 0xc4, 0xb4, 0xc6, 0xe2, 0x69, 0xcb, 0x7f, 0x3c, 0xfe,
+	0xdd, 0x2a, 0xf4, 0x73, 0x33, 0xe3, 0x1b, 0xe8, 0x65, 0xb6, 0x91, 0xe8, 0xff, 0xca, 0xa5, 0x5a,
+	0xde, 0x6e, 0xaa, 0xf7, 0xe6, 0x50, 0x89, 0x06, 0xfd, 0x11, 0xf2, 0xe0, 0x76, 0x69, 0xdb, 0x87,
+	0x76, 0xcb, 0xdc, 0x75, 0xbb, 0x44, 0xf5, 0xa1, 0x14, 0x6d, 0xaa, 0x8f, 0xc0, 0x52, 0xc5, 0xfa,
+	0x0e, 0xed, 0xcd, 0x91, 0x92, 0x5b, 0x21, 0xaa, 0xfb, 0x92, 0xd4, 0xa9, 0xd6, 0xef, 0x01, 0x95,
+	0x77, 0x7b, 0xe8, 0xe1, 0x5c, 0x31, 0xb3, 0xdd, 0xa1, 0xba, 0x27, 0x47, 0x5c, 0xeb, 0x28, 0xdf,
+	0xfa, 0xcd, 0x75, 0x34, 0xb7, 0x57, 0x54, 0xf7, 0x25, 0xa9, 0x53, 0xad, 0x63, 0x18, 0x16, 0x37,
+	0x82, 0xe8, 0x41, 0xdd, 0x9a, 0xba, 0xb4, 0x70, 0x54, 0x77, 0x65, 0x48, 0x53, 0x65, 0x18, 0x3e,
+	0xce, 0x2f, 0xe6, 0xd0, 0x67, 0x65, 0xfe, 0xca, 0x1d, 0xa4, 0xba, 0x33, 0x9f, 0x30, 0xeb, 0x53,
+	0x71, 0x59, 0x57, 0xe5, 0x53, 0xcd, 0x26, 0x50, 0xdd, 0x95, 0x21, 0x4d, 0x95, 0x99, 0xd0, 0xcf,
+	0x6e, 0x92, 0xd0, 0xbd, 0x3a, 0xee, 0xdc, 0x06, 0x4c, 0xbd, 0x3f, 0x8f, 0x2c, 0x51, 0xf0, 0x48,
+	0x61, 0xc9, 0x58, 0x5a, 0xe9, 0x54, 0x26, 0x63, 0xdd, 0x5a, 0x4a, 0xdd, 0x93, 0x23, 0x4e, 0xbd,
+	0x7a, 0x03, 0xbd, 0xcc, 0xd2, 0xa7, 0xaa, 0x87, 0x94, 0xd7, 0x48, 0xea, 0xbd, 0x39, 0x54, 0xa9,
+	0xf4, 0x53, 0x18, 0xe4, 0xd6, 0x40, 0xa8, 0x36, 0x1a, 0xf9, 0xa7, 0xa7, 0xfa, 0xd9, 0x5c, 0xba,
+	0x54, 0x87, 0x91, 0x7c, 0x17, 0xd1, 0x06, 0x6b, 0x8d, 0xcb, 0xf7, 0xc1, 0xfb, 0xf3, 0xc8, 0x52,
+	0x05, 0xe7, 0xb0, 0x58, 0x58, 0xa7, 0xa0, 0x9d, 0xaa, 0x57, 0x51, 0xd5, 0x82, 0x49, 0x7d, 0x20,
+	0x41, 0x99, 0x6a, 0x7a, 0x07, 0xcb, 0x55, 0x6b, 0x02, 0xb4, 0x5f, 0x25, 0xa4, 0x76, 0x17, 0xa1,
+	0x1e, 0xc8, 0x92, 0xa7, 0x8a, 0xbf, 0x82, 0x4e, 0xb2, 0x30, 0x41, 0x77, 0xcb, 0xdc, 0x85, 0x8d,
+	0x91, 0xaa, 0x5d, 0x45, 0x52, 0x95, 0xcf, 0xd9, 0xe1, 0xbd, 0x3e, 0x9f, 0x2b, 0x96, 0x03, 0xf5,
+	0xf9, 0x5c, 0xb9, 0x0f, 0xf8, 0x08, 0xfd, 0x1a, 0x56, 0xaa, 0x67, 0x76, 0x74, 0x58, 0x27, 0xa9,
+	0x66, 0x77, 0xa0, 0x3e, 0x92, 0x67, 0x48, 0xd5, 0xbf, 0x87, 0x3b, 0x79, 0x1a, 0x31, 0xb3, 0xa3,
+	0x83, 0x79, 0xc2, 0xf2, 0x9b, 0x03, 0xf5, 0x50, 0x9a, 0x3e, 0x77, 0x95, 0x95, 0x86, 0xe3, 0xfa,
+	0x68, 0x57, 0xec, 0x01, 0xd4, 0x3d, 0x39, 0xe2, 0x6c, 0xc2, 0x56, 0x0d, 0xbe, 0x55, 0x09, 0x7b,
+	0xc5, 0x64, 0xae, 0x1e, 0xc8, 0x92, 0xe7, 0xee, 0xd0, 0xf2, 0x64, 0x8b, 0xe6, 0xda, 0x9f, 0x6b,
+	0x63, 0xfb, 0x92, 0xd4, 0xf5, 0x5f, 0x37, 0x69, 0x6b, 0x73, 0x1d, 0x28, 0xb4, 0xb7, 0x43, 0x69,
+	0xfa, 0x54, 0x77, 0x00, 0xb7, 0x4b, 0x13, 0x2b, 0xda, 0x9d, 0x23, 0x27, 0x33, 0x56, 0xab, 0x0f,
+	0xa5, 0x68, 0x33, 0xd5, 0xfb, 0xdb, 0xd9, 0xdf, 0x3f, 0xca, 0x13, 0x0f, 0x7a, 0x5c, 0x7b, 0xd1,
+	0xd4, 0x0e, 0x7a, 0xea, 0x93, 0x6b, 0xf1, 0x64, 0x4c, 0xf9, 0xbd, 0x02, 0xeb, 0x25, 0xca, 0xd9,
+	0xc8, 0x81, 0x7e, 0x20, 0x21, 0xb8, 0x34, 0x35, 0xa9, 0x3f, 0xbc, 0x26, 0xd7, 0xcc, 0xa0, 0xd3,
+	0x5b, 0xec, 0x5f, 0x0a, 0x9e, 0xfc, 0x67, 0x00, 0xce, 0x6f, 0x92, 0x04, 0x69, 0x20, 0x00, 0x00,
 }
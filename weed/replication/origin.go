@@ -0,0 +1,55 @@
+package replication
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+// OriginExtendedKey tags a replicated entry with the filer where the change was made,
+// so the change is not replicated back to where it came from.
+const OriginExtendedKey = "seaweedfs.sync.origin"
+
+// MarkOrigin returns a copy of the entry, tagged as changed on the origin filer
+func MarkOrigin(entry *filer_pb.Entry, origin string) *filer_pb.Entry {
+	if entry == nil {
+		return nil
+	}
+	var mtime int64
+	if entry.Attributes != nil {
+		mtime = entry.Attributes.Mtime
+	}
+
+	marked := proto.Clone(entry).(*filer_pb.Entry)
+	if marked.Extended == nil {
+		marked.Extended = make(map[string][]byte)
+	}
+	marked.Extended[OriginExtendedKey] = []byte(fmt.Sprintf("%s@%d", origin, mtime))
+
+	return marked
+}
+
+// IsFromOrigin checks whether the entry is exactly what was replicated from the origin filer.
+// The entry is not considered from the origin any more if it is modified locally after the replication.
+func IsFromOrigin(entry *filer_pb.Entry, origin string) bool {
+	if entry == nil || entry.Attributes == nil {
+		return false
+	}
+	value, found := entry.Extended[OriginExtendedKey]
+	if !found {
+		return false
+	}
+	tag := string(value)
+	atIndex := strings.LastIndex(tag, "@")
+	if atIndex < 0 || tag[:atIndex] != origin {
+		return false
+	}
+	mtime, err := strconv.ParseInt(tag[atIndex+1:], 10, 64)
+	if err != nil {
+		return false
+	}
+	return mtime == entry.Attributes.Mtime
+}
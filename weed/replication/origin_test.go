@@ -0,0 +1,45 @@
+package replication
+
+import (
+	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestOrigin(t *testing.T) {
+	entry := &filer_pb.Entry{
+		Name:       "/a/b.txt",
+		Attributes: &filer_pb.FuseAttributes{Mtime: 1000},
+		Extended:   map[string][]byte{"user.k": []byte("v")},
+
+		HardLinkId:      []byte{1, 2, 3},
+		HardLinkCounter: 2,
+	}
+
+	if IsFromOrigin(entry, "filerA:8888") {
+		t.Errorf("untagged entry should not be from any origin")
+	}
+
+	marked := MarkOrigin(entry, "filerA:8888")
+	if _, found := entry.Extended[OriginExtendedKey]; found {
+		t.Errorf("the original entry should not be changed")
+	}
+	if string(marked.Extended["user.k"]) != "v" {
+		t.Errorf("other extended attributes should be kept")
+	}
+	if string(marked.HardLinkId) != string(entry.HardLinkId) || marked.HardLinkCounter != 2 {
+		t.Errorf("the hard link should be kept: %v", marked)
+	}
+	if !IsFromOrigin(marked, "filerA:8888") {
+		t.Errorf("marked entry should be from filerA:8888")
+	}
+	if IsFromOrigin(marked, "filerB:8888") {
+		t.Errorf("marked entry should not be from filerB:8888")
+	}
+
+	// modified locally after the replication
+	marked.Attributes = &filer_pb.FuseAttributes{Mtime: 1001}
+	if IsFromOrigin(marked, "filerA:8888") {
+		t.Errorf("locally modified entry should not be from filerA:8888")
+	}
+}
@@ -22,6 +22,11 @@ func NewReplicator(sourceConfig util.Configuration, dataSink sink.ReplicationSin
 	source := &source.FilerSource{}
 	source.Initialize(sourceConfig)
 
+	return NewFilerSourceReplicator(source, dataSink)
+}
+
+func NewFilerSourceReplicator(source *source.FilerSource, dataSink sink.ReplicationSink) *Replicator {
+
 	dataSink.SetSourceFiler(source)
 
 	return &Replicator{
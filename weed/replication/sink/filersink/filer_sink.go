@@ -25,6 +25,7 @@ type FilerSink struct {
 	ttlSec         int32
 	dataCenter     string
 	grpcDialOption grpc.DialOption
+	origin         string
 }
 
 func init() {
@@ -53,14 +54,25 @@ func (fs *FilerSink) SetSourceFiler(s *source.FilerSource) {
 	fs.filerSource = s
 }
 
+// SetOrigin tags the deletions with the filer where they were made
+func (fs *FilerSink) SetOrigin(origin string) {
+	fs.origin = origin
+}
+
 func (fs *FilerSink) initialize(grpcAddress string, dir string,
 	replication string, collection string, ttlSec int) (err error) {
+	return fs.DoInitialize(grpcAddress, dir, replication, collection, ttlSec,
+		security.LoadClientTLS(viper.Sub("grpc"), "client"))
+}
+
+func (fs *FilerSink) DoInitialize(grpcAddress string, dir string,
+	replication string, collection string, ttlSec int, grpcDialOption grpc.DialOption) (err error) {
 	fs.grpcAddress = grpcAddress
 	fs.dir = dir
 	fs.replication = replication
 	fs.collection = collection
 	fs.ttlSec = int32(ttlSec)
-	fs.grpcDialOption = security.LoadClientTLS(viper.Sub("grpc"), "client")
+	fs.grpcDialOption = grpcDialOption
 	return nil
 }
 
@@ -69,10 +81,22 @@ func (fs *FilerSink) DeleteEntry(ctx context.Context, key string, isDirectory, d
 
 		dir, name := filer2.FullPath(key).DirAndName()
 
+		// skip if already deleted
+		// this usually happens when retrying the replication, or when the deletion is replicated back
+		lookupRequest := &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		}
+		if _, err := client.LookupDirectoryEntry(ctx, lookupRequest); err != nil {
+			glog.V(1).Infof("already deleted %s: %v", key, err)
+			return nil
+		}
+
 		request := &filer_pb.DeleteEntryRequest{
 			Directory:    dir,
 			Name:         name,
 			IsDeleteData: deleteIncludeChunks,
+			Origin:       fs.origin,
 		}
 
 		glog.V(1).Infof("delete entry: %v", request)
@@ -121,6 +145,7 @@ func (fs *FilerSink) CreateEntry(ctx context.Context, key string, entry *filer_p
 				IsDirectory: entry.IsDirectory,
 				Attributes:  entry.Attributes,
 				Chunks:      replicatedChunks,
+				Extended:    entry.Extended,
 			},
 		}
 
@@ -191,6 +216,12 @@ func (fs *FilerSink) UpdateEntry(ctx context.Context, key string, oldEntry, newE
 		existingEntry.Chunks = append(existingEntry.Chunks, replicatedChunks...)
 	}
 
+	if existingEntry.Attributes.Mtime <= newEntry.Attributes.Mtime {
+		// keep the attributes and the extended attributes in sync with the source
+		existingEntry.Attributes = newEntry.Attributes
+		existingEntry.Extended = newEntry.Extended
+	}
+
 	// save updated meta data
 	return true, fs.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 
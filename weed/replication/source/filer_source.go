@@ -34,9 +34,13 @@ func (fs *FilerSource) Initialize(configuration util.Configuration) error {
 }
 
 func (fs *FilerSource) initialize(grpcAddress string, dir string) (err error) {
+	return fs.DoInitialize(grpcAddress, dir, security.LoadClientTLS(viper.Sub("grpc"), "client"))
+}
+
+func (fs *FilerSource) DoInitialize(grpcAddress string, dir string, grpcDialOption grpc.DialOption) (err error) {
 	fs.grpcAddress = grpcAddress
 	fs.Dir = dir
-	fs.grpcDialOption = security.LoadClientTLS(viper.Sub("grpc"), "client")
+	fs.grpcDialOption = grpcDialOption
 	return nil
 }
 
@@ -0,0 +1,219 @@
+package s3api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/gorilla/mux"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/iam_pb"
+)
+
+type Action string
+
+const (
+	ACTION_READ  Action = "Read"
+	ACTION_WRITE Action = "Write"
+	ACTION_ADMIN Action = "Admin"
+	ACTION_LIST  Action = "List"
+
+	// the identity without any credentials, used for the requests without signatures
+	anonymousIdentityName = "anonymous"
+)
+
+type IdentityAccessManagement struct {
+	identities []*Identity
+}
+
+type Identity struct {
+	Name        string
+	Credentials []*Credential
+	Actions     []Action
+	// the uid and gids to access the filer as, nil to access without the permission checks
+	Posix *filer2.Identity
+}
+
+type Credential struct {
+	AccessKey string
+	SecretKey string
+}
+
+type identityContextKey struct{}
+
+// NewIdentityAccessManagement loads the identities from the json config file
+// Without the config file, all requests are allowed.
+func NewIdentityAccessManagement(fileName string) (*IdentityAccessManagement, error) {
+	iam := &IdentityAccessManagement{}
+	if fileName == "" {
+		return iam, nil
+	}
+	if err := iam.loadS3ApiConfiguration(fileName); err != nil {
+		return nil, err
+	}
+	return iam, nil
+}
+
+func (iam *IdentityAccessManagement) loadS3ApiConfiguration(fileName string) error {
+
+	rawData, readErr := ioutil.ReadFile(fileName)
+	if readErr != nil {
+		return fmt.Errorf("read %s: %v", fileName, readErr)
+	}
+
+	s3ApiConfiguration := &iam_pb.Identities{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(rawData), s3ApiConfiguration); err != nil {
+		return fmt.Errorf("unmarshal %s: %v", fileName, err)
+	}
+
+	for _, ident := range s3ApiConfiguration.Identities {
+		t := &Identity{
+			Name: ident.Name,
+		}
+		if ident.Posix != nil {
+			t.Posix = &filer2.Identity{
+				Uid:  ident.Posix.Uid,
+				Gids: ident.Posix.Gids,
+			}
+		}
+		for _, action := range ident.Actions {
+			t.Actions = append(t.Actions, Action(action))
+		}
+		for _, cred := range ident.Credentials {
+			t.Credentials = append(t.Credentials, &Credential{
+				AccessKey: cred.AccessKey,
+				SecretKey: cred.SecretKey,
+			})
+		}
+		iam.identities = append(iam.identities, t)
+	}
+
+	glog.V(0).Infof("loaded %d s3 identities from %s", len(iam.identities), fileName)
+
+	return nil
+}
+
+func (iam *IdentityAccessManagement) isEnabled() bool {
+	return len(iam.identities) > 0
+}
+
+func (iam *IdentityAccessManagement) lookupByAccessKey(accessKey string) (identity *Identity, cred *Credential, found bool) {
+	for _, ident := range iam.identities {
+		for _, cred := range ident.Credentials {
+			if cred.AccessKey == accessKey {
+				return ident, cred, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+func (iam *IdentityAccessManagement) lookupAnonymous() (identity *Identity, found bool) {
+	for _, ident := range iam.identities {
+		if ident.Name == anonymousIdentityName {
+			return ident, true
+		}
+	}
+	return nil, false
+}
+
+// Auth wraps the handler with the signature verification and the permission check of the action
+func (iam *IdentityAccessManagement) Auth(f http.HandlerFunc, action Action) http.HandlerFunc {
+
+	if !iam.isEnabled() {
+		return f
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, errCode := iam.authRequest(r, action)
+		if errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+		f(w, r.WithContext(context.WithValue(r.Context(), identityContextKey{}, identity)))
+	}
+}
+
+// authRequest verifies the request signature, and checks whether the identity can do the action on the bucket
+func (iam *IdentityAccessManagement) authRequest(r *http.Request, action Action) (*Identity, ErrorCode) {
+
+	var identity *Identity
+	var errCode ErrorCode
+	var found bool
+
+	switch getRequestAuthType(r) {
+	case authTypeStreamingSigned, authTypeSigned, authTypePresigned:
+		// the chunk signatures of the streaming upload are verified while reading the body
+		identity, errCode = iam.reqSignatureV4Verify(r)
+	case authTypeSignedV2, authTypePresignedV2:
+		identity, errCode = iam.isReqAuthenticatedV2(r)
+	case authTypeAnonymous:
+		identity, found = iam.lookupAnonymous()
+		if !found {
+			errCode = ErrAccessDenied
+		}
+	case authTypeJWT, authTypePostPolicy:
+		errCode = ErrSignatureVersionNotSupported
+	default:
+		errCode = ErrAccessDenied
+	}
+
+	if errCode != ErrNone {
+		glog.V(3).Infof("auth %s %s: %v", r.Method, r.URL.Path, getAPIError(errCode).Code)
+		return nil, errCode
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if !identity.canDo(action, bucket) {
+		glog.V(3).Infof("identity %s can not %s bucket %s", identity.Name, action, bucket)
+		return nil, ErrAccessDenied
+	}
+
+	return identity, ErrNone
+}
+
+// canDo checks the actions of the identity, e.g., "Read" for all buckets, or "Read:bucket1" for one bucket
+// Without the bucket, e.g., to list all buckets, any bucket level permission of the action is enough.
+func (identity *Identity) canDo(action Action, bucket string) bool {
+	for _, a := range identity.Actions {
+		if a == ACTION_ADMIN || a == action {
+			return true
+		}
+	}
+	for _, a := range identity.Actions {
+		if bucket == "" {
+			if strings.HasPrefix(string(a), string(action)+":") || strings.HasPrefix(string(a), string(ACTION_ADMIN)+":") {
+				return true
+			}
+			continue
+		}
+		if a == action+Action(":"+bucket) || a == ACTION_ADMIN+Action(":"+bucket) {
+			return true
+		}
+	}
+	return false
+}
+
+// getIdentity returns the authenticated identity of the request, or nil if the authentication is disabled
+func getIdentity(r *http.Request) *Identity {
+	identity, _ := r.Context().Value(identityContextKey{}).(*Identity)
+	return identity
+}
+
+// posixIdentity returns the filer identity of the authenticated identity, if configured
+func posixIdentity(r *http.Request) *filer2.Identity {
+	if identity := getIdentity(r); identity != nil {
+		return identity.Posix
+	}
+	return nil
+}
+
+// requestContext passes the filer identity of the request in the grpc calls to the filer
+func requestContext(r *http.Request) context.Context {
+	return filer2.AppendIdentityToOutgoingContext(context.Background(), posixIdentity(r))
+}
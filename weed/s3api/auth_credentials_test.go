@@ -0,0 +1,87 @@
+package s3api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIdentityCanDo(t *testing.T) {
+
+	admin := &Identity{Name: "admin", Actions: []Action{ACTION_ADMIN}}
+	reader := &Identity{Name: "reader", Actions: []Action{ACTION_READ, ACTION_LIST + ":bucket1"}}
+	bucketAdmin := &Identity{Name: "bucketAdmin", Actions: []Action{ACTION_ADMIN + ":bucket2"}}
+
+	for _, c := range []struct {
+		identity *Identity
+		action   Action
+		bucket   string
+		expected bool
+	}{
+		{admin, ACTION_WRITE, "bucket1", true},
+		{admin, ACTION_LIST, "", true},
+		{reader, ACTION_READ, "bucket1", true},
+		{reader, ACTION_READ, "bucket2", true},
+		{reader, ACTION_WRITE, "bucket1", false},
+		{reader, ACTION_LIST, "bucket1", true},
+		{reader, ACTION_LIST, "bucket2", false},
+		{reader, ACTION_LIST, "", true},
+		{bucketAdmin, ACTION_WRITE, "bucket2", true},
+		{bucketAdmin, ACTION_WRITE, "bucket1", false},
+		{bucketAdmin, ACTION_LIST, "", true},
+	} {
+		if actual := c.identity.canDo(c.action, c.bucket); actual != c.expected {
+			t.Errorf("%s %s on bucket %q: %v, expected %v", c.identity.Name, c.action, c.bucket, actual, c.expected)
+		}
+	}
+
+}
+
+func TestLoadS3ApiConfiguration(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "s3config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "s3.json")
+	ioutil.WriteFile(fileName, []byte(`{
+  "identities": [
+    {
+      "name": "anonymous",
+      "actions": ["Read:public"]
+    },
+    {
+      "name": "admin",
+      "credentials": [{"accessKey": "key1", "secretKey": "secret1"}, {"accessKey": "key2", "secretKey": "secret2"}],
+      "actions": ["Admin"]
+    }
+  ]
+}`), 0644)
+
+	iam, err := NewIdentityAccessManagement(fileName)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !iam.isEnabled() {
+		t.Fatalf("identities are not loaded")
+	}
+
+	identity, cred, found := iam.lookupByAccessKey("key2")
+	if !found || identity.Name != "admin" || cred.SecretKey != "secret2" {
+		t.Errorf("unexpected lookup: %+v %+v", identity, cred)
+	}
+	if _, _, found = iam.lookupByAccessKey("key3"); found {
+		t.Errorf("unknown access key should not be found")
+	}
+	if anonymous, found := iam.lookupAnonymous(); !found || !anonymous.canDo(ACTION_READ, "public") {
+		t.Errorf("unexpected anonymous identity: %+v", anonymous)
+	}
+
+	if iam, _ = NewIdentityAccessManagement(""); iam.isEnabled() {
+		t.Errorf("no identities without the config file")
+	}
+
+}
@@ -0,0 +1,233 @@
+package s3api
+
+// the related code is copied and modified from minio source code
+
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// the sub resources to be included in the v2 string to sign, in sorted order
+// http://docs.aws.amazon.com/AmazonS3/latest/dev/RESTAuthentication.html#ConstructingTheCanonicalizedResourceElement
+var resourceList = []string{
+	"acl",
+	"cors",
+	"delete",
+	"lifecycle",
+	"location",
+	"logging",
+	"notification",
+	"partNumber",
+	"policy",
+	"requestPayment",
+	"response-cache-control",
+	"response-content-disposition",
+	"response-content-encoding",
+	"response-content-language",
+	"response-content-type",
+	"response-expires",
+	"tagging",
+	"torrent",
+	"uploadId",
+	"uploads",
+	"versionId",
+	"versioning",
+	"versions",
+	"website",
+}
+
+func (iam *IdentityAccessManagement) isReqAuthenticatedV2(r *http.Request) (*Identity, ErrorCode) {
+	if isRequestSignatureV2(r) {
+		return iam.doesSignV2Match(r)
+	}
+	return iam.doesPresignV2SignatureMatch(r)
+}
+
+// doesSignV2Match verifies the "Authorization: AWS <access key>:<signature>" header
+func (iam *IdentityAccessManagement) doesSignV2Match(r *http.Request) (*Identity, ErrorCode) {
+
+	v2Auth := r.Header.Get("Authorization")
+	if v2Auth == "" {
+		return nil, ErrAuthHeaderEmpty
+	}
+	if !strings.HasPrefix(v2Auth, signV2Algorithm+" ") {
+		return nil, ErrSignatureVersionNotSupported
+	}
+
+	keySignFields := strings.Split(strings.TrimSpace(strings.TrimPrefix(v2Auth, signV2Algorithm+" ")), ":")
+	if len(keySignFields) != 2 {
+		return nil, ErrMissingFields
+	}
+
+	identity, cred, found := iam.lookupByAccessKey(keySignFields[0])
+	if !found {
+		return nil, ErrInvalidAccessKeyID
+	}
+
+	expectedSignature := signatureV2(cred, r.Method, encodedResourceV2(r), r.URL.Query(), r.Header, "")
+	if !compareSignatureV2(keySignFields[1], expectedSignature) {
+		return nil, ErrSignatureDoesNotMatch
+	}
+
+	return identity, ErrNone
+}
+
+// doesPresignV2SignatureMatch verifies the "AWSAccessKeyId=...&Expires=...&Signature=..." in the presigned url
+func (iam *IdentityAccessManagement) doesPresignV2SignatureMatch(r *http.Request) (*Identity, ErrorCode) {
+
+	query := r.URL.Query()
+	accessKey, expires, signature := query.Get("AWSAccessKeyId"), query.Get("Expires"), query.Get("Signature")
+	if accessKey == "" || expires == "" || signature == "" {
+		return nil, ErrInvalidQueryParams
+	}
+
+	identity, cred, found := iam.lookupByAccessKey(accessKey)
+	if !found {
+		return nil, ErrInvalidAccessKeyID
+	}
+
+	expiresInt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, ErrMalformedExpires
+	}
+	if expiresInt < time.Now().Unix() {
+		return nil, ErrExpiredPresignRequest
+	}
+
+	expectedSignature := signatureV2(cred, r.Method, encodedResourceV2(r), query, r.Header, expires)
+	if !compareSignatureV2(signature, expectedSignature) {
+		return nil, ErrSignatureDoesNotMatch
+	}
+
+	return identity, ErrNone
+}
+
+// encodedResourceV2 returns the path in the path style, i.e., starting with the bucket name
+func encodedResourceV2(r *http.Request) string {
+	resource := r.URL.EscapedPath()
+	if bucket := mux.Vars(r)["bucket"]; bucket != "" {
+		if resource != "/"+bucket && !strings.HasPrefix(resource, "/"+bucket+"/") {
+			// virtual hosted style
+			resource = "/" + bucket + resource
+		}
+	}
+	return resource
+}
+
+// signatureV2 returns the base64 encoded HMAC-SHA1 of
+//
+//	StringToSign = HTTP-Verb + "\n" +
+//		Content-Md5 + "\n" +
+//		Content-Type + "\n" +
+//		Date/Expires + "\n" +
+//		CanonicalizedProtocolHeaders +
+//		CanonicalizedResource;
+func signatureV2(cred *Credential, method string, encodedResource string, query url.Values, headers http.Header, expires string) string {
+	stringToSign := getStringToSignV2(method, encodedResource, query, headers, expires)
+	hm := hmac.New(sha1.New, []byte(cred.SecretKey))
+	hm.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(hm.Sum(nil))
+}
+
+func getStringToSignV2(method string, encodedResource string, query url.Values, headers http.Header, expires string) string {
+
+	date := expires
+	if date == "" {
+		// the x-amz-date is part of the canonicalized amz headers
+		if headers.Get("X-Amz-Date") == "" {
+			date = headers.Get("Date")
+		}
+	}
+
+	stringToSign := strings.Join([]string{
+		method,
+		headers.Get("Content-MD5"),
+		headers.Get("Content-Type"),
+		date,
+	}, "\n") + "\n"
+
+	stringToSign += canonicalizedAmzHeadersV2(headers)
+	stringToSign += canonicalizedResourceV2(encodedResource, query)
+
+	return stringToSign
+}
+
+// canonicalizedAmzHeadersV2 returns the sorted "x-amz-<name>:<values>\n" lines
+func canonicalizedAmzHeadersV2(headers http.Header) string {
+	var keys []string
+	keyval := make(map[string]string)
+	for key := range headers {
+		lkey := strings.ToLower(key)
+		if !strings.HasPrefix(lkey, "x-amz-") {
+			continue
+		}
+		keys = append(keys, lkey)
+		keyval[lkey] = strings.Join(headers[key], ",")
+	}
+	sort.Strings(keys)
+
+	var canonicalHeaders []string
+	for _, key := range keys {
+		canonicalHeaders = append(canonicalHeaders, key+":"+keyval[key]+"\n")
+	}
+	return strings.Join(canonicalHeaders, "")
+}
+
+// canonicalizedResourceV2 returns the path with the sorted sub resources
+func canonicalizedResourceV2(encodedResource string, query url.Values) string {
+	var subResources []string
+	for _, resource := range resourceList {
+		vals, ok := query[resource]
+		if !ok {
+			continue
+		}
+		if len(vals) == 0 || vals[0] == "" {
+			subResources = append(subResources, resource)
+			continue
+		}
+		subResources = append(subResources, resource+"="+vals[0])
+	}
+	if len(subResources) == 0 {
+		return encodedResource
+	}
+	return encodedResource + "?" + strings.Join(subResources, "&")
+}
+
+func compareSignatureV2(sig1, sig2 string) bool {
+	// the signatures are base64 encoded, decode them to compare the raw bytes
+	signature1, err := base64.StdEncoding.DecodeString(sig1)
+	if err != nil {
+		return false
+	}
+	signature2, err := base64.StdEncoding.DecodeString(sig2)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(signature1, signature2) == 1
+}
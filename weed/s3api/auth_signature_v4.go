@@ -0,0 +1,593 @@
+package s3api
+
+// the related code is copied and modified from minio source code
+
+/*
+ * Minio Cloud Storage, (C) 2015, 2016, 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	iso8601Format = "20060102T150405Z"
+	yyyymmdd      = "20060102"
+
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	emptySHA256     = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// the allowed clock difference between the client and the server
+	globalMaxSkewTime = 15 * time.Minute
+	// the max expiration of the presigned url
+	globalMaxPresignExpires = 7 * 24 * time.Hour
+)
+
+var errContentSHA256Mismatch = errors.New("x-amz-content-sha256 does not match the content")
+
+// credentialHeader is the parsed "Credential=<access key>/<date>/<region>/s3/aws4_request"
+type credentialHeader struct {
+	accessKey string
+	scope     struct {
+		date    time.Time
+		region  string
+		service string
+		request string
+	}
+}
+
+func (c credentialHeader) getScope() string {
+	return strings.Join([]string{
+		c.scope.date.Format(yyyymmdd),
+		c.scope.region,
+		c.scope.service,
+		c.scope.request,
+	}, "/")
+}
+
+// signValues is the parsed v4 authorization header
+type signValues struct {
+	Credential    credentialHeader
+	SignedHeaders []string
+	Signature     string
+}
+
+// preSignValues is the parsed v4 presigned url query
+type preSignValues struct {
+	signValues
+	Date    time.Time
+	Expires time.Duration
+}
+
+func (iam *IdentityAccessManagement) reqSignatureV4Verify(r *http.Request) (*Identity, ErrorCode) {
+	sha256sum := getContentSha256Cksum(r)
+	switch {
+	case isRequestSignatureV4(r):
+		identity, errCode := iam.doesSignatureMatch(sha256sum, r)
+		if errCode == ErrNone && sha256sum != unsignedPayload && sha256sum != streamingContentSHA256 && r.Body != nil {
+			r.Body = &contentSha256Reader{ReadCloser: r.Body, expected: sha256sum, hash: sha256.New()}
+		}
+		return identity, errCode
+	case isRequestPresignedSignatureV4(r):
+		return iam.doesPresignedSignatureMatch(sha256sum, r)
+	}
+	return nil, ErrAccessDenied
+}
+
+// getContentSha256Cksum returns the signed sha256 of the payload
+func getContentSha256Cksum(r *http.Request) string {
+	var (
+		defaultSha256Cksum string
+		v                  []string
+		ok                 bool
+	)
+
+	if isRequestPresignedSignatureV4(r) {
+		// the presigned url does not sign the payload by default
+		v, ok = r.URL.Query()["X-Amz-Content-Sha256"]
+		defaultSha256Cksum = unsignedPayload
+	} else {
+		v, ok = r.Header["X-Amz-Content-Sha256"]
+		defaultSha256Cksum = emptySHA256
+	}
+
+	if ok && len(v) > 0 {
+		return v[0]
+	}
+	return defaultSha256Cksum
+}
+
+// doesSignatureMatch verifies the "Authorization: AWS4-HMAC-SHA256 ..." header
+func (iam *IdentityAccessManagement) doesSignatureMatch(hashedPayload string, r *http.Request) (*Identity, ErrorCode) {
+
+	signV4Values, errCode := parseSignV4(r.Header.Get("Authorization"))
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+
+	extractedSignedHeaders, errCode := extractSignedHeaders(signV4Values.SignedHeaders, r)
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+
+	identity, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
+	if !found {
+		return nil, ErrInvalidAccessKeyID
+	}
+
+	t, errCode := parseAmzDate(r)
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+	if skew := time.Since(t); skew > globalMaxSkewTime || skew < -globalMaxSkewTime {
+		return nil, ErrRequestTimeTooSkewed
+	}
+
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, hashedPayload, r.URL.Query().Encode(), r.URL.Path, r.Method)
+	stringToSign := getStringToSign(canonicalRequest, t, signV4Values.Credential.getScope())
+	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, signV4Values.Credential.scope.region)
+	newSignature := getSignature(signingKey, stringToSign)
+
+	if !compareSignatureV4(newSignature, signV4Values.Signature) {
+		return nil, ErrSignatureDoesNotMatch
+	}
+
+	return identity, ErrNone
+}
+
+// doesPresignedSignatureMatch verifies the "X-Amz-Signature=..." in the presigned url
+func (iam *IdentityAccessManagement) doesPresignedSignatureMatch(hashedPayload string, r *http.Request) (*Identity, ErrorCode) {
+
+	pSignValues, errCode := parsePreSignV4(r.URL.Query())
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+
+	identity, cred, found := iam.lookupByAccessKey(pSignValues.Credential.accessKey)
+	if !found {
+		return nil, ErrInvalidAccessKeyID
+	}
+
+	extractedSignedHeaders, errCode := extractSignedHeaders(pSignValues.SignedHeaders, r)
+	if errCode != ErrNone {
+		return nil, errCode
+	}
+
+	now := time.Now()
+	if pSignValues.Date.Sub(now) > globalMaxSkewTime {
+		return nil, ErrRequestNotReadyYet
+	}
+	if now.Sub(pSignValues.Date) > pSignValues.Expires {
+		return nil, ErrExpiredPresignRequest
+	}
+
+	// the signature itself is not signed
+	query := make(url.Values)
+	for k, v := range r.URL.Query() {
+		if k != "X-Amz-Signature" {
+			query[k] = v
+		}
+	}
+
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, hashedPayload, query.Encode(), r.URL.Path, r.Method)
+	stringToSign := getStringToSign(canonicalRequest, pSignValues.Date, pSignValues.Credential.getScope())
+	signingKey := getSigningKey(cred.SecretKey, pSignValues.Credential.scope.date, pSignValues.Credential.scope.region)
+	newSignature := getSignature(signingKey, stringToSign)
+
+	if !compareSignatureV4(newSignature, pSignValues.Signature) {
+		return nil, ErrSignatureDoesNotMatch
+	}
+
+	return identity, ErrNone
+}
+
+// calculateSeedSignature verifies the seed signature of the streaming upload,
+// and returns the values to verify the following chunk signatures.
+func (iam *IdentityAccessManagement) calculateSeedSignature(r *http.Request) (cred *Credential, signature string, region string, date time.Time, errCode ErrorCode) {
+
+	signV4Values, errCode := parseSignV4(r.Header.Get("Authorization"))
+	if errCode != ErrNone {
+		return nil, "", "", time.Time{}, errCode
+	}
+
+	if payload := r.Header.Get("X-Amz-Content-Sha256"); payload != streamingContentSHA256 {
+		return nil, "", "", time.Time{}, ErrContentSHA256Mismatch
+	}
+
+	extractedSignedHeaders, errCode := extractSignedHeaders(signV4Values.SignedHeaders, r)
+	if errCode != ErrNone {
+		return nil, "", "", time.Time{}, errCode
+	}
+
+	_, cred, found := iam.lookupByAccessKey(signV4Values.Credential.accessKey)
+	if !found {
+		return nil, "", "", time.Time{}, ErrInvalidAccessKeyID
+	}
+
+	region = signV4Values.Credential.scope.region
+
+	date, errCode = parseAmzDate(r)
+	if errCode != ErrNone {
+		return nil, "", "", time.Time{}, errCode
+	}
+
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, streamingContentSHA256, r.URL.Query().Encode(), r.URL.Path, r.Method)
+	stringToSign := getStringToSign(canonicalRequest, date, signV4Values.Credential.getScope())
+	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, region)
+	newSignature := getSignature(signingKey, stringToSign)
+
+	if !compareSignatureV4(newSignature, signV4Values.Signature) {
+		return nil, "", "", time.Time{}, ErrSignatureDoesNotMatch
+	}
+
+	return cred, newSignature, region, date, ErrNone
+}
+
+// getChunkSignature returns the signature of one chunk, chained with the previous signature
+func getChunkSignature(cred *Credential, seedSignature string, region string, date time.Time, hashedChunk string) string {
+
+	stringToSign := signV4ChunkedAlgorithm + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		emptySHA256 + "\n" +
+		hashedChunk
+
+	signingKey := getSigningKey(cred.SecretKey, date, region)
+
+	return getSignature(signingKey, stringToSign)
+}
+
+// parseSignV4 parses "AWS4-HMAC-SHA256 Credential=..., SignedHeaders=..., Signature=..."
+func parseSignV4(v4Auth string) (sv signValues, errCode ErrorCode) {
+
+	if !strings.HasPrefix(v4Auth, signV4Algorithm) {
+		return sv, ErrSignatureVersionNotSupported
+	}
+
+	v4Auth = strings.Replace(v4Auth, " ", "", -1)
+	if v4Auth == "" {
+		return sv, ErrAuthHeaderEmpty
+	}
+	v4Auth = strings.TrimPrefix(v4Auth, signV4Algorithm)
+
+	authFields := strings.Split(strings.TrimSpace(v4Auth), ",")
+	if len(authFields) != 3 {
+		return sv, ErrMissingFields
+	}
+
+	if sv.Credential, errCode = parseCredentialHeader(authFields[0]); errCode != ErrNone {
+		return sv, errCode
+	}
+	if sv.SignedHeaders, errCode = parseSignedHeader(authFields[1]); errCode != ErrNone {
+		return sv, errCode
+	}
+	if sv.Signature, errCode = parseSignature(authFields[2]); errCode != ErrNone {
+		return sv, errCode
+	}
+
+	return sv, ErrNone
+}
+
+// parsePreSignV4 parses the X-Amz-* query parameters of the presigned url
+func parsePreSignV4(query url.Values) (psv preSignValues, errCode ErrorCode) {
+
+	if query.Get("X-Amz-Algorithm") != signV4Algorithm {
+		return psv, ErrSignatureVersionNotSupported
+	}
+
+	for _, k := range []string{"X-Amz-Credential", "X-Amz-Signature", "X-Amz-Date", "X-Amz-SignedHeaders", "X-Amz-Expires"} {
+		if _, ok := query[k]; !ok {
+			return psv, ErrInvalidQueryParams
+		}
+	}
+
+	if psv.Credential, errCode = parseCredentialHeader("Credential=" + query.Get("X-Amz-Credential")); errCode != ErrNone {
+		return psv, errCode
+	}
+
+	var err error
+	if psv.Date, err = time.Parse(iso8601Format, query.Get("X-Amz-Date")); err != nil {
+		return psv, ErrMalformedPresignedDate
+	}
+
+	expires, err := strconv.ParseInt(query.Get("X-Amz-Expires"), 10, 64)
+	if err != nil {
+		return psv, ErrMalformedExpires
+	}
+	psv.Expires = time.Duration(expires) * time.Second
+	if psv.Expires < 0 {
+		return psv, ErrNegativeExpires
+	}
+	if psv.Expires > globalMaxPresignExpires {
+		return psv, ErrMaximumExpires
+	}
+
+	if psv.SignedHeaders, errCode = parseSignedHeader("SignedHeaders=" + query.Get("X-Amz-SignedHeaders")); errCode != ErrNone {
+		return psv, errCode
+	}
+	if psv.Signature, errCode = parseSignature("Signature=" + query.Get("X-Amz-Signature")); errCode != ErrNone {
+		return psv, errCode
+	}
+
+	return psv, ErrNone
+}
+
+// parseCredentialHeader parses "Credential=<access key>/<date>/<region>/s3/aws4_request"
+func parseCredentialHeader(credElement string) (ch credentialHeader, errCode ErrorCode) {
+
+	creds := strings.SplitN(strings.TrimSpace(credElement), "=", 2)
+	if len(creds) != 2 {
+		return ch, ErrMissingFields
+	}
+	if creds[0] != "Credential" {
+		return ch, ErrMissingCredTag
+	}
+
+	credElements := strings.Split(strings.TrimSpace(creds[1]), "/")
+	if len(credElements) < 5 {
+		return ch, ErrCredMalformed
+	}
+	// the access key may contain "/"
+	n := len(credElements) - 4
+	ch.accessKey = strings.Join(credElements[:n], "/")
+	credElements = credElements[n:]
+
+	var err error
+	if ch.scope.date, err = time.Parse(yyyymmdd, credElements[0]); err != nil {
+		return ch, ErrCredMalformed
+	}
+	ch.scope.region = credElements[1]
+	ch.scope.service = credElements[2]
+	ch.scope.request = credElements[3]
+	if ch.scope.service != "s3" || ch.scope.request != "aws4_request" {
+		return ch, ErrCredMalformed
+	}
+
+	return ch, ErrNone
+}
+
+func parseSignedHeader(signedHdrElement string) ([]string, ErrorCode) {
+	signedHdrFields := strings.SplitN(strings.TrimSpace(signedHdrElement), "=", 2)
+	if len(signedHdrFields) != 2 {
+		return nil, ErrMissingFields
+	}
+	if signedHdrFields[0] != "SignedHeaders" {
+		return nil, ErrMissingSignHeadersTag
+	}
+	if signedHdrFields[1] == "" {
+		return nil, ErrMissingFields
+	}
+	return strings.Split(signedHdrFields[1], ";"), ErrNone
+}
+
+func parseSignature(signElement string) (string, ErrorCode) {
+	signFields := strings.SplitN(strings.TrimSpace(signElement), "=", 2)
+	if len(signFields) != 2 {
+		return "", ErrMissingFields
+	}
+	if signFields[0] != "Signature" {
+		return "", ErrMissingSignTag
+	}
+	if signFields[1] == "" {
+		return "", ErrMissingFields
+	}
+	return signFields[1], ErrNone
+}
+
+// parseAmzDate reads the request time from the X-Amz-Date or the Date header
+func parseAmzDate(r *http.Request) (time.Time, ErrorCode) {
+	amzDate := r.Header.Get("X-Amz-Date")
+	if amzDate == "" {
+		amzDate = r.Header.Get("Date")
+	}
+	if amzDate == "" {
+		return time.Time{}, ErrMissingDateHeader
+	}
+	for _, layout := range []string{iso8601Format, time.RFC1123, time.RFC1123Z, time.RFC850, time.ANSIC} {
+		if t, err := time.Parse(layout, amzDate); err == nil {
+			return t, ErrNone
+		}
+	}
+	return time.Time{}, ErrMalformedDate
+}
+
+// extractSignedHeaders collects the signed headers, some of which are moved out of the header map by the go http server
+func extractSignedHeaders(signedHeaders []string, r *http.Request) (http.Header, ErrorCode) {
+
+	hasHost := false
+	for _, header := range signedHeaders {
+		if header == "host" {
+			hasHost = true
+		}
+	}
+	if !hasHost {
+		return nil, ErrUnsignedHeaders
+	}
+
+	extractedSignedHeaders := make(http.Header)
+	for _, header := range signedHeaders {
+		if val, ok := r.Header[http.CanonicalHeaderKey(header)]; ok {
+			extractedSignedHeaders[header] = val
+			continue
+		}
+		switch header {
+		case "expect":
+			// the go http server removes the "Expect: 100-continue" header
+			extractedSignedHeaders[header] = []string{"100-continue"}
+		case "host":
+			extractedSignedHeaders[header] = []string{r.Host}
+		case "transfer-encoding":
+			extractedSignedHeaders[header] = r.TransferEncoding
+		case "content-length":
+			extractedSignedHeaders[header] = []string{strconv.FormatInt(r.ContentLength, 10)}
+		default:
+			return nil, ErrUnsignedHeaders
+		}
+	}
+
+	return extractedSignedHeaders, ErrNone
+}
+
+// getCanonicalHeaders returns the "<lowercase name>:<trimmed values>\n" lines sorted by the header names
+func getCanonicalHeaders(signedHeaders http.Header) string {
+	var headers []string
+	vals := make(http.Header)
+	for k, vv := range signedHeaders {
+		headers = append(headers, strings.ToLower(k))
+		vals[strings.ToLower(k)] = vv
+	}
+	sort.Strings(headers)
+
+	var buf bytes.Buffer
+	for _, k := range headers {
+		buf.WriteString(k)
+		buf.WriteByte(':')
+		for idx, v := range vals[k] {
+			if idx > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(signV4TrimAll(v))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// signV4TrimAll trims the leading and trailing spaces, and compresses the sequential spaces into one
+func signV4TrimAll(input string) string {
+	return strings.Join(strings.Fields(input), " ")
+}
+
+func getSignedHeaders(signedHeaders http.Header) string {
+	var headers []string
+	for k := range signedHeaders {
+		headers = append(headers, strings.ToLower(k))
+	}
+	sort.Strings(headers)
+	return strings.Join(headers, ";")
+}
+
+// getCanonicalRequest generates the canonical request
+//
+//	<HTTPMethod>\n
+//	<CanonicalURI>\n
+//	<CanonicalQueryString>\n
+//	<CanonicalHeaders>\n
+//	<SignedHeaders>\n
+//	<HashedPayload>
+func getCanonicalRequest(extractedSignedHeaders http.Header, payload, queryStr, urlPath, method string) string {
+	rawQuery := strings.Replace(queryStr, "+", "%20", -1)
+	canonicalRequest := strings.Join([]string{
+		method,
+		encodePath(urlPath),
+		rawQuery,
+		getCanonicalHeaders(extractedSignedHeaders),
+		getSignedHeaders(extractedSignedHeaders),
+		payload,
+	}, "\n")
+	return canonicalRequest
+}
+
+func getStringToSign(canonicalRequest string, t time.Time, scope string) string {
+	stringToSign := signV4Algorithm + "\n" + t.Format(iso8601Format) + "\n"
+	stringToSign = stringToSign + scope + "\n"
+	canonicalRequestBytes := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign = stringToSign + hex.EncodeToString(canonicalRequestBytes[:])
+	return stringToSign
+}
+
+func getScope(t time.Time, region string) string {
+	return strings.Join([]string{t.Format(yyyymmdd), region, "s3", "aws4_request"}, "/")
+}
+
+func getSigningKey(secretKey string, t time.Time, region string) []byte {
+	date := sumHMAC([]byte("AWS4"+secretKey), []byte(t.Format(yyyymmdd)))
+	regionBytes := sumHMAC(date, []byte(region))
+	service := sumHMAC(regionBytes, []byte("s3"))
+	signingKey := sumHMAC(service, []byte("aws4_request"))
+	return signingKey
+}
+
+func getSignature(signingKey []byte, stringToSign string) string {
+	return hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+}
+
+func sumHMAC(key []byte, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func compareSignatureV4(sig1, sig2 string) bool {
+	return subtle.ConstantTimeCompare([]byte(sig1), []byte(sig2)) == 1
+}
+
+// encodePath encodes the path by the AWS rules, i.e., except the unreserved characters and "/"
+func encodePath(pathName string) string {
+	var encodedPathname bytes.Buffer
+	for _, s := range pathName {
+		if ('A' <= s && s <= 'Z') || ('a' <= s && s <= 'z') || ('0' <= s && s <= '9') {
+			encodedPathname.WriteRune(s)
+			continue
+		}
+		switch s {
+		case '-', '_', '.', '~', '/':
+			encodedPathname.WriteRune(s)
+			continue
+		}
+		runeLen := utf8.RuneLen(s)
+		if runeLen < 0 {
+			// invalid utf8, keep it as is
+			return pathName
+		}
+		u := make([]byte, runeLen)
+		utf8.EncodeRune(u, s)
+		for _, r := range u {
+			encodedPathname.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{r})))
+		}
+	}
+	return encodedPathname.String()
+}
+
+// contentSha256Reader verifies the signed x-amz-content-sha256 against the body when the body is read completely
+type contentSha256Reader struct {
+	io.ReadCloser
+	expected string
+	hash     hash.Hash
+}
+
+func (r *contentSha256Reader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(r.hash.Sum(nil)) != r.expected {
+		return n, errContentSHA256Mismatch
+	}
+	return n, err
+}
@@ -15,6 +15,7 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
 )
 
 type InitiateMultipartUploadResult struct {
@@ -91,13 +92,45 @@ func (s3a *S3ApiServer) completeMultipartUpload(ctx context.Context, input *s3.C
 	}
 	dirName = fmt.Sprintf("%s/%s/%s", s3a.option.BucketsPath, *input.Bucket, dirName)
 
-	err = s3a.mkFile(ctx, dirName, entryName, finalParts)
+	object := "/" + strings.TrimPrefix(*input.Key, "/")
+
+	versioning := s3a.getBucketVersioning(ctx, *input.Bucket)
+
+	var ttlSec int32
+	if ttl := s3a.objectTtl(ctx, *input.Bucket, object, versioning); ttl != "" {
+		if t, ttlErr := storage.ReadTTL(ttl); ttlErr == nil {
+			ttlSec = int32(t.Minutes() * 60)
+		}
+	}
+
+	// the new version is written under the versions folder, and rotated to the object after it is complete
+	var versionId, stagingName string
+	var extended map[string][]byte
+	if versioning != "" {
+		versionId, stagingName = newVersionNames(versioning)
+		extended = versionExtended(versionId)
+		dirName, entryName = s3a.versionsDir(*input.Bucket, object), stagingName
+	}
+
+	err = s3a.mkFile(ctx, dirName, entryName, finalParts, func(entry *filer_pb.Entry) {
+		entry.Attributes.TtlSec = ttlSec
+		entry.Extended = extended
+	})
 
 	if err != nil {
 		glog.Errorf("completeMultipartUpload %s/%s error: %v", dirName, entryName, err)
 		return nil, ErrInternalError
 	}
 
+	if versioning != "" {
+		if err = s3a.rotateNewVersion(ctx, *input.Bucket, object, versionId, stagingName, versioning, false); err != nil {
+			glog.Errorf("completeMultipartUpload %s%s rotate new version: %v", *input.Bucket, object, err)
+			// the chunks are still used by the uploaded parts
+			s3a.removeNewVersion(ctx, *input.Bucket, object, stagingName, false)
+			return nil, ErrInternalError
+		}
+	}
+
 	output = &CompleteMultipartUploadResult{
 		CompleteMultipartUploadOutput: s3.CompleteMultipartUploadOutput{
 			Bucket: input.Bucket,
@@ -105,6 +138,9 @@ func (s3a *S3ApiServer) completeMultipartUpload(ctx context.Context, input *s3.C
 			Key:    input.Key,
 		},
 	}
+	if versionId != "" {
+		output.VersionId = aws.String(versionId)
+	}
 
 	if err = s3a.rm(ctx, s3a.genUploadsFolder(*input.Bucket), *input.UploadId, true, false, true); err != nil {
 		glog.V(1).Infof("completeMultipartUpload cleanup %s upload %s: %v", *input.Bucket, *input.UploadId, err)
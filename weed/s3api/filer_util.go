@@ -43,7 +43,7 @@ func (s3a *S3ApiServer) mkdir(ctx context.Context, parentDirectoryPath string, d
 	})
 }
 
-func (s3a *S3ApiServer) mkFile(ctx context.Context, parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk) error {
+func (s3a *S3ApiServer) mkFile(ctx context.Context, parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk, fn func(entry *filer_pb.Entry)) error {
 	return s3a.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 
 		entry := &filer_pb.Entry{
@@ -59,6 +59,10 @@ func (s3a *S3ApiServer) mkFile(ctx context.Context, parentDirectoryPath string,
 			Chunks: chunks,
 		}
 
+		if fn != nil {
+			fn(entry)
+		}
+
 		request := &filer_pb.CreateEntryRequest{
 			Directory: parentDirectoryPath,
 			Entry:     entry,
@@ -143,3 +147,64 @@ func (s3a *S3ApiServer) exists(ctx context.Context, parentDirectoryPath string,
 
 	return
 }
+
+func (s3a *S3ApiServer) lookupEntry(ctx context.Context, parentDirectoryPath string, entryName string) (entry *filer_pb.Entry, err error) {
+
+	err = s3a.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.LookupDirectoryEntryRequest{
+			Directory: parentDirectoryPath,
+			Name:      entryName,
+		}
+
+		glog.V(4).Infof("lookup entry %v/%v: %v", parentDirectoryPath, entryName, request)
+		resp, err := client.LookupDirectoryEntry(ctx, request)
+		if err != nil {
+			return fmt.Errorf("lookup entry %s/%s: %v", parentDirectoryPath, entryName, err)
+		}
+
+		entry = resp.Entry
+
+		return nil
+	})
+
+	return
+}
+
+func (s3a *S3ApiServer) updateEntry(ctx context.Context, parentDirectoryPath string, entry *filer_pb.Entry) error {
+
+	return s3a.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory: parentDirectoryPath,
+			Entry:     entry,
+		}
+
+		glog.V(1).Infof("update entry %v/%v", parentDirectoryPath, entry.Name)
+		if _, err := client.UpdateEntry(ctx, request); err != nil {
+			return fmt.Errorf("update entry %s/%s: %v", parentDirectoryPath, entry.Name, err)
+		}
+
+		return nil
+	})
+}
+
+func (s3a *S3ApiServer) mv(ctx context.Context, oldParentDirectoryPath, oldName, newParentDirectoryPath, newName string) error {
+
+	return s3a.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldParentDirectoryPath,
+			OldName:      oldName,
+			NewDirectory: newParentDirectoryPath,
+			NewName:      newName,
+		}
+
+		glog.V(1).Infof("move entry %v/%v => %v/%v", oldParentDirectoryPath, oldName, newParentDirectoryPath, newName)
+		if _, err := client.AtomicRenameEntry(ctx, request); err != nil {
+			return fmt.Errorf("move entry %s/%s => %s/%s: %v", oldParentDirectoryPath, oldName, newParentDirectoryPath, newName, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,243 @@
+package s3api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server"
+)
+
+// testFiler keeps the entries in memory, for the grpc calls and the http uploads of the S3 API server
+type testFiler struct {
+	filer_pb.SeaweedFilerServer // the calls not used by the tests are not implemented
+
+	lock       sync.Mutex
+	entries    map[string]*filer_pb.Entry
+	failUpload bool
+	failList   error
+}
+
+type testS3ApiServer struct {
+	*S3ApiServer
+	filer      *testFiler
+	router     *mux.Router
+	httpServer *httptest.Server
+	grpcServer *grpc.Server
+}
+
+func newTestS3ApiServer(t *testing.T) *testS3ApiServer {
+
+	filer := &testFiler{entries: make(map[string]*filer_pb.Entry)}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	filer_pb.RegisterSeaweedFilerServer(grpcServer, filer)
+	go grpcServer.Serve(listener)
+	httpServer := httptest.NewServer(filer)
+
+	router := mux.NewRouter()
+	s3a, err := NewS3ApiServer(router, &S3ApiServerOption{
+		Filer:            strings.TrimPrefix(httpServer.URL, "http://"),
+		FilerGrpcAddress: listener.Addr().String(),
+		BucketsPath:      "/buckets",
+		GrpcDialOption:   grpc.WithInsecure(),
+	})
+	if err != nil {
+		httpServer.Close()
+		grpcServer.Stop()
+		t.Fatalf("new s3 api server: %v", err)
+	}
+
+	return &testS3ApiServer{S3ApiServer: s3a, filer: filer, router: router, httpServer: httpServer, grpcServer: grpcServer}
+}
+
+func (s *testS3ApiServer) close() {
+	s.httpServer.Close()
+	s.grpcServer.Stop()
+}
+
+func (s *testS3ApiServer) createBucket(bucket string, versioning VersioningStatus) {
+	entry := &filer_pb.Entry{Name: bucket, IsDirectory: true, Attributes: &filer_pb.FuseAttributes{}}
+	if versioning != "" {
+		entry.Extended = map[string][]byte{s3VersioningKey: []byte(versioning)}
+	}
+	s.filer.save("/buckets", entry)
+}
+
+func (s *testS3ApiServer) setVersioning(bucket string, versioning VersioningStatus) {
+	s.filer.lock.Lock()
+	defer s.filer.lock.Unlock()
+	s.filer.entries["/buckets/"+bucket].Extended = map[string][]byte{s3VersioningKey: []byte(versioning)}
+}
+
+func (s *testS3ApiServer) do(method, url string, body []byte) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, url, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, r)
+	return w
+}
+
+// find returns the entry, or nil if not found
+func (f *testFiler) find(fullpath string) *filer_pb.Entry {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if entry, found := f.entries[fullpath]; found {
+		return proto.Clone(entry).(*filer_pb.Entry)
+	}
+	return nil
+}
+
+func (f *testFiler) children(dir string) (names []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for p := range f.entries {
+		if path.Dir(p) == dir {
+			names = append(names, path.Base(p))
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func (f *testFiler) save(dir string, entry *filer_pb.Entry) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.saveLocked(dir, entry)
+}
+
+func (f *testFiler) saveLocked(dir string, entry *filer_pb.Entry) {
+	for parent := dir; parent != "/"; parent = path.Dir(parent) {
+		if _, found := f.entries[parent]; !found {
+			f.entries[parent] = &filer_pb.Entry{Name: path.Base(parent), IsDirectory: true, Attributes: &filer_pb.FuseAttributes{}}
+		}
+	}
+	f.entries[path.Join(dir, entry.Name)] = proto.Clone(entry).(*filer_pb.Entry)
+}
+
+func (f *testFiler) LookupDirectoryEntry(ctx context.Context, req *filer_pb.LookupDirectoryEntryRequest) (*filer_pb.LookupDirectoryEntryResponse, error) {
+	entry := f.find(path.Join(req.Directory, req.Name))
+	if entry == nil {
+		return nil, fmt.Errorf("%s not found under %s: %v", req.Name, req.Directory, filer2.ErrNotFound)
+	}
+	return &filer_pb.LookupDirectoryEntryResponse{Entry: entry}, nil
+}
+
+func (f *testFiler) ListEntries(ctx context.Context, req *filer_pb.ListEntriesRequest) (*filer_pb.ListEntriesResponse, error) {
+	f.lock.Lock()
+	failList := f.failList
+	f.lock.Unlock()
+	if failList != nil {
+		return nil, failList
+	}
+	resp := &filer_pb.ListEntriesResponse{}
+	for _, name := range f.children(req.Directory) {
+		if !strings.HasPrefix(name, req.Prefix) || name < req.StartFromFileName ||
+			name == req.StartFromFileName && !req.InclusiveStartFrom {
+			continue
+		}
+		if req.Limit > 0 && len(resp.Entries) >= int(req.Limit) {
+			break
+		}
+		if entry := f.find(path.Join(req.Directory, name)); entry != nil {
+			resp.Entries = append(resp.Entries, entry)
+		}
+	}
+	return resp, nil
+}
+
+func (f *testFiler) CreateEntry(ctx context.Context, req *filer_pb.CreateEntryRequest) (*filer_pb.CreateEntryResponse, error) {
+	f.save(req.Directory, req.Entry)
+	return &filer_pb.CreateEntryResponse{}, nil
+}
+
+func (f *testFiler) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntryRequest) (*filer_pb.UpdateEntryResponse, error) {
+	f.save(req.Directory, req.Entry)
+	return &filer_pb.UpdateEntryResponse{}, nil
+}
+
+func (f *testFiler) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntryRequest) (*filer_pb.DeleteEntryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	fullpath := path.Join(req.Directory, req.Name)
+	if _, found := f.entries[fullpath]; !found {
+		return nil, fmt.Errorf("delete %s: %v", fullpath, filer2.ErrNotFound)
+	}
+	for p := range f.entries {
+		if p == fullpath || strings.HasPrefix(p, fullpath+"/") {
+			delete(f.entries, p)
+		}
+	}
+	return &filer_pb.DeleteEntryResponse{}, nil
+}
+
+func (f *testFiler) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	oldPath, newPath := path.Join(req.OldDirectory, req.OldName), path.Join(req.NewDirectory, req.NewName)
+	entry, found := f.entries[oldPath]
+	if !found {
+		return nil, fmt.Errorf("%s not found", oldPath)
+	}
+	for p, e := range f.entries {
+		if strings.HasPrefix(p, oldPath+"/") {
+			delete(f.entries, p)
+			f.entries[newPath+strings.TrimPrefix(p, oldPath)] = e
+		}
+	}
+	delete(f.entries, oldPath)
+	entry.Name = req.NewName
+	f.saveLocked(req.NewDirectory, entry)
+	return &filer_pb.AtomicRenameEntryResponse{}, nil
+}
+
+// ServeHTTP saves the uploaded files, with one chunk of the file size
+func (f *testFiler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	f.lock.Lock()
+	failUpload := f.failUpload
+	f.lock.Unlock()
+	if failUpload {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(weed_server.FilerPostResult{Error: "upload failed"})
+		return
+	}
+
+	dir, name := path.Split(r.URL.Path)
+	f.save(path.Clean(dir), &filer_pb.Entry{
+		Name: name,
+		Attributes: &filer_pb.FuseAttributes{
+			Mtime: time.Now().Unix(),
+		},
+		Chunks: []*filer_pb.FileChunk{{
+			FileId: "1,01637037d6",
+			Size:   uint64(len(body)),
+			Mtime:  time.Now().UnixNano(),
+		}},
+		Extended: filer2.ExtendedFromHeader(r.Header),
+	})
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(weed_server.FilerPostResult{Name: name, Size: uint32(len(body))})
+}
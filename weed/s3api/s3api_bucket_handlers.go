@@ -1,7 +1,6 @@
 package s3api
 
 import (
-	"context"
 	"encoding/xml"
 	"fmt"
 	"math"
@@ -31,15 +30,20 @@ func (s3a *S3ApiServer) ListBucketsHandler(w http.ResponseWriter, r *http.Reques
 
 	var response ListAllMyBucketsResult
 
-	entries, err := s3a.list(context.Background(), s3a.option.BucketsPath, "", "", false, math.MaxInt32)
+	entries, err := s3a.list(requestContext(r), s3a.option.BucketsPath, "", "", false, math.MaxInt32)
 
 	if err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
 		return
 	}
 
+	identity := getIdentity(r)
+
 	var buckets []*s3.Bucket
 	for _, entry := range entries {
+		if identity != nil && !identity.canDo(ACTION_LIST, entry.Name) {
+			continue
+		}
 		if entry.IsDirectory {
 			buckets = append(buckets, &s3.Bucket{
 				Name:         aws.String(entry.Name),
@@ -65,7 +69,7 @@ func (s3a *S3ApiServer) PutBucketHandler(w http.ResponseWriter, r *http.Request)
 	bucket := vars["bucket"]
 
 	// create the folder for bucket, but lazily create actual collection
-	if err := s3a.mkdir(context.Background(), s3a.option.BucketsPath, bucket, nil); err != nil {
+	if err := s3a.mkdir(requestContext(r), s3a.option.BucketsPath, bucket, nil); err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
 		return
 	}
@@ -78,7 +82,7 @@ func (s3a *S3ApiServer) DeleteBucketHandler(w http.ResponseWriter, r *http.Reque
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 
-	ctx := context.Background()
+	ctx := requestContext(r)
 	err := s3a.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 
 		// delete collection
@@ -109,7 +113,7 @@ func (s3a *S3ApiServer) HeadBucketHandler(w http.ResponseWriter, r *http.Request
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 
-	ctx := context.Background()
+	ctx := requestContext(r)
 
 	err := s3a.withFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
 
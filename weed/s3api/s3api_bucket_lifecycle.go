@@ -0,0 +1,273 @@
+package s3api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+)
+
+const (
+	s3LifecycleKey = "s3.lifecycle"
+
+	LifecycleRuleEnabled  = "Enabled"
+	LifecycleRuleDisabled = "Disabled"
+
+	// the max count of the volume ttl
+	maxTtlCount = 255
+)
+
+// LifecycleConfiguration accepts the configuration with or without the S3 namespace
+type LifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"LifecycleConfiguration"`
+	Xmlns   string          `xml:"xmlns,attr,omitempty"`
+	Rules   []LifecycleRule `xml:"Rule"`
+}
+
+type LifecycleRule struct {
+	ID                             string                          `xml:"ID,omitempty"`
+	Status                         string                          `xml:"Status"`
+	Prefix                         *string                         `xml:"Prefix"` // deprecated by Filter, but still used by some clients
+	Filter                         *LifecycleFilter                `xml:"Filter"`
+	Expiration                     *LifecycleExpiration            `xml:"Expiration"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload"`
+}
+
+type LifecycleFilter struct {
+	Prefix string    `xml:"Prefix"`
+	Tag    *struct{} `xml:"Tag"` // not supported
+	And    *struct{} `xml:"And"` // not supported
+}
+
+type LifecycleExpiration struct {
+	Days int    `xml:"Days,omitempty"`
+	Date string `xml:"Date,omitempty"`
+}
+
+type AbortIncompleteMultipartUpload struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+}
+
+func (rule *LifecycleRule) prefix() string {
+	if rule.Filter != nil {
+		return rule.Filter.Prefix
+	}
+	if rule.Prefix != nil {
+		return *rule.Prefix
+	}
+	return ""
+}
+
+// matches checks whether the object "/a/b/c.txt" is covered by the rule
+func (rule *LifecycleRule) matches(object string) bool {
+	return rule.Status == LifecycleRuleEnabled && strings.HasPrefix(strings.TrimPrefix(object, "/"), rule.prefix())
+}
+
+// isExpired checks whether an object modified at mtime is expired by the rule
+func (rule *LifecycleRule) isExpired(mtime, now time.Time) bool {
+	if rule.Expiration == nil {
+		return false
+	}
+	if rule.Expiration.Days > 0 {
+		return mtime.Add(time.Duration(rule.Expiration.Days) * 24 * time.Hour).Before(now)
+	}
+	if date, err := time.Parse(time.RFC3339, rule.Expiration.Date); err == nil {
+		return !date.After(now)
+	}
+	return false
+}
+
+// isStaleUpload checks whether a multipart upload initiated at crtime should be aborted by the rule
+func (rule *LifecycleRule) isStaleUpload(crtime, now time.Time) bool {
+	if rule.AbortIncompleteMultipartUpload == nil {
+		return false
+	}
+	days := rule.AbortIncompleteMultipartUpload.DaysAfterInitiation
+	return crtime.Add(time.Duration(days) * 24 * time.Hour).Before(now)
+}
+
+func (rule *LifecycleRule) validate() error {
+	if rule.Status != LifecycleRuleEnabled && rule.Status != LifecycleRuleDisabled {
+		return fmt.Errorf("rule %s: unknown status %q", rule.ID, rule.Status)
+	}
+	if rule.Filter != nil && (rule.Filter.Tag != nil || rule.Filter.And != nil) {
+		return fmt.Errorf("rule %s: only the prefix filter is supported", rule.ID)
+	}
+	if rule.Expiration == nil && rule.AbortIncompleteMultipartUpload == nil {
+		return fmt.Errorf("rule %s: no lifecycle action", rule.ID)
+	}
+	if rule.Expiration != nil {
+		if (rule.Expiration.Days > 0) == (rule.Expiration.Date != "") {
+			return fmt.Errorf("rule %s: expiration needs either days or date", rule.ID)
+		}
+		if rule.Expiration.Date != "" {
+			if _, err := time.Parse(time.RFC3339, rule.Expiration.Date); err != nil {
+				return fmt.Errorf("rule %s: expiration date %s: %v", rule.ID, rule.Expiration.Date, err)
+			}
+		}
+	}
+	if rule.AbortIncompleteMultipartUpload != nil && rule.AbortIncompleteMultipartUpload.DaysAfterInitiation <= 0 {
+		return fmt.Errorf("rule %s: days after initiation should be positive", rule.ID)
+	}
+	return nil
+}
+
+// expirationTtl returns the ttl for a new object, in the volume ttl format, e.g., 30d
+// The ttl only works as a hint, since the expiration by date is left to the lifecycle scanner.
+func (c *LifecycleConfiguration) expirationTtl(object string) string {
+	days := 0
+	for _, rule := range c.Rules {
+		if !rule.matches(object) || rule.Expiration == nil || rule.Expiration.Days <= 0 {
+			continue
+		}
+		if days == 0 || rule.Expiration.Days < days {
+			days = rule.Expiration.Days
+		}
+	}
+	if days == 0 {
+		return ""
+	}
+	if days <= maxTtlCount {
+		return fmt.Sprintf("%dd", days)
+	}
+	if weeks := (days + 6) / 7; weeks <= maxTtlCount {
+		return fmt.Sprintf("%dw", weeks)
+	}
+	years := (days + 364) / 365
+	if years > maxTtlCount {
+		years = maxTtlCount
+	}
+	return fmt.Sprintf("%dy", years)
+}
+
+func (s3a *S3ApiServer) getBucketLifecycle(ctx context.Context, bucket string) (*LifecycleConfiguration, error) {
+	bucketEntry, err := s3a.lookupEntry(ctx, s3a.option.BucketsPath, bucket)
+	if err != nil {
+		return nil, err
+	}
+	data, found := bucketEntry.Extended[s3LifecycleKey]
+	if !found {
+		return nil, nil
+	}
+	config := &LifecycleConfiguration{}
+	if err = xml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parse bucket %s lifecycle: %v", bucket, err)
+	}
+	return config, nil
+}
+
+// objectTtl returns the ttl for the new object according to the bucket lifecycle
+// The versioned buckets do not use the ttl, to keep the noncurrent versions readable.
+func (s3a *S3ApiServer) objectTtl(ctx context.Context, bucket, object string, versioning VersioningStatus) string {
+	if versioning != "" {
+		return ""
+	}
+	config, err := s3a.getBucketLifecycle(ctx, bucket)
+	if err != nil || config == nil {
+		return ""
+	}
+	return config.expirationTtl(object)
+}
+
+func (s3a *S3ApiServer) setBucketLifecycle(ctx context.Context, bucket string, data []byte) ErrorCode {
+
+	bucketEntry, err := s3a.lookupEntry(ctx, s3a.option.BucketsPath, bucket)
+	if err != nil {
+		return ErrNoSuchBucket
+	}
+	if data == nil {
+		delete(bucketEntry.Extended, s3LifecycleKey)
+	} else {
+		if bucketEntry.Extended == nil {
+			bucketEntry.Extended = make(map[string][]byte)
+		}
+		bucketEntry.Extended[s3LifecycleKey] = data
+	}
+
+	if err = s3a.updateEntry(ctx, s3a.option.BucketsPath, bucketEntry); err != nil {
+		glog.Errorf("set bucket %s lifecycle: %v", bucket, err)
+		return ErrInternalError
+	}
+
+	return ErrNone
+}
+
+// PutBucketLifecycleConfigurationHandler replaces the lifecycle rules of the bucket
+func (s3a *S3ApiServer) PutBucketLifecycleConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	var config LifecycleConfiguration
+	if err = xml.Unmarshal(body, &config); err != nil || len(config.Rules) == 0 {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+	for _, rule := range config.Rules {
+		if err = rule.validate(); err != nil {
+			glog.V(1).Infof("put bucket %s lifecycle: %v", bucket, err)
+			writeErrorResponse(w, ErrInvalidRequest, r.URL)
+			return
+		}
+	}
+
+	config.Xmlns = ""
+	data, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	if errCode := s3a.setBucketLifecycle(requestContext(r), bucket, data); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+// GetBucketLifecycleConfigurationHandler returns the lifecycle rules of the bucket
+func (s3a *S3ApiServer) GetBucketLifecycleConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	config, err := s3a.getBucketLifecycle(requestContext(r), bucket)
+	if err != nil {
+		glog.V(1).Infof("get bucket %s lifecycle: %v", bucket, err)
+		writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+		return
+	}
+	if config == nil {
+		writeErrorResponse(w, ErrNoSuchLifecycleConfiguration, r.URL)
+		return
+	}
+
+	config.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+	writeSuccessResponseXML(w, encodeResponse(config))
+}
+
+// DeleteBucketLifecycleHandler removes the lifecycle rules of the bucket
+func (s3a *S3ApiServer) DeleteBucketLifecycleHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if errCode := s3a.setBucketLifecycle(requestContext(r), bucket, nil); errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
@@ -0,0 +1,173 @@
+package s3api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+const lifecycleListLimit = 1024
+
+// loopLifecycleScan periodically applies the bucket lifecycle rules to the existing objects
+func (s3a *S3ApiServer) loopLifecycleScan(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s3a.scanLifecycle(context.Background(), time.Now())
+	}
+}
+
+func (s3a *S3ApiServer) scanLifecycle(ctx context.Context, now time.Time) {
+
+	buckets, err := s3a.list(ctx, s3a.option.BucketsPath, "", "", false, math.MaxInt32)
+	if err != nil {
+		glog.Errorf("lifecycle scan: %v", err)
+		return
+	}
+
+	for _, bucketEntry := range buckets {
+		data, found := bucketEntry.Extended[s3LifecycleKey]
+		if !bucketEntry.IsDirectory || !found {
+			continue
+		}
+		config := &LifecycleConfiguration{}
+		if err = xml.Unmarshal(data, config); err != nil {
+			glog.Errorf("lifecycle scan bucket %s: %v", bucketEntry.Name, err)
+			continue
+		}
+		versioning := VersioningStatus(bucketEntry.Extended[s3VersioningKey])
+		if err = s3a.applyBucketLifecycle(ctx, bucketEntry.Name, config, versioning, now); err != nil {
+			glog.Errorf("lifecycle scan bucket %s: %v", bucketEntry.Name, err)
+		}
+	}
+
+}
+
+func (s3a *S3ApiServer) applyBucketLifecycle(ctx context.Context, bucket string, config *LifecycleConfiguration, versioning VersioningStatus, now time.Time) error {
+
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		if rule.Status != LifecycleRuleEnabled {
+			continue
+		}
+		if rule.Expiration != nil {
+			if err := s3a.expireObjects(ctx, bucket, rule, versioning, now); err != nil {
+				return fmt.Errorf("rule %s: %v", rule.ID, err)
+			}
+		}
+		if rule.AbortIncompleteMultipartUpload != nil {
+			if err := s3a.abortStaleUploads(ctx, bucket, rule, now); err != nil {
+				return fmt.Errorf("rule %s: %v", rule.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expireObjects deletes the expired objects, or adds delete markers to them if the bucket is versioned
+func (s3a *S3ApiServer) expireObjects(ctx context.Context, bucket string, rule *LifecycleRule, versioning VersioningStatus, now time.Time) error {
+
+	bucketDir := s3a.bucketDir(bucket)
+	prefix := rule.prefix()
+
+	// start from the deepest folder covering the prefix
+	startDir := bucketDir
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		startDir = bucketDir + "/" + prefix[:i]
+	}
+
+	return s3a.walkObjects(ctx, bucketDir, startDir, prefix, func(dir string, entry *filer_pb.Entry) error {
+
+		object := strings.TrimPrefix(dir+"/"+entry.Name, bucketDir)
+		if !rule.matches(object) || !rule.isExpired(time.Unix(entry.Attributes.Mtime, 0), now) {
+			return nil
+		}
+
+		glog.V(1).Infof("lifecycle rule %s expires %s%s", rule.ID, bucket, object)
+		if versioning == "" {
+			return s3a.rm(ctx, dir, entry.Name, false, true, false)
+		}
+		_, err := s3a.addDeleteMarker(ctx, bucket, object, versioning)
+		return err
+	})
+}
+
+// walkObjects visits the files under the directory whose object names may start with the prefix
+func (s3a *S3ApiServer) walkObjects(ctx context.Context, bucketDir, dir, prefix string, fn func(dir string, entry *filer_pb.Entry) error) error {
+
+	lastFileName := ""
+	for {
+		entries, err := s3a.list(ctx, dir, "", lastFileName, false, lifecycleListLimit)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name
+			if !entry.IsDirectory {
+				if err = fn(dir, entry); err != nil {
+					return err
+				}
+				continue
+			}
+			if dir == bucketDir && (entry.Name == versionsFolder || entry.Name == uploadsFolder) {
+				continue
+			}
+			subDir := dir + "/" + entry.Name
+			folder := strings.TrimPrefix(subDir, bucketDir+"/") + "/"
+			if !strings.HasPrefix(folder, prefix) && !strings.HasPrefix(prefix, folder) {
+				continue
+			}
+			if err = s3a.walkObjects(ctx, bucketDir, subDir, prefix, fn); err != nil {
+				return err
+			}
+		}
+		if len(entries) < lifecycleListLimit {
+			return nil
+		}
+	}
+
+}
+
+// abortStaleUploads removes the incomplete multipart uploads and their uploaded parts
+func (s3a *S3ApiServer) abortStaleUploads(ctx context.Context, bucket string, rule *LifecycleRule, now time.Time) error {
+
+	uploadsDir := s3a.genUploadsFolder(bucket)
+
+	lastFileName := ""
+	for {
+		entries, err := s3a.list(ctx, uploadsDir, "", lastFileName, false, lifecycleListLimit)
+		if filer2.IsNotFound(err) {
+			// no uploads yet
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name
+			if !entry.IsDirectory {
+				continue
+			}
+			key := string(entry.Extended["key"])
+			if !rule.matches(key) || !rule.isStaleUpload(time.Unix(entry.Attributes.Crtime, 0), now) {
+				continue
+			}
+			glog.V(1).Infof("lifecycle rule %s aborts upload %s of %s%s", rule.ID, entry.Name, bucket, key)
+			if err = s3a.rm(ctx, uploadsDir, entry.Name, true, true, true); err != nil {
+				return err
+			}
+		}
+		if len(entries) < lifecycleListLimit {
+			return nil
+		}
+	}
+
+}
@@ -0,0 +1,182 @@
+package s3api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestLifecycleConfiguration(t *testing.T) {
+
+	input := `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule>
+    <ID>logs</ID>
+    <Filter><Prefix>logs/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>30</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>uploads</ID>
+    <Prefix></Prefix>
+    <Status>Enabled</Status>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+  <Rule>
+    <ID>disabled</ID>
+    <Filter><Prefix>logs/app/</Prefix></Filter>
+    <Status>Disabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+  </Rule>
+</LifecycleConfiguration>`
+
+	var config LifecycleConfiguration
+	if err := xml.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(config.Rules) != 3 {
+		t.Fatalf("unexpected rules: %+v", config.Rules)
+	}
+	for _, rule := range config.Rules {
+		if err := rule.validate(); err != nil {
+			t.Errorf("validate: %v", err)
+		}
+	}
+
+	logs, uploads := &config.Rules[0], &config.Rules[1]
+	if !logs.matches("/logs/app/1.log") || logs.matches("/data/1.log") {
+		t.Errorf("rule %s prefix %s", logs.ID, logs.prefix())
+	}
+	if !uploads.matches("/data/1.log") {
+		t.Errorf("rule %s should match all objects", uploads.ID)
+	}
+	if config.Rules[2].matches("/logs/app/1.log") {
+		t.Errorf("disabled rule should not match")
+	}
+
+	now := time.Now()
+	if !logs.isExpired(now.Add(-31*24*time.Hour), now) || logs.isExpired(now.Add(-29*24*time.Hour), now) {
+		t.Errorf("rule %s expiration by days", logs.ID)
+	}
+	if logs.isStaleUpload(now.Add(-31*24*time.Hour), now) {
+		t.Errorf("rule %s should not abort uploads", logs.ID)
+	}
+	if !uploads.isStaleUpload(now.Add(-8*24*time.Hour), now) || uploads.isStaleUpload(now.Add(-6*24*time.Hour), now) {
+		t.Errorf("rule %s abort incomplete uploads", uploads.ID)
+	}
+
+	if ttl := config.expirationTtl("/logs/app/1.log"); ttl != "30d" {
+		t.Errorf("unexpected ttl %s", ttl)
+	}
+	if ttl := config.expirationTtl("/data/1.log"); ttl != "" {
+		t.Errorf("unexpected ttl %s", ttl)
+	}
+
+}
+
+func TestLifecycleExpirationTtl(t *testing.T) {
+	for days, expected := range map[int]string{
+		1:    "1d",
+		255:  "255d",
+		256:  "37w",
+		1785: "255w",
+		1786: "5y",
+	} {
+		config := &LifecycleConfiguration{Rules: []LifecycleRule{{
+			Status:     LifecycleRuleEnabled,
+			Expiration: &LifecycleExpiration{Days: days},
+		}}}
+		if ttl := config.expirationTtl("/a.txt"); ttl != expected {
+			t.Errorf("%d days: ttl %s, expected %s", days, ttl, expected)
+		}
+	}
+}
+
+func TestLifecycleRuleValidate(t *testing.T) {
+
+	now := time.Now()
+	dateRule := LifecycleRule{
+		Status:     LifecycleRuleEnabled,
+		Expiration: &LifecycleExpiration{Date: now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+	}
+	if err := dateRule.validate(); err != nil {
+		t.Errorf("validate: %v", err)
+	}
+	if !dateRule.isExpired(now, now) {
+		t.Errorf("objects should be expired after the date")
+	}
+
+	for _, rule := range []LifecycleRule{
+		{Status: "enabled", Expiration: &LifecycleExpiration{Days: 1}},
+		{Status: LifecycleRuleEnabled},
+		{Status: LifecycleRuleEnabled, Expiration: &LifecycleExpiration{}},
+		{Status: LifecycleRuleEnabled, Expiration: &LifecycleExpiration{Days: 1, Date: "2019-01-01T00:00:00Z"}},
+		{Status: LifecycleRuleEnabled, Expiration: &LifecycleExpiration{Date: "2019-01-01"}},
+		{Status: LifecycleRuleEnabled, AbortIncompleteMultipartUpload: &AbortIncompleteMultipartUpload{}},
+		{Status: LifecycleRuleEnabled, Filter: &LifecycleFilter{Tag: &struct{}{}}, Expiration: &LifecycleExpiration{Days: 1}},
+	} {
+		if err := rule.validate(); err == nil {
+			t.Errorf("rule %+v should be invalid", rule)
+		}
+	}
+
+}
+
+func TestLifecycleAbortStaleUploads(t *testing.T) {
+	s := newTestS3ApiServer(t)
+	defer s.close()
+	s.createBucket("bucket", "")
+
+	ctx := context.Background()
+	now := time.Now()
+	rule := &LifecycleRule{
+		Status:                         LifecycleRuleEnabled,
+		AbortIncompleteMultipartUpload: &AbortIncompleteMultipartUpload{DaysAfterInitiation: 7},
+	}
+
+	// no uploads yet
+	if err := s.abortStaleUploads(ctx, "bucket", rule, now); err != nil {
+		t.Fatalf("abort without uploads: %v", err)
+	}
+
+	uploadsDir := s.genUploadsFolder("bucket")
+	for name, crtime := range map[string]time.Time{
+		"stale": now.Add(-8 * 24 * time.Hour),
+		"fresh": now.Add(-time.Hour),
+	} {
+		s.filer.save(uploadsDir, &filer_pb.Entry{
+			Name:        name,
+			IsDirectory: true,
+			Attributes:  &filer_pb.FuseAttributes{Crtime: crtime.Unix()},
+			Extended:    map[string][]byte{"key": []byte("/" + name + ".txt")},
+		})
+		s.filer.save(uploadsDir+"/"+name, &filer_pb.Entry{Name: "0001.part", Attributes: &filer_pb.FuseAttributes{}})
+	}
+	if err := s.abortStaleUploads(ctx, "bucket", rule, now); err != nil {
+		t.Fatalf("abort stale uploads: %v", err)
+	}
+	if names := s.filer.children(uploadsDir); len(names) != 1 || names[0] != "fresh" {
+		t.Errorf("uploads after abort: %v", names)
+	}
+	if s.filer.find(uploadsDir+"/stale/0001.part") != nil {
+		t.Errorf("the parts of the aborted upload are kept")
+	}
+
+	// the uploads folder not found is skipped, but other errors are returned
+	s.filer.lock.Lock()
+	s.filer.failList = fmt.Errorf("list %s: %v", uploadsDir, filer2.ErrNotFound)
+	s.filer.lock.Unlock()
+	if err := s.abortStaleUploads(ctx, "bucket", rule, now); err != nil {
+		t.Errorf("abort without the uploads folder: %v", err)
+	}
+	s.filer.lock.Lock()
+	s.filer.failList = fmt.Errorf("filer store unavailable")
+	s.filer.lock.Unlock()
+	if err := s.abortStaleUploads(ctx, "bucket", rule, now); err == nil {
+		t.Errorf("abort with the list failed")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server"
 )
@@ -41,19 +42,49 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 	rAuthType := getRequestAuthType(r)
 	dataReader := r.Body
 	if rAuthType == authTypeStreamingSigned {
-		dataReader = newSignV4ChunkedReader(r)
+		var errCode ErrorCode
+		if dataReader, errCode = s3a.iam.newSignV4ChunkedReader(r); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
 	}
 
+	ctx := requestContext(r)
+
+	versioning := s3a.getBucketVersioning(ctx, bucket)
+
 	uploadUrl := fmt.Sprintf("http://%s%s/%s%s?collection=%s",
 		s3a.option.Filer, s3a.option.BucketsPath, bucket, object, bucket)
+	if ttl := s3a.objectTtl(ctx, bucket, object, versioning); ttl != "" {
+		uploadUrl += "&ttl=" + ttl
+	}
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
+	// the new version is uploaded under the versions folder, and rotated to the object after the upload completes
+	var versionId, stagingName string
+	var extended map[string][]byte
+	if versioning != "" {
+		versionId, stagingName = newVersionNames(versioning)
+		extended = versionExtended(versionId)
+		uploadUrl = fmt.Sprintf("%s?collection=%s", s3a.versionUrl(bucket, object, stagingName), bucket)
+	}
+
+	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, extended)
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
 		return
 	}
 
+	if versioning != "" {
+		if err = s3a.rotateNewVersion(ctx, bucket, object, versionId, stagingName, versioning, false); err != nil {
+			glog.Errorf("rotate new version of %s/%s: %v", bucket, object, err)
+			s3a.removeNewVersion(ctx, bucket, object, stagingName, true)
+			writeErrorResponse(w, ErrInternalError, r.URL)
+			return
+		}
+		w.Header().Set("x-amz-version-id", versionId)
+	}
+
 	setEtag(w, etag)
 
 	writeSuccessResponseEmpty(w)
@@ -70,6 +101,11 @@ func (s3a *S3ApiServer) GetObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+		s3a.proxyObjectVersionToFiler(w, r, bucket, object, versionId)
+		return
+	}
+
 	destUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
 
@@ -83,6 +119,11 @@ func (s3a *S3ApiServer) HeadObjectHandler(w http.ResponseWriter, r *http.Request
 	bucket := vars["bucket"]
 	object := getObject(vars)
 
+	if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+		s3a.proxyObjectVersionToFiler(w, r, bucket, object, versionId)
+		return
+	}
+
 	destUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
 
@@ -96,6 +137,26 @@ func (s3a *S3ApiServer) DeleteObjectHandler(w http.ResponseWriter, r *http.Reque
 	bucket := vars["bucket"]
 	object := getObject(vars)
 
+	ctx := requestContext(r)
+
+	if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+		if errCode := s3a.deleteObjectVersion(ctx, w, bucket, object, versionId); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+		writeResponse(w, http.StatusNoContent, nil, mimeNone)
+		return
+	}
+
+	if versioning := s3a.getBucketVersioning(ctx, bucket); versioning != "" {
+		if errCode := s3a.createDeleteMarker(ctx, w, bucket, object, versioning); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
+		writeResponse(w, http.StatusNoContent, nil, mimeNone)
+		return
+	}
+
 	destUrl := fmt.Sprintf("http://%s%s/%s%s",
 		s3a.option.Filer, s3a.option.BucketsPath, bucket, object)
 
@@ -135,6 +196,7 @@ func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, des
 			proxyReq.Header.Add(header, value)
 		}
 	}
+	filer2.SetIdentityHeader(proxyReq.Header, posixIdentity(r))
 
 	resp, postErr := client.Do(proxyReq)
 
@@ -155,7 +217,7 @@ func passThroughResponse(proxyResonse *http.Response, w http.ResponseWriter) {
 	io.Copy(w, proxyResonse.Body)
 }
 
-func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.ReadCloser) (etag string, code ErrorCode) {
+func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader io.ReadCloser, extended map[string][]byte) (etag string, code ErrorCode) {
 
 	hash := md5.New()
 	var body = io.TeeReader(dataReader, hash)
@@ -175,6 +237,8 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 			proxyReq.Header.Add(header, value)
 		}
 	}
+	filer2.SetIdentityHeader(proxyReq.Header, posixIdentity(r))
+	filer2.SetExtendedHeader(proxyReq.Header, extended)
 
 	resp, postErr := client.Do(proxyReq)
 
@@ -201,6 +265,9 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 	}
 	if ret.Error != "" {
 		glog.Errorf("upload to filer error: %v", ret.Error)
+		if resp.StatusCode == http.StatusForbidden {
+			return "", ErrAccessDenied
+		}
 		return "", ErrInternalError
 	}
 
@@ -1,7 +1,6 @@
 package s3api
 
 import (
-	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -26,7 +25,7 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 	bucket = vars["bucket"]
 	object = vars["object"]
 
-	response, errCode := s3a.createMultipartUpload(context.Background(), &s3.CreateMultipartUploadInput{
+	response, errCode := s3a.createMultipartUpload(requestContext(r), &s3.CreateMultipartUploadInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(object),
 	})
@@ -51,7 +50,7 @@ func (s3a *S3ApiServer) CompleteMultipartUploadHandler(w http.ResponseWriter, r
 	// Get upload id.
 	uploadID, _, _, _ := getObjectResources(r.URL.Query())
 
-	response, errCode := s3a.completeMultipartUpload(context.Background(), &s3.CompleteMultipartUploadInput{
+	response, errCode := s3a.completeMultipartUpload(requestContext(r), &s3.CompleteMultipartUploadInput{
 		Bucket:   aws.String(bucket),
 		Key:      aws.String(object),
 		UploadId: aws.String(uploadID),
@@ -77,7 +76,7 @@ func (s3a *S3ApiServer) AbortMultipartUploadHandler(w http.ResponseWriter, r *ht
 	// Get upload id.
 	uploadID, _, _, _ := getObjectResources(r.URL.Query())
 
-	response, errCode := s3a.abortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+	response, errCode := s3a.abortMultipartUpload(requestContext(r), &s3.AbortMultipartUploadInput{
 		Bucket:   aws.String(bucket),
 		Key:      aws.String(object),
 		UploadId: aws.String(uploadID),
@@ -112,7 +111,7 @@ func (s3a *S3ApiServer) ListMultipartUploadsHandler(w http.ResponseWriter, r *ht
 		}
 	}
 
-	response, errCode := s3a.listMultipartUploads(context.Background(), &s3.ListMultipartUploadsInput{
+	response, errCode := s3a.listMultipartUploads(requestContext(r), &s3.ListMultipartUploadsInput{
 		Bucket:         aws.String(bucket),
 		Delimiter:      aws.String(delimiter),
 		EncodingType:   aws.String(encodingType),
@@ -149,7 +148,7 @@ func (s3a *S3ApiServer) ListObjectPartsHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	response, errCode := s3a.listObjectParts(context.Background(), &s3.ListPartsInput{
+	response, errCode := s3a.listObjectParts(requestContext(r), &s3.ListPartsInput{
 		Bucket:           aws.String(bucket),
 		Key:              aws.String(object),
 		MaxParts:         aws.Int64(int64(maxParts)),
@@ -175,7 +174,7 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 
 	rAuthType := getRequestAuthType(r)
 
-	ctx := context.Background()
+	ctx := requestContext(r)
 
 	uploadID := r.URL.Query().Get("uploadId")
 	exists, err := s3a.exists(ctx, s3a.genUploadsFolder(bucket), uploadID, true)
@@ -197,13 +196,20 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 
 	dataReader := r.Body
 	if rAuthType == authTypeStreamingSigned {
-		dataReader = newSignV4ChunkedReader(r)
+		var errCode ErrorCode
+		if dataReader, errCode = s3a.iam.newSignV4ChunkedReader(r); errCode != ErrNone {
+			writeErrorResponse(w, errCode, r.URL)
+			return
+		}
 	}
 
 	uploadUrl := fmt.Sprintf("http://%s%s/%s/%04d.part?collection=%s",
 		s3a.option.Filer, s3a.genUploadsFolder(bucket), uploadID, partID-1, bucket)
+	if ttl := s3a.objectTtl(ctx, bucket, getObject(vars), s3a.getBucketVersioning(ctx, bucket)); ttl != "" {
+		uploadUrl += "&ttl=" + ttl
+	}
 
-	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
+	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader, nil)
 
 	if errCode != ErrNone {
 		writeErrorResponse(w, errCode, r.URL)
@@ -217,7 +223,7 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 }
 
 func (s3a *S3ApiServer) genUploadsFolder(bucket string) string {
-	return fmt.Sprintf("%s/%s/.uploads", s3a.option.BucketsPath, bucket)
+	return fmt.Sprintf("%s/%s/%s", s3a.option.BucketsPath, bucket, uploadsFolder)
 }
 
 // Parse bucket url queries for ?uploads
@@ -0,0 +1,685 @@
+package s3api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+/*
+
+The current version of an object stays at the object path, so the normal reads and listings are not changed.
+
+The noncurrent versions and the delete markers are hidden under the bucket directory:
+
+	<bucket>/.versions/<escaped object key>/<version id>
+
+The version id is derived from the inverted creation time, so the newer versions are listed first.
+The objects created when the versioning is not enabled have the "null" version id.
+
+A new version is written under the versions folder first, together with its version id,
+and then made the latest version. So a failed upload does not change the existing versions.
+
+*/
+
+const (
+	versionsFolder    = ".versions"
+	uploadsFolder     = ".uploads"
+	nullVersionId     = "null"
+	s3VersioningKey   = "s3.versioning"
+	s3VersionIdKey    = "s3.versionId"
+	s3DeleteMarkerKey = "s3.deleteMarker"
+	s3VersionTimeKey  = "s3.versionTime" // the creation time of the null version in nanoseconds
+
+	VersioningEnabled   VersioningStatus = "Enabled"
+	VersioningSuspended VersioningStatus = "Suspended"
+)
+
+// VersioningConfigurationResult accepts the configuration with or without the S3 namespace
+type VersioningConfigurationResult struct {
+	XMLName xml.Name         `xml:"VersioningConfiguration"`
+	Xmlns   string           `xml:"xmlns,attr,omitempty"`
+	Status  VersioningStatus `xml:"Status,omitempty"`
+}
+
+type ListBucketVersionsResult struct {
+	XMLName             xml.Name            `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+	Name                string              `xml:"Name"`
+	Prefix              string              `xml:"Prefix"`
+	KeyMarker           string              `xml:"KeyMarker"`
+	VersionIdMarker     string              `xml:"VersionIdMarker"`
+	NextKeyMarker       string              `xml:"NextKeyMarker,omitempty"`
+	NextVersionIdMarker string              `xml:"NextVersionIdMarker,omitempty"`
+	MaxKeys             int                 `xml:"MaxKeys"`
+	Delimiter           string              `xml:"Delimiter,omitempty"`
+	IsTruncated         bool                `xml:"IsTruncated"`
+	Versions            []VersionEntry      `xml:"Version,omitempty"`
+	DeleteMarkers       []DeleteMarkerEntry `xml:"DeleteMarker,omitempty"`
+	CommonPrefixes      []PrefixEntry       `xml:"CommonPrefixes,omitempty"`
+}
+
+func newVersionId() string {
+	var random [4]byte
+	rand.Read(random[:])
+	return fmt.Sprintf("%016x%08x", math.MaxInt64-time.Now().UnixNano(), binary.BigEndian.Uint32(random[:]))
+}
+
+func getVersionId(entry *filer_pb.Entry) string {
+	if versionId, found := entry.Extended[s3VersionIdKey]; found {
+		return string(versionId)
+	}
+	return nullVersionId
+}
+
+func isDeleteMarker(entry *filer_pb.Entry) bool {
+	_, found := entry.Extended[s3DeleteMarkerKey]
+	return found
+}
+
+func (s3a *S3ApiServer) bucketDir(bucket string) string {
+	return fmt.Sprintf("%s/%s", s3a.option.BucketsPath, bucket)
+}
+
+// objectDirAndName splits the object "/a/b/c.txt" into the filer directory and the entry name
+func (s3a *S3ApiServer) objectDirAndName(bucket, object string) (string, string) {
+	return path.Join(s3a.bucketDir(bucket), path.Dir(object)), path.Base(object)
+}
+
+func (s3a *S3ApiServer) versionsDir(bucket, object string) string {
+	return fmt.Sprintf("%s/%s/%s", s3a.bucketDir(bucket), versionsFolder, url.QueryEscape(strings.TrimPrefix(object, "/")))
+}
+
+func (s3a *S3ApiServer) getBucketVersioning(ctx context.Context, bucket string) VersioningStatus {
+	bucketEntry, err := s3a.lookupEntry(ctx, s3a.option.BucketsPath, bucket)
+	if err != nil {
+		return ""
+	}
+	return VersioningStatus(bucketEntry.Extended[s3VersioningKey])
+}
+
+// newVersionNames returns the version id of the new version, and the name it is written with under the versions folder.
+// The new null version is written with a random name, not to replace the existing null version before it is complete.
+func newVersionNames(versioning VersioningStatus) (versionId, stagingName string) {
+	if versioning == VersioningEnabled {
+		versionId = newVersionId()
+		return versionId, versionId
+	}
+	return nullVersionId, newVersionId()
+}
+
+// versionExtended returns the extended attributes written together with the new version
+func versionExtended(versionId string) map[string][]byte {
+	if versionId == nullVersionId {
+		return map[string][]byte{
+			s3VersionTimeKey: []byte(strconv.FormatInt(time.Now().UnixNano(), 10)),
+		}
+	}
+	return map[string][]byte{
+		s3VersionIdKey: []byte(versionId),
+	}
+}
+
+// versionUrl is the filer url of the entry under the versions folder of the object
+func (s3a *S3ApiServer) versionUrl(bucket, object, name string) string {
+	return fmt.Sprintf("http://%s%s/%s/%s/%s", s3a.option.Filer, s3a.bucketDir(bucket), versionsFolder,
+		url.PathEscape(url.QueryEscape(strings.TrimPrefix(object, "/"))), url.PathEscape(name))
+}
+
+type versionMove struct {
+	fromDir, fromName, toDir, toName string
+}
+
+// rotateNewVersion makes the new version, already written under the versions folder with the staging name, the latest version.
+// The current version becomes noncurrent, and the null version is replaced by the new null version.
+// On failure, the moved versions are moved back, and the caller removes the new version.
+func (s3a *S3ApiServer) rotateNewVersion(ctx context.Context, bucket, object, versionId, stagingName string, versioning VersioningStatus, deleteMarker bool) (err error) {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	versionsDir := s3a.versionsDir(bucket, object)
+
+	var moved []versionMove
+	var replaced []string
+	move := func(fromDir, fromName, toDir, toName string) error {
+		if err := s3a.mv(ctx, fromDir, fromName, toDir, toName); err != nil {
+			return err
+		}
+		moved = append(moved, versionMove{fromDir, fromName, toDir, toName})
+		return nil
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(moved) - 1; i >= 0; i-- {
+			m := moved[i]
+			if undoErr := s3a.mv(ctx, m.toDir, m.toName, m.fromDir, m.fromName); undoErr != nil {
+				glog.Errorf("move %s/%s back to %s/%s: %v", m.toDir, m.toName, m.fromDir, m.fromName, undoErr)
+			}
+		}
+	}()
+
+	// the current version becomes noncurrent, or is put aside to be replaced by the new null version
+	if current, lookupErr := s3a.lookupEntry(ctx, dir, name); lookupErr == nil && !current.IsDirectory {
+		currentName := getVersionId(current)
+		if versioning != VersioningEnabled && currentName == nullVersionId {
+			currentName = newVersionId()
+			replaced = append(replaced, currentName)
+		}
+		if err = move(dir, name, versionsDir, currentName); err != nil {
+			return err
+		}
+	}
+
+	// the noncurrent null version is put aside to be replaced by the new null version
+	if versioning != VersioningEnabled {
+		if _, lookupErr := s3a.lookupEntry(ctx, versionsDir, nullVersionId); lookupErr == nil {
+			aside := newVersionId()
+			if err = move(versionsDir, nullVersionId, versionsDir, aside); err != nil {
+				return err
+			}
+			replaced = append(replaced, aside)
+		}
+	}
+
+	// the delete marker stays in the versions folder, and the new object version becomes current
+	if !deleteMarker {
+		err = move(versionsDir, stagingName, dir, name)
+	} else if stagingName != versionId {
+		err = move(versionsDir, stagingName, versionsDir, versionId)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, aside := range replaced {
+		if rmErr := s3a.rm(ctx, versionsDir, aside, false, true, false); rmErr != nil {
+			glog.Errorf("remove replaced null version %s/%s: %v", versionsDir, aside, rmErr)
+		}
+	}
+
+	return nil
+}
+
+// removeNewVersion removes the new version not made the latest version
+func (s3a *S3ApiServer) removeNewVersion(ctx context.Context, bucket, object, stagingName string, isDeleteData bool) {
+	versionsDir := s3a.versionsDir(bucket, object)
+	if err := s3a.rm(ctx, versionsDir, stagingName, false, isDeleteData, false); err != nil {
+		glog.Errorf("remove new version %s/%s: %v", versionsDir, stagingName, err)
+	}
+}
+
+// versionTimeNs is the creation time of the version in nanoseconds
+func versionTimeNs(entry *filer_pb.Entry) int64 {
+	if versionId := getVersionId(entry); versionId != nullVersionId && len(versionId) >= 16 {
+		if inverted, err := strconv.ParseInt(versionId[:16], 16, 64); err == nil {
+			return math.MaxInt64 - inverted
+		}
+	}
+	if versionTime, found := entry.Extended[s3VersionTimeKey]; found {
+		if ns, err := strconv.ParseInt(string(versionTime), 10, 64); err == nil {
+			return ns
+		}
+	}
+	// the null versions written before the version time is kept
+	var ns int64
+	for _, chunk := range entry.Chunks {
+		if chunk.Mtime > ns {
+			ns = chunk.Mtime
+		}
+	}
+	if ns == 0 {
+		ns = entry.Attributes.Mtime * int64(time.Second)
+	}
+	return ns
+}
+
+// listNoncurrentVersions returns the noncurrent versions and the delete markers, the newest first.
+// The entries not named by their version ids are the new versions not rotated yet, or the replaced null versions.
+func (s3a *S3ApiServer) listNoncurrentVersions(ctx context.Context, bucket, object string) ([]*filer_pb.Entry, error) {
+	entries, err := s3a.list(ctx, s3a.versionsDir(bucket, object), "", "", false, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	var versions []*filer_pb.Entry
+	for _, entry := range entries {
+		if entry.Name == getVersionId(entry) {
+			versions = append(versions, entry)
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		ti, tj := versionTimeNs(versions[i]), versionTimeNs(versions[j])
+		if ti != tj {
+			return ti > tj
+		}
+		return versions[i].Name < versions[j].Name
+	})
+	return versions, nil
+}
+
+// restoreLatestVersion makes the newest noncurrent version current, if the object has no current version
+func (s3a *S3ApiServer) restoreLatestVersion(ctx context.Context, bucket, object string) error {
+
+	dir, name := s3a.objectDirAndName(bucket, object)
+	if _, err := s3a.lookupEntry(ctx, dir, name); err == nil {
+		return nil
+	}
+
+	versions, err := s3a.listNoncurrentVersions(ctx, bucket, object)
+	if err != nil || len(versions) == 0 || isDeleteMarker(versions[0]) {
+		return nil
+	}
+
+	return s3a.mv(ctx, s3a.versionsDir(bucket, object), versions[0].Name, dir, name)
+}
+
+// lookupVersion finds the filer path of the object version
+func (s3a *S3ApiServer) lookupVersion(ctx context.Context, bucket, object, versionId string) (dir, name string, entry *filer_pb.Entry, code ErrorCode) {
+
+	dir, name = s3a.objectDirAndName(bucket, object)
+	if current, err := s3a.lookupEntry(ctx, dir, name); err == nil && !current.IsDirectory && getVersionId(current) == versionId {
+		return dir, name, current, ErrNone
+	}
+
+	dir = s3a.versionsDir(bucket, object)
+	entry, err := s3a.lookupEntry(ctx, dir, versionId)
+	if err != nil {
+		return "", "", nil, ErrNoSuchVersion
+	}
+	return dir, versionId, entry, ErrNone
+}
+
+// proxyObjectVersionToFiler serves GET and HEAD of one object version
+func (s3a *S3ApiServer) proxyObjectVersionToFiler(w http.ResponseWriter, r *http.Request, bucket, object, versionId string) {
+
+	dir, name, entry, errCode := s3a.lookupVersion(requestContext(r), bucket, object, versionId)
+	if errCode != ErrNone {
+		writeErrorResponse(w, errCode, r.URL)
+		return
+	}
+
+	w.Header().Set("x-amz-version-id", versionId)
+	if isDeleteMarker(entry) {
+		w.Header().Set("x-amz-delete-marker", "true")
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	destUrl := fmt.Sprintf("http://%s%s/%s", s3a.option.Filer, dir, url.PathEscape(name))
+	if dir == s3a.versionsDir(bucket, object) {
+		destUrl = s3a.versionUrl(bucket, object, name)
+	}
+
+	s3a.proxyToFiler(w, r, destUrl, passThroughResponse)
+}
+
+// deleteObjectVersion permanently deletes one object version
+func (s3a *S3ApiServer) deleteObjectVersion(ctx context.Context, w http.ResponseWriter, bucket, object, versionId string) ErrorCode {
+
+	dir, name, entry, errCode := s3a.lookupVersion(ctx, bucket, object, versionId)
+	if errCode != ErrNone {
+		return errCode
+	}
+
+	if err := s3a.rm(ctx, dir, name, false, true, false); err != nil {
+		glog.Errorf("delete %s version %s: %v", object, versionId, err)
+		return ErrInternalError
+	}
+
+	if err := s3a.restoreLatestVersion(ctx, bucket, object); err != nil {
+		glog.Errorf("restore %s latest version: %v", object, err)
+		return ErrInternalError
+	}
+
+	w.Header().Set("x-amz-version-id", versionId)
+	if isDeleteMarker(entry) {
+		w.Header().Set("x-amz-delete-marker", "true")
+	}
+
+	return ErrNone
+}
+
+// createDeleteMarker keeps the current version as a noncurrent version, and adds a delete marker as the latest version
+func (s3a *S3ApiServer) createDeleteMarker(ctx context.Context, w http.ResponseWriter, bucket, object string, versioning VersioningStatus) ErrorCode {
+
+	versionId, err := s3a.addDeleteMarker(ctx, bucket, object, versioning)
+	if err != nil {
+		glog.Errorf("delete %s: %v", object, err)
+		return ErrInternalError
+	}
+
+	w.Header().Set("x-amz-version-id", versionId)
+	w.Header().Set("x-amz-delete-marker", "true")
+
+	return ErrNone
+}
+
+func (s3a *S3ApiServer) addDeleteMarker(ctx context.Context, bucket, object string, versioning VersioningStatus) (versionId string, err error) {
+
+	versionId, stagingName := newVersionNames(versioning)
+
+	err = s3a.mkFile(ctx, s3a.versionsDir(bucket, object), stagingName, nil, func(entry *filer_pb.Entry) {
+		entry.Extended = versionExtended(versionId)
+		entry.Extended[s3DeleteMarkerKey] = []byte("true")
+	})
+	if err != nil {
+		return "", fmt.Errorf("create delete marker: %v", err)
+	}
+
+	if err = s3a.rotateNewVersion(ctx, bucket, object, versionId, stagingName, versioning, true); err != nil {
+		s3a.removeNewVersion(ctx, bucket, object, stagingName, false)
+		return "", err
+	}
+
+	return versionId, nil
+}
+
+// PutBucketVersioningHandler enables or suspends the versioning of the bucket
+func (s3a *S3ApiServer) PutBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	var config VersioningConfigurationResult
+	if err = xml.Unmarshal(body, &config); err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+	if config.Status != VersioningEnabled && config.Status != VersioningSuspended {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	ctx := requestContext(r)
+
+	bucketEntry, err := s3a.lookupEntry(ctx, s3a.option.BucketsPath, bucket)
+	if err != nil {
+		writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+		return
+	}
+	if bucketEntry.Extended == nil {
+		bucketEntry.Extended = make(map[string][]byte)
+	}
+	bucketEntry.Extended[s3VersioningKey] = []byte(config.Status)
+
+	if err = s3a.updateEntry(ctx, s3a.option.BucketsPath, bucketEntry); err != nil {
+		glog.Errorf("set bucket %s versioning: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseEmpty(w)
+}
+
+// GetBucketVersioningHandler returns the versioning state of the bucket
+func (s3a *S3ApiServer) GetBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	bucketEntry, err := s3a.lookupEntry(requestContext(r), s3a.option.BucketsPath, bucket)
+	if err != nil {
+		writeErrorResponse(w, ErrNoSuchBucket, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(VersioningConfigurationResult{
+		Xmlns:  "http://s3.amazonaws.com/doc/2006-03-01/",
+		Status: VersioningStatus(bucketEntry.Extended[s3VersioningKey]),
+	}))
+}
+
+// ListObjectVersionsHandler lists the versions and the delete markers of the objects
+func (s3a *S3ApiServer) ListObjectVersionsHandler(w http.ResponseWriter, r *http.Request) {
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	values := r.URL.Query()
+	originalPrefix, keyMarker, versionIdMarker, delimiter := values.Get("prefix"), values.Get("key-marker"), values.Get("version-id-marker"), values.Get("delimiter")
+	maxKeys := maxObjectListSizeLimit
+	if values.Get("max-keys") != "" {
+		maxKeys, _ = strconv.Atoi(values.Get("max-keys"))
+	}
+
+	if maxKeys < 0 {
+		writeErrorResponse(w, ErrInvalidMaxKeys, r.URL)
+		return
+	}
+	if delimiter != "" && delimiter != "/" {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	response, err := s3a.listObjectVersions(requestContext(r), bucket, originalPrefix, keyMarker, versionIdMarker, maxKeys)
+	if err != nil {
+		glog.Errorf("list %s versions: %v", bucket, err)
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+func (s3a *S3ApiServer) listObjectVersions(ctx context.Context, bucket, originalPrefix, keyMarker, versionIdMarker string, maxKeys int) (response ListBucketVersionsResult, err error) {
+
+	// convert full path prefix into directory name and prefix for entry name
+	dir, prefix := filepath.Split(originalPrefix)
+	inclusive := versionIdMarker != ""
+
+	response = ListBucketVersionsResult{
+		Name:            bucket,
+		Prefix:          originalPrefix,
+		KeyMarker:       keyMarker,
+		VersionIdMarker: versionIdMarker,
+		MaxKeys:         maxKeys,
+		Delimiter:       "/",
+	}
+
+	// the objects with the current version, after the key marker.
+	// The key marker of a common prefix skips the directory.
+	isPrefixMarker := strings.HasSuffix(keyMarker, "/")
+	startFrom := ""
+	if strings.HasPrefix(keyMarker, dir) {
+		startFrom = strings.TrimSuffix(keyMarker[len(dir):], "/")
+	}
+	currentEntries, err := s3a.list(ctx, fmt.Sprintf("%s/%s", s3a.bucketDir(bucket), dir), prefix, startFrom, inclusive, maxKeys+1)
+	if err != nil {
+		return response, err
+	}
+
+	// the objects with noncurrent versions, after the key marker
+	versionedStartFrom := url.QueryEscape(keyMarker)
+	if isPrefixMarker {
+		versionedStartFrom = prefixEnd(versionedStartFrom)
+	}
+	versionedEntries, err := s3a.list(ctx, fmt.Sprintf("%s/%s", s3a.bucketDir(bucket), versionsFolder),
+		url.QueryEscape(originalPrefix), versionedStartFrom, inclusive, maxKeys+1)
+	if err != nil {
+		versionedEntries = nil
+	}
+
+	// the keys and the common prefixes, up to where both listings are complete
+	var lastItem string
+	if len(currentEntries) > maxKeys {
+		lastEntry := currentEntries[len(currentEntries)-1]
+		lastItem = dir + lastEntry.Name
+		if lastEntry.IsDirectory {
+			lastItem += "/"
+		}
+	}
+	if len(versionedEntries) > maxKeys {
+		if key, unescapeErr := url.QueryUnescape(versionedEntries[len(versionedEntries)-1].Name); unescapeErr == nil && strings.HasPrefix(key, dir) {
+			if item := versionListItem(dir, key); lastItem == "" || versionListOrder(item) < versionListOrder(lastItem) {
+				lastItem = item
+			}
+		}
+	}
+
+	items := make(map[string]bool)
+	for _, entry := range currentEntries {
+		if dir == "" && (entry.Name == versionsFolder || entry.Name == uploadsFolder) {
+			continue
+		}
+		if entry.IsDirectory {
+			items[dir+entry.Name+"/"] = true
+		} else {
+			items[dir+entry.Name] = true
+		}
+	}
+	for _, entry := range versionedEntries {
+		key, unescapeErr := url.QueryUnescape(entry.Name)
+		if unescapeErr != nil || !strings.HasPrefix(key, dir) {
+			continue
+		}
+		items[versionListItem(dir, key)] = true
+	}
+
+	var sortedItems []string
+	for item := range items {
+		if lastItem != "" && versionListOrder(item) > versionListOrder(lastItem) {
+			continue
+		}
+		if keyMarker != "" {
+			if isPrefixMarker && strings.HasPrefix(item, keyMarker) {
+				continue
+			}
+			if (item == keyMarker && versionIdMarker == "") || versionListOrder(item) < versionListOrder(keyMarker) {
+				continue
+			}
+		}
+		sortedItems = append(sortedItems, item)
+	}
+	sort.Slice(sortedItems, func(i, j int) bool {
+		return versionListOrder(sortedItems[i]) < versionListOrder(sortedItems[j])
+	})
+
+	// each common prefix and each version counts as one key
+	counter := 0
+	for _, item := range sortedItems {
+
+		if strings.HasSuffix(item, "/") {
+			if counter >= maxKeys {
+				response.IsTruncated = true
+				return response, nil
+			}
+			counter++
+			response.CommonPrefixes = append(response.CommonPrefixes, PrefixEntry{Prefix: item})
+			response.NextKeyMarker, response.NextVersionIdMarker = item, ""
+			continue
+		}
+
+		key := item
+		var versions []*filer_pb.Entry
+		objectDir, objectName := s3a.objectDirAndName(bucket, "/"+key)
+		if current, lookupErr := s3a.lookupEntry(ctx, objectDir, objectName); lookupErr == nil && !current.IsDirectory {
+			versions = append(versions, current)
+		}
+		noncurrentVersions, listErr := s3a.listNoncurrentVersions(ctx, bucket, "/"+key)
+		if listErr == nil {
+			versions = append(versions, noncurrentVersions...)
+		}
+
+		skipping := key == keyMarker && versionIdMarker != ""
+		for i, version := range versions {
+			versionId := getVersionId(version)
+			if skipping {
+				if versionId == versionIdMarker {
+					skipping = false
+				}
+				continue
+			}
+			if counter >= maxKeys {
+				response.IsTruncated = true
+				return response, nil
+			}
+			counter++
+			response.NextKeyMarker, response.NextVersionIdMarker = key, versionId
+
+			owner := CanonicalUser{
+				ID:          fmt.Sprintf("%x", version.Attributes.Uid),
+				DisplayName: version.Attributes.UserName,
+			}
+			if isDeleteMarker(version) {
+				response.DeleteMarkers = append(response.DeleteMarkers, DeleteMarkerEntry{
+					Key:          key,
+					VersionId:    versionId,
+					IsLatest:     i == 0,
+					LastModified: time.Unix(version.Attributes.Mtime, 0),
+					Owner:        owner,
+				})
+			} else {
+				response.Versions = append(response.Versions, VersionEntry{
+					Key:          key,
+					VersionId:    versionId,
+					IsLatest:     i == 0,
+					LastModified: time.Unix(version.Attributes.Mtime, 0),
+					ETag:         "\"" + filer2.ETag(version.Chunks) + "\"",
+					Size:         int64(filer2.TotalSize(version.Chunks)),
+					Owner:        owner,
+					StorageClass: "STANDARD",
+				})
+			}
+		}
+	}
+
+	if lastItem != "" && counter > 0 {
+		response.IsTruncated = true
+	} else {
+		response.NextKeyMarker, response.NextVersionIdMarker = "", ""
+	}
+
+	return response, nil
+}
+
+// versionListItem is the key, or the common prefix of the key under the listed directory
+func versionListItem(dir, key string) string {
+	if slashIndex := strings.Index(key[len(dir):], "/"); slashIndex >= 0 {
+		return key[:len(dir)+slashIndex+1]
+	}
+	return key
+}
+
+// versionListOrder sorts the keys and the common prefixes in the order of the entry names in the filer,
+// e.g., the common prefix "a/" of the directory "a" is before the key "a.txt"
+func versionListOrder(item string) string {
+	if strings.HasSuffix(item, "/") {
+		return strings.TrimSuffix(item, "/") + "\x01"
+	}
+	return item + "\x00"
+}
+
+// prefixEnd is the smallest string after all strings with the prefix
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	return prefix
+}
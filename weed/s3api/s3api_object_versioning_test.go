@@ -0,0 +1,184 @@
+package s3api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+)
+
+func TestNewVersionIdOrder(t *testing.T) {
+
+	older := newVersionId()
+	time.Sleep(time.Millisecond)
+	newer := newVersionId()
+
+	if newer >= older {
+		t.Errorf("newer version id %s should sort before older version id %s", newer, older)
+	}
+
+}
+
+func TestVersioningConfiguration(t *testing.T) {
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`
+	encoded := string(encodeResponse(VersioningConfigurationResult{
+		Xmlns:  "http://s3.amazonaws.com/doc/2006-03-01/",
+		Status: VersioningEnabled,
+	}))
+	if encoded != expected {
+		t.Errorf("unexpected output: %s\nexpecting:%s", encoded, expected)
+	}
+
+	for _, input := range []string{
+		`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`,
+		`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Suspended</Status></VersioningConfiguration>`,
+	} {
+		var config VersioningConfigurationResult
+		if err := xml.Unmarshal([]byte(input), &config); err != nil {
+			t.Errorf("parse %s: %v", input, err)
+		}
+		if config.Status != VersioningSuspended {
+			t.Errorf("parse %s: unexpected status %s", input, config.Status)
+		}
+	}
+
+}
+
+func TestPutObjectVersionFailedUpload(t *testing.T) {
+
+	s := newTestS3ApiServer(t)
+	defer s.close()
+	ctx := context.Background()
+
+	// the null version is kept as a noncurrent version after the versioning is enabled
+	s.createBucket("bucket", VersioningSuspended)
+	if w := s.do("PUT", "/bucket/a/1", []byte("1")); w.Code != http.StatusOK {
+		t.Fatalf("put null version: %d %s", w.Code, w.Body.String())
+	}
+	s.setVersioning("bucket", VersioningEnabled)
+	w := s.do("PUT", "/bucket/a/1", []byte("22"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("put version: %d %s", w.Code, w.Body.String())
+	}
+	versionId := w.Header().Get("x-amz-version-id")
+
+	for _, versioning := range []VersioningStatus{VersioningEnabled, VersioningSuspended} {
+		s.setVersioning("bucket", versioning)
+		s.filer.failUpload = true
+		if w := s.do("PUT", "/bucket/a/1", []byte("333")); w.Code != http.StatusInternalServerError {
+			t.Fatalf("%s: failed upload: %d %s", versioning, w.Code, w.Body.String())
+		}
+		s.filer.failUpload = false
+
+		current := s.filer.find("/buckets/bucket/a/1")
+		if current == nil || getVersionId(current) != versionId || filer2.TotalSize(current.Chunks) != 2 {
+			t.Fatalf("%s: current version: %+v", versioning, current)
+		}
+		noncurrent, err := s.listNoncurrentVersions(ctx, "bucket", "/a/1")
+		if err != nil || len(noncurrent) != 1 || noncurrent[0].Name != nullVersionId || filer2.TotalSize(noncurrent[0].Chunks) != 1 {
+			t.Fatalf("%s: noncurrent versions: %+v %v", versioning, noncurrent, err)
+		}
+	}
+
+	// the new null version replaces the noncurrent null version
+	if w := s.do("PUT", "/bucket/a/1", []byte("4444")); w.Code != http.StatusOK {
+		t.Fatalf("put null version: %d %s", w.Code, w.Body.String())
+	}
+	current := s.filer.find("/buckets/bucket/a/1")
+	if current == nil || getVersionId(current) != nullVersionId || filer2.TotalSize(current.Chunks) != 4 {
+		t.Fatalf("current null version: %+v", current)
+	}
+	noncurrent, err := s.listNoncurrentVersions(ctx, "bucket", "/a/1")
+	if err != nil || len(noncurrent) != 1 || noncurrent[0].Name != versionId {
+		t.Fatalf("noncurrent versions: %+v %v", noncurrent, err)
+	}
+	if names := s.filer.children(s.versionsDir("bucket", "/a/1")); len(names) != 1 {
+		t.Errorf("versions folder: %v", names)
+	}
+
+}
+
+func TestNoncurrentVersionsInSameSecond(t *testing.T) {
+
+	s := newTestS3ApiServer(t)
+	defer s.close()
+
+	// the versions are created in the same second, the null version in the middle
+	s.createBucket("bucket", VersioningEnabled)
+	var versionIds []string
+	for i, versioning := range []VersioningStatus{VersioningEnabled, VersioningSuspended, VersioningEnabled, VersioningEnabled} {
+		s.setVersioning("bucket", versioning)
+		w := s.do("PUT", "/bucket/k", make([]byte, i+1))
+		if w.Code != http.StatusOK {
+			t.Fatalf("put: %d %s", w.Code, w.Body.String())
+		}
+		versionIds = append([]string{w.Header().Get("x-amz-version-id")}, versionIds...)
+	}
+
+	response, err := s.listObjectVersions(context.Background(), "bucket", "", "", "", 100)
+	if err != nil || len(response.Versions) != len(versionIds) {
+		t.Fatalf("list versions: %+v %v", response, err)
+	}
+	for i, version := range response.Versions {
+		if version.VersionId != versionIds[i] || version.IsLatest != (i == 0) {
+			t.Errorf("version %d: %+v, expected %s", i, version, versionIds[i])
+		}
+	}
+
+}
+
+func TestListObjectVersionsPagination(t *testing.T) {
+
+	s := newTestS3ApiServer(t)
+	defer s.close()
+
+	s.createBucket("bucket", VersioningEnabled)
+	for _, object := range []string{"a/1", "a.txt", "b/1", "b/2", "c/1", "d", "d"} {
+		if w := s.do("PUT", "/bucket/"+object, []byte(object)); w.Code != http.StatusOK {
+			t.Fatalf("put %s: %d %s", object, w.Code, w.Body.String())
+		}
+	}
+	// the deleted object only has noncurrent versions
+	if w := s.do("DELETE", "/bucket/e/1", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("delete: %d %s", w.Code, w.Body.String())
+	}
+
+	expected := []string{"a.txt", "a/", "b/", "c/", "d", "d", "e/"}
+
+	for maxKeys := 1; maxKeys <= len(expected)+1; maxKeys++ {
+		var listed []string
+		keyMarker, versionIdMarker := "", ""
+		for page := 0; ; page++ {
+			response, err := s.listObjectVersions(context.Background(), "bucket", "", keyMarker, versionIdMarker, maxKeys)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			items := len(response.CommonPrefixes) + len(response.Versions) + len(response.DeleteMarkers)
+			if items > maxKeys || page > len(expected) {
+				t.Fatalf("max keys %d: page %d has %d items", maxKeys, page, items)
+			}
+			for _, prefix := range response.CommonPrefixes {
+				listed = append(listed, prefix.Prefix)
+			}
+			for _, version := range response.Versions {
+				listed = append(listed, version.Key)
+			}
+			if !response.IsTruncated {
+				break
+			}
+			keyMarker, versionIdMarker = response.NextKeyMarker, response.NextVersionIdMarker
+		}
+		sort.Strings(listed)
+		if strings.Join(listed, ",") != strings.Join(expected, ",") {
+			t.Errorf("max keys %d: listed %v", maxKeys, listed)
+		}
+	}
+
+}
@@ -44,7 +44,7 @@ func (s3a *S3ApiServer) ListObjectsV2Handler(w http.ResponseWriter, r *http.Requ
 		marker = startAfter
 	}
 
-	ctx := context.Background()
+	ctx := requestContext(r)
 
 	response, err := s3a.listFilerEntries(ctx, bucket, originalPrefix, maxKeys, marker)
 
@@ -64,7 +64,7 @@ func (s3a *S3ApiServer) ListObjectsV1Handler(w http.ResponseWriter, r *http.Requ
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 
-	ctx := context.Background()
+	ctx := requestContext(r)
 
 	originalPrefix, marker, delimiter, maxKeys := getListObjectsV1Args(r.URL.Query())
 
@@ -120,6 +120,10 @@ func (s3a *S3ApiServer) listFilerEntries(ctx context.Context, bucket, originalPr
 				break
 			}
 			lastEntryName = entry.Name
+			if dir == "" && (entry.Name == versionsFolder || entry.Name == uploadsFolder) {
+				// hidden folders for the object versions and the multipart uploads
+				continue
+			}
 			if entry.IsDirectory {
 				commonPrefixes = append(commonPrefixes, PrefixEntry{
 					Prefix: fmt.Sprintf("%s%s/", dir, entry.Name),
@@ -87,32 +87,38 @@ func (g *Guard) checkWhiteList(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	host, err := GetActualRemoteHost(r)
-	if err == nil {
-		for _, ip := range g.whiteList {
-
-			// If the whitelist entry contains a "/" it
-			// is a CIDR range, and we should check the
-			// remote host is within it
-			if strings.Contains(ip, "/") {
-				_, cidrnet, err := net.ParseCIDR(ip)
-				if err != nil {
-					panic(err)
-				}
-				remote := net.ParseIP(host)
-				if cidrnet.Contains(remote) {
-					return nil
-				}
-			}
-
-			//
-			// Otherwise we're looking for a literal match.
-			//
-			if ip == host {
-				return nil
-			}
-		}
+	if err == nil && IsWhiteListed(g.whiteList, host) {
+		return nil
 	}
 
 	glog.V(0).Infof("Not in whitelist: %s", r.RemoteAddr)
 	return fmt.Errorf("Not in whitelis: %s", r.RemoteAddr)
 }
+
+// IsWhiteListed checks the host against the ip addresses and the CIDR ranges in the white list
+func IsWhiteListed(whiteList []string, host string) bool {
+	for _, ip := range whiteList {
+
+		// If the whitelist entry contains a "/" it
+		// is a CIDR range, and we should check the
+		// remote host is within it
+		if strings.Contains(ip, "/") {
+			_, cidrnet, err := net.ParseCIDR(ip)
+			if err != nil {
+				panic(err)
+			}
+			remote := net.ParseIP(host)
+			if cidrnet.Contains(remote) {
+				return true
+			}
+		}
+
+		//
+		// Otherwise we're looking for a literal match.
+		//
+		if ip == host {
+			return true
+		}
+	}
+	return false
+}
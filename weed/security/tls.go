@@ -13,6 +13,15 @@ import (
 )
 
 func LoadServerTLS(config *viper.Viper, component string) grpc.ServerOption {
+	tlsConfig := LoadServerTLSConfig(config, component)
+	if tlsConfig == nil {
+		return nil
+	}
+	return grpc.Creds(credentials.NewTLS(tlsConfig))
+}
+
+// LoadServerTLSConfig loads the server side TLS config requiring the client certificates, or nil if not configured
+func LoadServerTLSConfig(config *viper.Viper, component string) *tls.Config {
 	if config == nil {
 		return nil
 	}
@@ -30,38 +39,44 @@ func LoadServerTLS(config *viper.Viper, component string) grpc.ServerOption {
 	}
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM(caCert)
-	ta := credentials.NewTLS(&tls.Config{
+	return &tls.Config{
 		Certificates: []tls.Certificate{cert},
 		ClientCAs:    caCertPool,
 		ClientAuth:   tls.RequireAndVerifyClientCert,
-	})
-
-	return grpc.Creds(ta)
+	}
 }
 
 func LoadClientTLS(config *viper.Viper, component string) grpc.DialOption {
-	if config == nil {
+	tlsConfig := LoadClientTLSConfig(config, component)
+	if tlsConfig == nil {
 		return grpc.WithInsecure()
 	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+}
+
+// LoadClientTLSConfig loads the client side TLS config, or nil if not configured
+func LoadClientTLSConfig(config *viper.Viper, component string) *tls.Config {
+	if config == nil {
+		return nil
+	}
 
 	// load cert/key, cacert
 	cert, err := tls.LoadX509KeyPair(config.GetString(component+".cert"), config.GetString(component+".key"))
 	if err != nil {
 		glog.Errorf("load cert/key error: %v", err)
-		return grpc.WithInsecure()
+		return nil
 	}
 	caCert, err := ioutil.ReadFile(config.GetString("ca"))
 	if err != nil {
 		glog.Errorf("read ca cert file error: %v", err)
-		return grpc.WithInsecure()
+		return nil
 	}
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM(caCert)
 
-	ta := credentials.NewTLS(&tls.Config{
+	return &tls.Config{
 		Certificates:       []tls.Certificate{cert},
 		RootCAs:            caCertPool,
 		InsecureSkipVerify: true,
-	})
-	return grpc.WithTransportCredentials(ta)
+	}
 }
@@ -0,0 +1,141 @@
+package sequence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+)
+
+/*
+
+EtcdSequencer reserves the ranges of file ids in etcd, and hands out the ids in the reserved range.
+
+The etcd key keeps the end of the last reserved range. A range is reserved by advancing the key
+in an etcd txn, so the masters sharing the key never hand out the same id,
+and the ids after a restart are larger than the ids handed out before.
+
+*/
+
+const (
+	DefaultEtcdSequenceKey = "/seaweedfs/master/sequence"
+
+	// the number of ids reserved each time beyond the requested count
+	etcdSequenceSteps = 1000
+	etcdTimeout       = 10 * time.Second
+)
+
+type EtcdSequencer struct {
+	client       *clientv3.Client
+	key          string
+	currentSeq   uint64 // the next id to hand out
+	maxSeq       uint64 // the end of the reserved range, exclusive
+	sequenceLock sync.Mutex
+}
+
+func NewEtcdSequencer(endpoints []string, key string) (*EtcdSequencer, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: etcdTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect to etcd %v: %v", endpoints, err)
+	}
+	m := &EtcdSequencer{client: client, key: key}
+
+	value, _, err := m.get()
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	if value == 0 {
+		value = 1
+	}
+	m.currentSeq, m.maxSeq = value, value
+	return m, nil
+}
+
+func (m *EtcdSequencer) NextFileId(count uint64) (uint64, uint64) {
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+	if m.currentSeq+count > m.maxSeq {
+		if err := m.reserve(count); err != nil {
+			glog.Errorf("reserve %d file ids in etcd: %v", count, err)
+			return 0, 0
+		}
+	}
+	ret := m.currentSeq
+	m.currentSeq += count
+	return ret, count
+}
+
+// SetMax skips the ids up to the seen value, which are reserved again if not reserved yet
+func (m *EtcdSequencer) SetMax(seenValue uint64) {
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+	if m.currentSeq <= seenValue {
+		m.currentSeq = seenValue + 1
+	}
+}
+
+func (m *EtcdSequencer) Peek() uint64 {
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+	return m.currentSeq
+}
+
+func (m *EtcdSequencer) Close() error {
+	return m.client.Close()
+}
+
+// reserve advances the etcd key so that at least count ids are reserved after the current id
+func (m *EtcdSequencer) reserve(count uint64) error {
+	for {
+		value, revision, err := m.get()
+		if err != nil {
+			return err
+		}
+		start := m.currentSeq
+		if value > m.maxSeq && value > start {
+			// the ids up to the value are reserved by the others
+			start = value
+		}
+		end := start + count + etcdSequenceSteps
+
+		// a key not existing has the mod revision 0
+		ctx, cancel := context.WithTimeout(context.Background(), etcdTimeout)
+		resp, err := m.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(m.key), "=", revision)).
+			Then(clientv3.OpPut(m.key, strconv.FormatUint(end, 10))).
+			Commit()
+		cancel()
+		if err != nil {
+			return fmt.Errorf("put %s: %v", m.key, err)
+		}
+		if resp.Succeeded {
+			m.currentSeq, m.maxSeq = start, end
+			return nil
+		}
+		// the key is changed by the others, try again
+	}
+}
+
+func (m *EtcdSequencer) get() (value uint64, revision int64, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdTimeout)
+	defer cancel()
+	resp, err := m.client.Get(ctx, m.key)
+	if err != nil {
+		return 0, 0, fmt.Errorf("get %s: %v", m.key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, 0, nil
+	}
+	if value, err = strconv.ParseUint(string(resp.Kvs[0].Value), 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("parse %s value %s: %v", m.key, resp.Kvs[0].Value, err)
+	}
+	return value, resp.Kvs[0].ModRevision, nil
+}
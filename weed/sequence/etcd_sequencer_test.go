@@ -0,0 +1,105 @@
+package sequence
+
+import (
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/embed"
+)
+
+func startEmbeddedEtcd(t *testing.T, dir string) (*embed.Etcd, string) {
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	clientUrl, peerUrl := freeUrl(t), freeUrl(t)
+	cfg.LCUrls, cfg.ACUrls = []url.URL{clientUrl}, []url.URL{clientUrl}
+	cfg.LPUrls, cfg.APUrls = []url.URL{peerUrl}, []url.URL{peerUrl}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	server, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatalf("start etcd: %v", err)
+	}
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(time.Minute):
+		server.Close()
+		t.Fatalf("etcd is not ready")
+	}
+	return server, clientUrl.Host
+}
+
+func freeUrl(t *testing.T) url.URL {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	return url.URL{Scheme: "http", Host: listener.Addr().String()}
+}
+
+func TestEtcdSequencer(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_sequence_test")
+	defer os.RemoveAll(dir)
+	server, address := startEmbeddedEtcd(t, dir)
+	defer server.Close()
+
+	m, err := NewEtcdSequencer([]string{address}, DefaultEtcdSequenceKey)
+	if err != nil {
+		t.Fatalf("new etcd sequencer: %v", err)
+	}
+	last, count := m.NextFileId(1)
+	if last != 1 || count != 1 {
+		t.Fatalf("first id %d count %d", last, count)
+	}
+	for i := 0; i < 10; i++ {
+		id, count := m.NextFileId(300)
+		if id != last+1 || count != 300 {
+			t.Fatalf("id %d count %d after %d", id, count, last)
+		}
+		last = id + count - 1
+	}
+
+	// another master sharing the key does not reuse the reserved ids
+	other, err := NewEtcdSequencer([]string{address}, DefaultEtcdSequenceKey)
+	if err != nil {
+		t.Fatalf("new other etcd sequencer: %v", err)
+	}
+	otherId, _ := other.NextFileId(10)
+	if otherId <= last {
+		t.Fatalf("other id %d is reserved before %d", otherId, last)
+	}
+	otherLast := otherId + 9
+	id, _ := m.NextFileId(5000)
+	if id <= otherLast {
+		t.Fatalf("id %d is reserved by the other before %d", id, otherLast)
+	}
+	last = id + 4999
+	other.Close()
+
+	// restart
+	m.Close()
+	m, err = NewEtcdSequencer([]string{address}, DefaultEtcdSequenceKey)
+	if err != nil {
+		t.Fatalf("restart etcd sequencer: %v", err)
+	}
+	if id, _ := m.NextFileId(1); id <= last {
+		t.Fatalf("id %d after restart, not after %d", id, last)
+	}
+
+	// the ids seen from the heartbeats are skipped
+	seen := m.Peek() + 100000
+	m.SetMax(seen)
+	if id, _ := m.NextFileId(1); id <= seen {
+		t.Fatalf("id %d after set max %d", id, seen)
+	}
+	m.Close()
+	m, _ = NewEtcdSequencer([]string{address}, DefaultEtcdSequenceKey)
+	defer m.Close()
+	if id, _ := m.NextFileId(1); id <= seen {
+		t.Fatalf("id %d after restart, not after set max %d", id, seen)
+	}
+}
@@ -0,0 +1,100 @@
+package sequence
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*
+
+SnowflakeSequencer generates the file ids from the time and the node id of the master,
+so the masters do not need to coordinate with each other.
+
+An id has 41 bits of milliseconds since the snowflake epoch, 10 bits of the node id,
+and 12 bits of the sequence in the millisecond.
+
+The milliseconds start from the clock when the first id is generated, and only move to the next millisecond
+when the sequence is used up, instead of following the clock. So the ids of a master stay dense,
+and the needle ids of a volume fit in a few sections of the compact needle map.
+Generating less than 4096 ids per millisecond on average, the milliseconds stay behind the clock,
+so a restarted master starting from the clock does not reuse the ids.
+
+*/
+
+const (
+	snowflakeEpoch        = uint64(1577836800000) // 2020-01-01T00:00:00Z in milliseconds
+	snowflakeNodeIdBits   = 10
+	snowflakeSequenceBits = 12
+
+	SnowflakeMaxNodeId    = 1<<snowflakeNodeIdBits - 1
+	snowflakeSequenceMask = 1<<snowflakeSequenceBits - 1
+	snowflakeTimeShift    = snowflakeNodeIdBits + snowflakeSequenceBits
+)
+
+type SnowflakeSequencer struct {
+	nodeId        uint64
+	lastTimestamp uint64 // milliseconds since the snowflake epoch
+	sequence      uint64 // the next sequence in the last timestamp
+	started       bool
+	sequenceLock  sync.Mutex
+
+	now func() time.Time
+}
+
+func NewSnowflakeSequencer(nodeId int) (*SnowflakeSequencer, error) {
+	if nodeId < 0 || nodeId > SnowflakeMaxNodeId {
+		return nil, fmt.Errorf("snowflake node id %d is not in [0, %d]", nodeId, SnowflakeMaxNodeId)
+	}
+	return &SnowflakeSequencer{nodeId: uint64(nodeId), now: time.Now}, nil
+}
+
+// NextFileId returns count ids in one millisecond, or less if the rest of the millisecond is not enough
+func (m *SnowflakeSequencer) NextFileId(count uint64) (uint64, uint64) {
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+
+	// start from the clock, unless the ids of the future milliseconds are already seen
+	if !m.started {
+		if timestamp := m.timestamp(); timestamp > m.lastTimestamp {
+			m.lastTimestamp, m.sequence = timestamp, 0
+		}
+		m.started = true
+	}
+	if m.sequence > snowflakeSequenceMask {
+		m.lastTimestamp, m.sequence = m.lastTimestamp+1, 0
+	}
+	if rest := snowflakeSequenceMask + 1 - m.sequence; count > rest {
+		count = rest
+	}
+	ret := m.id(m.lastTimestamp, m.sequence)
+	m.sequence += count
+	return ret, count
+}
+
+// SetMax makes sure the next ids are larger than the seen value
+func (m *SnowflakeSequencer) SetMax(seenValue uint64) {
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+	if seenValue >= m.id(m.lastTimestamp, m.sequence) {
+		m.lastTimestamp = seenValue >> snowflakeTimeShift
+		m.sequence = snowflakeSequenceMask + 1
+	}
+}
+
+func (m *SnowflakeSequencer) Peek() uint64 {
+	m.sequenceLock.Lock()
+	defer m.sequenceLock.Unlock()
+	return m.id(m.lastTimestamp, m.sequence)
+}
+
+func (m *SnowflakeSequencer) timestamp() uint64 {
+	return uint64(m.now().UnixNano()/int64(time.Millisecond)) - snowflakeEpoch
+}
+
+func (m *SnowflakeSequencer) id(timestamp, sequence uint64) uint64 {
+	if sequence > snowflakeSequenceMask {
+		timestamp, sequence = timestamp+1, 0
+	}
+	return timestamp<<snowflakeTimeShift | m.nodeId<<snowflakeSequenceBits | sequence
+}
@@ -0,0 +1,83 @@
+package sequence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnowflakeSequencer(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	m, _ := NewSnowflakeSequencer(3)
+	m.now = clock
+
+	last, count := m.NextFileId(1)
+	if count != 1 {
+		t.Fatalf("count %d", count)
+	}
+	if nodeId := last >> snowflakeSequenceBits & SnowflakeMaxNodeId; nodeId != 3 {
+		t.Fatalf("node id %d", nodeId)
+	}
+	for i := 0; i < 10; i++ {
+		id, count := m.NextFileId(1000)
+		if id <= last {
+			t.Fatalf("id %d after %d", id, last)
+		}
+		last = id + count - 1
+	}
+
+	// the clock moves backwards
+	now = now.Add(-time.Second)
+	id, _ := m.NextFileId(1)
+	if id <= last {
+		t.Fatalf("id %d after %d when the clock moves backwards", id, last)
+	}
+	last = id
+
+	// the ids stay dense when the clock moves forward
+	now = now.Add(2 * time.Second)
+	if id, _ = m.NextFileId(1); id != last+1 {
+		t.Fatalf("id %d two seconds later, not next to %d", id, last)
+	}
+	last = id
+
+	// another sequencer of the same node id, restarted later
+	now = now.Add(2 * time.Second)
+	m, _ = NewSnowflakeSequencer(3)
+	m.now = clock
+	if id, _ := m.NextFileId(1); id <= last {
+		t.Fatalf("id %d after restart, not after %d", id, last)
+	}
+
+	// the ids seen from the heartbeats are skipped
+	seen := m.Peek() + 100000
+	m.SetMax(seen)
+	if id, _ := m.NextFileId(1); id <= seen {
+		t.Fatalf("id %d after set max %d", id, seen)
+	}
+
+	if _, err := NewSnowflakeSequencer(SnowflakeMaxNodeId + 1); err == nil {
+		t.Fatalf("node id %d should be invalid", SnowflakeMaxNodeId+1)
+	}
+}
+
+func TestSnowflakeSequencerCount(t *testing.T) {
+	m, _ := NewSnowflakeSequencer(0)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	id, count := m.NextFileId(4000)
+	if count != 4000 {
+		t.Fatalf("count %d", count)
+	}
+	// the ids of one request are in one millisecond
+	next, count := m.NextFileId(200)
+	if count != 96 || next != id+4000 {
+		t.Fatalf("id %d count %d after %d", next, count, id)
+	}
+	next, count = m.NextFileId(200)
+	if count != 200 || next>>snowflakeTimeShift != id>>snowflakeTimeShift+1 {
+		t.Fatalf("id %d count %d should be in the next millisecond of %d", next, count, id)
+	}
+}
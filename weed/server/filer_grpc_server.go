@@ -1,6 +1,7 @@
 package weed_server
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -29,6 +30,10 @@ func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.L
 			IsDirectory: entry.IsDirectory(),
 			Attributes:  filer2.EntryAttributeToPb(entry),
 			Chunks:      entry.Chunks,
+			Extended:    entry.Extended,
+
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
 		},
 	}, nil
 }
@@ -69,6 +74,10 @@ func (fs *FilerServer) ListEntries(ctx context.Context, req *filer_pb.ListEntrie
 				IsDirectory: entry.IsDirectory(),
 				Chunks:      entry.Chunks,
 				Attributes:  filer2.EntryAttributeToPb(entry),
+				Extended:    entry.Extended,
+
+				HardLinkId:      entry.HardLinkId,
+				HardLinkCounter: entry.HardLinkCounter,
 			})
 			limit--
 		}
@@ -97,8 +106,9 @@ func (fs *FilerServer) LookupVolume(ctx context.Context, req *filer_pb.LookupVol
 		var locs []*filer_pb.Location
 		for _, loc := range fs.filer.MasterClient.GetLocations(uint32(vid)) {
 			locs = append(locs, &filer_pb.Location{
-				Url:       loc.Url,
-				PublicUrl: loc.PublicUrl,
+				Url:        loc.Url,
+				PublicUrl:  loc.PublicUrl,
+				DataCenter: loc.DataCenter,
 			})
 		}
 		resp.LocationsMap[vidString] = &filer_pb.Locations{
@@ -120,10 +130,21 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 		return nil, fmt.Errorf("can not create entry with empty attributes")
 	}
 
+	// the hard links are created by LinkEntry, and only kept when overwriting the same link
+	var hardLinkId filer2.HardLinkId
+	if len(req.Entry.HardLinkId) > 0 {
+		if existing, findErr := fs.filer.FindEntry(ctx, fullpath); findErr == nil && bytes.Equal(existing.HardLinkId, req.Entry.HardLinkId) {
+			hardLinkId = existing.HardLinkId
+		}
+	}
+
 	err = fs.filer.CreateEntry(ctx, &filer2.Entry{
 		FullPath: fullpath,
 		Attr:     filer2.PbToEntryAttribute(req.Entry.Attributes),
 		Chunks:   chunks,
+		Extended: req.Entry.Extended,
+
+		HardLinkId: hardLinkId,
 	})
 
 	if err == nil {
@@ -149,6 +170,11 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		FullPath: filer2.FullPath(filepath.ToSlash(filepath.Join(req.Directory, req.Entry.Name))),
 		Attr:     entry.Attr,
 		Chunks:   chunks,
+		Extended: req.Entry.Extended,
+
+		// the hard link and its counter are owned by the filer
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 
 	glog.V(3).Infof("updating %s: %+v, chunks %d: %v => %+v, chunks %d: %v",
@@ -184,7 +210,32 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 	return &filer_pb.UpdateEntryResponse{}, err
 }
 
+func (fs *FilerServer) LinkEntry(ctx context.Context, req *filer_pb.LinkEntryRequest) (*filer_pb.LinkEntryResponse, error) {
+
+	oldPath := filer2.FullPath(filepath.ToSlash(filepath.Join(req.OldDirectory, req.OldName)))
+	newPath := filer2.FullPath(filepath.ToSlash(filepath.Join(req.NewDirectory, req.NewName)))
+
+	entry, err := fs.filer.LinkEntry(ctx, oldPath, newPath)
+	if err != nil {
+		return nil, fmt.Errorf("link %s to %s: %v", newPath, oldPath, err)
+	}
+
+	return &filer_pb.LinkEntryResponse{
+		Entry: &filer_pb.Entry{
+			Name:        req.NewName,
+			IsDirectory: entry.IsDirectory(),
+			Attributes:  filer2.EntryAttributeToPb(entry),
+			Chunks:      entry.Chunks,
+			Extended:    entry.Extended,
+
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
+		},
+	}, nil
+}
+
 func (fs *FilerServer) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntryRequest) (resp *filer_pb.DeleteEntryResponse, err error) {
+	ctx = filer2.WithOrigin(ctx, req.Origin)
 	err = fs.filer.DeleteEntryMetaAndData(ctx, filer2.FullPath(filepath.ToSlash(filepath.Join(req.Directory, req.Name))), req.IsRecursive, req.IsDeleteData)
 	return &filer_pb.DeleteEntryResponse{}, err
 }
@@ -203,12 +254,18 @@ func (fs *FilerServer) AssignVolume(ctx context.Context, req *filer_pb.AssignVol
 		dataCenter = fs.option.DataCenter
 	}
 
+	diskType := req.DiskType
+	if diskType == "" {
+		diskType = fs.option.DiskType
+	}
+
 	assignRequest := &operation.VolumeAssignRequest{
 		Count:       uint64(req.Count),
 		Replication: req.Replication,
 		Collection:  req.Collection,
 		Ttl:         ttlStr,
 		DataCenter:  dataCenter,
+		DiskType:    diskType,
 	}
 	if dataCenter != "" {
 		altRequest = &operation.VolumeAssignRequest{
@@ -217,6 +274,7 @@ func (fs *FilerServer) AssignVolume(ctx context.Context, req *filer_pb.AssignVol
 			Collection:  req.Collection,
 			Ttl:         ttlStr,
 			DataCenter:  "",
+			DiskType:    diskType,
 		}
 	}
 	assignResult, err := operation.Assign(fs.filer.GetMaster(), fs.grpcDialOption, assignRequest, altRequest)
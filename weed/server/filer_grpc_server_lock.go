@@ -0,0 +1,73 @@
+package weed_server
+
+import (
+	"context"
+	"path/filepath"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) Lock(ctx context.Context, req *filer_pb.LockRequest) (*filer_pb.LockResponse, error) {
+
+	if err := fs.lockManager.CheckServing(); err != nil {
+		return nil, err
+	}
+
+	fullpath := filer2.FullPath(filepath.ToSlash(filepath.Join(req.Directory, req.Name)))
+
+	conflict := fs.lockManager.Lock(fullpath, req.Lock)
+	if conflict != nil {
+		glog.V(3).Infof("lock %s %v: conflict with %v", fullpath, req.Lock, conflict)
+		return &filer_pb.LockResponse{Conflict: conflict}, nil
+	}
+
+	glog.V(3).Infof("lock %s %v", fullpath, req.Lock)
+	return &filer_pb.LockResponse{Granted: true}, nil
+}
+
+func (fs *FilerServer) Unlock(ctx context.Context, req *filer_pb.UnlockRequest) (*filer_pb.UnlockResponse, error) {
+
+	if err := fs.lockManager.CheckServing(); err != nil {
+		return nil, err
+	}
+
+	fullpath := filer2.FullPath(filepath.ToSlash(filepath.Join(req.Directory, req.Name)))
+
+	glog.V(3).Infof("unlock %s %v", fullpath, req.Lock)
+	fs.lockManager.Unlock(fullpath, req.Lock)
+
+	return &filer_pb.UnlockResponse{}, nil
+}
+
+func (fs *FilerServer) QueryLock(ctx context.Context, req *filer_pb.QueryLockRequest) (*filer_pb.QueryLockResponse, error) {
+
+	if err := fs.lockManager.CheckServing(); err != nil {
+		return nil, err
+	}
+
+	fullpath := filer2.FullPath(filepath.ToSlash(filepath.Join(req.Directory, req.Name)))
+
+	return &filer_pb.QueryLockResponse{
+		Conflict: fs.lockManager.QueryLock(fullpath, req.Lock),
+	}, nil
+}
+
+func (fs *FilerServer) KeepLocks(ctx context.Context, req *filer_pb.KeepLocksRequest) (*filer_pb.KeepLocksResponse, error) {
+
+	if err := fs.lockManager.CheckServing(); err != nil {
+		return nil, err
+	}
+
+	if req.ReleaseAll {
+		glog.V(0).Infof("release all locks of %s", req.Client)
+		fs.lockManager.ReleaseClient(req.Client)
+	} else {
+		fs.lockManager.KeepLocks(req.Client)
+	}
+
+	return &filer_pb.KeepLocksResponse{
+		LeaseSeconds: int64(fs.lockManager.LeaseDuration().Seconds()),
+	}, nil
+}
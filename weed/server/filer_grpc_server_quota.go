@@ -0,0 +1,49 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) SetQuota(ctx context.Context, req *filer_pb.SetQuotaRequest) (*filer_pb.SetQuotaResponse, error) {
+
+	dir := filer2.FullPath(filepath.ToSlash(filepath.Clean(req.Directory)))
+
+	if id := filer2.IdentityFromContext(ctx); id != nil && !id.IsRoot() {
+		return nil, fmt.Errorf("set quota of %s as %v: %v", dir, id, filer2.ErrPermissionDenied)
+	}
+
+	if req.Remove {
+		glog.V(0).Infof("remove quota of %s", dir)
+		return &filer_pb.SetQuotaResponse{}, fs.filer.RemoveQuota(ctx, dir)
+	}
+
+	glog.V(0).Infof("set quota of %s: max %d bytes, max %d files", dir, req.MaxBytes, req.MaxFiles)
+	quota, err := fs.filer.SetQuota(ctx, dir, req.MaxBytes, req.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.SetQuotaResponse{Quota: quota}, nil
+}
+
+func (fs *FilerServer) GetQuota(ctx context.Context, req *filer_pb.GetQuotaRequest) (*filer_pb.GetQuotaResponse, error) {
+
+	dir := filer2.FullPath(filepath.ToSlash(filepath.Clean(req.Directory)))
+
+	return &filer_pb.GetQuotaResponse{
+		Quota: fs.filer.GetQuota(dir),
+	}, nil
+}
+
+func (fs *FilerServer) ListQuotas(ctx context.Context, req *filer_pb.ListQuotasRequest) (*filer_pb.ListQuotasResponse, error) {
+
+	return &filer_pb.ListQuotasResponse{
+		Quotas: fs.filer.ListQuotas(),
+	}, nil
+}
@@ -10,53 +10,104 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 )
 
+// moveBatchSize bounds the entries moved in one transaction, so moving a large directory
+// does not exceed the transaction limits of the filer store, e.g., max_txn_ops of etcd
+const moveBatchSize = 16
+
+// AtomicRenameEntry renames a file in one transaction. A directory is moved in several transactions,
+// each moving a batch of the entries under it and the directory itself at last,
+// so a failed move may leave some entries moved to the new directory.
 func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
 
-	ctx, err := fs.filer.BeginTransaction(ctx)
-	if err != nil {
+	oldParent := filer2.FullPath(filepath.ToSlash(req.OldDirectory))
+	newParent := filer2.FullPath(filepath.ToSlash(req.NewDirectory))
+
+	mover := &entryMover{fs: fs}
+	if err := mover.begin(ctx); err != nil {
 		return nil, err
 	}
 
-	oldParent := filer2.FullPath(filepath.ToSlash(req.OldDirectory))
-
-	oldEntry, err := fs.filer.FindEntry(ctx, oldParent.Child(req.OldName))
+	oldEntry, err := fs.filer.FindEntry(mover.ctx, oldParent.Child(req.OldName))
 	if err != nil {
-		fs.filer.RollbackTransaction(ctx)
+		mover.rollback()
 		return nil, fmt.Errorf("%s/%s not found: %v", req.OldDirectory, req.OldName, err)
 	}
 
-	var events MoveEvents
-	moveErr := fs.moveEntry(ctx, oldParent, oldEntry, filer2.FullPath(filepath.ToSlash(req.NewDirectory)), req.NewName, &events)
-	if moveErr != nil {
-		fs.filer.RollbackTransaction(ctx)
-		return nil, fmt.Errorf("%s/%s move error: %v", req.OldDirectory, req.OldName, err)
-	} else {
-		if commitError := fs.filer.CommitTransaction(ctx); commitError != nil {
-			fs.filer.RollbackTransaction(ctx)
-			return nil, fmt.Errorf("%s/%s move commit error: %v", req.OldDirectory, req.OldName, err)
-		}
+	if err := fs.filer.CheckRename(mover.ctx, oldEntry, newParent.Child(req.NewName)); err != nil {
+		mover.rollback()
+		return nil, err
 	}
+	// the entries under the moved directory are moved as a whole
+	mover.base = filer2.WithIdentity(ctx, nil)
+	mover.ctx = filer2.WithIdentity(mover.ctx, nil)
 
-	for _, entry := range events.newEntries {
-		fs.filer.NotifyUpdateEvent(nil, entry, false)
+	if moveErr := mover.moveEntry(oldParent, oldEntry, newParent, req.NewName); moveErr != nil {
+		mover.rollback()
+		return nil, fmt.Errorf("%s/%s move error: %v", req.OldDirectory, req.OldName, moveErr)
 	}
-	for _, entry := range events.oldEntries {
-		fs.filer.NotifyUpdateEvent(entry, nil, false)
+	if commitErr := mover.commit(); commitErr != nil {
+		return nil, fmt.Errorf("%s/%s move commit error: %v", req.OldDirectory, req.OldName, commitErr)
 	}
 
 	return &filer_pb.AtomicRenameEntryResponse{}, nil
 }
 
-func (fs *FilerServer) moveEntry(ctx context.Context, oldParent filer2.FullPath, entry *filer2.Entry, newParent filer2.FullPath, newName string, events *MoveEvents) error {
+// entryMover moves the entries in transactions of at most moveBatchSize entries
+type entryMover struct {
+	fs     *FilerServer
+	base   context.Context
+	ctx    context.Context // the context of the current transaction
+	events MoveEvents
+}
+
+func (m *entryMover) begin(ctx context.Context) (err error) {
+	m.base = ctx
+	m.ctx, err = m.fs.filer.BeginTransaction(ctx)
+	return err
+}
+
+func (m *entryMover) rollback() {
+	m.fs.filer.RollbackTransaction(m.ctx)
+	m.events = MoveEvents{}
+}
+
+// commit commits the current transaction, and notifies the moved entries
+func (m *entryMover) commit() error {
+	if err := m.fs.filer.CommitTransaction(m.ctx); err != nil {
+		m.rollback()
+		return err
+	}
+	for _, entry := range m.events.newEntries {
+		m.fs.filer.NotifyUpdateEvent(nil, entry, false)
+	}
+	for _, entry := range m.events.oldEntries {
+		m.fs.filer.NotifyUpdateEvent(entry, nil, false)
+	}
+	m.events = MoveEvents{}
+	return nil
+}
+
+// moved starts a new transaction when the current one has moved enough entries
+func (m *entryMover) moved() error {
+	if len(m.events.newEntries) < moveBatchSize {
+		return nil
+	}
+	if err := m.commit(); err != nil {
+		return err
+	}
+	return m.begin(m.base)
+}
+
+func (m *entryMover) moveEntry(oldParent filer2.FullPath, entry *filer2.Entry, newParent filer2.FullPath, newName string) error {
 	if entry.IsDirectory() {
-		if err := fs.moveFolderSubEntries(ctx, oldParent, entry, newParent, newName, events); err != nil {
+		if err := m.moveFolderSubEntries(oldParent, entry, newParent, newName); err != nil {
 			return err
 		}
 	}
-	return fs.moveSelfEntry(ctx, oldParent, entry, newParent, newName, events)
+	return m.moveSelfEntry(oldParent, entry, newParent, newName)
 }
 
-func (fs *FilerServer) moveFolderSubEntries(ctx context.Context, oldParent filer2.FullPath, entry *filer2.Entry, newParent filer2.FullPath, newName string, events *MoveEvents) error {
+func (m *entryMover) moveFolderSubEntries(oldParent filer2.FullPath, entry *filer2.Entry, newParent filer2.FullPath, newName string) error {
 
 	currentDirPath := oldParent.Child(entry.Name())
 	newDirPath := newParent.Child(newName)
@@ -67,29 +118,27 @@ func (fs *FilerServer) moveFolderSubEntries(ctx context.Context, oldParent filer
 	includeLastFile := false
 	for {
 
-		entries, err := fs.filer.ListDirectoryEntries(ctx, currentDirPath, lastFileName, includeLastFile, 1024)
+		// list a batch at a time, the entries listed are also read in the transaction
+		entries, err := m.fs.filer.ListDirectoryEntries(m.ctx, currentDirPath, lastFileName, includeLastFile, moveBatchSize)
 		if err != nil {
 			return err
 		}
 
-		println("found", len(entries), "entries under", currentDirPath)
-
 		for _, item := range entries {
 			lastFileName = item.Name()
-			println("processing", lastFileName)
-			err := fs.moveEntry(ctx, currentDirPath, item, newDirPath, item.Name(), events)
+			err := m.moveEntry(currentDirPath, item, newDirPath, item.Name())
 			if err != nil {
 				return err
 			}
 		}
-		if len(entries) < 1024 {
+		if len(entries) < moveBatchSize {
 			break
 		}
 	}
 	return nil
 }
 
-func (fs *FilerServer) moveSelfEntry(ctx context.Context, oldParent filer2.FullPath, entry *filer2.Entry, newParent filer2.FullPath, newName string, events *MoveEvents) error {
+func (m *entryMover) moveSelfEntry(oldParent filer2.FullPath, entry *filer2.Entry, newParent filer2.FullPath, newName string) error {
 
 	oldPath, newPath := oldParent.Child(entry.Name()), newParent.Child(newName)
 
@@ -100,21 +149,35 @@ func (fs *FilerServer) moveSelfEntry(ctx context.Context, oldParent filer2.FullP
 		FullPath: newPath,
 		Attr:     entry.Attr,
 		Chunks:   entry.Chunks,
+		Extended: entry.Extended,
+
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
-	createErr := fs.filer.CreateEntry(ctx, newEntry)
+	createErr := m.fs.filer.CreateEntry(m.ctx, newEntry)
 	if createErr != nil {
 		return createErr
 	}
 
+	// the quota of the directory is removed with the old entry
+	quota := m.fs.filer.GetQuota(oldPath)
+
 	// delete old entry
-	deleteErr := fs.filer.DeleteEntryMetaAndData(ctx, oldPath, false, false)
+	deleteErr := m.fs.filer.DeleteEntryMetaAndData(m.ctx, oldPath, false, false)
 	if deleteErr != nil {
 		return deleteErr
 	}
 
-	events.oldEntries = append(events.oldEntries, entry)
-	events.newEntries = append(events.newEntries, newEntry)
-	return nil
+	// move the quota to the new directory
+	if quota != nil && quota.Directory == string(oldPath) {
+		if _, err := m.fs.filer.SetQuota(m.ctx, newPath, quota.MaxBytes, quota.MaxFiles); err != nil {
+			glog.Errorf("move quota %s => %s: %v", oldPath, newPath, err)
+		}
+	}
+
+	m.events.oldEntries = append(m.events.oldEntries, entry)
+	m.events.newEntries = append(m.events.newEntries, newEntry)
+	return m.moved()
 
 }
 
@@ -0,0 +1,105 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/embed"
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/etcd"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+// startEmbeddedEtcd starts etcd with the default --max-txn-ops 128
+func startEmbeddedEtcd(t *testing.T, dir string) (*embed.Etcd, string) {
+	freeUrl := func() url.URL {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		defer listener.Close()
+		return url.URL{Scheme: "http", Host: listener.Addr().String()}
+	}
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	clientUrl, peerUrl := freeUrl(), freeUrl()
+	cfg.LCUrls, cfg.ACUrls = []url.URL{clientUrl}, []url.URL{clientUrl}
+	cfg.LPUrls, cfg.APUrls = []url.URL{peerUrl}, []url.URL{peerUrl}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	server, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatalf("start etcd: %v", err)
+	}
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(time.Minute):
+		server.Close()
+		t.Fatalf("etcd is not ready")
+	}
+	return server, clientUrl.Host
+}
+
+func TestRenameLargeDirectory(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	server, address := startEmbeddedEtcd(t, dir)
+	defer server.Close()
+
+	config := viper.New()
+	config.Set("servers", []string{address})
+	config.Set("timeout", "10s")
+	config.Set("key_prefix", "seaweedfs.")
+	store := &etcd.EtcdStore{}
+	if err := store.Initialize(config); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	filer := filer2.NewFiler(nil, nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+	fs := &FilerServer{filer: filer}
+
+	// more entries than max_txn_ops, also in a sub directory
+	ctx := context.Background()
+	var names []string
+	for i := 0; i < 150; i++ {
+		names = append(names, fmt.Sprintf("/dir/file%03d", i))
+	}
+	for i := 0; i < 20; i++ {
+		names = append(names, fmt.Sprintf("/dir/sub/file%03d", i))
+	}
+	for _, name := range names {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath(name), Attr: filer2.Attr{Mode: 0644}}); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+
+	_, err := fs.AtomicRenameEntry(ctx, &filer_pb.AtomicRenameEntryRequest{
+		OldDirectory: "/",
+		OldName:      "dir",
+		NewDirectory: "/",
+		NewName:      "moved",
+	})
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+
+	for _, name := range names {
+		if _, err := filer.FindEntry(ctx, filer2.FullPath("/moved"+name[len("/dir"):])); err != nil {
+			t.Errorf("find moved %s: %v", name, err)
+		}
+	}
+	if _, err := filer.FindEntry(ctx, "/dir"); err == nil {
+		t.Errorf("the old directory is not removed")
+	}
+	if entries, _ := filer.ListDirectoryEntries(ctx, "/moved", "", false, 1024); len(entries) != 151 {
+		t.Errorf("listed %d entries in the moved directory", len(entries))
+	}
+}
@@ -0,0 +1,24 @@
+package weed_server
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) SubscribeMetadata(req *filer_pb.SubscribeMetadataRequest, stream filer_pb.SeaweedFiler_SubscribeMetadataServer) error {
+
+	if fs.filer.MetaLog == nil {
+		return fmt.Errorf("filer meta log is not enabled")
+	}
+
+	glog.V(0).Infof("%s subscribes %s since %v", req.ClientName, req.PathPrefix, time.Unix(0, req.SinceNs))
+	defer glog.V(0).Infof("%s unsubscribes %s", req.ClientName, req.PathPrefix)
+
+	return fs.filer.MetaLog.Subscribe(stream.Context(), req.PathPrefix, req.SinceNs, func(event *filer_pb.SubscribeMetadataResponse) error {
+		return stream.Send(event)
+	})
+
+}
@@ -3,17 +3,21 @@ package weed_server
 import (
 	"net/http"
 	"os"
+	"time"
 
 	"google.golang.org/grpc"
 
 	"github.com/spf13/viper"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/bbolt"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/cassandra"
+	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/etcd"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/leveldb"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/memdb"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/mysql"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/postgres"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/redis"
+	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2/tikv"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/notification"
 	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/notification/aws_sqs"
@@ -33,8 +37,13 @@ type FilerOption struct {
 	MaxMB              int
 	DirListingLimit    int
 	DataCenter         string
+	DiskType           string
 	DefaultLevelDbDir  string
 	DisableHttp        bool
+	MetaLogDir         string
+	MetaLogRetention   time.Duration
+	// the address of the filer, to claim serving the file locks
+	Address string
 }
 
 type FilerServer struct {
@@ -42,6 +51,8 @@ type FilerServer struct {
 	secret         security.SigningKey
 	filer          *filer2.Filer
 	grpcDialOption grpc.DialOption
+	lockManager    *filer2.LockManager
+	identityPolicy *identityPolicy
 }
 
 func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption) (fs *FilerServer, err error) {
@@ -49,12 +60,20 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	fs = &FilerServer{
 		option:         option,
 		grpcDialOption: security.LoadClientTLS(viper.Sub("grpc"), "filer"),
+		lockManager:    filer2.NewLockManager(30 * time.Second),
 	}
 
 	if len(option.Masters) == 0 {
 		glog.Fatal("master list is required!")
 	}
 
+	if fs.identityPolicy, err = loadIdentityPolicy(viper.Sub("filer.identity")); err != nil {
+		glog.Fatalf("filer identity: %v", err)
+	}
+	if fs.identityPolicy.defaultIdentity == nil {
+		glog.V(0).Infof("no default identity in security.toml [filer.identity], the requests without an identity are denied")
+	}
+
 	fs.filer = filer2.NewFiler(option.Masters, fs.grpcDialOption)
 
 	go fs.filer.KeepConnectedToMaster()
@@ -74,6 +93,14 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 
 	notification.LoadConfiguration(v.Sub("notification"))
 
+	fs.lockManager.Serve(fs.filer.Store(), option.Address)
+
+	if option.MetaLogDir != "" {
+		if fs.filer.MetaLog, err = filer2.NewMetaLog(option.MetaLogDir, option.MetaLogRetention); err != nil {
+			glog.Fatalf("Filer meta log: %v", err)
+		}
+	}
+
 	handleStaticResources(defaultMux)
 	if !option.DisableHttp {
 		defaultMux.HandleFunc("/", fs.filerHandler)
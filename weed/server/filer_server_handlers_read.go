@@ -1,7 +1,6 @@
 package weed_server
 
 import (
-	"context"
 	"io"
 	"io/ioutil"
 	"mime"
@@ -12,12 +11,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/wdclient"
 )
 
 func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request, isGetMethod bool) {
@@ -26,7 +25,14 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 		path = path[:len(path)-1]
 	}
 
-	entry, err := fs.filer.FindEntry(context.Background(), filer2.FullPath(path))
+	ctx := fs.requestContext(r)
+
+	entry, err := fs.filer.FindEntry(ctx, filer2.FullPath(path))
+	if filer2.IsPermissionDenied(err) {
+		glog.V(1).Infof("read %s: %v", path, err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		if path == "/" {
 			fs.listDirectoryHandler(w, r)
@@ -55,6 +61,12 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if id := filer2.IdentityFromContext(ctx); !filer2.HasPermission(id, entry.Uid, entry.Gid, entry.Mode, entry.Extended, filer2.PermRead) {
+		glog.V(1).Infof("read %s as %v: %v", path, id, filer2.ErrPermissionDenied)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	if len(entry.Chunks) == 0 {
 		glog.V(1).Infof("no file chunks for %s, attr=%+v", path, entry.Attr)
 		w.WriteHeader(http.StatusNoContent)
@@ -85,43 +97,58 @@ func (fs *FilerServer) handleSingleChunk(w http.ResponseWriter, r *http.Request,
 
 	fileId := entry.Chunks[0].FileId
 
-	urlString, err := fs.filer.MasterClient.LookupFileId(fileId)
+	locations, err := fs.filer.MasterClient.LookupFileIdLocations(fileId)
 	if err != nil {
 		glog.V(1).Infof("operation LookupFileId %s failed, err: %v", fileId, err)
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+	locations = wdclient.SortLocations(locations, fs.option.DataCenter)
 
 	if fs.option.RedirectOnRead {
-		http.Redirect(w, r, urlString, http.StatusFound)
+		http.Redirect(w, r, "http://"+locations[0].Url+"/"+fileId, http.StatusFound)
 		return
 	}
 
-	u, _ := url.Parse(urlString)
-	q := u.Query()
-	for key, values := range r.URL.Query() {
-		for _, value := range values {
-			q.Add(key, value)
+	// try the replicas in order, on connection errors or 5xx responses
+	var resp *http.Response
+	for i, loc := range locations {
+		u, _ := url.Parse("http://" + loc.Url + "/" + fileId)
+		q := u.Query()
+		for key, values := range r.URL.Query() {
+			for _, value := range values {
+				q.Add(key, value)
+			}
 		}
-	}
-	u.RawQuery = q.Encode()
-	request := &http.Request{
-		Method:        r.Method,
-		URL:           u,
-		Proto:         r.Proto,
-		ProtoMajor:    r.ProtoMajor,
-		ProtoMinor:    r.ProtoMinor,
-		Header:        r.Header,
-		Body:          r.Body,
-		Host:          r.Host,
-		ContentLength: r.ContentLength,
-	}
-	glog.V(3).Infoln("retrieving from", u)
-	resp, do_err := util.Do(request)
-	if do_err != nil {
-		glog.V(0).Infoln("failing to connect to volume server", do_err.Error())
-		writeJsonError(w, r, http.StatusInternalServerError, do_err)
-		return
+		u.RawQuery = q.Encode()
+		request := &http.Request{
+			Method:        r.Method,
+			URL:           u,
+			Proto:         r.Proto,
+			ProtoMajor:    r.ProtoMajor,
+			ProtoMinor:    r.ProtoMinor,
+			Header:        r.Header,
+			Body:          r.Body,
+			Host:          r.Host,
+			ContentLength: r.ContentLength,
+		}
+		glog.V(3).Infoln("retrieving from", u)
+		var do_err error
+		resp, do_err = util.Do(request)
+		if do_err != nil {
+			glog.V(0).Infoln("failing to connect to volume server", do_err.Error())
+			if i == len(locations)-1 {
+				writeJsonError(w, r, http.StatusInternalServerError, do_err)
+				return
+			}
+			continue
+		}
+		if resp.StatusCode < 500 || i == len(locations)-1 {
+			break
+		}
+		glog.V(0).Infof("retrieving from %s: %s, try the next replica", u, resp.Status)
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
 	}
 	defer func() {
 		io.Copy(ioutil.Discard, resp.Body)
@@ -248,43 +275,41 @@ func (fs *FilerServer) writeContent(w io.Writer, entry *filer2.Entry, offset int
 
 	chunkViews := filer2.ViewFromChunks(entry.Chunks, offset, size)
 
-	fileId2Url := make(map[string]string)
-
-	for _, chunkView := range chunkViews {
-
-		urlString, err := fs.filer.MasterClient.LookupFileId(chunkView.FileId)
-		if err != nil {
-			glog.V(1).Infof("operation LookupFileId %s failed, err: %v", chunkView.FileId, err)
-			return err
-		}
-		fileId2Url[chunkView.FileId] = urlString
-	}
+	// the holes between the chunks are read as zeros
 	buff := make([]byte, size)
 	var totalRead int64
+	var readErr error
+	var readErrLock sync.Mutex
 	var wg sync.WaitGroup
 	for _, chunkView := range chunkViews {
 		wg.Add(1)
 		go func(chunkView *filer2.ChunkView) {
 			defer wg.Done()
 			glog.V(4).Infof("read fh reading chunk: %+v", chunkView)
-			urlString, ok := fileId2Url[chunkView.FileId]
-			if !ok {
-				return
-			}
-			n, err := util.ReadUrl(urlString,
+			n, err := fs.filer.MasterClient.ReadFileId(chunkView.FileId, fs.option.DataCenter,
 				chunkView.Offset,
 				int(chunkView.Size),
 				buff[chunkView.LogicOffset-offset:chunkView.LogicOffset-offset+int64(chunkView.Size)],
 				!chunkView.IsFullChunk)
 			if err != nil {
-				glog.V(0).Infof("read %s failed: %v", urlString, err)
+				glog.V(0).Infof("read %s failed: %v", chunkView.FileId, err)
+				readErrLock.Lock()
+				readErr = err
+				readErrLock.Unlock()
 				return
 			}
 			glog.V(4).Infof("read fh read %d bytes: %+v", n, chunkView)
-			atomic.AddInt64(&totalRead, n)
+			readErrLock.Lock()
+			if readStop := chunkView.LogicOffset - offset + n; readStop > totalRead {
+				totalRead = readStop
+			}
+			readErrLock.Unlock()
 		}(chunkView)
 	}
 	wg.Wait()
+	if readErr != nil {
+		return readErr
+	}
 	_, err := w.Write(buff[:totalRead])
 	if err != nil {
 		return err
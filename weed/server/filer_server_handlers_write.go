@@ -20,6 +20,7 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
@@ -37,12 +38,17 @@ type FilerPostResult struct {
 }
 
 func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request, replication, collection string, dataCenter string) (fileId, urlLocation string, auth security.EncodedJwt, err error) {
+	diskType := r.URL.Query().Get("disk")
+	if diskType == "" {
+		diskType = fs.option.DiskType
+	}
 	ar := &operation.VolumeAssignRequest{
 		Count:       1,
 		Replication: replication,
 		Collection:  collection,
 		Ttl:         r.URL.Query().Get("ttl"),
 		DataCenter:  dataCenter,
+		DiskType:    diskType,
 	}
 	var altRequest *operation.VolumeAssignRequest
 	if dataCenter != "" {
@@ -52,6 +58,7 @@ func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request,
 			Collection:  collection,
 			Ttl:         r.URL.Query().Get("ttl"),
 			DataCenter:  "",
+			DiskType:    diskType,
 		}
 	}
 
@@ -70,7 +77,7 @@ func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request,
 
 func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := fs.requestContext(r)
 
 	query := r.URL.Query()
 	replication := query.Get("replication")
@@ -86,7 +93,17 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 		dataCenter = fs.option.DataCenter
 	}
 
-	if autoChunked := fs.autoChunk(ctx, w, r, replication, collection, dataCenter); autoChunked {
+	if err := fs.checkQuota(ctx, r); err != nil {
+		glog.V(0).Infof("write %s: %v", r.URL.Path, err)
+		writeJsonError(w, r, http.StatusInsufficientStorage, err)
+		return
+	}
+
+	// the extended attributes are saved in the filer, not in the volume servers
+	extended := filer2.ExtendedFromHeader(r.Header)
+	r.Header.Del(filer2.ExtendedHeader)
+
+	if autoChunked := fs.autoChunk(ctx, w, r, replication, collection, dataCenter, extended); autoChunked {
 		return
 	}
 
@@ -192,7 +209,7 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 			Gid:         OS_GID,
 			Replication: replication,
 			Collection:  collection,
-			TtlSec:      int32(util.ParseInt(r.URL.Query().Get("ttl"), 0)),
+			TtlSec:      ttlSecFromQuery(r.URL.Query().Get("ttl")),
 		},
 		Chunks: []*filer_pb.FileChunk{{
 			FileId: fileId,
@@ -200,6 +217,7 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 			Mtime:  time.Now().UnixNano(),
 			ETag:   etag,
 		}},
+		Extended: extended,
 	}
 	if ext := filenamePath.Ext(path); ext != "" {
 		entry.Attr.Mime = mime.TypeByExtension(ext)
@@ -208,7 +226,7 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 	if db_err := fs.filer.CreateEntry(ctx, entry); db_err != nil {
 		fs.filer.DeleteFileByFileId(fileId)
 		glog.V(0).Infof("failing to write %s to filer server : %v", path, db_err)
-		writeJsonError(w, r, http.StatusInternalServerError, db_err)
+		writeJsonError(w, r, writeErrorStatus(db_err), db_err)
 		return
 	}
 
@@ -224,18 +242,59 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 	writeJsonQuiet(w, r, http.StatusCreated, reply)
 }
 
+// checkQuota rejects the upload early if it exceeds the directory quota,
+// the quota is checked again when the entry is saved
+func (fs *FilerServer) checkQuota(ctx context.Context, r *http.Request) error {
+
+	p := filer2.FullPath(r.URL.Path)
+	deltaBytes, deltaFiles := r.ContentLength, int64(1)
+
+	if existingEntry, err := fs.filer.FindEntry(ctx, p); err == nil {
+		if existingEntry.IsDirectory() {
+			p = p.Child("")
+		} else {
+			deltaBytes -= int64(existingEntry.Size())
+			deltaFiles = 0
+		}
+	}
+
+	return fs.filer.CheckQuota(p, deltaBytes, deltaFiles)
+}
+
+func writeErrorStatus(err error) int {
+	if filer2.IsQuotaExceeded(err) {
+		return http.StatusInsufficientStorage
+	}
+	if filer2.IsPermissionDenied(err) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
 // curl -X DELETE http://localhost:8888/path/to
 // curl -X DELETE http://localhost:8888/path/to?recursive=true
 func (fs *FilerServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 
 	isRecursive := r.FormValue("recursive") == "true"
 
-	err := fs.filer.DeleteEntryMetaAndData(context.Background(), filer2.FullPath(r.URL.Path), isRecursive, true)
+	err := fs.filer.DeleteEntryMetaAndData(fs.requestContext(r), filer2.FullPath(r.URL.Path), isRecursive, true)
 	if err != nil {
 		glog.V(1).Infoln("deleting", r.URL.Path, ":", err.Error())
-		writeJsonError(w, r, http.StatusInternalServerError, err)
+		writeJsonError(w, r, writeErrorStatus(err), err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ttlSecFromQuery accepts the ttl either in seconds, or in the volume ttl format, e.g., 3m, 4h, 5d
+func ttlSecFromQuery(ttlString string) int32 {
+	if ttlSec := util.ParseInt(ttlString, 0); ttlSec > 0 {
+		return int32(ttlSec)
+	}
+	ttl, err := storage.ReadTTL(ttlString)
+	if err != nil {
+		return 0
+	}
+	return int32(ttl.Minutes() * 60)
+}
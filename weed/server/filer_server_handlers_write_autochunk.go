@@ -16,11 +16,10 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
-	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
 func (fs *FilerServer) autoChunk(ctx context.Context, w http.ResponseWriter, r *http.Request,
-	replication string, collection string, dataCenter string) bool {
+	replication string, collection string, dataCenter string, extended map[string][]byte) bool {
 	if r.Method != "POST" {
 		glog.V(4).Infoln("AutoChunking not supported for method", r.Method)
 		return false
@@ -56,9 +55,9 @@ func (fs *FilerServer) autoChunk(ctx context.Context, w http.ResponseWriter, r *
 		return false
 	}
 
-	reply, err := fs.doAutoChunk(ctx, w, r, contentLength, chunkSize, replication, collection, dataCenter)
+	reply, err := fs.doAutoChunk(ctx, w, r, contentLength, chunkSize, replication, collection, dataCenter, extended)
 	if err != nil {
-		writeJsonError(w, r, http.StatusInternalServerError, err)
+		writeJsonError(w, r, writeErrorStatus(err), err)
 	} else if reply != nil {
 		writeJsonQuiet(w, r, http.StatusCreated, reply)
 	}
@@ -66,7 +65,7 @@ func (fs *FilerServer) autoChunk(ctx context.Context, w http.ResponseWriter, r *
 }
 
 func (fs *FilerServer) doAutoChunk(ctx context.Context, w http.ResponseWriter, r *http.Request,
-	contentLength int64, chunkSize int32, replication string, collection string, dataCenter string) (filerResult *FilerPostResult, replyerr error) {
+	contentLength int64, chunkSize int32, replication string, collection string, dataCenter string, extended map[string][]byte) (filerResult *FilerPostResult, replyerr error) {
 
 	multipartReader, multipartReaderErr := r.MultipartReader()
 	if multipartReaderErr != nil {
@@ -165,11 +164,13 @@ func (fs *FilerServer) doAutoChunk(ctx context.Context, w http.ResponseWriter, r
 			Gid:         OS_GID,
 			Replication: replication,
 			Collection:  collection,
-			TtlSec:      int32(util.ParseInt(r.URL.Query().Get("ttl"), 0)),
+			TtlSec:      ttlSecFromQuery(r.URL.Query().Get("ttl")),
 		},
-		Chunks: fileChunks,
+		Chunks:   fileChunks,
+		Extended: extended,
 	}
 	if db_err := fs.filer.CreateEntry(ctx, entry); db_err != nil {
+		fs.filer.DeleteChunks(fileChunks)
 		replyerr = db_err
 		filerResult.Error = db_err.Error()
 		glog.V(0).Infof("failing to write %s to filer server : %v", path, db_err)
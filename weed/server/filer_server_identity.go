@@ -0,0 +1,113 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// identityPolicy decides the identity of the filer requests.
+// Only the trusted callers may pass the identity of their users, i.e., the grpc callers authenticated by the
+// mutual TLS, and the callers in the white list, e.g., the hosts running "weed mount" and the S3 gateway.
+// The requests without an identity take the default identity, or the anonymous identity denied by the permission checks.
+type identityPolicy struct {
+	whiteList       []string
+	defaultIdentity *filer2.Identity
+}
+
+// loadIdentityPolicy reads the [filer.identity] section of security.toml
+func loadIdentityPolicy(config *viper.Viper) (*identityPolicy, error) {
+	policy := &identityPolicy{}
+	if config == nil {
+		return policy, nil
+	}
+	for _, ip := range strings.Split(config.GetString("whiteList"), ",") {
+		if ip = strings.TrimSpace(ip); ip == "" {
+			continue
+		}
+		if strings.Contains(ip, "/") {
+			if _, _, err := net.ParseCIDR(ip); err != nil {
+				return nil, fmt.Errorf("identity white list %s: %v", ip, err)
+			}
+		}
+		policy.whiteList = append(policy.whiteList, ip)
+	}
+	if defaultIdentity := config.GetString("default"); defaultIdentity != "" {
+		id, err := filer2.ParseIdentity(defaultIdentity)
+		if err != nil {
+			return nil, fmt.Errorf("default identity %s: %v", defaultIdentity, err)
+		}
+		policy.defaultIdentity = id
+	}
+	return policy, nil
+}
+
+// resolve returns the identity of the request, from the identity passed by the caller
+func (p *identityPolicy) resolve(passed *filer2.Identity, host string, authenticated bool) *filer2.Identity {
+	if passed != nil {
+		if authenticated || security.IsWhiteListed(p.whiteList, host) {
+			return passed
+		}
+		glog.V(1).Infof("ignore the identity %v passed by the untrusted %s", passed, host)
+	}
+	if p.defaultIdentity != nil {
+		return p.defaultIdentity
+	}
+	return filer2.Anonymous
+}
+
+// requestContext carries the identity of the http request
+func (fs *FilerServer) requestContext(r *http.Request) context.Context {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	id := fs.identityPolicy.resolve(filer2.IdentityFromHeader(r.Header), host, false)
+	return filer2.WithIdentity(context.Background(), id)
+}
+
+// grpcContext carries the identity of the grpc request
+func (fs *FilerServer) grpcContext(ctx context.Context) context.Context {
+	var host string
+	var authenticated bool
+	if p, found := peer.FromContext(ctx); found {
+		host, _, _ = net.SplitHostPort(p.Addr.String())
+		// the filer grpc server with TLS requires and verifies the client certificates
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			authenticated = len(tlsInfo.State.VerifiedChains) > 0
+		}
+	}
+	id := fs.identityPolicy.resolve(filer2.IdentityFromIncomingContext(ctx), host, authenticated)
+	return filer2.WithIdentity(ctx, id)
+}
+
+// GrpcIdentityOptions sets the identity of the grpc requests to the filer
+func (fs *FilerServer) GrpcIdentityOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(fs.grpcContext(ctx), req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &identityServerStream{ServerStream: stream, ctx: fs.grpcContext(stream.Context())})
+		}),
+	}
+}
+
+type identityServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityServerStream) Context() context.Context {
+	return s.ctx
+}
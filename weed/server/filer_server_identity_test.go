@@ -0,0 +1,62 @@
+package weed_server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+)
+
+func TestIdentityPolicy(t *testing.T) {
+
+	config := viper.New()
+	config.Set("whiteList", "10.0.0.1, 192.168.0.0/16")
+	policy, err := loadIdentityPolicy(config)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	fs := &FilerServer{identityPolicy: policy}
+
+	// the identity headers are only taken from the trusted callers, not trusting the forwarded address
+	for _, c := range []struct {
+		remoteAddr string
+		expected   *filer2.Identity
+	}{
+		{"10.0.0.1:3000", &filer2.Identity{Uid: 1000, Gids: []uint32{100}}},
+		{"192.168.1.2:3000", &filer2.Identity{Uid: 1000, Gids: []uint32{100}}},
+		{"10.0.0.2:3000", filer2.Anonymous},
+	} {
+		r := httptest.NewRequest("GET", "/a", nil)
+		r.RemoteAddr = c.remoteAddr
+		r.Header.Set("X-Forwarded-For", "10.0.0.1")
+		filer2.SetIdentityHeader(r.Header, &filer2.Identity{Uid: 1000, Gids: []uint32{100}})
+		id := filer2.IdentityFromContext(fs.requestContext(r))
+		if id.String() != c.expected.String() {
+			t.Errorf("request from %s as %v, expected %v", c.remoteAddr, id, c.expected)
+		}
+	}
+
+	// the requests without an identity are denied, unless there is a default identity
+	r := httptest.NewRequest("GET", "/a", nil)
+	r.RemoteAddr = "10.0.0.1:3000"
+	if id := filer2.IdentityFromContext(fs.requestContext(r)); id != filer2.Anonymous {
+		t.Errorf("request without identity as %v", id)
+	}
+	if filer2.HasPermission(filer2.Anonymous, 0, 0, 0777, nil, filer2.PermRead) {
+		t.Errorf("anonymous has the permission of others")
+	}
+
+	config.Set("default", "2000:200,300")
+	if fs.identityPolicy, err = loadIdentityPolicy(config); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if id := filer2.IdentityFromContext(fs.requestContext(r)); id.String() != "uid=2000 gids=200,300" {
+		t.Errorf("request without identity as %v", id)
+	}
+
+	config.Set("default", "root")
+	if _, err = loadIdentityPolicy(config); err == nil {
+		t.Errorf("loaded invalid default identity")
+	}
+}
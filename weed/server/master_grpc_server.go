@@ -6,9 +6,10 @@ import (
 	"strings"
 	"time"
 
-	"github.com/chrislusf/raft"
+	"github.com/hashicorp/raft"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/topology"
 	"google.golang.org/grpc/peer"
 )
@@ -30,8 +31,11 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 			for _, v := range dn.GetVolumes() {
 				message.DeletedVids = append(message.DeletedVids, uint32(v.Id))
 			}
+			for _, s := range dn.GetEcShards() {
+				message.DeletedEcVids = append(message.DeletedEcVids, uint32(s.VolumeId))
+			}
 
-			if len(message.DeletedVids) > 0 {
+			if len(message.DeletedVids) > 0 || len(message.DeletedEcVids) > 0 {
 				ms.clientChansLock.RLock()
 				for _, ch := range ms.clientChans {
 					ch <- message
@@ -65,6 +69,7 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 				int(heartbeat.Port), heartbeat.PublicUrl,
 				int64(heartbeat.MaxVolumeCount))
 			glog.V(0).Infof("added volume server %v:%d", heartbeat.GetIp(), heartbeat.GetPort())
+			t.RestoreDataNodeDraining(dn)
 			if err := stream.Send(&master_pb.HeartbeatResponse{
 				VolumeSizeLimit: uint64(ms.volumeSizeLimitMB) * 1024 * 1024,
 			}); err != nil {
@@ -73,14 +78,35 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 		}
 
 		message := &master_pb.VolumeLocation{
-			Url:       dn.Url(),
-			PublicUrl: dn.PublicUrl,
+			Url:        dn.Url(),
+			PublicUrl:  dn.PublicUrl,
+			DataCenter: string(dn.GetDataCenter().Id()),
 		}
-		if len(heartbeat.NewVids) > 0 || len(heartbeat.DeletedVids) > 0 {
+		isDeltaBeat := len(heartbeat.NewVids) > 0 || len(heartbeat.DeletedVids) > 0 ||
+			len(heartbeat.NewEcShards) > 0 || len(heartbeat.DeletedEcShards) > 0
+		if isDeltaBeat {
 			// process delta volume ids if exists for fast volume id updates
 			message.NewVids = append(message.NewVids, heartbeat.NewVids...)
 			message.DeletedVids = append(message.DeletedVids, heartbeat.DeletedVids...)
+
+			// process delta ec shards
+			if len(heartbeat.NewEcShards) > 0 || len(heartbeat.DeletedEcShards) > 0 {
+				t.IncrementalSyncDataNodeEcShards(heartbeat.NewEcShards, heartbeat.DeletedEcShards, dn)
+				for _, s := range heartbeat.NewEcShards {
+					message.NewEcVids = append(message.NewEcVids, s.Id)
+				}
+				for _, s := range heartbeat.DeletedEcShards {
+					if len(dn.EcShardIds(storage.VolumeId(s.Id))) == 0 {
+						message.DeletedEcVids = append(message.DeletedEcVids, s.Id)
+					}
+				}
+			}
 		} else {
+			// the max volume counts of the disk types, from the volume servers aware of the disk types
+			if len(heartbeat.MaxVolumeCounts) > 0 {
+				dn.UpdateMaxVolumeCounts(heartbeat.MaxVolumeCounts)
+			}
+
 			// process heartbeat.Volumes
 			newVolumes, deletedVolumes := t.SyncDataNodeRegistration(heartbeat.Volumes, dn)
 
@@ -90,9 +116,22 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 			for _, v := range deletedVolumes {
 				message.DeletedVids = append(message.DeletedVids, uint32(v.Id))
 			}
+
+			// process heartbeat.EcShards
+			newShards, deletedShards := t.SyncDataNodeEcShards(heartbeat.EcShards, dn)
+
+			for _, s := range newShards {
+				message.NewEcVids = append(message.NewEcVids, uint32(s.VolumeId))
+			}
+			for _, s := range deletedShards {
+				if len(dn.EcShardIds(s.VolumeId)) == 0 {
+					message.DeletedEcVids = append(message.DeletedEcVids, uint32(s.VolumeId))
+				}
+			}
 		}
 
-		if len(message.NewVids) > 0 || len(message.DeletedVids) > 0 {
+		if len(message.NewVids) > 0 || len(message.DeletedVids) > 0 ||
+			len(message.NewEcVids) > 0 || len(message.DeletedEcVids) > 0 {
 			ms.clientChansLock.RLock()
 			for _, ch := range ms.clientChans {
 				ch <- message
@@ -123,7 +162,7 @@ func (ms *MasterServer) KeepConnected(stream master_pb.Seaweed_KeepConnectedServ
 	}
 
 	if !ms.Topo.IsLeader() {
-		return raft.NotLeaderError
+		return raft.ErrNotLeader
 	}
 
 	// remember client address
@@ -183,7 +222,7 @@ func (ms *MasterServer) KeepConnected(stream master_pb.Seaweed_KeepConnectedServ
 			}
 		case <-ticker.C:
 			if !ms.Topo.IsLeader() {
-				return raft.NotLeaderError
+				return raft.ErrNotLeader
 			}
 		case <-stopChan:
 			return nil
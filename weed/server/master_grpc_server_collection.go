@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/chrislusf/raft"
+	"github.com/hashicorp/raft"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
@@ -13,7 +13,7 @@ import (
 func (ms *MasterServer) CollectionList(ctx context.Context, req *master_pb.CollectionListRequest) (*master_pb.CollectionListResponse, error) {
 
 	if !ms.Topo.IsLeader() {
-		return nil, raft.NotLeaderError
+		return nil, raft.ErrNotLeader
 	}
 
 	resp := &master_pb.CollectionListResponse{}
@@ -30,7 +30,7 @@ func (ms *MasterServer) CollectionList(ctx context.Context, req *master_pb.Colle
 func (ms *MasterServer) CollectionDelete(ctx context.Context, req *master_pb.CollectionDeleteRequest) (*master_pb.CollectionDeleteResponse, error) {
 
 	if !ms.Topo.IsLeader() {
-		return nil, raft.NotLeaderError
+		return nil, raft.ErrNotLeader
 	}
 
 	resp := &master_pb.CollectionDeleteResponse{}
@@ -0,0 +1,87 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/raft"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+func (ms *MasterServer) RaftListClusterServers(ctx context.Context, req *master_pb.RaftListClusterServersRequest) (*master_pb.RaftListClusterServersResponse, error) {
+
+	if ms.Topo.RaftServer == nil {
+		return nil, fmt.Errorf("raft server is not ready yet")
+	}
+
+	future := ms.Topo.RaftServer.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+
+	_, leaderId := ms.Topo.RaftServer.LeaderWithID()
+
+	resp := &master_pb.RaftListClusterServersResponse{}
+	for _, server := range future.Configuration().Servers {
+		resp.ClusterServers = append(resp.ClusterServers, &master_pb.RaftListClusterServersResponse_ClusterServer{
+			Id:       string(server.ID),
+			Address:  string(server.Address),
+			Suffrage: server.Suffrage.String(),
+			IsLeader: server.ID == leaderId,
+		})
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) RaftAddServer(ctx context.Context, req *master_pb.RaftAddServerRequest) (*master_pb.RaftAddServerResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.ErrNotLeader
+	}
+
+	if req.Id == "" {
+		return nil, fmt.Errorf("missing server id")
+	}
+	address := req.Address
+	if address == "" {
+		raftAddress, err := util.ParseServerToRaftAddress(req.Id)
+		if err != nil {
+			return nil, fmt.Errorf("raft address of %s: %v", req.Id, err)
+		}
+		address = raftAddress
+	}
+
+	var future raft.IndexFuture
+	if req.Voter {
+		future = ms.Topo.RaftServer.AddVoter(raft.ServerID(req.Id), raft.ServerAddress(address), 0, 0)
+	} else {
+		future = ms.Topo.RaftServer.AddNonvoter(raft.ServerID(req.Id), raft.ServerAddress(address), 0, 0)
+	}
+	if err := future.Error(); err != nil {
+		return nil, fmt.Errorf("add raft server %s %s: %v", req.Id, address, err)
+	}
+	glog.V(0).Infof("added raft server %s %s voter:%v", req.Id, address, req.Voter)
+
+	return &master_pb.RaftAddServerResponse{}, nil
+}
+
+func (ms *MasterServer) RaftRemoveServer(ctx context.Context, req *master_pb.RaftRemoveServerRequest) (*master_pb.RaftRemoveServerResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.ErrNotLeader
+	}
+
+	if req.Id == "" {
+		return nil, fmt.Errorf("missing server id")
+	}
+
+	if err := ms.Topo.RaftServer.RemoveServer(raft.ServerID(req.Id), 0, 0).Error(); err != nil {
+		return nil, fmt.Errorf("remove raft server %s: %v", req.Id, err)
+	}
+	glog.V(0).Infof("removed raft server %s", req.Id)
+
+	return &master_pb.RaftRemoveServerResponse{}, nil
+}
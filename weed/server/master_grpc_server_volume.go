@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/chrislusf/raft"
+	"github.com/hashicorp/raft"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
@@ -14,7 +14,7 @@ import (
 func (ms *MasterServer) LookupVolume(ctx context.Context, req *master_pb.LookupVolumeRequest) (*master_pb.LookupVolumeResponse, error) {
 
 	if !ms.Topo.IsLeader() {
-		return nil, raft.NotLeaderError
+		return nil, raft.ErrNotLeader
 	}
 
 	resp := &master_pb.LookupVolumeResponse{}
@@ -41,7 +41,7 @@ func (ms *MasterServer) LookupVolume(ctx context.Context, req *master_pb.LookupV
 func (ms *MasterServer) Assign(ctx context.Context, req *master_pb.AssignRequest) (*master_pb.AssignResponse, error) {
 
 	if !ms.Topo.IsLeader() {
-		return nil, raft.NotLeaderError
+		return nil, raft.ErrNotLeader
 	}
 
 	if req.Count == 0 {
@@ -59,6 +59,10 @@ func (ms *MasterServer) Assign(ctx context.Context, req *master_pb.AssignRequest
 	if err != nil {
 		return nil, err
 	}
+	diskType, err := storage.ToDiskType(req.DiskType)
+	if err != nil {
+		return nil, err
+	}
 
 	option := &topology.VolumeGrowOption{
 		Collection:       req.Collection,
@@ -68,11 +72,12 @@ func (ms *MasterServer) Assign(ctx context.Context, req *master_pb.AssignRequest
 		DataCenter:       req.DataCenter,
 		Rack:             req.Rack,
 		DataNode:         req.DataNode,
+		DiskType:         diskType,
 	}
 
 	if !ms.Topo.HasWritableVolume(option) {
-		if ms.Topo.FreeSpace() <= 0 {
-			return nil, fmt.Errorf("No free volumes left!")
+		if ms.Topo.FreeSpaceOf(diskType) <= 0 {
+			return nil, fmt.Errorf("No free volumes left on %s disks!", diskType)
 		}
 		ms.vgLock.Lock()
 		if !ms.Topo.HasWritableVolume(option) {
@@ -100,7 +105,7 @@ func (ms *MasterServer) Assign(ctx context.Context, req *master_pb.AssignRequest
 func (ms *MasterServer) Statistics(ctx context.Context, req *master_pb.StatisticsRequest) (*master_pb.StatisticsResponse, error) {
 
 	if !ms.Topo.IsLeader() {
-		return nil, raft.NotLeaderError
+		return nil, raft.ErrNotLeader
 	}
 
 	if req.Replication == "" {
@@ -114,8 +119,12 @@ func (ms *MasterServer) Statistics(ctx context.Context, req *master_pb.Statistic
 	if err != nil {
 		return nil, err
 	}
+	diskType, err := storage.ToDiskType(req.DiskType)
+	if err != nil {
+		return nil, err
+	}
 
-	volumeLayout := ms.Topo.GetVolumeLayout(req.Collection, replicaPlacement, ttl)
+	volumeLayout := ms.Topo.GetVolumeLayout(req.Collection, replicaPlacement, ttl, diskType)
 	stats := volumeLayout.Stats()
 
 	resp := &master_pb.StatisticsResponse{
@@ -130,7 +139,7 @@ func (ms *MasterServer) Statistics(ctx context.Context, req *master_pb.Statistic
 func (ms *MasterServer) VolumeList(ctx context.Context, req *master_pb.VolumeListRequest) (*master_pb.VolumeListResponse, error) {
 
 	if !ms.Topo.IsLeader() {
-		return nil, raft.NotLeaderError
+		return nil, raft.ErrNotLeader
 	}
 
 	resp := &master_pb.VolumeListResponse{
@@ -139,3 +148,97 @@ func (ms *MasterServer) VolumeList(ctx context.Context, req *master_pb.VolumeLis
 
 	return resp, nil
 }
+
+func (ms *MasterServer) LookupEcVolume(ctx context.Context, req *master_pb.LookupEcVolumeRequest) (*master_pb.LookupEcVolumeResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.ErrNotLeader
+	}
+
+	resp := &master_pb.LookupEcVolumeResponse{}
+
+	ecLocations, found := ms.Topo.LookupEcShards(storage.VolumeId(req.VolumeId))
+
+	if !found {
+		return resp, fmt.Errorf("ec volume %d not found", req.VolumeId)
+	}
+
+	resp.VolumeId = req.VolumeId
+
+	for shardId, shardLocations := range ecLocations.Locations {
+		if len(shardLocations) == 0 {
+			continue
+		}
+		var locations []*master_pb.Location
+		for _, dn := range shardLocations {
+			locations = append(locations, &master_pb.Location{
+				Url:       dn.Url(),
+				PublicUrl: dn.PublicUrl,
+			})
+		}
+		resp.ShardIdLocations = append(resp.ShardIdLocations, &master_pb.LookupEcVolumeResponse_EcShardIdLocation{
+			ShardId:   uint32(shardId),
+			Locations: locations,
+		})
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) VolumeServerDrain(ctx context.Context, req *master_pb.VolumeServerDrainRequest) (*master_pb.VolumeServerDrainResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.ErrNotLeader
+	}
+
+	if found := ms.Topo.SetDataNodeDraining(req.Node, req.Draining); !found && req.Draining {
+		return nil, fmt.Errorf("volume server %s not found", req.Node)
+	}
+
+	return &master_pb.VolumeServerDrainResponse{}, nil
+}
+
+func (ms *MasterServer) VolumeMoveLocation(ctx context.Context, req *master_pb.VolumeMoveLocationRequest) (*master_pb.VolumeMoveLocationResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.ErrNotLeader
+	}
+
+	if err := ms.Topo.MoveVolumeLocation(storage.VolumeId(req.VolumeId), req.SourceNode, req.TargetNode, req.ReadOnly); err != nil {
+		return nil, err
+	}
+
+	return &master_pb.VolumeMoveLocationResponse{}, nil
+}
+
+func (ms *MasterServer) ReplicationRepairStatus(ctx context.Context, req *master_pb.ReplicationRepairStatusRequest) (*master_pb.ReplicationRepairStatusResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.ErrNotLeader
+	}
+
+	resp := &master_pb.ReplicationRepairStatusResponse{}
+	for _, repair := range ms.Topo.ReplicationRepairs() {
+		message := &master_pb.ReplicationRepairStatusResponse_ReplicationRepair{
+			VolumeId:         uint32(repair.VolumeId),
+			Collection:       repair.Collection,
+			ReplicaPlacement: repair.ReplicaPlacement.String(),
+			Ttl:              repair.Ttl.String(),
+			DiskType:         string(repair.DiskType),
+			State:            repair.State,
+			Locations:        repair.Locations,
+			Targets:          repair.Targets,
+			DetectedAt:       repair.DetectedAt.Unix(),
+			Error:            repair.Error,
+		}
+		if !repair.StartedAt.IsZero() {
+			message.StartedAt = repair.StartedAt.Unix()
+		}
+		if !repair.FinishedAt.IsZero() {
+			message.FinishedAt = repair.FinishedAt.Unix()
+		}
+		resp.Repairs = append(resp.Repairs, message)
+	}
+
+	return resp, nil
+}
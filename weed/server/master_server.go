@@ -6,6 +6,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"sync"
+	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
@@ -14,8 +15,8 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/topology"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 
-	"github.com/chrislusf/raft"
 	"github.com/gorilla/mux"
+	"github.com/hashicorp/raft"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 )
@@ -30,9 +31,10 @@ type MasterServer struct {
 	garbageThreshold        float64
 	guard                   *security.Guard
 
-	Topo   *topology.Topology
-	vg     *topology.VolumeGrowth
-	vgLock sync.Mutex
+	Topo       *topology.Topology
+	raftServer *RaftServer
+	vg         *topology.VolumeGrowth
+	vgLock     sync.Mutex
 
 	bounedLeaderChan chan int
 
@@ -51,6 +53,9 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 	garbageThreshold float64,
 	whiteList []string,
 	disableHttp bool,
+	seq sequence.Sequencer,
+	replicationRepairGracePeriod time.Duration,
+	replicationRepairInterval time.Duration,
 ) *MasterServer {
 
 	v := viper.GetViper()
@@ -71,7 +76,6 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 		grpcDialOpiton:          security.LoadClientTLS(v.Sub("grpc"), "master"),
 	}
 	ms.bounedLeaderChan = make(chan int, 16)
-	seq := sequence.NewMemorySequencer()
 	ms.Topo = topology.NewTopology("topo", seq, uint64(volumeSizeLimitMB)*1024*1024, pulseSeconds)
 	ms.vg = topology.NewDefaultVolumeGrowth()
 	glog.V(0).Infoln("Volume Size Limit is", volumeSizeLimitMB, "MB")
@@ -89,6 +93,7 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 		r.HandleFunc("/vol/grow", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeGrowHandler)))
 		r.HandleFunc("/vol/status", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeStatusHandler)))
 		r.HandleFunc("/vol/vacuum", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeVacuumHandler)))
+		r.HandleFunc("/vol/replication/status", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeReplicationStatusHandler)))
 		r.HandleFunc("/submit", ms.guard.WhiteList(ms.submitFromMasterServerHandler))
 		r.HandleFunc("/healthz", ms.guard.WhiteList(healthzHandler))
 		r.HandleFunc("/metrics", ms.guard.WhiteList(ms.metricsHandler))
@@ -96,26 +101,41 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 	}
 
 	ms.Topo.StartRefreshWritableVolumes(ms.grpcDialOpiton, garbageThreshold, ms.preallocate)
+	ms.Topo.StartReplicationRepair(ms.vg, ms.grpcDialOpiton, replicationRepairGracePeriod, replicationRepairInterval)
 
 	return ms
 }
 
 func (ms *MasterServer) SetRaftServer(raftServer *RaftServer) {
+	ms.raftServer = raftServer
 	ms.Topo.RaftServer = raftServer.raftServer
-	ms.Topo.RaftServer.AddEventListener(raft.LeaderChangeEventType, func(e raft.Event) {
-		glog.V(0).Infof("event: %+v", e)
-		if ms.Topo.RaftServer.Leader() != "" {
-			glog.V(0).Infoln("[", ms.Topo.RaftServer.Name(), "]", ms.Topo.RaftServer.Leader(), "becomes leader.")
+
+	observations := make(chan raft.Observation, 16)
+	ms.Topo.RaftServer.RegisterObserver(raft.NewObserver(observations, false, func(o *raft.Observation) bool {
+		switch o.Data.(type) {
+		case raft.LeaderObservation, raft.RaftState:
+			return true
 		}
-	})
-	ms.Topo.RaftServer.AddEventListener(raft.StateChangeEventType, func(e raft.Event) {
-		glog.V(0).Infof("state change: %+v", e)
-	})
+		return false
+	}))
+	go func() {
+		for o := range observations {
+			switch e := o.Data.(type) {
+			case raft.LeaderObservation:
+				if e.LeaderID != "" {
+					glog.V(0).Infoln("[", raftServer.serverAddr, "]", e.LeaderID, "becomes leader.")
+				}
+			case raft.RaftState:
+				glog.V(0).Infof("state change: %v", e)
+			}
+		}
+	}()
+
 	if ms.Topo.IsLeader() {
-		glog.V(0).Infoln("[", ms.Topo.RaftServer.Name(), "]", "I am the leader!")
+		glog.V(0).Infoln("[", raftServer.serverAddr, "]", "I am the leader!")
 	} else {
-		if ms.Topo.RaftServer.Leader() != "" {
-			glog.V(0).Infoln("[", ms.Topo.RaftServer.Name(), "]", ms.Topo.RaftServer.Leader(), "is the leader.")
+		if leader := raftServer.Leader(); leader != "" {
+			glog.V(0).Infoln("[", raftServer.serverAddr, "]", leader, "is the leader.")
 		}
 	}
 }
@@ -124,16 +144,16 @@ func (ms *MasterServer) proxyToLeader(f func(w http.ResponseWriter, r *http.Requ
 	return func(w http.ResponseWriter, r *http.Request) {
 		if ms.Topo.IsLeader() {
 			f(w, r)
-		} else if ms.Topo.RaftServer != nil && ms.Topo.RaftServer.Leader() != "" {
+		} else if leader, err := ms.Topo.Leader(); err == nil {
 			ms.bounedLeaderChan <- 1
 			defer func() { <-ms.bounedLeaderChan }()
-			targetUrl, err := url.Parse("http://" + ms.Topo.RaftServer.Leader())
+			targetUrl, err := url.Parse("http://" + leader)
 			if err != nil {
 				writeJsonError(w, r, http.StatusInternalServerError,
-					fmt.Errorf("Leader URL http://%s Parse Error: %v", ms.Topo.RaftServer.Leader(), err))
+					fmt.Errorf("Leader URL http://%s Parse Error: %v", leader, err))
 				return
 			}
-			glog.V(4).Infoln("proxying to leader", ms.Topo.RaftServer.Leader())
+			glog.V(4).Infoln("proxying to leader", leader)
 			proxy := httputil.NewSingleHostReverseProxy(targetUrl)
 			director := proxy.Director
 			proxy.Director = func(req *http.Request) {
@@ -147,7 +167,7 @@ func (ms *MasterServer) proxyToLeader(f func(w http.ResponseWriter, r *http.Requ
 			proxy.ServeHTTP(w, r)
 		} else {
 			//drop it to the floor
-			//writeJsonError(w, r, errors.New(ms.Topo.RaftServer.Name()+" does not know Leader yet:"+ms.Topo.RaftServer.Leader()))
+			//writeJsonError(w, r, errors.New(ms.raftServer.serverAddr+" does not know Leader yet:"+ms.raftServer.Leader()))
 		}
 	}
 }
@@ -30,6 +30,20 @@ func (ms *MasterServer) lookupVolumeId(vids []string, collection string) (volume
 					ret = append(ret, operation.Location{Url: dn.Url(), PublicUrl: dn.PublicUrl})
 				}
 				volumeLocations[vid] = operation.LookupResult{VolumeId: vid, Locations: ret}
+			} else if ecLocations, found := ms.Topo.LookupEcShards(volumeId); found {
+				// any data node holding an ec shard can serve the read
+				var ret []operation.Location
+				seen := make(map[string]bool)
+				for _, shardLocations := range ecLocations.Locations {
+					for _, dn := range shardLocations {
+						if seen[dn.Url()] {
+							continue
+						}
+						seen[dn.Url()] = true
+						ret = append(ret, operation.Location{Url: dn.Url(), PublicUrl: dn.PublicUrl})
+					}
+				}
+				volumeLocations[vid] = operation.LookupResult{VolumeId: vid, Locations: ret}
 			} else {
 				volumeLocations[vid] = operation.LookupResult{VolumeId: vid, Error: fmt.Sprintf("volumeId %s not found.", vid)}
 			}
@@ -85,8 +99,8 @@ func (ms *MasterServer) dirAssignHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	if !ms.Topo.HasWritableVolume(option) {
-		if ms.Topo.FreeSpace() <= 0 {
-			writeJsonQuiet(w, r, http.StatusNotFound, operation.AssignResult{Error: "No free volumes left!"})
+		if ms.Topo.FreeSpaceOf(option.DiskType) <= 0 {
+			writeJsonQuiet(w, r, http.StatusNotFound, operation.AssignResult{Error: "No free volumes left on " + option.DiskType.String() + " disks!"})
 			return
 		}
 		ms.vgLock.Lock()
@@ -64,6 +64,17 @@ func (ms *MasterServer) volumeVacuumHandler(w http.ResponseWriter, r *http.Reque
 	ms.dirStatusHandler(w, r)
 }
 
+func (ms *MasterServer) volumeReplicationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	repairs := []interface{}{}
+	for _, repair := range ms.Topo.ReplicationRepairs() {
+		repairs = append(repairs, repair.ToMap())
+	}
+	m := make(map[string]interface{})
+	m["Version"] = util.VERSION
+	m["Repairs"] = repairs
+	writeJsonQuiet(w, r, http.StatusOK, m)
+}
+
 func (ms *MasterServer) volumeGrowHandler(w http.ResponseWriter, r *http.Request) {
 	httpStatus := http.StatusOK
 	count := 0
@@ -75,8 +86,8 @@ func (ms *MasterServer) volumeGrowHandler(w http.ResponseWriter, r *http.Request
 	}
 	if err == nil {
 		if count, err = strconv.Atoi(r.FormValue("count")); err == nil {
-			if ms.Topo.FreeSpace() < int64(count*option.ReplicaPlacement.GetCopyCount()) {
-				err = fmt.Errorf("only %d volumes left, not enough for %d", ms.Topo.FreeSpace(), count*option.ReplicaPlacement.GetCopyCount())
+			if ms.Topo.FreeSpaceOf(option.DiskType) < int64(count*option.ReplicaPlacement.GetCopyCount()) {
+				err = fmt.Errorf("only %d volumes left on %s disks, not enough for %d", ms.Topo.FreeSpaceOf(option.DiskType), option.DiskType, count*option.ReplicaPlacement.GetCopyCount())
 			} else {
 				count, err = ms.vg.GrowByCountAndType(ms.grpcDialOpiton, count, option, ms.Topo)
 			}
@@ -142,7 +153,7 @@ func (ms *MasterServer) submitFromMasterServerHandler(w http.ResponseWriter, r *
 }
 
 func (ms *MasterServer) HasWritableVolume(option *topology.VolumeGrowOption) bool {
-	vl := ms.Topo.GetVolumeLayout(option.Collection, option.ReplicaPlacement, option.Ttl)
+	vl := ms.Topo.GetVolumeLayout(option.Collection, option.ReplicaPlacement, option.Ttl, option.DiskType)
 	return vl.GetActiveVolumeCount(option) > 0
 }
 
@@ -159,6 +170,10 @@ func (ms *MasterServer) getVolumeGrowOption(r *http.Request) (*topology.VolumeGr
 	if err != nil {
 		return nil, err
 	}
+	diskType, err := storage.ToDiskType(r.FormValue("disk"))
+	if err != nil {
+		return nil, err
+	}
 	preallocate := ms.preallocate
 	if r.FormValue("preallocate") != "" {
 		preallocate, err = strconv.ParseInt(r.FormValue("preallocate"), 10, 64)
@@ -174,6 +189,7 @@ func (ms *MasterServer) getVolumeGrowOption(r *http.Request) (*topology.VolumeGr
 		DataCenter:       r.FormValue("dataCenter"),
 		Rack:             r.FormValue("rack"),
 		DataNode:         r.FormValue("dataNode"),
+		DiskType:         diskType,
 	}
 	return volumeGrowOption, nil
 }
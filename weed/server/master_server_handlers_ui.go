@@ -3,7 +3,6 @@ package weed_server
 import (
 	"net/http"
 
-	"github.com/chrislusf/raft"
 	ui "gitlab.momenta.works/kubetrain/seaweedfs/weed/server/master_ui"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/stats"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
@@ -15,13 +14,13 @@ func (ms *MasterServer) uiStatusHandler(w http.ResponseWriter, r *http.Request)
 	args := struct {
 		Version    string
 		Topology   interface{}
-		RaftServer raft.Server
+		RaftServer *RaftServer
 		Stats      map[string]interface{}
 		Counters   *stats.ServerStats
 	}{
 		util.VERSION,
 		ms.Topo.ToMap(),
-		ms.Topo.RaftServer,
+		ms.raftServer,
 		infos,
 		serverStats,
 	}
@@ -1,134 +1,231 @@
 package weed_server
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"crypto/tls"
+	"fmt"
+	"net"
 	"os"
 	"path"
 	"reflect"
 	"sort"
 	"time"
 
-	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
-	"google.golang.org/grpc"
-
-	"github.com/chrislusf/raft"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	"github.com/hashicorp/raft-boltdb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/topology"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
 type RaftServer struct {
 	peers      []string // initial peers to join with
-	raftServer raft.Server
+	raftServer *raft.Raft
 	dataDir    string
 	serverAddr string
 	topo       *topology.Topology
-	*raft.GrpcServer
+	store      *raftboltdb.BoltStore
+	transport  *raft.NetworkTransport
 }
 
-func NewRaftServer(grpcDialOption grpc.DialOption, peers []string, serverAddr string, dataDir string, topo *topology.Topology, pulseSeconds int) *RaftServer {
+// NewRaftServer starts the raft server of the master serverAddr, identified by its http address,
+// with its transport listening on the http port + 20000, with TLS if serverTLS and clientTLS are set.
+// The peers only bootstrap a new cluster, unless joining an existing cluster. Once the raft state
+// is persisted in dataDir, the membership is only changed by the RaftAddServer and RaftRemoveServer requests.
+func NewRaftServer(peers []string, serverAddr string, bindIp string, dataDir string, topo *topology.Topology, pulseSeconds int, join bool,
+	serverTLS, clientTLS *tls.Config) (*RaftServer, error) {
 	s := &RaftServer{
 		peers:      peers,
 		serverAddr: serverAddr,
-		dataDir:    dataDir,
+		dataDir:    path.Join(dataDir, "raft"),
 		topo:       topo,
 	}
 
-	if glog.V(4) {
-		raft.SetLogLevel(2)
-	}
-
-	raft.RegisterCommand(&topology.MaxVolumeIdCommand{})
-
-	var err error
-	transporter := raft.NewGrpcTransporter(grpcDialOption)
 	glog.V(0).Infof("Starting RaftServer with %v", serverAddr)
 
-	// Clear old cluster configurations if peers are changed
-	if oldPeers, changed := isPeersChanged(s.dataDir, serverAddr, s.peers); changed {
-		glog.V(0).Infof("Peers Change: %v => %v", oldPeers, s.peers)
-		os.RemoveAll(path.Join(s.dataDir, "conf"))
-		os.RemoveAll(path.Join(s.dataDir, "log"))
-		os.RemoveAll(path.Join(s.dataDir, "snapshot"))
+	logLevel := hclog.Warn
+	if glog.V(4) {
+		logLevel = hclog.Debug
+	}
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "raft",
+		Level:  logLevel,
+		Output: os.Stderr,
+	})
+
+	if err := os.MkdirAll(s.dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("create raft dir %s: %v", s.dataDir, err)
+	}
+	store, err := raftboltdb.NewBoltStore(path.Join(s.dataDir, "raft.db"))
+	if err != nil {
+		return nil, fmt.Errorf("open raft store: %v", err)
+	}
+	s.store = store
+	snapshots, err := raft.NewFileSnapshotStoreWithLogger(s.dataDir, 3, logger)
+	if err != nil {
+		store.Close()
+		return nil, fmt.Errorf("open raft snapshot store: %v", err)
 	}
 
-	s.raftServer, err = raft.NewServer(s.serverAddr, s.dataDir, transporter, nil, topo, "")
+	raftAddress, err := util.ParseServerToRaftAddress(serverAddr)
+	if err != nil {
+		store.Close()
+		return nil, fmt.Errorf("raft address of %s: %v", serverAddr, err)
+	}
+	_, raftPort, err := net.SplitHostPort(raftAddress)
 	if err != nil {
-		glog.V(0).Infoln(err)
-		return nil
+		store.Close()
+		return nil, fmt.Errorf("raft address %s: %v", raftAddress, err)
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(bindIp, raftPort))
+	if err != nil {
+		store.Close()
+		return nil, fmt.Errorf("raft listen on %s: %v", raftPort, err)
+	}
+	if serverTLS != nil {
+		listener = tls.NewListener(listener, serverTLS)
+	}
+	s.transport = raft.NewNetworkTransportWithLogger(&raftStreamLayer{
+		Listener:  listener,
+		advertise: raftAddr(raftAddress),
+		clientTLS: clientTLS,
+	}, 3, 10*time.Second, logger)
+
+	config := raft.DefaultConfig()
+	config.LocalID = raft.ServerID(serverAddr)
+	config.Logger = logger
+	config.HeartbeatTimeout = time.Duration(pulseSeconds) * 500 * time.Millisecond
+	config.ElectionTimeout = time.Duration(pulseSeconds) * 500 * time.Millisecond
+	if config.HeartbeatTimeout < config.LeaderLeaseTimeout {
+		config.HeartbeatTimeout = config.LeaderLeaseTimeout
+		config.ElectionTimeout = config.LeaderLeaseTimeout
 	}
-	s.raftServer.SetHeartbeatInterval(500 * time.Millisecond)
-	s.raftServer.SetElectionTimeout(time.Duration(pulseSeconds) * 500 * time.Millisecond)
-	s.raftServer.Start()
 
-	for _, peer := range s.peers {
-		s.raftServer.AddPeer(peer, util.ServerToGrpcAddress(peer))
+	hasState, err := raft.HasExistingState(store, store, snapshots)
+	if err == nil {
+		s.raftServer, err = raft.NewRaft(config, topology.NewStateMachine(topo), store, store, snapshots, s.transport)
+	}
+	if err != nil {
+		s.transport.Close()
+		store.Close()
+		return nil, fmt.Errorf("start raft: %v", err)
 	}
 
-	s.GrpcServer = raft.NewGrpcServer(s.raftServer)
+	if !hasState && join {
+		glog.V(0).Infof("Waiting to be added to the cluster")
+	} else if !hasState {
+		// all peers bootstrap with the same configuration, and the later ones are ignored
+		peers := allPeers(serverAddr, s.peers)
+		glog.V(0).Infof("Initializing new cluster with %v", peers)
+		configuration := raft.Configuration{}
+		for _, peer := range peers {
+			peerRaftAddress, parseErr := util.ParseServerToRaftAddress(peer)
+			if parseErr != nil {
+				s.Shutdown()
+				return nil, fmt.Errorf("raft address of peer %s: %v", peer, parseErr)
+			}
+			configuration.Servers = append(configuration.Servers, raft.Server{
+				Suffrage: raft.Voter,
+				ID:       raft.ServerID(peer),
+				Address:  raft.ServerAddress(peerRaftAddress),
+			})
+		}
+		if err = s.raftServer.BootstrapCluster(configuration).Error(); err != nil && err != raft.ErrCantBootstrap {
+			s.Shutdown()
+			return nil, fmt.Errorf("bootstrap raft cluster: %v", err)
+		}
+	} else if oldPeers := s.AllPeers(); len(s.peers) > 0 && isPeersChanged(oldPeers, allPeers(serverAddr, s.peers)) {
+		glog.V(0).Infof("Ignoring peers %v, the cluster members are %v, use cluster.raft.add and cluster.raft.remove to change them", s.peers, oldPeers)
+	}
 
-	if s.raftServer.IsLogEmpty() && isTheFirstOne(serverAddr, s.peers) {
-		// Initialize the server by joining itself.
-		glog.V(0).Infoln("Initializing new cluster")
+	glog.V(0).Infof("current cluster leader: %v", s.Leader())
 
-		_, err := s.raftServer.Do(&raft.DefaultJoinCommand{
-			Name:             s.raftServer.Name(),
-			ConnectionString: util.ServerToGrpcAddress(s.serverAddr),
-		})
+	return s, nil
+}
 
-		if err != nil {
-			glog.V(0).Infoln(err)
-			return nil
-		}
+// Shutdown stops the raft server, and releases its transport and store
+func (s *RaftServer) Shutdown() error {
+	err := s.raftServer.Shutdown().Error()
+	s.transport.Close()
+	if closeErr := s.store.Close(); err == nil {
+		err = closeErr
 	}
+	return err
+}
 
-	glog.V(0).Infof("current cluster leader: %v", s.raftServer.Leader())
-
-	return s
+// Leader returns the http address of the leader master, or empty if unknown
+func (s *RaftServer) Leader() string {
+	_, id := s.raftServer.LeaderWithID()
+	return string(id)
 }
 
+// Peers returns the other masters in the cluster
 func (s *RaftServer) Peers() (members []string) {
-	peers := s.raftServer.Peers()
-
-	for _, p := range peers {
-		members = append(members, p.Name)
+	for _, peer := range s.AllPeers() {
+		if peer != s.serverAddr {
+			members = append(members, peer)
+		}
 	}
-
 	return
 }
 
-func isPeersChanged(dir string, self string, peers []string) (oldPeers []string, changed bool) {
-	confPath := path.Join(dir, "conf")
-	// open conf file
-	b, err := ioutil.ReadFile(confPath)
-	if err != nil {
-		return oldPeers, true
-	}
-	conf := &raft.Config{}
-	if err = json.Unmarshal(b, conf); err != nil {
-		return oldPeers, true
+// AllPeers returns all masters in the cluster, including this one
+func (s *RaftServer) AllPeers() (members []string) {
+	future := s.raftServer.GetConfiguration()
+	if err := future.Error(); err != nil {
+		glog.V(0).Infof("get raft configuration: %v", err)
+		return
 	}
-
-	for _, p := range conf.Peers {
-		oldPeers = append(oldPeers, p.Name)
+	for _, server := range future.Configuration().Servers {
+		members = append(members, string(server.ID))
 	}
-	oldPeers = append(oldPeers, self)
+	return
+}
 
-	if len(peers) == 0 && len(oldPeers) <= 1 {
-		return oldPeers, false
+// allPeers adds the master itself to the peers if missing
+func allPeers(self string, peers []string) []string {
+	for _, peer := range peers {
+		if peer == self {
+			return peers
+		}
 	}
+	return append([]string{self}, peers...)
+}
 
+func isPeersChanged(oldPeers []string, peers []string) bool {
+	oldPeers = append([]string(nil), oldPeers...)
+	peers = append([]string(nil), peers...)
 	sort.Strings(peers)
 	sort.Strings(oldPeers)
 
-	return oldPeers, !reflect.DeepEqual(peers, oldPeers)
+	return !reflect.DeepEqual(peers, oldPeers)
+}
 
+// raftStreamLayer advertises the raft address by the configured host name, instead of a resolved ip,
+// and dials the other masters with TLS if the listener is with TLS
+type raftStreamLayer struct {
+	net.Listener
+	advertise net.Addr
+	clientTLS *tls.Config
 }
 
-func isTheFirstOne(self string, peers []string) bool {
-	sort.Strings(peers)
-	if len(peers) <= 0 {
-		return true
+func (l *raftStreamLayer) Dial(address raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	if l.clientTLS != nil {
+		return tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", string(address), l.clientTLS)
 	}
-	return self == peers[0]
+	return net.DialTimeout("tcp", string(address), timeout)
+}
+
+func (l *raftStreamLayer) Addr() net.Addr {
+	return l.advertise
+}
+
+type raftAddr string
+
+func (a raftAddr) Network() string {
+	return "tcp"
+}
+
+func (a raftAddr) String() string {
+	return string(a)
 }
@@ -0,0 +1,215 @@
+package weed_server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/topology"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+// freeMasterAddress returns a master address whose raft port, the http port + 20000, is free
+func freeMasterAddress(t *testing.T) string {
+	for {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		port := l.Addr().(*net.TCPAddr).Port
+		l.Close()
+		if port > 20000 {
+			return fmt.Sprintf("127.0.0.1:%d", port-20000)
+		}
+	}
+}
+
+func startTestRaftServer(t *testing.T, peers []string, address, dir string, join bool) *RaftServer {
+	return startTestRaftServerWithTLS(t, peers, address, dir, join, nil, nil)
+}
+
+func startTestRaftServerWithTLS(t *testing.T, peers []string, address, dir string, join bool, serverTLS, clientTLS *tls.Config) *RaftServer {
+	topo := topology.NewTopology("topo", sequence.NewMemorySequencer(), 32*1024, 1)
+	s, err := NewRaftServer(peers, address, "127.0.0.1", dir, topo, 1, join, serverTLS, clientTLS)
+	if err != nil {
+		t.Fatalf("start raft server %s: %v", address, err)
+	}
+	topo.RaftServer = s.raftServer
+	return s
+}
+
+func raftAddress(t *testing.T, address string) string {
+	raftAddress, err := util.ParseServerToRaftAddress(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raftAddress
+}
+
+// testTLSConfigs creates a certificate signed by a new CA, for the masters on both sides of the connections
+func testTLSConfigs(t *testing.T) (serverTLS, clientTLS *tls.Config) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDer)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "master"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	serverTLS = &tls.Config{Certificates: []tls.Certificate{cert}, ClientCAs: pool, ClientAuth: tls.RequireAndVerifyClientCert}
+	clientTLS = &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: pool, InsecureSkipVerify: true}
+	return
+}
+
+func waitFor(t *testing.T, what string, condition func() bool) {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestRaftServerMembership(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a, b := freeMasterAddress(t), freeMasterAddress(t)
+
+	first := startTestRaftServer(t, nil, a, dir+"/a", false)
+	waitFor(t, "the first master to lead", first.topo.IsLeader)
+	for i := 0; i < 2; i++ {
+		if _, err := first.topo.NextVolumeId(); err != nil {
+			t.Fatalf("next volume id: %v", err)
+		}
+	}
+
+	// the joining master waits to be added, and then receives the raft state
+	second := startTestRaftServer(t, []string{a, b}, b, dir+"/b", true)
+	if err := first.raftServer.AddVoter(raft.ServerID(b), raft.ServerAddress(raftAddress(t, b)), 0, 0).Error(); err != nil {
+		t.Fatalf("add %s: %v", b, err)
+	}
+	waitFor(t, "the max volume id to replicate", func() bool {
+		return second.topo.GetMaxVolumeId() == storage.VolumeId(2)
+	})
+	if peers := first.Peers(); len(peers) != 1 || peers[0] != b {
+		t.Errorf("peers of %s: %v", a, peers)
+	}
+	if leader := second.Leader(); leader != a {
+		t.Errorf("leader %s, expected %s", leader, a)
+	}
+
+	if err := first.raftServer.RemoveServer(raft.ServerID(b), 0, 0).Error(); err != nil {
+		t.Fatalf("remove %s: %v", b, err)
+	}
+	if peers := first.AllPeers(); len(peers) != 1 || peers[0] != a {
+		t.Errorf("peers after removal: %v", peers)
+	}
+	second.Shutdown()
+
+	// restarting keeps the state and the membership, ignoring the different peers
+	if err := first.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	restarted := startTestRaftServer(t, []string{a, b, "127.0.0.1:3"}, a, dir+"/a", false)
+	defer restarted.Shutdown()
+	waitFor(t, "the restarted master to lead", restarted.topo.IsLeader)
+	if err := restarted.raftServer.Barrier(0).Error(); err != nil {
+		t.Fatalf("barrier: %v", err)
+	}
+	if restarted.topo.GetMaxVolumeId() != 2 {
+		t.Errorf("restarted max volume id %d, expected 2", restarted.topo.GetMaxVolumeId())
+	}
+	if peers := restarted.AllPeers(); len(peers) != 1 || peers[0] != a {
+		t.Errorf("peers after restart: %v", peers)
+	}
+	if vid, err := restarted.topo.NextVolumeId(); err != nil || vid != 3 {
+		t.Errorf("next volume id after restart %d: %v", vid, err)
+	}
+}
+
+func TestRaftServerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	serverTLS, clientTLS := testTLSConfigs(t)
+	a, b := freeMasterAddress(t), freeMasterAddress(t)
+
+	first := startTestRaftServerWithTLS(t, nil, a, dir+"/a", false, serverTLS, clientTLS)
+	defer first.Shutdown()
+	waitFor(t, "the first master to lead", first.topo.IsLeader)
+	if _, err := first.topo.NextVolumeId(); err != nil {
+		t.Fatalf("next volume id: %v", err)
+	}
+
+	// the plain connections are refused
+	conn, err := net.DialTimeout("tcp", raftAddress(t, a), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(time.Second))
+	if _, err = conn.Write([]byte{0}); err == nil {
+		_, err = conn.Read(make([]byte, 1))
+	}
+	conn.Close()
+	if err == nil {
+		t.Errorf("plain connection accepted by the raft transport with TLS")
+	}
+
+	second := startTestRaftServerWithTLS(t, []string{a, b}, b, dir+"/b", true, serverTLS, clientTLS)
+	defer second.Shutdown()
+	if err := first.raftServer.AddVoter(raft.ServerID(b), raft.ServerAddress(raftAddress(t, b)), 0, 0).Error(); err != nil {
+		t.Fatalf("add %s: %v", b, err)
+	}
+	waitFor(t, "the max volume id to replicate with TLS", func() bool {
+		return second.topo.GetMaxVolumeId() == storage.VolumeId(1)
+	})
+
+	if _, err := util.ParseServerToRaftAddress("127.0.0.1:45536"); err == nil {
+		t.Errorf("unexpected raft address of port 45536")
+	}
+}
@@ -28,13 +28,19 @@ func (vs *VolumeServer) AllocateVolume(ctx context.Context, req *volume_server_p
 
 	resp := &volume_server_pb.AllocateVolumeResponse{}
 
-	err := vs.store.AddVolume(
+	diskType, err := storage.ToDiskType(req.DiskType)
+	if err != nil {
+		return resp, err
+	}
+
+	err = vs.store.AddVolume(
 		storage.VolumeId(req.VolumeId),
 		req.Collection,
 		vs.needleMapKind,
 		req.Replication,
 		req.Ttl,
 		req.Preallocate,
+		diskType,
 	)
 
 	if err != nil {
@@ -83,7 +89,7 @@ func (vs *VolumeServer) VolumeDelete(ctx context.Context, req *volume_server_pb.
 
 	resp := &volume_server_pb.VolumeDeleteResponse{}
 
-	err := vs.store.DeleteVolume(storage.VolumeId(req.VolumeId))
+	err := vs.store.DeleteVolume(storage.VolumeId(req.VolumeId), req.AllowReadOnly)
 
 	if err != nil {
 		glog.Errorf("volume delete %v: %v", req, err)
@@ -94,3 +100,19 @@ func (vs *VolumeServer) VolumeDelete(ctx context.Context, req *volume_server_pb.
 	return resp, err
 
 }
+
+func (vs *VolumeServer) VolumeMarkReadonly(ctx context.Context, req *volume_server_pb.VolumeMarkReadonlyRequest) (*volume_server_pb.VolumeMarkReadonlyResponse, error) {
+
+	resp := &volume_server_pb.VolumeMarkReadonlyResponse{}
+
+	err := vs.store.MarkVolumeReadonly(storage.VolumeId(req.VolumeId))
+
+	if err != nil {
+		glog.Errorf("volume mark readonly %v: %v", req, err)
+	} else {
+		glog.V(2).Infof("volume mark readonly %v", req)
+	}
+
+	return resp, err
+
+}
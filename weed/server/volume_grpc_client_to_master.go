@@ -63,6 +63,7 @@ func (vs *VolumeServer) doHeartbeat(ctx context.Context, masterNode, masterGrpcA
 	}
 	glog.V(0).Infof("Heartbeat to: %v", masterNode)
 	vs.currentMaster = masterNode
+	vs.store.MasterAddress = masterNode
 
 	vs.store.Client = stream
 	defer func() { vs.store.Client = nil }()
@@ -113,6 +114,26 @@ func (vs *VolumeServer) doHeartbeat(ctx context.Context, masterNode, masterGrpcA
 				glog.V(0).Infof("Volume Server Failed to update to master %s: %v", masterNode, err)
 				return "", err
 			}
+		case ecShardMessage := <-vs.store.NewEcShardsChan:
+			deltaBeat := &master_pb.Heartbeat{
+				NewEcShards: []*master_pb.VolumeEcShardInformationMessage{
+					&ecShardMessage,
+				},
+			}
+			if err = stream.Send(deltaBeat); err != nil {
+				glog.V(0).Infof("Volume Server Failed to update to master %s: %v", masterNode, err)
+				return "", err
+			}
+		case ecShardMessage := <-vs.store.DeletedEcShardsChan:
+			deltaBeat := &master_pb.Heartbeat{
+				DeletedEcShards: []*master_pb.VolumeEcShardInformationMessage{
+					&ecShardMessage,
+				},
+			}
+			if err = stream.Send(deltaBeat); err != nil {
+				glog.V(0).Infof("Volume Server Failed to update to master %s: %v", masterNode, err)
+				return "", err
+			}
 		case <-tickChan:
 			if err = stream.Send(vs.store.CollectHeartbeat()); err != nil {
 				glog.V(0).Infof("Volume Server Failed to talk with master %s: %v", masterNode, err)
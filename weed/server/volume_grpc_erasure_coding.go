@@ -0,0 +1,285 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+const BufferSizeLimit = 1024 * 1024 * 2
+
+/*
+
+Steps to apply erasure coding to .dat .idx files
+0. ensure the volume is readonly
+1. client call VolumeEcShardsGenerate to generate the .ecx and .ec00 ~ .ec13 files
+2. client ask master for possible servers to hold the ec files, at least 4 servers
+3. client call VolumeEcShardsCopy on above target servers to copy ec files from the source server
+4. target servers report the new ec files to the master
+5.   master stores vid -> [14]*DataNode
+6. client checks master. If all 14 slices are ready, delete the original .dat, .idx files
+
+*/
+
+// VolumeEcShardsGenerate generates the .ecx and .ec00 ~ .ec13 files
+func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_server_pb.VolumeEcShardsGenerateRequest) (*volume_server_pb.VolumeEcShardsGenerateResponse, error) {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumeId))
+	if v == nil {
+		return nil, fmt.Errorf("volume %d not found", req.VolumeId)
+	}
+	baseFileName := v.FileName()
+
+	if v.Collection != req.Collection {
+		return nil, fmt.Errorf("existing collection:%v unexpected input: %v", v.Collection, req.Collection)
+	}
+
+	// write .ecx file
+	if err := erasure_coding.WriteSortedEcxFile(baseFileName); err != nil {
+		return nil, fmt.Errorf("WriteSortedEcxFile %s: %v", baseFileName, err)
+	}
+
+	// write .ec00 ~ .ec13 files
+	if err := erasure_coding.WriteEcFiles(baseFileName); err != nil {
+		return nil, fmt.Errorf("WriteEcFiles %s: %v", baseFileName, err)
+	}
+
+	return &volume_server_pb.VolumeEcShardsGenerateResponse{}, nil
+}
+
+// VolumeEcShardsRebuild generates any of the missing .ec00 ~ .ec13 files
+func (vs *VolumeServer) VolumeEcShardsRebuild(ctx context.Context, req *volume_server_pb.VolumeEcShardsRebuildRequest) (*volume_server_pb.VolumeEcShardsRebuildResponse, error) {
+
+	var rebuiltShardIds []uint32
+
+	for _, location := range vs.store.Locations {
+		baseFileName := storage.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+		if !util.FileExists(baseFileName + ".ecx") {
+			continue
+		}
+		generatedShardIds, err := erasure_coding.RebuildEcFiles(baseFileName)
+		if err != nil {
+			return nil, fmt.Errorf("RebuildEcFiles %s: %v", baseFileName, err)
+		}
+		for _, shardId := range generatedShardIds {
+			rebuiltShardIds = append(rebuiltShardIds, uint32(shardId))
+		}
+		break
+	}
+
+	return &volume_server_pb.VolumeEcShardsRebuildResponse{
+		RebuiltShardIds: rebuiltShardIds,
+	}, nil
+}
+
+// VolumeEcShardsCopy copy the .ecx and some ec data slices
+func (vs *VolumeServer) VolumeEcShardsCopy(ctx context.Context, req *volume_server_pb.VolumeEcShardsCopyRequest) (*volume_server_pb.VolumeEcShardsCopyResponse, error) {
+
+	location := vs.store.FindFreeLocation(storage.HardDriveType)
+	if location == nil {
+		return nil, fmt.Errorf("no space left")
+	}
+
+	baseFileName := storage.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+
+	err := operation.WithVolumeServerClient(req.SourceDataNode, vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+
+		// copy ec data slices
+		for _, shardId := range req.ShardIds {
+			if err := vs.doCopyEcFile(ctx, client, req, erasure_coding.ToExt(int(shardId)), baseFileName); err != nil {
+				return err
+			}
+		}
+
+		if !req.CopyEcxFile {
+			return nil
+		}
+
+		// copy ecx file
+		return vs.doCopyEcFile(ctx, client, req, ".ecx", baseFileName)
+	})
+	if err != nil {
+		// do not leave a partial copy behind
+		for _, shardId := range req.ShardIds {
+			os.Remove(baseFileName + erasure_coding.ToExt(int(shardId)))
+		}
+		if req.CopyEcxFile {
+			os.Remove(baseFileName + ".ecx")
+		}
+		return nil, fmt.Errorf("VolumeEcShardsCopy volume %d: %v", req.VolumeId, err)
+	}
+
+	return &volume_server_pb.VolumeEcShardsCopyResponse{}, nil
+}
+
+func (vs *VolumeServer) doCopyEcFile(ctx context.Context, client volume_server_pb.VolumeServerClient, req *volume_server_pb.VolumeEcShardsCopyRequest, ext string, baseFileName string) error {
+
+	copyFileClient, err := client.CopyFile(ctx, &volume_server_pb.CopyFileRequest{
+		VolumeId:   req.VolumeId,
+		Ext:        ext,
+		Collection: req.Collection,
+		IsEcVolume: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start copying ec file %d%s: %v", req.VolumeId, ext, err)
+	}
+
+	if err = writeToFile(copyFileClient, baseFileName+ext); err != nil {
+		os.Remove(baseFileName + ext)
+		return fmt.Errorf("failed to copy ec file %s%s: %v", baseFileName, ext, err)
+	}
+
+	return nil
+}
+
+// VolumeEcShardsDelete local delete the .ecx and some ec data slices if not needed
+// the shard should not be mounted before calling this.
+func (vs *VolumeServer) VolumeEcShardsDelete(ctx context.Context, req *volume_server_pb.VolumeEcShardsDeleteRequest) (*volume_server_pb.VolumeEcShardsDeleteResponse, error) {
+
+	for _, location := range vs.store.Locations {
+		baseFileName := storage.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+		if !util.FileExists(baseFileName + ".ecx") {
+			continue
+		}
+
+		glog.V(0).Infof("ec volume %d shard delete %v", req.VolumeId, req.ShardIds)
+		for _, shardId := range req.ShardIds {
+			if err := os.Remove(baseFileName + erasure_coding.ToExt(int(shardId))); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+
+		// remove the .ecx file if no more shards are left in this location
+		hasEcxFile, hasEcShards, err := checkEcVolumeStatus(location.Directory, path.Base(baseFileName))
+		if err != nil {
+			return nil, err
+		}
+		if hasEcxFile && !hasEcShards {
+			if err := os.Remove(baseFileName + ".ecx"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &volume_server_pb.VolumeEcShardsDeleteResponse{}, nil
+}
+
+func checkEcVolumeStatus(dir string, baseName string) (hasEcxFile bool, hasEcShards bool, err error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false, false, err
+	}
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if !strings.HasPrefix(name, baseName+".ec") {
+			continue
+		}
+		if name == baseName+".ecx" {
+			hasEcxFile = true
+			continue
+		}
+		hasEcShards = true
+	}
+	return
+}
+
+func (vs *VolumeServer) VolumeEcShardsMount(ctx context.Context, req *volume_server_pb.VolumeEcShardsMountRequest) (*volume_server_pb.VolumeEcShardsMountResponse, error) {
+
+	for _, shardId := range req.ShardIds {
+		err := vs.store.MountEcShards(req.Collection, storage.VolumeId(req.VolumeId), erasure_coding.ShardId(shardId))
+
+		if err != nil {
+			glog.Errorf("ec shard mount %v: %v", req, err)
+		} else {
+			glog.V(2).Infof("ec shard mount %v", req)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("mount %d.%d: %v", req.VolumeId, shardId, err)
+		}
+	}
+
+	return &volume_server_pb.VolumeEcShardsMountResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsUnmount(ctx context.Context, req *volume_server_pb.VolumeEcShardsUnmountRequest) (*volume_server_pb.VolumeEcShardsUnmountResponse, error) {
+
+	for _, shardId := range req.ShardIds {
+		err := vs.store.UnmountEcShards(storage.VolumeId(req.VolumeId), erasure_coding.ShardId(shardId))
+
+		if err != nil {
+			glog.Errorf("ec shard unmount %v: %v", req, err)
+		} else {
+			glog.V(2).Infof("ec shard unmount %v", req)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unmount %d.%d: %v", req.VolumeId, shardId, err)
+		}
+	}
+
+	return &volume_server_pb.VolumeEcShardsUnmountResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardRead(req *volume_server_pb.VolumeEcShardReadRequest, stream volume_server_pb.VolumeServer_VolumeEcShardReadServer) error {
+
+	ecVolume, found := vs.store.FindEcVolume(storage.VolumeId(req.VolumeId))
+	if !found {
+		return fmt.Errorf("not found ec volume id %d", req.VolumeId)
+	}
+	ecShard, found := ecVolume.FindEcVolumeShard(erasure_coding.ShardId(req.ShardId))
+	if !found {
+		return fmt.Errorf("not found ec shard %d.%d", req.VolumeId, req.ShardId)
+	}
+
+	bufSize := req.Size
+	if bufSize > BufferSizeLimit {
+		bufSize = BufferSizeLimit
+	}
+	buffer := make([]byte, bufSize)
+
+	startOffset, bytesToRead := req.Offset, req.Size
+
+	for bytesToRead > 0 {
+		bytesread, err := ecShard.ReadAt(buffer, startOffset)
+
+		if bytesread > 0 {
+
+			if int64(bytesread) > bytesToRead {
+				bytesread = int(bytesToRead)
+			}
+			err = stream.Send(&volume_server_pb.VolumeEcShardReadResponse{
+				Data: buffer[:bytesread],
+			})
+			if err != nil {
+				return err
+			}
+
+			bytesToRead -= int64(bytesread)
+			startOffset += int64(bytesread)
+
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			return nil
+		}
+
+	}
+
+	return nil
+
+}
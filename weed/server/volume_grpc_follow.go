@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"time"
 
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 )
 
 func (vs *VolumeServer) VolumeFollow(req *volume_server_pb.VolumeFollowRequest, stream volume_server_pb.VolumeServer_VolumeFollowServer) error {
@@ -18,19 +20,67 @@ func (vs *VolumeServer) VolumeFollow(req *volume_server_pb.VolumeFollowRequest,
 	}
 
 	stopOffset := v.Size()
-	foundOffset, isLastOne, err := v.BinarySearchByAppendAtNs(req.Since)
-	if err != nil {
-		return fmt.Errorf("fail to locate by appendAtNs %d: %s", req.Since, err)
+
+	var startOffset int64
+	if req.SinceOffset > 0 {
+		// the follower is a byte copy of this volume
+		if compactRevision := uint32(v.SuperBlock.CompactRevision); compactRevision != req.CompactRevision {
+			return fmt.Errorf("volume %d is compacted to revision %d, not %d", req.VolumeId, compactRevision, req.CompactRevision)
+		}
+		if int64(req.SinceOffset) > stopOffset {
+			return fmt.Errorf("volume %d offset %d is beyond the size %d", req.VolumeId, req.SinceOffset, stopOffset)
+		}
+		startOffset = int64(req.SinceOffset)
+	} else {
+		foundOffset, isLastOne, err := v.BinarySearchByAppendAtNs(req.Since)
+		if err != nil {
+			return fmt.Errorf("fail to locate by appendAtNs %d: %s", req.Since, err)
+		}
+
+		if isLastOne {
+			return nil
+		}
+
+		startOffset = foundOffset.ToAcutalOffset()
 	}
 
-	if isLastOne {
-		return nil
+	buf := make([]byte, 1024*1024*2)
+	return sendFileContent(v.DataBackend, buf, startOffset, stopOffset, stream)
+
+}
+
+// VolumeTailReceiver keeps appending the new needles from the source volume server,
+// until no new needles arrive within the idle timeout. The volume is a byte copy of the source volume.
+func (vs *VolumeServer) VolumeTailReceiver(ctx context.Context, req *volume_server_pb.VolumeTailReceiverRequest) (*volume_server_pb.VolumeTailReceiverResponse, error) {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumeId))
+	if v == nil {
+		return nil, fmt.Errorf("not found volume id %d", req.VolumeId)
 	}
 
-	startOffset := foundOffset.ToAcutalOffset()
+	idleTimeout := time.Duration(req.IdleTimeoutSeconds) * time.Second
+	lastProgressTime := time.Now()
+	for {
+		lastSize := v.Size()
+		if err := v.FollowFromOffset(req.SourceVolumeServer, vs.grpcDialOption); err != nil {
+			return nil, fmt.Errorf("follow volume %d from %s: %v", req.VolumeId, req.SourceVolumeServer, err)
+		}
+		if v.Size() != lastSize {
+			glog.V(1).Infof("volume %d followed %d bytes from %s", req.VolumeId, v.Size()-lastSize, req.SourceVolumeServer)
+			lastProgressTime = time.Now()
+			continue
+		}
+		if time.Now().Sub(lastProgressTime) >= idleTimeout {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
 
-	buf := make([]byte, 1024*1024*2)
-	return sendFileContent(v.DataFile(), buf, startOffset, stopOffset, stream)
+	return &volume_server_pb.VolumeTailReceiverResponse{}, nil
 
 }
 
@@ -42,15 +92,23 @@ func (vs *VolumeServer) VolumeSyncStatus(ctx context.Context, req *volume_server
 	}
 
 	resp := v.GetVolumeSyncStatus()
+	if diskType, found := vs.store.GetVolumeDiskType(v.Id); found {
+		resp.DiskType = string(diskType)
+	}
 
 	return resp, nil
 
 }
 
-func sendFileContent(datFile *os.File, buf []byte, startOffset, stopOffset int64, stream volume_server_pb.VolumeServer_VolumeFollowServer) error {
+func sendFileContent(datFile backend.BackendStorageFile, buf []byte, startOffset, stopOffset int64, stream volume_server_pb.VolumeServer_VolumeFollowServer) error {
 	var blockSizeLimit = int64(len(buf))
 	for i := int64(0); i < stopOffset-startOffset; i += blockSizeLimit {
-		n, readErr := datFile.ReadAt(buf, startOffset+i)
+		// do not read beyond the stop offset, where the needles may be partially written
+		readBuf := buf
+		if remaining := stopOffset - startOffset - i; remaining < blockSizeLimit {
+			readBuf = buf[:remaining]
+		}
+		n, readErr := datFile.ReadAt(readBuf, startOffset+i)
 		if readErr == nil || readErr == io.EOF {
 			resp := &volume_server_pb.VolumeFollowResponse{}
 			resp.FileContent = buf[:int64(n)]
@@ -10,6 +10,7 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
 func (vs *VolumeServer) ReplicateVolume(ctx context.Context, req *volume_server_pb.ReplicateVolumeRequest) (*volume_server_pb.ReplicateVolumeResponse, error) {
@@ -23,9 +24,13 @@ func (vs *VolumeServer) ReplicateVolume(ctx context.Context, req *volume_server_
 		}
 	}
 
-	location := vs.store.FindFreeLocation()
+	diskType, err := storage.ToDiskType(req.DiskType)
+	if err != nil {
+		return nil, err
+	}
+	location := vs.store.FindFreeLocation(diskType)
 	if location == nil {
-		return nil, fmt.Errorf("no space left")
+		return nil, fmt.Errorf("no space left on %s disks", diskType)
 	}
 
 	volumeFileName := storage.VolumeFileName(req.Collection, location.Directory, int(req.VolumeId))
@@ -39,7 +44,7 @@ func (vs *VolumeServer) ReplicateVolume(ctx context.Context, req *volume_server_
 	var volFileInfoResp *volume_server_pb.ReadVolumeFileStatusResponse
 	datFileName := volumeFileName + ".dat"
 	idxFileName := volumeFileName + ".idx"
-	err := operation.WithVolumeServerClient(req.SourceDataNode, vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+	err = operation.WithVolumeServerClient(req.SourceDataNode, vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
 		var err error
 		volFileInfoResp, err = client.ReadVolumeFileStatus(ctx,
 			&volume_server_pb.ReadVolumeFileStatusRequest{
@@ -49,9 +54,11 @@ func (vs *VolumeServer) ReplicateVolume(ctx context.Context, req *volume_server_
 			return fmt.Errorf("read volume file status failed, %v", err)
 		}
 
+		// copy the files up to the sizes read together, since the source volume may be written during the copy
 		copyFileClient, err := client.CopyFile(ctx, &volume_server_pb.CopyFileRequest{
-			VolumeId:  req.VolumeId,
-			IsIdxFile: true,
+			VolumeId:   req.VolumeId,
+			IsIdxFile:  true,
+			StopOffset: volFileInfoResp.IdxFileSize,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to start copying volume %d idx file: %v", req.VolumeId, err)
@@ -63,8 +70,9 @@ func (vs *VolumeServer) ReplicateVolume(ctx context.Context, req *volume_server_
 		}
 
 		copyFileClient, err = client.CopyFile(ctx, &volume_server_pb.CopyFileRequest{
-			VolumeId:  req.VolumeId,
-			IsDatFile: true,
+			VolumeId:   req.VolumeId,
+			IsDatFile:  true,
+			StopOffset: volFileInfoResp.DatFileSize,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to start copying volume %d dat file: %v", req.VolumeId, err)
@@ -87,8 +95,12 @@ func (vs *VolumeServer) ReplicateVolume(ctx context.Context, req *volume_server_
 		return nil, err
 	}
 
-	// mount the volume
-	err = vs.store.MountVolume(storage.VolumeId(req.VolumeId))
+	// mount the volume, read-only to take no writes before catching up with the source volume
+	if req.ReadOnly {
+		err = vs.store.MountVolumeReadonly(storage.VolumeId(req.VolumeId))
+	} else {
+		err = vs.store.MountVolume(storage.VolumeId(req.VolumeId))
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to mount volume %d: %v", req.VolumeId, err)
 	}
@@ -125,10 +137,12 @@ func writeToFile(client volume_server_pb.VolumeServer_CopyFileClient, fileName s
 	glog.V(4).Infof("writing to %s", fileName)
 	dst, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
-		return nil
+		return fmt.Errorf("open %s: %v", fileName, err)
 	}
 	defer dst.Close()
 
+	var written, fileSize uint64
+	var received bool
 	for {
 		resp, receiveErr := client.Recv()
 		if receiveErr == io.EOF {
@@ -137,9 +151,18 @@ func writeToFile(client volume_server_pb.VolumeServer_CopyFileClient, fileName s
 		if receiveErr != nil {
 			return fmt.Errorf("receiving %s: %v", fileName, receiveErr)
 		}
-		dst.Write(resp.FileContent)
+		if !received {
+			fileSize, received = resp.FileSize, true
+		}
+		if _, writeErr := dst.Write(resp.FileContent); writeErr != nil {
+			return fmt.Errorf("write %s: %v", fileName, writeErr)
+		}
+		written += uint64(len(resp.FileContent))
 	}
-	return nil
+	if received && written != fileSize {
+		return fmt.Errorf("copied %d bytes to %s, expected %d", written, fileName, fileSize)
+	}
+	return dst.Sync()
 }
 
 func (vs *VolumeServer) ReadVolumeFileStatus(ctx context.Context, req *volume_server_pb.ReadVolumeFileStatusRequest) (*volume_server_pb.ReadVolumeFileStatusResponse, error) {
@@ -149,9 +172,14 @@ func (vs *VolumeServer) ReadVolumeFileStatus(ctx context.Context, req *volume_se
 		return nil, fmt.Errorf("not found volume id %d", req.VolumeId)
 	}
 
+	datFileSize, idxFileSize, err := v.FileStat()
+	if err != nil {
+		return nil, err
+	}
+
 	resp.VolumeId = req.VolumeId
-	resp.DatFileSize = v.DataFileSize()
-	resp.IdxFileSize = v.IndexFileSize()
+	resp.DatFileSize = datFileSize
+	resp.IdxFileSize = idxFileSize
 	resp.DatFileTimestamp = v.LastModifiedTime()
 	resp.IdxFileTimestamp = v.LastModifiedTime()
 	resp.FileCount = v.FileCount()
@@ -160,17 +188,34 @@ func (vs *VolumeServer) ReadVolumeFileStatus(ctx context.Context, req *volume_se
 
 func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream volume_server_pb.VolumeServer_CopyFileServer) error {
 
-	v := vs.store.GetVolume(storage.VolumeId(req.VolumeId))
-	if v == nil {
-		return fmt.Errorf("not found volume id %d", req.VolumeId)
+	var fileName string
+	if !req.IsEcVolume {
+		v := vs.store.GetVolume(storage.VolumeId(req.VolumeId))
+		if v == nil {
+			return fmt.Errorf("not found volume id %d", req.VolumeId)
+		}
+		fileName = v.FileName()
+	} else {
+		// the ec files may not be mounted yet, so look for them on disk
+		for _, location := range vs.store.Locations {
+			baseFileName := storage.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+			if util.FileExists(baseFileName + req.Ext) {
+				fileName = baseFileName
+				break
+			}
+		}
+		if fileName == "" {
+			return fmt.Errorf("not found ec volume %d file %s", req.VolumeId, req.Ext)
+		}
 	}
 
 	const BufferSize = 1024 * 16
-	var fileName = v.FileName()
 	if req.IsDatFile {
 		fileName += ".dat"
 	} else if req.IsIdxFile {
 		fileName += ".idx"
+	} else {
+		fileName += req.Ext
 	}
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -178,10 +223,27 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 	}
 	defer file.Close()
 
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	fileSize := uint64(stat.Size())
+
 	buffer := make([]byte, BufferSize)
 
+	var reader io.Reader = file
+	if req.StopOffset > 0 {
+		reader = io.LimitReader(file, int64(req.StopOffset))
+		if req.StopOffset < fileSize {
+			fileSize = req.StopOffset
+		}
+	}
+
+	// the first response carries the size, so the receiver can tell a truncated copy
+	resp := &volume_server_pb.CopyFileResponse{FileSize: fileSize}
+	sent := false
 	for {
-		bytesread, err := file.Read(buffer)
+		bytesread, err := reader.Read(buffer)
 
 		if err != nil {
 			if err != io.EOF {
@@ -190,11 +252,20 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 			break
 		}
 
-		stream.Send(&volume_server_pb.CopyFileResponse{
-			FileContent: buffer[:bytesread],
-		})
+		resp.FileContent = buffer[:bytesread]
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+		resp, sent = &volume_server_pb.CopyFileResponse{}, true
 
 	}
 
+	if !sent {
+		// still tell the receiver the size of an empty file
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
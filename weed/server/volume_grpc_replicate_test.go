@@ -0,0 +1,239 @@
+package weed_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
+	"google.golang.org/grpc"
+)
+
+type testVolumeServer struct {
+	*VolumeServer
+	address    string
+	grpcServer *grpc.Server
+	done       chan bool
+}
+
+// startTestVolumeServer serves the grpc calls of a volume server without a master,
+// on a free grpc port, the http port + 10000
+func startTestVolumeServer(t *testing.T, dir string) *testVolumeServer {
+	var listener net.Listener
+	for listener == nil {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Addr().(*net.TCPAddr).Port > 10000 {
+			listener = l
+		} else {
+			l.Close()
+		}
+	}
+	port := listener.Addr().(*net.TCPAddr).Port - 10000
+
+	vs := &VolumeServer{
+		store: storage.NewStore(grpc.WithInsecure(), port, "127.0.0.1", "", []string{dir}, []int{10},
+			[]storage.DiskType{storage.HardDriveType}, storage.NeedleMapInMemory),
+		grpcDialOption: grpc.WithInsecure(),
+	}
+	s := &testVolumeServer{
+		VolumeServer: vs,
+		address:      fmt.Sprintf("127.0.0.1:%d", port),
+		grpcServer:   grpc.NewServer(),
+		done:         make(chan bool),
+	}
+
+	// no heartbeat takes the volume changes
+	go func() {
+		for {
+			select {
+			case <-vs.store.NewVolumeIdChan:
+			case <-vs.store.DeletedVolumeIdChan:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	volume_server_pb.RegisterVolumeServerServer(s.grpcServer, vs)
+	go s.grpcServer.Serve(listener)
+	return s
+}
+
+func (s *testVolumeServer) stop() {
+	s.grpcServer.Stop()
+	close(s.done)
+	s.store.Close()
+}
+
+func testNeedle(id uint64) *storage.Needle {
+	n := &storage.Needle{
+		Id:   types.Uint64ToNeedleId(id),
+		Data: []byte(fmt.Sprintf("needle %d", id)),
+	}
+	n.Checksum = storage.NewCRC(n.Data)
+	return n
+}
+
+func TestReplicateVolumeWhileWriting(t *testing.T) {
+	sourceDir, _ := ioutil.TempDir("", "source")
+	defer os.RemoveAll(sourceDir)
+	targetDir, _ := ioutil.TempDir("", "target")
+	defer os.RemoveAll(targetDir)
+
+	source := startTestVolumeServer(t, sourceDir)
+	defer source.stop()
+	target := startTestVolumeServer(t, targetDir)
+	defer target.stop()
+
+	const vid = 1
+	if err := source.store.AddVolume(vid, "", storage.NeedleMapInMemory, "000", "", 0, storage.HardDriveType); err != nil {
+		t.Fatalf("add volume: %v", err)
+	}
+
+	// keep writing until the source volume is readonly
+	var written []uint64
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for id := uint64(1); ; id++ {
+			if _, err := source.store.Write(vid, testNeedle(id)); err != nil {
+				return
+			}
+			written = append(written, id)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx := context.Background()
+	err := operation.WithVolumeServerClient(target.address, grpc.WithInsecure(), func(client volume_server_pb.VolumeServerClient) error {
+		_, replicateErr := client.ReplicateVolume(ctx, &volume_server_pb.ReplicateVolumeRequest{
+			VolumeId:       vid,
+			SourceDataNode: source.address,
+			ReadOnly:       true,
+		})
+		return replicateErr
+	})
+	if err != nil {
+		t.Fatalf("replicate volume: %v", err)
+	}
+	if _, err = target.store.Write(vid, testNeedle(1<<32)); err == nil {
+		t.Fatalf("the copy takes writes before catching up with the source")
+	}
+
+	// the writes landed after the copy
+	time.Sleep(100 * time.Millisecond)
+	if err = source.store.MarkVolumeReadonly(vid); err != nil {
+		t.Fatalf("mark source readonly: %v", err)
+	}
+	wg.Wait()
+
+	err = operation.WithVolumeServerClient(target.address, grpc.WithInsecure(), func(client volume_server_pb.VolumeServerClient) error {
+		if _, tailErr := client.VolumeTailReceiver(ctx, &volume_server_pb.VolumeTailReceiverRequest{
+			VolumeId:           vid,
+			SourceVolumeServer: source.address,
+			IdleTimeoutSeconds: 1,
+		}); tailErr != nil {
+			return tailErr
+		}
+		// reload the caught up copy from disk
+		if _, unmountErr := client.VolumeUnmount(ctx, &volume_server_pb.VolumeUnmountRequest{VolumeId: vid}); unmountErr != nil {
+			return unmountErr
+		}
+		_, mountErr := client.VolumeMount(ctx, &volume_server_pb.VolumeMountRequest{VolumeId: vid})
+		return mountErr
+	})
+	if err != nil {
+		t.Fatalf("catch up: %v", err)
+	}
+
+	if len(written) == 0 {
+		t.Fatalf("no needles written")
+	}
+	for _, id := range written {
+		n := &storage.Needle{Id: types.Uint64ToNeedleId(id)}
+		if _, readErr := target.store.ReadVolumeNeedle(vid, n); readErr != nil {
+			t.Fatalf("read needle %d of %d: %v", id, len(written), readErr)
+		}
+		if !bytes.Equal(n.Data, testNeedle(id).Data) {
+			t.Fatalf("needle %d: %q", id, n.Data)
+		}
+	}
+	if _, err = target.store.Write(vid, testNeedle(1<<32)); err != nil {
+		t.Fatalf("write the moved volume: %v", err)
+	}
+}
+
+type testCopyFileClient struct {
+	volume_server_pb.VolumeServer_CopyFileClient
+	responses []*volume_server_pb.CopyFileResponse
+	err       error
+}
+
+func (c *testCopyFileClient) Recv() (*volume_server_pb.CopyFileResponse, error) {
+	if len(c.responses) == 0 {
+		if c.err != nil {
+			return nil, c.err
+		}
+		return nil, io.EOF
+	}
+	resp := c.responses[0]
+	c.responses = c.responses[1:]
+	return resp, nil
+}
+
+func TestWriteToFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "copy")
+	defer os.RemoveAll(dir)
+	fileName := path.Join(dir, "1.ec00")
+
+	err := writeToFile(&testCopyFileClient{responses: []*volume_server_pb.CopyFileResponse{
+		{FileContent: []byte("abc"), FileSize: 5},
+		{FileContent: []byte("de")},
+	}}, fileName)
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if data, _ := ioutil.ReadFile(fileName); string(data) != "abcde" {
+		t.Errorf("copied %q", data)
+	}
+
+	if err = writeToFile(&testCopyFileClient{responses: []*volume_server_pb.CopyFileResponse{{FileSize: 0}}}, fileName); err != nil {
+		t.Errorf("copy empty file: %v", err)
+	}
+
+	// a truncated stream, a broken stream, or a file not written are errors
+	err = writeToFile(&testCopyFileClient{responses: []*volume_server_pb.CopyFileResponse{
+		{FileContent: []byte("abc"), FileSize: 5},
+	}}, fileName)
+	if err == nil {
+		t.Errorf("truncated copy succeeded")
+	}
+	err = writeToFile(&testCopyFileClient{responses: []*volume_server_pb.CopyFileResponse{
+		{FileContent: []byte("abc"), FileSize: 5},
+	}, err: fmt.Errorf("connection reset")}, fileName)
+	if err == nil {
+		t.Errorf("broken copy succeeded")
+	}
+	err = writeToFile(&testCopyFileClient{responses: []*volume_server_pb.CopyFileResponse{
+		{FileContent: []byte("abc"), FileSize: 3},
+	}}, path.Join(dir, "missing", "1.ec00"))
+	if err == nil {
+		t.Errorf("copy to a missing directory succeeded")
+	}
+}
@@ -0,0 +1,186 @@
+package weed_server
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
+)
+
+/*
+
+Steps to move a volume .dat file to a remote tier
+1. mark the volume readonly, so the .dat file does not change any more
+2. upload the .dat file to the backend storage
+3. save the backend type, id, and object key to the .vif file
+4. remount the volume, which now reads the needles from the remote .dat file
+5. optionally remove the local .dat file
+
+The .idx file always stays local.
+
+*/
+
+// VolumeTierMoveDatToRemote copies a local .dat file to a remote backend storage
+func (vs *VolumeServer) VolumeTierMoveDatToRemote(req *volume_server_pb.VolumeTierMoveDatToRemoteRequest, stream volume_server_pb.VolumeServer_VolumeTierMoveDatToRemoteServer) error {
+
+	// find existing volume
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumeId))
+	if v == nil {
+		return fmt.Errorf("volume %d not found", req.VolumeId)
+	}
+
+	// verify the collection
+	if v.Collection != req.Collection {
+		return fmt.Errorf("existing collection:%v unexpected input: %v", v.Collection, req.Collection)
+	}
+
+	// locate the disk file
+	diskFile, ok := v.DataBackend.(*backend.DiskFile)
+	if !ok || v.HasRemoteFile() {
+		return fmt.Errorf("volume %d is already on remote storage", req.VolumeId)
+	}
+
+	// check valid storage backend type
+	backendStorage, found := backend.BackendStorages[req.DestinationBackendName]
+	if !found {
+		var keys []string
+		for key := range backend.BackendStorages {
+			keys = append(keys, key)
+		}
+		return fmt.Errorf("destination %s not found, supported: %v", req.DestinationBackendName, keys)
+	}
+	backendType, backendId := backend.BackendNameToTypeId(req.DestinationBackendName)
+
+	// seal the volume
+	if err := vs.store.MarkVolumeReadonly(storage.VolumeId(req.VolumeId)); err != nil {
+		return fmt.Errorf("mark volume %d readonly: %v", req.VolumeId, err)
+	}
+
+	startTime := time.Now()
+	fn := func(progressed int64, percentage float32) error {
+		return stream.Send(&volume_server_pb.VolumeTierMoveDatToRemoteResponse{
+			Processed:           progressed,
+			ProcessedPercentage: percentage,
+		})
+	}
+
+	// copy the data file
+	key, size, err := backendStorage.CopyFile(diskFile.File, fn)
+	if err != nil {
+		return fmt.Errorf("backend %s copy file %s: %v", req.DestinationBackendName, diskFile.Name(), err)
+	}
+	glog.V(0).Infof("volume %d copied %d bytes to %s in %v", req.VolumeId, size, req.DestinationBackendName, time.Since(startTime))
+
+	// save the remote file to volume tier info
+	volumeInfo := v.GetVolumeInfo()
+	volumeInfo.Version = uint32(v.Version())
+	volumeInfo.Files = append(volumeInfo.GetFiles(), &volume_server_pb.RemoteFile{
+		BackendType:  backendType,
+		BackendId:    backendId,
+		Key:          key,
+		Offset:       0,
+		FileSize:     uint64(size),
+		ModifiedTime: uint64(time.Now().Unix()),
+		Extension:    ".dat",
+	})
+	if err := v.SaveVolumeInfo(); err != nil {
+		return fmt.Errorf("volume %d fail to save remote file info: %v", v.Id, err)
+	}
+
+	// reload the volume to read from the remote file
+	if err := vs.remountVolume(storage.VolumeId(req.VolumeId), func() error {
+		if req.KeepLocalDatFile {
+			return nil
+		}
+		return os.Remove(v.FileName() + ".dat")
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// VolumeTierMoveDatFromRemote copies a remote .dat file back to the local disk
+func (vs *VolumeServer) VolumeTierMoveDatFromRemote(req *volume_server_pb.VolumeTierMoveDatFromRemoteRequest, stream volume_server_pb.VolumeServer_VolumeTierMoveDatFromRemoteServer) error {
+
+	// find existing volume
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumeId))
+	if v == nil {
+		return fmt.Errorf("volume %d not found", req.VolumeId)
+	}
+
+	// verify the collection
+	if v.Collection != req.Collection {
+		return fmt.Errorf("existing collection:%v unexpected input: %v", v.Collection, req.Collection)
+	}
+
+	// locate the remote file
+	if !v.HasRemoteFile() {
+		return fmt.Errorf("volume %d is already on local disk", req.VolumeId)
+	}
+	storageName, storageKey := v.RemoteStorageNameKey()
+
+	// check valid storage backend type
+	backendStorage, found := backend.BackendStorages[storageName]
+	if !found {
+		return fmt.Errorf("remote storage %s not found", storageName)
+	}
+
+	startTime := time.Now()
+	fn := func(progressed int64, percentage float32) error {
+		return stream.Send(&volume_server_pb.VolumeTierMoveDatFromRemoteResponse{
+			Processed:           progressed,
+			ProcessedPercentage: percentage,
+		})
+	}
+
+	// copy the data file
+	size, err := backendStorage.DownloadFile(v.FileName()+".dat", storageKey, fn)
+	if err != nil {
+		return fmt.Errorf("backend %s download file %s: %v", storageName, storageKey, err)
+	}
+	glog.V(0).Infof("volume %d downloaded %d bytes from %s in %v", req.VolumeId, size, storageName, time.Since(startTime))
+
+	// remove the remote file info, and reload the volume to read from the local file,
+	// keeping the remote file if the volume still reads from it
+	if err := vs.remountVolume(storage.VolumeId(req.VolumeId), func() error {
+		return storage.RemoveVolumeInfo(v.FileName() + ".vif")
+	}); err != nil {
+		return err
+	}
+
+	if !req.KeepRemoteDatFile {
+		if remounted := vs.store.GetVolume(storage.VolumeId(req.VolumeId)); remounted == nil || remounted.HasRemoteFile() {
+			return fmt.Errorf("volume %d is not reading from the local file, keeping remote file %s", req.VolumeId, storageKey)
+		}
+		if err := backendStorage.DeleteFile(storageKey); err != nil {
+			return fmt.Errorf("volume %d fail to delete remote file %s: %v", v.Id, storageKey, err)
+		}
+	}
+
+	return nil
+}
+
+// remountVolume unmounts the volume, changes the files on disk, and mounts the volume again.
+// The volume is mounted again even if the changes failed, and the error of the changes is returned,
+// so the callers stop before touching the other copy of the .dat file.
+func (vs *VolumeServer) remountVolume(vid storage.VolumeId, fn func() error) error {
+	if err := vs.store.UnmountVolume(vid); err != nil {
+		return fmt.Errorf("unmount volume %d: %v", vid, err)
+	}
+	fnErr := fn()
+	if err := vs.store.MountVolume(vid); err != nil {
+		if fnErr != nil {
+			return fmt.Errorf("volume %d: %v, and mount volume: %v", vid, fnErr, err)
+		}
+		return fmt.Errorf("mount volume %d: %v", vid, err)
+	}
+	if fnErr != nil {
+		return fmt.Errorf("volume %d: %v", vid, fnErr)
+	}
+	return nil
+}
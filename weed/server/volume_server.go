@@ -7,6 +7,8 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/server/metrics"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
+	_ "gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend/s3_backend"
 
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
@@ -29,7 +31,7 @@ type VolumeServer struct {
 
 func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 	port int, publicUrl string,
-	folders []string, maxCounts []int,
+	folders []string, maxCounts []int, diskTypes []storage.DiskType,
 	needleMapKind storage.NeedleMapType,
 	masterNodes []string, pulseSeconds int,
 	dataCenter string, rack string,
@@ -37,6 +39,8 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 	fixJpgOrientation bool,
 	readRedirect bool) *VolumeServer {
 
+	LoadConfiguration("tier", false)
+
 	v := viper.GetViper()
 	signingKey := v.GetString("jwt.signing.key")
 	enableUiAccess := v.GetBool("access.ui")
@@ -52,7 +56,11 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 		grpcDialOption:    security.LoadClientTLS(viper.Sub("grpc"), "volume"),
 	}
 	vs.MasterNodes = masterNodes
-	vs.store = storage.NewStore(port, ip, publicUrl, folders, maxCounts, vs.needleMapKind)
+
+	// the remote tiers need to be ready before loading the volumes
+	backend.LoadConfiguration(v)
+
+	vs.store = storage.NewStore(vs.grpcDialOption, port, ip, publicUrl, folders, maxCounts, diskTypes, vs.needleMapKind)
 
 	vs.guard = security.NewGuard(whiteList, signingKey)
 
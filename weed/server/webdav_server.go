@@ -0,0 +1,727 @@
+package weed_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+	"google.golang.org/grpc"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/security"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/wdclient"
+)
+
+type WebDavOption struct {
+	Filer            string
+	FilerGrpcAddress string
+	GrpcDialOption   grpc.DialOption
+	Collection       string
+	Replication      string
+	ChunkSizeLimit   int64
+	Uid              uint32
+	Gid              uint32
+}
+
+type WebDavServer struct {
+	option  *WebDavOption
+	Handler *webdav.Handler
+}
+
+func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
+
+	fs, err := NewWebDavFileSystem(option)
+	if err != nil {
+		return nil, err
+	}
+
+	ws = &WebDavServer{
+		option: option,
+		Handler: &webdav.Handler{
+			FileSystem: fs,
+			LockSystem: webdav.NewMemLS(),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					glog.V(1).Infof("webdav %s %s: %v", r.Method, r.URL.Path, err)
+				} else {
+					glog.V(4).Infof("webdav %s %s", r.Method, r.URL.Path)
+				}
+			},
+		},
+	}
+
+	return ws, nil
+}
+
+type WebDavFileSystem struct {
+	option *WebDavOption
+}
+
+type FileInfo struct {
+	name         string
+	size         int64
+	mode         os.FileMode
+	modifiedTime time.Time
+	isDirectory  bool
+	mime         string
+}
+
+func (fi *FileInfo) Name() string       { return fi.name }
+func (fi *FileInfo) Size() int64        { return fi.size }
+func (fi *FileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi *FileInfo) ModTime() time.Time { return fi.modifiedTime }
+func (fi *FileInfo) IsDir() bool        { return fi.isDirectory }
+func (fi *FileInfo) Sys() interface{}   { return nil }
+
+// ContentType avoids reading the file content to detect the mime type
+func (fi *FileInfo) ContentType(ctx context.Context) (string, error) {
+	if fi.mime == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return fi.mime, nil
+}
+
+type WebDavFile struct {
+	fs             *WebDavFileSystem
+	name           string
+	isDirectory    bool
+	off            int64
+	entry          *filer_pb.Entry
+	entryViewCache []filer2.VisibleInterval
+	isDirty        bool
+	// buffered writes, saved as one chunk
+	dirtyOffset int64
+	dirtyData   []byte
+	// position when listing the directory
+	lastFileName string
+	isListed     bool
+}
+
+func NewWebDavFileSystem(option *WebDavOption) (webdav.FileSystem, error) {
+	if option.FilerGrpcAddress == "" {
+		return nil, fmt.Errorf("webdav: no filer grpc address")
+	}
+	if option.ChunkSizeLimit <= 0 {
+		return nil, fmt.Errorf("webdav: invalid chunk size limit %d", option.ChunkSizeLimit)
+	}
+	return &WebDavFileSystem{
+		option: option,
+	}, nil
+}
+
+func (fs *WebDavFileSystem) WithFilerClient(ctx context.Context, fn func(filer_pb.SeaweedFilerClient) error) error {
+
+	return util.WithCachedGrpcClient(ctx, func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		return fn(client)
+	}, fs.option.FilerGrpcAddress, fs.option.GrpcDialOption)
+
+}
+
+func clearName(name string) (string, error) {
+	if !strings.HasPrefix(name, "/") {
+		return "", os.ErrInvalid
+	}
+	return path.Clean(name), nil
+}
+
+func (fs *WebDavFileSystem) lookupEntry(ctx context.Context, fullFilePath string) (entry *filer_pb.Entry, err error) {
+
+	dir, name := filer2.FullPath(fullFilePath).DirAndName()
+
+	err = fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		}
+
+		glog.V(4).Infof("lookup directory entry: %v", request)
+		resp, err := client.LookupDirectoryEntry(ctx, request)
+		if err != nil {
+			glog.V(4).Infof("lookup %s: %v", fullFilePath, err)
+			return os.ErrNotExist
+		}
+
+		entry = resp.Entry
+
+		return nil
+	})
+
+	return
+}
+
+func (fs *WebDavFileSystem) createEntry(ctx context.Context, fullFilePath string, isDirectory bool, perm os.FileMode) error {
+
+	dir, name := filer2.FullPath(fullFilePath).DirAndName()
+
+	// the parent directory should exist
+	parentEntry, err := fs.lookupEntry(ctx, dir)
+	if err != nil {
+		return os.ErrNotExist
+	}
+	if !parentEntry.IsDirectory {
+		return os.ErrInvalid
+	}
+
+	mode := perm
+	if isDirectory {
+		mode |= os.ModeDir
+	}
+	now := time.Now().Unix()
+
+	return fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.CreateEntryRequest{
+			Directory: dir,
+			Entry: &filer_pb.Entry{
+				Name:        name,
+				IsDirectory: isDirectory,
+				Attributes: &filer_pb.FuseAttributes{
+					Mtime:       now,
+					Crtime:      now,
+					FileMode:    uint32(mode),
+					Uid:         fs.option.Uid,
+					Gid:         fs.option.Gid,
+					Collection:  fs.option.Collection,
+					Replication: fs.option.Replication,
+				},
+			},
+		}
+
+		glog.V(3).Infof("webdav create: %v", request)
+		if _, err := client.CreateEntry(ctx, request); err != nil {
+			return fmt.Errorf("create %s: %v", fullFilePath, err)
+		}
+
+		return nil
+	})
+}
+
+func (fs *WebDavFileSystem) updateEntry(ctx context.Context, fullFilePath string, entry *filer_pb.Entry) error {
+
+	dir, name := filer2.FullPath(fullFilePath).DirAndName()
+	entry.Name = name
+
+	return fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory: dir,
+			Entry:     entry,
+		}
+
+		glog.V(3).Infof("webdav update: %v", request)
+		if _, err := client.UpdateEntry(ctx, request); err != nil {
+			return fmt.Errorf("update %s: %v", fullFilePath, err)
+		}
+
+		return nil
+	})
+}
+
+func (fs *WebDavFileSystem) Mkdir(ctx context.Context, fullDirPath string, perm os.FileMode) error {
+
+	glog.V(2).Infof("WebDavFileSystem.Mkdir %v", fullDirPath)
+
+	fullDirPath, err := clearName(fullDirPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fs.lookupEntry(ctx, fullDirPath); err == nil {
+		return os.ErrExist
+	}
+
+	return fs.createEntry(ctx, fullDirPath, true, perm)
+}
+
+func (fs *WebDavFileSystem) OpenFile(ctx context.Context, fullFilePath string, flag int, perm os.FileMode) (webdav.File, error) {
+
+	glog.V(2).Infof("WebDavFileSystem.OpenFile %v %x", fullFilePath, flag)
+
+	fullFilePath, err := clearName(fullFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag&os.O_CREATE != 0 {
+		existingEntry, lookupErr := fs.lookupEntry(ctx, fullFilePath)
+		if lookupErr == nil {
+			if flag&os.O_EXCL != 0 {
+				return nil, os.ErrExist
+			}
+			if existingEntry.IsDirectory {
+				return nil, fmt.Errorf("%s is a directory", fullFilePath)
+			}
+			if flag&os.O_TRUNC != 0 {
+				// the old chunks are deleted by the filer
+				existingEntry.Chunks = nil
+				existingEntry.Attributes.Mtime = time.Now().Unix()
+				if err = fs.updateEntry(ctx, fullFilePath, existingEntry); err != nil {
+					return nil, err
+				}
+			}
+		} else if err = fs.createEntry(ctx, fullFilePath, false, perm); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := fs.lookupEntry(ctx, fullFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebDavFile{
+		fs:          fs,
+		name:        fullFilePath,
+		isDirectory: entry.IsDirectory,
+		entry:       entry,
+	}, nil
+
+}
+
+func (fs *WebDavFileSystem) RemoveAll(ctx context.Context, name string) error {
+
+	glog.V(2).Infof("WebDavFileSystem.RemoveAll %v", name)
+
+	fullFilePath, err := clearName(name)
+	if err != nil {
+		return err
+	}
+	if fullFilePath == "/" {
+		return os.ErrPermission
+	}
+
+	dir, fileName := filer2.FullPath(fullFilePath).DirAndName()
+
+	return fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.DeleteEntryRequest{
+			Directory:    dir,
+			Name:         fileName,
+			IsDeleteData: true,
+			IsRecursive:  true,
+		}
+
+		glog.V(3).Infof("webdav delete: %v", request)
+		if _, err := client.DeleteEntry(ctx, request); err != nil {
+			return fmt.Errorf("delete %s: %v", fullFilePath, err)
+		}
+
+		return nil
+	})
+
+}
+
+func (fs *WebDavFileSystem) Rename(ctx context.Context, oldName, newName string) error {
+
+	glog.V(2).Infof("WebDavFileSystem.Rename %v to %v", oldName, newName)
+
+	var err error
+	if oldName, err = clearName(oldName); err != nil {
+		return err
+	}
+	if newName, err = clearName(newName); err != nil {
+		return err
+	}
+
+	if oldName == "/" {
+		return os.ErrPermission
+	}
+
+	if _, err = fs.lookupEntry(ctx, oldName); err != nil {
+		return err
+	}
+	if _, err = fs.lookupEntry(ctx, newName); err == nil {
+		return os.ErrExist
+	}
+
+	oldDir, oldBaseName := filer2.FullPath(oldName).DirAndName()
+	newDir, newBaseName := filer2.FullPath(newName).DirAndName()
+
+	return fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDir,
+			OldName:      oldBaseName,
+			NewDirectory: newDir,
+			NewName:      newBaseName,
+		}
+
+		glog.V(3).Infof("webdav rename: %v", request)
+		if _, err := client.AtomicRenameEntry(ctx, request); err != nil {
+			return fmt.Errorf("rename %s => %s: %v", oldName, newName, err)
+		}
+
+		return nil
+	})
+
+}
+
+func (fs *WebDavFileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+
+	glog.V(2).Infof("WebDavFileSystem.Stat %v", name)
+
+	fullFilePath, err := clearName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := fs.lookupEntry(ctx, fullFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFileInfo(path.Base(fullFilePath), entry, int64(filer2.TotalSize(entry.Chunks))), nil
+}
+
+func toFileInfo(name string, entry *filer_pb.Entry, size int64) *FileInfo {
+	fi := &FileInfo{
+		name:        name,
+		size:        size,
+		isDirectory: entry.IsDirectory,
+	}
+	if entry.Attributes != nil {
+		fi.mode = os.FileMode(entry.Attributes.FileMode)
+		fi.modifiedTime = time.Unix(entry.Attributes.Mtime, 0)
+		fi.mime = entry.Attributes.Mime
+	}
+	return fi
+}
+
+func (f *WebDavFile) size() int64 {
+	size := int64(filer2.TotalSize(f.entry.Chunks))
+	if dirtyEnd := f.dirtyOffset + int64(len(f.dirtyData)); len(f.dirtyData) > 0 && dirtyEnd > size {
+		size = dirtyEnd
+	}
+	return size
+}
+
+func (f *WebDavFile) Write(buf []byte) (int, error) {
+
+	glog.V(3).Infof("WebDavFileSystem.Write %v [%d,%d)", f.name, f.off, f.off+int64(len(buf)))
+
+	if f.isDirectory {
+		return 0, os.ErrInvalid
+	}
+
+	// only continuous writes are buffered together
+	if len(f.dirtyData) > 0 && f.off != f.dirtyOffset+int64(len(f.dirtyData)) {
+		if err := f.flushDirtyData(context.Background()); err != nil {
+			return 0, err
+		}
+	}
+	if len(f.dirtyData) == 0 {
+		f.dirtyOffset = f.off
+	}
+
+	f.dirtyData = append(f.dirtyData, buf...)
+	f.off += int64(len(buf))
+
+	if int64(len(f.dirtyData)) >= f.fs.option.ChunkSizeLimit {
+		if err := f.flushDirtyData(context.Background()); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(buf), nil
+}
+
+func (f *WebDavFile) flushDirtyData(ctx context.Context) error {
+
+	if len(f.dirtyData) == 0 {
+		return nil
+	}
+
+	chunk, err := f.saveDataAsChunk(ctx, f.dirtyData, f.dirtyOffset)
+	if err != nil {
+		return err
+	}
+
+	f.entry.Chunks = append(f.entry.Chunks, chunk)
+	f.entryViewCache = nil
+	f.isDirty = true
+	f.dirtyData = f.dirtyData[:0]
+
+	return nil
+}
+
+func (f *WebDavFile) saveDataAsChunk(ctx context.Context, data []byte, offset int64) (*filer_pb.FileChunk, error) {
+
+	var fileId, host string
+	var auth security.EncodedJwt
+
+	if err := f.fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AssignVolumeRequest{
+			Count:       1,
+			Replication: f.fs.option.Replication,
+			Collection:  f.fs.option.Collection,
+		}
+
+		resp, err := client.AssignVolume(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("assign volume failure %v: %v", request, err)
+			return err
+		}
+
+		fileId, host, auth = resp.FileId, resp.Url, security.EncodedJwt(resp.Auth)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("filerGrpcAddress assign volume: %v", err)
+	}
+
+	fileUrl := fmt.Sprintf("http://%s/%s", host, fileId)
+	uploadResult, err := operation.Upload(fileUrl, path.Base(f.name), bytes.NewReader(data), false, "application/octet-stream", nil, auth)
+	if err != nil {
+		glog.V(0).Infof("upload data %v to %s: %v", f.name, fileUrl, err)
+		return nil, fmt.Errorf("upload data: %v", err)
+	}
+	if uploadResult.Error != "" {
+		glog.V(0).Infof("upload failure %v to %s: %v", f.name, fileUrl, uploadResult.Error)
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+
+	return &filer_pb.FileChunk{
+		FileId: fileId,
+		Offset: offset,
+		Size:   uint64(len(data)),
+		Mtime:  time.Now().UnixNano(),
+		ETag:   uploadResult.ETag,
+	}, nil
+}
+
+func (f *WebDavFile) Close() error {
+
+	glog.V(2).Infof("WebDavFileSystem.Close %v", f.name)
+
+	if f.isDirectory {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	if err := f.flushDirtyData(ctx); err != nil {
+		return err
+	}
+
+	if !f.isDirty {
+		return nil
+	}
+	f.isDirty = false
+
+	f.entry.Attributes.Mtime = time.Now().Unix()
+	return f.fs.updateEntry(ctx, f.name, f.entry)
+}
+
+func (f *WebDavFile) Read(p []byte) (readSize int, err error) {
+
+	glog.V(3).Infof("WebDavFileSystem.Read %v [%d,%d)", f.name, f.off, f.off+int64(len(p)))
+
+	if f.isDirectory {
+		return 0, os.ErrInvalid
+	}
+
+	ctx := context.Background()
+
+	fileSize := f.size()
+	if f.off >= fileSize {
+		return 0, io.EOF
+	}
+	if remaining := fileSize - f.off; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	if f.entryViewCache == nil {
+		f.entryViewCache = filer2.NonOverlappingVisibleIntervals(f.entry.Chunks)
+	}
+	chunkViews := filer2.ViewFromVisibleIntervals(f.entryViewCache, f.off, len(p))
+
+	// the holes are read as zeros
+	for i := range p {
+		p[i] = 0
+	}
+
+	if err = f.readChunkViews(ctx, chunkViews, p, f.off); err != nil {
+		return 0, err
+	}
+
+	// the buffered writes are newer than the chunks, and only saved on Close
+	f.readDirtyData(p, f.off)
+
+	f.off += int64(len(p))
+
+	return len(p), nil
+}
+
+func (f *WebDavFile) readDirtyData(buff []byte, offset int64) {
+	start, stop := offset, offset+int64(len(buff))
+	if dirtyStart := f.dirtyOffset; dirtyStart > start {
+		start = dirtyStart
+	}
+	if dirtyStop := f.dirtyOffset + int64(len(f.dirtyData)); dirtyStop < stop {
+		stop = dirtyStop
+	}
+	if start < stop {
+		copy(buff[start-offset:stop-offset], f.dirtyData[start-f.dirtyOffset:stop-f.dirtyOffset])
+	}
+}
+
+func (f *WebDavFile) readChunkViews(ctx context.Context, chunkViews []*filer2.ChunkView, buff []byte, offset int64) error {
+
+	if len(chunkViews) == 0 {
+		return nil
+	}
+
+	var vids []string
+	for _, chunkView := range chunkViews {
+		vids = append(vids, volumeId(chunkView.FileId))
+	}
+
+	vid2Locations := make(map[string]*filer_pb.Locations)
+
+	err := f.fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+		glog.V(4).Infof("read lookup volume id locations: %v", vids)
+		resp, err := client.LookupVolume(ctx, &filer_pb.LookupVolumeRequest{
+			VolumeIds: vids,
+		})
+		if err != nil {
+			return err
+		}
+
+		vid2Locations = resp.LocationsMap
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lookup volume ids %v: %v", vids, err)
+	}
+
+	for _, chunkView := range chunkViews {
+
+		locations := vid2Locations[volumeId(chunkView.FileId)]
+		if locations == nil || len(locations.Locations) == 0 {
+			return fmt.Errorf("failed to locate %s", chunkView.FileId)
+		}
+
+		_, err = wdclient.ReadFromLocations(wdclient.FromFilerLocations(locations), "",
+			chunkView.FileId,
+			chunkView.Offset,
+			int(chunkView.Size),
+			buff[chunkView.LogicOffset-offset:chunkView.LogicOffset-offset+int64(chunkView.Size)],
+			!chunkView.IsFullChunk)
+		if err != nil {
+			glog.V(0).Infof("%s read %s: %v", f.name, chunkView.FileId, err)
+			return fmt.Errorf("failed to read %s: %v", chunkView.FileId, err)
+		}
+	}
+
+	return nil
+}
+
+func (f *WebDavFile) Readdir(count int) (ret []os.FileInfo, err error) {
+
+	glog.V(2).Infof("WebDavFileSystem.Readdir %v count %d", f.name, count)
+
+	if !f.isDirectory {
+		return nil, os.ErrInvalid
+	}
+
+	ctx := context.Background()
+
+	dir := f.name
+
+	for !f.isListed && (count <= 0 || len(ret) < count) {
+
+		limit := 1024
+		if count > 0 && count-len(ret) < limit {
+			limit = count - len(ret)
+		}
+
+		var entries []*filer_pb.Entry
+		err = f.fs.WithFilerClient(ctx, func(client filer_pb.SeaweedFilerClient) error {
+
+			request := &filer_pb.ListEntriesRequest{
+				Directory:         dir,
+				StartFromFileName: f.lastFileName,
+				Limit:             uint32(limit),
+			}
+
+			glog.V(4).Infof("read directory: %v", request)
+			resp, err := client.ListEntries(ctx, request)
+			if err != nil {
+				return err
+			}
+
+			entries = resp.Entries
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %v", dir, err)
+		}
+
+		for _, entry := range entries {
+			ret = append(ret, toFileInfo(entry.Name, entry, int64(filer2.TotalSize(entry.Chunks))))
+			f.lastFileName = entry.Name
+		}
+
+		if len(entries) < limit {
+			f.isListed = true
+		}
+	}
+
+	if count > 0 && len(ret) == 0 {
+		return nil, io.EOF
+	}
+
+	return ret, nil
+}
+
+func (f *WebDavFile) Seek(offset int64, whence int) (int64, error) {
+
+	glog.V(3).Infof("WebDavFile.Seek %v %v %v", f.name, offset, whence)
+
+	var err error
+	switch whence {
+	case io.SeekStart:
+		f.off = 0
+	case io.SeekEnd:
+		f.off = f.size()
+	}
+	f.off += offset
+	if f.off < 0 {
+		f.off = 0
+		err = os.ErrInvalid
+	}
+
+	return f.off, err
+}
+
+func (f *WebDavFile) Stat() (os.FileInfo, error) {
+
+	glog.V(2).Infof("WebDavFile.Stat %v", f.name)
+
+	return toFileInfo(path.Base(f.name), f.entry, f.size()), nil
+}
+
+func volumeId(fileId string) string {
+	lastCommaIndex := strings.LastIndex(fileId, ",")
+	if lastCommaIndex > 0 {
+		return fileId[:lastCommaIndex]
+	}
+	return fileId
+}
@@ -0,0 +1,342 @@
+package weed_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/webdav"
+	"google.golang.org/grpc"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/filer2"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+// testWebDavFiler keeps the entries in memory, and the chunks uploaded to its volume server
+type testWebDavFiler struct {
+	filer_pb.SeaweedFilerServer // the calls not used by the tests are not implemented
+
+	lock         sync.Mutex
+	entries      map[string]*filer_pb.Entry
+	chunks       map[string][]byte
+	volumeServer *httptest.Server
+	grpcServer   *grpc.Server
+}
+
+func newTestWebDavFileSystem(t *testing.T) (*testWebDavFiler, webdav.FileSystem) {
+
+	filer := &testWebDavFiler{
+		entries: map[string]*filer_pb.Entry{"/": {IsDirectory: true, Attributes: &filer_pb.FuseAttributes{}}},
+		chunks:  make(map[string][]byte),
+	}
+	filer.volumeServer = httptest.NewServer(http.HandlerFunc(filer.serveChunk))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	filer.grpcServer = grpc.NewServer()
+	filer_pb.RegisterSeaweedFilerServer(filer.grpcServer, filer)
+	go filer.grpcServer.Serve(listener)
+
+	fs, err := NewWebDavFileSystem(&WebDavOption{
+		FilerGrpcAddress: listener.Addr().String(),
+		GrpcDialOption:   grpc.WithInsecure(),
+		ChunkSizeLimit:   1024,
+	})
+	if err != nil {
+		filer.close()
+		t.Fatalf("new webdav file system: %v", err)
+	}
+	return filer, fs
+}
+
+func (f *testWebDavFiler) close() {
+	f.grpcServer.Stop()
+	f.volumeServer.Close()
+}
+
+func (f *testWebDavFiler) uploadCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.chunks)
+}
+
+func (f *testWebDavFiler) serveChunk(w http.ResponseWriter, r *http.Request) {
+	fileId := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case "POST":
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(file)
+		f.lock.Lock()
+		f.chunks[fileId] = data
+		f.lock.Unlock()
+		fmt.Fprintf(w, `{"size":%d}`, len(data))
+	case "GET":
+		f.lock.Lock()
+		data, found := f.chunks[fileId]
+		f.lock.Unlock()
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}
+}
+
+func (f *testWebDavFiler) find(fullpath string) *filer_pb.Entry {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if entry, found := f.entries[fullpath]; found {
+		return proto.Clone(entry).(*filer_pb.Entry)
+	}
+	return nil
+}
+
+func (f *testWebDavFiler) save(dir string, entry *filer_pb.Entry) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.entries[path.Join(dir, entry.Name)] = proto.Clone(entry).(*filer_pb.Entry)
+}
+
+func (f *testWebDavFiler) LookupDirectoryEntry(ctx context.Context, req *filer_pb.LookupDirectoryEntryRequest) (*filer_pb.LookupDirectoryEntryResponse, error) {
+	entry := f.find(path.Join(req.Directory, req.Name))
+	if entry == nil {
+		return nil, fmt.Errorf("%s not found under %s: %v", req.Name, req.Directory, filer2.ErrNotFound)
+	}
+	return &filer_pb.LookupDirectoryEntryResponse{Entry: entry}, nil
+}
+
+func (f *testWebDavFiler) ListEntries(ctx context.Context, req *filer_pb.ListEntriesRequest) (*filer_pb.ListEntriesResponse, error) {
+	f.lock.Lock()
+	var names []string
+	for p := range f.entries {
+		if p != "/" && path.Dir(p) == req.Directory && path.Base(p) > req.StartFromFileName {
+			names = append(names, path.Base(p))
+		}
+	}
+	f.lock.Unlock()
+	sort.Strings(names)
+	resp := &filer_pb.ListEntriesResponse{}
+	for _, name := range names {
+		if req.Limit > 0 && len(resp.Entries) >= int(req.Limit) {
+			break
+		}
+		resp.Entries = append(resp.Entries, f.find(path.Join(req.Directory, name)))
+	}
+	return resp, nil
+}
+
+func (f *testWebDavFiler) CreateEntry(ctx context.Context, req *filer_pb.CreateEntryRequest) (*filer_pb.CreateEntryResponse, error) {
+	f.save(req.Directory, req.Entry)
+	return &filer_pb.CreateEntryResponse{}, nil
+}
+
+func (f *testWebDavFiler) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntryRequest) (*filer_pb.UpdateEntryResponse, error) {
+	f.save(req.Directory, req.Entry)
+	return &filer_pb.UpdateEntryResponse{}, nil
+}
+
+func (f *testWebDavFiler) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntryRequest) (*filer_pb.DeleteEntryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	fullpath := path.Join(req.Directory, req.Name)
+	for p := range f.entries {
+		if p == fullpath || strings.HasPrefix(p, fullpath+"/") {
+			delete(f.entries, p)
+		}
+	}
+	return &filer_pb.DeleteEntryResponse{}, nil
+}
+
+func (f *testWebDavFiler) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	oldPath, newPath := path.Join(req.OldDirectory, req.OldName), path.Join(req.NewDirectory, req.NewName)
+	for p, entry := range f.entries {
+		if p == oldPath || strings.HasPrefix(p, oldPath+"/") {
+			delete(f.entries, p)
+			if p == oldPath {
+				entry.Name = req.NewName
+			}
+			f.entries[newPath+p[len(oldPath):]] = entry
+		}
+	}
+	return &filer_pb.AtomicRenameEntryResponse{}, nil
+}
+
+func (f *testWebDavFiler) AssignVolume(ctx context.Context, req *filer_pb.AssignVolumeRequest) (*filer_pb.AssignVolumeResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return &filer_pb.AssignVolumeResponse{
+		FileId: fmt.Sprintf("1,%x", len(f.chunks)+1),
+		Url:    strings.TrimPrefix(f.volumeServer.URL, "http://"),
+	}, nil
+}
+
+func (f *testWebDavFiler) LookupVolume(ctx context.Context, req *filer_pb.LookupVolumeRequest) (*filer_pb.LookupVolumeResponse, error) {
+	resp := &filer_pb.LookupVolumeResponse{LocationsMap: make(map[string]*filer_pb.Locations)}
+	for _, vid := range req.VolumeIds {
+		resp.LocationsMap[vid] = &filer_pb.Locations{Locations: []*filer_pb.Location{
+			{Url: strings.TrimPrefix(f.volumeServer.URL, "http://")},
+		}}
+	}
+	return resp, nil
+}
+
+func readAll(t *testing.T, file webdav.File) string {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("seek: %v", err)
+	}
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return string(data)
+}
+
+func TestWebDavReadBufferedWrites(t *testing.T) {
+
+	filer, fs := newTestWebDavFileSystem(t)
+	defer filer.close()
+	ctx := context.Background()
+
+	file, err := fs.OpenFile(ctx, "/data", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	for _, s := range []string{"hello", " world"} {
+		if _, err = file.Write([]byte(s)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	// the buffered writes are read without saving them as chunks
+	if data := readAll(t, file); data != "hello world" {
+		t.Errorf("read %q", data)
+	}
+	if data := readAll(t, file); data != "hello world" {
+		t.Errorf("read again %q", data)
+	}
+	if count := filer.uploadCount(); count != 0 {
+		t.Errorf("read saved %d chunks", count)
+	}
+
+	// a write elsewhere saves the buffered writes, and is read over the saved chunk
+	file.Seek(6, io.SeekStart)
+	if _, err = file.Write([]byte("there")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if data := readAll(t, file); data != "hello there" {
+		t.Errorf("read %q", data)
+	}
+	if count := filer.uploadCount(); count != 1 {
+		t.Errorf("saved %d chunks", count)
+	}
+
+	if err = file.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if entry := filer.find("/data"); entry == nil || len(entry.Chunks) != 2 {
+		t.Fatalf("saved entry %v", entry)
+	}
+
+	file, err = fs.OpenFile(ctx, "/data", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer file.Close()
+	if data := readAll(t, file); data != "hello there" {
+		t.Errorf("read saved %q", data)
+	}
+	if info, _ := file.Stat(); info.Size() != int64(len("hello there")) {
+		t.Errorf("size %d", info.Size())
+	}
+}
+
+func TestWebDavDirectories(t *testing.T) {
+
+	filer, fs := newTestWebDavFileSystem(t)
+	defer filer.close()
+	ctx := context.Background()
+
+	if err := fs.Mkdir(ctx, "/dir", 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := fs.Mkdir(ctx, "/dir", 0755); err != os.ErrExist {
+		t.Errorf("mkdir existing: %v", err)
+	}
+	if _, err := fs.OpenFile(ctx, "/missing/a", os.O_RDWR|os.O_CREATE, 0644); err == nil {
+		t.Errorf("created a file without the parent directory")
+	}
+	for _, name := range []string{"/dir/b", "/dir/a"} {
+		file, err := fs.OpenFile(ctx, name, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		file.Close()
+	}
+
+	dir, err := fs.OpenFile(ctx, "/dir", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("open dir: %v", err)
+	}
+	infos, err := dir.Readdir(1)
+	if err != nil || len(infos) != 1 || infos[0].Name() != "a" {
+		t.Errorf("read dir %v: %v", infos, err)
+	}
+	if infos, err = dir.Readdir(0); err != nil || len(infos) != 1 || infos[0].Name() != "b" {
+		t.Errorf("read dir remaining %v: %v", infos, err)
+	}
+	dir.Close()
+
+	if err = fs.Rename(ctx, "/dir", "/moved"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if info, err := fs.Stat(ctx, "/moved/a"); err != nil || info.IsDir() {
+		t.Errorf("stat moved file %v: %v", info, err)
+	}
+	if _, err = fs.Stat(ctx, "/dir"); !os.IsNotExist(err) {
+		t.Errorf("stat old dir: %v", err)
+	}
+
+	if err = fs.RemoveAll(ctx, "/moved"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err = fs.Stat(ctx, "/moved/a"); !os.IsNotExist(err) {
+		t.Errorf("stat removed file: %v", err)
+	}
+	if err = fs.RemoveAll(ctx, "/"); err != os.ErrPermission {
+		t.Errorf("remove root: %v", err)
+	}
+}
+
+func TestNewWebDavServer(t *testing.T) {
+
+	if _, err := NewWebDavServer(&WebDavOption{ChunkSizeLimit: 1024}); err == nil {
+		t.Errorf("created without the filer grpc address")
+	}
+	if _, err := NewWebDavServer(&WebDavOption{FilerGrpcAddress: "localhost:18888"}); err == nil {
+		t.Errorf("created without the chunk size limit")
+	}
+	if _, err := NewWebDavServer(&WebDavOption{FilerGrpcAddress: "localhost:18888", ChunkSizeLimit: 1024}); err != nil {
+		t.Errorf("create: %v", err)
+	}
+}
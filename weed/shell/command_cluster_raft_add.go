@@ -0,0 +1,67 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+)
+
+func init() {
+	commands = append(commands, &commandRaftServerAdd{})
+}
+
+type commandRaftServerAdd struct {
+}
+
+func (c *commandRaftServerAdd) Name() string {
+	return "cluster.raft.add"
+}
+
+func (c *commandRaftServerAdd) Help() string {
+	return `add a master to the raft cluster
+
+	cluster.raft.add -id=<master_host:port> [-address=<raft_host:port>] [-voter=true]
+
+	The id is the http address of the new master, and its raft transport listens on the http port + 20000
+	if the address is not specified.
+
+	Start the new master with -raftJoin, so it waits to be added here instead of bootstrapping a new cluster.
+	The raft state of the cluster is replicated to the new master, without restarting the other masters.
+
+`
+}
+
+func (c *commandRaftServerAdd) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	addCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	serverId := addCommand.String("id", "", "the http address of the master <host>:<port>")
+	serverAddress := addCommand.String("address", "", "the raft address of the master <host>:<port>, default to the http port + 20000")
+	serverVoter := addCommand.Bool("voter", true, "whether the master votes in the leader election")
+	if err = addCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *serverId == "" {
+		return fmt.Errorf("missing -id")
+	}
+
+	ctx := context.Background()
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		_, err := client.RaftAddServer(ctx, &master_pb.RaftAddServerRequest{
+			Id:      *serverId,
+			Address: *serverAddress,
+			Voter:   *serverVoter,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "added master %s\n", *serverId)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+)
+
+func init() {
+	commands = append(commands, &commandRaftClusterPs{})
+}
+
+type commandRaftClusterPs struct {
+}
+
+func (c *commandRaftClusterPs) Name() string {
+	return "cluster.raft.ps"
+}
+
+func (c *commandRaftClusterPs) Help() string {
+	return `print the masters in the raft cluster
+
+	cluster.raft.ps
+
+`
+}
+
+func (c *commandRaftClusterPs) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	var resp *master_pb.RaftListClusterServersResponse
+	ctx := context.Background()
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		resp, err = client.RaftListClusterServers(ctx, &master_pb.RaftListClusterServersRequest{})
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, server := range resp.ClusterServers {
+		leader := ""
+		if server.IsLeader {
+			leader = " leader"
+		}
+		fmt.Fprintf(writer, "id:%s address:%s suffrage:%s%s\n", server.Id, server.Address, server.Suffrage, leader)
+	}
+
+	fmt.Fprintf(writer, "Total %d masters.\n", len(resp.ClusterServers))
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+)
+
+func init() {
+	commands = append(commands, &commandRaftServerRemove{})
+}
+
+type commandRaftServerRemove struct {
+}
+
+func (c *commandRaftServerRemove) Name() string {
+	return "cluster.raft.remove"
+}
+
+func (c *commandRaftServerRemove) Help() string {
+	return `remove a master from the raft cluster
+
+	cluster.raft.remove -id=<master_host:port>
+
+	The removed master stops taking part in the raft cluster, and can be shut down afterwards.
+	If the leader is removed, the remaining masters elect a new leader.
+
+`
+}
+
+func (c *commandRaftServerRemove) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	removeCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	serverId := removeCommand.String("id", "", "the http address of the master <host>:<port>")
+	if err = removeCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *serverId == "" {
+		return fmt.Errorf("missing -id")
+	}
+
+	ctx := context.Background()
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		_, err := client.RaftRemoveServer(ctx, &master_pb.RaftRemoveServerRequest{
+			Id: *serverId,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "removed master %s\n", *serverId)
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandEcEncode{})
+}
+
+type commandEcEncode struct {
+}
+
+func (c *commandEcEncode) Name() string {
+	return "ec.encode"
+}
+
+func (c *commandEcEncode) Help() string {
+	return `apply erasure coding to a volume
+
+	This command will:
+	1. mark all replicas of the volume readonly
+	2. generate the ec shards .ec00 ~ .ec13 and the .ecx index on one replica
+	3. spread the ec shards across the volume servers with the most free slots, and mount them
+	4. remove the original volume replicas
+
+	ec.encode -volumeId=<volume_id>
+
+	Note:
+		* the volume should be full or not written to any more, since it becomes readonly.
+		* the ec shards take about 1.4 times the volume size, spread over up to 14 volume servers.
+
+`
+}
+
+func (c *commandEcEncode) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	encodeCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeId := encodeCommand.Int("volumeId", 0, "the volume id")
+	if err = encodeCommand.Parse(args); err != nil {
+		return nil
+	}
+	if *volumeId == 0 {
+		return fmt.Errorf("missing -volumeId")
+	}
+	vid := uint32(*volumeId)
+
+	ctx := context.Background()
+
+	var resp *master_pb.VolumeListResponse
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		resp, err = client.VolumeList(ctx, &master_pb.VolumeListRequest{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// find the volume replicas and all the data nodes
+	var replicas []*master_pb.DataNodeInfo
+	var volumeInfo *master_pb.VolumeInformationMessage
+	var allDataNodes []*master_pb.DataNodeInfo
+	for _, dc := range resp.TopologyInfo.DataCenterInfos {
+		for _, rack := range dc.RackInfos {
+			for _, dn := range rack.DataNodeInfos {
+				for _, v := range dn.VolumeInfos {
+					if v.Id == vid {
+						replicas = append(replicas, dn)
+						volumeInfo = v
+					}
+				}
+				allDataNodes = append(allDataNodes, dn)
+			}
+		}
+	}
+	if len(replicas) == 0 {
+		return fmt.Errorf("volume %d not found", vid)
+	}
+
+	// mark the volume as readonly
+	for _, replica := range replicas {
+		fmt.Fprintf(writer, "mark volume %d on %s as readonly\n", vid, replica.Id)
+		err = operation.WithVolumeServerClient(replica.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, markErr := volumeServerClient.VolumeMarkReadonly(ctx, &volume_server_pb.VolumeMarkReadonlyRequest{
+				VolumeId: vid,
+			})
+			return markErr
+		})
+		if err != nil {
+			return fmt.Errorf("mark volume %d on %s as readonly: %v", vid, replica.Id, err)
+		}
+	}
+
+	// generate the ec shards on one replica
+	sourceDataNode := replicas[0].Id
+	fmt.Fprintf(writer, "generate ec shards for volume %d on %s\n", vid, sourceDataNode)
+	err = operation.WithVolumeServerClient(sourceDataNode, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, genErr := volumeServerClient.VolumeEcShardsGenerate(ctx, &volume_server_pb.VolumeEcShardsGenerateRequest{
+			VolumeId:   vid,
+			Collection: volumeInfo.Collection,
+		})
+		return genErr
+	})
+	if err != nil {
+		return fmt.Errorf("generate ec shards for volume %d on %s: %v", vid, sourceDataNode, err)
+	}
+
+	// spread the ec shards
+	if err = spreadEcShards(ctx, commandEnv.option.GrpcDialOption, writer, vid, volumeInfo.Collection, sourceDataNode, allDataNodes); err != nil {
+		return err
+	}
+
+	// delete the original volume
+	for _, replica := range replicas {
+		fmt.Fprintf(writer, "delete volume %d from %s\n", vid, replica.Id)
+		err = operation.WithVolumeServerClient(replica.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, deleteErr := volumeServerClient.VolumeDelete(ctx, &volume_server_pb.VolumeDeleteRequest{
+				VolumeId:      vid,
+				AllowReadOnly: true,
+			})
+			return deleteErr
+		})
+		if err != nil {
+			return fmt.Errorf("delete volume %d from %s: %v", vid, replica.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func spreadEcShards(ctx context.Context, grpcDialOption grpc.DialOption, writer io.Writer, vid uint32, collection string, sourceDataNode string, allDataNodes []*master_pb.DataNodeInfo) (err error) {
+
+	allocatedShardIds := allocateEcShards(allDataNodes)
+	if len(allocatedShardIds) == 0 {
+		return fmt.Errorf("no free volume slots to place the ec shards of volume %d", vid)
+	}
+
+	// copy and mount the ec shards on the other data nodes
+	var movedShardIds []uint32
+	for dataNodeId, shardIds := range allocatedShardIds {
+		if dataNodeId == sourceDataNode {
+			continue
+		}
+		fmt.Fprintf(writer, "copy ec shards %d.%v from %s to %s\n", vid, shardIds, sourceDataNode, dataNodeId)
+		err = operation.WithVolumeServerClient(dataNodeId, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, copyErr := volumeServerClient.VolumeEcShardsCopy(ctx, &volume_server_pb.VolumeEcShardsCopyRequest{
+				VolumeId:       vid,
+				Collection:     collection,
+				ShardIds:       shardIds,
+				CopyEcxFile:    true,
+				SourceDataNode: sourceDataNode,
+			})
+			if copyErr != nil {
+				return copyErr
+			}
+			_, mountErr := volumeServerClient.VolumeEcShardsMount(ctx, &volume_server_pb.VolumeEcShardsMountRequest{
+				VolumeId:   vid,
+				Collection: collection,
+				ShardIds:   shardIds,
+			})
+			return mountErr
+		})
+		if err != nil {
+			return fmt.Errorf("copy ec shards %d.%v from %s to %s: %v", vid, shardIds, sourceDataNode, dataNodeId, err)
+		}
+		movedShardIds = append(movedShardIds, shardIds...)
+	}
+
+	// remove the moved ec shards from the source, and mount the rest
+	return operation.WithVolumeServerClient(sourceDataNode, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		if len(movedShardIds) > 0 {
+			fmt.Fprintf(writer, "delete ec shards %d.%v from %s\n", vid, movedShardIds, sourceDataNode)
+			if _, deleteErr := volumeServerClient.VolumeEcShardsDelete(ctx, &volume_server_pb.VolumeEcShardsDeleteRequest{
+				VolumeId:   vid,
+				Collection: collection,
+				ShardIds:   movedShardIds,
+			}); deleteErr != nil {
+				return fmt.Errorf("delete ec shards %d.%v from %s: %v", vid, movedShardIds, sourceDataNode, deleteErr)
+			}
+		}
+		if localShardIds, found := allocatedShardIds[sourceDataNode]; found {
+			fmt.Fprintf(writer, "mount ec shards %d.%v on %s\n", vid, localShardIds, sourceDataNode)
+			if _, mountErr := volumeServerClient.VolumeEcShardsMount(ctx, &volume_server_pb.VolumeEcShardsMountRequest{
+				VolumeId:   vid,
+				Collection: collection,
+				ShardIds:   localShardIds,
+			}); mountErr != nil {
+				return fmt.Errorf("mount ec shards %d.%v on %s: %v", vid, localShardIds, sourceDataNode, mountErr)
+			}
+		}
+		return nil
+	})
+}
+
+// allocateEcShards assigns the ec shards one by one to the data nodes with the most free volume slots
+func allocateEcShards(allDataNodes []*master_pb.DataNodeInfo) (allocated map[string][]uint32) {
+	allocated = make(map[string][]uint32)
+
+	freeSlots := make(map[string]int)
+	for _, dn := range allDataNodes {
+		freeSlots[dn.Id] = int(dn.FreeVolumeCount) * erasure_coding.DataShardsCount
+	}
+	sortedDataNodes := make([]*master_pb.DataNodeInfo, len(allDataNodes))
+	copy(sortedDataNodes, allDataNodes)
+
+	for shardId := 0; shardId < erasure_coding.TotalShardsCount; shardId++ {
+		sort.Slice(sortedDataNodes, func(i, j int) bool {
+			a, b := sortedDataNodes[i].Id, sortedDataNodes[j].Id
+			if (freeSlots[a] > 0) != (freeSlots[b] > 0) {
+				return freeSlots[a] > 0
+			}
+			if len(allocated[a]) != len(allocated[b]) {
+				return len(allocated[a]) < len(allocated[b])
+			}
+			return freeSlots[a] > freeSlots[b]
+		})
+		if len(sortedDataNodes) == 0 || freeSlots[sortedDataNodes[0].Id] <= 0 {
+			return nil
+		}
+		dataNodeId := sortedDataNodes[0].Id
+		allocated[dataNodeId] = append(allocated[dataNodeId], uint32(shardId))
+		freeSlots[dataNodeId]--
+	}
+
+	return
+}
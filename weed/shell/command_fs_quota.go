@@ -0,0 +1,143 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	commands = append(commands, &commandFsQuota{})
+}
+
+type commandFsQuota struct {
+}
+
+func (c *commandFsQuota) Name() string {
+	return "fs.quota"
+}
+
+func (c *commandFsQuota) Help() string {
+	return `show or set the quota of a directory
+
+	fs.quota -list                                              # list all quotas
+	fs.quota http://<filer_server>:<port>/dir/                  # show the quota of the directory or its ancestors
+	fs.quota [-maxBytes=100GiB] [-maxFiles=1000000] /dir/       # set the quota, 0 means unlimited
+	fs.quota -remove /dir/                                      # remove the quota
+
+	The filer rejects the writes exceeding the quota of any ancestor directory.
+	The usage is counted when the quota is first set, and tracked by the filer afterwards.
+	The quota is moved together with the directory, and removed when the directory is deleted.
+
+`
+}
+
+func (c *commandFsQuota) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	quotaCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	list := quotaCommand.Bool("list", false, "list all quotas")
+	maxBytes := quotaCommand.String("maxBytes", "", "the max total file size, e.g., 500MiB, 10GiB, 0 for unlimited")
+	maxFiles := quotaCommand.Int64("maxFiles", -1, "the max number of files, 0 for unlimited")
+	remove := quotaCommand.Bool("remove", false, "remove the quota")
+	if err = quotaCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	filerServer, filerPort, path, err := commandEnv.parseUrl(findInputDirectory(quotaCommand.Args()))
+	if err != nil {
+		return err
+	}
+	path = filepath.ToSlash(filepath.Clean(path))
+
+	ctx := context.Background()
+
+	return commandEnv.withFilerClient(ctx, filerServer, filerPort, func(client filer_pb.SeaweedFilerClient) error {
+
+		if *list {
+			resp, err := client.ListQuotas(ctx, &filer_pb.ListQuotasRequest{})
+			if err != nil {
+				return err
+			}
+			for _, quota := range resp.Quotas {
+				printQuota(writer, quota)
+			}
+			return nil
+		}
+
+		if *remove {
+			_, err := client.SetQuota(ctx, &filer_pb.SetQuotaRequest{
+				Directory: path,
+				Remove:    true,
+			})
+			return err
+		}
+
+		if *maxBytes == "" && *maxFiles < 0 {
+			resp, err := client.GetQuota(ctx, &filer_pb.GetQuotaRequest{
+				Directory: path,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.Quota == nil {
+				fmt.Fprintf(writer, "no quota on %s\n", path)
+				return nil
+			}
+			printQuota(writer, resp.Quota)
+			return nil
+		}
+
+		// keep the limit not specified
+		request := &filer_pb.SetQuotaRequest{
+			Directory: path,
+			MaxFiles:  *maxFiles,
+		}
+		if *maxBytes == "" || *maxFiles < 0 {
+			resp, err := client.GetQuota(ctx, &filer_pb.GetQuotaRequest{
+				Directory: path,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.Quota != nil && resp.Quota.Directory == path {
+				request.MaxBytes, request.MaxFiles = resp.Quota.MaxBytes, resp.Quota.MaxFiles
+			}
+		}
+		if *maxBytes != "" {
+			bytes, err := humanize.ParseBytes(*maxBytes)
+			if err != nil {
+				return fmt.Errorf("parse maxBytes %s: %v", *maxBytes, err)
+			}
+			request.MaxBytes = int64(bytes)
+		}
+		if *maxFiles >= 0 {
+			request.MaxFiles = *maxFiles
+		}
+
+		resp, err := client.SetQuota(ctx, request)
+		if err != nil {
+			return err
+		}
+		printQuota(writer, resp.Quota)
+
+		return nil
+	})
+
+}
+
+func printQuota(writer io.Writer, quota *filer_pb.Quota) {
+	maxBytes, maxFiles := "unlimited", "unlimited"
+	if quota.MaxBytes > 0 {
+		maxBytes = humanize.IBytes(uint64(quota.MaxBytes))
+	}
+	if quota.MaxFiles > 0 {
+		maxFiles = fmt.Sprintf("%d", quota.MaxFiles)
+	}
+	fmt.Fprintf(writer, "%s\tbytes: %s / %s\tfiles: %d / %s\n",
+		quota.Directory, humanize.IBytes(uint64(quota.UsedBytes)), maxBytes, quota.UsedFiles, maxFiles)
+}
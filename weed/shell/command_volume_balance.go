@@ -0,0 +1,312 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeBalance{})
+}
+
+type commandVolumeBalance struct {
+}
+
+func (c *commandVolumeBalance) Name() string {
+	return "volume.balance"
+}
+
+func (c *commandVolumeBalance) Help() string {
+	return `balance all volumes among volume servers
+
+	volume.balance [-collection=ALL|EACH_COLLECTION|<collection_name>] [-force]
+
+	Algorithm:
+	For each disk type, hdd or ssd, and each type of volume server (different max volume count limit of the disk type){
+		for each collection {
+			balanceWritableVolumes()
+			balanceReadOnlyVolumes()
+		}
+	}
+
+	func balanceVolumes(){
+		idealRatio = total volumes / total max volume count
+		while True {
+			sort all volume servers ordered by the ratio of local volumes to max volume count
+			pick the volume server A with the highest ratio
+			pick the volume server B with the lowest ratio
+			if moving one volume from A to B does not make B above the ideal ratio
+			   and B does not have this volume yet
+			   and the replica placement is still satisfied {
+				move one volume from A to B
+			} else {
+				break
+			}
+		}
+	}
+
+	By default, this command only prints the plan. Use "-force" to actually move the volumes.
+	Each volume is moved the same way as "volume.move", so the writes during the move are not lost.
+
+`
+}
+
+func (c *commandVolumeBalance) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	balanceCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	collection := balanceCommand.String("collection", "EACH_COLLECTION", "collection name, or use \"ALL\" across collections, \"EACH_COLLECTION\" for each collection")
+	applyBalancing := balanceCommand.Bool("force", false, "apply the balancing plan.")
+	if err = balanceCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	var resp *master_pb.VolumeListResponse
+	ctx := context.Background()
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		resp, err = client.VolumeList(ctx, &master_pb.VolumeListRequest{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	typeToNodes := collectVolumeServersByType(resp.TopologyInfo)
+	for _, volumeServers := range typeToNodes {
+		if len(volumeServers) < 2 {
+			continue
+		}
+		if *collection == "EACH_COLLECTION" {
+			for _, c := range collectCollectionNames(volumeServers) {
+				if err = balanceVolumeServers(commandEnv, writer, volumeServers, c, *applyBalancing); err != nil {
+					return err
+				}
+			}
+		} else if *collection == "ALL" {
+			if err = balanceVolumeServers(commandEnv, writer, volumeServers, "ALL", *applyBalancing); err != nil {
+				return err
+			}
+		} else {
+			if err = balanceVolumeServers(commandEnv, writer, volumeServers, *collection, *applyBalancing); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func balanceVolumeServers(commandEnv *commandEnv, writer io.Writer, nodes []*balanceNode, collection string, applyBalancing bool) error {
+
+	// balance writable volumes and readonly volumes separately
+	for _, readOnly := range []bool{false, true} {
+		for _, n := range nodes {
+			n.selectVolumes(func(v *master_pb.VolumeInformationMessage) bool {
+				if collection != "ALL" && v.Collection != collection {
+					return false
+				}
+				if storage.DiskType(v.DiskType).String() != n.diskInfo.Type {
+					return false
+				}
+				return v.ReadOnly == readOnly
+			})
+		}
+		if err := balanceSelectedVolume(commandEnv, writer, nodes, applyBalancing); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectVolumeServersByType groups the volume servers by the disk type and the max volume count of the disk type
+func collectVolumeServersByType(t *master_pb.TopologyInfo) (typeToNodes map[string][]*balanceNode) {
+	typeToNodes = make(map[string][]*balanceNode)
+	for _, dc := range t.DataCenterInfos {
+		for _, r := range dc.RackInfos {
+			for _, dn := range r.DataNodeInfos {
+				// the hard drive counts of the volume servers from the masters not aware of the disk types
+				findDiskInfo(dn, "")
+				for _, diskInfo := range dn.DiskInfos {
+					if diskInfo.MaxVolumeCount == 0 {
+						continue
+					}
+					serverType := fmt.Sprintf("%s:%d", diskInfo.Type, diskInfo.MaxVolumeCount)
+					typeToNodes[serverType] = append(typeToNodes[serverType], &balanceNode{
+						location: newLocation(dc.Id, r.Id, dn),
+						diskInfo: diskInfo,
+					})
+				}
+			}
+		}
+	}
+	return
+}
+
+func collectCollectionNames(nodes []*balanceNode) (collections []string) {
+	found := make(map[string]bool)
+	for _, n := range nodes {
+		for _, v := range n.dataNode.VolumeInfos {
+			if !found[v.Collection] {
+				found[v.Collection] = true
+				collections = append(collections, v.Collection)
+			}
+		}
+	}
+	sort.Strings(collections)
+	return
+}
+
+type balanceNode struct {
+	location
+	diskInfo        *master_pb.DiskInfo
+	selectedVolumes map[uint32]*master_pb.VolumeInformationMessage
+}
+
+func (n *balanceNode) localVolumeRatio() float64 {
+	return divide(len(n.selectedVolumes), int(n.diskInfo.MaxVolumeCount))
+}
+
+func (n *balanceNode) localVolumeNextRatio() float64 {
+	return divide(len(n.selectedVolumes)+1, int(n.diskInfo.MaxVolumeCount))
+}
+
+func (n *balanceNode) selectVolumes(fn func(v *master_pb.VolumeInformationMessage) bool) {
+	n.selectedVolumes = make(map[uint32]*master_pb.VolumeInformationMessage)
+	for _, v := range n.dataNode.VolumeInfos {
+		if fn(v) {
+			n.selectedVolumes[v.Id] = v
+		}
+	}
+}
+
+func (n *balanceNode) hasVolume(vid uint32) bool {
+	for _, v := range n.dataNode.VolumeInfos {
+		if v.Id == vid {
+			return true
+		}
+	}
+	return false
+}
+
+func divide(total, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(total) / float64(n)
+}
+
+func sortVolumesBySize(volumes []*master_pb.VolumeInformationMessage) {
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Size < volumes[j].Size
+	})
+}
+
+func balanceSelectedVolume(commandEnv *commandEnv, writer io.Writer, nodes []*balanceNode, applyBalancing bool) error {
+	selectedVolumeCount, volumeMaxCount := 0, 0
+	for _, n := range nodes {
+		selectedVolumeCount += len(n.selectedVolumes)
+		volumeMaxCount += int(n.diskInfo.MaxVolumeCount)
+	}
+
+	idealSelectedVolumeRatio := divide(selectedVolumeCount, volumeMaxCount)
+
+	for {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].localVolumeRatio() < nodes[j].localVolumeRatio()
+		})
+
+		fullNode := nodes[len(nodes)-1]
+		var candidateVolumes []*master_pb.VolumeInformationMessage
+		for _, v := range fullNode.selectedVolumes {
+			candidateVolumes = append(candidateVolumes, v)
+		}
+		sortVolumesBySize(candidateVolumes)
+
+		hasMoved := false
+		for _, v := range candidateVolumes {
+			for i := 0; i < len(nodes)-1; i++ {
+				emptyNode := nodes[i]
+				if !(fullNode.localVolumeRatio() > idealSelectedVolumeRatio && emptyNode.localVolumeNextRatio() <= idealSelectedVolumeRatio) {
+					// no more volume servers with empty slots
+					break
+				}
+				if emptyNode.diskInfo.FreeVolumeCount <= 0 || emptyNode.hasVolume(v.Id) {
+					continue
+				}
+				if !isGoodMove(v, nodes, fullNode, emptyNode) {
+					continue
+				}
+				if err := moveVolume(commandEnv, writer, v, fullNode, emptyNode, applyBalancing); err != nil {
+					return err
+				}
+				hasMoved = true
+				break
+			}
+			if hasMoved {
+				break
+			}
+		}
+
+		if !hasMoved {
+			break
+		}
+	}
+	return nil
+}
+
+// isGoodMove checks the replica placement is still satisfied after moving the volume
+func isGoodMove(v *master_pb.VolumeInformationMessage, nodes []*balanceNode, sourceNode, targetNode *balanceNode) bool {
+	replicaPlacement, err := storage.NewReplicaPlacementFromByte(byte(v.ReplicaPlacement))
+	if err != nil {
+		return false
+	}
+	var otherReplicaLocations []location
+	for _, n := range nodes {
+		if n == sourceNode || !n.hasVolume(v.Id) {
+			continue
+		}
+		otherReplicaLocations = append(otherReplicaLocations, n.location)
+	}
+	return satisfyReplicaPlacement(replicaPlacement, otherReplicaLocations, targetNode.location)
+}
+
+func moveVolume(commandEnv *commandEnv, writer io.Writer, v *master_pb.VolumeInformationMessage, fullNode, emptyNode *balanceNode, applyBalancing bool) error {
+	collectionPrefix := v.Collection + "_"
+	if v.Collection == "" {
+		collectionPrefix = ""
+	}
+	fmt.Fprintf(writer, "moving volume %s%d %s => %s\n", collectionPrefix, v.Id, fullNode.dataNode.Id, emptyNode.dataNode.Id)
+	if applyBalancing {
+		ctx := context.Background()
+		if err := liveMoveVolume(ctx, commandEnv, writer, v.Id, fullNode.dataNode.Id, emptyNode.dataNode.Id, 5*time.Second); err != nil {
+			return fmt.Errorf("move volume %d %s => %s: %v", v.Id, fullNode.dataNode.Id, emptyNode.dataNode.Id, err)
+		}
+	}
+
+	// adjust the in memory view of the cluster
+	delete(fullNode.selectedVolumes, v.Id)
+	emptyNode.selectedVolumes[v.Id] = v
+	removeVolumeInfo(fullNode.dataNode, v.Id)
+	emptyNode.dataNode.VolumeInfos = append(emptyNode.dataNode.VolumeInfos, v)
+	fullNode.dataNode.FreeVolumeCount++
+	emptyNode.dataNode.FreeVolumeCount--
+	fullNode.diskInfo.FreeVolumeCount++
+	emptyNode.diskInfo.FreeVolumeCount--
+	return nil
+}
+
+func removeVolumeInfo(dataNode *master_pb.DataNodeInfo, vid uint32) {
+	for i, v := range dataNode.VolumeInfos {
+		if v.Id == vid {
+			dataNode.VolumeInfos = append(dataNode.VolumeInfos[:i], dataNode.VolumeInfos[i+1:]...)
+			return
+		}
+	}
+}
@@ -38,6 +38,7 @@ func (c *commandVolumeFixReplication) Help() string {
 		  are missing, e.g. multiple volume servers are new, you may need to run this multiple times.
 		* do not run this too quick within seconds, since the new volume replica may take a few seconds 
 		  to register itself to the master.
+		* the new replica is placed on the disks of the same type, hdd or ssd, as the volume.
 
 `
 }
@@ -69,7 +70,8 @@ func (c *commandVolumeFixReplication) Do(args []string, commandEnv *commandEnv,
 			for _, dn := range rack.DataNodeInfos {
 				loc := newLocation(dc.Id, rack.Id, dn)
 				for _, v := range dn.VolumeInfos {
-					if v.ReplicaPlacement > 0 {
+					// the volumes on a remote tier keep one local copy, whatever the replica placement
+					if v.ReplicaPlacement > 0 && v.RemoteStorageName == "" {
 						replicatedVolumeLocations[v.Id] = append(replicatedVolumeLocations[v.Id], loc)
 						replicatedVolumeInfo[v.Id] = v
 					}
@@ -106,7 +108,8 @@ func (c *commandVolumeFixReplication) Do(args []string, commandEnv *commandEnv,
 		foundNewLocation := false
 		for _, dst := range allLocations {
 			// check whether data nodes satisfy the constraints
-			if dst.dataNode.FreeVolumeCount > 0 && satisfyReplicaPlacement(replicaPlacement, locations, dst) {
+			diskInfo := findDiskInfo(dst.dataNode, volumeInfo.DiskType)
+			if diskInfo.FreeVolumeCount > 0 && satisfyReplicaPlacement(replicaPlacement, locations, dst) {
 				// ask the volume server to replicate the volume
 				sourceNodes := underReplicatedVolumeLocations[vid]
 				sourceNode := sourceNodes[rand.Intn(len(sourceNodes))]
@@ -122,6 +125,7 @@ func (c *commandVolumeFixReplication) Do(args []string, commandEnv *commandEnv,
 						VolumeId:       volumeInfo.Id,
 						Collection:     volumeInfo.Collection,
 						SourceDataNode: sourceNode.dataNode.Id,
+						DiskType:       volumeInfo.DiskType,
 					})
 					return replicateErr
 				})
@@ -132,6 +136,7 @@ func (c *commandVolumeFixReplication) Do(args []string, commandEnv *commandEnv,
 
 				// adjust free volume count
 				dst.dataNode.FreeVolumeCount--
+				diskInfo.FreeVolumeCount--
 				keepDataNodesSorted(allLocations)
 				break
 			}
@@ -185,6 +190,25 @@ type location struct {
 	dataNode *master_pb.DataNodeInfo
 }
 
+// findDiskInfo finds the counts of the disk type of the data node.
+// The data nodes from the masters not aware of the disk types only have the hard drives.
+func findDiskInfo(dataNode *master_pb.DataNodeInfo, diskType string) *master_pb.DiskInfo {
+	diskTypeName := storage.DiskType(diskType).String()
+	for _, diskInfo := range dataNode.DiskInfos {
+		if diskInfo.Type == diskTypeName {
+			return diskInfo
+		}
+	}
+	diskInfo := &master_pb.DiskInfo{Type: diskTypeName}
+	if len(dataNode.DiskInfos) == 0 && diskTypeName == storage.HardDriveType.String() {
+		diskInfo.VolumeCount = dataNode.VolumeCount
+		diskInfo.MaxVolumeCount = dataNode.MaxVolumeCount
+		diskInfo.FreeVolumeCount = dataNode.FreeVolumeCount
+	}
+	dataNode.DiskInfos = append(dataNode.DiskInfos, diskInfo)
+	return diskInfo
+}
+
 func newLocation(dc, rack string, dataNode *master_pb.DataNodeInfo) location {
 	return location{
 		dc:       dc,
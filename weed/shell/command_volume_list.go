@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
 )
 
 func init() {
@@ -44,7 +46,7 @@ func (c *commandVolumeList) Do(args []string, commandEnv *commandEnv, writer io.
 }
 
 func writeTopologyInfo(writer io.Writer, t *master_pb.TopologyInfo) statistics {
-	fmt.Fprintf(writer, "Topology volume:%d/%d active:%d free:%d\n", t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount)
+	fmt.Fprintf(writer, "Topology volume:%d/%d active:%d free:%d%s\n", t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount, diskInfosString(t.DiskInfos))
 	var s statistics
 	for _, dc := range t.DataCenterInfos {
 		s = s.plus(writeDataCenterInfo(writer, dc))
@@ -53,7 +55,7 @@ func writeTopologyInfo(writer io.Writer, t *master_pb.TopologyInfo) statistics {
 	return s
 }
 func writeDataCenterInfo(writer io.Writer, t *master_pb.DataCenterInfo) statistics {
-	fmt.Fprintf(writer, "  DataCenter %s volume:%d/%d active:%d free:%d\n", t.Id, t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount)
+	fmt.Fprintf(writer, "  DataCenter %s volume:%d/%d active:%d free:%d%s\n", t.Id, t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount, diskInfosString(t.DiskInfos))
 	var s statistics
 	for _, r := range t.RackInfos {
 		s = s.plus(writeRackInfo(writer, r))
@@ -62,7 +64,7 @@ func writeDataCenterInfo(writer io.Writer, t *master_pb.DataCenterInfo) statisti
 	return s
 }
 func writeRackInfo(writer io.Writer, t *master_pb.RackInfo) statistics {
-	fmt.Fprintf(writer, "    Rack %s volume:%d/%d active:%d free:%d\n", t.Id, t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount)
+	fmt.Fprintf(writer, "    Rack %s volume:%d/%d active:%d free:%d%s\n", t.Id, t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount, diskInfosString(t.DiskInfos))
 	var s statistics
 	for _, dn := range t.DataNodeInfos {
 		s = s.plus(writeDataNodeInfo(writer, dn))
@@ -71,14 +73,30 @@ func writeRackInfo(writer io.Writer, t *master_pb.RackInfo) statistics {
 	return s
 }
 func writeDataNodeInfo(writer io.Writer, t *master_pb.DataNodeInfo) statistics {
-	fmt.Fprintf(writer, "      DataNode %s volume:%d/%d active:%d free:%d\n", t.Id, t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount)
+	draining := ""
+	if t.IsDraining {
+		draining = " draining"
+	}
+	fmt.Fprintf(writer, "      DataNode %s volume:%d/%d active:%d free:%d%s%s\n", t.Id, t.VolumeCount, t.MaxVolumeCount, t.ActiveVolumeCount, t.FreeVolumeCount, diskInfosString(t.DiskInfos), draining)
 	var s statistics
 	for _, vi := range t.VolumeInfos {
 		s = s.plus(writeVolumeInformationMessage(writer, vi))
 	}
+	for _, ecShardInfo := range t.EcShardInfos {
+		fmt.Fprintf(writer, "        ec volume id:%v collection:%v shards:%v\n", ecShardInfo.Id, ecShardInfo.Collection, erasure_coding.ShardBits(ecShardInfo.EcIndexBits).ShardIds())
+	}
 	fmt.Fprintf(writer, "      DataNode %s %+v \n", t.Id, s)
 	return s
 }
+func diskInfosString(diskInfos []*master_pb.DiskInfo) (s string) {
+	sort.Slice(diskInfos, func(i, j int) bool {
+		return diskInfos[i].Type < diskInfos[j].Type
+	})
+	for _, d := range diskInfos {
+		s += fmt.Sprintf(" %s:%d/%d free:%d", d.Type, d.VolumeCount, d.MaxVolumeCount, d.FreeVolumeCount)
+	}
+	return
+}
 func writeVolumeInformationMessage(writer io.Writer, t *master_pb.VolumeInformationMessage) statistics {
 	fmt.Fprintf(writer, "        volume %+v \n", t)
 	return newStatiscis(t)
@@ -0,0 +1,196 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeMove{})
+}
+
+type commandVolumeMove struct {
+}
+
+func (c *commandVolumeMove) Name() string {
+	return "volume.move"
+}
+
+func (c *commandVolumeMove) Help() string {
+	return `move a live volume from one volume server to another volume server
+
+	volume.move -volumeId=<volume_id> -source=<source_volume_server:port> -target=<target_volume_server:port>
+
+	This command moves a live volume from one volume server to another volume server. Here are the steps:
+
+	1. This command asks the target volume server to copy the source volume from source volume server.
+	   The copy is mounted readonly, taking no writes.
+	2. The target volume server follows the source volume, appending the needles written during the copy.
+	3. The source volume is marked readonly, and the target volume server catches up the last needles.
+	4. The target volume is remounted to become writable, unless the source volume was readonly before the move.
+	5. The master switches the volume location from the source to the target, and the source volume is deleted.
+
+	The volume is copied to the disks of the same type, hdd or ssd, on the target volume server.
+
+`
+}
+
+func (c *commandVolumeMove) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	moveCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeId := moveCommand.Int("volumeId", 0, "the volume id")
+	sourceNode := moveCommand.String("source", "", "the source volume server <host>:<port>")
+	targetNode := moveCommand.String("target", "", "the target volume server <host>:<port>")
+	if err = moveCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *volumeId == 0 || *sourceNode == "" || *targetNode == "" {
+		return fmt.Errorf("missing -volumeId, -source or -target")
+	}
+	if *sourceNode == *targetNode {
+		return fmt.Errorf("source and target volume servers are the same!")
+	}
+
+	ctx := context.Background()
+	return liveMoveVolume(ctx, commandEnv, writer, uint32(*volumeId), *sourceNode, *targetNode, 5*time.Second)
+}
+
+// liveMoveVolume moves the volume without losing the writes landed during the move
+func liveMoveVolume(ctx context.Context, commandEnv *commandEnv, writer io.Writer, vid uint32, sourceVolumeServer, targetVolumeServer string, idleTimeout time.Duration) (err error) {
+
+	grpcDialOption := commandEnv.option.GrpcDialOption
+
+	// find the collection, the disk type, and whether the volume is readonly before the move
+	var collection, diskType string
+	var readOnly bool
+	err = operation.WithVolumeServerClient(sourceVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		resp, statusErr := volumeServerClient.VolumeSyncStatus(ctx, &volume_server_pb.VolumeSyncStatusRequest{
+			VolumeId: vid,
+		})
+		if statusErr != nil {
+			return statusErr
+		}
+		collection = resp.Collection
+		diskType = resp.DiskType
+		readOnly = resp.ReadOnly
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read volume %d status from %s: %v", vid, sourceVolumeServer, err)
+	}
+
+	// the target is read-only to take no writes, until it catches up with the source
+	fmt.Fprintf(writer, "copying volume %d from %s to %s\n", vid, sourceVolumeServer, targetVolumeServer)
+	err = operation.WithVolumeServerClient(targetVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, replicateErr := volumeServerClient.ReplicateVolume(ctx, &volume_server_pb.ReplicateVolumeRequest{
+			VolumeId:       vid,
+			Collection:     collection,
+			SourceDataNode: sourceVolumeServer,
+			DiskType:       diskType,
+			ReadOnly:       true,
+		})
+		return replicateErr
+	})
+	if err != nil {
+		return fmt.Errorf("copy volume %d from %s to %s: %v", vid, sourceVolumeServer, targetVolumeServer, err)
+	}
+
+	fmt.Fprintf(writer, "tailing volume %d from %s to %s\n", vid, sourceVolumeServer, targetVolumeServer)
+	if err = tailVolume(ctx, grpcDialOption, vid, sourceVolumeServer, targetVolumeServer, idleTimeout); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "marking volume %d on %s readonly\n", vid, sourceVolumeServer)
+	err = operation.WithVolumeServerClient(sourceVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, markErr := volumeServerClient.VolumeMarkReadonly(ctx, &volume_server_pb.VolumeMarkReadonlyRequest{
+			VolumeId: vid,
+		})
+		return markErr
+	})
+	if err != nil {
+		return fmt.Errorf("mark volume %d on %s readonly: %v", vid, sourceVolumeServer, err)
+	}
+
+	// catch up the needles written before the source became readonly
+	if err = tailVolume(ctx, grpcDialOption, vid, sourceVolumeServer, targetVolumeServer, time.Second); err != nil {
+		return err
+	}
+
+	if readOnly {
+		fmt.Fprintf(writer, "keeping volume %d on %s readonly as the source\n", vid, targetVolumeServer)
+	} else {
+		fmt.Fprintf(writer, "remounting volume %d on %s\n", vid, targetVolumeServer)
+		err = remountVolume(ctx, grpcDialOption, vid, targetVolumeServer)
+	}
+	if err != nil {
+		return fmt.Errorf("remount volume %d on %s: %v", vid, targetVolumeServer, err)
+	}
+
+	// switch the volume location on the master at once, before the source is gone
+	fmt.Fprintf(writer, "moving volume %d location from %s to %s\n", vid, sourceVolumeServer, targetVolumeServer)
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		_, moveErr := client.VolumeMoveLocation(ctx, &master_pb.VolumeMoveLocationRequest{
+			VolumeId:   vid,
+			SourceNode: sourceVolumeServer,
+			TargetNode: targetVolumeServer,
+			ReadOnly:   readOnly,
+		})
+		return moveErr
+	})
+	if err != nil {
+		return fmt.Errorf("move volume %d location from %s to %s: %v", vid, sourceVolumeServer, targetVolumeServer, err)
+	}
+
+	fmt.Fprintf(writer, "deleting volume %d from %s\n", vid, sourceVolumeServer)
+	err = operation.WithVolumeServerClient(sourceVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, deleteErr := volumeServerClient.VolumeDelete(ctx, &volume_server_pb.VolumeDeleteRequest{
+			VolumeId:      vid,
+			AllowReadOnly: true,
+		})
+		return deleteErr
+	})
+	if err != nil {
+		return fmt.Errorf("delete volume %d from %s: %v", vid, sourceVolumeServer, err)
+	}
+
+	return nil
+}
+
+// remountVolume unmounts and mounts the volume, which becomes writable
+func remountVolume(ctx context.Context, grpcDialOption grpc.DialOption, vid uint32, volumeServer string) error {
+	return operation.WithVolumeServerClient(volumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		if _, unmountErr := volumeServerClient.VolumeUnmount(ctx, &volume_server_pb.VolumeUnmountRequest{
+			VolumeId: vid,
+		}); unmountErr != nil {
+			return unmountErr
+		}
+		_, mountErr := volumeServerClient.VolumeMount(ctx, &volume_server_pb.VolumeMountRequest{
+			VolumeId: vid,
+		})
+		return mountErr
+	})
+}
+
+func tailVolume(ctx context.Context, grpcDialOption grpc.DialOption, vid uint32, sourceVolumeServer, targetVolumeServer string, idleTimeout time.Duration) (err error) {
+	err = operation.WithVolumeServerClient(targetVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, tailErr := volumeServerClient.VolumeTailReceiver(ctx, &volume_server_pb.VolumeTailReceiverRequest{
+			VolumeId:           vid,
+			SourceVolumeServer: sourceVolumeServer,
+			IdleTimeoutSeconds: uint32(idleTimeout.Seconds()),
+		})
+		return tailErr
+	})
+	if err != nil {
+		return fmt.Errorf("tail volume %d from %s to %s: %v", vid, sourceVolumeServer, targetVolumeServer, err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeServerEvacuate{})
+}
+
+type commandVolumeServerEvacuate struct {
+}
+
+func (c *commandVolumeServerEvacuate) Name() string {
+	return "volumeServer.evacuate"
+}
+
+func (c *commandVolumeServerEvacuate) Help() string {
+	return `move out all volumes on a volume server, before retiring it
+
+	volumeServer.evacuate -node=<host:port> [-force]
+	volumeServer.evacuate -node=<host:port> -cancel
+
+	This command asks the master to drain the volume server first. A draining volume server takes
+	no new writes to its volumes, and no new volumes. Then for each volume on the volume server:
+
+	1. Mark the volume readonly on the volume server.
+	2. Pick another volume server with a free slot of the same disk type, hdd or ssd,
+	   where the replica placement of the volume is still satisfied.
+	3. Ask the picked volume server to replicate the volume from the draining volume server.
+	4. Verify the file count and the file size of the copy.
+	5. Delete the volume from the draining volume server.
+
+	If interrupted, run this command again to resume. The volumes already copied are only verified and deleted.
+	The draining state is kept by the master leader until cancelled, so run this command again after a master restart.
+	The ec shards on the volume server are not moved.
+
+	By default, this command only prints the plan. Use "-force" to actually move the volumes.
+	Use "-cancel" to stop draining the volume server.
+
+`
+}
+
+func (c *commandVolumeServerEvacuate) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	evacuateCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	node := evacuateCommand.String("node", "", "the volume server <host>:<port> to evacuate")
+	applyChange := evacuateCommand.Bool("force", false, "actually move the volumes")
+	cancel := evacuateCommand.Bool("cancel", false, "stop draining the volume server")
+	if err = evacuateCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *node == "" {
+		return fmt.Errorf("missing -node")
+	}
+
+	ctx := context.Background()
+
+	if *cancel || *applyChange {
+		err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+			_, drainErr := client.VolumeServerDrain(ctx, &master_pb.VolumeServerDrainRequest{
+				Node:     *node,
+				Draining: !*cancel,
+			})
+			return drainErr
+		})
+		if err != nil {
+			return err
+		}
+		if *cancel {
+			fmt.Fprintf(writer, "volume server %s stops draining\n", *node)
+			return nil
+		}
+		fmt.Fprintf(writer, "volume server %s is draining\n", *node)
+	}
+
+	var resp *master_pb.VolumeListResponse
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		resp, err = client.VolumeList(ctx, &master_pb.VolumeListRequest{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// collect all data nodes, and the locations of the volumes
+	var source *location
+	var allLocations []location
+	volumeLocations := make(map[uint32][]location)
+	for _, dc := range resp.TopologyInfo.DataCenterInfos {
+		for _, rack := range dc.RackInfos {
+			for _, dn := range rack.DataNodeInfos {
+				loc := newLocation(dc.Id, rack.Id, dn)
+				if dn.Id == *node {
+					source = &loc
+				}
+				for _, v := range dn.VolumeInfos {
+					volumeLocations[v.Id] = append(volumeLocations[v.Id], loc)
+				}
+				allLocations = append(allLocations, loc)
+			}
+		}
+	}
+	if source == nil {
+		return fmt.Errorf("volume server %s not found", *node)
+	}
+
+	volumes := source.dataNode.VolumeInfos
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Id < volumes[j].Id
+	})
+
+	keepDataNodesSorted(allLocations)
+
+	for i, v := range volumes {
+		fmt.Fprintf(writer, "volume %d (%d/%d):\n", v.Id, i+1, len(volumes))
+		if err = evacuateVolume(ctx, commandEnv.option.GrpcDialOption, writer, v, *source, volumeLocations[v.Id], allLocations, *applyChange); err != nil {
+			return fmt.Errorf("evacuate volume %d from %s: %v", v.Id, *node, err)
+		}
+	}
+
+	if len(source.dataNode.EcShardInfos) > 0 {
+		fmt.Fprintf(writer, "volume server %s still has shards of %d ec volumes\n", *node, len(source.dataNode.EcShardInfos))
+	}
+	if *applyChange {
+		fmt.Fprintf(writer, "volume server %s is evacuated\n", *node)
+	}
+
+	return nil
+}
+
+func evacuateVolume(ctx context.Context, grpcDialOption grpc.DialOption, writer io.Writer, v *master_pb.VolumeInformationMessage,
+	source location, locations []location, allLocations []location, applyChange bool) (err error) {
+
+	replicaPlacement, _ := storage.NewReplicaPlacementFromByte(byte(v.ReplicaPlacement))
+
+	var replicas []location
+	for _, loc := range locations {
+		if loc.dataNode.Id != source.dataNode.Id {
+			replicas = append(replicas, loc)
+		}
+	}
+
+	if applyChange {
+		err = operation.WithVolumeServerClient(source.dataNode.Id, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, markErr := volumeServerClient.VolumeMarkReadonly(ctx, &volume_server_pb.VolumeMarkReadonlyRequest{
+				VolumeId: v.Id,
+			})
+			return markErr
+		})
+		if err != nil {
+			return fmt.Errorf("mark readonly: %v", err)
+		}
+		// the volume stays on the source if it is not moved, and is writable again unless it was readonly
+		defer func() {
+			if err == nil || v.ReadOnly {
+				return
+			}
+			if remountErr := remountVolume(ctx, grpcDialOption, v.Id, source.dataNode.Id); remountErr != nil {
+				fmt.Fprintf(writer, "  volume %d is left readonly on %s: %v\n", v.Id, source.dataNode.Id, remountErr)
+				return
+			}
+			fmt.Fprintf(writer, "  volume %d is writable again on %s\n", v.Id, source.dataNode.Id)
+		}()
+	}
+
+	// the volume has been copied, but not deleted from the source yet
+	if len(replicas) >= replicaPlacement.GetCopyCount() {
+		fmt.Fprintf(writer, "  volume %d %s already has %d replicas on other volume servers\n", v.Id, replicaPlacement, len(replicas))
+		if !applyChange {
+			return nil
+		}
+		return verifyAndDeleteVolume(ctx, grpcDialOption, writer, v.Id, source, replicas)
+	}
+
+	var target *location
+	var targetDiskInfo *master_pb.DiskInfo
+	for _, dst := range allLocations {
+		if dst.dataNode.Id == source.dataNode.Id || isVolumeOn(replicas, dst) {
+			continue
+		}
+		diskInfo := findDiskInfo(dst.dataNode, v.DiskType)
+		if diskInfo.FreeVolumeCount > 0 && satisfyReplicaPlacement(replicaPlacement, replicas, dst) {
+			dst := dst
+			target, targetDiskInfo = &dst, diskInfo
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("no volume server to place volume %d as %s, existing:%+v", v.Id, replicaPlacement, replicas)
+	}
+
+	fmt.Fprintf(writer, "  moving volume %d %s from %s to %s\n", v.Id, replicaPlacement, source.dataNode.Id, target.dataNode.Id)
+
+	// adjust free volume count
+	target.dataNode.FreeVolumeCount--
+	targetDiskInfo.FreeVolumeCount--
+	keepDataNodesSorted(allLocations)
+
+	if !applyChange {
+		return nil
+	}
+
+	err = operation.WithVolumeServerClient(target.dataNode.Id, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, replicateErr := volumeServerClient.ReplicateVolume(ctx, &volume_server_pb.ReplicateVolumeRequest{
+			VolumeId:       v.Id,
+			Collection:     v.Collection,
+			SourceDataNode: source.dataNode.Id,
+			DiskType:       v.DiskType,
+		})
+		return replicateErr
+	})
+	if err != nil {
+		return fmt.Errorf("replicate to %s: %v", target.dataNode.Id, err)
+	}
+
+	return verifyAndDeleteVolume(ctx, grpcDialOption, writer, v.Id, source, []location{*target})
+}
+
+// verifyAndDeleteVolume deletes the volume from the source, if one of the replicas has the same files
+func verifyAndDeleteVolume(ctx context.Context, grpcDialOption grpc.DialOption, writer io.Writer, vid uint32, source location, replicas []location) error {
+
+	sourceStatus, err := readVolumeFileStatus(ctx, grpcDialOption, vid, source.dataNode.Id)
+	if err != nil {
+		return err
+	}
+
+	verified := false
+	for _, replica := range replicas {
+		status, err := readVolumeFileStatus(ctx, grpcDialOption, vid, replica.dataNode.Id)
+		if err != nil {
+			fmt.Fprintf(writer, "  %v\n", err)
+			continue
+		}
+		if status.FileCount == sourceStatus.FileCount && status.DatFileSize == sourceStatus.DatFileSize {
+			fmt.Fprintf(writer, "  verified volume %d on %s: %d files, %d bytes\n", vid, replica.dataNode.Id, status.FileCount, status.DatFileSize)
+			verified = true
+			break
+		}
+		fmt.Fprintf(writer, "  volume %d on %s has %d files, %d bytes, expected %d files, %d bytes\n",
+			vid, replica.dataNode.Id, status.FileCount, status.DatFileSize, sourceStatus.FileCount, sourceStatus.DatFileSize)
+	}
+	if !verified {
+		return fmt.Errorf("no replica matches volume %d on %s", vid, source.dataNode.Id)
+	}
+
+	err = operation.WithVolumeServerClient(source.dataNode.Id, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		_, deleteErr := volumeServerClient.VolumeDelete(ctx, &volume_server_pb.VolumeDeleteRequest{
+			VolumeId:      vid,
+			AllowReadOnly: true,
+		})
+		return deleteErr
+	})
+	if err != nil {
+		return fmt.Errorf("delete volume %d from %s: %v", vid, source.dataNode.Id, err)
+	}
+	fmt.Fprintf(writer, "  deleted volume %d from %s\n", vid, source.dataNode.Id)
+
+	return nil
+}
+
+func readVolumeFileStatus(ctx context.Context, grpcDialOption grpc.DialOption, vid uint32, volumeServer string) (status *volume_server_pb.ReadVolumeFileStatusResponse, err error) {
+	err = operation.WithVolumeServerClient(volumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		status, err = volumeServerClient.ReadVolumeFileStatus(ctx, &volume_server_pb.ReadVolumeFileStatusRequest{
+			VolumeId: vid,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read volume %d status from %s: %v", vid, volumeServer, err)
+	}
+	return
+}
+
+func isVolumeOn(locations []location, loc location) bool {
+	for _, l := range locations {
+		if l.dataNode.Id == loc.dataNode.Id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeTierDownload{})
+}
+
+type commandVolumeTierDownload struct {
+}
+
+func (c *commandVolumeTierDownload) Name() string {
+	return "volume.tier.download"
+}
+
+func (c *commandVolumeTierDownload) Help() string {
+	return `move the volume .dat file from the remote tier back to the local disk
+
+	volume.tier.download -volumeId=<volume_id> [-keepRemoteDatFile]
+
+	This command will:
+	1. download the .dat file from the remote tier to the volume server holding the volume
+	2. read the needles from the local .dat file again
+	3. delete the remote .dat file, unless -keepRemoteDatFile is set
+
+	Use "volume.fix.replication" afterwards to add back the replicas removed by "volume.tier.upload".
+
+`
+}
+
+func (c *commandVolumeTierDownload) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	tierCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeId := tierCommand.Int("volumeId", 0, "the volume id")
+	keepRemoteDatFile := tierCommand.Bool("keepRemoteDatFile", false, "whether keep the remote dat file")
+	if err = tierCommand.Parse(args); err != nil {
+		return nil
+	}
+	if *volumeId == 0 {
+		return fmt.Errorf("missing -volumeId")
+	}
+	vid := uint32(*volumeId)
+
+	ctx := context.Background()
+
+	replicas, volumeInfo, err := collectVolumeReplicas(ctx, commandEnv, vid)
+	if err != nil {
+		return err
+	}
+	if volumeInfo.RemoteStorageName == "" {
+		return fmt.Errorf("volume %d is not on a remote tier", vid)
+	}
+
+	for _, replica := range replicas {
+		fmt.Fprintf(writer, "download volume %d .dat file from %s to %s\n", vid, volumeInfo.RemoteStorageName, replica.Id)
+		if err = downloadDatFromRemoteTier(ctx, commandEnv.option.GrpcDialOption, writer, vid, volumeInfo.Collection, replica.Id, *keepRemoteDatFile); err != nil {
+			return fmt.Errorf("download volume %d .dat file to %s: %v", vid, replica.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func downloadDatFromRemoteTier(ctx context.Context, grpcDialOption grpc.DialOption, writer io.Writer, vid uint32, collection string, targetVolumeServer string, keepRemoteDatFile bool) error {
+
+	return operation.WithVolumeServerClient(targetVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		stream, downloadErr := volumeServerClient.VolumeTierMoveDatFromRemote(ctx, &volume_server_pb.VolumeTierMoveDatFromRemoteRequest{
+			VolumeId:          vid,
+			Collection:        collection,
+			KeepRemoteDatFile: keepRemoteDatFile,
+		})
+		if downloadErr != nil {
+			return downloadErr
+		}
+
+		var lastProcessed int64
+		lastPercentage := -1
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr != nil {
+				if recvErr == io.EOF {
+					break
+				}
+				return recvErr
+			}
+			lastProcessed = resp.Processed
+			if percentage := int(resp.ProcessedPercentage); percentage > lastPercentage {
+				lastPercentage = percentage
+				fmt.Fprintf(writer, "  downloaded %d bytes, %d%%\n", resp.Processed, percentage)
+			}
+		}
+		fmt.Fprintf(writer, "volume %d downloaded %d bytes\n", vid, lastProcessed)
+
+		return nil
+	})
+
+}
@@ -0,0 +1,180 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	commands = append(commands, &commandVolumeTierUpload{})
+}
+
+type commandVolumeTierUpload struct {
+}
+
+func (c *commandVolumeTierUpload) Name() string {
+	return "volume.tier.upload"
+}
+
+func (c *commandVolumeTierUpload) Help() string {
+	return `move the volume .dat file to a remote tier
+
+	volume.tier.upload -volumeId=<volume_id> -dest=<storage_backend> [-keepLocalDatFile]
+
+	e.g.:
+	volume.tier.upload -volumeId=7 -dest=s3
+	volume.tier.upload -volumeId=7 -dest=s3.default
+
+	The <storage_backend> is defined in the volume servers' tier.toml file, e.g., "weed scaffold -config=tier".
+
+	This command will:
+	1. mark all replicas of the volume readonly
+	2. upload the .dat file of one replica to the remote tier, and read the needles from there
+	3. remove the other replicas, since the remote tier keeps the data durable
+
+	The volume keeps its replica placement. The volumes on a remote tier are exempt from the replica placement,
+	so neither "volume.fix.replication" nor the master adds back the removed replicas.
+	The .idx file stays on the local disk. Use "volume.tier.download" to move the .dat file back.
+
+`
+}
+
+func (c *commandVolumeTierUpload) Do(args []string, commandEnv *commandEnv, writer io.Writer) (err error) {
+
+	tierCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeId := tierCommand.Int("volumeId", 0, "the volume id")
+	dest := tierCommand.String("dest", "", "the target tier name, e.g., s3 or s3.default")
+	keepLocalDatFile := tierCommand.Bool("keepLocalDatFile", false, "whether keep local dat file")
+	if err = tierCommand.Parse(args); err != nil {
+		return nil
+	}
+	if *volumeId == 0 {
+		return fmt.Errorf("missing -volumeId")
+	}
+	if *dest == "" {
+		return fmt.Errorf("missing -dest")
+	}
+	vid := uint32(*volumeId)
+
+	ctx := context.Background()
+
+	replicas, volumeInfo, err := collectVolumeReplicas(ctx, commandEnv, vid)
+	if err != nil {
+		return err
+	}
+	if volumeInfo.RemoteStorageName != "" {
+		return fmt.Errorf("volume %d is already on remote tier %s", vid, volumeInfo.RemoteStorageName)
+	}
+
+	// mark the volume as readonly
+	for _, replica := range replicas {
+		fmt.Fprintf(writer, "mark volume %d on %s as readonly\n", vid, replica.Id)
+		err = operation.WithVolumeServerClient(replica.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, markErr := volumeServerClient.VolumeMarkReadonly(ctx, &volume_server_pb.VolumeMarkReadonlyRequest{
+				VolumeId: vid,
+			})
+			return markErr
+		})
+		if err != nil {
+			return fmt.Errorf("mark volume %d on %s as readonly: %v", vid, replica.Id, err)
+		}
+	}
+
+	// upload the .dat file of one replica
+	sourceDataNode := replicas[0].Id
+	fmt.Fprintf(writer, "upload volume %d .dat file on %s to %s\n", vid, sourceDataNode, *dest)
+	if err = uploadDatToRemoteTier(ctx, commandEnv.option.GrpcDialOption, writer, vid, volumeInfo.Collection, sourceDataNode, *dest, *keepLocalDatFile); err != nil {
+		return fmt.Errorf("upload volume %d .dat file on %s to %s: %v", vid, sourceDataNode, *dest, err)
+	}
+
+	// delete the other replicas, the volume on the remote tier is exempt from the replica placement
+	for _, replica := range replicas[1:] {
+		fmt.Fprintf(writer, "delete volume %d from %s\n", vid, replica.Id)
+		err = operation.WithVolumeServerClient(replica.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, deleteErr := volumeServerClient.VolumeDelete(ctx, &volume_server_pb.VolumeDeleteRequest{
+				VolumeId:      vid,
+				AllowReadOnly: true,
+			})
+			return deleteErr
+		})
+		if err != nil {
+			return fmt.Errorf("delete volume %d from %s: %v", vid, replica.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func uploadDatToRemoteTier(ctx context.Context, grpcDialOption grpc.DialOption, writer io.Writer, vid uint32, collection string, sourceVolumeServer string, dest string, keepLocalDatFile bool) error {
+
+	return operation.WithVolumeServerClient(sourceVolumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		stream, copyErr := volumeServerClient.VolumeTierMoveDatToRemote(ctx, &volume_server_pb.VolumeTierMoveDatToRemoteRequest{
+			VolumeId:               vid,
+			Collection:             collection,
+			DestinationBackendName: dest,
+			KeepLocalDatFile:       keepLocalDatFile,
+		})
+		if copyErr != nil {
+			return copyErr
+		}
+
+		var lastProcessed int64
+		lastPercentage := -1
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr != nil {
+				if recvErr == io.EOF {
+					break
+				}
+				return recvErr
+			}
+			lastProcessed = resp.Processed
+			if percentage := int(resp.ProcessedPercentage); percentage > lastPercentage {
+				lastPercentage = percentage
+				fmt.Fprintf(writer, "  uploaded %d bytes, %d%%\n", resp.Processed, percentage)
+			}
+		}
+		fmt.Fprintf(writer, "volume %d uploaded %d bytes\n", vid, lastProcessed)
+
+		return nil
+	})
+
+}
+
+// collectVolumeReplicas finds the volume servers holding the volume
+func collectVolumeReplicas(ctx context.Context, commandEnv *commandEnv, vid uint32) (replicas []*master_pb.DataNodeInfo, volumeInfo *master_pb.VolumeInformationMessage, err error) {
+
+	var resp *master_pb.VolumeListResponse
+	err = commandEnv.masterClient.WithClient(ctx, func(client master_pb.SeaweedClient) error {
+		resp, err = client.VolumeList(ctx, &master_pb.VolumeListRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, dc := range resp.TopologyInfo.DataCenterInfos {
+		for _, rack := range dc.RackInfos {
+			for _, dn := range rack.DataNodeInfos {
+				for _, v := range dn.VolumeInfos {
+					if v.Id == vid {
+						replicas = append(replicas, dn)
+						volumeInfo = v
+					}
+				}
+			}
+		}
+	}
+	if len(replicas) == 0 {
+		return nil, nil, fmt.Errorf("volume %d not found", vid)
+	}
+
+	return replicas, volumeInfo, nil
+}
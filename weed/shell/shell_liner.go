@@ -72,7 +72,7 @@ func RunShell(options ShellOptions) {
 				return
 			} else {
 				for _, c := range commands {
-					if c.Name() == cmd {
+					if strings.ToLower(c.Name()) == cmd {
 						if err := c.Do(args, commandEnv, os.Stdout); err != nil {
 							fmt.Fprintf(os.Stderr, "error: %v\n", err)
 						}
@@ -113,7 +113,7 @@ func printHelp(cmds []string) {
 		})
 
 		for _, c := range commands {
-			if c.Name() == cmd {
+			if strings.ToLower(c.Name()) == cmd {
 				fmt.Printf("  %s\t# %s\n", c.Name(), c.Help())
 			}
 		}
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+// BackendStorageFile is the data file of a volume, either a local file or a remote object.
+type BackendStorageFile interface {
+	io.ReaderAt
+	io.WriterAt
+	Truncate(off int64) error
+	io.Closer
+	GetStat() (datSize int64, modTime time.Time, err error)
+	Name() string
+}
+
+// BackendStorage is a place to keep volume data files, e.g., an S3 bucket.
+type BackendStorage interface {
+	// NewStorageFile opens an object previously stored by CopyFile
+	NewStorageFile(remoteFile *volume_server_pb.RemoteFile) BackendStorageFile
+	// CopyFile uploads a local file, and returns the key to locate it later
+	CopyFile(f *os.File, fn func(progressed int64, percentage float32) error) (key string, size int64, err error)
+	// DownloadFile saves the object into a local file
+	DownloadFile(fileName string, key string, fn func(progressed int64, percentage float32) error) (size int64, err error)
+	DeleteFile(key string) (err error)
+}
+
+type StorageType string
+
+type BackendStorageFactory interface {
+	StorageType() StorageType
+	BuildStorage(configuration util.Configuration, id string) (BackendStorage, error)
+}
+
+var (
+	BackendStorageFactories = make(map[StorageType]BackendStorageFactory)
+	// BackendStorages is keyed by "<type>.<id>", e.g., "s3.default"
+	BackendStorages = make(map[string]BackendStorage)
+)
+
+// LoadConfiguration builds all enabled [storage.backend.<type>.<id>] sections
+func LoadConfiguration(config *viper.Viper) {
+
+	if config == nil {
+		return
+	}
+
+	backendSub := config.Sub("storage.backend")
+	if backendSub == nil {
+		return
+	}
+
+	for backendTypeName := range backendSub.AllSettings() {
+		backendStorageFactory, found := BackendStorageFactories[StorageType(backendTypeName)]
+		if !found {
+			glog.Fatalf("backend storage type %s not found", backendTypeName)
+		}
+		typeSub := backendSub.Sub(backendTypeName)
+		for backendStorageId := range typeSub.AllSettings() {
+			if !typeSub.GetBool(backendStorageId + ".enabled") {
+				continue
+			}
+			backendStorage, buildErr := backendStorageFactory.BuildStorage(typeSub.Sub(backendStorageId), backendStorageId)
+			if buildErr != nil {
+				glog.Fatalf("fail to create backend storage %s.%s: %v", backendTypeName, backendStorageId, buildErr)
+			}
+			BackendStorages[backendTypeName+"."+backendStorageId] = backendStorage
+			if backendStorageId == "default" {
+				BackendStorages[backendTypeName] = backendStorage
+			}
+			glog.V(0).Infof("configured backend storage %s.%s", backendTypeName, backendStorageId)
+		}
+	}
+
+}
+
+// BackendNameToTypeId splits "s3.default" into "s3" and "default"
+func BackendNameToTypeId(backendName string) (backendType, backendId string) {
+	parts := strings.Split(backendName, ".")
+	if len(parts) == 1 {
+		return backendName, "default"
+	}
+	if len(parts) != 2 {
+		return
+	}
+
+	backendType, backendId = parts[0], parts[1]
+	return
+}
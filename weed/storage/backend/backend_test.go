@@ -0,0 +1,133 @@
+package backend
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+)
+
+func TestBackendNameToTypeId(t *testing.T) {
+	for _, c := range []struct {
+		name, backendType, backendId string
+	}{
+		{"s3", "s3", "default"},
+		{"s3.default", "s3", "default"},
+		{"dir.cold", "dir", "cold"},
+		{"a.b.c", "", ""},
+	} {
+		backendType, backendId := BackendNameToTypeId(c.name)
+		if backendType != c.backendType || backendId != c.backendId {
+			t.Errorf("%s: expected %s.%s, got %s.%s", c.name, c.backendType, c.backendId, backendType, backendId)
+		}
+	}
+}
+
+func TestDirBackendStorage(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := viper.New()
+	config.SetConfigType("toml")
+	toml := `
+[storage.backend.dir.cold]
+enabled = true
+directory = "` + filepath.Join(tempDir, "cold") + `"
+
+[storage.backend.dir.disabled]
+enabled = false
+directory = "` + filepath.Join(tempDir, "disabled") + `"
+`
+	if err := config.ReadConfig(strings.NewReader(toml)); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	LoadConfiguration(config)
+
+	backendStorage, found := BackendStorages["dir.cold"]
+	if !found {
+		t.Fatalf("backend storage dir.cold not loaded")
+	}
+	if _, found := BackendStorages["dir.disabled"]; found {
+		t.Errorf("disabled backend storage should not be loaded")
+	}
+
+	// upload a local file
+	data := make([]byte, 10*1024*1024+123)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	localFileName := filepath.Join(tempDir, "1.dat")
+	if err := ioutil.WriteFile(localFileName, data, 0644); err != nil {
+		t.Fatalf("write local file: %v", err)
+	}
+	localFile, err := os.Open(localFileName)
+	if err != nil {
+		t.Fatalf("open local file: %v", err)
+	}
+	defer localFile.Close()
+
+	var lastPercentage float32
+	key, size, err := backendStorage.CopyFile(localFile, func(progressed int64, percentage float32) error {
+		lastPercentage = percentage
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("copy file: %v", err)
+	}
+	if size != int64(len(data)) || lastPercentage != 100 {
+		t.Errorf("copied %d bytes %.1f%%, expected %d bytes", size, lastPercentage, len(data))
+	}
+
+	// read the remote file at random places
+	remoteFile := backendStorage.NewStorageFile(&volume_server_pb.RemoteFile{
+		BackendType: "dir",
+		BackendId:   "cold",
+		Key:         key,
+		FileSize:    uint64(size),
+	})
+	datSize, _, err := remoteFile.GetStat()
+	if err != nil || datSize != size {
+		t.Errorf("remote file size %d: %v", datSize, err)
+	}
+	for _, offset := range []int64{0, 8, 4*1024*1024 - 3, int64(len(data)) - 100} {
+		buf := make([]byte, 100)
+		if _, err := remoteFile.ReadAt(buf, offset); err != nil {
+			t.Fatalf("read at %d: %v", offset, err)
+		}
+		if !bytes.Equal(buf, data[offset:offset+100]) {
+			t.Errorf("read at %d: unexpected content", offset)
+		}
+	}
+	if _, err := remoteFile.WriteAt([]byte("x"), 0); err == nil {
+		t.Errorf("remote file should be read only")
+	}
+	remoteFile.Close()
+
+	// download the file back
+	downloadedFileName := filepath.Join(tempDir, "2.dat")
+	if _, err := backendStorage.DownloadFile(downloadedFileName, key, nil); err != nil {
+		t.Fatalf("download file: %v", err)
+	}
+	downloaded, err := ioutil.ReadFile(downloadedFileName)
+	if err != nil {
+		t.Fatalf("read downloaded file: %v", err)
+	}
+	if !bytes.Equal(downloaded, data) {
+		t.Errorf("downloaded file differs")
+	}
+
+	if err := backendStorage.DeleteFile(key); err != nil {
+		t.Errorf("delete file: %v", err)
+	}
+	if _, err := backendStorage.DownloadFile(downloadedFileName, key, nil); err == nil {
+		t.Errorf("deleted file should not be downloadable")
+	}
+}
@@ -0,0 +1,165 @@
+package backend
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/satori/uuid"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+// the "dir" backend keeps the objects as files in a directory,
+// usually a mounted HDD or network file system for the cold data.
+
+func init() {
+	BackendStorageFactories["dir"] = &DirBackendFactory{}
+}
+
+type DirBackendFactory struct {
+}
+
+func (factory *DirBackendFactory) StorageType() StorageType {
+	return StorageType("dir")
+}
+func (factory *DirBackendFactory) BuildStorage(configuration util.Configuration, id string) (BackendStorage, error) {
+	return newDirBackendStorage(configuration.GetString("directory"), id)
+}
+
+type DirBackendStorage struct {
+	id        string
+	directory string
+}
+
+func newDirBackendStorage(directory string, id string) (*DirBackendStorage, error) {
+	if directory == "" {
+		return nil, fmt.Errorf("missing directory for dir backend %s", id)
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, fmt.Errorf("create directory %s: %v", directory, err)
+	}
+	return &DirBackendStorage{
+		id:        id,
+		directory: directory,
+	}, nil
+}
+
+func (s *DirBackendStorage) NewStorageFile(remoteFile *volume_server_pb.RemoteFile) BackendStorageFile {
+	return &DirBackendStorageFile{
+		backendStorage: s,
+		key:            remoteFile.Key,
+		remoteFile:     remoteFile,
+	}
+}
+
+func (s *DirBackendStorage) CopyFile(f *os.File, fn func(progressed int64, percentage float32) error) (key string, size int64, err error) {
+	key = uuid.NewV4().String()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+
+	dst, err := os.OpenFile(s.objectPath(key), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", 0, err
+	}
+	defer dst.Close()
+
+	size, err = copyWithProgress(dst, io.NewSectionReader(f, 0, stat.Size()), stat.Size(), fn)
+	if err != nil {
+		os.Remove(s.objectPath(key))
+		return "", 0, err
+	}
+	return key, size, nil
+}
+
+func (s *DirBackendStorage) DownloadFile(fileName string, key string, fn func(progressed int64, percentage float32) error) (size int64, err error) {
+	src, err := os.Open(s.objectPath(key))
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	stat, err := src.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	dst, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	return copyWithProgress(dst, src, stat.Size(), fn)
+}
+
+func (s *DirBackendStorage) DeleteFile(key string) (err error) {
+	return os.Remove(s.objectPath(key))
+}
+
+func (s *DirBackendStorage) objectPath(key string) string {
+	return filepath.Join(s.directory, key)
+}
+
+type DirBackendStorageFile struct {
+	backendStorage *DirBackendStorage
+	key            string
+	remoteFile     *volume_server_pb.RemoteFile
+
+	fileLock sync.Mutex
+	file     *os.File
+}
+
+func (f *DirBackendStorageFile) getFile() (*os.File, error) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	if f.file == nil {
+		file, err := os.Open(f.backendStorage.objectPath(f.key))
+		if err != nil {
+			return nil, err
+		}
+		f.file = file
+	}
+	return f.file, nil
+}
+
+func (f *DirBackendStorageFile) ReadAt(p []byte, off int64) (n int, err error) {
+	file, err := f.getFile()
+	if err != nil {
+		return 0, err
+	}
+	return file.ReadAt(p, off)
+}
+
+func (f *DirBackendStorageFile) WriteAt(p []byte, off int64) (n int, err error) {
+	return 0, fmt.Errorf("dir backend file %s is read only", f.key)
+}
+
+func (f *DirBackendStorageFile) Truncate(off int64) error {
+	return fmt.Errorf("dir backend file %s is read only", f.key)
+}
+
+func (f *DirBackendStorageFile) Close() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *DirBackendStorageFile) GetStat() (datSize int64, modTime time.Time, err error) {
+	return int64(f.remoteFile.FileSize), time.Unix(int64(f.remoteFile.ModifiedTime), 0), nil
+}
+
+func (f *DirBackendStorageFile) Name() string {
+	return f.key
+}
@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"io"
+)
+
+// copyWithProgress copies src to dst, and reports the progress after each buffer
+func copyWithProgress(dst io.Writer, src io.Reader, totalSize int64, fn func(progressed int64, percentage float32) error) (written int64, err error) {
+	buf := make([]byte, 4*1024*1024)
+	for {
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			if _, writeErr := dst.Write(buf[:n]); writeErr != nil {
+				return written, writeErr
+			}
+			written += int64(n)
+			if fn != nil {
+				if err = fn(written, ProgressPercentage(written, totalSize)); err != nil {
+					return written, err
+				}
+			}
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
+
+func ProgressPercentage(progressed, totalSize int64) float32 {
+	if totalSize <= 0 {
+		return 100
+	}
+	return float32(progressed*1000/totalSize) / 10
+}
@@ -0,0 +1,166 @@
+package s3_backend
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/satori/uuid"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+func init() {
+	backend.BackendStorageFactories["s3"] = &S3BackendFactory{}
+}
+
+type S3BackendFactory struct {
+}
+
+func (factory *S3BackendFactory) StorageType() backend.StorageType {
+	return backend.StorageType("s3")
+}
+func (factory *S3BackendFactory) BuildStorage(configuration util.Configuration, id string) (backend.BackendStorage, error) {
+	return newS3BackendStorage(configuration, id)
+}
+
+type S3BackendStorage struct {
+	id                    string
+	aws_access_key_id     string
+	aws_secret_access_key string
+	region                string
+	bucket                string
+	endpoint              string
+	conn                  s3iface.S3API
+}
+
+func newS3BackendStorage(configuration util.Configuration, id string) (s *S3BackendStorage, err error) {
+	s = &S3BackendStorage{}
+	s.id = id
+	s.aws_access_key_id = configuration.GetString("aws_access_key_id")
+	s.aws_secret_access_key = configuration.GetString("aws_secret_access_key")
+	s.region = configuration.GetString("region")
+	s.bucket = configuration.GetString("bucket")
+	s.endpoint = configuration.GetString("endpoint")
+
+	s.conn, err = createSession(s.aws_access_key_id, s.aws_secret_access_key, s.region, s.endpoint)
+
+	glog.V(0).Infof("created backend storage s3.%s for region %s bucket %s", s.id, s.region, s.bucket)
+	return
+}
+
+func (s *S3BackendStorage) NewStorageFile(remoteFile *volume_server_pb.RemoteFile) backend.BackendStorageFile {
+	return &S3BackendStorageFile{
+		backendStorage: s,
+		key:            remoteFile.Key,
+		remoteFile:     remoteFile,
+	}
+}
+
+func (s *S3BackendStorage) CopyFile(f *os.File, fn func(progressed int64, percentage float32) error) (key string, size int64, err error) {
+	randomUuid := uuid.NewV4()
+	key = randomUuid.String()
+
+	glog.V(1).Infof("copying dat file of %s to remote s3.%s as %s", f.Name(), s.id, key)
+
+	size, err = uploadToS3(s.conn, f.Name(), s.bucket, key, fn)
+
+	return
+}
+
+func (s *S3BackendStorage) DownloadFile(fileName string, key string, fn func(progressed int64, percentage float32) error) (size int64, err error) {
+
+	glog.V(1).Infof("download dat file of %s from remote s3.%s as %s", fileName, s.id, key)
+
+	size, err = downloadFromS3(s.conn, fileName, s.bucket, key, fn)
+
+	return
+}
+
+func (s *S3BackendStorage) DeleteFile(key string) (err error) {
+
+	glog.V(1).Infof("delete dat file %s from remote", key)
+
+	err = deleteFromS3(s.conn, s.bucket, key)
+
+	return
+}
+
+type S3BackendStorageFile struct {
+	backendStorage *S3BackendStorage
+	key            string
+	remoteFile     *volume_server_pb.RemoteFile
+}
+
+// ReadAt reads the needle with a ranged GET
+func (s3backendStorageFile S3BackendStorageFile) ReadAt(p []byte, off int64) (n int, err error) {
+
+	bytesRange := fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1)
+
+	getObjectOutput, getObjectErr := s3backendStorageFile.backendStorage.conn.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s3backendStorageFile.backendStorage.bucket),
+		Key:    aws.String(s3backendStorageFile.key),
+		Range:  aws.String(bytesRange),
+	})
+
+	if getObjectErr != nil {
+		return 0, fmt.Errorf("bucket %s GetObject %s: %v", s3backendStorageFile.backendStorage.bucket, s3backendStorageFile.key, getObjectErr)
+	}
+	defer getObjectOutput.Body.Close()
+
+	glog.V(4).Infof("read %s %s", s3backendStorageFile.key, bytesRange)
+
+	for {
+		var m int
+		m, err = getObjectOutput.Body.Read(p[n:])
+		n += m
+		if err != nil || n == len(p) {
+			break
+		}
+	}
+	if err == io.EOF {
+		if n < len(p) {
+			return n, io.EOF
+		}
+		err = nil
+	}
+
+	return
+}
+
+func (s3backendStorageFile S3BackendStorageFile) WriteAt(p []byte, off int64) (n int, err error) {
+	return 0, fmt.Errorf("s3 backend file %s is read only", s3backendStorageFile.key)
+}
+
+func (s3backendStorageFile S3BackendStorageFile) Truncate(off int64) error {
+	return fmt.Errorf("s3 backend file %s is read only", s3backendStorageFile.key)
+}
+
+func (s3backendStorageFile S3BackendStorageFile) Close() error {
+	return nil
+}
+
+func (s3backendStorageFile S3BackendStorageFile) GetStat() (datSize int64, modTime time.Time, err error) {
+
+	files := s3backendStorageFile.remoteFile
+
+	if files == nil {
+		err = fmt.Errorf("remote file info not found")
+		return
+	}
+
+	datSize = int64(files.FileSize)
+	modTime = time.Unix(int64(files.ModifiedTime), 0)
+
+	return
+}
+
+func (s3backendStorageFile S3BackendStorageFile) Name() string {
+	return s3backendStorageFile.key
+}
@@ -0,0 +1,68 @@
+package s3_backend
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+var (
+	s3Sessions   = make(map[string]s3iface.S3API)
+	sessionsLock sync.RWMutex
+)
+
+func getSession(region string) (s3iface.S3API, bool) {
+	sessionsLock.RLock()
+	defer sessionsLock.RUnlock()
+
+	sess, found := s3Sessions[region]
+	return sess, found
+}
+
+func createSession(awsAccessKeyId, awsSecretAccessKey, region, endpoint string) (s3iface.S3API, error) {
+
+	sessionKey := region + "@" + endpoint
+	if t, found := getSession(sessionKey); found {
+		return t, nil
+	}
+
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+
+	config := &aws.Config{
+		Region: aws.String(region),
+	}
+	if endpoint != "" {
+		// S3 compatible services, e.g., MinIO, usually need the path style
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	if awsAccessKeyId != "" && awsSecretAccessKey != "" {
+		config.Credentials = credentials.NewStaticCredentials(awsAccessKeyId, awsSecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create aws session in region %s: %v", region, err)
+	}
+
+	t := s3.New(sess)
+
+	s3Sessions[sessionKey] = t
+
+	return t, nil
+
+}
+
+func deleteFromS3(sess s3iface.S3API, sourceBucket string, sourceKey string) (err error) {
+	_, err = sess.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(sourceBucket),
+		Key:    aws.String(sourceKey),
+	})
+	return err
+}
@@ -0,0 +1,84 @@
+package s3_backend
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
+)
+
+func uploadToS3(sess s3iface.S3API, filename string, destBucket string, destKey string,
+	fn func(progressed int64, percentage float32) error) (fileSize int64, err error) {
+
+	//open the file
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %q, %v", filename, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat file %q, %v", filename, err)
+	}
+
+	fileSize = info.Size()
+
+	partSize := int64(64 * 1024 * 1024) // The minimum/default allowed part size is 5MB
+	for partSize*1000 < fileSize {
+		partSize *= 4
+	}
+
+	// Create an uploader with the session and custom options
+	uploader := s3manager.NewUploaderWithClient(sess, func(u *s3manager.Uploader) {
+		u.PartSize = partSize
+		u.Concurrency = 5
+	})
+
+	fileReader := &s3UploadProgressedReader{
+		fp:   f,
+		size: fileSize,
+		fn:   fn,
+	}
+
+	// Upload the file to S3.
+	var result *s3manager.UploadOutput
+	result, err = uploader.Upload(&s3manager.UploadInput{
+		Bucket:       aws.String(destBucket),
+		Key:          aws.String(destKey),
+		Body:         fileReader,
+		StorageClass: aws.String("STANDARD_IA"),
+	})
+
+	//in case it fails to upload
+	if err != nil {
+		return 0, fmt.Errorf("failed to upload file %s: %v", filename, err)
+	}
+	glog.V(1).Infof("file %s uploaded to %s\n", filename, result.Location)
+
+	return
+}
+
+// reports the progress while s3manager reads the file
+type s3UploadProgressedReader struct {
+	fp   *os.File
+	size int64
+	read int64
+	fn   func(progressed int64, percentage float32) error
+}
+
+func (r *s3UploadProgressedReader) Read(p []byte) (int, error) {
+	n, err := r.fp.Read(p)
+	if n > 0 && r.fn != nil {
+		read := atomic.AddInt64(&r.read, int64(n))
+		if fnErr := r.fn(read, backend.ProgressPercentage(read, r.size)); fnErr != nil {
+			return n, fnErr
+		}
+	}
+	return n, err
+}
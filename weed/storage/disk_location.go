@@ -4,30 +4,53 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"strings"
 	"sync"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
 type DiskLocation struct {
 	Directory      string
 	MaxVolumeCount int
+	DiskType       DiskType
 	volumes        map[VolumeId]*Volume
 	sync.RWMutex
+
+	// erasure coding
+	ecVolumes     map[VolumeId]*EcVolume
+	ecVolumesLock sync.RWMutex
 }
 
-func NewDiskLocation(dir string, maxVolumeCount int) *DiskLocation {
-	location := &DiskLocation{Directory: dir, MaxVolumeCount: maxVolumeCount}
+func NewDiskLocation(dir string, maxVolumeCount int, diskType DiskType) *DiskLocation {
+	location := &DiskLocation{Directory: dir, MaxVolumeCount: maxVolumeCount, DiskType: diskType}
 	location.volumes = make(map[VolumeId]*Volume)
+	location.ecVolumes = make(map[VolumeId]*EcVolume)
 	return location
 }
 
+// isVolumeFile finds a volume by its .dat file, or by its .vif file if the .dat file is on a remote tier
+func (l *DiskLocation) isVolumeFile(dir os.FileInfo) bool {
+	name := dir.Name()
+	if dir.IsDir() {
+		return false
+	}
+	if strings.HasSuffix(name, ".dat") {
+		return true
+	}
+	if strings.HasSuffix(name, ".vif") {
+		return !util.FileExists(path.Join(l.Directory, name[:len(name)-len(".vif")]+".dat"))
+	}
+	return false
+}
+
 func (l *DiskLocation) volumeIdFromPath(dir os.FileInfo) (VolumeId, string, error) {
 	name := dir.Name()
-	if !dir.IsDir() && strings.HasSuffix(name, ".dat") {
+	if l.isVolumeFile(dir) {
 		collection := ""
-		base := name[:len(name)-len(".dat")]
+		base := name[:len(name)-len(path.Ext(name))]
 		i := strings.LastIndex(base, "_")
 		if i > 0 {
 			collection, base = base[0:i], base[i+1:]
@@ -39,9 +62,9 @@ func (l *DiskLocation) volumeIdFromPath(dir os.FileInfo) (VolumeId, string, erro
 	return 0, "", fmt.Errorf("Path is not a volume: %s", name)
 }
 
-func (l *DiskLocation) loadExistingVolume(dir os.FileInfo, needleMapKind NeedleMapType, mutex *sync.RWMutex) {
+func (l *DiskLocation) loadExistingVolume(dir os.FileInfo, needleMapKind NeedleMapType, readOnly bool, mutex *sync.RWMutex) {
 	name := dir.Name()
-	if !dir.IsDir() && strings.HasSuffix(name, ".dat") {
+	if l.isVolumeFile(dir) {
 		vid, collection, err := l.volumeIdFromPath(dir)
 		if err == nil {
 			mutex.RLock()
@@ -49,6 +72,9 @@ func (l *DiskLocation) loadExistingVolume(dir os.FileInfo, needleMapKind NeedleM
 			mutex.RUnlock()
 			if !found {
 				if v, e := NewVolume(l.Directory, collection, vid, needleMapKind, nil, nil, 0); e == nil {
+					if readOnly {
+						v.readOnly = true
+					}
 					mutex.Lock()
 					l.volumes[vid] = v
 					mutex.Unlock()
@@ -81,7 +107,7 @@ func (l *DiskLocation) concurrentLoadingVolumes(needleMapKind NeedleMapType, con
 		go func() {
 			defer wg.Done()
 			for dir := range task_queue {
-				l.loadExistingVolume(dir, needleMapKind, &mutex)
+				l.loadExistingVolume(dir, needleMapKind, false, &mutex)
 			}
 		}()
 	}
@@ -96,6 +122,11 @@ func (l *DiskLocation) loadExistingVolumes(needleMapKind NeedleMapType) {
 	l.concurrentLoadingVolumes(needleMapKind, 10)
 
 	glog.V(0).Infoln("Store started on dir:", l.Directory, "with", len(l.volumes), "volumes", "max", l.MaxVolumeCount)
+
+	if err := l.loadAllEcShards(); err != nil {
+		glog.Errorf("load ec shards from dir %s: %v", l.Directory, err)
+	}
+	glog.V(0).Infoln("Store started on dir:", l.Directory, "with", l.EcVolumesLen(), "ec volumes")
 }
 
 func (l *DiskLocation) DeleteCollectionFromDiskLocation(collection string) (e error) {
@@ -104,7 +135,7 @@ func (l *DiskLocation) DeleteCollectionFromDiskLocation(collection string) (e er
 
 	for k, v := range l.volumes {
 		if v.Collection == collection {
-			e = l.deleteVolumeById(k)
+			e = l.deleteVolumeById(k, false)
 			if e != nil {
 				return
 			}
@@ -113,12 +144,17 @@ func (l *DiskLocation) DeleteCollectionFromDiskLocation(collection string) (e er
 	return
 }
 
-func (l *DiskLocation) deleteVolumeById(vid VolumeId) (e error) {
+// deleteVolumeById deletes the volume, which is required to be writable unless allowReadOnly
+func (l *DiskLocation) deleteVolumeById(vid VolumeId, allowReadOnly bool) (e error) {
 	v, ok := l.volumes[vid]
 	if !ok {
 		return
 	}
-	e = v.Destroy()
+	if allowReadOnly {
+		e = v.destroy()
+	} else {
+		e = v.Destroy()
+	}
 	if e != nil {
 		return
 	}
@@ -126,13 +162,14 @@ func (l *DiskLocation) deleteVolumeById(vid VolumeId) (e error) {
 	return
 }
 
-func (l *DiskLocation) LoadVolume(vid VolumeId, needleMapKind NeedleMapType) bool {
+// LoadVolume loads the volume from disk. A read-only volume is already read-only when it becomes visible.
+func (l *DiskLocation) LoadVolume(vid VolumeId, needleMapKind NeedleMapType, readOnly bool) bool {
 	if dirs, err := ioutil.ReadDir(l.Directory); err == nil {
 		for _, dir := range dirs {
 			volId, _, err := l.volumeIdFromPath(dir)
 			if vid == volId && err == nil {
 				var mutex sync.RWMutex
-				l.loadExistingVolume(dir, needleMapKind, &mutex)
+				l.loadExistingVolume(dir, needleMapKind, readOnly, &mutex)
 				return true
 			}
 		}
@@ -141,7 +178,7 @@ func (l *DiskLocation) LoadVolume(vid VolumeId, needleMapKind NeedleMapType) boo
 	return false
 }
 
-func (l *DiskLocation) DeleteVolume(vid VolumeId) error {
+func (l *DiskLocation) DeleteVolume(vid VolumeId, allowReadOnly bool) error {
 	l.Lock()
 	defer l.Unlock()
 
@@ -149,7 +186,7 @@ func (l *DiskLocation) DeleteVolume(vid VolumeId) error {
 	if !ok {
 		return fmt.Errorf("Volume not found, VolumeId: %d", vid)
 	}
-	return l.deleteVolumeById(vid)
+	return l.deleteVolumeById(vid, allowReadOnly)
 }
 
 func (l *DiskLocation) UnloadVolume(vid VolumeId) error {
@@ -194,5 +231,12 @@ func (l *DiskLocation) Close() {
 	for _, v := range l.volumes {
 		v.Close()
 	}
+
+	l.ecVolumesLock.Lock()
+	for _, ecVolume := range l.ecVolumes {
+		ecVolume.Close()
+	}
+	l.ecVolumesLock.Unlock()
+
 	return
 }
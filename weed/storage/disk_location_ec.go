@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+)
+
+var (
+	re = regexp.MustCompile("\\.ec[0-9][0-9]")
+)
+
+func (l *DiskLocation) FindEcShard(vid VolumeId, shardId erasure_coding.ShardId) (*EcVolumeShard, bool) {
+	l.ecVolumesLock.RLock()
+	defer l.ecVolumesLock.RUnlock()
+
+	ecVolume, ok := l.ecVolumes[vid]
+	if !ok {
+		return nil, false
+	}
+	return ecVolume.FindEcVolumeShard(shardId)
+}
+
+func (l *DiskLocation) FindEcVolume(vid VolumeId) (*EcVolume, bool) {
+	l.ecVolumesLock.RLock()
+	defer l.ecVolumesLock.RUnlock()
+
+	ecVolume, ok := l.ecVolumes[vid]
+	if ok {
+		return ecVolume, true
+	}
+	return nil, false
+}
+
+func (l *DiskLocation) DestroyEcVolume(vid VolumeId) {
+	l.ecVolumesLock.Lock()
+	defer l.ecVolumesLock.Unlock()
+
+	ecVolume, found := l.ecVolumes[vid]
+	if found {
+		ecVolume.Destroy()
+		delete(l.ecVolumes, vid)
+	}
+}
+
+func (l *DiskLocation) EcVolumesLen() int {
+	l.ecVolumesLock.RLock()
+	defer l.ecVolumesLock.RUnlock()
+
+	return len(l.ecVolumes)
+}
+
+// EcShardCount returns the number of ec shards in this location
+func (l *DiskLocation) EcShardCount() (count int) {
+	l.ecVolumesLock.RLock()
+	defer l.ecVolumesLock.RUnlock()
+
+	for _, ecVolume := range l.ecVolumes {
+		count += ecVolume.ShardCount()
+	}
+	return
+}
+
+func (l *DiskLocation) LoadEcShard(collection string, vid VolumeId, shardId erasure_coding.ShardId) (err error) {
+
+	ecVolumeShard, err := NewEcVolumeShard(l.Directory, collection, vid, shardId)
+	if err != nil {
+		return fmt.Errorf("failed to create ec shard %d.%d: %v", vid, shardId, err)
+	}
+	l.ecVolumesLock.Lock()
+	defer l.ecVolumesLock.Unlock()
+	ecVolume, found := l.ecVolumes[vid]
+	if !found {
+		ecVolume, err = NewEcVolume(l.Directory, collection, vid)
+		if err != nil {
+			ecVolumeShard.Close()
+			return fmt.Errorf("failed to create ec volume %d: %v", vid, err)
+		}
+		l.ecVolumes[vid] = ecVolume
+	}
+	if !ecVolume.AddEcVolumeShard(ecVolumeShard) {
+		ecVolumeShard.Close()
+	}
+
+	return nil
+}
+
+func (l *DiskLocation) UnloadEcShard(vid VolumeId, shardId erasure_coding.ShardId) bool {
+
+	l.ecVolumesLock.Lock()
+	defer l.ecVolumesLock.Unlock()
+
+	ecVolume, found := l.ecVolumes[vid]
+	if !found {
+		return false
+	}
+	if ecShard, deleted := ecVolume.DeleteEcVolumeShard(shardId); deleted {
+		ecShard.Close()
+	} else {
+		return false
+	}
+	if ecVolume.ShardCount() == 0 {
+		delete(l.ecVolumes, vid)
+		ecVolume.Close()
+	}
+
+	return true
+}
+
+func (l *DiskLocation) loadEcShards(shards []string, collection string, vid VolumeId) (err error) {
+
+	for _, shard := range shards {
+		shardId, err := strconv.ParseInt(path.Ext(shard)[3:], 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse ec shard name %v: %v", shard, err)
+		}
+
+		err = l.LoadEcShard(collection, vid, erasure_coding.ShardId(shardId))
+		if err != nil {
+			return fmt.Errorf("failed to load ec shard %v: %v", shard, err)
+		}
+	}
+
+	return nil
+}
+
+func (l *DiskLocation) loadAllEcShards() (err error) {
+
+	fileInfos, err := ioutil.ReadDir(l.Directory)
+	if err != nil {
+		return fmt.Errorf("load all ec shards in dir %s: %v", l.Directory, err)
+	}
+
+	sort.Slice(fileInfos, func(i, j int) bool {
+		return fileInfos[i].Name() < fileInfos[j].Name()
+	})
+
+	var sameVolumeShards []string
+	var prevVolumeId VolumeId
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+		ext := path.Ext(fileInfo.Name())
+		name := fileInfo.Name()
+		baseName := name[:len(name)-len(ext)]
+
+		collection, volumeId, err := parseCollectionVolumeId(baseName)
+		if err != nil {
+			continue
+		}
+
+		if re.MatchString(ext) {
+			if prevVolumeId == 0 || volumeId == prevVolumeId {
+				sameVolumeShards = append(sameVolumeShards, fileInfo.Name())
+			} else {
+				sameVolumeShards = []string{fileInfo.Name()}
+			}
+			prevVolumeId = volumeId
+			continue
+		}
+
+		// the .ecx file is sorted after all the shards of the same volume
+		if ext == ".ecx" && volumeId == prevVolumeId {
+			if err = l.loadEcShards(sameVolumeShards, collection, volumeId); err != nil {
+				return fmt.Errorf("loadEcShards collection:%v volumeId:%d : %v", collection, volumeId, err)
+			}
+			prevVolumeId = volumeId
+			sameVolumeShards = nil
+			continue
+		}
+
+	}
+	return nil
+}
+
+func parseCollectionVolumeId(base string) (collection string, vid VolumeId, err error) {
+	i := strings.LastIndex(base, "_")
+	if i > 0 {
+		collection, base = base[0:i], base[i+1:]
+	}
+	vid, err = NewVolumeId(base)
+	return
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiskType is the type of the disk of a volume directory.
+// The hard drive is the empty type, so the volumes and servers not aware of the disk types are on hard drives.
+type DiskType string
+
+const (
+	HardDriveType DiskType = ""
+	SsdType       DiskType = "ssd"
+)
+
+func ToDiskType(s string) (DiskType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "hdd":
+		return HardDriveType, nil
+	case "ssd":
+		return SsdType, nil
+	}
+	return HardDriveType, fmt.Errorf("unknown disk type %q", s)
+}
+
+func (diskType DiskType) String() string {
+	if diskType == HardDriveType {
+		return "hdd"
+	}
+	return string(diskType)
+}
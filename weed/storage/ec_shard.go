@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+)
+
+type EcVolumeShard struct {
+	VolumeId    VolumeId
+	ShardId     erasure_coding.ShardId
+	Collection  string
+	dir         string
+	ecdFile     *os.File
+	ecdFileSize int64
+}
+
+func NewEcVolumeShard(dirname string, collection string, id VolumeId, shardId erasure_coding.ShardId) (v *EcVolumeShard, e error) {
+
+	v = &EcVolumeShard{dir: dirname, Collection: collection, VolumeId: id, ShardId: shardId}
+
+	baseFileName := v.FileName()
+
+	// open ecd file
+	if v.ecdFile, e = os.OpenFile(baseFileName+erasure_coding.ToExt(int(shardId)), os.O_RDONLY, 0644); e != nil {
+		return nil, fmt.Errorf("failed to open ecd file %s: %v", baseFileName+erasure_coding.ToExt(int(shardId)), e)
+	}
+	ecdFi, statErr := v.ecdFile.Stat()
+	if statErr != nil {
+		v.ecdFile.Close()
+		return nil, fmt.Errorf("can not stat ec volume shard %s: %v", baseFileName+erasure_coding.ToExt(int(shardId)), statErr)
+	}
+	v.ecdFileSize = ecdFi.Size()
+
+	return
+}
+
+func (shard *EcVolumeShard) String() string {
+	return fmt.Sprintf("ec shard %v:%v, dir:%s, Collection:%s", shard.VolumeId, shard.ShardId, shard.dir, shard.Collection)
+}
+
+func (shard *EcVolumeShard) FileName() (fileName string) {
+	return EcShardFileName(shard.Collection, shard.dir, int(shard.VolumeId))
+}
+
+func EcShardFileName(collection string, dir string, id int) (fileName string) {
+	idString := strconv.Itoa(id)
+	if collection == "" {
+		fileName = path.Join(dir, idString)
+	} else {
+		fileName = path.Join(dir, collection+"_"+idString)
+	}
+	return
+}
+
+func (shard *EcVolumeShard) Size() int64 {
+	return shard.ecdFileSize
+}
+
+func (shard *EcVolumeShard) Close() {
+	if shard.ecdFile != nil {
+		_ = shard.ecdFile.Close()
+		shard.ecdFile = nil
+	}
+}
+
+func (shard *EcVolumeShard) Destroy() {
+	os.Remove(shard.FileName() + erasure_coding.ToExt(int(shard.ShardId)))
+}
+
+func (shard *EcVolumeShard) ReadAt(buf []byte, offset int64) (int, error) {
+	return shard.ecdFile.ReadAt(buf, offset)
+}
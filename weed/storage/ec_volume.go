@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
+)
+
+// EcVolume is a sealed volume converted into erasure coding shards.
+// It holds the sorted .ecx index and the locally mounted shards.
+type EcVolume struct {
+	VolumeId    VolumeId
+	Collection  string
+	dir         string
+	ecxFile     *os.File
+	ecxFileSize int64
+	Shards      []*EcVolumeShard
+	shardsLock  sync.RWMutex // guards Shards, and keeps the shards open while being read
+	version     Version
+	versionLock sync.RWMutex
+
+	// cached locations of all the shards, refreshed from the master
+	ShardLocations            map[erasure_coding.ShardId][]string
+	ShardLocationsRefreshTime time.Time
+	ShardLocationsLock        sync.RWMutex
+}
+
+func NewEcVolume(dir string, collection string, vid VolumeId) (ev *EcVolume, err error) {
+	ev = &EcVolume{dir: dir, Collection: collection, VolumeId: vid}
+
+	baseFileName := EcShardFileName(collection, dir, int(vid))
+
+	// open ecx file
+	if ev.ecxFile, err = os.OpenFile(baseFileName+".ecx", os.O_RDONLY, 0644); err != nil {
+		return nil, fmt.Errorf("cannot open ec volume index %s.ecx: %v", baseFileName, err)
+	}
+	ecxFi, statErr := ev.ecxFile.Stat()
+	if statErr != nil {
+		ev.ecxFile.Close()
+		return nil, fmt.Errorf("can not stat ec volume index %s.ecx: %v", baseFileName, statErr)
+	}
+	ev.ecxFileSize = ecxFi.Size()
+
+	ev.ShardLocations = make(map[erasure_coding.ShardId][]string)
+
+	return
+}
+
+func (ev *EcVolume) AddEcVolumeShard(ecVolumeShard *EcVolumeShard) bool {
+	ev.shardsLock.Lock()
+	defer ev.shardsLock.Unlock()
+
+	for _, s := range ev.Shards {
+		if s.ShardId == ecVolumeShard.ShardId {
+			return false
+		}
+	}
+	ev.Shards = append(ev.Shards, ecVolumeShard)
+	sort.Slice(ev.Shards, func(i, j int) bool {
+		return ev.Shards[i].ShardId < ev.Shards[j].ShardId
+	})
+	return true
+}
+
+func (ev *EcVolume) DeleteEcVolumeShard(shardId erasure_coding.ShardId) (ecVolumeShard *EcVolumeShard, deleted bool) {
+	ev.shardsLock.Lock()
+	defer ev.shardsLock.Unlock()
+
+	foundPosition := -1
+	for i, s := range ev.Shards {
+		if s.ShardId == shardId {
+			foundPosition = i
+		}
+	}
+	if foundPosition < 0 {
+		return nil, false
+	}
+
+	ecVolumeShard = ev.Shards[foundPosition]
+	ev.Shards = append(ev.Shards[:foundPosition], ev.Shards[foundPosition+1:]...)
+	return ecVolumeShard, true
+}
+
+func (ev *EcVolume) FindEcVolumeShard(shardId erasure_coding.ShardId) (ecVolumeShard *EcVolumeShard, found bool) {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	return ev.findEcVolumeShard(shardId)
+}
+
+func (ev *EcVolume) findEcVolumeShard(shardId erasure_coding.ShardId) (ecVolumeShard *EcVolumeShard, found bool) {
+	for _, s := range ev.Shards {
+		if s.ShardId == shardId {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// ShardCount returns the number of the locally mounted shards
+func (ev *EcVolume) ShardCount() int {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	return len(ev.Shards)
+}
+
+func (ev *EcVolume) Close() {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	for _, s := range ev.Shards {
+		s.Close()
+	}
+	if ev.ecxFile != nil {
+		_ = ev.ecxFile.Close()
+		ev.ecxFile = nil
+	}
+}
+
+// Destroy removes the local shard files and the .ecx file
+func (ev *EcVolume) Destroy() {
+
+	ev.Close()
+
+	ev.shardsLock.RLock()
+	for _, s := range ev.Shards {
+		s.Destroy()
+	}
+	ev.shardsLock.RUnlock()
+	os.Remove(ev.FileName() + ".ecx")
+}
+
+func (ev *EcVolume) FileName() string {
+	return EcShardFileName(ev.Collection, ev.dir, int(ev.VolumeId))
+}
+
+func (ev *EcVolume) ShardSize() int64 {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	if len(ev.Shards) > 0 {
+		return ev.Shards[0].Size()
+	}
+	return 0
+}
+
+func (ev *EcVolume) ShardBits() (b erasure_coding.ShardBits) {
+	ev.shardsLock.RLock()
+	defer ev.shardsLock.RUnlock()
+
+	for _, s := range ev.Shards {
+		b = b.AddShardId(s.ShardId)
+	}
+	return
+}
+
+func (ev *EcVolume) ToVolumeEcShardInformationMessage() *master_pb.VolumeEcShardInformationMessage {
+	return &master_pb.VolumeEcShardInformationMessage{
+		Id:          uint32(ev.VolumeId),
+		Collection:  ev.Collection,
+		EcIndexBits: uint32(ev.ShardBits()),
+	}
+}
+
+// LocateEcShardNeedle finds the needle offset and size from the .ecx file,
+// and the intervals in the ec shards holding the needle content
+func (ev *EcVolume) LocateEcShardNeedle(n *Needle, version Version) (offset types.Offset, size uint32, intervals []erasure_coding.Interval, err error) {
+
+	// find the needle from ecx file
+	offset, size, err = erasure_coding.SearchNeedleFromSortedIndex(ev.ecxFile, ev.ecxFileSize, n.Id)
+	if err != nil {
+		return types.Offset{}, 0, nil, fmt.Errorf("FindNeedleFromEcx: %v", err)
+	}
+
+	shardSize := ev.ShardSize()
+
+	// calculate the locations in the ec shards
+	intervals = erasure_coding.LocateData(erasure_coding.ErasureCodingLargeBlockSize, erasure_coding.ErasureCodingSmallBlockSize, shardSize, offset.ToAcutalOffset(), int(getActualSize(size, version)))
+
+	return
+}
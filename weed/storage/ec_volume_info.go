@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+)
+
+// EcVolumeInfo is the master side view of the ec shards of one volume on one data node
+type EcVolumeInfo struct {
+	VolumeId   VolumeId
+	Collection string
+	ShardBits  erasure_coding.ShardBits
+}
+
+func NewEcVolumeInfo(collection string, vid VolumeId, shardBits erasure_coding.ShardBits) *EcVolumeInfo {
+	return &EcVolumeInfo{
+		Collection: collection,
+		VolumeId:   vid,
+		ShardBits:  shardBits,
+	}
+}
+
+func (ecInfo *EcVolumeInfo) AddShardId(id erasure_coding.ShardId) {
+	ecInfo.ShardBits = ecInfo.ShardBits.AddShardId(id)
+}
+
+func (ecInfo *EcVolumeInfo) RemoveShardId(id erasure_coding.ShardId) {
+	ecInfo.ShardBits = ecInfo.ShardBits.RemoveShardId(id)
+}
+
+func (ecInfo *EcVolumeInfo) HasShardId(id erasure_coding.ShardId) bool {
+	return ecInfo.ShardBits.HasShardId(id)
+}
+
+func (ecInfo *EcVolumeInfo) ShardIds() (ret []erasure_coding.ShardId) {
+	return ecInfo.ShardBits.ShardIds()
+}
+
+func (ecInfo *EcVolumeInfo) ShardIdCount() (count int) {
+	return ecInfo.ShardBits.ShardIdCount()
+}
+
+// Minus returns the shards in ecInfo but not in other
+func (ecInfo *EcVolumeInfo) Minus(other *EcVolumeInfo) *EcVolumeInfo {
+	return &EcVolumeInfo{
+		VolumeId:   ecInfo.VolumeId,
+		Collection: ecInfo.Collection,
+		ShardBits:  ecInfo.ShardBits.Minus(other.ShardBits),
+	}
+}
+
+func (ecInfo *EcVolumeInfo) ToVolumeEcShardInformationMessage() *master_pb.VolumeEcShardInformationMessage {
+	return &master_pb.VolumeEcShardInformationMessage{
+		Id:          uint32(ecInfo.VolumeId),
+		EcIndexBits: uint32(ecInfo.ShardBits),
+		Collection:  ecInfo.Collection,
+	}
+}
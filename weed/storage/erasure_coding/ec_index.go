@@ -0,0 +1,58 @@
+package erasure_coding
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+const (
+	NeedleMapEntrySize = types.NeedleIdSize + types.OffsetSize + types.SizeSize
+)
+
+var (
+	NotFoundError = errors.New("needle not found")
+)
+
+func ToIndexEntryBytes(key types.NeedleId, offset types.Offset, size uint32) []byte {
+	bytes := make([]byte, NeedleMapEntrySize)
+	types.NeedleIdToBytes(bytes[0:types.NeedleIdSize], key)
+	types.OffsetToBytes(bytes[types.NeedleIdSize:types.NeedleIdSize+types.OffsetSize], offset)
+	util.Uint32toBytes(bytes[types.NeedleIdSize+types.OffsetSize:types.NeedleIdSize+types.OffsetSize+types.SizeSize], size)
+	return bytes
+}
+
+func FromIndexEntryBytes(bytes []byte) (key types.NeedleId, offset types.Offset, size uint32) {
+	key = types.BytesToNeedleId(bytes[:types.NeedleIdSize])
+	offset = types.BytesToOffset(bytes[types.NeedleIdSize : types.NeedleIdSize+types.OffsetSize])
+	size = util.BytesToUint32(bytes[types.NeedleIdSize+types.OffsetSize : types.NeedleIdSize+types.OffsetSize+types.SizeSize])
+	return
+}
+
+// SearchNeedleFromSortedIndex binary searches the sorted .ecx file for the needle id
+func SearchNeedleFromSortedIndex(ecxFile *os.File, ecxFileSize int64, needleId types.NeedleId) (offset types.Offset, size uint32, err error) {
+	var key types.NeedleId
+	buf := make([]byte, NeedleMapEntrySize)
+	l, h := int64(0), ecxFileSize/NeedleMapEntrySize
+	for l < h {
+		m := (l + h) / 2
+		if _, err = ecxFile.ReadAt(buf, m*NeedleMapEntrySize); err != nil {
+			return types.Offset{}, 0, fmt.Errorf("ecx file %d read at %d: %v", ecxFileSize, m*NeedleMapEntrySize, err)
+		}
+		key, offset, size = FromIndexEntryBytes(buf)
+		if key == needleId {
+			return
+		}
+		if key < needleId {
+			l = m + 1
+		} else {
+			h = m
+		}
+	}
+
+	err = NotFoundError
+	return
+}
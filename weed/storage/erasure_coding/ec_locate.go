@@ -0,0 +1,88 @@
+package erasure_coding
+
+type Interval struct {
+	BlockIndex          int
+	InnerBlockOffset    int64
+	Size                int
+	IsLargeBlock        bool
+	LargeBlockRowsCount int
+}
+
+// LocateData finds the intervals in the ec shards for the data at offset with size in the original .dat file.
+// shardDatSize is the size of one ec shard file, which all have the same size.
+func LocateData(largeBlockLength, smallBlockLength int64, shardDatSize int64, offset int64, size int) (intervals []Interval) {
+	// the last row is always a small block row, see encodeDatFile()
+	nLargeBlockRows := int((shardDatSize - 1) / largeBlockLength)
+	if shardDatSize <= 0 {
+		nLargeBlockRows = 0
+	}
+
+	blockIndex, isLargeBlock, innerBlockOffset := locateOffset(largeBlockLength, smallBlockLength, nLargeBlockRows, offset)
+
+	for size > 0 {
+		interval := Interval{
+			BlockIndex:          blockIndex,
+			InnerBlockOffset:    innerBlockOffset,
+			IsLargeBlock:        isLargeBlock,
+			LargeBlockRowsCount: nLargeBlockRows,
+		}
+
+		blockRemaining := largeBlockLength - innerBlockOffset
+		if !isLargeBlock {
+			blockRemaining = smallBlockLength - innerBlockOffset
+		}
+
+		if int64(size) <= blockRemaining {
+			interval.Size = size
+			intervals = append(intervals, interval)
+			return
+		}
+		interval.Size = int(blockRemaining)
+		intervals = append(intervals, interval)
+
+		size -= interval.Size
+		blockIndex += 1
+		if isLargeBlock && blockIndex == nLargeBlockRows*DataShardsCount {
+			isLargeBlock = false
+			blockIndex = 0
+		}
+		innerBlockOffset = 0
+
+	}
+	return
+}
+
+func locateOffset(largeBlockLength, smallBlockLength int64, nLargeBlockRows int, offset int64) (blockIndex int, isLargeBlock bool, innerBlockOffset int64) {
+	largeRowSize := largeBlockLength * DataShardsCount
+
+	// if the offset is within the large block area
+	if offset < int64(nLargeBlockRows)*largeRowSize {
+		isLargeBlock = true
+		blockIndex, innerBlockOffset = locateOffsetWithinBlocks(largeBlockLength, offset)
+		return
+	}
+
+	isLargeBlock = false
+	offset -= int64(nLargeBlockRows) * largeRowSize
+	blockIndex, innerBlockOffset = locateOffsetWithinBlocks(smallBlockLength, offset)
+	return
+}
+
+func locateOffsetWithinBlocks(blockLength int64, offset int64) (blockIndex int, innerBlockOffset int64) {
+	blockIndex = int(offset / blockLength)
+	innerBlockOffset = offset % blockLength
+	return
+}
+
+// ToShardIdAndOffset converts the interval to the ec shard id and the offset within that shard file
+func (interval Interval) ToShardIdAndOffset(largeBlockSize, smallBlockSize int64) (ShardId, int64) {
+	ecFileOffset := interval.InnerBlockOffset
+	rowIndex := interval.BlockIndex / DataShardsCount
+	if interval.IsLargeBlock {
+		ecFileOffset += int64(rowIndex) * largeBlockSize
+	} else {
+		ecFileOffset += int64(interval.LargeBlockRowsCount)*largeBlockSize + int64(rowIndex)*smallBlockSize
+	}
+	ecFileIndex := interval.BlockIndex % DataShardsCount
+	return ShardId(ecFileIndex), ecFileOffset
+}
@@ -0,0 +1,42 @@
+package erasure_coding
+
+type ShardId uint8
+
+// ShardBits is a bitmap of the ec shard ids, bit i is set if shard i is present
+type ShardBits uint32
+
+func (b ShardBits) AddShardId(id ShardId) ShardBits {
+	return b | (1 << id)
+}
+
+func (b ShardBits) RemoveShardId(id ShardId) ShardBits {
+	return b &^ (1 << id)
+}
+
+func (b ShardBits) HasShardId(id ShardId) bool {
+	return b&(1<<id) > 0
+}
+
+func (b ShardBits) ShardIds() (ret []ShardId) {
+	for i := ShardId(0); i < TotalShardsCount; i++ {
+		if b.HasShardId(i) {
+			ret = append(ret, i)
+		}
+	}
+	return
+}
+
+func (b ShardBits) ShardIdCount() (count int) {
+	for count = 0; b > 0; count++ {
+		b &= b - 1
+	}
+	return
+}
+
+func (b ShardBits) Minus(other ShardBits) ShardBits {
+	return b &^ other
+}
+
+func (b ShardBits) Plus(other ShardBits) ShardBits {
+	return b | other
+}
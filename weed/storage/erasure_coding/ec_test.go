@@ -0,0 +1,167 @@
+package erasure_coding
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
+)
+
+const (
+	largeBlockSize = 10000
+	smallBlockSize = 100
+)
+
+func TestEncodingDecoding(t *testing.T) {
+	for _, datSize := range []int64{1, 999, 1000, 1001, 100000, 100001, 123456, 209000} {
+		dir, err := ioutil.TempDir("", "ec")
+		if err != nil {
+			t.Fatalf("create temp dir: %v", err)
+		}
+		baseFileName := path.Join(dir, "1")
+
+		data := make([]byte, datSize)
+		rand.Read(data)
+		if err = ioutil.WriteFile(baseFileName+".dat", data, 0644); err != nil {
+			t.Fatalf("write dat file: %v", err)
+		}
+
+		if err = generateEcFiles(baseFileName, 50, largeBlockSize, smallBlockSize); err != nil {
+			t.Fatalf("generateEcFiles: %v", err)
+		}
+
+		if err = validateFiles(baseFileName, data); err != nil {
+			t.Fatalf("validate dat size %d: %v", datSize, err)
+		}
+
+		// remove some shards, and rebuild them
+		for _, shardId := range []int{0, 3, 11, 13} {
+			os.Remove(baseFileName + ToExt(shardId))
+		}
+		generatedShardIds, err := generateMissingEcFiles(baseFileName, 50, largeBlockSize, smallBlockSize)
+		if err != nil {
+			t.Fatalf("generateMissingEcFiles: %v", err)
+		}
+		if len(generatedShardIds) != 4 {
+			t.Fatalf("expected 4 generated shards, got %v", generatedShardIds)
+		}
+
+		if err = validateFiles(baseFileName, data); err != nil {
+			t.Fatalf("validate rebuilt dat size %d: %v", datSize, err)
+		}
+
+		os.RemoveAll(dir)
+	}
+}
+
+func validateFiles(baseFileName string, data []byte) error {
+	ecFiles, err := openEcFiles(baseFileName, true)
+	if err != nil {
+		return err
+	}
+	defer closeEcFiles(ecFiles)
+
+	fi, err := ecFiles[0].Stat()
+	if err != nil {
+		return err
+	}
+	shardDatSize := fi.Size()
+
+	for i := 0; i < 200; i++ {
+		offset := rand.Int63n(int64(len(data)))
+		size := rand.Intn(len(data) - int(offset) + 1)
+		if err = assertSame(ecFiles, shardDatSize, data, offset, size); err != nil {
+			return err
+		}
+	}
+	return assertSame(ecFiles, shardDatSize, data, 0, len(data))
+}
+
+func assertSame(ecFiles []*os.File, shardDatSize int64, data []byte, offset int64, size int) error {
+	var got []byte
+	for _, interval := range LocateData(largeBlockSize, smallBlockSize, shardDatSize, offset, size) {
+		shardId, shardOffset := interval.ToShardIdAndOffset(largeBlockSize, smallBlockSize)
+		buf := make([]byte, interval.Size)
+		if _, err := ecFiles[shardId].ReadAt(buf, shardOffset); err != nil {
+			return err
+		}
+		got = append(got, buf...)
+	}
+	if !bytes.Equal(got, data[offset:offset+int64(size)]) {
+		return &mismatchError{offset, size}
+	}
+	return nil
+}
+
+type mismatchError struct {
+	offset int64
+	size   int
+}
+
+func (e *mismatchError) Error() string {
+	return "data mismatch"
+}
+
+func TestSearchNeedleFromSortedIndex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ecx")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	baseFileName := path.Join(dir, "1")
+
+	var idx []byte
+	for _, key := range []uint64{5, 3, 9, 1, 7, 3} {
+		idx = append(idx, ToIndexEntryBytes(types.Uint64ToNeedleId(key), types.ToOffset(int64(key*8)), uint32(key))...)
+	}
+	// delete needle 9
+	idx = append(idx, ToIndexEntryBytes(types.Uint64ToNeedleId(9), types.Offset{}, types.TombstoneFileSize)...)
+	if err = ioutil.WriteFile(baseFileName+".idx", idx, 0644); err != nil {
+		t.Fatalf("write idx file: %v", err)
+	}
+
+	if err = WriteSortedEcxFile(baseFileName); err != nil {
+		t.Fatalf("WriteSortedEcxFile: %v", err)
+	}
+
+	ecxFile, err := os.Open(baseFileName + ".ecx")
+	if err != nil {
+		t.Fatalf("open ecx file: %v", err)
+	}
+	defer ecxFile.Close()
+	fi, _ := ecxFile.Stat()
+
+	for _, key := range []uint64{1, 3, 5, 7} {
+		offset, size, err := SearchNeedleFromSortedIndex(ecxFile, fi.Size(), types.Uint64ToNeedleId(key))
+		if err != nil {
+			t.Fatalf("search needle %d: %v", key, err)
+		}
+		if offset.ToAcutalOffset() != int64(key*8) || size != uint32(key) {
+			t.Errorf("needle %d: unexpected offset %d size %d", key, offset.ToAcutalOffset(), size)
+		}
+	}
+	for _, key := range []uint64{0, 2, 9, 10} {
+		if _, _, err := SearchNeedleFromSortedIndex(ecxFile, fi.Size(), types.Uint64ToNeedleId(key)); err != NotFoundError {
+			t.Errorf("needle %d: expected not found, got %v", key, err)
+		}
+	}
+}
+
+func TestShardBits(t *testing.T) {
+	var b ShardBits
+	b = b.AddShardId(0).AddShardId(3).AddShardId(13)
+	if b.ShardIdCount() != 3 || !b.HasShardId(3) || b.HasShardId(4) {
+		t.Errorf("unexpected shard bits %b", b)
+	}
+	b = b.RemoveShardId(3)
+	if ids := b.ShardIds(); len(ids) != 2 || ids[0] != 0 || ids[1] != 13 {
+		t.Errorf("unexpected shard ids %v", ids)
+	}
+	if b.Minus(ShardBits(1)).ShardIdCount() != 1 {
+		t.Errorf("unexpected minus result %b", b.Minus(ShardBits(1)))
+	}
+}
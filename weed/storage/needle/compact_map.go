@@ -9,6 +9,9 @@ import (
 
 const (
 	batch = 100000
+	// the values of a section grow up to the batch size, so the sparse keys do not take the memory of the full batch,
+	// e.g., the snowflake file ids of different masters are in different sections
+	initialSectionSize = 16
 )
 
 type SectionalNeedleId uint32
@@ -41,8 +44,8 @@ type OverflowExtra []SectionalNeedleValueExtra
 
 func NewCompactSection(start types.NeedleId) *CompactSection {
 	return &CompactSection{
-		values:        make([]SectionalNeedleValue, batch),
-		valuesExtra:   make([]SectionalNeedleValueExtra, batch),
+		values:        make([]SectionalNeedleValue, initialSectionSize),
+		valuesExtra:   make([]SectionalNeedleValueExtra, initialSectionSize),
 		overflow:      Overflow(make([]SectionalNeedleValue, 0)),
 		overflowExtra: OverflowExtra(make([]SectionalNeedleValueExtra, 0)),
 		start:         start,
@@ -70,6 +73,9 @@ func (cs *CompactSection) Set(key types.NeedleId, offset types.Offset, size uint
 			}
 			cs.setOverflowEntry(skey, offset, size)
 		} else {
+			if cs.counter >= len(cs.values) {
+				cs.grow()
+			}
 			p := &cs.values[cs.counter]
 			p.Key, cs.valuesExtra[cs.counter].OffsetHigher, p.OffsetLower, p.Size = skey, offset.OffsetHigher, offset.OffsetLower, size
 			//println("added index", cs.counter, "key", key, cs.values[cs.counter].Key)
@@ -80,6 +86,19 @@ func (cs *CompactSection) Set(key types.NeedleId, offset types.Offset, size uint
 	return
 }
 
+// grow doubles the values of the section, up to the batch size
+func (cs *CompactSection) grow() {
+	size := 2 * len(cs.values)
+	if size > batch {
+		size = batch
+	}
+	values := make([]SectionalNeedleValue, size)
+	valuesExtra := make([]SectionalNeedleValueExtra, size)
+	copy(values, cs.values[:cs.counter])
+	copy(valuesExtra, cs.valuesExtra[:cs.counter])
+	cs.values, cs.valuesExtra = values, valuesExtra
+}
+
 func (cs *CompactSection) setOverflowEntry(skey SectionalNeedleId, offset types.Offset, size uint32) {
 	needleValue := SectionalNeedleValue{Key: skey, OffsetLower: offset.OffsetLower, Size: size}
 	needleValueExtra := SectionalNeedleValueExtra{OffsetHigher: types.OffsetHigher{}}
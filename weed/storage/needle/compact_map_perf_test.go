@@ -33,6 +33,9 @@ func TestMemoryUsage(t *testing.T) {
 	startTime := time.Now()
 	for i := 0; i < 10; i++ {
 		indexFile, ie := os.OpenFile("../../../test/sample.idx", os.O_RDWR|os.O_RDONLY, 0644)
+		if os.IsNotExist(ie) {
+			t.Skipf("sample index: %v", ie)
+		}
 		if ie != nil {
 			log.Fatalln(ie)
 		}
@@ -3,20 +3,23 @@ package needle
 import (
 	"fmt"
 	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
 )
 
 func TestOverflow2(t *testing.T) {
 	m := NewCompactMap()
-	m.Set(NeedleId(150088), ToOffset(8), 3000073)
-	m.Set(NeedleId(150073), ToOffset(8), 3000073)
-	m.Set(NeedleId(150089), ToOffset(8), 3000073)
-	m.Set(NeedleId(150076), ToOffset(8), 3000073)
-	m.Set(NeedleId(150124), ToOffset(8), 3000073)
-	m.Set(NeedleId(150137), ToOffset(8), 3000073)
-	m.Set(NeedleId(150147), ToOffset(8), 3000073)
-	m.Set(NeedleId(150145), ToOffset(8), 3000073)
-	m.Set(NeedleId(150158), ToOffset(8), 3000073)
-	m.Set(NeedleId(150162), ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150088), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150073), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150089), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150076), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150124), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150137), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150147), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150145), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150158), types.ToOffset(8), 3000073)
+	m.Set(types.NeedleId(150162), types.ToOffset(8), 3000073)
 
 	m.Visit(func(value NeedleValue) error {
 		println("needle key:", value.Key)
@@ -26,12 +29,12 @@ func TestOverflow2(t *testing.T) {
 
 func TestIssue52(t *testing.T) {
 	m := NewCompactMap()
-	m.Set(NeedleId(10002), ToOffset(10002), 10002)
-	if element, ok := m.Get(NeedleId(10002)); ok {
+	m.Set(types.NeedleId(10002), types.ToOffset(10002), 10002)
+	if element, ok := m.Get(types.NeedleId(10002)); ok {
 		fmt.Printf("key %d ok %v %d, %v, %d\n", 10002, ok, element.Key, element.Offset, element.Size)
 	}
-	m.Set(NeedleId(10001), ToOffset(10001), 10001)
-	if element, ok := m.Get(NeedleId(10002)); ok {
+	m.Set(types.NeedleId(10001), types.ToOffset(10001), 10001)
+	if element, ok := m.Get(types.NeedleId(10002)); ok {
 		fmt.Printf("key %d ok %v %d, %v, %d\n", 10002, ok, element.Key, element.Offset, element.Size)
 	} else {
 		t.Fatal("key 10002 missing after setting 10001")
@@ -41,15 +44,15 @@ func TestIssue52(t *testing.T) {
 func TestCompactMap(t *testing.T) {
 	m := NewCompactMap()
 	for i := uint32(0); i < 100*batch; i += 2 {
-		m.Set(NeedleId(i), ToOffset(int64(i)), i)
+		m.Set(types.NeedleId(i), types.ToOffset(int64(i)), i)
 	}
 
 	for i := uint32(0); i < 100*batch; i += 37 {
-		m.Delete(NeedleId(i))
+		m.Delete(types.NeedleId(i))
 	}
 
 	for i := uint32(0); i < 10*batch; i += 3 {
-		m.Set(NeedleId(i), ToOffset(int64(i+11)), i+5)
+		m.Set(types.NeedleId(i), types.ToOffset(int64(i+11)), i+5)
 	}
 
 	//	for i := uint32(0); i < 100; i++ {
@@ -59,7 +62,7 @@ func TestCompactMap(t *testing.T) {
 	//	}
 
 	for i := uint32(0); i < 10*batch; i++ {
-		v, ok := m.Get(NeedleId(i))
+		v, ok := m.Get(types.NeedleId(i))
 		if i%3 == 0 {
 			if !ok {
 				t.Fatal("key", i, "missing!")
@@ -68,7 +71,7 @@ func TestCompactMap(t *testing.T) {
 				t.Fatal("key", i, "size", v.Size)
 			}
 		} else if i%37 == 0 {
-			if ok && v.Size != TombstoneFileSize {
+			if ok && v.Size != types.TombstoneFileSize {
 				t.Fatal("key", i, "should have been deleted needle value", v)
 			}
 		} else if i%2 == 0 {
@@ -79,9 +82,9 @@ func TestCompactMap(t *testing.T) {
 	}
 
 	for i := uint32(10 * batch); i < 100*batch; i++ {
-		v, ok := m.Get(NeedleId(i))
+		v, ok := m.Get(types.NeedleId(i))
 		if i%37 == 0 {
-			if ok && v.Size != TombstoneFileSize {
+			if ok && v.Size != types.TombstoneFileSize {
 				t.Fatal("key", i, "should have been deleted needle value", v)
 			}
 		} else if i%2 == 0 {
@@ -99,17 +102,17 @@ func TestCompactMap(t *testing.T) {
 func TestOverflow(t *testing.T) {
 	cs := NewCompactSection(1)
 
-	cs.setOverflowEntry(1, ToOffset(12), 12)
-	cs.setOverflowEntry(2, ToOffset(12), 12)
-	cs.setOverflowEntry(3, ToOffset(12), 12)
-	cs.setOverflowEntry(4, ToOffset(12), 12)
-	cs.setOverflowEntry(5, ToOffset(12), 12)
+	cs.setOverflowEntry(1, types.ToOffset(12), 12)
+	cs.setOverflowEntry(2, types.ToOffset(12), 12)
+	cs.setOverflowEntry(3, types.ToOffset(12), 12)
+	cs.setOverflowEntry(4, types.ToOffset(12), 12)
+	cs.setOverflowEntry(5, types.ToOffset(12), 12)
 
 	if cs.overflow[2].Key != 3 {
 		t.Fatalf("expecting o[2] has key 3: %+v", cs.overflow[2].Key)
 	}
 
-	cs.setOverflowEntry(3, ToOffset(24), 24)
+	cs.setOverflowEntry(3, types.ToOffset(24), 24)
 
 	if cs.overflow[2].Key != 3 {
 		t.Fatalf("expecting o[2] has key 3: %+v", cs.overflow[2].Key)
@@ -142,13 +145,13 @@ func TestOverflow(t *testing.T) {
 	}
 	println()
 
-	cs.setOverflowEntry(4, ToOffset(44), 44)
+	cs.setOverflowEntry(4, types.ToOffset(44), 44)
 	for i, x := range cs.overflow {
 		println("overflow[", i, "]:", x.Key)
 	}
 	println()
 
-	cs.setOverflowEntry(1, ToOffset(11), 11)
+	cs.setOverflowEntry(1, types.ToOffset(11), 11)
 
 	for i, x := range cs.overflow {
 		println("overflow[", i, "]:", x.Key)
@@ -156,3 +159,44 @@ func TestOverflow(t *testing.T) {
 	println()
 
 }
+
+func TestCompactMapSnowflakeIds(t *testing.T) {
+
+	// the ids are assigned to 7 volumes in turn, before and after the master fails over to another node
+	var ids []types.NeedleId
+	for nodeId := 5; nodeId <= 6; nodeId++ {
+		sequencer, _ := sequence.NewSnowflakeSequencer(nodeId)
+		if nodeId == 6 {
+			sequencer.SetMax(uint64(ids[len(ids)-1]))
+		}
+		for i := 0; i < 50000; i++ {
+			id, _ := sequencer.NextFileId(1)
+			if i%7 == 0 {
+				ids = append(ids, types.NeedleId(id))
+			}
+		}
+	}
+
+	m := NewCompactMap()
+	for i, id := range ids {
+		m.Set(id, types.ToOffset(int64(i+1)*8), 100)
+	}
+	for i, id := range ids {
+		if v, ok := m.Get(id); !ok || v.Offset != types.ToOffset(int64(i+1)*8) {
+			t.Fatalf("get file %d: %+v", i, v)
+		}
+	}
+
+	// the memory is bounded by the files, not by the time between them
+	if len(m.list) > 2 {
+		t.Errorf("%d sections for %d files", len(m.list), len(ids))
+	}
+	values := 0
+	for _, cs := range m.list {
+		values += len(cs.values) + len(cs.overflow)
+	}
+	if values > 2*len(ids)+len(m.list)*initialSectionSize {
+		t.Errorf("allocated %d values for %d files", values, len(ids))
+	}
+
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
 )
 
 func TestFastLoadingNeedleMapMetrics(t *testing.T) {
@@ -14,9 +15,9 @@ func TestFastLoadingNeedleMapMetrics(t *testing.T) {
 	nm := NewBtreeNeedleMap(idxFile)
 
 	for i := 0; i < 10000; i++ {
-		nm.Put(Uint64ToNeedleId(uint64(i+1)), Uint32ToOffset(uint32(0)), uint32(1))
+		nm.Put(types.Uint64ToNeedleId(uint64(i+1)), types.Uint32ToOffset(uint32(0)), uint32(1))
 		if rand.Float32() < 0.2 {
-			nm.Delete(Uint64ToNeedleId(uint64(rand.Int63n(int64(i))+1)), Uint32ToOffset(uint32(0)))
+			nm.Delete(types.Uint64ToNeedleId(uint64(rand.Int63n(int64(i+1))+1)), types.Uint32ToOffset(uint32(0)))
 		}
 	}
 
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"sync/atomic"
 
+	"google.golang.org/grpc"
+
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
 	. "gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
 )
 
@@ -17,6 +20,8 @@ const (
  * A VolumeServer contains one Store
  */
 type Store struct {
+	MasterAddress       string
+	grpcDialOption      grpc.DialOption
 	Ip                  string
 	Port                int
 	PublicUrl           string
@@ -29,6 +34,8 @@ type Store struct {
 	NeedleMapType       NeedleMapType
 	NewVolumeIdChan     chan VolumeId
 	DeletedVolumeIdChan chan VolumeId
+	NewEcShardsChan     chan master_pb.VolumeEcShardInformationMessage
+	DeletedEcShardsChan chan master_pb.VolumeEcShardInformationMessage
 }
 
 func (s *Store) String() (str string) {
@@ -36,19 +43,23 @@ func (s *Store) String() (str string) {
 	return
 }
 
-func NewStore(port int, ip, publicUrl string, dirnames []string, maxVolumeCounts []int, needleMapKind NeedleMapType) (s *Store) {
-	s = &Store{Port: port, Ip: ip, PublicUrl: publicUrl, NeedleMapType: needleMapKind}
+func NewStore(grpcDialOption grpc.DialOption, port int, ip, publicUrl string, dirnames []string, maxVolumeCounts []int, diskTypes []DiskType, needleMapKind NeedleMapType) (s *Store) {
+	s = &Store{grpcDialOption: grpcDialOption, Port: port, Ip: ip, PublicUrl: publicUrl, NeedleMapType: needleMapKind}
 	s.Locations = make([]*DiskLocation, 0)
 	for i := 0; i < len(dirnames); i++ {
-		location := NewDiskLocation(dirnames[i], maxVolumeCounts[i])
+		location := NewDiskLocation(dirnames[i], maxVolumeCounts[i], diskTypes[i])
 		location.loadExistingVolumes(needleMapKind)
 		s.Locations = append(s.Locations, location)
 	}
 	s.NewVolumeIdChan = make(chan VolumeId, 3)
 	s.DeletedVolumeIdChan = make(chan VolumeId, 3)
+
+	s.NewEcShardsChan = make(chan master_pb.VolumeEcShardInformationMessage, 3)
+	s.DeletedEcShardsChan = make(chan master_pb.VolumeEcShardInformationMessage, 3)
+
 	return
 }
-func (s *Store) AddVolume(volumeId VolumeId, collection string, needleMapKind NeedleMapType, replicaPlacement string, ttlString string, preallocate int64) error {
+func (s *Store) AddVolume(volumeId VolumeId, collection string, needleMapKind NeedleMapType, replicaPlacement string, ttlString string, preallocate int64, diskType DiskType) error {
 	rt, e := NewReplicaPlacementFromString(replicaPlacement)
 	if e != nil {
 		return e
@@ -57,7 +68,7 @@ func (s *Store) AddVolume(volumeId VolumeId, collection string, needleMapKind Ne
 	if e != nil {
 		return e
 	}
-	e = s.addVolume(volumeId, collection, needleMapKind, rt, ttl, preallocate)
+	e = s.addVolume(volumeId, collection, needleMapKind, rt, ttl, preallocate, diskType)
 	return e
 }
 func (s *Store) DeleteCollection(collection string) (e error) {
@@ -79,10 +90,17 @@ func (s *Store) findVolume(vid VolumeId) *Volume {
 	}
 	return nil
 }
-func (s *Store) FindFreeLocation() (ret *DiskLocation) {
+
+// FindFreeLocation finds the location of the disk type with the most free volume slots
+func (s *Store) FindFreeLocation(diskType DiskType) (ret *DiskLocation) {
 	max := 0
 	for _, location := range s.Locations {
+		if location.DiskType != diskType {
+			continue
+		}
 		currentFreeCount := location.MaxVolumeCount - location.VolumesLen()
+		// every DataShardsCount ec shards take up the space of one volume
+		currentFreeCount -= (location.EcShardCount() + erasure_coding.DataShardsCount - 1) / erasure_coding.DataShardsCount
 		if currentFreeCount > max {
 			max = currentFreeCount
 			ret = location
@@ -90,13 +108,13 @@ func (s *Store) FindFreeLocation() (ret *DiskLocation) {
 	}
 	return ret
 }
-func (s *Store) addVolume(vid VolumeId, collection string, needleMapKind NeedleMapType, replicaPlacement *ReplicaPlacement, ttl *TTL, preallocate int64) error {
+func (s *Store) addVolume(vid VolumeId, collection string, needleMapKind NeedleMapType, replicaPlacement *ReplicaPlacement, ttl *TTL, preallocate int64, diskType DiskType) error {
 	if s.findVolume(vid) != nil {
 		return fmt.Errorf("Volume Id %d already exists!", vid)
 	}
-	if location := s.FindFreeLocation(); location != nil {
-		glog.V(0).Infof("In dir %s adds volume:%v collection:%s replicaPlacement:%v ttl:%v",
-			location.Directory, vid, collection, replicaPlacement, ttl)
+	if location := s.FindFreeLocation(diskType); location != nil {
+		glog.V(0).Infof("In dir %s adds volume:%v collection:%s replicaPlacement:%v ttl:%v disk:%s",
+			location.Directory, vid, collection, replicaPlacement, ttl, diskType)
 		if volume, err := NewVolume(location.Directory, collection, vid, needleMapKind, replicaPlacement, ttl, preallocate); err == nil {
 			location.SetVolume(vid, volume)
 			s.NewVolumeIdChan <- vid
@@ -105,7 +123,7 @@ func (s *Store) addVolume(vid VolumeId, collection string, needleMapKind NeedleM
 			return err
 		}
 	}
-	return fmt.Errorf("No more free space left")
+	return fmt.Errorf("No more free space left on %s disks", diskType)
 }
 
 func (s *Store) Status() []*VolumeInfo {
@@ -125,6 +143,7 @@ func (s *Store) Status() []*VolumeInfo {
 				ReadOnly:         v.readOnly,
 				Ttl:              v.Ttl,
 				CompactRevision:  uint32(v.CompactRevision),
+				DiskType:         location.DiskType,
 			}
 			stats = append(stats, s)
 		}
@@ -144,19 +163,23 @@ func (s *Store) SetRack(rack string) {
 func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 	var volumeMessages []*master_pb.VolumeInformationMessage
 	maxVolumeCount := 0
+	maxVolumeCounts := make(map[string]uint32)
 	var maxFileKey NeedleId
 	for _, location := range s.Locations {
 		maxVolumeCount = maxVolumeCount + location.MaxVolumeCount
+		maxVolumeCounts[string(location.DiskType)] += uint32(location.MaxVolumeCount)
 		location.Lock()
 		for _, v := range location.volumes {
 			if maxFileKey < v.nm.MaxFileKey() {
 				maxFileKey = v.nm.MaxFileKey()
 			}
 			if !v.expired(s.GetVolumeSizeLimit()) {
-				volumeMessages = append(volumeMessages, v.ToVolumeInformationMessage())
+				volumeMessage := v.ToVolumeInformationMessage()
+				volumeMessage.DiskType = string(location.DiskType)
+				volumeMessages = append(volumeMessages, volumeMessage)
 			} else {
 				if v.expiredLongEnough(MAX_TTL_VOLUME_REMOVAL_DELAY) {
-					location.deleteVolumeById(v.Id)
+					location.deleteVolumeById(v.Id, false)
 					glog.V(0).Infoln("volume", v.Id, "is deleted.")
 				} else {
 					glog.V(0).Infoln("volume", v.Id, "is expired.")
@@ -167,14 +190,16 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 	}
 
 	return &master_pb.Heartbeat{
-		Ip:             s.Ip,
-		Port:           uint32(s.Port),
-		PublicUrl:      s.PublicUrl,
-		MaxVolumeCount: uint32(maxVolumeCount),
-		MaxFileKey:     NeedleIdToUint64(maxFileKey),
-		DataCenter:     s.dataCenter,
-		Rack:           s.rack,
-		Volumes:        volumeMessages,
+		Ip:              s.Ip,
+		Port:            uint32(s.Port),
+		PublicUrl:       s.PublicUrl,
+		MaxVolumeCount:  uint32(maxVolumeCount),
+		MaxVolumeCounts: maxVolumeCounts,
+		MaxFileKey:      NeedleIdToUint64(maxFileKey),
+		DataCenter:      s.dataCenter,
+		Rack:            s.rack,
+		Volumes:         volumeMessages,
+		EcShards:        s.collectEcShardMessages(),
 	}
 
 }
@@ -220,14 +245,34 @@ func (s *Store) GetVolume(i VolumeId) *Volume {
 	return s.findVolume(i)
 }
 
+// GetVolumeDiskType returns the disk type of the location having the volume
+func (s *Store) GetVolumeDiskType(i VolumeId) (DiskType, bool) {
+	for _, location := range s.Locations {
+		if _, found := location.FindVolume(i); found {
+			return location.DiskType, true
+		}
+	}
+	return HardDriveType, false
+}
+
 func (s *Store) HasVolume(i VolumeId) bool {
 	v := s.findVolume(i)
 	return v != nil
 }
 
 func (s *Store) MountVolume(i VolumeId) error {
+	return s.mountVolume(i, false)
+}
+
+// MountVolumeReadonly mounts the volume as read-only, before it is reported to the master,
+// e.g. for a copy still catching up with its source volume
+func (s *Store) MountVolumeReadonly(i VolumeId) error {
+	return s.mountVolume(i, true)
+}
+
+func (s *Store) mountVolume(i VolumeId, readOnly bool) error {
 	for _, location := range s.Locations {
-		if found := location.LoadVolume(i, s.NeedleMapType); found == true {
+		if found := location.LoadVolume(i, s.NeedleMapType, readOnly); found == true {
 			s.NewVolumeIdChan <- VolumeId(i)
 			return nil
 		}
@@ -247,9 +292,11 @@ func (s *Store) UnmountVolume(i VolumeId) error {
 	return fmt.Errorf("Volume %d not found on disk", i)
 }
 
-func (s *Store) DeleteVolume(i VolumeId) error {
+// DeleteVolume deletes the volume. A read-only volume is only deleted if allowReadOnly,
+// when the volume is sealed intentionally before being moved, tiered or converted to ec shards.
+func (s *Store) DeleteVolume(i VolumeId, allowReadOnly bool) error {
 	for _, location := range s.Locations {
-		if error := location.deleteVolumeById(i); error == nil {
+		if error := location.deleteVolumeById(i, allowReadOnly); error == nil {
 			s.DeletedVolumeIdChan <- VolumeId(i)
 			return nil
 		}
@@ -258,6 +305,15 @@ func (s *Store) DeleteVolume(i VolumeId) error {
 	return fmt.Errorf("Volume %d not found on disk", i)
 }
 
+func (s *Store) MarkVolumeReadonly(i VolumeId) error {
+	v := s.findVolume(i)
+	if v == nil {
+		return fmt.Errorf("volume %d not found", i)
+	}
+	v.readOnly = true
+	return nil
+}
+
 func (s *Store) SetVolumeSizeLimit(x uint64) {
 	atomic.StoreUint64(&s.volumeSizeLimit, x)
 }
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestDeleteReadOnlyVolume(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "store")
+	defer os.RemoveAll(dir)
+
+	s := NewStore(grpc.WithInsecure(), 8080, "127.0.0.1", "", []string{dir}, []int{10}, []DiskType{HardDriveType}, NeedleMapInMemory)
+	defer s.Close()
+	go func() {
+		for range s.DeletedVolumeIdChan {
+		}
+	}()
+	if err := s.AddVolume(1, "", NeedleMapInMemory, "000", "", 0, HardDriveType); err != nil {
+		t.Fatalf("add volume: %v", err)
+	}
+	<-s.NewVolumeIdChan
+	if err := s.MarkVolumeReadonly(1); err != nil {
+		t.Fatalf("mark readonly: %v", err)
+	}
+
+	if err := s.DeleteVolume(1, false); err == nil {
+		t.Fatalf("read-only volume deleted")
+	}
+	if s.HasVolume(1) == false {
+		t.Fatalf("read-only volume unloaded")
+	}
+	if err := s.DeleteVolume(1, true); err != nil {
+		t.Fatalf("delete read-only volume: %v", err)
+	}
+	if s.HasVolume(1) {
+		t.Fatalf("volume not deleted")
+	}
+	if _, err := os.Stat(dir + "/1.dat"); !os.IsNotExist(err) {
+		t.Fatalf("volume file not removed: %v", err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 )
@@ -17,7 +19,8 @@ type Volume struct {
 	Id            VolumeId
 	dir           string
 	Collection    string
-	dataFile      *os.File
+	dataFile      *os.File // the local data file for writing, nil if the data file is on a remote tier
+	DataBackend   backend.BackendStorageFile
 	nm            NeedleMapper
 	compactingWg  sync.WaitGroup
 	needleMapKind NeedleMapType
@@ -30,6 +33,8 @@ type Volume struct {
 
 	lastCompactIndexOffset uint64
 	lastCompactRevision    uint16
+
+	volumeInfo *volume_server_pb.VolumeInfo
 }
 
 func NewVolume(dirname string, collection string, id VolumeId, needleMapKind NeedleMapType, replicaPlacement *ReplicaPlacement, ttl *TTL, preallocate int64) (v *Volume, e error) {
@@ -41,7 +46,7 @@ func NewVolume(dirname string, collection string, id VolumeId, needleMapKind Nee
 	return
 }
 func (v *Volume) String() string {
-	return fmt.Sprintf("Id:%v, dir:%s, Collection:%s, dataFile:%v, nm:%v, readOnly:%v", v.Id, v.dir, v.Collection, v.dataFile, v.nm, v.readOnly)
+	return fmt.Sprintf("Id:%v, dir:%s, Collection:%s, dataFile:%v, nm:%v, readOnly:%v", v.Id, v.dir, v.Collection, v.DataBackend, v.nm, v.readOnly)
 }
 
 func VolumeFileName(collection string, dir string, id int) (fileName string) {
@@ -56,9 +61,6 @@ func VolumeFileName(collection string, dir string, id int) (fileName string) {
 func (v *Volume) FileName() (fileName string) {
 	return VolumeFileName(v.Collection, v.dir, int(v.Id))
 }
-func (v *Volume) DataFile() *os.File {
-	return v.dataFile
-}
 
 func (v *Volume) Version() Version {
 	return v.SuperBlock.Version()
@@ -68,15 +70,15 @@ func (v *Volume) Size() int64 {
 	v.dataFileAccessLock.Lock()
 	defer v.dataFileAccessLock.Unlock()
 
-	if v.dataFile == nil {
+	if v.DataBackend == nil {
 		return 0
 	}
 
-	stat, e := v.dataFile.Stat()
+	datFileSize, _, e := v.DataBackend.GetStat()
 	if e == nil {
-		return stat.Size()
+		return datFileSize
 	}
-	glog.V(0).Infof("Failed to read file size %s %v", v.dataFile.Name(), e)
+	glog.V(0).Infof("Failed to read file size %s %v", v.DataBackend.Name(), e)
 	return 0 // -1 causes integer overflow and the volume to become unwritable.
 }
 
@@ -88,6 +90,22 @@ func (v *Volume) DataFileSize() uint64 {
 	return uint64(v.Size())
 }
 
+// FileStat reads the sizes of the .dat and .idx files at the same time, between the writes,
+// so every needle in the .idx file prefix is complete in the .dat file prefix
+func (v *Volume) FileStat() (datSize uint64, idxSize uint64, err error) {
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+
+	if v.DataBackend == nil {
+		return 0, 0, fmt.Errorf("volume %d is closed", v.Id)
+	}
+	datFileSize, _, e := v.DataBackend.GetStat()
+	if e != nil {
+		return 0, 0, fmt.Errorf("stat %s: %v", v.DataBackend.Name(), e)
+	}
+	return uint64(datFileSize), v.nm.IndexFileSize(), nil
+}
+
 /**
 unix time in seconds
 */
@@ -107,8 +125,9 @@ func (v *Volume) Close() {
 		v.nm.Close()
 		v.nm = nil
 	}
-	if v.dataFile != nil {
-		_ = v.dataFile.Close()
+	if v.DataBackend != nil {
+		_ = v.DataBackend.Close()
+		v.DataBackend = nil
 		v.dataFile = nil
 	}
 }
@@ -162,17 +181,20 @@ func (v *Volume) expiredLongEnough(maxDelayMinutes uint32) bool {
 }
 
 func (v *Volume) ToVolumeInformationMessage() *master_pb.VolumeInformationMessage {
+	remoteStorageName, remoteStorageKey := v.RemoteStorageNameKey()
 	return &master_pb.VolumeInformationMessage{
-		Id:               uint32(v.Id),
-		Size:             uint64(v.Size()),
-		Collection:       v.Collection,
-		FileCount:        uint64(v.nm.FileCount()),
-		DeleteCount:      uint64(v.nm.DeletedCount()),
-		DeletedByteCount: v.nm.DeletedSize(),
-		ReadOnly:         v.readOnly,
-		ReplicaPlacement: uint32(v.ReplicaPlacement.Byte()),
-		Version:          uint32(v.Version()),
-		Ttl:              v.Ttl.ToUint32(),
-		CompactRevision:  uint32(v.SuperBlock.CompactRevision),
+		Id:                uint32(v.Id),
+		Size:              uint64(v.Size()),
+		Collection:        v.Collection,
+		FileCount:         uint64(v.nm.FileCount()),
+		DeleteCount:       uint64(v.nm.DeletedCount()),
+		DeletedByteCount:  v.nm.DeletedSize(),
+		ReadOnly:          v.readOnly,
+		ReplicaPlacement:  uint32(v.ReplicaPlacement.Byte()),
+		Version:           uint32(v.Version()),
+		Ttl:               v.Ttl.ToUint32(),
+		CompactRevision:   uint32(v.SuperBlock.CompactRevision),
+		RemoteStorageName: remoteStorageName,
+		RemoteStorageKey:  remoteStorageKey,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 	. "gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
@@ -29,7 +30,7 @@ func CheckVolumeDataIntegrity(v *Volume, indexFile *os.File) error {
 	if offset.IsZero() || size == TombstoneFileSize {
 		return nil
 	}
-	if e = verifyNeedleIntegrity(v.dataFile, v.Version(), offset.ToAcutalOffset(), key, size); e != nil {
+	if e = verifyNeedleIntegrity(v.DataBackend, v.Version(), offset.ToAcutalOffset(), key, size); e != nil {
 		return fmt.Errorf("verifyNeedleIntegrity %s failed: %v", indexFile.Name(), e)
 	}
 
@@ -55,7 +56,7 @@ func readIndexEntryAtOffset(indexFile *os.File, offset int64) (bytes []byte, err
 	return
 }
 
-func verifyNeedleIntegrity(datFile *os.File, v Version, offset int64, key NeedleId, size uint32) error {
+func verifyNeedleIntegrity(datFile backend.BackendStorageFile, v Version, offset int64, key NeedleId, size uint32) error {
 	n := new(Needle)
 	err := n.ReadData(datFile, offset, size, v)
 	if err != nil {
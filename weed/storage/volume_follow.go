@@ -22,6 +22,7 @@ func (v *Volume) GetVolumeSyncStatus() *volume_server_pb.VolumeSyncStatusRespons
 	syncStatus.CompactRevision = uint32(v.SuperBlock.CompactRevision)
 	syncStatus.Ttl = v.SuperBlock.Ttl.String()
 	syncStatus.Replication = v.SuperBlock.ReplicaPlacement.String()
+	syncStatus.ReadOnly = v.readOnly
 	return syncStatus
 }
 
@@ -57,20 +58,40 @@ update needle map when receiving new .dat bytes. But seems not necessary now.)
 
 func (v *Volume) Follow(volumeServer string, grpcDialOption grpc.DialOption) error {
 
-	ctx := context.Background()
-
-	startFromOffset := v.Size()
 	appendAtNs, err := v.findLastAppendAtNs()
 	if err != nil {
 		return err
 	}
 
-	err = operation.WithVolumeServerClient(volumeServer, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+	return v.follow(volumeServer, grpcDialOption, &volume_server_pb.VolumeFollowRequest{
+		VolumeId: uint32(v.Id),
+		Since:    appendAtNs,
+	})
+
+}
+
+// FollowFromOffset appends the bytes of the source volume after the end of this volume.
+// Unlike Follow, it does not skip the needles of the same appendAtNs, but this volume
+// needs to be a byte copy of the source volume, of the same compact revision.
+func (v *Volume) FollowFromOffset(volumeServer string, grpcDialOption grpc.DialOption) error {
+
+	return v.follow(volumeServer, grpcDialOption, &volume_server_pb.VolumeFollowRequest{
+		VolumeId:        uint32(v.Id),
+		SinceOffset:     uint64(v.Size()),
+		CompactRevision: uint32(v.SuperBlock.CompactRevision),
+	})
+
+}
+
+func (v *Volume) follow(volumeServer string, grpcDialOption grpc.DialOption, req *volume_server_pb.VolumeFollowRequest) error {
+
+	ctx := context.Background()
+
+	startFromOffset := v.Size()
+
+	err := operation.WithVolumeServerClient(volumeServer, grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
 
-		stream, err := client.VolumeFollow(ctx, &volume_server_pb.VolumeFollowRequest{
-			VolumeId: uint32(v.Id),
-			Since:    appendAtNs,
-		})
+		stream, err := client.VolumeFollow(ctx, req)
 		if err != nil {
 			return err
 		}
@@ -102,7 +123,7 @@ func (v *Volume) Follow(volumeServer string, grpcDialOption grpc.DialOption) err
 	}
 
 	// add to needle map
-	return ScanVolumeFileFrom(v.version, v.dataFile, startFromOffset, &VolumeFileScanner4GenIdx{v: v})
+	return ScanVolumeFileFrom(v.version, v.DataBackend, startFromOffset, &VolumeFileScanner4GenIdx{v: v})
 
 }
 
@@ -148,11 +169,11 @@ func (v *Volume) locateLastAppendEntry() (types.Offset, error) {
 
 func (v *Volume) readAppendAtNs(offset types.Offset) (uint64, error) {
 
-	n, bodyLength, err := ReadNeedleHeader(v.dataFile, v.SuperBlock.version, offset.ToAcutalOffset())
+	n, bodyLength, err := ReadNeedleHeader(v.DataBackend, v.SuperBlock.version, offset.ToAcutalOffset())
 	if err != nil {
 		return 0, fmt.Errorf("ReadNeedleHeader: %v", err)
 	}
-	err = n.ReadNeedleBody(v.dataFile, v.SuperBlock.version, offset.ToAcutalOffset()+int64(types.NeedleEntrySize), bodyLength)
+	err = n.ReadNeedleBody(v.DataBackend, v.SuperBlock.version, offset.ToAcutalOffset()+int64(types.NeedleEntrySize), bodyLength)
 	if err != nil {
 		return 0, fmt.Errorf("ReadNeedleBody offset %d, bodyLength %d: %v", offset.ToAcutalOffset(), bodyLength, err)
 	}
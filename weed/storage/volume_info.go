@@ -8,30 +8,36 @@ import (
 )
 
 type VolumeInfo struct {
-	Id               VolumeId
-	Size             uint64
-	ReplicaPlacement *ReplicaPlacement
-	Ttl              *TTL
-	Collection       string
-	Version          Version
-	FileCount        int
-	DeleteCount      int
-	DeletedByteCount uint64
-	ReadOnly         bool
-	CompactRevision  uint32
+	Id                VolumeId
+	Size              uint64
+	ReplicaPlacement  *ReplicaPlacement
+	Ttl               *TTL
+	Collection        string
+	Version           Version
+	FileCount         int
+	DeleteCount       int
+	DeletedByteCount  uint64
+	ReadOnly          bool
+	CompactRevision   uint32
+	RemoteStorageName string
+	RemoteStorageKey  string
+	DiskType          DiskType
 }
 
 func NewVolumeInfo(m *master_pb.VolumeInformationMessage) (vi VolumeInfo, err error) {
 	vi = VolumeInfo{
-		Id:               VolumeId(m.Id),
-		Size:             m.Size,
-		Collection:       m.Collection,
-		FileCount:        int(m.FileCount),
-		DeleteCount:      int(m.DeleteCount),
-		DeletedByteCount: m.DeletedByteCount,
-		ReadOnly:         m.ReadOnly,
-		Version:          Version(m.Version),
-		CompactRevision:  m.CompactRevision,
+		Id:                VolumeId(m.Id),
+		Size:              m.Size,
+		Collection:        m.Collection,
+		FileCount:         int(m.FileCount),
+		DeleteCount:       int(m.DeleteCount),
+		DeletedByteCount:  m.DeletedByteCount,
+		ReadOnly:          m.ReadOnly,
+		Version:           Version(m.Version),
+		CompactRevision:   m.CompactRevision,
+		RemoteStorageName: m.RemoteStorageName,
+		RemoteStorageKey:  m.RemoteStorageKey,
+		DiskType:          DiskType(m.DiskType),
 	}
 	rp, e := NewReplicaPlacementFromByte(byte(m.ReplicaPlacement))
 	if e != nil {
@@ -49,17 +55,20 @@ func (vi VolumeInfo) String() string {
 
 func (vi VolumeInfo) ToVolumeInformationMessage() *master_pb.VolumeInformationMessage {
 	return &master_pb.VolumeInformationMessage{
-		Id:               uint32(vi.Id),
-		Size:             uint64(vi.Size),
-		Collection:       vi.Collection,
-		FileCount:        uint64(vi.FileCount),
-		DeleteCount:      uint64(vi.DeleteCount),
-		DeletedByteCount: vi.DeletedByteCount,
-		ReadOnly:         vi.ReadOnly,
-		ReplicaPlacement: uint32(vi.ReplicaPlacement.Byte()),
-		Version:          uint32(vi.Version),
-		Ttl:              vi.Ttl.ToUint32(),
-		CompactRevision:  vi.CompactRevision,
+		Id:                uint32(vi.Id),
+		Size:              uint64(vi.Size),
+		Collection:        vi.Collection,
+		FileCount:         uint64(vi.FileCount),
+		DeleteCount:       uint64(vi.DeleteCount),
+		DeletedByteCount:  vi.DeletedByteCount,
+		ReadOnly:          vi.ReadOnly,
+		ReplicaPlacement:  uint32(vi.ReplicaPlacement.Byte()),
+		Version:           uint32(vi.Version),
+		Ttl:               vi.Ttl.ToUint32(),
+		CompactRevision:   vi.CompactRevision,
+		RemoteStorageName: vi.RemoteStorageName,
+		RemoteStorageKey:  vi.RemoteStorageKey,
+		DiskType:          string(vi.DiskType),
 	}
 }
 
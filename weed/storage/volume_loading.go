@@ -8,6 +8,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/opt"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 )
 
 func loadVolumeWithoutIndex(dirname string, collection string, id VolumeId, needleMapKind NeedleMapType) (v *Volume, e error) {
@@ -23,7 +24,15 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 	fileName := v.FileName()
 	alreadyHasSuperBlock := false
 
-	if exists, canRead, canWrite, modifiedTime, fileSize := checkFile(fileName + ".dat"); exists {
+	if v.maybeLoadVolumeInfo() && v.HasRemoteFile() {
+		// the .dat file is on a remote tier, and only the .idx file is local
+		glog.V(0).Infof("loading volume %d data file from remote %v", v.Id, v.volumeInfo.Files[0].BackendType)
+		if e = v.LoadRemoteFile(); e != nil {
+			return fmt.Errorf("load remote data file of volume %d: %v", v.Id, e)
+		}
+		v.readOnly = true
+		alreadyHasSuperBlock = true
+	} else if exists, canRead, canWrite, modifiedTime, fileSize := checkFile(fileName + ".dat"); exists {
 		if !canRead {
 			return fmt.Errorf("cannot read Volume Data file %s.dat", fileName)
 		}
@@ -46,6 +55,10 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 		}
 	}
 
+	if v.dataFile != nil {
+		v.DataBackend = backend.NewDiskFile(v.dataFile)
+	}
+
 	if e != nil {
 		if !os.IsPermission(e) {
 			return fmt.Errorf("cannot load Volume Data %s.dat: %v", fileName, e)
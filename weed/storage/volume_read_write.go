@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 	. "gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
 )
 
@@ -23,7 +24,7 @@ func (v *Volume) isFileUnchanged(n *Needle) bool {
 	nv, ok := v.nm.Get(n.Id)
 	if ok && !nv.Offset.IsZero() {
 		oldNeedle := new(Needle)
-		err := oldNeedle.ReadData(v.dataFile, nv.Offset.ToAcutalOffset(), nv.Size, v.Version())
+		err := oldNeedle.ReadData(v.DataBackend, nv.Offset.ToAcutalOffset(), nv.Size, v.Version())
 		if err != nil {
 			glog.V(0).Infof("Failed to check updated file %v", err)
 			return false
@@ -39,9 +40,19 @@ func (v *Volume) isFileUnchanged(n *Needle) bool {
 // Destroy removes everything related to this volume
 func (v *Volume) Destroy() (err error) {
 	if v.readOnly {
-		err = fmt.Errorf("%s is read-only", v.dataFile.Name())
+		err = fmt.Errorf("%s is read-only", v.DataBackend.Name())
 		return
 	}
+	return v.destroy()
+}
+
+// destroy removes the volume even if it is read-only, e.g., after converted to ec shards
+func (v *Volume) destroy() (err error) {
+	if v.HasRemoteFile() {
+		if err = v.deleteRemoteFile(); err != nil {
+			glog.V(0).Infof("volume %d delete remote data file: %v", v.Id, err)
+		}
+	}
 	v.Close()
 	os.Remove(v.FileName() + ".dat")
 	os.Remove(v.FileName() + ".idx")
@@ -49,13 +60,14 @@ func (v *Volume) Destroy() (err error) {
 	os.Remove(v.FileName() + ".cpx")
 	os.Remove(v.FileName() + ".ldb")
 	os.Remove(v.FileName() + ".bdb")
+	os.Remove(v.FileName() + ".vif")
 	return
 }
 
 // AppendBlob append a blob to end of the data file, used in replication
 func (v *Volume) AppendBlob(b []byte) (offset int64, err error) {
 	if v.readOnly {
-		err = fmt.Errorf("%s is read-only", v.dataFile.Name())
+		err = fmt.Errorf("%s is read-only", v.DataBackend.Name())
 		return
 	}
 	v.dataFileAccessLock.Lock()
@@ -79,7 +91,7 @@ func (v *Volume) AppendBlob(b []byte) (offset int64, err error) {
 func (v *Volume) writeNeedle(n *Needle) (offset uint64, size uint32, err error) {
 	glog.V(4).Infof("writing needle %s", NewFileIdFromNeedle(v.Id, n).String())
 	if v.readOnly {
-		err = fmt.Errorf("%s is read-only", v.dataFile.Name())
+		err = fmt.Errorf("%s is read-only", v.DataBackend.Name())
 		return
 	}
 	v.dataFileAccessLock.Lock()
@@ -110,7 +122,7 @@ func (v *Volume) writeNeedle(n *Needle) (offset uint64, size uint32, err error)
 func (v *Volume) deleteNeedle(n *Needle) (uint32, error) {
 	glog.V(4).Infof("delete needle %s", NewFileIdFromNeedle(v.Id, n).String())
 	if v.readOnly {
-		return 0, fmt.Errorf("%s is read-only", v.dataFile.Name())
+		return 0, fmt.Errorf("%s is read-only", v.DataBackend.Name())
 	}
 	v.dataFileAccessLock.Lock()
 	defer v.dataFileAccessLock.Unlock()
@@ -148,7 +160,7 @@ func (v *Volume) readNeedle(n *Needle) (int, error) {
 	if nv.Size == 0 {
 		return 0, nil
 	}
-	err := n.ReadData(v.dataFile, nv.Offset.ToAcutalOffset(), nv.Size, v.Version())
+	err := n.ReadData(v.DataBackend, nv.Offset.ToAcutalOffset(), nv.Size, v.Version())
 	if err != nil {
 		return 0, err
 	}
@@ -191,10 +203,10 @@ func ScanVolumeFile(dirname string, collection string, id VolumeId,
 
 	offset := int64(v.SuperBlock.BlockSize())
 
-	return ScanVolumeFileFrom(version, v.dataFile, offset, volumeFileScanner)
+	return ScanVolumeFileFrom(version, v.DataBackend, offset, volumeFileScanner)
 }
 
-func ScanVolumeFileFrom(version Version, dataFile *os.File, offset int64, volumeFileScanner VolumeFileScanner) (err error) {
+func ScanVolumeFileFrom(version Version, dataFile backend.BackendStorageFile, offset int64, volumeFileScanner VolumeFileScanner) (err error) {
 	n, rest, e := ReadNeedleHeader(dataFile, version, offset)
 	if e != nil {
 		if e == io.EOF {
@@ -7,6 +7,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
 
@@ -80,6 +81,7 @@ func (v *Volume) maybeWriteSuperBlock() error {
 		if e != nil && os.IsPermission(e) {
 			//read-only, but zero length - recreate it!
 			if v.dataFile, e = os.Create(v.dataFile.Name()); e == nil {
+				v.DataBackend = backend.NewDiskFile(v.dataFile)
 				if _, e = v.dataFile.Write(v.SuperBlock.Bytes()); e == nil {
 					v.readOnly = false
 				}
@@ -90,18 +92,14 @@ func (v *Volume) maybeWriteSuperBlock() error {
 }
 
 func (v *Volume) readSuperBlock() (err error) {
-	v.SuperBlock, err = ReadSuperBlock(v.dataFile)
+	v.SuperBlock, err = ReadSuperBlock(v.DataBackend)
 	return err
 }
 
 // ReadSuperBlock reads from data file and load it into volume's super block
-func ReadSuperBlock(dataFile *os.File) (superBlock SuperBlock, err error) {
-	if _, err = dataFile.Seek(0, 0); err != nil {
-		err = fmt.Errorf("cannot seek to the beginning of %s: %v", dataFile.Name(), err)
-		return
-	}
+func ReadSuperBlock(dataFile backend.BackendStorageFile) (superBlock SuperBlock, err error) {
 	header := make([]byte, _SuperBlockSize)
-	if _, e := dataFile.Read(header); e != nil {
+	if _, e := dataFile.ReadAt(header, 0); e != nil {
 		err = fmt.Errorf("cannot read volume %s super block: %v", dataFile.Name(), e)
 		return
 	}
@@ -117,6 +115,10 @@ func ReadSuperBlock(dataFile *os.File) (superBlock SuperBlock, err error) {
 	if superBlock.extraSize > 0 {
 		// read more
 		extraData := make([]byte, int(superBlock.extraSize))
+		if _, e := dataFile.ReadAt(extraData, _SuperBlockSize); e != nil {
+			err = fmt.Errorf("cannot read volume %s super block extra: %v", dataFile.Name(), e)
+			return
+		}
 		superBlock.Extra = &master_pb.SuperBlockExtra{}
 		err = proto.Unmarshal(extraData, superBlock.Extra)
 		if err != nil {
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/jsonpb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+// the .vif file keeps where the .dat file is tiered to
+
+func (v *Volume) GetVolumeInfo() *volume_server_pb.VolumeInfo {
+	return v.volumeInfo
+}
+
+func (v *Volume) maybeLoadVolumeInfo() (found bool) {
+
+	v.volumeInfo, found = MaybeLoadVolumeInfo(v.FileName() + ".vif")
+
+	return found
+
+}
+
+func (v *Volume) HasRemoteFile() bool {
+	return v.volumeInfo != nil && len(v.volumeInfo.Files) > 0
+}
+
+// LoadRemoteFile opens the remote data file recorded in the .vif file
+func (v *Volume) LoadRemoteFile() error {
+	tierFile := v.volumeInfo.GetFiles()[0]
+	backendStorage, found := backend.BackendStorages[tierFile.BackendType+"."+tierFile.BackendId]
+	if !found {
+		return fmt.Errorf("backend storage %s.%s is not configured", tierFile.BackendType, tierFile.BackendId)
+	}
+
+	if v.DataBackend != nil {
+		v.DataBackend.Close()
+	}
+
+	v.DataBackend = backendStorage.NewStorageFile(tierFile)
+	v.dataFile = nil
+	v.lastModifiedTime = tierFile.ModifiedTime
+	return nil
+}
+
+func (v *Volume) SaveVolumeInfo() error {
+
+	tierFileName := v.FileName() + ".vif"
+
+	return SaveVolumeInfo(tierFileName, v.volumeInfo)
+
+}
+
+// RemoteStorageNameKey returns the backend name and object key if the data file is on a remote tier
+func (v *Volume) RemoteStorageNameKey() (storageName, storageKey string) {
+	if !v.HasRemoteFile() {
+		return
+	}
+	tierFile := v.volumeInfo.GetFiles()[0]
+	return tierFile.BackendType + "." + tierFile.BackendId, tierFile.Key
+}
+
+func (v *Volume) deleteRemoteFile() error {
+	tierFile := v.volumeInfo.GetFiles()[0]
+	backendStorage, found := backend.BackendStorages[tierFile.BackendType+"."+tierFile.BackendId]
+	if !found {
+		return fmt.Errorf("backend storage %s.%s is not configured", tierFile.BackendType, tierFile.BackendId)
+	}
+	return backendStorage.DeleteFile(tierFile.Key)
+}
+
+func MaybeLoadVolumeInfo(fileName string) (*volume_server_pb.VolumeInfo, bool) {
+
+	volumeInfo := &volume_server_pb.VolumeInfo{}
+
+	glog.V(1).Infof("maybeLoadVolumeInfo checks %s", fileName)
+	if exists, canRead, _, _, _ := checkFile(fileName); !exists {
+		return volumeInfo, false
+	} else if !canRead {
+		glog.Warningf("can not read %s", fileName)
+		return volumeInfo, false
+	}
+
+	glog.V(1).Infof("maybeLoadVolumeInfo reads %s", fileName)
+	tierData, readErr := ioutil.ReadFile(fileName)
+	if readErr != nil {
+		glog.Warningf("fail to read %s : %v", fileName, readErr)
+		return volumeInfo, false
+	}
+
+	glog.V(1).Infof("maybeLoadVolumeInfo Unmarshal volume info %v", fileName)
+	if err := jsonpb.Unmarshal(bytes.NewReader(tierData), volumeInfo); err != nil {
+		glog.Warningf("unmarshal error: %v", err)
+		return volumeInfo, false
+	}
+
+	return volumeInfo, true
+}
+
+func SaveVolumeInfo(fileName string, volumeInfo *volume_server_pb.VolumeInfo) error {
+
+	if exists, _, canWrite, _, _ := checkFile(fileName); exists && !canWrite {
+		return fmt.Errorf("%s not writable", fileName)
+	}
+
+	m := jsonpb.Marshaler{
+		EmitDefaults: true,
+		Indent:       "  ",
+	}
+
+	text, marshalErr := m.MarshalToString(volumeInfo)
+	if marshalErr != nil {
+		return fmt.Errorf("marshal to %s: %v", fileName, marshalErr)
+	}
+
+	writeErr := ioutil.WriteFile(fileName, []byte(text), 0644)
+	if writeErr != nil {
+		return fmt.Errorf("fail to write %s : %v", fileName, writeErr)
+	}
+
+	return nil
+}
+
+// RemoveVolumeInfo removes the .vif file, after the data file is back on the local disk
+func RemoveVolumeInfo(fileName string) error {
+	if !util.FileExists(fileName) {
+		return nil
+	}
+	return os.Remove(fileName)
+}
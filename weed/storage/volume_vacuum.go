@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/backend"
 	. "gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/types"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 )
@@ -18,6 +19,9 @@ func (v *Volume) garbageLevel() float64 {
 }
 
 func (v *Volume) Compact(preallocate int64) error {
+	if v.HasRemoteFile() {
+		return fmt.Errorf("volume %d data file is on remote storage %v", v.Id, v.volumeInfo.Files[0].BackendType)
+	}
 	glog.V(3).Infof("Compacting volume %d ...", v.Id)
 	//no need to lock for copy on write
 	//v.accessLock.Lock()
@@ -32,6 +36,9 @@ func (v *Volume) Compact(preallocate int64) error {
 }
 
 func (v *Volume) Compact2() error {
+	if v.HasRemoteFile() {
+		return fmt.Errorf("volume %d data file is on remote storage %v", v.Id, v.volumeInfo.Files[0].BackendType)
+	}
 	glog.V(3).Infof("Compact2 volume %d ...", v.Id)
 	filePath := v.FileName()
 	glog.V(3).Infof("creating copies for volume %d ...", v.Id)
@@ -46,9 +53,10 @@ func (v *Volume) CommitCompact() error {
 	v.compactingWg.Add(1)
 	defer v.compactingWg.Done()
 	v.nm.Close()
-	if err := v.dataFile.Close(); err != nil {
+	if err := v.DataBackend.Close(); err != nil {
 		glog.V(0).Infof("fail to close volume %d", v.Id)
 	}
+	v.DataBackend = nil
 	v.dataFile = nil
 
 	var e error
@@ -100,7 +108,7 @@ func (v *Volume) cleanupCompact() error {
 }
 
 func fetchCompactRevisionFromDatFile(file *os.File) (compactRevision uint16, err error) {
-	superBlock, err := ReadSuperBlock(file)
+	superBlock, err := ReadSuperBlock(backend.NewDiskFile(file))
 	if err != nil {
 		return 0, err
 	}
@@ -205,7 +213,7 @@ func (v *Volume) makeupDiff(newDatFileName, newIdxFileName, oldDatFileName, oldI
 			//even the needle cache in memory is hit, the need_bytes is correct
 			glog.V(4).Infof("file %d offset %d size %d", key, increIdxEntry.offset.ToAcutalOffset(), increIdxEntry.size)
 			var needleBytes []byte
-			needleBytes, err = ReadNeedleBlob(oldDatFile, increIdxEntry.offset.ToAcutalOffset(), increIdxEntry.size, v.Version())
+			needleBytes, err = ReadNeedleBlob(backend.NewDiskFile(oldDatFile), increIdxEntry.offset.ToAcutalOffset(), increIdxEntry.size, v.Version())
 			if err != nil {
 				return fmt.Errorf("ReadNeedleBlob %s key %d offset %d size %d failed: %v", oldDatFile.Name(), key, increIdxEntry.offset.ToAcutalOffset(), increIdxEntry.size, err)
 			}
@@ -335,7 +343,7 @@ func (v *Volume) copyDataBasedOnIndexFile(dstName, idxName string) (err error) {
 		}
 
 		n := new(Needle)
-		err := n.ReadData(v.dataFile, offset.ToAcutalOffset(), size, v.Version())
+		err := n.ReadData(v.DataBackend, offset.ToAcutalOffset(), size, v.Version())
 		if err != nil {
 			return nil
 		}
@@ -23,6 +23,7 @@ func AllocateVolume(dn *DataNode, grpcDialOption grpc.DialOption, vid storage.Vo
 			Replication: option.ReplicaPlacement.String(),
 			Ttl:         option.Ttl.String(),
 			Preallocate: option.Prealloacte,
+			DiskType:    string(option.DiskType),
 		})
 		return deleteErr
 	})
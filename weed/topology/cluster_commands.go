@@ -1,7 +1,11 @@
 package topology
 
 import (
-	"github.com/chrislusf/raft"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/hashicorp/raft"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
 )
@@ -20,12 +24,70 @@ func (c *MaxVolumeIdCommand) CommandName() string {
 	return "MaxVolumeId"
 }
 
-func (c *MaxVolumeIdCommand) Apply(server raft.Server) (interface{}, error) {
-	topo := server.Context().(*Topology)
+func (c *MaxVolumeIdCommand) Apply(topo *Topology) {
 	before := topo.GetMaxVolumeId()
 	topo.UpAdjustMaxVolumeId(c.MaxVolumeId)
 
 	glog.V(1).Infoln("max volume id", before, "==>", topo.GetMaxVolumeId())
+}
+
+// StateMachine replicates the topology state, which is the max volume id, through the raft log
+type StateMachine struct {
+	topo *Topology
+}
+
+var _ = raft.FSM(&StateMachine{})
+
+func NewStateMachine(topo *Topology) *StateMachine {
+	return &StateMachine{topo: topo}
+}
+
+func (s *StateMachine) Apply(l *raft.Log) interface{} {
+	c := &MaxVolumeIdCommand{}
+	if err := json.Unmarshal(l.Data, c); err != nil {
+		glog.Errorf("unmarshal raft log %d: %v", l.Index, err)
+		return err
+	}
+	c.Apply(s.topo)
+	return nil
+}
+
+func (s *StateMachine) Snapshot() (raft.FSMSnapshot, error) {
+	return &topologySnapshot{
+		MaxVolumeId: s.topo.GetMaxVolumeId(),
+	}, nil
+}
+
+func (s *StateMachine) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	snapshot := &topologySnapshot{}
+	if err = json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+	NewMaxVolumeIdCommand(snapshot.MaxVolumeId).Apply(s.topo)
+	return nil
+}
+
+type topologySnapshot struct {
+	MaxVolumeId storage.VolumeId `json:"maxVolumeId"`
+}
+
+func (s *topologySnapshot) Persist(sink raft.SnapshotSink) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		sink.Cancel()
+		return err
+	}
+	if _, err = sink.Write(data); err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
 
-	return nil, nil
+func (s *topologySnapshot) Release() {
 }
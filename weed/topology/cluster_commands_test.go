@@ -0,0 +1,58 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/hashicorp/raft"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+)
+
+type bufferSnapshotSink struct {
+	bytes.Buffer
+	cancelled bool
+}
+
+func (s *bufferSnapshotSink) ID() string    { return "test" }
+func (s *bufferSnapshotSink) Cancel() error { s.cancelled = true; return nil }
+func (s *bufferSnapshotSink) Close() error  { return nil }
+
+func TestStateMachine(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	fsm := NewStateMachine(topo)
+
+	for _, vid := range []int{3, 7, 5} {
+		command, _ := json.Marshal(NewMaxVolumeIdCommand(storage.VolumeId(vid)))
+		if resp := fsm.Apply(&raft.Log{Data: command}); resp != nil {
+			t.Fatalf("apply max volume id %d: %v", vid, resp)
+		}
+	}
+	if topo.GetMaxVolumeId() != 7 {
+		t.Errorf("max volume id %d, expected 7", topo.GetMaxVolumeId())
+	}
+
+	if resp := fsm.Apply(&raft.Log{Data: []byte("bad")}); resp == nil {
+		t.Errorf("expected an error for a malformed command")
+	}
+
+	snapshot, err := fsm.Snapshot()
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	sink := &bufferSnapshotSink{}
+	if err = snapshot.Persist(sink); err != nil || sink.cancelled {
+		t.Fatalf("persist snapshot: %v", err)
+	}
+	snapshot.Release()
+
+	restored := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	if err = NewStateMachine(restored).Restore(ioutil.NopCloser(&sink.Buffer)); err != nil {
+		t.Fatalf("restore snapshot: %v", err)
+	}
+	if restored.GetMaxVolumeId() != 7 {
+		t.Errorf("restored max volume id %d, expected 7", restored.GetMaxVolumeId())
+	}
+}
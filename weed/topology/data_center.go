@@ -53,5 +53,6 @@ func (dc *DataCenter) ToDataCenterInfo() *master_pb.DataCenterInfo {
 		rack := c.(*Rack)
 		m.RackInfos = append(m.RackInfos, rack.ToRackInfo())
 	}
+	m.DiskInfos = toDiskInfos(dc)
 	return m
 }
@@ -3,6 +3,8 @@ package topology
 import (
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
 
@@ -17,6 +19,11 @@ type DataNode struct {
 	Port      int
 	PublicUrl string
 	LastSeen  int64 // unix time in seconds
+
+	ecShards     map[storage.VolumeId]*storage.EcVolumeInfo
+	ecShardsLock sync.RWMutex
+
+	draining int32 // no new writes or volumes while draining
 }
 
 func NewDataNode(id string) *DataNode {
@@ -24,6 +31,7 @@ func NewDataNode(id string) *DataNode {
 	s.id = NodeId(id)
 	s.nodeType = "DataNode"
 	s.volumes = make(map[storage.VolumeId]storage.VolumeInfo)
+	s.ecShards = make(map[storage.VolumeId]*storage.EcVolumeInfo)
 	s.NodeImpl.value = s
 	return s
 }
@@ -34,12 +42,40 @@ func (dn *DataNode) String() string {
 	return fmt.Sprintf("Node:%s, volumes:%v, Ip:%s, Port:%d, PublicUrl:%s", dn.NodeImpl.String(), dn.volumes, dn.Ip, dn.Port, dn.PublicUrl)
 }
 
+func (dn *DataNode) IsDraining() bool {
+	return atomic.LoadInt32(&dn.draining) == 1
+}
+
+func (dn *DataNode) setDraining(draining bool) {
+	var value int32
+	if draining {
+		value = 1
+	}
+	atomic.StoreInt32(&dn.draining, value)
+}
+
+// FreeSpace is zero for a draining data node, so no new volumes are placed on it
+func (dn *DataNode) FreeSpace() int64 {
+	if dn.IsDraining() {
+		return 0
+	}
+	return dn.NodeImpl.FreeSpace()
+}
+
+func (dn *DataNode) FreeSpaceOf(diskType storage.DiskType) int64 {
+	if dn.IsDraining() {
+		return 0
+	}
+	return dn.NodeImpl.FreeSpaceOf(diskType)
+}
+
 func (dn *DataNode) AddOrUpdateVolume(v storage.VolumeInfo) (isNew bool) {
 	dn.Lock()
 	defer dn.Unlock()
-	if _, ok := dn.volumes[v.Id]; !ok {
+	if oldVolume, ok := dn.volumes[v.Id]; !ok {
 		dn.volumes[v.Id] = v
 		dn.UpAdjustVolumeCountDelta(1)
+		dn.UpAdjustDiskUsageDelta(v.DiskType, 1, 0)
 		if !v.ReadOnly {
 			dn.UpAdjustActiveVolumeCountDelta(1)
 		}
@@ -47,10 +83,46 @@ func (dn *DataNode) AddOrUpdateVolume(v storage.VolumeInfo) (isNew bool) {
 		isNew = true
 	} else {
 		dn.volumes[v.Id] = v
+		if oldVolume.DiskType != v.DiskType {
+			dn.UpAdjustDiskUsageDelta(oldVolume.DiskType, -1, 0)
+			dn.UpAdjustDiskUsageDelta(v.DiskType, 1, 0)
+		}
 	}
 	return
 }
 
+// DeleteVolumeById removes the volume, e.g. after the volume is moved to another data node
+func (dn *DataNode) DeleteVolumeById(id storage.VolumeId) {
+	dn.Lock()
+	defer dn.Unlock()
+	if v, ok := dn.volumes[id]; ok {
+		delete(dn.volumes, id)
+		dn.UpAdjustVolumeCountDelta(-1)
+		dn.UpAdjustDiskUsageDelta(v.DiskType, -1, 0)
+		if !v.ReadOnly {
+			dn.UpAdjustActiveVolumeCountDelta(-1)
+		}
+	}
+}
+
+// UpdateMaxVolumeCounts sets the max volume counts of the disk types, and the total max volume count
+func (dn *DataNode) UpdateMaxVolumeCounts(maxVolumeCounts map[string]uint32) {
+	var total int64
+	for diskType, usage := range dn.GetDiskUsages() {
+		if _, found := maxVolumeCounts[string(diskType)]; !found {
+			dn.UpAdjustDiskUsageDelta(diskType, 0, -usage.MaxVolumeCount)
+		}
+	}
+	usages := dn.GetDiskUsages()
+	for diskType, maxVolumeCount := range maxVolumeCounts {
+		total += int64(maxVolumeCount)
+		dn.UpAdjustDiskUsageDelta(storage.DiskType(diskType), 0, int64(maxVolumeCount)-usages[storage.DiskType(diskType)].MaxVolumeCount)
+	}
+	if delta := total - dn.GetMaxVolumeCount(); delta != 0 {
+		dn.UpAdjustMaxVolumeCountDelta(delta)
+	}
+}
+
 func (dn *DataNode) UpdateVolumes(actualVolumes []storage.VolumeInfo) (newVolumes, deletedVolumes []storage.VolumeInfo) {
 	actualVolumeMap := make(map[storage.VolumeId]storage.VolumeInfo)
 	for _, v := range actualVolumes {
@@ -63,6 +135,7 @@ func (dn *DataNode) UpdateVolumes(actualVolumes []storage.VolumeInfo) (newVolume
 			delete(dn.volumes, vid)
 			deletedVolumes = append(deletedVolumes, v)
 			dn.UpAdjustVolumeCountDelta(-1)
+			dn.UpAdjustDiskUsageDelta(v.DiskType, -1, 0)
 			dn.UpAdjustActiveVolumeCountDelta(-1)
 		}
 	}
@@ -125,9 +198,11 @@ func (dn *DataNode) ToMap() interface{} {
 	ret := make(map[string]interface{})
 	ret["Url"] = dn.Url()
 	ret["Volumes"] = dn.GetVolumeCount()
+	ret["EcShards"] = dn.GetEcShardCount()
 	ret["Max"] = dn.GetMaxVolumeCount()
 	ret["Free"] = dn.FreeSpace()
 	ret["PublicUrl"] = dn.PublicUrl
+	ret["Draining"] = dn.IsDraining()
 	return ret
 }
 
@@ -138,9 +213,14 @@ func (dn *DataNode) ToDataNodeInfo() *master_pb.DataNodeInfo {
 		MaxVolumeCount:    uint64(dn.GetMaxVolumeCount()),
 		FreeVolumeCount:   uint64(dn.FreeSpace()),
 		ActiveVolumeCount: uint64(dn.GetActiveVolumeCount()),
+		IsDraining:        dn.IsDraining(),
 	}
 	for _, v := range dn.GetVolumes() {
 		m.VolumeInfos = append(m.VolumeInfos, v.ToVolumeInformationMessage())
 	}
+	for _, ecv := range dn.GetEcShards() {
+		m.EcShardInfos = append(m.EcShardInfos, ecv.ToVolumeEcShardInformationMessage())
+	}
+	m.DiskInfos = toDiskInfos(dn)
 	return m
 }
@@ -0,0 +1,118 @@
+package topology
+
+import (
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+)
+
+func (dn *DataNode) GetEcShards() (ret []*storage.EcVolumeInfo) {
+	dn.ecShardsLock.RLock()
+	for _, ecVolumeInfo := range dn.ecShards {
+		ret = append(ret, ecVolumeInfo)
+	}
+	dn.ecShardsLock.RUnlock()
+	return ret
+}
+
+func (dn *DataNode) UpdateEcShards(actualShards []*storage.EcVolumeInfo) (newShards, deletedShards []*storage.EcVolumeInfo) {
+	// prepare the new ec shard map
+	actualEcShardMap := make(map[storage.VolumeId]*storage.EcVolumeInfo)
+	for _, ecShards := range actualShards {
+		actualEcShardMap[ecShards.VolumeId] = ecShards
+	}
+
+	// found out the newShards and deletedShards
+	var newShardCount, deletedShardCount int
+	dn.ecShardsLock.RLock()
+	for vid, ecShards := range dn.ecShards {
+		if actualEcShards, ok := actualEcShardMap[vid]; !ok {
+			// dn registered ec shards not found in the new set of ec shards
+			deletedShards = append(deletedShards, ecShards)
+			deletedShardCount += ecShards.ShardIdCount()
+		} else {
+			// found, but maybe the actual shard could be missing
+			a := actualEcShards.Minus(ecShards)
+			if a.ShardIdCount() > 0 {
+				newShards = append(newShards, a)
+				newShardCount += a.ShardIdCount()
+			}
+			d := ecShards.Minus(actualEcShards)
+			if d.ShardIdCount() > 0 {
+				deletedShards = append(deletedShards, d)
+				deletedShardCount += d.ShardIdCount()
+			}
+		}
+	}
+	for _, ecShards := range actualShards {
+		if _, found := dn.ecShards[ecShards.VolumeId]; !found {
+			newShards = append(newShards, ecShards)
+			newShardCount += ecShards.ShardIdCount()
+		}
+	}
+	dn.ecShardsLock.RUnlock()
+
+	if len(newShards) > 0 || len(deletedShards) > 0 {
+		// if changed, set to the new ec shard map
+		dn.ecShardsLock.Lock()
+		dn.ecShards = actualEcShardMap
+		dn.UpAdjustEcShardCountDelta(int64(newShardCount - deletedShardCount))
+		dn.ecShardsLock.Unlock()
+	}
+
+	return
+}
+
+func (dn *DataNode) DeltaUpdateEcShards(newShards, deletedShards []*storage.EcVolumeInfo) {
+
+	for _, newShard := range newShards {
+		dn.AddOrUpdateEcShard(newShard)
+	}
+	for _, deletedShard := range deletedShards {
+		dn.DeleteEcShard(deletedShard)
+	}
+
+}
+
+func (dn *DataNode) AddOrUpdateEcShard(s *storage.EcVolumeInfo) {
+	dn.ecShardsLock.Lock()
+	defer dn.ecShardsLock.Unlock()
+
+	delta := 0
+	if existing, ok := dn.ecShards[s.VolumeId]; !ok {
+		dn.ecShards[s.VolumeId] = s
+		delta = s.ShardIdCount()
+	} else {
+		oldCount := existing.ShardIdCount()
+		existing.ShardBits = existing.ShardBits.Plus(s.ShardBits)
+		delta = existing.ShardIdCount() - oldCount
+	}
+
+	dn.UpAdjustEcShardCountDelta(int64(delta))
+
+}
+
+func (dn *DataNode) DeleteEcShard(s *storage.EcVolumeInfo) {
+	dn.ecShardsLock.Lock()
+	defer dn.ecShardsLock.Unlock()
+
+	if existing, ok := dn.ecShards[s.VolumeId]; ok {
+		oldCount := existing.ShardIdCount()
+		existing.ShardBits = existing.ShardBits.Minus(s.ShardBits)
+		delta := existing.ShardIdCount() - oldCount
+		dn.UpAdjustEcShardCountDelta(int64(delta))
+		if existing.ShardIdCount() == 0 {
+			delete(dn.ecShards, s.VolumeId)
+		}
+	}
+
+}
+
+func (dn *DataNode) EcShardIds(volumeId storage.VolumeId) (shardIds []erasure_coding.ShardId) {
+	dn.ecShardsLock.RLock()
+	defer dn.ecShardsLock.RUnlock()
+
+	if ecVolumeInfo, found := dn.ecShards[volumeId]; found {
+		return ecVolumeInfo.ShardIds()
+	}
+	return nil
+}
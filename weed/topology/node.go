@@ -9,6 +9,7 @@ import (
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
 )
 
 type NodeId string
@@ -16,15 +17,20 @@ type Node interface {
 	Id() NodeId
 	String() string
 	FreeSpace() int64
-	ReserveOneVolume(r int64) (*DataNode, error)
+	FreeSpaceOf(diskType storage.DiskType) int64
+	ReserveOneVolume(r int64, diskType storage.DiskType) (*DataNode, error)
 	UpAdjustMaxVolumeCountDelta(maxVolumeCountDelta int64)
 	UpAdjustVolumeCountDelta(volumeCountDelta int64)
 	UpAdjustActiveVolumeCountDelta(activeVolumeCountDelta int64)
+	UpAdjustEcShardCountDelta(ecShardCountDelta int64)
 	UpAdjustMaxVolumeId(vid storage.VolumeId)
+	UpAdjustDiskUsageDelta(diskType storage.DiskType, volumeCountDelta, maxVolumeCountDelta int64)
 
 	GetVolumeCount() int64
 	GetActiveVolumeCount() int64
+	GetEcShardCount() int64
 	GetMaxVolumeCount() int64
+	GetDiskUsages() map[storage.DiskType]DiskUsageCounts
 	GetMaxVolumeId() storage.VolumeId
 	SetParent(Node)
 	LinkChildNode(node Node)
@@ -43,19 +49,30 @@ type NodeImpl struct {
 	id                NodeId
 	volumeCount       int64
 	activeVolumeCount int64
+	ecShardCount      int64
 	maxVolumeCount    int64
 	parent            Node
 	sync.RWMutex      // lock children
 	children          map[NodeId]Node
 	maxVolumeId       storage.VolumeId
 
+	// the volume counts of the disk types other than the hard drive,
+	// the hard drive takes the rest of the total counts
+	diskUsages     map[storage.DiskType]*DiskUsageCounts
+	diskUsagesLock sync.RWMutex
+
 	//for rack, data center, topology
 	nodeType string
 	value    interface{}
 }
 
-// the first node must satisfy filterFirstNodeFn(), the rest nodes must have one free slot
-func (n *NodeImpl) RandomlyPickNodes(numberOfNodes int, filterFirstNodeFn func(dn Node) error) (firstNode Node, restNodes []Node, err error) {
+type DiskUsageCounts struct {
+	VolumeCount    int64
+	MaxVolumeCount int64
+}
+
+// the first node must satisfy filterFirstNodeFn(), the rest nodes must have one free slot of the disk type
+func (n *NodeImpl) RandomlyPickNodes(numberOfNodes int, diskType storage.DiskType, filterFirstNodeFn func(dn Node) error) (firstNode Node, restNodes []Node, err error) {
 	candidates := make([]Node, 0, len(n.children))
 	var errs []string
 	n.RLock()
@@ -80,7 +97,7 @@ func (n *NodeImpl) RandomlyPickNodes(numberOfNodes int, filterFirstNodeFn func(d
 		if node.Id() == firstNode.Id() {
 			continue
 		}
-		if node.FreeSpace() <= 0 {
+		if node.FreeSpaceOf(diskType) <= 0 {
 			continue
 		}
 		glog.V(2).Infoln("select rest node candidate:", node.Id())
@@ -128,7 +145,29 @@ func (n *NodeImpl) Id() NodeId {
 	return n.id
 }
 func (n *NodeImpl) FreeSpace() int64 {
-	return n.maxVolumeCount - n.volumeCount
+	freeVolumeSlotCount := n.maxVolumeCount - n.volumeCount
+	if n.ecShardCount > 0 {
+		// every DataShardsCount ec shards take up the space of one volume
+		freeVolumeSlotCount = freeVolumeSlotCount - (n.ecShardCount+erasure_coding.DataShardsCount-1)/erasure_coding.DataShardsCount
+	}
+	return freeVolumeSlotCount
+}
+
+// FreeSpaceOf is the free volume slots of the disk type. The ec shards are on the hard drives.
+func (n *NodeImpl) FreeSpaceOf(diskType storage.DiskType) int64 {
+	n.diskUsagesLock.RLock()
+	defer n.diskUsagesLock.RUnlock()
+	if diskType != storage.HardDriveType {
+		if usage, found := n.diskUsages[diskType]; found {
+			return usage.MaxVolumeCount - usage.VolumeCount
+		}
+		return 0
+	}
+	freeVolumeSlotCount := n.FreeSpace()
+	for _, usage := range n.diskUsages {
+		freeVolumeSlotCount -= usage.MaxVolumeCount - usage.VolumeCount
+	}
+	return freeVolumeSlotCount
 }
 func (n *NodeImpl) SetParent(node Node) {
 	n.parent = node
@@ -147,11 +186,22 @@ func (n *NodeImpl) Parent() Node {
 func (n *NodeImpl) GetValue() interface{} {
 	return n.value
 }
-func (n *NodeImpl) ReserveOneVolume(r int64) (assignedNode *DataNode, err error) {
+func (n *NodeImpl) ReserveOneVolume(r int64, diskType storage.DiskType) (assignedNode *DataNode, err error) {
 	n.RLock()
 	defer n.RUnlock()
+	// the children may have less free space than counted by this node, e.g. with draining data nodes
+	var childrenFreeSpace int64
+	for _, node := range n.children {
+		if freeSpace := node.FreeSpaceOf(diskType); freeSpace > 0 {
+			childrenFreeSpace += freeSpace
+		}
+	}
+	if childrenFreeSpace <= 0 {
+		return nil, errors.New("No free volume slot found!")
+	}
+	r = r % childrenFreeSpace
 	for _, node := range n.children {
-		freeSpace := node.FreeSpace()
+		freeSpace := node.FreeSpaceOf(diskType)
 		// fmt.Println("r =", r, ", node =", node, ", freeSpace =", freeSpace)
 		if freeSpace <= 0 {
 			continue
@@ -159,11 +209,11 @@ func (n *NodeImpl) ReserveOneVolume(r int64) (assignedNode *DataNode, err error)
 		if r >= freeSpace {
 			r -= freeSpace
 		} else {
-			if node.IsDataNode() && node.FreeSpace() > 0 {
+			if node.IsDataNode() && node.FreeSpaceOf(diskType) > 0 {
 				// fmt.Println("vid =", vid, " assigned to node =", node, ", freeSpace =", node.FreeSpace())
 				return node.(*DataNode), nil
 			}
-			assignedNode, err = node.ReserveOneVolume(r)
+			assignedNode, err = node.ReserveOneVolume(r, diskType)
 			if err == nil {
 				return
 			}
@@ -190,6 +240,37 @@ func (n *NodeImpl) UpAdjustActiveVolumeCountDelta(activeVolumeCountDelta int64)
 		n.parent.UpAdjustActiveVolumeCountDelta(activeVolumeCountDelta)
 	}
 }
+func (n *NodeImpl) UpAdjustEcShardCountDelta(ecShardCountDelta int64) { //can be negative
+	atomic.AddInt64(&n.ecShardCount, ecShardCountDelta)
+	if n.parent != nil {
+		n.parent.UpAdjustEcShardCountDelta(ecShardCountDelta)
+	}
+}
+
+// UpAdjustDiskUsageDelta adjusts the counts of the disk type, in addition to the total counts
+func (n *NodeImpl) UpAdjustDiskUsageDelta(diskType storage.DiskType, volumeCountDelta, maxVolumeCountDelta int64) { //can be negative
+	if diskType == storage.HardDriveType {
+		return
+	}
+	n.diskUsagesLock.Lock()
+	if n.diskUsages == nil {
+		n.diskUsages = make(map[storage.DiskType]*DiskUsageCounts)
+	}
+	usage, found := n.diskUsages[diskType]
+	if !found {
+		usage = &DiskUsageCounts{}
+		n.diskUsages[diskType] = usage
+	}
+	usage.VolumeCount += volumeCountDelta
+	usage.MaxVolumeCount += maxVolumeCountDelta
+	if usage.VolumeCount == 0 && usage.MaxVolumeCount == 0 {
+		delete(n.diskUsages, diskType)
+	}
+	n.diskUsagesLock.Unlock()
+	if n.parent != nil {
+		n.parent.UpAdjustDiskUsageDelta(diskType, volumeCountDelta, maxVolumeCountDelta)
+	}
+}
 func (n *NodeImpl) UpAdjustMaxVolumeId(vid storage.VolumeId) { //can be negative
 	if n.maxVolumeId < vid {
 		n.maxVolumeId = vid
@@ -207,10 +288,30 @@ func (n *NodeImpl) GetVolumeCount() int64 {
 func (n *NodeImpl) GetActiveVolumeCount() int64 {
 	return n.activeVolumeCount
 }
+func (n *NodeImpl) GetEcShardCount() int64 {
+	return n.ecShardCount
+}
 func (n *NodeImpl) GetMaxVolumeCount() int64 {
 	return n.maxVolumeCount
 }
 
+// GetDiskUsages returns the volume counts of all the disk types, including the hard drive
+func (n *NodeImpl) GetDiskUsages() map[storage.DiskType]DiskUsageCounts {
+	n.diskUsagesLock.RLock()
+	defer n.diskUsagesLock.RUnlock()
+	hardDrive := DiskUsageCounts{VolumeCount: n.GetVolumeCount(), MaxVolumeCount: n.GetMaxVolumeCount()}
+	usages := make(map[storage.DiskType]DiskUsageCounts)
+	for diskType, usage := range n.diskUsages {
+		usages[diskType] = *usage
+		hardDrive.VolumeCount -= usage.VolumeCount
+		hardDrive.MaxVolumeCount -= usage.MaxVolumeCount
+	}
+	if hardDrive.VolumeCount != 0 || hardDrive.MaxVolumeCount != 0 {
+		usages[storage.HardDriveType] = hardDrive
+	}
+	return usages
+}
+
 func (n *NodeImpl) LinkChildNode(node Node) {
 	n.Lock()
 	defer n.Unlock()
@@ -220,6 +321,10 @@ func (n *NodeImpl) LinkChildNode(node Node) {
 		n.UpAdjustMaxVolumeId(node.GetMaxVolumeId())
 		n.UpAdjustVolumeCountDelta(node.GetVolumeCount())
 		n.UpAdjustActiveVolumeCountDelta(node.GetActiveVolumeCount())
+		n.UpAdjustEcShardCountDelta(node.GetEcShardCount())
+		for diskType, usage := range node.GetDiskUsages() {
+			n.UpAdjustDiskUsageDelta(diskType, usage.VolumeCount, usage.MaxVolumeCount)
+		}
 		node.SetParent(n)
 		glog.V(0).Infoln(n, "adds child", node.Id())
 	}
@@ -234,7 +339,11 @@ func (n *NodeImpl) UnlinkChildNode(nodeId NodeId) {
 		delete(n.children, node.Id())
 		n.UpAdjustVolumeCountDelta(-node.GetVolumeCount())
 		n.UpAdjustActiveVolumeCountDelta(-node.GetActiveVolumeCount())
+		n.UpAdjustEcShardCountDelta(-node.GetEcShardCount())
 		n.UpAdjustMaxVolumeCountDelta(-node.GetMaxVolumeCount())
+		for diskType, usage := range node.GetDiskUsages() {
+			n.UpAdjustDiskUsageDelta(diskType, -usage.VolumeCount, -usage.MaxVolumeCount)
+		}
 		glog.V(0).Infoln(n, "removes", node.Id())
 	}
 }
@@ -73,5 +73,6 @@ func (r *Rack) ToRackInfo() *master_pb.RackInfo {
 		dn := c.(*DataNode)
 		m.DataNodeInfos = append(m.DataNodeInfos, dn.ToDataNodeInfo())
 	}
+	m.DiskInfos = toDiskInfos(r)
 	return m
 }
@@ -1,8 +1,11 @@
 package topology
 
 import (
+	"encoding/json"
 	"errors"
 	"math/rand"
+	"sync"
+	"time"
 
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
@@ -11,7 +14,7 @@ import (
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
 	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
 
-	"github.com/chrislusf/raft"
+	"github.com/hashicorp/raft"
 )
 
 type Topology struct {
@@ -19,6 +22,9 @@ type Topology struct {
 
 	collectionMap *util.ConcurrentReadMap
 
+	ecShardMap     map[storage.VolumeId]*EcShardLocations
+	ecShardMapLock sync.RWMutex
+
 	pulse int64
 
 	volumeSizeLimit uint64
@@ -29,7 +35,12 @@ type Topology struct {
 
 	Configuration *Configuration
 
-	RaftServer raft.Server
+	RaftServer *raft.Raft
+
+	drainingDataNodes map[string]bool
+	drainingLock      sync.RWMutex
+
+	replicationRepairs *replicationRepairs
 }
 
 func NewTopology(id string, seq sequence.Sequencer, volumeSizeLimit uint64, pulse int) *Topology {
@@ -39,6 +50,7 @@ func NewTopology(id string, seq sequence.Sequencer, volumeSizeLimit uint64, puls
 	t.NodeImpl.value = t
 	t.children = make(map[NodeId]Node)
 	t.collectionMap = util.NewConcurrentReadMap()
+	t.ecShardMap = make(map[storage.VolumeId]*EcShardLocations)
 	t.pulse = int64(pulse)
 	t.volumeSizeLimit = volumeSizeLimit
 
@@ -48,6 +60,10 @@ func NewTopology(id string, seq sequence.Sequencer, volumeSizeLimit uint64, puls
 
 	t.Configuration = &Configuration{}
 
+	t.drainingDataNodes = make(map[string]bool)
+
+	t.replicationRepairs = newReplicationRepairs()
+
 	return t
 }
 
@@ -58,20 +74,18 @@ func (t *Topology) IsLeader() bool {
 	return false
 }
 
+// Leader returns the http address of the leader master, which is its raft server id
 func (t *Topology) Leader() (string, error) {
-	l := ""
-	if t.RaftServer != nil {
-		l = t.RaftServer.Leader()
-	} else {
+	if t.RaftServer == nil {
 		return "", errors.New("Raft Server not ready yet!")
 	}
 
+	_, l := t.RaftServer.LeaderWithID()
 	if l == "" {
-		// We are a single node cluster, we are the leader
-		return t.RaftServer.Name(), errors.New("Raft Server not initialized!")
+		return "", errors.New("Raft Server has no leader yet!")
 	}
 
-	return l, nil
+	return string(l), nil
 }
 
 func (t *Topology) Lookup(collection string, vid storage.VolumeId) []*DataNode {
@@ -93,31 +107,38 @@ func (t *Topology) Lookup(collection string, vid storage.VolumeId) []*DataNode {
 func (t *Topology) NextVolumeId() (storage.VolumeId, error) {
 	vid := t.GetMaxVolumeId()
 	next := vid.Next()
-	if _, err := t.RaftServer.Do(NewMaxVolumeIdCommand(next)); err != nil {
+	command, err := json.Marshal(NewMaxVolumeIdCommand(next))
+	if err != nil {
+		return 0, err
+	}
+	if err := t.RaftServer.Apply(command, 5*time.Second).Error(); err != nil {
 		return 0, err
 	}
 	return next, nil
 }
 
 func (t *Topology) HasWritableVolume(option *VolumeGrowOption) bool {
-	vl := t.GetVolumeLayout(option.Collection, option.ReplicaPlacement, option.Ttl)
+	vl := t.GetVolumeLayout(option.Collection, option.ReplicaPlacement, option.Ttl, option.DiskType)
 	return vl.GetActiveVolumeCount(option) > 0
 }
 
 func (t *Topology) PickForWrite(count uint64, option *VolumeGrowOption) (string, uint64, *DataNode, error) {
-	vid, count, datanodes, err := t.GetVolumeLayout(option.Collection, option.ReplicaPlacement, option.Ttl).PickForWrite(count, option)
+	vid, count, datanodes, err := t.GetVolumeLayout(option.Collection, option.ReplicaPlacement, option.Ttl, option.DiskType).PickForWrite(count, option)
 	if err != nil || datanodes.Length() == 0 {
 		return "", 0, nil, errors.New("No writable volumes available!")
 	}
 	fileId, count := t.Sequence.NextFileId(count)
+	if count == 0 {
+		return "", 0, nil, errors.New("No file id available!")
+	}
 	return storage.NewFileId(*vid, fileId, rand.Uint32()).String(), count, datanodes.Head(), nil
 }
 
-func (t *Topology) GetVolumeLayout(collectionName string, rp *storage.ReplicaPlacement, ttl *storage.TTL) *VolumeLayout {
+func (t *Topology) GetVolumeLayout(collectionName string, rp *storage.ReplicaPlacement, ttl *storage.TTL, diskType storage.DiskType) *VolumeLayout {
 	return t.collectionMap.Get(collectionName, func() interface{} {
 		defer metrics.CollectionNumber.WithLabelValues(string(t.Id())).Inc()
 		return NewCollection(collectionName, t.volumeSizeLimit)
-	}).(*Collection).GetOrCreateVolumeLayout(rp, ttl)
+	}).(*Collection).GetOrCreateVolumeLayout(rp, ttl, diskType)
 }
 
 func (t *Topology) ListCollections() (ret []*Collection) {
@@ -141,11 +162,11 @@ func (t *Topology) DeleteCollection(collectionName string) {
 }
 
 func (t *Topology) RegisterVolumeLayout(v storage.VolumeInfo, dn *DataNode) {
-	t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl).RegisterVolume(&v, dn)
+	t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl, v.DiskType).RegisterVolume(&v, dn)
 }
 func (t *Topology) UnRegisterVolumeLayout(v storage.VolumeInfo, dn *DataNode) {
 	glog.Infof("removing volume info:%+v", v)
-	volumeLayout := t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl)
+	volumeLayout := t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl, v.DiskType)
 	volumeLayout.UnRegisterVolume(&v, dn)
 	if volumeLayout.isEmpty() {
 		t.DeleteCollection(v.Collection)
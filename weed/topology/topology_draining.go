@@ -0,0 +1,64 @@
+package topology
+
+import (
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+)
+
+// SetDataNodeDraining starts or stops draining the data node. A draining data node takes no new writes
+// to its volumes, and no new volumes. The draining state is kept for the data node to reconnect.
+func (t *Topology) SetDataNodeDraining(id string, draining bool) (found bool) {
+	dn := t.findDataNode(id)
+	if dn == nil && draining {
+		return false
+	}
+
+	t.drainingLock.Lock()
+	if draining {
+		t.drainingDataNodes[id] = true
+	} else {
+		delete(t.drainingDataNodes, id)
+	}
+	t.drainingLock.Unlock()
+
+	if dn != nil {
+		t.setDataNodeDraining(dn, draining)
+	}
+	return dn != nil
+}
+
+// RestoreDataNodeDraining continues draining the reconnected data node
+func (t *Topology) RestoreDataNodeDraining(dn *DataNode) {
+	t.drainingLock.RLock()
+	draining := t.drainingDataNodes[string(dn.Id())]
+	t.drainingLock.RUnlock()
+
+	if draining {
+		t.setDataNodeDraining(dn, true)
+	}
+}
+
+func (t *Topology) setDataNodeDraining(dn *DataNode, draining bool) {
+	if dn.IsDraining() == draining {
+		return
+	}
+	glog.V(0).Infof("volume server %s draining: %v", dn.Id(), draining)
+	dn.setDraining(draining)
+
+	// update the writable volumes
+	for _, v := range dn.GetVolumes() {
+		t.RegisterVolumeLayout(v, dn)
+	}
+}
+
+func (t *Topology) findDataNode(id string) *DataNode {
+	for _, c := range t.Children() {
+		for _, r := range c.Children() {
+			for _, n := range r.Children() {
+				if string(n.Id()) == id {
+					return n.(*DataNode)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package topology
+
+import (
+	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+)
+
+func TestDrainingDataNode(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	dn := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+
+	volumeCount := 7
+	var volumeMessages []*master_pb.VolumeInformationMessage
+	for k := 1; k <= volumeCount; k++ {
+		volumeMessages = append(volumeMessages, &master_pb.VolumeInformationMessage{
+			Id:      uint32(k),
+			Size:    uint64(25432),
+			Version: uint32(storage.CurrentVersion),
+		})
+	}
+	topo.SyncDataNodeRegistration(volumeMessages, dn)
+
+	rp, _ := storage.NewReplicaPlacementFromByte(0)
+	ttl, _ := storage.ReadTTL("")
+	vl := topo.GetVolumeLayout("", rp, ttl, storage.HardDriveType)
+	option := &VolumeGrowOption{}
+
+	assert(t, "writable volumes", vl.GetActiveVolumeCount(option), volumeCount)
+	assert(t, "free space", int(dn.FreeSpace()), 25-volumeCount)
+
+	if found := topo.SetDataNodeDraining("127.0.0.1:34535", true); found {
+		t.Fatalf("unexpected draining unknown volume server")
+	}
+	if found := topo.SetDataNodeDraining("127.0.0.1:34534", true); !found {
+		t.Fatalf("volume server not found")
+	}
+
+	assert(t, "draining writable volumes", vl.GetActiveVolumeCount(option), 0)
+	assert(t, "draining free space", int(dn.FreeSpace()), 0)
+
+	// the volume server reconnects, still draining
+	topo.UnRegisterDataNode(dn)
+	dn = rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+	topo.RestoreDataNodeDraining(dn)
+	topo.SyncDataNodeRegistration(volumeMessages, dn)
+
+	if !dn.IsDraining() {
+		t.Fatalf("reconnected volume server is not draining")
+	}
+	assert(t, "reconnected writable volumes", vl.GetActiveVolumeCount(option), 0)
+
+	topo.SetDataNodeDraining("127.0.0.1:34534", false)
+
+	assert(t, "undrained writable volumes", vl.GetActiveVolumeCount(option), volumeCount)
+	assert(t, "undrained free space", int(dn.FreeSpace()), 25-volumeCount)
+}
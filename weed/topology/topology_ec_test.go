@@ -0,0 +1,59 @@
+package topology
+
+import (
+	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage/erasure_coding"
+)
+
+func TestHandlingEcShardsHeartbeat(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	dn1 := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+	dn2 := rack.GetOrCreateDataNode("127.0.0.1", 34535, "127.0.0.1", 25)
+
+	var bits1, bits2 erasure_coding.ShardBits
+	for i := 0; i < 7; i++ {
+		bits1 = bits1.AddShardId(erasure_coding.ShardId(i))
+		bits2 = bits2.AddShardId(erasure_coding.ShardId(i + 7))
+	}
+
+	topo.SyncDataNodeEcShards([]*master_pb.VolumeEcShardInformationMessage{
+		{Id: 3, Collection: "", EcIndexBits: uint32(bits1)},
+	}, dn1)
+	topo.SyncDataNodeEcShards([]*master_pb.VolumeEcShardInformationMessage{
+		{Id: 3, Collection: "", EcIndexBits: uint32(bits2)},
+	}, dn2)
+
+	assert(t, "ecShardCount", int(topo.GetEcShardCount()), erasure_coding.TotalShardsCount)
+	assert(t, "dn1 free", int(dn1.FreeSpace()), 24)
+
+	locations, found := topo.LookupEcShards(storage.VolumeId(3))
+	if !found {
+		t.Fatalf("ec volume 3 not found")
+	}
+	assert(t, "shardCount", locations.ShardCount(), erasure_coding.TotalShardsCount)
+
+	// remove one shard from dn2 by a delta heartbeat
+	var deletedBits erasure_coding.ShardBits
+	deletedBits = deletedBits.AddShardId(13)
+	topo.IncrementalSyncDataNodeEcShards(nil, []*master_pb.VolumeEcShardInformationMessage{
+		{Id: 3, Collection: "", EcIndexBits: uint32(deletedBits)},
+	}, dn2)
+
+	assert(t, "ecShardCount", int(topo.GetEcShardCount()), erasure_coding.TotalShardsCount-1)
+	assert(t, "shardCount", locations.ShardCount(), erasure_coding.TotalShardsCount-1)
+
+	topo.UnRegisterDataNode(dn1)
+	topo.UnRegisterDataNode(dn2)
+
+	assert(t, "ecShardCount", int(topo.GetEcShardCount()), 0)
+	if _, found := topo.LookupEcShards(storage.VolumeId(3)); found {
+		t.Fatalf("ec volume 3 should be removed")
+	}
+}
@@ -62,12 +62,16 @@ func (t *Topology) ToVolumeLocations() (volumeLocations []*master_pb.VolumeLocat
 			for _, d := range rack.Children() {
 				dn := d.(*DataNode)
 				volumeLocation := &master_pb.VolumeLocation{
-					Url:       dn.Url(),
-					PublicUrl: dn.PublicUrl,
+					Url:        dn.Url(),
+					PublicUrl:  dn.PublicUrl,
+					DataCenter: string(dc.Id()),
 				}
 				for _, v := range dn.GetVolumes() {
 					volumeLocation.NewVids = append(volumeLocation.NewVids, uint32(v.Id))
 				}
+				for _, s := range dn.GetEcShards() {
+					volumeLocation.NewEcVids = append(volumeLocation.NewEcVids, uint32(s.VolumeId))
+				}
 				volumeLocations = append(volumeLocations, volumeLocation)
 			}
 		}
@@ -87,5 +91,18 @@ func (t *Topology) ToTopologyInfo() *master_pb.TopologyInfo {
 		dc := c.(*DataCenter)
 		m.DataCenterInfos = append(m.DataCenterInfos, dc.ToDataCenterInfo())
 	}
+	m.DiskInfos = toDiskInfos(t)
 	return m
 }
+
+func toDiskInfos(n Node) (diskInfos []*master_pb.DiskInfo) {
+	for diskType, usage := range n.GetDiskUsages() {
+		diskInfos = append(diskInfos, &master_pb.DiskInfo{
+			Type:            diskType.String(),
+			VolumeCount:     uint64(usage.VolumeCount),
+			MaxVolumeCount:  uint64(usage.MaxVolumeCount),
+			FreeVolumeCount: uint64(n.FreeSpaceOf(diskType)),
+		})
+	}
+	return
+}
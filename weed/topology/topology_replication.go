@@ -0,0 +1,352 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/operation"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/volume_server_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+)
+
+const (
+	ReplicationRepairWaiting   = "waiting" // in the grace period for the lost replicas to come back
+	ReplicationRepairPending   = "pending"
+	ReplicationRepairRunning   = "running"
+	ReplicationRepairSucceeded = "succeeded"
+	ReplicationRepairFailed    = "failed"
+
+	maxFinishedReplicationRepairs = 100
+)
+
+// ReplicationRepair copies an under-replicated volume to more data nodes, for its lost replicas
+type ReplicationRepair struct {
+	VolumeId         storage.VolumeId
+	Collection       string
+	ReplicaPlacement *storage.ReplicaPlacement
+	Ttl              *storage.TTL
+	DiskType         storage.DiskType
+	State            string
+	Locations        []string // the data nodes having the volume
+	Targets          []string // the data nodes the volume is copied to
+	DetectedAt       time.Time
+	StartedAt        time.Time
+	FinishedAt       time.Time
+	Error            string
+}
+
+func (repair *ReplicationRepair) ToMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	m["VolumeId"] = repair.VolumeId
+	m["Collection"] = repair.Collection
+	m["Replication"] = repair.ReplicaPlacement.String()
+	m["Ttl"] = repair.Ttl.String()
+	m["DiskType"] = repair.DiskType.String()
+	m["State"] = repair.State
+	m["Locations"] = repair.Locations
+	m["Targets"] = repair.Targets
+	m["DetectedAt"] = repair.DetectedAt
+	if !repair.StartedAt.IsZero() {
+		m["StartedAt"] = repair.StartedAt
+	}
+	if !repair.FinishedAt.IsZero() {
+		m["FinishedAt"] = repair.FinishedAt
+	}
+	if repair.Error != "" {
+		m["Error"] = repair.Error
+	}
+	return m
+}
+
+type replicationRepairs struct {
+	sync.RWMutex
+	underReplicated map[storage.VolumeId]*ReplicationRepair // waiting, pending, or running
+	pending         []*ReplicationRepair
+	finished        []*ReplicationRepair // the most recent first
+}
+
+func newReplicationRepairs() *replicationRepairs {
+	return &replicationRepairs{
+		underReplicated: make(map[storage.VolumeId]*ReplicationRepair),
+	}
+}
+
+// StartReplicationRepair lets the leader master copy the under-replicated volumes to more data nodes,
+// after the lost replicas are not back for the grace period, e.g., not a restart of the volume server.
+// At most one volume is repaired in each interval.
+func (t *Topology) StartReplicationRepair(vg *VolumeGrowth, grpcDialOption grpc.DialOption, gracePeriod time.Duration, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	glog.V(0).Infof("repair under-replicated volumes every %v, after %v", interval, gracePeriod)
+	go func() {
+		c := time.Tick(interval)
+		for range c {
+			if !t.IsLeader() {
+				t.replicationRepairs.reset()
+				continue
+			}
+			t.scheduleReplicationRepairs(gracePeriod, time.Now())
+			if repair := t.replicationRepairs.next(); repair != nil {
+				err := t.repairReplication(vg, grpcDialOption, repair)
+				t.replicationRepairs.finish(repair, err, time.Now())
+			}
+		}
+	}()
+}
+
+// ReplicationRepairs returns the under-replicated volumes being repaired, and the recently finished repairs
+func (t *Topology) ReplicationRepairs() (repairs []ReplicationRepair) {
+	r := t.replicationRepairs
+	r.RLock()
+	defer r.RUnlock()
+
+	var waiting []ReplicationRepair
+	for _, repair := range r.underReplicated {
+		switch repair.State {
+		case ReplicationRepairRunning:
+			repairs = append([]ReplicationRepair{*repair}, repairs...)
+		case ReplicationRepairWaiting:
+			waiting = append(waiting, *repair)
+		}
+	}
+	for _, repair := range r.pending {
+		repairs = append(repairs, *repair)
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		return waiting[i].VolumeId < waiting[j].VolumeId
+	})
+	repairs = append(repairs, waiting...)
+	for _, repair := range r.finished {
+		repairs = append(repairs, *repair)
+	}
+	return
+}
+
+func (t *Topology) scheduleReplicationRepairs(gracePeriod time.Duration, now time.Time) {
+	underReplicated := t.collectUnderReplicatedVolumes()
+
+	r := t.replicationRepairs
+	r.Lock()
+	defer r.Unlock()
+
+	// forget the volumes with the replicas back
+	for vid, repair := range r.underReplicated {
+		if _, found := underReplicated[vid]; !found && repair.State != ReplicationRepairRunning {
+			glog.V(0).Infof("volume %d is no longer under-replicated", vid)
+			delete(r.underReplicated, vid)
+		}
+	}
+	var pending []*ReplicationRepair
+	for _, repair := range r.pending {
+		if _, found := r.underReplicated[repair.VolumeId]; found {
+			pending = append(pending, repair)
+		}
+	}
+	r.pending = pending
+
+	var vids []storage.VolumeId
+	for vid := range underReplicated {
+		vids = append(vids, vid)
+	}
+	sort.Slice(vids, func(i, j int) bool {
+		return vids[i] < vids[j]
+	})
+	for _, vid := range vids {
+		repair, found := r.underReplicated[vid]
+		if !found {
+			repair = underReplicated[vid]
+			repair.State = ReplicationRepairWaiting
+			repair.DetectedAt = now
+			r.underReplicated[vid] = repair
+			glog.V(0).Infof("volume %d %s has replicas on %v only", vid, repair.ReplicaPlacement, repair.Locations)
+		}
+		if repair.State != ReplicationRepairWaiting {
+			continue
+		}
+		repair.Locations = underReplicated[vid].Locations
+		if now.Sub(repair.DetectedAt) >= gracePeriod {
+			repair.State = ReplicationRepairPending
+			r.pending = append(r.pending, repair)
+		}
+	}
+}
+
+func (t *Topology) collectUnderReplicatedVolumes() map[storage.VolumeId]*ReplicationRepair {
+	underReplicated := make(map[storage.VolumeId]*ReplicationRepair)
+	for _, col := range t.collectionMap.Items() {
+		c := col.(*Collection)
+		for _, vl := range c.storageType2VolumeLayout.Items() {
+			if vl == nil {
+				continue
+			}
+			volumeLayout := vl.(*VolumeLayout)
+			volumeLayout.accessLock.RLock()
+			for vid, locationList := range volumeLayout.vid2location {
+				// the volume with all replicas lost can not be repaired
+				if locationList.Length() == 0 || locationList.Length() >= volumeLayout.rp.GetCopyCount() {
+					continue
+				}
+				if !isReplicationRepairable(vid, locationList) {
+					continue
+				}
+				repair := &ReplicationRepair{
+					VolumeId:         vid,
+					Collection:       c.Name,
+					ReplicaPlacement: volumeLayout.rp,
+					Ttl:              volumeLayout.ttl,
+					DiskType:         volumeLayout.diskType,
+				}
+				for _, dn := range locationList.list {
+					repair.Locations = append(repair.Locations, dn.Url())
+				}
+				underReplicated[vid] = repair
+			}
+			volumeLayout.accessLock.RUnlock()
+		}
+	}
+	return underReplicated
+}
+
+// isReplicationRepairable skips the volumes on the remote tiers, which are not copied, and the read-only volumes,
+// which may be moved or tiered
+func isReplicationRepairable(vid storage.VolumeId, locationList *VolumeLocationList) bool {
+	for _, dn := range locationList.list {
+		volumeInfo, err := dn.GetVolumesById(vid)
+		if err != nil || volumeInfo.ReadOnly || volumeInfo.RemoteStorageName != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// replicationSources lists the data nodes to copy the volume from, the largest copy first,
+// excluding the draining data nodes, which are being emptied
+func replicationSources(vid storage.VolumeId, locations []*DataNode) (sources []*DataNode) {
+	sizes := make(map[*DataNode]uint64)
+	for _, dn := range locations {
+		if dn.IsDraining() {
+			continue
+		}
+		volumeInfo, err := dn.GetVolumesById(vid)
+		if err != nil {
+			continue
+		}
+		sizes[dn] = volumeInfo.Size
+		sources = append(sources, dn)
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sizes[sources[i]] > sizes[sources[j]]
+	})
+	return
+}
+
+func (t *Topology) repairReplication(vg *VolumeGrowth, grpcDialOption grpc.DialOption, repair *ReplicationRepair) error {
+	locations := t.Lookup(repair.Collection, repair.VolumeId)
+	if len(locations) == 0 {
+		return fmt.Errorf("volume %d not found", repair.VolumeId)
+	}
+	sources := replicationSources(repair.VolumeId, locations)
+	if len(sources) == 0 {
+		return fmt.Errorf("volume %d has replicas on draining volume servers only", repair.VolumeId)
+	}
+
+	option := &VolumeGrowOption{
+		Collection:       repair.Collection,
+		ReplicaPlacement: repair.ReplicaPlacement,
+		Ttl:              repair.Ttl,
+		DiskType:         repair.DiskType,
+	}
+	servers, err := vg.FindEmptySlotsForReplicas(t, option, locations)
+	if err != nil {
+		return fmt.Errorf("find data nodes for volume %d: %v", repair.VolumeId, err)
+	}
+
+	// the new replicas are registered by the heartbeats of the data nodes
+	for _, server := range servers {
+		for i, source := range sources {
+			glog.V(0).Infof("replicating volume %d from %s to %s", repair.VolumeId, source.Url(), server.Url())
+			err = operation.WithVolumeServerClient(server.Url(), grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+				_, replicateErr := volumeServerClient.ReplicateVolume(context.Background(), &volume_server_pb.ReplicateVolumeRequest{
+					VolumeId:       uint32(repair.VolumeId),
+					Collection:     repair.Collection,
+					SourceDataNode: source.Url(),
+					DiskType:       string(repair.DiskType),
+				})
+				return replicateErr
+			})
+			if err == nil {
+				break
+			}
+			err = fmt.Errorf("replicate volume %d from %s to %s: %v", repair.VolumeId, source.Url(), server.Url(), err)
+			if i < len(sources)-1 {
+				glog.V(0).Infof("%v, trying another replica", err)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		t.replicationRepairs.addTarget(repair, server.Url())
+	}
+
+	return nil
+}
+
+func (r *replicationRepairs) next() *ReplicationRepair {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.pending) == 0 {
+		return nil
+	}
+	repair := r.pending[0]
+	r.pending = r.pending[1:]
+	repair.State = ReplicationRepairRunning
+	repair.StartedAt = time.Now()
+	return repair
+}
+
+func (r *replicationRepairs) addTarget(repair *ReplicationRepair, target string) {
+	r.Lock()
+	defer r.Unlock()
+
+	repair.Targets = append(repair.Targets, target)
+}
+
+func (r *replicationRepairs) finish(repair *ReplicationRepair, err error, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+
+	finished := *repair
+	finished.FinishedAt = now
+	if err != nil {
+		glog.V(0).Infof("repair volume %d: %v", repair.VolumeId, err)
+		finished.State, finished.Error = ReplicationRepairFailed, err.Error()
+		// retry after another grace period
+		repair.State, repair.DetectedAt, repair.Targets = ReplicationRepairWaiting, now, nil
+	} else {
+		glog.V(0).Infof("repaired volume %d with replicas on %v", repair.VolumeId, repair.Targets)
+		finished.State = ReplicationRepairSucceeded
+		delete(r.underReplicated, repair.VolumeId)
+	}
+
+	r.finished = append([]*ReplicationRepair{&finished}, r.finished...)
+	if len(r.finished) > maxFinishedReplicationRepairs {
+		r.finished = r.finished[:maxFinishedReplicationRepairs]
+	}
+}
+
+// reset forgets the under-replicated volumes, when the master is no longer the leader
+func (r *replicationRepairs) reset() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.underReplicated = make(map[storage.VolumeId]*ReplicationRepair)
+	r.pending = nil
+}
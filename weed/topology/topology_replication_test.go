@@ -0,0 +1,108 @@
+package topology
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+)
+
+func TestScheduleReplicationRepairs(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	rack := topo.GetOrCreateDataCenter("dc1").GetOrCreateRack("rack1")
+	dn1 := rack.GetOrCreateDataNode("127.0.0.1", 8080, "127.0.0.1:8080", 1)
+	dn2 := rack.GetOrCreateDataNode("127.0.0.1", 8081, "127.0.0.1:8081", 1)
+
+	volumeMessages := []*master_pb.VolumeInformationMessage{{
+		Id:               1,
+		ReplicaPlacement: 1,
+		Version:          3,
+	}}
+	topo.SyncDataNodeRegistration(volumeMessages, dn1)
+
+	gracePeriod := 10 * time.Minute
+	now := time.Now()
+
+	topo.scheduleReplicationRepairs(gracePeriod, now)
+	assertReplicationRepairs(t, "detected", topo, ReplicationRepairWaiting)
+
+	topo.scheduleReplicationRepairs(gracePeriod, now.Add(gracePeriod))
+	assertReplicationRepairs(t, "after grace period", topo, ReplicationRepairPending)
+
+	// no free slot for the replica
+	repair := topo.replicationRepairs.next()
+	assertReplicationRepairs(t, "next", topo, ReplicationRepairRunning)
+	err := topo.repairReplication(NewDefaultVolumeGrowth(), nil, repair)
+	if err == nil {
+		t.Fatalf("repaired without free slots")
+	}
+	topo.replicationRepairs.finish(repair, err, now.Add(gracePeriod))
+	assertReplicationRepairs(t, "failed", topo, ReplicationRepairWaiting, ReplicationRepairFailed)
+
+	topo.scheduleReplicationRepairs(gracePeriod, now.Add(gracePeriod+time.Minute))
+	assertReplicationRepairs(t, "retry after another grace period", topo, ReplicationRepairWaiting, ReplicationRepairFailed)
+
+	// the replica is back
+	topo.SyncDataNodeRegistration(volumeMessages, dn2)
+	topo.scheduleReplicationRepairs(gracePeriod, now.Add(2*gracePeriod))
+	assertReplicationRepairs(t, "replica back", topo, ReplicationRepairFailed)
+}
+
+func TestCollectUnderReplicatedVolumes(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	rack := topo.GetOrCreateDataCenter("dc1").GetOrCreateRack("rack1")
+	dn := rack.GetOrCreateDataNode("127.0.0.1", 8080, "127.0.0.1:8080", 10)
+
+	topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{
+		{Id: 1, ReplicaPlacement: 1, Version: 3},
+		{Id: 2, ReplicaPlacement: 1, Version: 3, ReadOnly: true},
+		{Id: 3, ReplicaPlacement: 1, Version: 3, RemoteStorageName: "s3.default", RemoteStorageKey: "3.dat"},
+	}, dn)
+
+	underReplicated := topo.collectUnderReplicatedVolumes()
+	if len(underReplicated) != 1 || underReplicated[1] == nil {
+		t.Fatalf("unexpected under-replicated volumes %+v", underReplicated)
+	}
+}
+
+func TestReplicationSources(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	rack := topo.GetOrCreateDataCenter("dc1").GetOrCreateRack("rack1")
+	dn1 := rack.GetOrCreateDataNode("127.0.0.1", 8080, "127.0.0.1:8080", 10)
+	dn2 := rack.GetOrCreateDataNode("127.0.0.1", 8081, "127.0.0.1:8081", 10)
+	dn3 := rack.GetOrCreateDataNode("127.0.0.1", 8082, "127.0.0.1:8082", 10)
+
+	for dn, size := range map[*DataNode]uint64{dn1: 300, dn2: 100, dn3: 200} {
+		topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{
+			{Id: 1, Size: size, ReplicaPlacement: 2, Version: 3},
+		}, dn)
+	}
+	topo.SetDataNodeDraining("127.0.0.1:8080", true)
+
+	sources := replicationSources(1, []*DataNode{dn1, dn2, dn3})
+	if len(sources) != 2 || sources[0] != dn3 || sources[1] != dn2 {
+		t.Fatalf("unexpected sources %v", sources)
+	}
+
+	topo.SetDataNodeDraining("127.0.0.1:8081", true)
+	topo.SetDataNodeDraining("127.0.0.1:8082", true)
+	if sources = replicationSources(1, []*DataNode{dn1, dn2, dn3}); len(sources) != 0 {
+		t.Fatalf("unexpected sources %v on draining volume servers", sources)
+	}
+}
+
+func assertReplicationRepairs(t *testing.T, message string, topo *Topology, states ...string) {
+	repairs := topo.ReplicationRepairs()
+	if len(repairs) != len(states) {
+		t.Fatalf("%s: unexpected repairs %+v, expected %v", message, repairs, states)
+	}
+	for i, repair := range repairs {
+		if repair.State != states[i] {
+			t.Fatalf("%s: unexpected repairs %+v, expected %v", message, repairs, states)
+		}
+	}
+}
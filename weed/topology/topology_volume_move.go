@@ -0,0 +1,36 @@
+package topology
+
+import (
+	"fmt"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+)
+
+// MoveVolumeLocation switches the location of a moved volume from the source to the target data node,
+// unregistering the source and registering the target in one step. The target copy has the same volume
+// information as the source, and is writable once the source is gone, unless the source was read-only
+// before the move.
+func (t *Topology) MoveVolumeLocation(vid storage.VolumeId, sourceId, targetId string, readOnly bool) error {
+
+	source, target := t.findDataNode(sourceId), t.findDataNode(targetId)
+	if source == nil {
+		return fmt.Errorf("volume server %s not found", sourceId)
+	}
+	if target == nil {
+		return fmt.Errorf("volume server %s not found", targetId)
+	}
+
+	v, err := source.GetVolumesById(vid)
+	if err != nil {
+		return fmt.Errorf("volume %d not found on %s", vid, sourceId)
+	}
+	v.ReadOnly = readOnly
+
+	glog.V(0).Infof("moving volume %d from %s to %s", vid, sourceId, targetId)
+	target.AddOrUpdateVolume(v)
+	t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl, v.DiskType).MoveVolume(&v, source, target)
+	source.DeleteVolumeById(vid)
+
+	return nil
+}
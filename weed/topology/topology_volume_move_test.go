@@ -0,0 +1,67 @@
+package topology
+
+import (
+	"testing"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/master_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/sequence"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/storage"
+)
+
+func TestMoveVolumeLocation(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	source := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+	target := rack.GetOrCreateDataNode("127.0.0.1", 34535, "127.0.0.1", 25)
+
+	// the source is readonly, and the target copy is still catching up
+	topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{{
+		Id:       1,
+		Size:     uint64(25432),
+		Version:  uint32(storage.CurrentVersion),
+		ReadOnly: true,
+	}}, source)
+
+	rp, _ := storage.NewReplicaPlacementFromByte(0)
+	ttl, _ := storage.ReadTTL("")
+	vl := topo.GetVolumeLayout("", rp, ttl, storage.HardDriveType)
+	option := &VolumeGrowOption{}
+	assert(t, "readonly writable volumes", vl.GetActiveVolumeCount(option), 0)
+
+	if err := topo.MoveVolumeLocation(1, "127.0.0.1:34534", "127.0.0.1:34536", false); err == nil {
+		t.Fatalf("unexpected moving to unknown volume server")
+	}
+	if err := topo.MoveVolumeLocation(2, "127.0.0.1:34534", "127.0.0.1:34535", false); err == nil {
+		t.Fatalf("unexpected moving unknown volume")
+	}
+	if err := topo.MoveVolumeLocation(1, "127.0.0.1:34534", "127.0.0.1:34535", false); err != nil {
+		t.Fatalf("move volume: %v", err)
+	}
+
+	locations := vl.Lookup(1)
+	if len(locations) != 1 || locations[0] != target {
+		t.Fatalf("volume locations %v", locations)
+	}
+	assert(t, "moved writable volumes", vl.GetActiveVolumeCount(option), 1)
+	assert(t, "source volumes", len(source.GetVolumes()), 0)
+	assert(t, "target volumes", len(target.GetVolumes()), 1)
+	assert(t, "source free space", int(source.FreeSpace()), 25)
+	assert(t, "target free space", int(target.FreeSpace()), 24)
+
+	// a volume read-only before the move stays read-only
+	topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{{
+		Id:       3,
+		Size:     uint64(25432),
+		Version:  uint32(storage.CurrentVersion),
+		ReadOnly: true,
+	}}, source)
+	if err := topo.MoveVolumeLocation(3, "127.0.0.1:34534", "127.0.0.1:34535", true); err != nil {
+		t.Fatalf("move read-only volume: %v", err)
+	}
+	if locations := vl.Lookup(3); len(locations) != 1 || locations[0] != target {
+		t.Fatalf("read-only volume locations %v", locations)
+	}
+	assert(t, "writable volumes after moving a read-only volume", vl.GetActiveVolumeCount(option), 1)
+}
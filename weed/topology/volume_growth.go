@@ -15,7 +15,7 @@ import (
 This package is created to resolve these replica placement issues:
 1. growth factor for each replica level, e.g., add 10 volumes for 1 copy, 20 volumes for 2 copies, 30 volumes for 3 copies
 2. in time of tight storage, how to reduce replica level
-3. optimizing for hot data on faster disk, cold data on cheaper storage,
+3. optimizing for hot data on faster disk, cold data on cheaper storage, by the disk type of the volumes
 4. volume allocation for each bucket
 */
 
@@ -27,6 +27,7 @@ type VolumeGrowOption struct {
 	DataCenter       string
 	Rack             string
 	DataNode         string
+	DiskType         storage.DiskType
 }
 
 type VolumeGrowth struct {
@@ -34,7 +35,7 @@ type VolumeGrowth struct {
 }
 
 func (o *VolumeGrowOption) String() string {
-	return fmt.Sprintf("Collection:%s, ReplicaPlacement:%v, Ttl:%v, DataCenter:%s, Rack:%s, DataNode:%s", o.Collection, o.ReplicaPlacement, o.Ttl, o.DataCenter, o.Rack, o.DataNode)
+	return fmt.Sprintf("Collection:%s, ReplicaPlacement:%v, Ttl:%v, DataCenter:%s, Rack:%s, DataNode:%s, DiskType:%s", o.Collection, o.ReplicaPlacement, o.Ttl, o.DataCenter, o.Rack, o.DataNode, o.DiskType)
 }
 
 func NewDefaultVolumeGrowth() *VolumeGrowth {
@@ -99,21 +100,21 @@ func (vg *VolumeGrowth) findAndGrow(grpcDialOption grpc.DialOption, topo *Topolo
 func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *VolumeGrowOption) (servers []*DataNode, err error) {
 	//find main datacenter and other data centers
 	rp := option.ReplicaPlacement
-	mainDataCenter, otherDataCenters, dc_err := topo.RandomlyPickNodes(rp.DiffDataCenterCount+1, func(node Node) error {
+	mainDataCenter, otherDataCenters, dc_err := topo.RandomlyPickNodes(rp.DiffDataCenterCount+1, option.DiskType, func(node Node) error {
 		if option.DataCenter != "" && node.IsDataCenter() && node.Id() != NodeId(option.DataCenter) {
 			return fmt.Errorf("Not matching preferred data center:%s", option.DataCenter)
 		}
 		if len(node.Children()) < rp.DiffRackCount+1 {
 			return fmt.Errorf("Only has %d racks, not enough for %d.", len(node.Children()), rp.DiffRackCount+1)
 		}
-		if node.FreeSpace() < int64(rp.DiffRackCount+rp.SameRackCount+1) {
-			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpace(), rp.DiffRackCount+rp.SameRackCount+1)
+		if node.FreeSpaceOf(option.DiskType) < int64(rp.DiffRackCount+rp.SameRackCount+1) {
+			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpaceOf(option.DiskType), rp.DiffRackCount+rp.SameRackCount+1)
 		}
 		possibleRacksCount := 0
 		for _, rack := range node.Children() {
 			possibleDataNodesCount := 0
 			for _, n := range rack.Children() {
-				if n.FreeSpace() >= 1 {
+				if n.FreeSpaceOf(option.DiskType) >= 1 {
 					possibleDataNodesCount++
 				}
 			}
@@ -131,12 +132,12 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 	}
 
 	//find main rack and other racks
-	mainRack, otherRacks, rackErr := mainDataCenter.(*DataCenter).RandomlyPickNodes(rp.DiffRackCount+1, func(node Node) error {
+	mainRack, otherRacks, rackErr := mainDataCenter.(*DataCenter).RandomlyPickNodes(rp.DiffRackCount+1, option.DiskType, func(node Node) error {
 		if option.Rack != "" && node.IsRack() && node.Id() != NodeId(option.Rack) {
 			return fmt.Errorf("Not matching preferred rack:%s", option.Rack)
 		}
-		if node.FreeSpace() < int64(rp.SameRackCount+1) {
-			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpace(), rp.SameRackCount+1)
+		if node.FreeSpaceOf(option.DiskType) < int64(rp.SameRackCount+1) {
+			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpaceOf(option.DiskType), rp.SameRackCount+1)
 		}
 		if len(node.Children()) < rp.SameRackCount+1 {
 			// a bit faster way to test free racks
@@ -144,7 +145,7 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 		}
 		possibleDataNodesCount := 0
 		for _, n := range node.Children() {
-			if n.FreeSpace() >= 1 {
+			if n.FreeSpaceOf(option.DiskType) >= 1 {
 				possibleDataNodesCount++
 			}
 		}
@@ -158,12 +159,12 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 	}
 
 	//find main rack and other racks
-	mainServer, otherServers, serverErr := mainRack.(*Rack).RandomlyPickNodes(rp.SameRackCount+1, func(node Node) error {
+	mainServer, otherServers, serverErr := mainRack.(*Rack).RandomlyPickNodes(rp.SameRackCount+1, option.DiskType, func(node Node) error {
 		if option.DataNode != "" && node.IsDataNode() && node.Id() != NodeId(option.DataNode) {
 			return fmt.Errorf("Not matching preferred data node:%s", option.DataNode)
 		}
-		if node.FreeSpace() < 1 {
-			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpace(), 1)
+		if node.FreeSpaceOf(option.DiskType) < 1 {
+			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpaceOf(option.DiskType), 1)
 		}
 		return nil
 	})
@@ -176,16 +177,16 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 		servers = append(servers, server.(*DataNode))
 	}
 	for _, rack := range otherRacks {
-		r := rand.Int63n(rack.FreeSpace())
-		if server, e := rack.ReserveOneVolume(r); e == nil {
+		r := rand.Int63n(rack.FreeSpaceOf(option.DiskType))
+		if server, e := rack.ReserveOneVolume(r, option.DiskType); e == nil {
 			servers = append(servers, server)
 		} else {
 			return servers, e
 		}
 	}
 	for _, datacenter := range otherDataCenters {
-		r := rand.Int63n(datacenter.FreeSpace())
-		if server, e := datacenter.ReserveOneVolume(r); e == nil {
+		r := rand.Int63n(datacenter.FreeSpaceOf(option.DiskType))
+		if server, e := datacenter.ReserveOneVolume(r, option.DiskType); e == nil {
 			servers = append(servers, server)
 		} else {
 			return servers, e
@@ -194,6 +195,103 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 	return
 }
 
+// FindEmptySlotsForReplicas finds the data nodes for the missing replicas of a volume.
+// Same as findEmptySlotsForOneVolume, the main data center and the main rack are the ones
+// with the most replicas, the other data centers and the other racks have one replica each.
+// 1. find a data node in another data center, if not enough data centers
+// 2. find a data node in another rack of the main data center, if not enough racks
+// 3. find another data node in the main rack
+func (vg *VolumeGrowth) FindEmptySlotsForReplicas(topo *Topology, option *VolumeGrowOption, existingServers []*DataNode) (servers []*DataNode, err error) {
+	vg.accessLock.Lock()
+	defer vg.accessLock.Unlock()
+
+	replicas := append([]*DataNode(nil), existingServers...)
+	for len(replicas) < option.ReplicaPlacement.GetCopyCount() {
+		server, e := vg.findEmptySlotForOneReplica(topo, option, replicas)
+		if e != nil {
+			return servers, e
+		}
+		servers = append(servers, server)
+		replicas = append(replicas, server)
+	}
+	return
+}
+
+func (vg *VolumeGrowth) findEmptySlotForOneReplica(topo *Topology, option *VolumeGrowOption, replicas []*DataNode) (*DataNode, error) {
+	rp := option.ReplicaPlacement
+
+	// the replica counts by the data centers and the racks
+	dataCenterReplicas := make(map[Node]int)
+	rackReplicas := make(map[Node]int)
+	rackDataCenters := make(map[Node]Node)
+	servers := make(map[NodeId]bool)
+	var mainDataCenter, mainRack Node
+	for _, dn := range replicas {
+		dataCenter, rack := Node(dn.GetDataCenter()), Node(dn.GetRack())
+		dataCenterReplicas[dataCenter]++
+		if mainDataCenter == nil || dataCenterReplicas[dataCenter] > dataCenterReplicas[mainDataCenter] {
+			mainDataCenter = dataCenter
+		}
+		rackReplicas[rack]++
+		rackDataCenters[rack] = dataCenter
+		servers[dn.Id()] = true
+	}
+	mainDataCenterRacks := 0
+	for rack, count := range rackReplicas {
+		if rackDataCenters[rack] != mainDataCenter {
+			continue
+		}
+		mainDataCenterRacks++
+		if mainRack == nil || count > rackReplicas[mainRack] {
+			mainRack = rack
+		}
+	}
+
+	hasFreeSlot := func(node Node) error {
+		if node.FreeSpaceOf(option.DiskType) < 1 {
+			return fmt.Errorf("Free:%d < Expected:%d", node.FreeSpaceOf(option.DiskType), 1)
+		}
+		return nil
+	}
+
+	if len(dataCenterReplicas) < rp.DiffDataCenterCount+1 {
+		dataCenter, _, err := topo.RandomlyPickNodes(1, option.DiskType, func(node Node) error {
+			if dataCenterReplicas[node] > 0 {
+				return fmt.Errorf("Already has %d replicas", dataCenterReplicas[node])
+			}
+			return hasFreeSlot(node)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return dataCenter.ReserveOneVolume(rand.Int63n(dataCenter.FreeSpaceOf(option.DiskType)), option.DiskType)
+	}
+
+	if mainDataCenterRacks < rp.DiffRackCount+1 {
+		rack, _, err := mainDataCenter.(*DataCenter).RandomlyPickNodes(1, option.DiskType, func(node Node) error {
+			if rackReplicas[node] > 0 {
+				return fmt.Errorf("Already has %d replicas", rackReplicas[node])
+			}
+			return hasFreeSlot(node)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return rack.ReserveOneVolume(rand.Int63n(rack.FreeSpaceOf(option.DiskType)), option.DiskType)
+	}
+
+	server, _, err := mainRack.(*Rack).RandomlyPickNodes(1, option.DiskType, func(node Node) error {
+		if servers[node.Id()] {
+			return fmt.Errorf("Already has the replica")
+		}
+		return hasFreeSlot(node)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server.(*DataNode), nil
+}
+
 func (vg *VolumeGrowth) grow(grpcDialOption grpc.DialOption, topo *Topology, vid storage.VolumeId, option *VolumeGrowOption, servers ...*DataNode) error {
 	for _, server := range servers {
 		if err := AllocateVolume(server, grpcDialOption, vid, option); err == nil {
@@ -204,6 +302,7 @@ func (vg *VolumeGrowth) grow(grpcDialOption grpc.DialOption, topo *Topology, vid
 				ReplicaPlacement: option.ReplicaPlacement,
 				Ttl:              option.Ttl,
 				Version:          storage.CurrentVersion,
+				DiskType:         option.DiskType,
 			}
 			server.AddOrUpdateVolume(vi)
 			topo.RegisterVolumeLayout(vi, server)
@@ -129,3 +129,105 @@ func TestFindEmptySlotsForOneVolume(t *testing.T) {
 		fmt.Println("assigned node :", server.Id())
 	}
 }
+
+func TestFindEmptySlotsOfDiskType(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	rack := topo.GetOrCreateDataCenter("dc1").GetOrCreateRack("rack1")
+	hddServer := rack.GetOrCreateDataNode("127.0.0.1", 8080, "127.0.0.1:8080", 10)
+	ssdServer1 := rack.GetOrCreateDataNode("127.0.0.1", 8081, "127.0.0.1:8081", 10)
+	ssdServer1.UpdateMaxVolumeCounts(map[string]uint32{"": 8, "ssd": 2})
+	ssdServer2 := rack.GetOrCreateDataNode("127.0.0.1", 8082, "127.0.0.1:8082", 10)
+	ssdServer2.UpdateMaxVolumeCounts(map[string]uint32{"ssd": 1})
+
+	if free := topo.FreeSpaceOf(storage.SsdType); free != 3 {
+		t.Fatalf("ssd free %d", free)
+	}
+	if free := topo.FreeSpaceOf(storage.HardDriveType); free != 18 {
+		t.Fatalf("hdd free %d", free)
+	}
+	if max := topo.GetMaxVolumeCount(); max != 21 {
+		t.Fatalf("max %d", max)
+	}
+
+	vg := NewDefaultVolumeGrowth()
+	rp, _ := storage.NewReplicaPlacementFromString("001")
+	option := &VolumeGrowOption{ReplicaPlacement: rp, DiskType: storage.SsdType}
+	for i := 0; i < 10; i++ {
+		servers, err := vg.findEmptySlotsForOneVolume(topo, option)
+		if err != nil {
+			t.Fatalf("find ssd slots: %v", err)
+		}
+		for _, server := range servers {
+			if server == hddServer {
+				t.Fatalf("ssd volume assigned to hdd only server %s", server.Id())
+			}
+		}
+	}
+
+	// the ssd volumes only take the ssd slots
+	ssdServer2.AddOrUpdateVolume(storage.VolumeInfo{Id: 1, ReplicaPlacement: rp, DiskType: storage.SsdType, Version: storage.CurrentVersion})
+	ssdServer1.AddOrUpdateVolume(storage.VolumeInfo{Id: 1, ReplicaPlacement: rp, DiskType: storage.SsdType, Version: storage.CurrentVersion})
+	if free := topo.FreeSpaceOf(storage.SsdType); free != 1 {
+		t.Fatalf("ssd free after adding volumes %d", free)
+	}
+	if free := topo.FreeSpaceOf(storage.HardDriveType); free != 18 {
+		t.Fatalf("hdd free after adding volumes %d", free)
+	}
+	if _, err := vg.findEmptySlotsForOneVolume(topo, option); err == nil {
+		t.Fatalf("should not find 2 ssd slots on different servers")
+	}
+
+	topo.UnRegisterDataNode(ssdServer1)
+	if free := topo.FreeSpaceOf(storage.SsdType); free != 0 {
+		t.Fatalf("ssd free after removing server %d", free)
+	}
+	if free := topo.FreeSpaceOf(storage.HardDriveType); free != 10 {
+		t.Fatalf("hdd free after removing server %d", free)
+	}
+}
+
+func TestFindEmptySlotsForReplicas(t *testing.T) {
+	topo := setup(topologyLayout)
+	vg := NewDefaultVolumeGrowth()
+
+	tests := []struct {
+		replication string
+		existing    []string
+		expected    []string // any one of them
+	}{
+		{"100", []string{"server111"}, []string{"server321"}},
+		{"010", []string{"server111"}, []string{"server121", "server122", "server123"}},
+		{"001", []string{"server121"}, []string{"server122", "server123"}},
+		{"011", []string{"server111", "server121"}, []string{"server112", "server122", "server123"}},
+		{"200", []string{"server111"}, nil},
+	}
+	for _, test := range tests {
+		rp, _ := storage.NewReplicaPlacementFromString(test.replication)
+		var existing []*DataNode
+		for _, id := range test.existing {
+			existing = append(existing, topo.findDataNode(id))
+		}
+		for i := 0; i < 10; i++ {
+			servers, err := vg.FindEmptySlotsForReplicas(topo, &VolumeGrowOption{ReplicaPlacement: rp}, existing)
+			if test.expected == nil {
+				if err == nil {
+					t.Fatalf("%s on %v: unexpected %v", test.replication, test.existing, servers)
+				}
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s on %v: %v", test.replication, test.existing, err)
+			}
+			if len(servers) != 1 {
+				t.Fatalf("%s on %v: found %v", test.replication, test.existing, servers)
+			}
+			found := false
+			for _, id := range test.expected {
+				found = found || string(servers[0].Id()) == id
+			}
+			if !found {
+				t.Fatalf("%s on %v: found %s, expected one of %v", test.replication, test.existing, servers[0].Id(), test.expected)
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 type VolumeLayout struct {
 	rp               *storage.ReplicaPlacement
 	ttl              *storage.TTL
+	diskType         storage.DiskType
 	vid2location     map[storage.VolumeId]*VolumeLocationList
 	writables        []storage.VolumeId        // transient array of writable volume id
 	readonlyVolumes  map[storage.VolumeId]bool // transient set of readonly volumes
@@ -29,10 +30,11 @@ type VolumeLayoutStats struct {
 	FileCount uint64
 }
 
-func NewVolumeLayout(rp *storage.ReplicaPlacement, ttl *storage.TTL, volumeSizeLimit uint64) *VolumeLayout {
+func NewVolumeLayout(rp *storage.ReplicaPlacement, ttl *storage.TTL, diskType storage.DiskType, volumeSizeLimit uint64) *VolumeLayout {
 	return &VolumeLayout{
 		rp:               rp,
 		ttl:              ttl,
+		diskType:         diskType,
 		vid2location:     make(map[storage.VolumeId]*VolumeLocationList),
 		writables:        *new([]storage.VolumeId),
 		readonlyVolumes:  make(map[storage.VolumeId]bool),
@@ -49,6 +51,22 @@ func (vl *VolumeLayout) RegisterVolume(v *storage.VolumeInfo, dn *DataNode) {
 	vl.accessLock.Lock()
 	defer vl.accessLock.Unlock()
 
+	vl.registerVolume(v, dn)
+}
+
+// MoveVolume replaces the source location of the volume with the target location at once,
+// so the lookups always find one of them
+func (vl *VolumeLayout) MoveVolume(v *storage.VolumeInfo, source, target *DataNode) {
+	vl.accessLock.Lock()
+	defer vl.accessLock.Unlock()
+
+	if location, ok := vl.vid2location[v.Id]; ok {
+		location.Remove(source)
+	}
+	vl.registerVolume(v, target)
+}
+
+func (vl *VolumeLayout) registerVolume(v *storage.VolumeInfo, dn *DataNode) {
 	if _, ok := vl.vid2location[v.Id]; !ok {
 		vl.vid2location[v.Id] = NewVolumeLocationList()
 	}
@@ -71,7 +89,7 @@ func (vl *VolumeLayout) RegisterVolume(v *storage.VolumeInfo, dn *DataNode) {
 			return
 		}
 	}
-	if vl.vid2location[v.Id].Length() == vl.rp.GetCopyCount() && vl.isWritable(v) {
+	if vl.vid2location[v.Id].Length() == vl.rp.GetCopyCount() && vl.isWritable(v) && !vl.vid2location[v.Id].HasDrainingNode() {
 		if _, ok := vl.oversizedVolumes[v.Id]; !ok {
 			vl.addToWritable(v.Id)
 		}
@@ -92,7 +110,13 @@ func (vl *VolumeLayout) UnRegisterVolume(v *storage.VolumeInfo, dn *DataNode) {
 	defer vl.accessLock.Unlock()
 
 	vl.removeFromWritable(v.Id)
-	delete(vl.vid2location, v.Id)
+	// the other replicas are still available, e.g. after moving one replica away
+	if location, ok := vl.vid2location[v.Id]; ok {
+		location.Remove(dn)
+		if location.Length() == 0 {
+			delete(vl.vid2location, v.Id)
+		}
+	}
 }
 
 func (vl *VolumeLayout) addToWritable(vid storage.VolumeId) {
@@ -250,7 +274,7 @@ func (vl *VolumeLayout) SetVolumeAvailable(dn *DataNode, vid storage.VolumeId) b
 	defer vl.accessLock.Unlock()
 
 	vl.vid2location[vid].Set(dn)
-	if vl.vid2location[vid].Length() >= vl.rp.GetCopyCount() {
+	if vl.vid2location[vid].Length() >= vl.rp.GetCopyCount() && !vl.vid2location[vid].HasDrainingNode() {
 		return vl.setVolumeWritable(vid)
 	}
 	return false
@@ -268,6 +292,7 @@ func (vl *VolumeLayout) ToMap() map[string]interface{} {
 	m := make(map[string]interface{})
 	m["replication"] = vl.rp.String()
 	m["ttl"] = vl.ttl.String()
+	m["disk"] = vl.diskType.String()
 	m["writables"] = vl.writables
 	//m["locations"] = vl.vid2location
 	return m
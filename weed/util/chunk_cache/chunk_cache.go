@@ -0,0 +1,85 @@
+package chunk_cache
+
+import (
+	"time"
+
+	"github.com/karlseguin/ccache"
+)
+
+// the chunks are immutable, the ttl only keeps ccache from treating them as stale
+const memoryCacheTtl = 24 * time.Hour
+
+// ChunkCache caches the chunk content by the file id,
+// in memory, and optionally on the local disk.
+type ChunkCache struct {
+	memCache       *ccache.Cache
+	memoryCapacity int64
+	diskCache      *onDiskCache
+}
+
+// NewChunkCache creates a cache with the memory capacity in bytes.
+// If the dir is not empty, the chunks are also kept in the dir, up to the disk capacity in bytes.
+func NewChunkCache(memoryCapacity int64, dir string, diskCapacity int64) (*ChunkCache, error) {
+	c := &ChunkCache{
+		memoryCapacity: memoryCapacity,
+	}
+	if memoryCapacity > 0 {
+		c.memCache = ccache.New(ccache.Configure().MaxSize(memoryCapacity).ItemsToPrune(16))
+	}
+	if dir != "" && diskCapacity > 0 {
+		diskCache, err := newOnDiskCache(dir, diskCapacity)
+		if err != nil {
+			return nil, err
+		}
+		c.diskCache = diskCache
+	}
+	return c, nil
+}
+
+// GetChunk returns the cached chunk content, or nil if not cached
+func (c *ChunkCache) GetChunk(fileId string) []byte {
+
+	if c.memCache != nil {
+		if item := c.memCache.Get(fileId); item != nil {
+			return item.Value().(chunkData)
+		}
+	}
+
+	if c.diskCache == nil {
+		return nil
+	}
+	data := c.diskCache.getChunk(fileId)
+	if data != nil {
+		c.setMemoryChunk(fileId, data)
+	}
+	return data
+}
+
+// SetChunk caches the chunk content
+func (c *ChunkCache) SetChunk(fileId string, data []byte) {
+	c.setMemoryChunk(fileId, data)
+	if c.diskCache != nil {
+		c.diskCache.setChunk(fileId, data)
+	}
+}
+
+func (c *ChunkCache) setMemoryChunk(fileId string, data []byte) {
+	// too large chunks would evict most of the other chunks
+	if c.memCache == nil || int64(len(data)) > c.memoryCapacity/4 {
+		return
+	}
+	c.memCache.Set(fileId, chunkData(data), memoryCacheTtl)
+}
+
+func (c *ChunkCache) Shutdown() {
+	if c.memCache != nil {
+		c.memCache.Stop()
+	}
+}
+
+// chunkData is sized by the bytes in ccache
+type chunkData []byte
+
+func (d chunkData) Size() int64 {
+	return int64(len(d))
+}
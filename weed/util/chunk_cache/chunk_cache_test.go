@@ -0,0 +1,72 @@
+package chunk_cache
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestMemoryChunkCache(t *testing.T) {
+
+	cache, _ := NewChunkCache(1024, "", 0)
+	defer cache.Shutdown()
+
+	cache.SetChunk("1,01", []byte("hello"))
+	if data := cache.GetChunk("1,01"); string(data) != "hello" {
+		t.Errorf("unexpected chunk: %s", data)
+	}
+	if data := cache.GetChunk("1,02"); data != nil {
+		t.Errorf("unexpected chunk: %s", data)
+	}
+
+	// larger than a quarter of the capacity
+	cache.SetChunk("1,03", make([]byte, 512))
+	if data := cache.GetChunk("1,03"); data != nil {
+		t.Errorf("too large chunk should not be cached")
+	}
+
+}
+
+func TestOnDiskChunkCache(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "chunk_cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewChunkCache(0, dir, 1000)
+	if err != nil {
+		t.Fatalf("new chunk cache: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		cache.SetChunk(fmt.Sprintf("1,%02d", i), bytes.Repeat([]byte{byte(i)}, 100))
+	}
+	// use the first chunk, and evict the second one
+	if data := cache.GetChunk("1,00"); len(data) != 100 || data[0] != 0 {
+		t.Errorf("unexpected chunk 1,00: %v", data)
+	}
+	cache.SetChunk("1,10", bytes.Repeat([]byte{10}, 100))
+	if data := cache.GetChunk("1,01"); data != nil {
+		t.Errorf("chunk 1,01 should be evicted")
+	}
+	if data := cache.GetChunk("1,00"); len(data) != 100 {
+		t.Errorf("chunk 1,00 should not be evicted")
+	}
+
+	// load the cached chunks again
+	cache, err = NewChunkCache(0, dir, 1000)
+	if err != nil {
+		t.Fatalf("reload chunk cache: %v", err)
+	}
+	if data := cache.GetChunk("1,10"); len(data) != 100 || data[0] != 10 {
+		t.Errorf("unexpected reloaded chunk 1,10: %v", data)
+	}
+	if len(cache.diskCache.entries) != 10 || cache.diskCache.size != 1000 {
+		t.Errorf("unexpected reloaded cache: %d chunks, %d bytes", len(cache.diskCache.entries), cache.diskCache.size)
+	}
+
+}
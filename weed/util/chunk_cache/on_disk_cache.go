@@ -0,0 +1,159 @@
+package chunk_cache
+
+import (
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+)
+
+// onDiskCache keeps one file per chunk in the dir, and evicts the least recently used chunks
+type onDiskCache struct {
+	dir      string
+	capacity int64
+
+	sync.Mutex
+	size    int64
+	lru     *list.List // of *onDiskChunk, the most recently used in the front
+	entries map[string]*list.Element
+}
+
+type onDiskChunk struct {
+	name string
+	size int64
+}
+
+func newOnDiskCache(dir string, capacity int64) (*onDiskCache, error) {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create chunk cache dir %s: %v", dir, err)
+	}
+
+	c := &onDiskCache{
+		dir:      dir,
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+
+	// the chunks cached by the previous mount are still valid
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read chunk cache dir %s: %v", dir, err)
+	}
+	sort.Slice(fileInfos, func(i, j int) bool {
+		return fileInfos[i].ModTime().After(fileInfos[j].ModTime())
+	})
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(fileInfo.Name(), ".tmp") {
+			// interrupted while writing
+			os.Remove(filepath.Join(dir, fileInfo.Name()))
+			continue
+		}
+		c.entries[fileInfo.Name()] = c.lru.PushBack(&onDiskChunk{
+			name: fileInfo.Name(),
+			size: fileInfo.Size(),
+		})
+		c.size += fileInfo.Size()
+	}
+	c.evict()
+
+	glog.V(0).Infof("loaded %d cached chunks, %d bytes from %s", len(c.entries), c.size, dir)
+
+	return c, nil
+}
+
+func (c *onDiskCache) getChunk(fileId string) []byte {
+
+	name := toFileName(fileId)
+
+	c.Lock()
+	element, found := c.entries[name]
+	if found {
+		c.lru.MoveToFront(element)
+	}
+	c.Unlock()
+
+	if !found {
+		return nil
+	}
+
+	// the chunk may be evicted concurrently
+	data, err := ioutil.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		glog.V(4).Infof("read cached chunk %s: %v", fileId, err)
+		return nil
+	}
+	return data
+}
+
+func (c *onDiskCache) setChunk(fileId string, data []byte) {
+
+	size := int64(len(data))
+	if size > c.capacity {
+		return
+	}
+
+	name := toFileName(fileId)
+
+	c.Lock()
+	_, found := c.entries[name]
+	c.Unlock()
+	if found {
+		return
+	}
+
+	tmpPath := filepath.Join(c.dir, name+".tmp")
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		glog.V(0).Infof("write cached chunk %s: %v", fileId, err)
+		os.Remove(tmpPath)
+		return
+	}
+	if err := os.Rename(tmpPath, filepath.Join(c.dir, name)); err != nil {
+		glog.V(0).Infof("rename cached chunk %s: %v", fileId, err)
+		os.Remove(tmpPath)
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if _, found = c.entries[name]; found {
+		return
+	}
+	c.entries[name] = c.lru.PushFront(&onDiskChunk{
+		name: name,
+		size: size,
+	})
+	c.size += size
+	c.evict()
+}
+
+// evict removes the least recently used chunks until the cache fits the capacity
+func (c *onDiskCache) evict() {
+	for c.size > c.capacity {
+		element := c.lru.Back()
+		if element == nil {
+			return
+		}
+		chunk := c.lru.Remove(element).(*onDiskChunk)
+		delete(c.entries, chunk.name)
+		c.size -= chunk.size
+		if err := os.Remove(filepath.Join(c.dir, chunk.name)); err != nil {
+			glog.V(0).Infof("remove cached chunk %s: %v", chunk.name, err)
+		}
+	}
+}
+
+// the file id looks like "3,01637037d6", use it as the file name without the comma
+func toFileName(fileId string) string {
+	return strings.Replace(fileId, ",", "_", -1)
+}
@@ -118,3 +118,23 @@ func ServerToGrpcAddress(server string) (serverGrpcAddress string) {
 
 	return fmt.Sprintf("%s:%d", hostnameAndPort[0], grpcPort)
 }
+
+// ParseServerToRaftAddress returns the address of the raft transport of a master, which listens on the http port + 20000
+func ParseServerToRaftAddress(server string) (serverRaftAddress string, err error) {
+	hostnameAndPort := strings.Split(server, ":")
+	if len(hostnameAndPort) != 2 {
+		return "", fmt.Errorf("server should have hostname:port format: %v", hostnameAndPort)
+	}
+
+	port, parseErr := strconv.ParseUint(hostnameAndPort[1], 10, 64)
+	if parseErr != nil {
+		return "", fmt.Errorf("server port parse error: %v", parseErr)
+	}
+
+	raftPort := int(port) + 20000
+	if raftPort > 65535 {
+		return "", fmt.Errorf("server port %d is above 45535, the raft port %d is out of range", port, raftPort)
+	}
+
+	return fmt.Sprintf("%s:%d", hostnameAndPort[0], raftPort), nil
+}
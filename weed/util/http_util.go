@@ -189,6 +189,17 @@ func NormalizeUrl(url string) string {
 	return "http://" + url
 }
 
+// HttpStatusError is returned when the response status is not successful
+type HttpStatusError struct {
+	Url        string
+	StatusCode int
+	Status     string
+}
+
+func (e *HttpStatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Url, e.Status)
+}
+
 func ReadUrl(fileUrl string, offset int64, size int, buf []byte, isReadRange bool) (n int64, e error) {
 
 	req, _ := http.NewRequest("GET", fileUrl, nil)
@@ -205,7 +216,7 @@ func ReadUrl(fileUrl string, offset int64, size int, buf []byte, isReadRange boo
 
 	defer r.Body.Close()
 	if r.StatusCode >= 400 {
-		return 0, fmt.Errorf("%s: %s", fileUrl, r.Status)
+		return 0, &HttpStatusError{Url: fileUrl, StatusCode: r.StatusCode, Status: r.Status}
 	}
 
 	var reader io.ReadCloser
@@ -221,16 +232,16 @@ func ReadUrl(fileUrl string, offset int64, size int, buf []byte, isReadRange boo
 
 	for {
 		m, err = reader.Read(buf[i:])
-		if m == 0 {
-			return
-		}
 		i += m
 		n += int64(m)
 		if err == io.EOF {
 			return n, nil
 		}
-		if e != nil {
-			return n, e
+		if err != nil {
+			return n, err
+		}
+		if m == 0 {
+			return
 		}
 	}
 
@@ -247,7 +258,7 @@ func ReadUrlAsStream(fileUrl string, offset int64, size int, fn func(data []byte
 	}
 	defer r.Body.Close()
 	if r.StatusCode >= 400 {
-		return 0, fmt.Errorf("%s: %s", fileUrl, r.Status)
+		return 0, &HttpStatusError{Url: fileUrl, StatusCode: r.StatusCode, Status: r.Status}
 	}
 
 	var m int
@@ -77,8 +77,9 @@ func (mc *MasterClient) tryAllMasters() {
 					return err
 				} else {
 					loc := Location{
-						Url:       volumeLocation.Url,
-						PublicUrl: volumeLocation.PublicUrl,
+						Url:        volumeLocation.Url,
+						PublicUrl:  volumeLocation.PublicUrl,
+						DataCenter: volumeLocation.DataCenter,
 					}
 					for _, newVid := range volumeLocation.NewVids {
 						mc.addLocation(newVid, loc)
@@ -86,6 +87,12 @@ func (mc *MasterClient) tryAllMasters() {
 					for _, deletedVid := range volumeLocation.DeletedVids {
 						mc.deleteLocation(deletedVid, loc)
 					}
+					for _, newEcVid := range volumeLocation.NewEcVids {
+						mc.addEcLocation(newEcVid, loc)
+					}
+					for _, deletedEcVid := range volumeLocation.DeletedEcVids {
+						mc.deleteEcLocation(deletedEcVid, loc)
+					}
 				}
 			}
 
@@ -0,0 +1,90 @@
+package wdclient
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/glog"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/pb/filer_pb"
+	"gitlab.momenta.works/kubetrain/seaweedfs/weed/util"
+)
+
+const (
+	readRetryRounds   = 2
+	readRetryInterval = 500 * time.Millisecond
+)
+
+// SortLocations returns a copy of the locations, with the ones in the data center first
+func SortLocations(locations []Location, dataCenter string) (sorted []Location) {
+	sorted = make([]Location, 0, len(locations))
+	for _, loc := range locations {
+		if dataCenter != "" && loc.DataCenter == dataCenter {
+			sorted = append(sorted, loc)
+		}
+	}
+	for _, loc := range locations {
+		if dataCenter == "" || loc.DataCenter != dataCenter {
+			sorted = append(sorted, loc)
+		}
+	}
+	return
+}
+
+// ReadFromLocations reads the file id from the replicas in order, preferring the data center.
+// The next replica is tried on connection errors or 5xx responses.
+func ReadFromLocations(locations []Location, dataCenter string, fileId string, offset int64, size int, buf []byte, isReadRange bool) (n int64, err error) {
+
+	if len(locations) == 0 {
+		return 0, fmt.Errorf("failed to locate %s", fileId)
+	}
+
+	locations = SortLocations(locations, dataCenter)
+
+	for round := 0; round < readRetryRounds; round++ {
+		if round > 0 {
+			time.Sleep(readRetryInterval)
+		}
+		for _, loc := range locations {
+			fileUrl := fmt.Sprintf("http://%s/%s", loc.Url, fileId)
+			n, err = util.ReadUrl(fileUrl, offset, size, buf, isReadRange)
+			if err == nil {
+				return n, nil
+			}
+			if !IsRetryableReadError(err) {
+				return n, err
+			}
+			glog.V(1).Infof("read %s: %v, try the next replica", fileUrl, err)
+		}
+	}
+
+	return n, fmt.Errorf("failed to read %s from %d replicas: %v", fileId, len(locations), err)
+}
+
+// FromFilerLocations converts the locations looked up from the filer
+func FromFilerLocations(locations *filer_pb.Locations) (locs []Location) {
+	for _, loc := range locations.Locations {
+		locs = append(locs, Location{
+			Url:        loc.Url,
+			PublicUrl:  loc.PublicUrl,
+			DataCenter: loc.DataCenter,
+		})
+	}
+	return
+}
+
+// IsRetryableReadError returns false for the 4xx responses, which the other replicas would also return
+func IsRetryableReadError(err error) bool {
+	if statusErr, ok := err.(*util.HttpStatusError); ok {
+		return statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// ReadFileId reads the file id from the volume servers having the volume
+func (vc *vidMap) ReadFileId(fileId string, dataCenter string, offset int64, size int, buf []byte, isReadRange bool) (n int64, err error) {
+	locations, err := vc.LookupFileIdLocations(fileId)
+	if err != nil {
+		return 0, err
+	}
+	return ReadFromLocations(locations, dataCenter, fileId, offset, size, buf, isReadRange)
+}
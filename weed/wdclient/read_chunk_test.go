@@ -0,0 +1,77 @@
+package wdclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSortLocations(t *testing.T) {
+
+	locations := []Location{
+		{Url: "a", DataCenter: "dc1"},
+		{Url: "b", DataCenter: "dc2"},
+		{Url: "c", DataCenter: "dc1"},
+		{Url: "d", DataCenter: "dc2"},
+	}
+
+	var urls []string
+	for _, loc := range SortLocations(locations, "dc2") {
+		urls = append(urls, loc.Url)
+	}
+	if strings.Join(urls, "") != "bdac" {
+		t.Errorf("unexpected order %v", urls)
+	}
+	if locations[0].Url != "a" {
+		t.Errorf("the locations should not be changed")
+	}
+
+}
+
+func TestReadFromLocations(t *testing.T) {
+
+	var goodRequests, missingRequests int
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		goodRequests++
+		w.Write([]byte("hello"))
+	}))
+	defer good.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+	missing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		missingRequests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer missing.Close()
+	stopped := httptest.NewServer(http.NotFoundHandler())
+	stopped.Close()
+
+	toLocation := func(server *httptest.Server, dataCenter string) Location {
+		return Location{Url: strings.TrimPrefix(server.URL, "http://"), DataCenter: dataCenter}
+	}
+
+	buf := make([]byte, 5)
+	n, err := ReadFromLocations([]Location{toLocation(stopped, ""), toLocation(broken, ""), toLocation(good, "")}, "", "3,01637037d6", 0, 5, buf, false)
+	if err != nil || string(buf[:n]) != "hello" {
+		t.Errorf("read from the replica: %s %v", buf[:n], err)
+	}
+
+	// the replica in the same data center is read first
+	goodRequests = 0
+	if _, err = ReadFromLocations([]Location{toLocation(missing, "dc1"), toLocation(good, "dc2")}, "dc2", "3,01637037d6", 0, 5, buf, false); err != nil || goodRequests != 1 || missingRequests != 0 {
+		t.Errorf("read from the same data center: %v, %d %d requests", err, goodRequests, missingRequests)
+	}
+
+	// 404 is not retried
+	if _, err = ReadFromLocations([]Location{toLocation(missing, ""), toLocation(good, "")}, "", "3,01637037d6", 0, 5, buf, false); err == nil || IsRetryableReadError(err) {
+		t.Errorf("read missing file: %v", err)
+	}
+
+	if _, err = ReadFromLocations([]Location{toLocation(stopped, ""), toLocation(broken, "")}, "", "3,01637037d6", 0, 5, buf, false); err == nil {
+		t.Errorf("all replicas are down")
+	}
+
+}
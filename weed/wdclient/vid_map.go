@@ -13,20 +13,23 @@ import (
 )
 
 type Location struct {
-	Url       string `json:"url,omitempty"`
-	PublicUrl string `json:"publicUrl,omitempty"`
+	Url        string `json:"url,omitempty"`
+	PublicUrl  string `json:"publicUrl,omitempty"`
+	DataCenter string `json:"dataCenter,omitempty"`
 }
 
 type vidMap struct {
 	sync.RWMutex
-	vid2Locations map[uint32][]Location
-	r             *rand.Rand
+	vid2Locations   map[uint32][]Location
+	ecVid2Locations map[uint32][]Location
+	r               *rand.Rand
 }
 
 func newVidMap() vidMap {
 	return vidMap{
-		vid2Locations: make(map[uint32][]Location),
-		r:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		vid2Locations:   make(map[uint32][]Location),
+		ecVid2Locations: make(map[uint32][]Location),
+		r:               rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
@@ -69,6 +72,19 @@ func (vc *vidMap) LookupVolumeServer(fileId string) (volumeServer string, err er
 	return serverUrl, nil
 }
 
+// LookupFileIdLocations returns all the locations of the volume of the file id
+func (vc *vidMap) LookupFileIdLocations(fileId string) (locations []Location, err error) {
+	parts := strings.Split(fileId, ",")
+	if len(parts) != 2 {
+		return nil, errors.New("Invalid fileId " + fileId)
+	}
+	locations = vc.GetVidLocations(parts[0])
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("volume %s not found", parts[0])
+	}
+	return locations, nil
+}
+
 func (vc *vidMap) GetVidLocations(vid string) (locations []Location) {
 	id, err := strconv.Atoi(vid)
 	if err != nil {
@@ -82,7 +98,13 @@ func (vc *vidMap) GetLocations(vid uint32) (locations []Location) {
 	vc.RLock()
 	defer vc.RUnlock()
 
-	return vc.vid2Locations[vid]
+	locations, found := vc.vid2Locations[vid]
+	if found && len(locations) > 0 {
+		return locations
+	}
+
+	// the volume may have been converted into ec shards
+	return vc.ecVid2Locations[vid]
 }
 
 func (vc *vidMap) addLocation(vid uint32, location Location) {
@@ -121,3 +143,41 @@ func (vc *vidMap) deleteLocation(vid uint32, location Location) {
 	}
 
 }
+
+func (vc *vidMap) addEcLocation(vid uint32, location Location) {
+	vc.Lock()
+	defer vc.Unlock()
+
+	locations, found := vc.ecVid2Locations[vid]
+	if !found {
+		vc.ecVid2Locations[vid] = []Location{location}
+		return
+	}
+
+	for _, loc := range locations {
+		if loc.Url == location.Url {
+			return
+		}
+	}
+
+	vc.ecVid2Locations[vid] = append(locations, location)
+
+}
+
+func (vc *vidMap) deleteEcLocation(vid uint32, location Location) {
+	vc.Lock()
+	defer vc.Unlock()
+
+	locations, found := vc.ecVid2Locations[vid]
+	if !found {
+		return
+	}
+
+	for i, loc := range locations {
+		if loc.Url == location.Url {
+			vc.ecVid2Locations[vid] = append(locations[0:i], locations[i+1:]...)
+			break
+		}
+	}
+
+}